@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/blobstore"
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// backupPrefix is the blob store key prefix database backups are stored under
+const backupPrefix = "backups/"
+
+// newBlobStore creates the configured blob store, or returns nil when none is configured
+func newBlobStore(cfg *config.Config, log logrus.FieldLogger) (blobstore.Store, error) {
+	if cfg.Blobstore.Backend == "" {
+		return nil, nil
+	}
+
+	return blobstore.New(blobstore.Options{
+		Backend:   cfg.Blobstore.Backend,
+		Prefix:    cfg.Blobstore.Prefix,
+		Dir:       cfg.Blobstore.Dir,
+		Bucket:    cfg.Blobstore.Bucket,
+		Endpoint:  cfg.Blobstore.Endpoint,
+		Region:    cfg.Blobstore.Region,
+		AccessKey: cfg.Blobstore.AccessKey,
+		SecretKey: cfg.Blobstore.SecretKey,
+		Insecure:  cfg.Blobstore.Insecure,
+	}, log)
+}
+
+// runBackup handles the backup command, uploading a snapshot of the database to the blob store
+// and deleting all but the newest backup.keep backups
+func runBackup(ctx context.Context, cfg *config.Config, args []string, log *logrus.Logger) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	blobs, err := newBlobStore(cfg, log)
+	if err != nil {
+		return err
+	}
+	if blobs == nil {
+		return fmt.Errorf("no blob store is configured, set blobstore.backend")
+	}
+
+	store := storage.NewStorage(cfg.Database.Path, 0, cfg.Positions.DustValue, log)
+	if err := store.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop storage")
+		}
+	}()
+
+	dir, err := os.MkdirTemp("", "pyre-backup-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := filepath.Join(dir, "pyre.db")
+	if err := store.Backup(ctx, snapshot); err != nil {
+		return err
+	}
+
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat snapshot: %w", err)
+	}
+
+	key := backupPrefix + "pyre-" + time.Now().UTC().Format("20060102T150405Z") + ".db"
+	if err := blobs.Put(ctx, key, f, info.Size(), "application/vnd.sqlite3"); err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"location": blobs.Location(key),
+		"size":     info.Size(),
+	}).Info("database backed up")
+
+	return pruneBackups(ctx, blobs, cfg.Backup.Keep, log)
+}
+
+// pruneBackups deletes all but the newest keep backups. Keys embed the backup time, so they
+// sort oldest first.
+func pruneBackups(ctx context.Context, blobs blobstore.Store, keep int, log *logrus.Logger) error {
+	if keep == 0 {
+		return nil
+	}
+
+	objects, err := blobs.List(ctx, backupPrefix)
+	if err != nil {
+		return err
+	}
+
+	backups := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.Key, ".db") {
+			backups = append(backups, obj.Key)
+		}
+	}
+	sort.Strings(backups)
+
+	for len(backups) > keep {
+		if err := blobs.Delete(ctx, backups[0]); err != nil {
+			return err
+		}
+		log.WithField("key", backups[0]).Info("deleted old backup")
+		backups = backups[1:]
+	}
+
+	return nil
+}
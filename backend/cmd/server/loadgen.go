@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samcm/pyre/internal/loadgen"
+	"github.com/samcm/pyre/internal/seed"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const loadgenUsage = `usage: pyre loadgen <command> [flags]
+
+commands:
+  generate   write synthetic users, trades, positions and PnL snapshots to a new database
+  replay     send the frontend's traffic mix to a running instance and report latencies`
+
+// runLoadgen handles the loadgen command, which needs no config: generate writes a database
+// of its own, and replay targets an instance by URL
+func runLoadgen(ctx context.Context, args []string, log *logrus.Logger) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, loadgenUsage)
+		return fmt.Errorf("missing loadgen command")
+	}
+
+	switch args[0] {
+	case "generate":
+		return runLoadgenGenerate(ctx, args[1:], log)
+	case "replay":
+		return runLoadgenReplay(ctx, args[1:])
+	}
+
+	fmt.Fprintln(os.Stderr, loadgenUsage)
+	return fmt.Errorf("unknown loadgen command: %s", args[0])
+}
+
+// runLoadgenGenerate writes a synthetic database, to a temporary directory unless --db is given
+func runLoadgenGenerate(ctx context.Context, args []string, log *logrus.Logger) error {
+	flags := flag.NewFlagSet("loadgen generate", flag.ContinueOnError)
+	dbPath := flags.String("db", "", "database to create, in a new temporary directory by default")
+	opts := loadgen.GenerateOptions{}
+	flags.IntVar(&opts.Users, "users", 100, "users to generate")
+	flags.IntVar(&opts.Personas, "personas", 10, "personas, each pairing two of the users")
+	flags.IntVar(&opts.Markets, "markets", 200, "markets users trade in")
+	flags.IntVar(&opts.MarketsPerUser, "markets-per-user", 20, "markets each user trades, a few trades each")
+	flags.IntVar(&opts.Days, "days", 180, "days of history, each user getting a PnL snapshot a day")
+	flags.Float64Var(&opts.Stake, "stake", 500, "average USDC a user puts into a market")
+	flags.Uint64Var(&opts.Seed, "seed", 1, "random seed")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fixture, err := loadgen.Fixture(opts)
+	if err != nil {
+		return err
+	}
+
+	if *dbPath == "" {
+		dir, err := os.MkdirTemp("", "pyre-loadgen-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		*dbPath = filepath.Join(dir, "pyre.db")
+	} else if _, err := os.Stat(*dbPath); err == nil {
+		return fmt.Errorf("database %s already exists, generate into a new one", *dbPath)
+	}
+
+	// Per user logging of the seed drowns out the summary at these volumes
+	seedLog := logrus.New()
+	seedLog.SetLevel(min(log.GetLevel(), logrus.WarnLevel))
+	seedLog.SetFormatter(log.Formatter)
+
+	store := storage.NewStorage(*dbPath, 0, 0.1, seedLog)
+	if err := store.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop storage")
+		}
+	}()
+
+	started := time.Now()
+	summary, err := seed.Apply(ctx, store, fixture, seed.Options{}, seedLog)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"db":        *dbPath,
+		"users":     summary.Users,
+		"trades":    summary.Trades,
+		"positions": summary.Positions,
+		"snapshots": summary.Snapshots,
+		"took":      time.Since(started).Round(time.Millisecond),
+	}).Info("generated database, serve it with database.path and sync disabled to replay against it")
+
+	return nil
+}
+
+// runLoadgenReplay replays traffic against an instance and prints latencies by request
+func runLoadgenReplay(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("loadgen replay", flag.ContinueOnError)
+	opts := loadgen.ReplayOptions{}
+	flags.StringVar(&opts.Target, "target", "http://localhost:8080", "base URL of the instance")
+	flags.StringVar(&opts.APIKey, "api-key", "", "API key to send, for instances with server.requireKey")
+	flags.IntVar(&opts.Concurrency, "concurrency", 10, "requests in flight at once")
+	flags.DurationVar(&opts.Duration, "duration", 30*time.Second, "how long to send traffic")
+	flags.Float64Var(&opts.Rate, "rate", 0, "requests per second, as fast as responses come when 0")
+	flags.Uint64Var(&opts.Seed, "seed", 1, "random seed")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "replaying traffic against %s for %s with %d workers\n", opts.Target, opts.Duration, opts.Concurrency)
+	report, err := loadgen.Replay(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REQUEST\tCOUNT\tERRORS\tP50\tP95\tP99\tMAX")
+	for _, s := range report.Patterns {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", s.Name, s.Requests, s.Errors,
+			roundLatency(s.Percentile(0.5)), roundLatency(s.Percentile(0.95)), roundLatency(s.Percentile(0.99)), roundLatency(s.Max()))
+	}
+	w.Flush()
+
+	requests := report.Requests()
+	fmt.Printf("\n%d requests to %d users and %d personas in %s, %.1f/s, %d errors\n",
+		requests, report.Users, report.Personas, report.Elapsed.Round(time.Millisecond),
+		float64(requests)/report.Elapsed.Seconds(), report.Errors())
+
+	if requests > 0 && report.Errors() == requests {
+		return fmt.Errorf("every request failed")
+	}
+	return nil
+}
+
+// roundLatency rounds a latency for display
+func roundLatency(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
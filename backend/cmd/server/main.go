@@ -2,19 +2,44 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	backend "github.com/samcm/pyre"
 	"github.com/samcm/pyre/internal/api"
+	"github.com/samcm/pyre/internal/avatars"
 	"github.com/samcm/pyre/internal/backfill"
+	"github.com/samcm/pyre/internal/badges"
+	"github.com/samcm/pyre/internal/benchmark"
+	"github.com/samcm/pyre/internal/chatbot"
+	"github.com/samcm/pyre/internal/claims"
+	"github.com/samcm/pyre/internal/clickhouse"
+	"github.com/samcm/pyre/internal/concentration"
 	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/discord"
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/fetch"
+	"github.com/samcm/pyre/internal/lookup"
+	"github.com/samcm/pyre/internal/milestones"
+	"github.com/samcm/pyre/internal/notify"
 	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/presence"
+	"github.com/samcm/pyre/internal/recompute"
+	"github.com/samcm/pyre/internal/replication"
+	"github.com/samcm/pyre/internal/retention"
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/scoring"
 	"github.com/samcm/pyre/internal/server"
 	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/stream"
+	"github.com/samcm/pyre/internal/telegram"
+	"github.com/samcm/pyre/internal/tradeimport"
 	"github.com/sirupsen/logrus"
 )
 
@@ -30,6 +55,14 @@ func main() {
 	log := setupLogger(*logLevel)
 	log.Info("starting pyre")
 
+	// Load testing runs without a config
+	if flag.Arg(0) == "loadgen" {
+		if err := runLoadgen(context.Background(), flag.Args()[1:], log); err != nil {
+			log.WithError(err).Fatal("loadgen failed")
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load(*configPath)
 	if err != nil {
@@ -41,9 +74,61 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	switch flag.Arg(0) {
+	case "migrate":
+		if err := runMigrate(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.WithError(err).Fatal("migrate failed")
+		}
+		return
+	case "seed":
+		if err := runSeed(ctx, cfg, flag.Args()[1:], log); err != nil {
+			log.WithError(err).Fatal("seed failed")
+		}
+		return
+	case "verify-trades":
+		if err := runVerifyTrades(ctx, cfg, flag.Args()[1:], log); err != nil {
+			log.WithError(err).Fatal("verify-trades failed")
+		}
+		return
+	case "backup":
+		if err := runBackup(ctx, cfg, flag.Args()[1:], log); err != nil {
+			log.WithError(err).Fatal("backup failed")
+		}
+		return
+	case "rebuild":
+		if err := runRebuild(ctx, cfg, flag.Args()[1:], log); err != nil {
+			log.WithError(err).Fatal("rebuild failed")
+		}
+		return
+	}
+
+	// Initialize replication, restoring the database from the replica before storage opens it
+	var replicator replication.Service
+	if cfg.Replication.Enabled {
+		log.Info("initializing replication")
+		opts := replication.Options{
+			LitestreamPath:   cfg.Replication.LitestreamPath,
+			ReplicaURL:       cfg.Replication.ReplicaURL,
+			SyncInterval:     cfg.Replication.SyncInterval,
+			SnapshotInterval: cfg.Replication.SnapshotInterval,
+			Retention:        cfg.Replication.Retention,
+			RestoreOnStart:   cfg.Replication.RestoreOnStart,
+		}
+		if cfg.Replication.RestoreTimestamp != "" {
+			// Already validated when loading config
+			ts, _ := time.Parse(time.RFC3339, cfg.Replication.RestoreTimestamp)
+			opts.RestoreTimestamp = &ts
+		}
+
+		replicator = replication.NewService(cfg.Database.Path, opts, log)
+		if err := replicator.Restore(ctx); err != nil {
+			log.WithError(err).Fatal("failed to restore database")
+		}
+	}
+
 	// Initialize storage
 	log.Info("initializing storage")
-	store := storage.NewStorage(cfg.Database.Path, log)
+	store := storage.NewStorage(cfg.Database.Path, cfg.Database.ReadConnections, cfg.Positions.DustValue, log)
 	if err := store.Start(ctx); err != nil {
 		log.WithError(err).Fatal("failed to start storage")
 	}
@@ -53,42 +138,387 @@ func main() {
 		}
 	}()
 
+	if replicator != nil {
+		if err := replicator.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start replication")
+		}
+		defer func() {
+			if err := replicator.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop replication")
+			}
+		}()
+	}
+
+	// Pages and images from the web are fetched through one queue, so subsystems share its
+	// per-host rate limits
+	fetchHosts := make(map[string]float64, len(cfg.Fetch.Hosts))
+	for _, host := range cfg.Fetch.Hosts {
+		fetchHosts[strings.ToLower(host.Host)] = host.RequestsPerSecond
+	}
+	fetchQueue := fetch.NewQueue(fetch.Options{
+		RequestsPerSecond: cfg.Fetch.RequestsPerSecond,
+		Hosts:             fetchHosts,
+		ReuseFor:          cfg.Fetch.ReuseFor,
+		Timeout:           cfg.Fetch.Timeout,
+	}, log)
+
 	// Initialize Polymarket client
 	log.Info("initializing polymarket client")
-	pmClient := polymarket.NewClient(log)
+	pmClient := polymarket.NewClient(cfg.Sync.CallBudget, polymarket.BrowserOptions{
+		Enabled:       cfg.Sync.Browser.Enabled,
+		ExecPath:      cfg.Sync.Browser.ExecPath,
+		Timeout:       cfg.Sync.Browser.Timeout,
+		MaxConcurrent: cfg.Sync.Browser.MaxConcurrent,
+		MaxHeapMB:     cfg.Sync.Browser.MaxHeapMB,
+		NoSandbox:     cfg.Sync.Browser.NoSandbox,
+	}, fetchQueue, log)
 
 	// Ensure personas exist in database
 	log.Info("ensuring personas exist")
-	if err := ensurePersonas(ctx, store, cfg, log); err != nil {
+	rosterService := roster.NewService(store, log)
+	if err := ensurePersonas(ctx, store, rosterService, cfg, log); err != nil {
 		log.WithError(err).Fatal("failed to ensure personas")
 	}
 
-	// Initialize sync service with all users (from both legacy and personas)
-	log.Info("initializing sync service")
-	syncService := polymarket.NewService(pmClient, store, cfg.GetAllUsers(), cfg.Sync.IntervalMinutes, log)
-	if err := syncService.Start(ctx); err != nil {
-		log.WithError(err).Fatal("failed to start sync service")
+	// Initialize event bus, published to by the sync service
+	log.WithField("backend", cfg.Events.Backend).Info("initializing event bus")
+	bus, err := events.NewBus(events.Options{
+		Backend:    cfg.Events.Backend,
+		URL:        cfg.Events.URL,
+		Subject:    cfg.Events.Subject,
+		BufferSize: cfg.Events.BufferSize,
+	}, log)
+	if err != nil {
+		log.WithError(err).Fatal("failed to initialize event bus")
+	}
+	defer func() {
+		if err := bus.Close(); err != nil {
+			log.WithError(err).Error("failed to close event bus")
+		}
+	}()
+	bus.Subscribe("log", events.LogHandler(log))
+
+	// Initialize ClickHouse sink, mirroring trades and PnL snapshots from the bus for analytics
+	if cfg.ClickHouse.Enabled {
+		log.Info("initializing clickhouse sink")
+		sink := clickhouse.NewSink(clickhouse.Options{
+			URL:           cfg.ClickHouse.URL,
+			Database:      cfg.ClickHouse.Database,
+			Username:      cfg.ClickHouse.Username,
+			Password:      cfg.ClickHouse.Password,
+			BatchSize:     cfg.ClickHouse.BatchSize,
+			FlushInterval: cfg.ClickHouse.FlushInterval,
+			CreateTables:  cfg.ClickHouse.CreateTables,
+		}, store, bus, log)
+		if err := sink.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start clickhouse sink")
+		}
+		defer func() {
+			if err := sink.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop clickhouse sink")
+			}
+		}()
+	}
+
+	// Initialize badge engine, evaluated after each user sync
+	log.Info("initializing badge service")
+	badgeService := badges.NewService(store, bus, log)
+	if err := badgeService.Start(ctx); err != nil {
+		log.WithError(err).Fatal("failed to start badge service")
+	}
+	defer func() {
+		if err := badgeService.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop badge service")
+		}
+	}()
+
+	// Initialize milestone detection, evaluated after each user sync
+	log.Info("initializing milestone service")
+	milestoneService := milestones.NewService(store, bus, log)
+	if err := milestoneService.Start(ctx); err != nil {
+		log.WithError(err).Fatal("failed to start milestone service")
+	}
+	defer func() {
+		if err := milestoneService.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop milestone service")
+		}
+	}()
+
+	// Initialize presence tracking, announcing users who start trading after a quiet spell
+	log.Info("initializing presence service")
+	presenceService := presence.NewService(store, bus, cfg.Presence.ActiveMinutes, log)
+	if err := presenceService.Start(ctx); err != nil {
+		log.WithError(err).Fatal("failed to start presence service")
 	}
 	defer func() {
-		if err := syncService.Stop(); err != nil {
-			log.WithError(err).Error("failed to stop sync service")
+		if err := presenceService.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop presence service")
 		}
 	}()
 
+	// Initialize concentration checks, flagging markets holding too much of a user's open exposure
+	log.Info("initializing concentration service")
+	concentrationService := concentration.NewService(store, bus, cfg.Concentration.ThresholdPercent, cfg.Concentration.MinExposure, log)
+	if err := concentrationService.Start(ctx); err != nil {
+		log.WithError(err).Fatal("failed to start concentration service")
+	}
+	defer func() {
+		if err := concentrationService.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop concentration service")
+		}
+	}()
+
+	// Initialize notifications, alerting a Discord channel to big trades and positions
+	var notifyService notify.Service
+	if cfg.Notifications.Enabled {
+		log.Info("initializing notification service")
+		opts, err := notificationOptions(cfg.Notifications)
+		if err != nil {
+			log.WithError(err).Fatal("failed to parse notification templates")
+		}
+		notifyService = notify.NewService(store, bus, []notify.Sink{notify.NewDiscordSink(cfg.Notifications.Discord.WebhookURL)}, opts, log)
+		if err := notifyService.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start notification service")
+		}
+		defer func() {
+			if err := notifyService.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop notification service")
+			}
+		}()
+	}
+
+	// Initialize feed stream, fanning bus events out to WebSocket subscribers
+	var streamService stream.Service
+	if cfg.Feed.Stream.Enabled {
+		log.Info("initializing stream service")
+		streamService = stream.NewService(store, bus, stream.Options{
+			WhaleThreshold:      cfg.Feed.Stream.WhaleThreshold,
+			LeaderboardInterval: cfg.Feed.Stream.LeaderboardInterval,
+			BufferSize:          cfg.Feed.Stream.BufferSize,
+		}, log)
+		if err := streamService.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start stream service")
+		}
+		defer func() {
+			if err := streamService.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop stream service")
+			}
+		}()
+	}
+
+	// Initialize sync service with all users (from both legacy and personas)
+	log.Info("initializing sync service")
+	syncService := polymarket.NewService(pmClient, store, cfg.GetAllUsers(), polymarket.Options{
+		Interval:            time.Duration(cfg.Sync.IntervalMinutes) * time.Minute,
+		ErrorHistory:        cfg.Sync.ErrorHistory,
+		RunHistory:          cfg.Sync.RunHistory,
+		ReconcileInterval:   time.Duration(cfg.Sync.ReconcileIntervalHours) * time.Hour,
+		LeaseTTL:            time.Duration(cfg.Sync.LeaseSeconds) * time.Second,
+		BookMaxAge:          time.Duration(cfg.Sync.BookMaxAgeMinutes) * time.Minute,
+		LeaderboardInterval: time.Duration(cfg.Sync.GlobalLeaderboardHours) * time.Hour,
+		LeaderboardSize:     cfg.Sync.GlobalLeaderboardSize,
+		DormantAfter:        time.Duration(cfg.Sync.DormantDays) * 24 * time.Hour,
+		DormantCheckEvery:   cfg.Sync.DormantCheckEvery,
+	}, bus, log)
+	if cfg.Sync.Enabled {
+		if err := syncService.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start sync service")
+		}
+		defer func() {
+			if err := syncService.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop sync service")
+			}
+		}()
+	} else {
+		log.Warn("periodic sync is disabled, serving stored data only")
+	}
+
+	// Initialize snapshot retention, downsampling old PnL snapshots
+	if cfg.Snapshots.RetentionInterval > 0 && len(cfg.Snapshots.Retention) > 0 {
+		log.Info("initializing retention service")
+		tiers := make([]storage.SnapshotTier, len(cfg.Snapshots.Retention))
+		for i, tier := range cfg.Snapshots.Retention {
+			tiers[i] = storage.SnapshotTier{After: tier.After, Every: tier.Every}
+		}
+		retentionService := retention.NewService(store, tiers, cfg.Snapshots.RetentionInterval, log)
+		if err := retentionService.Start(ctx); err != nil {
+			log.WithError(err).Fatal("failed to start retention service")
+		}
+		defer func() {
+			if err := retentionService.Stop(); err != nil {
+				log.WithError(err).Error("failed to stop retention service")
+			}
+		}()
+	}
+
 	// Initialize backfill service
 	log.Info("initializing backfill service")
 	backfillService := backfill.NewService(store, log)
 
+	// Initialize recompute jobs, rebuilding derived stats on request
+	log.Info("initializing recompute service")
+	recomputeService := recompute.NewService(store, backfillService, badgeService, milestoneService, log)
+	if err := recomputeService.Start(ctx); err != nil {
+		log.WithError(err).Fatal("failed to start recompute service")
+	}
+	defer func() {
+		if err := recomputeService.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop recompute service")
+		}
+	}()
+
 	// Initialize API handler
 	log.Info("initializing API handler")
-	handler := api.NewHandler(store, syncService, backfillService, log)
+	feedMute := &storage.MuteRules{
+		Users:      cfg.Feed.Mute.Users,
+		Categories: cfg.Feed.Mute.Categories,
+	}
+	if cfg.Feed.Mute.MinValue > 0 {
+		feedMute.MinValue = &cfg.Feed.Mute.MinValue
+	}
+	scores := make([]*scoring.Score, 0, len(cfg.Leaderboard.Scores))
+	for _, sc := range cfg.Leaderboard.Scores {
+		score, err := scoring.NewScore(sc.Name, sc.Formula)
+		if err != nil {
+			log.WithError(err).Fatal("failed to compile leaderboard score")
+		}
+		scores = append(scores, score)
+	}
+	definitions := make([]benchmark.Definition, 0, len(cfg.Benchmarks))
+	for _, bench := range cfg.Benchmarks {
+		definitions = append(definitions, benchmark.Definition{Name: bench.Name, Users: bench.Users})
+	}
+	benchmarks := benchmark.NewService(store, definitions, log)
+	avatarProxy, err := avatars.NewProxy(avatars.Options{
+		CacheDir:      cfg.Avatars.CacheDir,
+		MemoryEntries: cfg.Avatars.MemoryEntries,
+		RefreshAfter:  cfg.Avatars.RefreshAfter,
+		MaxBytes:      cfg.Avatars.MaxBytes,
+		Timeout:       cfg.Avatars.Timeout,
+	}, fetchQueue, log)
+	if err != nil {
+		log.WithError(err).Fatal("failed to initialize avatar proxy")
+	}
+	var publicAPI *api.PublicAPI
+	if cfg.PublicAPI.Enabled {
+		// A client of its own, so lookups don't spend the sync call budget
+		lookupClient := polymarket.NewClient(0, polymarket.BrowserOptions{}, fetchQueue, log)
+		publicAPI = &api.PublicAPI{
+			Lookup:            lookup.NewService(lookupClient, cfg.PublicAPI.CacheTTL, cfg.PublicAPI.CacheEntries, log),
+			Keys:              cfg.PublicAPI.Keys,
+			RequestsPerMinute: cfg.PublicAPI.RequestsPerMinute,
+		}
+		log.WithField("keys", len(cfg.PublicAPI.Keys)).Info("public address api enabled")
+	}
+	var reactions *api.Reactions
+	if cfg.Feed.Reactions.Enabled {
+		reactions = &api.Reactions{
+			Authors:           make(map[string]string, len(cfg.Feed.Reactions.Authors)),
+			MaxCommentLength:  cfg.Feed.Reactions.MaxCommentLength,
+			RequestsPerMinute: cfg.Feed.Reactions.RequestsPerMinute,
+		}
+		for _, author := range cfg.Feed.Reactions.Authors {
+			reactions.Authors[author.Key] = author.Name
+		}
+		log.WithField("authors", len(cfg.Feed.Reactions.Authors)).Info("feed reactions enabled")
+	}
+	// A client of its own for profile checks, like the public API's lookups
+	claimsService := claims.NewService(store, polymarket.NewClient(0, polymarket.BrowserOptions{}, fetchQueue, log), log)
+	columns := cfg.TradeImport.Columns
+	tradeImport := &api.TradeImport{
+		Columns: tradeimport.Columns{
+			ID:          columns.ID,
+			Timestamp:   columns.Timestamp,
+			Address:     columns.Address,
+			ConditionID: columns.ConditionID,
+			MarketTitle: columns.MarketTitle,
+			MarketSlug:  columns.MarketSlug,
+			Outcome:     columns.Outcome,
+			Side:        columns.Side,
+			Price:       columns.Price,
+			Size:        columns.Size,
+			Value:       columns.Value,
+		},
+		MaxRows: cfg.TradeImport.MaxRows,
+	}
+	blobs, err := newBlobStore(cfg, log)
+	if err != nil {
+		log.WithError(err).Fatal("failed to initialize blob store")
+	}
+	if blobs != nil {
+		log.WithField("backend", cfg.Blobstore.Backend).Info("blob store enabled")
+	}
+	handler := api.NewHandler(store, syncService, backfillService, rosterService, feedMute, scores, avatarProxy, publicAPI, reactions, claimsService, streamService, notifyService, tradeImport, blobs, benchmarks, presenceService, concentrationService, recomputeService, cfg, log)
 
 	// Get frontend embed
 	frontendFS := backend.FrontendFiles
 
 	// Initialize HTTP server
 	log.Info("initializing HTTP server")
-	httpServer := server.NewServer(cfg.Server.Host, cfg.Server.Port, handler, frontendFS, log)
+	routeTimeouts := make(map[string]time.Duration, len(cfg.Server.RouteTimeouts))
+	for _, route := range cfg.Server.RouteTimeouts {
+		routeTimeouts[route.Prefix] = route.Timeout
+	}
+	limits := server.Limits{
+		MaxBodyBytes:         cfg.Server.MaxBodyBytes,
+		RequestTimeout:       cfg.Server.RequestTimeout,
+		RouteTimeouts:        routeTimeouts,
+		SlowRequestThreshold: cfg.Server.SlowRequestThreshold,
+	}
+	accessLog := server.AccessLog{
+		Enabled:      cfg.Server.AccessLog.Enabled,
+		RedactParams: cfg.Server.AccessLog.RedactParams,
+		Routes:       make([]server.AccessLogRoute, 0, len(cfg.Server.AccessLog.Routes)),
+	}
+	for _, route := range cfg.Server.AccessLog.Routes {
+		logRoute := server.AccessLogRoute{Prefix: route.Prefix, Enabled: true, SampleRate: 1}
+		if route.Enabled != nil {
+			logRoute.Enabled = *route.Enabled
+		}
+		if route.SampleRate != nil {
+			logRoute.SampleRate = *route.SampleRate
+		}
+		accessLog.Routes = append(accessLog.Routes, logRoute)
+	}
+	// Initialize chat bots, answering the same commands from storage
+	var discordBot discord.Bot
+	if cfg.Discord.Enabled || cfg.Telegram.Enabled {
+		commands := chatbot.NewService(store, log)
+
+		if cfg.Discord.Enabled {
+			discordBot, err = discord.NewBot(discord.Options{
+				ApplicationID: cfg.Discord.ApplicationID,
+				PublicKey:     cfg.Discord.PublicKey,
+				BotToken:      cfg.Discord.BotToken,
+				GuildID:       cfg.Discord.GuildID,
+			}, commands, log)
+			if err != nil {
+				log.WithError(err).Fatal("failed to initialize discord bot")
+			}
+			if err := discordBot.Start(ctx); err != nil {
+				log.WithError(err).Fatal("failed to start discord bot")
+			}
+			defer func() {
+				if err := discordBot.Stop(); err != nil {
+					log.WithError(err).Error("failed to stop discord bot")
+				}
+			}()
+		}
+
+		if cfg.Telegram.Enabled {
+			telegramBot := telegram.NewBot(telegram.Options{BotToken: cfg.Telegram.BotToken}, commands, log)
+			if err := telegramBot.Start(ctx); err != nil {
+				log.WithError(err).Fatal("failed to start telegram bot")
+			}
+			defer func() {
+				if err := telegramBot.Stop(); err != nil {
+					log.WithError(err).Error("failed to stop telegram bot")
+				}
+			}()
+		}
+	}
+
+	httpServer := server.NewServer(cfg.Server.Host, cfg.Server.Port, limits, accessLog, handler, discordBot, frontendFS, log)
 	if err := httpServer.Start(ctx); err != nil {
 		log.WithError(err).Fatal("failed to start HTTP server")
 	}
@@ -130,8 +560,19 @@ func setupLogger(level string) *logrus.Logger {
 	return log
 }
 
-// ensurePersonas creates personas and their users in the database from config
-func ensurePersonas(ctx context.Context, store storage.Storage, cfg *config.Config, log *logrus.Logger) error {
+// ensurePersonas creates personas and their users in the database from config, logging the
+// changes made in the roster change log
+func ensurePersonas(ctx context.Context, store storage.Storage, rosterService roster.Service, cfg *config.Config, log *logrus.Logger) error {
+	changes := make([]roster.Change, 0)
+	record := func(action, target, detail string) {
+		changes = append(changes, roster.Change{Action: action, Target: target, Detail: detail})
+	}
+	defer func() {
+		if err := rosterService.Record(ctx, roster.SourceConfig, changes); err != nil {
+			log.WithError(err).Error("failed to record roster changes")
+		}
+	}()
+
 	// Process personas from config
 	for slug, personaCfg := range cfg.Personas {
 		log.WithFields(logrus.Fields{
@@ -142,8 +583,8 @@ func ensurePersonas(ctx context.Context, store storage.Storage, cfg *config.Conf
 
 		// Check if persona exists, create if not
 		persona, err := store.GetPersona(ctx, slug)
-		if err != nil {
-			// Persona doesn't exist, create it
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
 			if personaCfg.Image != "" {
 				persona, err = store.CreatePersonaWithImage(ctx, slug, personaCfg.DisplayName, personaCfg.Image)
 			} else {
@@ -152,11 +593,16 @@ func ensurePersonas(ctx context.Context, store storage.Storage, cfg *config.Conf
 			if err != nil {
 				return fmt.Errorf("failed to create persona %s: %w", slug, err)
 			}
+			record(roster.ActionCreatePersona, slug, personaCfg.DisplayName)
 			log.WithField("slug", slug).Info("created persona")
-		} else if personaCfg.Image != "" {
+		case err != nil:
+			return fmt.Errorf("failed to get persona %s: %w", slug, err)
+		case personaCfg.Image != "":
 			// Update persona image if it changed
 			if err := store.UpdatePersonaImage(ctx, persona.ID, personaCfg.Image); err != nil {
 				log.WithError(err).WithField("slug", slug).Warn("failed to update persona image")
+			} else if persona.Image == nil || *persona.Image != personaCfg.Image {
+				record(roster.ActionUpdatePersona, slug, "image: "+personaCfg.Image)
 			}
 		}
 
@@ -170,21 +616,33 @@ func ensurePersonas(ctx context.Context, store storage.Storage, cfg *config.Conf
 
 			// Check if user exists
 			user, err := store.GetUser(ctx, username)
-			if err != nil {
-				// User doesn't exist, create with persona
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
 				_, err = store.CreateUserWithPersona(ctx, username, addresses, persona.ID)
 				if err != nil {
 					return fmt.Errorf("failed to create user %s for persona %s: %w", username, slug, err)
 				}
+				record(roster.ActionCreateUser, username, "persona "+slug)
 				log.WithFields(logrus.Fields{
 					"username": username,
 					"persona":  slug,
 				}).Info("created user with persona")
-			} else {
+			case err != nil:
+				return fmt.Errorf("failed to get user %s: %w", username, err)
+			default:
 				// User exists, update persona association
+				current, err := store.GetUserPersonaInfo(ctx, user.ID)
+				if err != nil {
+					return fmt.Errorf("failed to get persona of user %s: %w", username, err)
+				}
 				if err := store.UpdateUserPersona(ctx, user.ID, persona.ID); err != nil {
 					return fmt.Errorf("failed to update persona for user %s: %w", username, err)
 				}
+				if current == nil {
+					record(roster.ActionMoveUser, username, "no persona -> persona "+slug)
+				} else if current.Slug != slug {
+					record(roster.ActionMoveUser, username, fmt.Sprintf("persona %s -> persona %s", current.Slug, slug))
+				}
 				log.WithFields(logrus.Fields{
 					"username": username,
 					"persona":  slug,
@@ -202,15 +660,90 @@ func ensurePersonas(ctx context.Context, store storage.Storage, cfg *config.Conf
 
 		// Check if user exists
 		_, err := store.GetUser(ctx, username)
-		if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
 			// User doesn't exist, create without persona
 			_, err = store.CreateUser(ctx, username, addresses)
 			if err != nil {
 				return fmt.Errorf("failed to create legacy user %s: %w", username, err)
 			}
+			record(roster.ActionCreateUser, username, "no persona")
 			log.WithField("username", username).Info("created legacy user")
+		} else if err != nil {
+			return fmt.Errorf("failed to get legacy user %s: %w", username, err)
 		}
 	}
 
+	// Apply configured address groups
+	for username, groups := range cfg.AddressGroups {
+		user, err := store.GetUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s for address groups: %w", username, err)
+		}
+		stored, err := store.GetUserAddresses(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get addresses of user %s: %w", username, err)
+		}
+		current := make(map[string]string, len(stored))
+		for _, addr := range stored {
+			if addr.Group != nil {
+				current[addr.Address] = *addr.Group
+			}
+		}
+		for group, addresses := range groups {
+			for _, address := range addresses {
+				if err := store.SetAddressGroup(ctx, user.ID, address, &group); err != nil {
+					return fmt.Errorf("failed to set address group %s for user %s: %w", group, username, err)
+				}
+				if current[address] != group {
+					record(roster.ActionSetGroup, username, address+" -> group "+group)
+				}
+			}
+		}
+	}
+
+	// Apply ghost mode for configured users
+	for _, username := range cfg.Ghosts {
+		user, err := store.GetUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("failed to get ghost user %s: %w", username, err)
+		}
+		if err := store.UpdateUserGhost(ctx, user.ID, true); err != nil {
+			return fmt.Errorf("failed to enable ghost mode for user %s: %w", username, err)
+		}
+		if !user.Ghost {
+			record(roster.ActionSetGhost, username, "true")
+		}
+		log.WithField("username", username).Debug("enabled ghost mode")
+	}
+
 	return nil
 }
+
+// notificationOptions converts the notifications config into notify service options
+func notificationOptions(cfg config.NotificationsConfig) (notify.Options, error) {
+	discord, err := notify.ParseTemplates(cfg.Discord.Templates)
+	if err != nil {
+		return notify.Options{}, err
+	}
+
+	opts := notify.Options{
+		Trades:          cfg.Trades,
+		PositionsOpened: cfg.PositionsOpened,
+		PositionsClosed: cfg.PositionsClosed,
+		MaxTradeAge:     cfg.MaxTradeAge,
+		Default: notify.Thresholds{
+			MinTradeValue:    &cfg.MinTradeValue,
+			MinPositionValue: &cfg.MinPositionValue,
+		},
+		Personas:  make(map[string]notify.Thresholds, len(cfg.Personas)),
+		Markets:   make(map[string]notify.Thresholds, len(cfg.Markets)),
+		Templates: map[string]notify.Templates{"discord": discord},
+	}
+	for slug, t := range cfg.Personas {
+		opts.Personas[slug] = notify.Thresholds{MinTradeValue: t.MinTradeValue, MinPositionValue: t.MinPositionValue}
+	}
+	for key, t := range cfg.Markets {
+		opts.Markets[key] = notify.Thresholds{MinTradeValue: t.MinTradeValue, MinPositionValue: t.MinPositionValue}
+	}
+	return opts, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+const migrateUsage = `usage: pyre [--config path] migrate <command>
+
+commands:
+  status     list migrations and whether they are applied
+  up         apply all pending migrations
+  down [n]   revert the last n applied migrations (default 1)`
+
+// runMigrate handles the migrate command, run against the configured database instead of
+// starting the server
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return fmt.Errorf("missing migrate command")
+	}
+
+	m, err := storage.OpenMigrator(ctx, cfg.Database.Path)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "status":
+		return printMigrationStatus(ctx, m)
+
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mig := range applied {
+			fmt.Printf("applied %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to revert: %s", args[1])
+			}
+		}
+
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			fmt.Printf("reverted %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err == nil && len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+		return err
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return fmt.Errorf("unknown migrate command: %s", args[0])
+	}
+}
+
+// printMigrationStatus writes a table of migrations and their state
+func printMigrationStatus(ctx context.Context, m *storage.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, st := range statuses {
+		state, appliedAt := "pending", ""
+		if st.AppliedAt != nil {
+			state, appliedAt = "applied", st.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		if st.Modified {
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+	}
+
+	return w.Flush()
+}
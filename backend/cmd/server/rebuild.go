@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/samcm/pyre/internal/backfill"
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/fetch"
+	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// runRebuild handles the rebuild command, reconstructing what is derived from trades for
+// recovery from a backup holding only users, addresses and trades: trade classifications,
+// positions and results as of the last trades, and the PnL history. With --backfill each
+// address's trade history is fetched from Polymarket first, so it can start from users alone.
+func runRebuild(ctx context.Context, cfg *config.Config, args []string, log *logrus.Logger) error {
+	flags := flag.NewFlagSet("rebuild", flag.ContinueOnError)
+	username := flags.String("user", "", "only rebuild this user")
+	fetchTrades := flags.Bool("backfill", false, "first fetch each address's full trade history from Polymarket")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	store := storage.NewStorage(cfg.Database.Path, 0, cfg.Positions.DustValue, log)
+	if err := store.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop storage")
+		}
+	}()
+
+	// Users and addresses in the config are created like at startup, the rest must be stored
+	if err := ensurePersonas(ctx, store, roster.NewService(store, log), cfg, log); err != nil {
+		return fmt.Errorf("failed to ensure personas: %w", err)
+	}
+
+	var users []*storage.User
+	if *username != "" {
+		user, err := store.GetUser(ctx, *username)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", *username, err)
+		}
+		users = []*storage.User{user}
+	} else {
+		all, err := store.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		users = all
+	}
+
+	var client polymarket.Client
+	if *fetchTrades {
+		pages := fetch.NewQueue(fetch.Options{
+			RequestsPerSecond: cfg.Fetch.RequestsPerSecond,
+			ReuseFor:          cfg.Fetch.ReuseFor,
+			Timeout:           cfg.Fetch.Timeout,
+		}, log)
+		client = polymarket.NewClient(0, polymarket.BrowserOptions{}, pages, log)
+	}
+
+	backfiller := backfill.NewService(store, log)
+
+	var inserted, positions, snapshots int
+	for _, user := range users {
+		if client != nil {
+			n, err := backfillTrades(ctx, store, client, user)
+			if err != nil {
+				return err
+			}
+			inserted += n
+		}
+
+		if _, err := store.ClassifyTrades(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to classify trades of %s: %w", user.Username, err)
+		}
+
+		rebuilt, err := store.RebuildUserPositions(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild positions of %s: %w", user.Username, err)
+		}
+		positions += rebuilt
+
+		// Synced snapshots are kept, the reconstructed history only fills in before them
+		result, err := backfiller.BackfillUser(ctx, user.Username, false)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild pnl history of %s: %w", user.Username, err)
+		}
+		snapshots += result.SnapshotsCreated
+
+		log.WithFields(logrus.Fields{
+			"username":  user.Username,
+			"positions": rebuilt,
+			"snapshots": result.SnapshotsCreated,
+		}).Info("rebuilt user")
+	}
+
+	log.WithFields(logrus.Fields{
+		"users":     len(users),
+		"trades":    inserted,
+		"positions": positions,
+		"snapshots": snapshots,
+	}).Info("rebuild completed")
+
+	return nil
+}
+
+// backfillTrades fetches the full trade history of a user's addresses and stores the trades
+// missing, returning how many were stored
+func backfillTrades(ctx context.Context, store storage.Storage, client polymarket.Client, user *storage.User) (int, error) {
+	addresses, err := store.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get addresses of %s: %w", user.Username, err)
+	}
+
+	var inserted int
+	for _, addr := range addresses {
+		trades, err := client.GetAllTrades(ctx, addr.Address)
+		if err != nil {
+			return inserted, fmt.Errorf("failed to fetch trades of %s: %w", user.Username, err)
+		}
+
+		for _, trade := range trades {
+			stored, err := store.InsertTrade(ctx, polymarket.StorageTrade(user.ID, addr.Address, trade))
+			if err != nil {
+				return inserted, fmt.Errorf("failed to store trade of %s: %w", user.Username, err)
+			}
+			if stored {
+				inserted++
+			}
+		}
+	}
+
+	return inserted, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/seed"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// runSeed handles the seed command, loading a fixture of synthetic data into the configured
+// database instead of starting the server
+func runSeed(ctx context.Context, cfg *config.Config, args []string, log *logrus.Logger) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fixturePath := flags.String("fixture", "", "path to the fixture file")
+	replace := flags.Bool("replace", false, "replace existing users with the fixture's usernames")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *fixturePath == "" {
+		flags.Usage()
+		return fmt.Errorf("missing --fixture")
+	}
+
+	fixture, err := seed.Load(*fixturePath)
+	if err != nil {
+		return err
+	}
+
+	store := storage.NewStorage(cfg.Database.Path, 0, cfg.Positions.DustValue, log)
+	if err := store.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop storage")
+		}
+	}()
+
+	summary, err := seed.Apply(ctx, store, fixture, seed.Options{Replace: *replace}, log)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"users":       summary.Users,
+		"trades":      summary.Trades,
+		"positions":   summary.Positions,
+		"settlements": summary.Settlements,
+		"snapshots":   summary.Snapshots,
+	}).Info("fixture loaded")
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/fetch"
+	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/tradecheck"
+	"github.com/sirupsen/logrus"
+)
+
+// runVerifyTrades handles the verify-trades command, checking stored trade values against
+// price times size and optionally against Polymarket, and printing the discrepancies found
+func runVerifyTrades(ctx context.Context, cfg *config.Config, args []string, log *logrus.Logger) error {
+	flags := flag.NewFlagSet("verify-trades", flag.ContinueOnError)
+	username := flags.String("user", "", "only check this user's trades")
+	upstream := flags.Bool("upstream", false, "also compare against the USDC amounts in recent Polymarket activity")
+	tolerance := flags.Float64("tolerance", 0.01, "largest difference in dollars treated as agreeing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *tolerance < 0 {
+		return fmt.Errorf("tolerance must be non-negative, got: %v", *tolerance)
+	}
+
+	store := storage.NewStorage(cfg.Database.Path, 0, cfg.Positions.DustValue, log)
+	if err := store.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Stop(); err != nil {
+			log.WithError(err).Error("failed to stop storage")
+		}
+	}()
+
+	var users []*storage.User
+	if *username != "" {
+		user, err := store.GetUser(ctx, *username)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", *username, err)
+		}
+		users = []*storage.User{user}
+	} else {
+		all, err := store.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		users = all
+	}
+
+	var client polymarket.Client
+	if *upstream {
+		pages := fetch.NewQueue(fetch.Options{
+			RequestsPerSecond: cfg.Fetch.RequestsPerSecond,
+			ReuseFor:          cfg.Fetch.ReuseFor,
+			Timeout:           cfg.Fetch.Timeout,
+		}, log)
+		client = polymarket.NewClient(0, polymarket.BrowserOptions{}, pages, log)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tTRADE\tTIMESTAMP\tKIND\tVALUE\tPRICE*SIZE\tUPSTREAM")
+
+	var checked, compared, discrepancies int
+	for _, user := range users {
+		trades, err := store.GetUserTradesChronological(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		values := make(tradecheck.Upstream)
+		if client != nil {
+			addresses, err := store.GetUserAddresses(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			for _, addr := range addresses {
+				activities, err := client.GetActivity(ctx, addr.Address)
+				if err != nil {
+					log.WithError(err).WithField("address", addr.Address).Warn("failed to fetch activity, skipping upstream comparison")
+					continue
+				}
+				for key, value := range tradecheck.UpstreamValues(activities) {
+					values[key] = value
+				}
+			}
+		}
+
+		report := tradecheck.Verify(trades, values, *tolerance)
+		checked += report.Checked
+		compared += report.Compared
+		discrepancies += len(report.Discrepancies)
+
+		for _, d := range report.Discrepancies {
+			timestamp := ""
+			if d.Trade.Timestamp != nil {
+				timestamp = d.Trade.Timestamp.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				user.Username, d.Trade.ID, timestamp, d.Kind,
+				formatAmount(d.Trade.Value), formatAmount(d.Recomputed), formatAmount(d.Upstream),
+			)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"users":         len(users),
+		"checked":       checked,
+		"compared":      compared,
+		"discrepancies": discrepancies,
+	}).Info("trade values verified")
+
+	return nil
+}
+
+// formatAmount formats an optional dollar amount for the discrepancy table
+func formatAmount(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", *v)
+}
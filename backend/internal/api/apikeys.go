@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// apiKeyPrefix makes issued API keys recognisable, e.g. in a leaked config
+const apiKeyPrefix = "pyre_ak_"
+
+// authorize enforces the scope an operation's security in the OpenAPI spec asks for: admin
+// operations need an admin key, and the rest a read key when server.requireKey is set.
+// Operations without security authenticate callers their own way, with persona tokens,
+// reaction keys or public API keys.
+func (h *APIHandler) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopes, _ := r.Context().Value(ApiKeyScopes).([]string)
+
+		switch {
+		case slices.Contains(scopes, storage.APIKeyScopeAdmin):
+			if !h.adminAuthorized(w, r) {
+				return
+			}
+		case slices.Contains(scopes, storage.APIKeyScopeRead) && h.config.Server.RequireKey:
+			scope, err := h.keyScope(r.Context(), requestAPIKey(r))
+			if err != nil {
+				h.log.WithError(err).Error("failed to check api key")
+				respondError(w, http.StatusInternalServerError, "Failed to check API key")
+				return
+			}
+			if scope == "" {
+				respondError(w, http.StatusUnauthorized, "Missing or unknown API key")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminAuthorized checks the request carries an admin key, otherwise responding 401, or 404
+// when there are no admin keys at all
+func (h *APIHandler) adminAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	ctx := r.Context()
+
+	scope, err := h.keyScope(ctx, requestAPIKey(r))
+	if err != nil {
+		h.log.WithError(err).Error("failed to check api key")
+		respondError(w, http.StatusInternalServerError, "Failed to check API key")
+		return false
+	}
+	if scope == storage.APIKeyScopeAdmin {
+		return true
+	}
+
+	if len(h.config.Server.AdminKeys) == 0 {
+		issued, err := h.storage.HasAPIKeys(ctx, storage.APIKeyScopeAdmin)
+		if err != nil {
+			h.log.WithError(err).Error("failed to check api keys")
+			respondError(w, http.StatusInternalServerError, "Failed to check API key")
+			return false
+		}
+		if !issued {
+			respondError(w, http.StatusNotFound, "The admin API is disabled")
+			return false
+		}
+	}
+
+	respondError(w, http.StatusUnauthorized, "Missing or unknown admin key")
+	return false
+}
+
+// isAdmin reports whether the request carries an admin key, for public operations that show
+// admins more. Unlike adminAuthorized it never responds.
+func (h *APIHandler) isAdmin(r *http.Request) bool {
+	scope, err := h.keyScope(r.Context(), requestAPIKey(r))
+	if err != nil {
+		h.log.WithError(err).Error("failed to check api key")
+		return false
+	}
+	return scope == storage.APIKeyScopeAdmin
+}
+
+// keyScope returns the scope of an API key from the config or issued through the admin API,
+// or "" when the key is unknown or revoked
+func (h *APIHandler) keyScope(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+
+	for _, configured := range []struct {
+		keys  []string
+		scope string
+	}{
+		{h.config.Server.AdminKeys, storage.APIKeyScopeAdmin},
+		{h.config.Server.ReadKeys, storage.APIKeyScopeRead},
+	} {
+		for _, valid := range configured.keys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(valid)) == 1 {
+				return configured.scope, nil
+			}
+		}
+	}
+
+	// Only issued keys are looked up, persona tokens and other secrets are never API keys
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", nil
+	}
+	issued, err := h.storage.UseAPIKey(ctx, hashToken(key))
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return issued.Scope, nil
+}
+
+// GetApiKeys lists the API keys issued through the admin API
+func (h *APIHandler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.storage.GetAPIKeys(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get api keys")
+		respondError(w, http.StatusInternalServerError, "Failed to get API keys")
+		return
+	}
+
+	response := ApiKeysResponse{Keys: make([]ApiKey, len(keys))}
+	for i, key := range keys {
+		response.Keys[i] = toAPIApiKey(key)
+	}
+
+	h.respondList(w, r, response, response.Keys, unpaged(len(response.Keys)))
+}
+
+// CreateApiKey issues an API key. Only its hash is stored, so the key is returned this once.
+func (h *APIHandler) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ApiKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "Key name is required")
+		return
+	}
+	if req.Scope != Read && req.Scope != Admin {
+		respondError(w, http.StatusBadRequest, "Scope must be read or admin")
+		return
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		h.log.WithError(err).Error("failed to generate api key")
+		respondError(w, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(b)
+
+	key, err := h.storage.CreateAPIKey(ctx, req.Name, string(req.Scope), hashToken(secret))
+	if err != nil {
+		h.log.WithError(err).Error("failed to create api key")
+		respondError(w, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	h.log.WithField("key", key.ID).WithField("scope", key.Scope).Info("issued api key")
+
+	respondJSON(w, http.StatusCreated, IssuedApiKey{
+		Key:    toAPIApiKey(key),
+		Secret: secret,
+	})
+}
+
+// RevokeApiKey revokes an API key issued through the admin API
+func (h *APIHandler) RevokeApiKey(w http.ResponseWriter, r *http.Request, id int64) {
+	if err := h.storage.RevokeAPIKey(r.Context(), id); err != nil {
+		h.log.WithError(err).WithField("key", id).Error("failed to revoke api key")
+		respondStorageError(w, err, "Key not found", "Failed to revoke API key")
+		return
+	}
+
+	h.log.WithField("key", id).Info("revoked api key")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toAPIApiKey converts a storage API key to the API representation
+func toAPIApiKey(key *storage.APIKey) ApiKey {
+	return ApiKey{
+		Id:         key.ID,
+		Name:       key.Name,
+		Scope:      ApiKeyScope(key.Scope),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// keyStorage serves the API keys issued through the admin API, keyed by the issued key. Other
+// storage calls aren't expected by the handlers under test.
+type keyStorage struct {
+	storage.Storage
+	issued map[string]*storage.APIKey
+	err    error
+}
+
+func (s *keyStorage) UseAPIKey(_ context.Context, keyHash string) (*storage.APIKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	for key, issued := range s.issued {
+		if hashToken(key) == keyHash && issued.RevokedAt == nil {
+			return issued, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (s *keyStorage) HasAPIKeys(_ context.Context, scope string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	for _, issued := range s.issued {
+		if issued.Scope == scope && issued.RevokedAt == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	configured := config.ServerConfig{
+		AdminKeys: []string{"admin-secret"},
+		ReadKeys:  []string{"read-secret"},
+	}
+	issued := map[string]*storage.APIKey{
+		apiKeyPrefix + "issued-admin": {Scope: storage.APIKeyScopeAdmin},
+		apiKeyPrefix + "issued-read":  {Scope: storage.APIKeyScopeRead},
+	}
+
+	revokedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	admin := []string{storage.APIKeyScopeAdmin}
+	read := []string{storage.APIKeyScopeRead}
+
+	tests := []struct {
+		name       string
+		server     config.ServerConfig
+		requireKey bool
+		issued     map[string]*storage.APIKey
+		storageErr error
+		scopes     []string // of the operation, nil when it has no security
+		key        string
+		want       int
+	}{
+		{name: "no security needs no key", server: configured, scopes: nil, want: http.StatusOK},
+		{name: "no security ignores unknown keys", server: configured, scopes: nil, key: "nope", want: http.StatusOK},
+
+		{name: "read without requireKey needs no key", server: configured, scopes: read, want: http.StatusOK},
+		{name: "read with requireKey needs a key", server: configured, requireKey: true, scopes: read, want: http.StatusUnauthorized},
+		{name: "read with requireKey rejects unknown keys", server: configured, requireKey: true, scopes: read, key: "nope", want: http.StatusUnauthorized},
+		{name: "read accepts a read key", server: configured, requireKey: true, scopes: read, key: "read-secret", want: http.StatusOK},
+		{name: "read accepts an admin key", server: configured, requireKey: true, scopes: read, key: "admin-secret", want: http.StatusOK},
+		{name: "read accepts an issued read key", server: configured, requireKey: true, issued: issued, scopes: read, key: apiKeyPrefix + "issued-read", want: http.StatusOK},
+		{name: "read rejects a revoked key", server: configured, requireKey: true, issued: map[string]*storage.APIKey{apiKeyPrefix + "revoked": {Scope: storage.APIKeyScopeRead, RevokedAt: &revokedAt}}, scopes: read, key: apiKeyPrefix + "revoked", want: http.StatusUnauthorized},
+		{name: "read fails when keys can't be checked", server: configured, requireKey: true, storageErr: errors.New("down"), scopes: read, key: apiKeyPrefix + "issued-read", want: http.StatusInternalServerError},
+
+		{name: "admin accepts an admin key", server: configured, scopes: admin, key: "admin-secret", want: http.StatusOK},
+		{name: "admin accepts an issued admin key", server: configured, issued: issued, scopes: admin, key: apiKeyPrefix + "issued-admin", want: http.StatusOK},
+		{name: "admin rejects a read key", server: configured, scopes: admin, key: "read-secret", want: http.StatusUnauthorized},
+		{name: "admin rejects an issued read key", server: configured, issued: issued, scopes: admin, key: apiKeyPrefix + "issued-read", want: http.StatusUnauthorized},
+		{name: "admin needs a key", server: configured, scopes: admin, want: http.StatusUnauthorized},
+		{name: "admin is disabled without admin keys", server: config.ServerConfig{ReadKeys: []string{"read-secret"}}, scopes: admin, key: "read-secret", want: http.StatusNotFound},
+		{name: "admin is enabled by issued admin keys", server: config.ServerConfig{}, issued: issued, scopes: admin, want: http.StatusUnauthorized},
+		{name: "admin fails when keys can't be checked", server: config.ServerConfig{}, storageErr: errors.New("down"), scopes: admin, want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logrus.New()
+			log.SetOutput(io.Discard)
+
+			cfg := &config.Config{Server: tt.server}
+			cfg.Server.RequireKey = tt.requireKey
+			h := &APIHandler{
+				storage: &keyStorage{issued: tt.issued, err: tt.storageErr},
+				config:  cfg,
+				log:     log,
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/anything", nil)
+			if tt.scopes != nil {
+				r = r.WithContext(context.WithValue(r.Context(), ApiKeyScopes, tt.scopes))
+			}
+			if tt.key != "" {
+				r.Header.Set("X-API-Key", tt.key)
+			}
+
+			w := httptest.NewRecorder()
+			h.authorize(next).ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
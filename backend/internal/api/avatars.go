@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/samcm/pyre/internal/avatars"
+)
+
+// avatarMaxAge is how long clients may cache an avatar without revalidating
+const avatarMaxAge = "public, max-age=86400"
+
+// GetUserAvatar serves a user's profile image through the avatar proxy
+func (h *APIHandler) GetUserAvatar(w http.ResponseWriter, r *http.Request, username string, params GetUserAvatarParams) {
+	user, err := h.storage.GetUser(r.Context(), username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	if user.ProfileImage == nil || *user.ProfileImage == "" {
+		respondError(w, http.StatusNotFound, "User has no profile image")
+		return
+	}
+
+	h.serveAvatar(w, r, *user.ProfileImage, params.Size)
+}
+
+// GetPersonaAvatar serves a persona's image through the avatar proxy, falling back to the
+// profile image of its first account that has one
+func (h *APIHandler) GetPersonaAvatar(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaAvatarParams) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	var image string
+	if persona.Image != nil {
+		image = *persona.Image
+	}
+	if image == "" {
+		users, err := h.storage.GetPersonaUsers(ctx, persona.ID)
+		if err != nil {
+			h.log.WithError(err).WithField("slug", slug).Error("failed to get persona users")
+			respondError(w, http.StatusInternalServerError, "Failed to get persona users")
+			return
+		}
+		for _, user := range publicUsers(users) {
+			if user.ProfileImage != nil && *user.ProfileImage != "" {
+				image = *user.ProfileImage
+				break
+			}
+		}
+	}
+
+	switch {
+	case image == "":
+		respondError(w, http.StatusNotFound, "Persona has no image")
+	case strings.HasPrefix(image, "/"):
+		// Images bundled with the frontend are already served locally
+		http.Redirect(w, r, image, http.StatusFound)
+	default:
+		h.serveAvatar(w, r, image, params.Size)
+	}
+}
+
+// serveAvatar writes a proxied avatar with caching headers, answering conditional requests
+func (h *APIHandler) serveAvatar(w http.ResponseWriter, r *http.Request, url string, size *int) {
+	requested := 0
+	if size != nil {
+		requested = *size
+	}
+
+	img, err := h.avatars.Get(r.Context(), url, requested)
+	if err != nil {
+		h.log.WithError(err).WithField("url", url).Warn("failed to get avatar")
+		respondError(w, http.StatusBadGateway, "Failed to fetch avatar")
+		return
+	}
+
+	writeImage(w, r, img)
+}
+
+// GetPersonaBanner serves a banner compositing the avatars of a persona's accounts
+func (h *APIHandler) GetPersonaBanner(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	users, err := h.storage.GetPersonaUsers(ctx, persona.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona users")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona users")
+		return
+	}
+	users = publicUsers(users)
+	if len(users) == 0 {
+		respondError(w, http.StatusNotFound, "Persona has no accounts")
+		return
+	}
+
+	members := make([]avatars.Member, 0, len(users))
+	for _, user := range users {
+		member := avatars.Member{Name: user.Username}
+		if user.ProfileImage != nil {
+			member.ImageURL = *user.ProfileImage
+		}
+		members = append(members, member)
+	}
+
+	img, err := h.avatars.Collage(ctx, members)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to generate persona banner")
+		respondError(w, http.StatusInternalServerError, "Failed to generate banner")
+		return
+	}
+
+	writeImage(w, r, img)
+}
+
+// writeImage writes an avatar or banner with caching headers, answering conditional requests
+func writeImage(w http.ResponseWriter, r *http.Request, img *avatars.Image) {
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Cache-Control", avatarMaxAge)
+	w.Header().Set("ETag", img.ETag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", img.ModTime, bytes.NewReader(img.Data))
+}
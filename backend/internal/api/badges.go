@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/samcm/pyre/internal/badges"
+	"github.com/samcm/pyre/internal/milestones"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetUserBadges returns the achievements a user has been awarded
+func (h *APIHandler) GetUserBadges(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	awarded, err := h.storage.GetUserBadges(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get badges")
+		respondError(w, http.StatusInternalServerError, "Failed to get badges")
+		return
+	}
+
+	response := make([]UserBadge, 0, len(awarded))
+	for _, b := range awarded {
+		response = append(response, toAPIBadge(b))
+	}
+
+	h.respondList(w, r, response, response, unpaged(len(response)))
+}
+
+// toAPIBadge converts a storage badge to the API representation
+func toAPIBadge(b *storage.UserBadge) UserBadge {
+	badge := UserBadge{
+		Id:        b.Badge,
+		Name:      b.Badge,
+		AwardedAt: b.AwardedAt,
+		Detail:    b.Detail,
+	}
+	if def := badges.Lookup(b.Badge); def != nil {
+		badge.Name = def.Name
+		badge.Description = def.Description
+	}
+
+	return badge
+}
+
+// GetUserTimeline returns a user's milestones and badges, newest first
+func (h *APIHandler) GetUserTimeline(w http.ResponseWriter, r *http.Request, username string, params GetUserTimelineParams) {
+	ctx := r.Context()
+
+	limit := 50
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > 500 {
+		respondError(w, http.StatusBadRequest, "Limit must be between 1 and 500")
+		return
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	reached, err := h.storage.GetUserMilestones(ctx, user.ID, limit)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get milestones")
+		respondError(w, http.StatusInternalServerError, "Failed to get timeline")
+		return
+	}
+
+	awarded, err := h.storage.GetUserBadges(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get badges")
+		respondError(w, http.StatusInternalServerError, "Failed to get timeline")
+		return
+	}
+
+	timeline := make([]TimelineEntry, 0, len(reached)+len(awarded))
+	for _, m := range reached {
+		milestone := toAPIMilestone(m)
+		timeline = append(timeline, TimelineEntry{Type: Milestone, Time: m.OccurredAt, Milestone: &milestone})
+	}
+	for _, b := range awarded {
+		badge := toAPIBadge(b)
+		timeline = append(timeline, TimelineEntry{Type: Badge, Time: b.AwardedAt, Badge: &badge})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].Time.After(timeline[j].Time) })
+	if len(timeline) > limit {
+		timeline = timeline[:limit]
+	}
+
+	h.respondList(w, r, timeline, timeline, unpaged(len(timeline)))
+}
+
+// toAPIMilestone converts a storage milestone to the API representation
+func toAPIMilestone(m *storage.UserMilestone) UserMilestone {
+	return UserMilestone{
+		Kind:       m.Kind,
+		Title:      milestones.Title(m),
+		OccurredAt: m.OccurredAt,
+		Value:      m.Value,
+		Detail:     m.Detail,
+	}
+}
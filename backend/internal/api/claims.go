@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/samcm/pyre/internal/claims"
+)
+
+// ClaimUser starts a claim of a user's Polymarket account
+func (h *APIHandler) ClaimUser(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	claim, err := h.claims.Claim(ctx, user)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to start claim")
+		respondError(w, http.StatusInternalServerError, "Failed to start claim")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, AccountClaim{
+		Username:  user.Username,
+		Nonce:     claim.Nonce,
+		CreatedAt: claim.CreatedAt,
+		ExpiresAt: claim.ExpiresAt,
+	})
+}
+
+// VerifyUserClaim checks the user's Polymarket bios for their claim's nonce
+func (h *APIHandler) VerifyUserClaim(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	result, err := h.claims.Verify(ctx, user)
+	var cooldown *claims.CooldownError
+	switch {
+	case errors.Is(err, claims.ErrNoClaim):
+		respondError(w, http.StatusConflict, "No pending claim, start one first")
+		return
+	case errors.Is(err, claims.ErrClaimExpired):
+		respondError(w, http.StatusConflict, "Claim expired, start a new one")
+		return
+	case errors.As(err, &cooldown):
+		w.Header().Set("Retry-After", strconv.Itoa(int(cooldown.Wait.Seconds()+0.999)))
+		respondError(w, http.StatusTooManyRequests, "Claim was checked recently")
+		return
+	case errors.Is(err, claims.ErrProfilesUnavailable):
+		h.log.WithError(err).WithField("username", username).Warn("failed to verify claim")
+		respondError(w, http.StatusBadGateway, "Failed to fetch profiles from Polymarket")
+		return
+	case err != nil:
+		h.log.WithError(err).WithField("username", username).Error("failed to verify claim")
+		respondError(w, http.StatusInternalServerError, "Failed to verify claim")
+		return
+	}
+
+	response := ClaimVerification{
+		Username:         user.Username,
+		Verified:         result.Verified,
+		CheckedAddresses: result.Checked,
+	}
+	if result.Verified {
+		response.Address = &result.Address
+		response.VerifiedAt = &result.VerifiedAt
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
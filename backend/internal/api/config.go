@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/roster"
+	"gopkg.in/yaml.v3"
+)
+
+// GetConfig returns the configuration loaded at startup in the config.yaml schema, with
+// secrets redacted
+func (h *APIHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	encoder := yaml.NewEncoder(&body)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(h.config.Redacted()); err != nil {
+		h.log.WithError(err).Error("failed to encode config")
+		respondError(w, http.StatusInternalServerError, "Failed to get config")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
+// ValidateConfig loads an uploaded config file as the server would at startup, reporting why
+// it wouldn't load, and for one that would, the roster changes applying it would make
+func (h *APIHandler) ValidateConfig(w http.ResponseWriter, r *http.Request, params ValidateConfigParams) {
+	ctx := r.Context()
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Failed to read config: %v", err))
+		return
+	}
+
+	result := ConfigValidationResult{
+		Errors:   make([]ConfigIssue, 0),
+		Warnings: make([]ConfigIssue, 0),
+	}
+
+	cfg, unused, err := config.Parse(data)
+	for _, key := range unused {
+		result.Warnings = append(result.Warnings, ConfigIssue{
+			Stage:   Decode,
+			Key:     &key,
+			Message: fmt.Sprintf("Unknown key %s is ignored", key),
+		})
+	}
+	if err != nil {
+		stage := config.StageValidate
+		var checkErr *config.CheckError
+		if errors.As(err, &checkErr) {
+			stage = checkErr.Stage
+		}
+		result.Errors = append(result.Errors, ConfigIssue{Stage: ConfigIssueStage(stage), Message: err.Error()})
+		respondJSON(w, http.StatusOK, result)
+		return
+	}
+	result.Valid = true
+
+	opts := roster.ApplyOptions{DryRun: true}
+	if params.Prune != nil {
+		opts.Prune = *params.Prune
+	}
+	changes, err := h.roster.Apply(ctx, &cfg.Roster, opts)
+	if err != nil {
+		h.log.WithError(err).Error("failed to compare roster")
+		respondError(w, http.StatusInternalServerError, "Failed to compare roster")
+		return
+	}
+	rosterChanges := toAPIRosterChanges(changes)
+	result.RosterChanges = &rosterChanges
+
+	respondJSON(w, http.StatusOK, result)
+}
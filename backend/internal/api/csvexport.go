@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samcm/pyre/internal/netting"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// Header rows of the CSV responses of endpoints called with format=csv. Trades use
+// tradeExportHeader, so they come out as /trades/export writes them.
+var (
+	positionCSVHeader = []string{
+		"username", "id", "conditionId", "marketTitle", "marketSlug", "outcome", "size", "avgPrice",
+		"currentPrice", "initialValue", "currentValue", "unrealizedPnl", "unrealizedPnlPercent", "endDate",
+		"pnlIfWins", "pnlIfLoses", "firstEnteredAt", "entryTradeId",
+	}
+	resultCSVHeader = []string{
+		"username", "id", "conditionId", "marketTitle", "marketSlug", "outcome", "initialValue", "realizedPnl",
+		"settlementPrice", "settledPnl", "endDate", "resolutionDate",
+	}
+	pnlCSVHeader = []string{"series", "timestamp", "totalPnl", "realizedPnl", "unrealizedPnl", "volume"}
+)
+
+// wantsCSV reports whether an endpoint's format parameter asks for CSV
+func wantsCSV[Format ~string](format *Format) bool {
+	return format != nil && string(*format) == "csv"
+}
+
+// supportedFormat reports whether an endpoint's format parameter is unset or one of formats,
+// responding with a 400 when it isn't. The generated wrappers don't check enums, so without it
+// an unknown format would get the default one.
+func supportedFormat[Format ~string](w http.ResponseWriter, format *Format, formats ...string) bool {
+	if format == nil || slices.Contains(formats, string(*format)) {
+		return true
+	}
+
+	respondError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported format: %s, expected one of: %s", *format, strings.Join(formats, ", ")))
+	return false
+}
+
+// respondCSV sends rows under a header as a CSV attachment named filename
+func (h *APIHandler) respondCSV(w http.ResponseWriter, filename string, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		h.log.WithError(err).WithField("filename", filename).Error("failed to write csv response")
+		return
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		h.log.WithError(err).WithField("filename", filename).Error("failed to write csv response")
+	}
+}
+
+// positionCSVRow lays a position out in the columns of positionCSVHeader
+func positionCSVRow(pos *storage.PositionWithUsername) []string {
+	var ifWins, ifLoses *float64
+	if pos.Size != nil {
+		wins, loses := netting.ResolutionPnl(&pos.Position)
+		ifWins, ifLoses = &wins, &loses
+	}
+
+	return []string{
+		pos.Username,
+		fmt.Sprintf("%d", pos.ID),
+		pos.ConditionID,
+		pos.DisplayTitle(),
+		stringOrEmpty(pos.MarketSlug),
+		stringOrEmpty(pos.Outcome),
+		floatOrEmpty(pos.Size),
+		floatOrEmpty(pos.AvgPrice),
+		floatOrEmpty(pos.CurrentPrice),
+		floatOrEmpty(pos.InitialValue),
+		floatOrEmpty(pos.CurrentValue),
+		floatOrEmpty(pos.UnrealizedPnl),
+		floatOrEmpty(pos.UnrealizedPnlPercent),
+		timeOrEmpty(pos.EndDate),
+		floatOrEmpty(ifWins),
+		floatOrEmpty(ifLoses),
+		timeOrEmpty(pos.FirstEnteredAt),
+		stringOrEmpty(pos.EntryTradeID),
+	}
+}
+
+// resultCSVRow lays a result out in the columns of resultCSVHeader
+func resultCSVRow(result *storage.ResultWithUsername) []string {
+	return []string{
+		result.Username,
+		fmt.Sprintf("%d", result.ID),
+		result.ConditionID,
+		result.DisplayTitle(),
+		stringOrEmpty(result.MarketSlug),
+		stringOrEmpty(result.Outcome),
+		floatOrEmpty(result.InitialValue),
+		floatOrEmpty(&result.RealizedPnl),
+		floatOrEmpty(result.SettlementPrice),
+		floatOrEmpty(result.SettledPnl),
+		timeOrEmpty(result.EndDate),
+		timeOrEmpty(result.ResolutionDate),
+	}
+}
+
+// pnlCSVRows lays a PnL history out in the columns of pnlCSVHeader, pyre's series first, then
+// the official one and the benchmark, if any
+func pnlCSVRows(history PnlHistory) [][]string {
+	rows := make([][]string, 0, len(history.DataPoints))
+	for _, point := range history.DataPoints {
+		rows = append(rows, []string{
+			"pyre",
+			timeOrEmpty(&point.Timestamp),
+			floatOrEmpty(&point.TotalPnl),
+			floatOrEmpty(&point.RealizedPnl),
+			floatOrEmpty(&point.UnrealizedPnl),
+			"",
+		})
+	}
+	if history.OfficialDataPoints != nil {
+		for _, point := range *history.OfficialDataPoints {
+			rows = append(rows, []string{
+				"official",
+				timeOrEmpty(&point.Timestamp),
+				floatOrEmpty(&point.TotalPnl),
+				"",
+				"",
+				floatOrEmpty(point.Volume),
+			})
+		}
+	}
+	if history.Benchmark != nil {
+		for _, point := range history.Benchmark.DataPoints {
+			rows = append(rows, []string{
+				"benchmark:" + history.Benchmark.Name,
+				timeOrEmpty(&point.Timestamp),
+				floatOrEmpty(&point.Pnl),
+				"",
+				"",
+				"",
+			})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// listPage describes the part of a list a response holds
+type listPage struct {
+	total  int // items in the whole list
+	count  int // items in the response
+	limit  int // 0 when the list isn't paged
+	offset int
+}
+
+// unpaged describes a response holding a whole list of count items
+func unpaged(count int) listPage {
+	return listPage{total: count, count: count}
+}
+
+// respondList sends a list endpoint's response. Clients asking for envelope=true get items
+// wrapped in a ListEnvelope with their pagination and freshness, others get body unchanged.
+func (h *APIHandler) respondList(w http.ResponseWriter, r *http.Request, body, items any, page listPage) {
+	if envelope, _ := strconv.ParseBool(r.URL.Query().Get("envelope")); !envelope {
+		respondJSON(w, http.StatusOK, body)
+		return
+	}
+
+	response := ListEnvelope{
+		Data:        items,
+		Pagination:  Pagination{Total: page.total},
+		GeneratedAt: time.Now().UTC(),
+	}
+	if page.limit > 0 {
+		response.Pagination.Limit = &page.limit
+		response.Pagination.Offset = &page.offset
+		if next := page.offset + page.count; page.count > 0 && next < page.total {
+			cursor := strconv.Itoa(next)
+			response.Pagination.NextCursor = &cursor
+		}
+	}
+
+	// Freshness is best effort, the list itself was already read
+	lastSynced, err := h.storage.GetLastSyncedAt(r.Context())
+	if err != nil {
+		h.log.WithError(err).Warn("failed to get data freshness")
+	}
+	response.DataFreshness = lastSynced
+
+	respondJSON(w, http.StatusOK, response)
+}
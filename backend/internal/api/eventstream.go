@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/stream"
+)
+
+// eventStreamRetry is how long browsers wait before reconnecting a dropped event stream
+const eventStreamRetry = 5 * time.Second
+
+// GetEventStream streams trades, completed syncs and PnL updates as Server-Sent Events, a
+// lighter alternative to the feed stream's WebSocket for clients behind restrictive proxies
+func (h *APIHandler) GetEventStream(w http.ResponseWriter, r *http.Request, params GetEventStreamParams) {
+	if h.stream == nil {
+		respondError(w, http.StatusNotFound, "Feed stream is disabled")
+		return
+	}
+
+	topics := make([]string, 0)
+	for _, username := range splitList(params.Username) {
+		topics = append(topics, stream.UserTopic(username))
+	}
+	for _, slug := range splitList(params.Persona) {
+		topics = append(topics, stream.PersonaTopic(slug))
+	}
+	if len(topics) > stream.MaxTopics {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("At most %d users and personas can be streamed", stream.MaxTopics))
+		return
+	}
+	for _, topic := range topics {
+		if err := h.stream.ValidateTopic(r.Context(), topic); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if len(topics) == 0 {
+		topics = append(topics, stream.TopicAllUsers)
+	}
+
+	sub := h.stream.Subscribe()
+	defer sub.Close()
+	if err := sub.Add(topics...); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Stops nginx and the like from buffering events
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	write := func(chunk string) error {
+		// Best effort: not all writers support deadlines
+		_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	if err := write(fmt.Sprintf("retry: %d\n\n", eventStreamRetry.Milliseconds())); err != nil {
+		h.log.WithError(err).Debug("failed to open event stream")
+		return
+	}
+
+	// Comments keep proxies from closing idle streams
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case msg, ok := <-sub.Messages():
+			if !ok {
+				// The stream service stopped
+				return
+			}
+			for _, event := range toEventStreamMessages(msg) {
+				data, err := json.Marshal(event)
+				if err != nil {
+					h.log.WithError(err).Error("failed to encode event stream message")
+					continue
+				}
+				if err := write(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data)); err != nil {
+					return
+				}
+			}
+
+		case <-ping.C:
+			if err := write(": ping\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// toEventStreamMessages converts a stream message to the events it is sent as: a trade, or a
+// completed sync followed by the PnL snapshot it took. Other updates aren't streamed.
+func toEventStreamMessages(msg *stream.Message) []EventStreamMessage {
+	event := msg.Event
+	if event == nil {
+		return nil
+	}
+
+	switch event.Type {
+	case events.TradeIngested:
+		if event.Trade == nil {
+			return nil
+		}
+		trade := toAPITrade(event.Trade)
+		return []EventStreamMessage{{
+			Type:     EventStreamMessageTypeTrade,
+			Time:     msg.Time,
+			Username: msg.Username,
+			Trade:    &trade,
+		}}
+
+	case events.UserSynced:
+		out := []EventStreamMessage{{
+			Type:     EventStreamMessageTypeSyncComplete,
+			Time:     msg.Time,
+			Username: msg.Username,
+		}}
+		if event.Snapshot != nil {
+			pnl := toAPIPnlDataPoint(event.Snapshot)
+			out = append(out, EventStreamMessage{
+				Type:     EventStreamMessageTypePnlUpdate,
+				Time:     msg.Time,
+				Username: msg.Username,
+				Pnl:      &pnl,
+			})
+		}
+		return out
+	}
+
+	return nil
+}
+
+// toAPIPnlDataPoint converts a PnL snapshot to a point of a PnL history
+func toAPIPnlDataPoint(snap *storage.PnlSnapshot) PnlDataPoint {
+	point := PnlDataPoint{Timestamp: snap.Timestamp}
+	if snap.TotalPnl != nil {
+		point.TotalPnl = *snap.TotalPnl
+	}
+	if snap.RealizedPnl != nil {
+		point.RealizedPnl = *snap.RealizedPnl
+	}
+	if snap.UnrealizedPnl != nil {
+		point.UnrealizedPnl = *snap.UnrealizedPnl
+	}
+	return point
+}
+
+// splitList splits a comma separated query parameter, dropping empty entries
+func splitList(param *string) []string {
+	if param == nil {
+		return nil
+	}
+
+	items := make([]string, 0)
+	for _, item := range strings.Split(*param, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
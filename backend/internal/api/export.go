@@ -0,0 +1,299 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// exportFlushInterval is the number of rows written between flushes of a streamed export
+const exportFlushInterval = 500
+
+// tradeExportHeader is the CSV header row for trade exports
+var tradeExportHeader = []string{
+	"id", "timestamp", "username", "conditionId", "marketTitle", "marketSlug",
+	"outcome", "side", "price", "size", "value",
+}
+
+// rowEncoder writes a single export row
+type rowEncoder interface {
+	encode(trade *storage.TradeWithUsername) error
+	flush() error
+}
+
+// csvTradeEncoder encodes trades as CSV rows
+type csvTradeEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvTradeEncoder) encode(trade *storage.TradeWithUsername) error {
+	return e.w.Write(tradeCSVRow(trade))
+}
+
+// tradeCSVRow lays a trade out in the columns of tradeExportHeader
+func tradeCSVRow(trade *storage.TradeWithUsername) []string {
+	return []string{
+		stringOrEmpty(trade.TradeID),
+		timeOrEmpty(trade.Timestamp),
+		trade.Username,
+		stringOrEmpty(trade.ConditionID),
+		trade.DisplayTitle(),
+		stringOrEmpty(trade.MarketSlug),
+		stringOrEmpty(trade.Outcome),
+		stringOrEmpty(trade.Side),
+		floatOrEmpty(trade.Price),
+		floatOrEmpty(trade.Size),
+		floatOrEmpty(trade.Value),
+	}
+}
+
+func (e *csvTradeEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonTradeEncoder encodes trades as newline-delimited JSON objects
+type ndjsonTradeEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonTradeEncoder) encode(trade *storage.TradeWithUsername) error {
+	return e.enc.Encode(tradeExportRow{
+		ID:          trade.TradeID,
+		Timestamp:   trade.Timestamp,
+		Username:    trade.Username,
+		ConditionID: trade.ConditionID,
+		MarketTitle: trade.MarketTitle,
+		MarketSlug:  trade.MarketSlug,
+		Outcome:     trade.Outcome,
+		Side:        trade.Side,
+		Price:       trade.Price,
+		Size:        trade.Size,
+		Value:       trade.Value,
+	})
+}
+
+func (e *ndjsonTradeEncoder) flush() error {
+	return nil
+}
+
+// tradeExportRow is the JSON shape of an exported trade
+type tradeExportRow struct {
+	ID          *string    `json:"id,omitempty"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+	Username    string     `json:"username"`
+	ConditionID *string    `json:"conditionId,omitempty"`
+	MarketTitle *string    `json:"marketTitle,omitempty"`
+	MarketSlug  *string    `json:"marketSlug,omitempty"`
+	Outcome     *string    `json:"outcome,omitempty"`
+	Side        *string    `json:"side,omitempty"`
+	Price       *float64   `json:"price,omitempty"`
+	Size        *float64   `json:"size,omitempty"`
+	Value       *float64   `json:"value,omitempty"`
+}
+
+// ExportTrades streams all trades matching the filters without buffering them in memory
+func (h *APIHandler) ExportTrades(w http.ResponseWriter, r *http.Request, params ExportTradesParams) {
+	ctx := r.Context()
+
+	if !supportedFormat(w, params.Format, "csv", "ndjson") {
+		return
+	}
+
+	filters := tradeExportFilters(params.Username, params.Side, params.MinValue, params.SortBy, params.SortDirection)
+
+	format := string(ExportTradesParamsFormatCsv)
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+
+	w.Header().Set("Content-Type", exportContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="trades.%s"`, format))
+
+	rc := http.NewResponseController(w)
+	rows, err := h.writeTradeExport(ctx, w, format, filters, rc.Flush)
+	if err != nil {
+		// Headers and possibly rows are already sent, so the client sees a truncated stream
+		h.log.WithError(err).WithField("rows", rows).Error("failed to export trades")
+	}
+}
+
+// SaveTradeExport writes all trades matching the filters to the blob store
+func (h *APIHandler) SaveTradeExport(w http.ResponseWriter, r *http.Request, params SaveTradeExportParams) {
+	ctx := r.Context()
+
+	if !supportedFormat(w, params.Format, "csv", "ndjson") {
+		return
+	}
+
+	if h.blobs == nil {
+		respondError(w, http.StatusServiceUnavailable, "No blob store is configured")
+		return
+	}
+
+	filters := tradeExportFilters(params.Username, params.Side, params.MinValue, params.SortBy, params.SortDirection)
+
+	format := string(SaveTradeExportParamsFormatCsv)
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+
+	// The export is staged in a temporary file so the blob store is given its size upfront
+	tmp, err := os.CreateTemp("", "pyre-export-*")
+	if err != nil {
+		h.log.WithError(err).Error("failed to create export file")
+		respondError(w, http.StatusInternalServerError, "Failed to export trades")
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rows, err := h.writeTradeExport(ctx, tmp, format, filters, nil)
+	if err != nil {
+		h.log.WithError(err).WithField("rows", rows).Error("failed to export trades")
+		respondError(w, http.StatusInternalServerError, "Failed to export trades")
+		return
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to rewind export file")
+		respondError(w, http.StatusInternalServerError, "Failed to export trades")
+		return
+	}
+
+	key := fmt.Sprintf("exports/trades-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	if err := h.blobs.Put(ctx, key, tmp, size, exportContentType(format)); err != nil {
+		h.log.WithError(err).WithField("key", key).Error("failed to save trade export")
+		respondError(w, http.StatusInternalServerError, "Failed to save trade export")
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"key":  key,
+		"rows": rows,
+		"size": size,
+	}).Info("saved trade export")
+
+	respondJSON(w, http.StatusCreated, SavedExport{
+		Key:      key,
+		Location: h.blobs.Location(key),
+		Rows:     rows,
+		Size:     size,
+	})
+}
+
+// tradeExportFilters builds the trade filters of an export from its query parameters, which
+// the streamed and saved exports share
+func tradeExportFilters[Side, SortBy, SortDirection ~string](username *string, side *Side, minValue *float64, sortBy *SortBy, sortDirection *SortDirection) storage.TradeFilters {
+	filters := storage.TradeFilters{
+		SortBy:        "timestamp",
+		SortDirection: "desc",
+		Username:      username,
+		MinValue:      minValue,
+	}
+
+	if side != nil {
+		s := string(*side)
+		filters.Side = &s
+	}
+
+	if sortBy != nil {
+		filters.SortBy = string(*sortBy)
+	}
+
+	if sortDirection != nil {
+		filters.SortDirection = string(*sortDirection)
+	}
+
+	return filters
+}
+
+// exportContentType returns the content type of an export format
+func exportContentType(format string) string {
+	if format == "ndjson" {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// writeTradeExport encodes the trades matching the filters to w as they are read from the
+// database, calling flush, when set, every exportFlushInterval rows. It returns the number of
+// rows written.
+func (h *APIHandler) writeTradeExport(ctx context.Context, w io.Writer, format string, filters storage.TradeFilters, flush func() error) (int, error) {
+	var encoder rowEncoder
+	switch format {
+	case "ndjson":
+		encoder = &ndjsonTradeEncoder{enc: json.NewEncoder(w)}
+	default:
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(tradeExportHeader); err != nil {
+			return 0, fmt.Errorf("failed to write export header: %w", err)
+		}
+		encoder = &csvTradeEncoder{w: csvWriter}
+	}
+
+	rows := 0
+	err := h.storage.IterateTrades(ctx, filters, func(trade *storage.TradeWithUsername) error {
+		if err := encoder.encode(trade); err != nil {
+			return fmt.Errorf("failed to encode trade: %w", err)
+		}
+
+		rows++
+		if flush != nil && rows%exportFlushInterval == 0 {
+			if err := encoder.flush(); err != nil {
+				return fmt.Errorf("failed to flush encoder: %w", err)
+			}
+			if err := flush(); err != nil {
+				return fmt.Errorf("failed to flush response: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return rows, err
+	}
+
+	if err := encoder.flush(); err != nil {
+		return rows, fmt.Errorf("failed to flush trade export: %w", err)
+	}
+
+	return rows, nil
+}
+
+// stringOrEmpty returns the string value or an empty string for nil
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// floatOrEmpty formats the float value or returns an empty string for nil
+func floatOrEmpty(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+// timeOrEmpty formats the time as RFC 3339 or returns an empty string for nil
+func timeOrEmpty(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
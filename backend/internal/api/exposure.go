@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/netting"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetUserExposure returns a user's open exposure and PnL at resolution
+func (h *APIHandler) GetUserExposure(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	exposure, err := h.exposure(ctx, func(includeDust bool) ([]*storage.Position, error) {
+		return h.storage.GetUserOpenPositions(ctx, user.ID, includeDust)
+	})
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get exposure")
+		respondError(w, http.StatusInternalServerError, "Failed to get exposure")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, exposure)
+}
+
+// GetPersonaExposure returns the open exposure and PnL at resolution across a persona's accounts
+func (h *APIHandler) GetPersonaExposure(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	if _, err := h.storage.GetPersona(ctx, slug); err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	exposure, err := h.exposure(ctx, func(includeDust bool) ([]*storage.Position, error) {
+		dbPositions, err := h.storage.GetPersonaPositions(ctx, slug, "", "desc", includeDust)
+		if err != nil {
+			return nil, err
+		}
+
+		positions := make([]*storage.Position, 0, len(dbPositions))
+		for _, pos := range dbPositions {
+			positions = append(positions, &pos.Position)
+		}
+		return positions, nil
+	})
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona exposure")
+		respondError(w, http.StatusInternalServerError, "Failed to get exposure")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, exposure)
+}
+
+// exposure summarises open positions. Like the user stats, dust counts towards cost and PnL
+// but not towards open positions and their value.
+func (h *APIHandler) exposure(ctx context.Context, positions func(includeDust bool) ([]*storage.Position, error)) (*Exposure, error) {
+	open, err := positions(false)
+	if err != nil {
+		return nil, err
+	}
+
+	exposure := &Exposure{OpenPositions: len(open)}
+	for _, pos := range open {
+		if pos.CurrentValue != nil {
+			exposure.OpenPositionValue += *pos.CurrentValue
+		}
+	}
+
+	all, err := positions(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pos := range all {
+		if pos.InitialValue != nil {
+			exposure.CostBasis += *pos.InitialValue
+		}
+		if pos.UnrealizedPnl != nil {
+			exposure.UnrealizedPnl += *pos.UnrealizedPnl
+		}
+	}
+	exposure.BestCasePnl, exposure.WorstCasePnl = netting.Scenarios(all)
+
+	return exposure, nil
+}
+
+// resolutionScenarios returns the combined best and worst case PnL at resolution of users'
+// open positions. Each user's markets resolve independently of the others'.
+func (h *APIHandler) resolutionScenarios(ctx context.Context, users []*storage.User) (best, worst float64, err error) {
+	for _, user := range users {
+		positions, err := h.storage.GetUserOpenPositions(ctx, user.ID, true)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		userBest, userWorst := netting.Scenarios(positions)
+		best += userBest
+		worst += userWorst
+	}
+
+	return best, worst, nil
+}
@@ -1,11 +1,12 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version (devel) DO NOT EDIT.
 package api
 
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -19,18 +20,88 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	ApiKeyScopes = "apiKey.Scopes"
+)
+
+// Defines values for ApiKeyScope.
+const (
+	Admin ApiKeyScope = "admin"
+	Read  ApiKeyScope = "read"
+)
+
+// Defines values for ConfigIssueStage.
+const (
+	Decode   ConfigIssueStage = "decode"
+	Parse    ConfigIssueStage = "parse"
+	Validate ConfigIssueStage = "validate"
+)
+
+// Defines values for EventStreamMessageType.
+const (
+	EventStreamMessageTypePnlUpdate    EventStreamMessageType = "pnl-update"
+	EventStreamMessageTypeSyncComplete EventStreamMessageType = "sync-complete"
+	EventStreamMessageTypeTrade        EventStreamMessageType = "trade"
+)
+
+// Defines values for NotificationPreviewRequestChannel.
+const (
+	Discord NotificationPreviewRequestChannel = "discord"
+)
+
+// Defines values for NotificationPreviewRequestKind.
+const (
+	NotificationPreviewRequestKindPositionClosed NotificationPreviewRequestKind = "position_closed"
+	NotificationPreviewRequestKindPositionOpened NotificationPreviewRequestKind = "position_opened"
+	NotificationPreviewRequestKindTrade          NotificationPreviewRequestKind = "trade"
+)
+
+// Defines values for RecomputeJobStatus.
+const (
+	Completed RecomputeJobStatus = "completed"
+	Failed    RecomputeJobStatus = "failed"
+	Running   RecomputeJobStatus = "running"
+)
+
+// Defines values for RosterChangeLogEntrySource.
+const (
+	Api    RosterChangeLogEntrySource = "api"
+	Config RosterChangeLogEntrySource = "config"
+)
+
+// Defines values for SyncRunTrigger.
+const (
+	Initial   SyncRunTrigger = "initial"
+	Manual    SyncRunTrigger = "manual"
+	Scheduled SyncRunTrigger = "scheduled"
+)
+
+// Defines values for TimelineEntryType.
+const (
+	Badge     TimelineEntryType = "badge"
+	Milestone TimelineEntryType = "milestone"
+)
+
+// Defines values for TradePositionChange.
+const (
+	Add   TradePositionChange = "add"
+	Close TradePositionChange = "close"
+	Open  TradePositionChange = "open"
+	Trim  TradePositionChange = "trim"
+)
+
 // Defines values for TradeSide.
 const (
 	TradeSideBUY  TradeSide = "BUY"
 	TradeSideSELL TradeSide = "SELL"
 )
 
-// Defines values for GetLeaderboardParamsSortBy.
+// Defines values for TradeDiscrepancyKind.
 const (
-	GetLeaderboardParamsSortByRealizedPnl   GetLeaderboardParamsSortBy = "realizedPnl"
-	GetLeaderboardParamsSortByTotalPnl      GetLeaderboardParamsSortBy = "totalPnl"
-	GetLeaderboardParamsSortByUnrealizedPnl GetLeaderboardParamsSortBy = "unrealizedPnl"
-	GetLeaderboardParamsSortByWinRate       GetLeaderboardParamsSortBy = "winRate"
+	ExceedsSize  TradeDiscrepancyKind = "exceeds_size"
+	InvalidValue TradeDiscrepancyKind = "invalid_value"
+	MissingValue TradeDiscrepancyKind = "missing_value"
+	Recomputed   TradeDiscrepancyKind = "recomputed"
 )
 
 // Defines values for GetLeaderboardParamsSortDirection.
@@ -53,6 +124,81 @@ const (
 	GetPersonaLeaderboardParamsSortDirectionDesc GetPersonaLeaderboardParamsSortDirection = "desc"
 )
 
+// Defines values for GetPersonaPositionsParamsSortBy.
+const (
+	GetPersonaPositionsParamsSortByCurrentValue  GetPersonaPositionsParamsSortBy = "currentValue"
+	GetPersonaPositionsParamsSortByEndDate       GetPersonaPositionsParamsSortBy = "endDate"
+	GetPersonaPositionsParamsSortByInitialValue  GetPersonaPositionsParamsSortBy = "initialValue"
+	GetPersonaPositionsParamsSortByMarketTitle   GetPersonaPositionsParamsSortBy = "marketTitle"
+	GetPersonaPositionsParamsSortBySize          GetPersonaPositionsParamsSortBy = "size"
+	GetPersonaPositionsParamsSortByUnrealizedPnl GetPersonaPositionsParamsSortBy = "unrealizedPnl"
+)
+
+// Defines values for GetPersonaPositionsParamsSortDirection.
+const (
+	GetPersonaPositionsParamsSortDirectionAsc  GetPersonaPositionsParamsSortDirection = "asc"
+	GetPersonaPositionsParamsSortDirectionDesc GetPersonaPositionsParamsSortDirection = "desc"
+)
+
+// Defines values for GetPersonaPositionsParamsFormat.
+const (
+	GetPersonaPositionsParamsFormatCsv  GetPersonaPositionsParamsFormat = "csv"
+	GetPersonaPositionsParamsFormatJson GetPersonaPositionsParamsFormat = "json"
+)
+
+// Defines values for GetPersonaResultsParamsSortBy.
+const (
+	GetPersonaResultsParamsSortByEndDate        GetPersonaResultsParamsSortBy = "endDate"
+	GetPersonaResultsParamsSortByInitialValue   GetPersonaResultsParamsSortBy = "initialValue"
+	GetPersonaResultsParamsSortByRealizedPnl    GetPersonaResultsParamsSortBy = "realizedPnl"
+	GetPersonaResultsParamsSortByResolutionDate GetPersonaResultsParamsSortBy = "resolutionDate"
+)
+
+// Defines values for GetPersonaResultsParamsSortDirection.
+const (
+	GetPersonaResultsParamsSortDirectionAsc  GetPersonaResultsParamsSortDirection = "asc"
+	GetPersonaResultsParamsSortDirectionDesc GetPersonaResultsParamsSortDirection = "desc"
+)
+
+// Defines values for GetPersonaResultsParamsFormat.
+const (
+	GetPersonaResultsParamsFormatCsv  GetPersonaResultsParamsFormat = "csv"
+	GetPersonaResultsParamsFormatJson GetPersonaResultsParamsFormat = "json"
+)
+
+// Defines values for GetPersonaTradesParamsSortBy.
+const (
+	GetPersonaTradesParamsSortBySize      GetPersonaTradesParamsSortBy = "size"
+	GetPersonaTradesParamsSortByTimestamp GetPersonaTradesParamsSortBy = "timestamp"
+	GetPersonaTradesParamsSortByValue     GetPersonaTradesParamsSortBy = "value"
+)
+
+// Defines values for GetPersonaTradesParamsSortDirection.
+const (
+	GetPersonaTradesParamsSortDirectionAsc  GetPersonaTradesParamsSortDirection = "asc"
+	GetPersonaTradesParamsSortDirectionDesc GetPersonaTradesParamsSortDirection = "desc"
+)
+
+// Defines values for GetPersonaTradesParamsFormat.
+const (
+	GetPersonaTradesParamsFormatCsv  GetPersonaTradesParamsFormat = "csv"
+	GetPersonaTradesParamsFormatJson GetPersonaTradesParamsFormat = "json"
+)
+
+// Defines values for GetSentimentParamsSortBy.
+const (
+	Holders        GetSentimentParamsSortBy = "holders"
+	SentimentScore GetSentimentParamsSortBy = "sentimentScore"
+	TotalSize      GetSentimentParamsSortBy = "totalSize"
+	TotalValue     GetSentimentParamsSortBy = "totalValue"
+)
+
+// Defines values for GetSentimentParamsSortDirection.
+const (
+	GetSentimentParamsSortDirectionAsc  GetSentimentParamsSortDirection = "asc"
+	GetSentimentParamsSortDirectionDesc GetSentimentParamsSortDirection = "desc"
+)
+
 // Defines values for GetTradesParamsSide.
 const (
 	GetTradesParamsSideBUY  GetTradesParamsSide = "BUY"
@@ -61,503 +207,5128 @@ const (
 
 // Defines values for GetTradesParamsSortBy.
 const (
-	Size      GetTradesParamsSortBy = "size"
-	Timestamp GetTradesParamsSortBy = "timestamp"
-	Value     GetTradesParamsSortBy = "value"
+	GetTradesParamsSortBySize      GetTradesParamsSortBy = "size"
+	GetTradesParamsSortByTimestamp GetTradesParamsSortBy = "timestamp"
+	GetTradesParamsSortByValue     GetTradesParamsSortBy = "value"
 )
 
 // Defines values for GetTradesParamsSortDirection.
 const (
-	Asc  GetTradesParamsSortDirection = "asc"
-	Desc GetTradesParamsSortDirection = "desc"
+	GetTradesParamsSortDirectionAsc  GetTradesParamsSortDirection = "asc"
+	GetTradesParamsSortDirectionDesc GetTradesParamsSortDirection = "desc"
 )
 
-// BackfillResult defines model for BackfillResult.
-type BackfillResult struct {
-	NewestTradeDate  *time.Time `json:"newestTradeDate,omitempty"`
-	OldestTradeDate  *time.Time `json:"oldestTradeDate,omitempty"`
-	SnapshotsCreated int        `json:"snapshotsCreated"`
-	TotalRealizedPnl float64    `json:"totalRealizedPnl"`
-	TradesProcessed  int        `json:"tradesProcessed"`
-	Username         string     `json:"username"`
+// Defines values for GetTradesParamsGroupBy.
+const (
+	Market GetTradesParamsGroupBy = "market"
+)
+
+// Defines values for GetTradesParamsFormat.
+const (
+	GetTradesParamsFormatCsv  GetTradesParamsFormat = "csv"
+	GetTradesParamsFormatJson GetTradesParamsFormat = "json"
+)
+
+// Defines values for ExportTradesParamsFormat.
+const (
+	ExportTradesParamsFormatCsv    ExportTradesParamsFormat = "csv"
+	ExportTradesParamsFormatNdjson ExportTradesParamsFormat = "ndjson"
+)
+
+// Defines values for ExportTradesParamsSide.
+const (
+	ExportTradesParamsSideBUY  ExportTradesParamsSide = "BUY"
+	ExportTradesParamsSideSELL ExportTradesParamsSide = "SELL"
+)
+
+// Defines values for ExportTradesParamsSortBy.
+const (
+	ExportTradesParamsSortBySize      ExportTradesParamsSortBy = "size"
+	ExportTradesParamsSortByTimestamp ExportTradesParamsSortBy = "timestamp"
+	ExportTradesParamsSortByValue     ExportTradesParamsSortBy = "value"
+)
+
+// Defines values for ExportTradesParamsSortDirection.
+const (
+	ExportTradesParamsSortDirectionAsc  ExportTradesParamsSortDirection = "asc"
+	ExportTradesParamsSortDirectionDesc ExportTradesParamsSortDirection = "desc"
+)
+
+// Defines values for SaveTradeExportParamsFormat.
+const (
+	SaveTradeExportParamsFormatCsv    SaveTradeExportParamsFormat = "csv"
+	SaveTradeExportParamsFormatNdjson SaveTradeExportParamsFormat = "ndjson"
+)
+
+// Defines values for SaveTradeExportParamsSide.
+const (
+	SaveTradeExportParamsSideBUY  SaveTradeExportParamsSide = "BUY"
+	SaveTradeExportParamsSideSELL SaveTradeExportParamsSide = "SELL"
+)
+
+// Defines values for SaveTradeExportParamsSortBy.
+const (
+	SaveTradeExportParamsSortBySize      SaveTradeExportParamsSortBy = "size"
+	SaveTradeExportParamsSortByTimestamp SaveTradeExportParamsSortBy = "timestamp"
+	SaveTradeExportParamsSortByValue     SaveTradeExportParamsSortBy = "value"
+)
+
+// Defines values for SaveTradeExportParamsSortDirection.
+const (
+	SaveTradeExportParamsSortDirectionAsc  SaveTradeExportParamsSortDirection = "asc"
+	SaveTradeExportParamsSortDirectionDesc SaveTradeExportParamsSortDirection = "desc"
+)
+
+// Defines values for GetUsersParamsSortBy.
+const (
+	GetUsersParamsSortByCreatedAt     GetUsersParamsSortBy = "createdAt"
+	GetUsersParamsSortByLastSynced    GetUsersParamsSortBy = "lastSynced"
+	GetUsersParamsSortByOpenPositions GetUsersParamsSortBy = "openPositions"
+	GetUsersParamsSortByRealizedPnl   GetUsersParamsSortBy = "realizedPnl"
+	GetUsersParamsSortByTotalPnl      GetUsersParamsSortBy = "totalPnl"
+	GetUsersParamsSortByTotalTrades   GetUsersParamsSortBy = "totalTrades"
+	GetUsersParamsSortByUnrealizedPnl GetUsersParamsSortBy = "unrealizedPnl"
+	GetUsersParamsSortByUsername      GetUsersParamsSortBy = "username"
+	GetUsersParamsSortByVolume        GetUsersParamsSortBy = "volume"
+	GetUsersParamsSortByWinRate       GetUsersParamsSortBy = "winRate"
+)
+
+// Defines values for GetUsersParamsSortDirection.
+const (
+	GetUsersParamsSortDirectionAsc  GetUsersParamsSortDirection = "asc"
+	GetUsersParamsSortDirectionDesc GetUsersParamsSortDirection = "desc"
+)
+
+// Defines values for GetUserPnlParamsFormat.
+const (
+	GetUserPnlParamsFormatCsv  GetUserPnlParamsFormat = "csv"
+	GetUserPnlParamsFormatJson GetUserPnlParamsFormat = "json"
+)
+
+// Defines values for GetUserPositionsParamsFormat.
+const (
+	GetUserPositionsParamsFormatCsv  GetUserPositionsParamsFormat = "csv"
+	GetUserPositionsParamsFormatJson GetUserPositionsParamsFormat = "json"
+)
+
+// Defines values for GetUserResultsParamsFormat.
+const (
+	GetUserResultsParamsFormatCsv  GetUserResultsParamsFormat = "csv"
+	GetUserResultsParamsFormatJson GetUserResultsParamsFormat = "json"
+)
+
+// Defines values for GetUserTradesParamsFormat.
+const (
+	GetUserTradesParamsFormatCsv  GetUserTradesParamsFormat = "csv"
+	GetUserTradesParamsFormatJson GetUserTradesParamsFormat = "json"
+)
+
+// Defines values for ImportUserTradesParamsFormat.
+const (
+	Columns    ImportUserTradesParamsFormat = "columns"
+	Polymarket ImportUserTradesParamsFormat = "polymarket"
+)
+
+// AccountClaim defines model for AccountClaim.
+type AccountClaim struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Nonce Text to put in the bio of one of the user's Polymarket profiles
+	Nonce    string `json:"nonce"`
+	Username string `json:"username"`
 }
 
-// LeaderboardEntry defines model for LeaderboardEntry.
-type LeaderboardEntry struct {
-	OpenPositions      *int     `json:"openPositions,omitempty"`
-	PersonaDisplayName *string  `json:"personaDisplayName,omitempty"`
-	PersonaSlug        *string  `json:"personaSlug,omitempty"`
-	ProfileImage       *string  `json:"profileImage,omitempty"`
-	Rank               int      `json:"rank"`
-	RealizedPnl        float64  `json:"realizedPnl"`
-	TotalPnl           float64  `json:"totalPnl"`
-	UnrealizedPnl      float64  `json:"unrealizedPnl"`
-	Username           string   `json:"username"`
-	WinRate            *float64 `json:"winRate,omitempty"`
+// AccountIdentity defines model for AccountIdentity.
+type AccountIdentity struct {
+	Bio *string `json:"bio,omitempty"`
+
+	// Name Polymarket profile name
+	Name      *string   `json:"name,omitempty"`
+	Pseudonym *string   `json:"pseudonym,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Username  string    `json:"username"`
+
+	// XUsername X/Twitter handle linked to the profile or its bio
+	XUsername *string `json:"xUsername,omitempty"`
 }
 
-// PersonaAccount defines model for PersonaAccount.
-type PersonaAccount struct {
-	Addresses     []string `json:"addresses"`
-	OpenPositions *int     `json:"openPositions,omitempty"`
-	ProfileImage  *string  `json:"profileImage,omitempty"`
-	RealizedPnl   float64  `json:"realizedPnl"`
-	TotalPnl      float64  `json:"totalPnl"`
-	TotalTrades   *int     `json:"totalTrades,omitempty"`
-	UnrealizedPnl float64  `json:"unrealizedPnl"`
-	Username      string   `json:"username"`
-	WinRate       *float64 `json:"winRate,omitempty"`
+// AddressGroup defines model for AddressGroup.
+type AddressGroup struct {
+	Addresses    []string `json:"addresses"`
+	CurrentValue float64  `json:"currentValue"`
+
+	// Name Group name, or "ungrouped" for addresses without a group
+	Name          string `json:"name"`
+	OpenPositions int    `json:"openPositions"`
+
+	// RealizedPnl FIFO realized PnL over the group's trades
+	RealizedPnl   float64 `json:"realizedPnl"`
+	TotalPnl      float64 `json:"totalPnl"`
+	TotalTrades   int     `json:"totalTrades"`
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
+	Volume        float64 `json:"volume"`
+	WinRate       float64 `json:"winRate"`
 }
 
-// PersonaDetail defines model for PersonaDetail.
-type PersonaDetail struct {
-	DisplayName   string   `json:"displayName"`
-	Image         *string  `json:"image,omitempty"`
-	OpenPositions *int     `json:"openPositions,omitempty"`
-	RealizedPnl   float64  `json:"realizedPnl"`
-	Slug          string   `json:"slug"`
-	TotalPnl      float64  `json:"totalPnl"`
-	TotalTrades   *int     `json:"totalTrades,omitempty"`
-	UnrealizedPnl float64  `json:"unrealizedPnl"`
-	Usernames     []string `json:"usernames"`
-	WinRate       *float64 `json:"winRate,omitempty"`
+// AddressGroupRequest defines model for AddressGroupRequest.
+type AddressGroupRequest struct {
+	Addresses []string `json:"addresses"`
 }
 
-// PersonaLeaderboardEntry defines model for PersonaLeaderboardEntry.
-type PersonaLeaderboardEntry struct {
-	DisplayName   string    `json:"displayName"`
-	Image         *string   `json:"image,omitempty"`
-	OpenPositions *int      `json:"openPositions,omitempty"`
-	Rank          int       `json:"rank"`
-	RealizedPnl   float64   `json:"realizedPnl"`
-	Slug          string    `json:"slug"`
-	TotalPnl      float64   `json:"totalPnl"`
-	UnrealizedPnl float64   `json:"unrealizedPnl"`
-	Usernames     *[]string `json:"usernames,omitempty"`
-	WinRate       *float64  `json:"winRate,omitempty"`
+// AddressPnl defines model for AddressPnl.
+type AddressPnl struct {
+	Address    string    `json:"address"`
+	ComputedAt time.Time `json:"computedAt"`
+
+	// Name Polymarket profile name, when the address has a profile
+	Name          *string `json:"name,omitempty"`
+	OpenPositions int     `json:"openPositions"`
+
+	// PositionValue Current value of open positions
+	PositionValue float64 `json:"positionValue"`
+	ProfileImage  *string `json:"profileImage,omitempty"`
+	RealizedPnl   float64 `json:"realizedPnl"`
+
+	// Source Where the PnL comes from: official (the Polymarket profile's all-time stats) or
+	// positions (summed from the address's current positions, for addresses without a profile)
+	Source        string  `json:"source"`
+	TotalPnl      float64 `json:"totalPnl"`
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
+
+	// Volume All-time volume, only present when source is official
+	Volume *float64 `json:"volume,omitempty"`
 }
 
-// PersonaPosition defines model for PersonaPosition.
-type PersonaPosition struct {
-	AvgPrice             float64    `json:"avgPrice"`
-	ConditionId          *string    `json:"conditionId,omitempty"`
-	CurrentPrice         float64    `json:"currentPrice"`
-	CurrentValue         *float64   `json:"currentValue,omitempty"`
-	EndDate              *time.Time `json:"endDate,omitempty"`
-	Id                   string     `json:"id"`
-	InitialValue         *float64   `json:"initialValue,omitempty"`
-	MarketSlug           *string    `json:"marketSlug,omitempty"`
-	MarketTitle          string     `json:"marketTitle"`
-	Outcome              string     `json:"outcome"`
-	Size                 float64    `json:"size"`
-	UnrealizedPnl        float64    `json:"unrealizedPnl"`
-	UnrealizedPnlPercent *float64   `json:"unrealizedPnlPercent,omitempty"`
-	Username             string     `json:"username"`
+// AddressTradeBackfill defines model for AddressTradeBackfill.
+type AddressTradeBackfill struct {
+	Address        string `json:"address"`
+	TradesFetched  int    `json:"tradesFetched"`
+	TradesInserted int    `json:"tradesInserted"`
 }
 
-// PersonaResult defines model for PersonaResult.
-type PersonaResult struct {
-	ConditionId    string     `json:"conditionId"`
-	EndDate        *time.Time `json:"endDate,omitempty"`
-	Id             string     `json:"id"`
-	InitialValue   *float64   `json:"initialValue,omitempty"`
-	MarketSlug     *string    `json:"marketSlug,omitempty"`
-	MarketTitle    string     `json:"marketTitle"`
-	Outcome        string     `json:"outcome"`
-	RealizedPnl    float64    `json:"realizedPnl"`
-	ResolutionDate *time.Time `json:"resolutionDate,omitempty"`
-	Username       string     `json:"username"`
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  time.Time  `json:"createdAt"`
+	Id         int64      `json:"id"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       string     `json:"name"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+
+	// Scope read allows reading the API, admin also allows changing it
+	Scope ApiKeyScope `json:"scope"`
 }
 
-// PersonaResultsResponse defines model for PersonaResultsResponse.
-type PersonaResultsResponse struct {
-	Limit   *int            `json:"limit,omitempty"`
-	Offset  *int            `json:"offset,omitempty"`
-	Results []PersonaResult `json:"results"`
-	Total   int             `json:"total"`
+// ApiKeyRequest defines model for ApiKeyRequest.
+type ApiKeyRequest struct {
+	// Name Who or what the key is for
+	Name string `json:"name"`
+
+	// Scope read allows reading the API, admin also allows changing it
+	Scope ApiKeyScope `json:"scope"`
 }
 
-// PersonaSummary defines model for PersonaSummary.
-type PersonaSummary struct {
-	DisplayName string   `json:"displayName"`
-	Image       *string  `json:"image,omitempty"`
-	Slug        string   `json:"slug"`
-	Usernames   []string `json:"usernames"`
+// ApiKeyScope read allows reading the API, admin also allows changing it
+type ApiKeyScope string
+
+// ApiKeysResponse defines model for ApiKeysResponse.
+type ApiKeysResponse struct {
+	Keys []ApiKey `json:"keys"`
 }
 
-// PnlDataPoint defines model for PnlDataPoint.
-type PnlDataPoint struct {
-	RealizedPnl   float64   `json:"realizedPnl"`
-	Timestamp     time.Time `json:"timestamp"`
-	TotalPnl      float64   `json:"totalPnl"`
-	UnrealizedPnl float64   `json:"unrealizedPnl"`
+// BackfillResult defines model for BackfillResult.
+type BackfillResult struct {
+	NewestTradeDate  *time.Time `json:"newestTradeDate,omitempty"`
+	OldestTradeDate  *time.Time `json:"oldestTradeDate,omitempty"`
+	SnapshotsCreated int        `json:"snapshotsCreated"`
+
+	// SnapshotsSkipped Reconstructed snapshots not written because synced snapshots already cover them
+	SnapshotsSkipped int     `json:"snapshotsSkipped"`
+	TotalRealizedPnl float64 `json:"totalRealizedPnl"`
+	TradesProcessed  int     `json:"tradesProcessed"`
+	Username         string  `json:"username"`
 }
 
-// PnlHistory defines model for PnlHistory.
-type PnlHistory struct {
-	DataPoints []PnlDataPoint `json:"dataPoints"`
-	Username   string         `json:"username"`
+// BenchmarkDataPoint defines model for BenchmarkDataPoint.
+type BenchmarkDataPoint struct {
+	Pnl       float64   `json:"pnl"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
-// Position defines model for Position.
-type Position struct {
-	AvgPrice             float64    `json:"avgPrice"`
-	ConditionId          *string    `json:"conditionId,omitempty"`
-	CurrentPrice         float64    `json:"currentPrice"`
-	CurrentValue         *float64   `json:"currentValue,omitempty"`
-	EndDate              *time.Time `json:"endDate,omitempty"`
-	Id                   string     `json:"id"`
-	InitialValue         *float64   `json:"initialValue,omitempty"`
-	MarketSlug           *string    `json:"marketSlug,omitempty"`
-	MarketTitle          string     `json:"marketTitle"`
-	Outcome              string     `json:"outcome"`
-	Size                 float64    `json:"size"`
-	UnrealizedPnl        float64    `json:"unrealizedPnl"`
-	UnrealizedPnlPercent *float64   `json:"unrealizedPnlPercent,omitempty"`
+// ClaimVerification defines model for ClaimVerification.
+type ClaimVerification struct {
+	// Address Address whose bio carried the nonce, set when verified
+	Address *string `json:"address,omitempty"`
+
+	// CheckedAddresses Addresses whose profiles were fetched and checked
+	CheckedAddresses []string   `json:"checkedAddresses"`
+	Username         string     `json:"username"`
+	Verified         bool       `json:"verified"`
+	VerifiedAt       *time.Time `json:"verifiedAt,omitempty"`
 }
 
-// Result defines model for Result.
-type Result struct {
-	ConditionId    string     `json:"conditionId"`
-	EndDate        *time.Time `json:"endDate,omitempty"`
-	Id             string     `json:"id"`
-	InitialValue   *float64   `json:"initialValue,omitempty"`
-	MarketSlug     *string    `json:"marketSlug,omitempty"`
-	MarketTitle    string     `json:"marketTitle"`
-	Outcome        string     `json:"outcome"`
-	RealizedPnl    float64    `json:"realizedPnl"`
-	ResolutionDate *time.Time `json:"resolutionDate,omitempty"`
+// ConfigIssue defines model for ConfigIssue.
+type ConfigIssue struct {
+	// Key The setting the issue is about, when known
+	Key     *string `json:"key,omitempty"`
+	Message string  `json:"message"`
+
+	// Stage parse (YAML syntax), decode (values of the wrong type, unknown keys) or validate (settings that don't make sense, alone or together)
+	Stage ConfigIssueStage `json:"stage"`
 }
 
-// ResultsResponse defines model for ResultsResponse.
-type ResultsResponse struct {
-	Limit   *int     `json:"limit,omitempty"`
-	Offset  *int     `json:"offset,omitempty"`
-	Results []Result `json:"results"`
-	Total   int      `json:"total"`
+// ConfigIssueStage parse (YAML syntax), decode (values of the wrong type, unknown keys) or validate (settings that don't make sense, alone or together)
+type ConfigIssueStage string
+
+// ConfigValidationResult defines model for ConfigValidationResult.
+type ConfigValidationResult struct {
+	// Errors What stops the config loading. Checking ends at the first stage with errors.
+	Errors []ConfigIssue `json:"errors"`
+
+	// RosterChanges Changes applying the config's roster would make, set when the config is valid
+	RosterChanges *[]RosterChange `json:"rosterChanges,omitempty"`
+
+	// Valid The config would load
+	Valid bool `json:"valid"`
+
+	// Warnings Problems that don't stop the config loading, such as unknown keys
+	Warnings []ConfigIssue `json:"warnings"`
 }
 
-// Trade defines model for Trade.
-type Trade struct {
-	ConditionId        *string   `json:"conditionId,omitempty"`
-	Id                 string    `json:"id"`
-	MarketSlug         *string   `json:"marketSlug,omitempty"`
-	MarketTitle        string    `json:"marketTitle"`
-	Outcome            string    `json:"outcome"`
-	PersonaDisplayName *string   `json:"personaDisplayName,omitempty"`
-	PersonaSlug        *string   `json:"personaSlug,omitempty"`
-	Price              float64   `json:"price"`
-	ProfileImage       *string   `json:"profileImage,omitempty"`
-	Side               TradeSide `json:"side"`
-	Size               float64   `json:"size"`
-	Timestamp          time.Time `json:"timestamp"`
-	Username           *string   `json:"username,omitempty"`
-	Value              float64   `json:"value"`
+// CopySimulation defines model for CopySimulation.
+type CopySimulation struct {
+	Capital     float64           `json:"capital"`
+	DataPoints  []EquityDataPoint `json:"dataPoints"`
+	FinalEquity float64           `json:"finalEquity"`
+	ReturnPct   float64           `json:"returnPct"`
+
+	// Scale Copier shares bought per share the trader bought
+	Scale         float64    `json:"scale"`
+	Start         *time.Time `json:"start,omitempty"`
+	TradesCopied  int        `json:"tradesCopied"`
+	TradesSkipped int        `json:"tradesSkipped"`
+	Username      string     `json:"username"`
 }
 
-// TradeSide defines model for Trade.Side.
-type TradeSide string
+// CreatePersonaRequest defines model for CreatePersonaRequest.
+type CreatePersonaRequest struct {
+	DisplayName string  `json:"displayName"`
+	Image       *string `json:"image,omitempty"`
 
-// TradesResponse defines model for TradesResponse.
-type TradesResponse struct {
-	Limit  *int    `json:"limit,omitempty"`
-	Offset *int    `json:"offset,omitempty"`
-	Total  int     `json:"total"`
-	Trades []Trade `json:"trades"`
+	// Slug Lowercase letters, digits, dashes and underscores
+	Slug string `json:"slug"`
 }
 
-// User defines model for User.
-type User struct {
-	Addresses    []string   `json:"addresses"`
-	LastSynced   *time.Time `json:"lastSynced,omitempty"`
-	ProfileImage *string    `json:"profileImage,omitempty"`
-	Username     string     `json:"username"`
+// CreateUserRequest defines model for CreateUserRequest.
+type CreateUserRequest struct {
+	// Addresses Wallet addresses, 0x followed by 40 hex characters
+	Addresses []string `json:"addresses"`
+	Ghost     *bool    `json:"ghost,omitempty"`
+
+	// Persona Slug of the persona the user belongs to
+	Persona  *string `json:"persona,omitempty"`
+	Username string  `json:"username"`
 }
 
-// UserDetail defines model for UserDetail.
-type UserDetail struct {
-	Addresses     []string   `json:"addresses"`
-	LastSynced    *time.Time `json:"lastSynced,omitempty"`
-	OpenPositions *int       `json:"openPositions,omitempty"`
-	ProfileImage  *string    `json:"profileImage,omitempty"`
-	RealizedPnl   float64    `json:"realizedPnl"`
-	TotalPnl      float64    `json:"totalPnl"`
-	TotalTrades   *int       `json:"totalTrades,omitempty"`
-	UnrealizedPnl float64    `json:"unrealizedPnl"`
-	Username      string     `json:"username"`
-	WinRate       *float64   `json:"winRate,omitempty"`
+// EdgeStats Entry price statistics over resolved positions. Entry price is the implied probability paid,
+// so edge is the realized win rate minus the average entry price.
+type EdgeStats struct {
+	AvgEntryPrice     float64  `json:"avgEntryPrice"`
+	AvgLossEntryPrice *float64 `json:"avgLossEntryPrice,omitempty"`
+	AvgWinEntryPrice  *float64 `json:"avgWinEntryPrice,omitempty"`
+
+	// Category Market category inferred from the market title, or "all" for the overall bucket
+	Category string  `json:"category"`
+	Edge     float64 `json:"edge"`
+
+	// KellyFraction Full Kelly stake fraction implied by the win rate at the average entry price
+	KellyFraction float64 `json:"kellyFraction"`
+	Losses        int     `json:"losses"`
+	Resolved      int     `json:"resolved"`
+	WinRate       float64 `json:"winRate"`
+	Wins          int     `json:"wins"`
 }
 
-// GetLeaderboardParams defines parameters for GetLeaderboard.
-type GetLeaderboardParams struct {
-	SortBy        *GetLeaderboardParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
-	SortDirection *GetLeaderboardParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+// EntryTimingStats How long before the market's end date positions are entered
+type EntryTimingStats struct {
+	// AvgDaysBeforeEnd Days from entry to the market's end date, weighted by buy cost; buys after the end date count as zero
+	AvgDaysBeforeEnd *float64 `json:"avgDaysBeforeEnd,omitempty"`
+
+	// Entries Number of buys in markets with a known end date
+	Entries int `json:"entries"`
 }
 
-// GetLeaderboardParamsSortBy defines parameters for GetLeaderboard.
-type GetLeaderboardParamsSortBy string
+// EquityDataPoint defines model for EquityDataPoint.
+type EquityDataPoint struct {
+	Cash      float64   `json:"cash"`
+	Equity    float64   `json:"equity"`
+	Timestamp time.Time `json:"timestamp"`
+}
 
-// GetLeaderboardParamsSortDirection defines parameters for GetLeaderboard.
-type GetLeaderboardParamsSortDirection string
+// EventLeaderboard defines model for EventLeaderboard.
+type EventLeaderboard struct {
+	Entries   []LeaderboardEntry `json:"entries"`
+	EventSlug string             `json:"eventSlug"`
+}
 
-// GetPersonaLeaderboardParams defines parameters for GetPersonaLeaderboard.
-type GetPersonaLeaderboardParams struct {
-	SortBy        *GetPersonaLeaderboardParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
-	SortDirection *GetPersonaLeaderboardParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+// EventStreamMessage defines model for EventStreamMessage.
+type EventStreamMessage struct {
+	Pnl      *PnlDataPoint          `json:"pnl,omitempty"`
+	Time     time.Time              `json:"time"`
+	Trade    *Trade                 `json:"trade,omitempty"`
+	Type     EventStreamMessageType `json:"type"`
+	Username string                 `json:"username"`
 }
 
-// GetPersonaLeaderboardParamsSortBy defines parameters for GetPersonaLeaderboard.
-type GetPersonaLeaderboardParamsSortBy string
+// EventStreamMessageType defines model for EventStreamMessage.Type.
+type EventStreamMessageType string
 
-// GetPersonaLeaderboardParamsSortDirection defines parameters for GetPersonaLeaderboard.
-type GetPersonaLeaderboardParamsSortDirection string
+// Exposure Open positions and what they make at resolution. Best and worst case resolve each market to the outcome best or worst for the holder, relative to what the positions cost; markets already priced at 0 or 1 count as resolved.
+type Exposure struct {
+	// BestCasePnl PnL at resolution if every market resolves in the holder's favour
+	BestCasePnl float64 `json:"bestCasePnl"`
 
-// GetPersonaResultsParams defines parameters for GetPersonaResults.
-type GetPersonaResultsParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// CostBasis Paid for the open positions
+	CostBasis float64 `json:"costBasis"`
+
+	// OpenPositionValue Combined current value of open positions
+	OpenPositionValue float64 `json:"openPositionValue"`
+	OpenPositions     int     `json:"openPositions"`
+	UnrealizedPnl     float64 `json:"unrealizedPnl"`
+
+	// WorstCasePnl PnL at resolution if every market resolves against the holder
+	WorstCasePnl float64 `json:"worstCasePnl"`
 }
 
-// GetPersonaTradesParams defines parameters for GetPersonaTrades.
-type GetPersonaTradesParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// FeedMuteRules defines model for FeedMuteRules.
+type FeedMuteRules struct {
+	Config MuteRules `json:"config"`
+	Custom MuteRules `json:"custom"`
 }
 
-// GetTradesParams defines parameters for GetTrades.
-type GetTradesParams struct {
-	Limit         *int                          `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset        *int                          `form:"offset,omitempty" json:"offset,omitempty"`
-	Username      *string                       `form:"username,omitempty" json:"username,omitempty"`
-	Side          *GetTradesParamsSide          `form:"side,omitempty" json:"side,omitempty"`
-	MinValue      *float64                      `form:"minValue,omitempty" json:"minValue,omitempty"`
-	SortBy        *GetTradesParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
-	SortDirection *GetTradesParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+// FeedStreamCommand defines model for FeedStreamCommand.
+type FeedStreamCommand struct {
+	// Action subscribe or unsubscribe
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
 }
 
-// GetTradesParamsSide defines parameters for GetTrades.
-type GetTradesParamsSide string
+// FeedStreamMessage defines model for FeedStreamMessage.
+type FeedStreamMessage struct {
+	Badge *UserBadge `json:"badge,omitempty"`
+
+	// Event What happened, for event messages: trade_ingested, position_opened, position_closed,
+	// market_resolved, sync_failed, user_synced, badge_awarded, milestone_reached,
+	// trading_started (a user's first trade after presence.activeMinutes without one) or
+	// concentration_alert (a market newly holding more than concentration.thresholdPercent
+	// of the user's open exposure, with its largest position)
+	Event       *string                  `json:"event,omitempty"`
+	Leaderboard *[]LeaderboardRankChange `json:"leaderboard,omitempty"`
+
+	// Message Why a command failed, for error messages
+	Message   *string        `json:"message,omitempty"`
+	Milestone *UserMilestone `json:"milestone,omitempty"`
+	Position  *Position      `json:"position,omitempty"`
+	Time      *time.Time     `json:"time,omitempty"`
+
+	// Topics Topics the message was delivered for, or all subscribed topics for subscribed
+	Topics *[]string `json:"topics,omitempty"`
+	Trade  *Trade    `json:"trade,omitempty"`
+
+	// Type subscribed (the connection's topics after a change), event (an update for a user),
+	// leaderboard (rank changes) or error (an invalid command)
+	Type     string  `json:"type"`
+	Username *string `json:"username,omitempty"`
+}
 
-// GetTradesParamsSortBy defines parameters for GetTrades.
-type GetTradesParamsSortBy string
+// GhostModeRequest defines model for GhostModeRequest.
+type GhostModeRequest struct {
+	Ghost bool `json:"ghost"`
+}
 
-// GetTradesParamsSortDirection defines parameters for GetTrades.
-type GetTradesParamsSortDirection string
+// GlobalLeaderboard defines model for GlobalLeaderboard.
+type GlobalLeaderboard struct {
+	Entries []GlobalLeaderboardEntry `json:"entries"`
+	Limit   int                      `json:"limit"`
+	Offset  int                      `json:"offset"`
 
-// GetUserPnlParams defines parameters for GetUserPnl.
-type GetUserPnlParams struct {
-	Start *time.Time `form:"start,omitempty" json:"start,omitempty"`
-	End   *time.Time `form:"end,omitempty" json:"end,omitempty"`
+	// Total Number of global traders stored
+	Total int `json:"total"`
+
+	// Tracked Tracked users by global rank, best first. Users below the stored leaderboard have no rank and come last.
+	Tracked []GlobalRank `json:"tracked"`
+
+	// UpdatedAt When the leaderboard was fetched, unset before the first fetch
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
-// GetUserResultsParams defines parameters for GetUserResults.
-type GetUserResultsParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// GlobalLeaderboardEntry defines model for GlobalLeaderboardEntry.
+type GlobalLeaderboardEntry struct {
+	Address string `json:"address"`
+
+	// Name Polymarket username, unset for anonymous traders
+	Name         *string `json:"name,omitempty"`
+	Pnl          float64 `json:"pnl"`
+	ProfileImage *string `json:"profileImage,omitempty"`
+	Rank         int     `json:"rank"`
+
+	// Username The tracked user holding this address, if any
+	Username *string `json:"username,omitempty"`
+	Volume   float64 `json:"volume"`
 }
 
-// GetUserTradesParams defines parameters for GetUserTrades.
-type GetUserTradesParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// GlobalRank defines model for GlobalRank.
+type GlobalRank struct {
+	// Estimated The user's addresses aren't on the stored leaderboard, and rank is where their PnL would place among the stored traders
+	Estimated bool `json:"estimated"`
+
+	// Pnl The PnL ranked, official PnL from Polymarket when known, else pyre's total PnL
+	Pnl float64 `json:"pnl"`
+
+	// Rank Global rank, unset when the user's PnL is below the last stored trader
+	Rank     *int   `json:"rank,omitempty"`
+	Username string `json:"username"`
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get leaderboard of all users
-	// (GET /leaderboard)
-	GetLeaderboard(w http.ResponseWriter, r *http.Request, params GetLeaderboardParams)
-	// Get all personas (real people mapped to usernames)
-	// (GET /personas)
-	GetPersonas(w http.ResponseWriter, r *http.Request)
-	// Get leaderboard by persona (aggregated stats)
-	// (GET /personas/leaderboard)
-	GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request, params GetPersonaLeaderboardParams)
-	// Get persona details with aggregated stats
-	// (GET /personas/{slug})
-	GetPersona(w http.ResponseWriter, r *http.Request, slug string)
-	// Get all accounts for a persona with individual stats
-	// (GET /personas/{slug}/accounts)
-	GetPersonaAccounts(w http.ResponseWriter, r *http.Request, slug string)
-	// Get combined positions across all accounts for a persona
-	// (GET /personas/{slug}/positions)
-	GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string)
-	// Get combined resolved positions (results) across all accounts for a persona
-	// (GET /personas/{slug}/results)
-	GetPersonaResults(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaResultsParams)
-	// Get combined trades across all accounts for a persona
-	// (GET /personas/{slug}/trades)
-	GetPersonaTrades(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaTradesParams)
-	// Trigger a sync of all user data
-	// (POST /sync)
-	TriggerSync(w http.ResponseWriter, r *http.Request)
-	// Get all recent trades with filtering
-	// (GET /trades)
-	GetTrades(w http.ResponseWriter, r *http.Request, params GetTradesParams)
-	// Get all tracked users
-	// (GET /users)
-	GetUsers(w http.ResponseWriter, r *http.Request)
-	// Get user details
-	// (GET /users/{username})
-	GetUser(w http.ResponseWriter, r *http.Request, username string)
-	// Backfill PNL history from trade data using FIFO cost basis
-	// (POST /users/{username}/backfill)
-	BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string)
-	// Get user's PNL history
-	// (GET /users/{username}/pnl)
-	GetUserPnl(w http.ResponseWriter, r *http.Request, username string, params GetUserPnlParams)
-	// Get user's current positions
-	// (GET /users/{username}/positions)
-	GetUserPositions(w http.ResponseWriter, r *http.Request, username string)
-	// Get user's resolved positions (results)
-	// (GET /users/{username}/results)
-	GetUserResults(w http.ResponseWriter, r *http.Request, username string, params GetUserResultsParams)
-	// Get user's trade history
-	// (GET /users/{username}/trades)
-	GetUserTrades(w http.ResponseWriter, r *http.Request, username string, params GetUserTradesParams)
+// GroupEquity defines model for GroupEquity.
+type GroupEquity struct {
+	// BestCasePnl Unrealized PnL if every open market resolves in the group's favour
+	BestCasePnl float64 `json:"bestCasePnl"`
+
+	// DataPoints Combined PnL history in hourly buckets
+	DataPoints []PnlDataPoint `json:"dataPoints"`
+
+	// OpenPositionValue Combined current value of all open positions
+	OpenPositionValue float64 `json:"openPositionValue"`
+	OpenPositions     int     `json:"openPositions"`
+	Persona           *string `json:"persona,omitempty"`
+	RealizedPnl       float64 `json:"realizedPnl"`
+	TotalPnl          float64 `json:"totalPnl"`
+	UnrealizedPnl     float64 `json:"unrealizedPnl"`
+	Users             int     `json:"users"`
+
+	// WorstCasePnl Unrealized PnL if every open market resolves against the group
+	WorstCasePnl float64 `json:"worstCasePnl"`
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// HoldTimeBucket defines model for HoldTimeBucket.
+type HoldTimeBucket struct {
+	Disposals int `json:"disposals"`
 
-type Unimplemented struct{}
+	// Label Hold time range (<1h, 1h-1d, 1d-1w, 1w-1m, >1m)
+	Label  string  `json:"label"`
+	Shares float64 `json:"shares"`
+}
 
-// Get leaderboard of all users
-// (GET /leaderboard)
-func (_ Unimplemented) GetLeaderboard(w http.ResponseWriter, r *http.Request, params GetLeaderboardParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// HoldTimeStats Hold times of FIFO-matched lots, from entry to sell or market resolution
+type HoldTimeStats struct {
+	Buckets []HoldTimeBucket `json:"buckets"`
+
+	// Disposals Number of lots (or parts of lots) closed with a known hold time
+	Disposals int `json:"disposals"`
+
+	// MeanHours Mean hold time weighted by shares
+	MeanHours   *float64 `json:"meanHours,omitempty"`
+	MedianHours *float64 `json:"medianHours,omitempty"`
 }
 
-// Get all personas (real people mapped to usernames)
-// (GET /personas)
-func (_ Unimplemented) GetPersonas(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// IssuedApiKey defines model for IssuedApiKey.
+type IssuedApiKey struct {
+	Key ApiKey `json:"key"`
+
+	// Secret The key to send, only shown when it is issued
+	Secret string `json:"secret"`
 }
 
-// Get leaderboard by persona (aggregated stats)
-// (GET /personas/leaderboard)
-func (_ Unimplemented) GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request, params GetPersonaLeaderboardParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// IssuedPersonaToken defines model for IssuedPersonaToken.
+type IssuedPersonaToken struct {
+	// Secret The token to send, only shown when it is issued
+	Secret string       `json:"secret"`
+	Token  PersonaToken `json:"token"`
 }
 
-// Get persona details with aggregated stats
-// (GET /personas/{slug})
-func (_ Unimplemented) GetPersona(w http.ResponseWriter, r *http.Request, slug string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// LeaderboardEntry defines model for LeaderboardEntry.
+type LeaderboardEntry struct {
+	// IsActive Traded within the last presence.activeMinutes
+	IsActive           *bool      `json:"isActive,omitempty"`
+	LastTradeAt        *time.Time `json:"lastTradeAt,omitempty"`
+	OpenPositions      *int       `json:"openPositions,omitempty"`
+	PersonaDisplayName *string    `json:"personaDisplayName,omitempty"`
+	PersonaSlug        *string    `json:"personaSlug,omitempty"`
+	ProfileImage       *string    `json:"profileImage,omitempty"`
+	Rank               int        `json:"rank"`
+	RealizedPnl        float64    `json:"realizedPnl"`
+
+	// Scores Values of the custom scores configured under leaderboard.scores, keyed by score name
+	Scores        *map[string]float64 `json:"scores,omitempty"`
+	TotalPnl      float64             `json:"totalPnl"`
+	UnrealizedPnl float64             `json:"unrealizedPnl"`
+	Username      string              `json:"username"`
+	Volume        *float64            `json:"volume,omitempty"`
+	WinRate       *float64            `json:"winRate,omitempty"`
 }
 
-// Get all accounts for a persona with individual stats
-// (GET /personas/{slug}/accounts)
-func (_ Unimplemented) GetPersonaAccounts(w http.ResponseWriter, r *http.Request, slug string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// LeaderboardRankChange defines model for LeaderboardRankChange.
+type LeaderboardRankChange struct {
+	// PreviousRank Rank before the change, absent when the user wasn't ranked
+	PreviousRank *int    `json:"previousRank,omitempty"`
+	Rank         int     `json:"rank"`
+	TotalPnl     float64 `json:"totalPnl"`
+	Username     string  `json:"username"`
 }
 
-// Get combined positions across all accounts for a persona
-// (GET /personas/{slug}/positions)
-func (_ Unimplemented) GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// ListEnvelope Response of a list endpoint called with envelope=true. data holds the list the endpoint returns without the envelope: its array, or the array of its paged response object.
+type ListEnvelope struct {
+	// Data The listed items
+	Data interface{} `json:"data"`
+
+	// DataFreshness When tracked users were last synced, unset before the first sync
+	DataFreshness *time.Time `json:"dataFreshness,omitempty"`
+	GeneratedAt   time.Time  `json:"generatedAt"`
+	Pagination    Pagination `json:"pagination"`
 }
 
-// Get combined resolved positions (results) across all accounts for a persona
-// (GET /personas/{slug}/results)
-func (_ Unimplemented) GetPersonaResults(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaResultsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketMetadata defines model for MarketMetadata.
+type MarketMetadata struct {
+	Category *string `json:"category,omitempty"`
+
+	// Closed Whether the market has stopped trading
+	Closed      bool       `json:"closed"`
+	ConditionId string     `json:"conditionId"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	EventSlug   *string    `json:"eventSlug,omitempty"`
+	EventTitle  *string    `json:"eventTitle,omitempty"`
+	FetchedAt   time.Time  `json:"fetchedAt"`
+
+	// Found False when Gamma doesn't know the market, titled from its trades instead
+	Found    bool            `json:"found"`
+	Outcomes []MarketOutcome `json:"outcomes"`
+
+	// Resolved Whether the market has closed with an outcome settled at 1
+	Resolved       bool    `json:"resolved"`
+	Slug           *string `json:"slug,omitempty"`
+	Title          string  `json:"title"`
+	WinningOutcome *string `json:"winningOutcome,omitempty"`
 }
 
-// Get combined trades across all accounts for a persona
-// (GET /personas/{slug}/trades)
-func (_ Unimplemented) GetPersonaTrades(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaTradesParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketOutcome defines model for MarketOutcome.
+type MarketOutcome struct {
+	Name string `json:"name"`
+
+	// Price Last price on Gamma
+	Price *float64 `json:"price,omitempty"`
 }
 
-// Trigger a sync of all user data
-// (POST /sync)
-func (_ Unimplemented) TriggerSync(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketOutcomeStats defines model for MarketOutcomeStats.
+type MarketOutcomeStats struct {
+	Holders int    `json:"holders"`
+	Outcome string `json:"outcome"`
+
+	// Share Fraction of tracked open size held in this outcome
+	Share float64 `json:"share"`
+	Size  float64 `json:"size"`
 }
 
-// Get all recent trades with filtering
-// (GET /trades)
-func (_ Unimplemented) GetTrades(w http.ResponseWriter, r *http.Request, params GetTradesParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketSearchResult defines model for MarketSearchResult.
+type MarketSearchResult struct {
+	ConditionId string `json:"conditionId"`
+	Holders     int    `json:"holders"`
+
+	// LeanOutcome Outcome holding the largest share of tracked open size
+	LeanOutcome *string              `json:"leanOutcome,omitempty"`
+	LeanShare   *float64             `json:"leanShare,omitempty"`
+	MarketSlug  *string              `json:"marketSlug,omitempty"`
+	MarketTitle string               `json:"marketTitle"`
+	Outcomes    []MarketOutcomeStats `json:"outcomes"`
+	TotalSize   float64              `json:"totalSize"`
+	TradeCount  int                  `json:"tradeCount"`
 }
 
-// Get all tracked users
-// (GET /users)
-func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketSentiment defines model for MarketSentiment.
+type MarketSentiment struct {
+	ConditionId string               `json:"conditionId"`
+	Holders     int                  `json:"holders"`
+	LeanOutcome *string              `json:"leanOutcome,omitempty"`
+	MarketSlug  *string              `json:"marketSlug,omitempty"`
+	MarketTitle string               `json:"marketTitle"`
+	Outcomes    []MarketOutcomeStats `json:"outcomes"`
+
+	// SentimentScore Net sentiment in [-1, 1]: (size on the leading outcome - size on all other outcomes) / total size.
+	// 1 means every tracked holder is on the same side, 0 means the group is evenly split.
+	SentimentScore float64 `json:"sentimentScore"`
+	TotalSize      float64 `json:"totalSize"`
+	TotalValue     float64 `json:"totalValue"`
 }
 
-// Get user details
-// (GET /users/{username})
-func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, username string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketTradeGroup defines model for MarketTradeGroup.
+type MarketTradeGroup struct {
+	// BuyValue Value of the buys, in USDC
+	BuyValue     float64   `json:"buyValue"`
+	ConditionId  string    `json:"conditionId"`
+	FirstTradeAt time.Time `json:"firstTradeAt"`
+	LastTradeAt  time.Time `json:"lastTradeAt"`
+	MarketSlug   *string   `json:"marketSlug,omitempty"`
+	MarketTitle  string    `json:"marketTitle"`
+
+	// NetValue Buy value less sell value, positive when tracked users are buying in
+	NetValue float64 `json:"netValue"`
+
+	// Participants Users who traded the market, by volume
+	Participants []MarketTradeParticipant `json:"participants"`
+
+	// SellValue Value of the sells, in USDC
+	SellValue float64 `json:"sellValue"`
+
+	// Size Shares traded
+	Size float64 `json:"size"`
+
+	// Trades Number of trades in the market
+	Trades int `json:"trades"`
+
+	// Volume Value of all trades, in USDC
+	Volume float64 `json:"volume"`
 }
 
-// Backfill PNL history from trade data using FIFO cost basis
-// (POST /users/{username}/backfill)
-func (_ Unimplemented) BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MarketTradeParticipant defines model for MarketTradeParticipant.
+type MarketTradeParticipant struct {
+	BuyValue     float64 `json:"buyValue"`
+	NetValue     float64 `json:"netValue"`
+	ProfileImage *string `json:"profileImage,omitempty"`
+	SellValue    float64 `json:"sellValue"`
+	Trades       int     `json:"trades"`
+	Username     string  `json:"username"`
 }
 
-// Get user's PNL history
-// (GET /users/{username}/pnl)
-func (_ Unimplemented) GetUserPnl(w http.ResponseWriter, r *http.Request, username string, params GetUserPnlParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// MuteRules defines model for MuteRules.
+type MuteRules struct {
+	// Categories Hide trades in these market categories (politics, sports, crypto, economics, culture, other)
+	Categories *[]string `json:"categories,omitempty"`
+
+	// MinValue Hide trades worth less than this
+	MinValue *float64 `json:"minValue,omitempty"`
+
+	// Users Hide trades by these usernames
+	Users *[]string `json:"users,omitempty"`
 }
 
-// Get user's current positions
-// (GET /users/{username}/positions)
-func (_ Unimplemented) GetUserPositions(w http.ResponseWriter, r *http.Request, username string) {
-	w.WriteHeader(http.StatusNotImplemented)
+// NetPosition defines model for NetPosition.
+type NetPosition struct {
+	// AvgPrice Effective entry price of the net shares, after crediting the locked value
+	AvgPrice    *float64 `json:"avgPrice,omitempty"`
+	ConditionId string   `json:"conditionId"`
+
+	// CostBasis Paid for both outcomes
+	CostBasis    float64  `json:"costBasis"`
+	CurrentPrice *float64 `json:"currentPrice,omitempty"`
+
+	// CurrentValue Value of the net shares, the exposure
+	CurrentValue float64    `json:"currentValue"`
+	EndDate      *time.Time `json:"endDate,omitempty"`
+
+	// HedgedSize Shares held on both outcomes
+	HedgedSize float64 `json:"hedgedSize"`
+
+	// Legs Positions combined into this one
+	Legs int `json:"legs"`
+
+	// LockedValue What the hedged shares redeem for whatever the result
+	LockedValue float64 `json:"lockedValue"`
+	MarketSlug  *string `json:"marketSlug,omitempty"`
+	MarketTitle string  `json:"marketTitle"`
+
+	// Outcome Side of the net shares, unset when fully hedged
+	Outcome *string `json:"outcome,omitempty"`
+
+	// Size Net shares on outcome
+	Size float64 `json:"size"`
+
+	// UnrealizedPnl With hedged shares valued at redemption
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
 }
 
-// Get user's resolved positions (results)
-// (GET /users/{username}/results)
-func (_ Unimplemented) GetUserResults(w http.ResponseWriter, r *http.Request, username string, params GetUserResultsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// NewReaction A comment or an emoji reaction, exactly one of which must be set
+type NewReaction struct {
+	// Comment Short comment, limited to the configured maximum length
+	Comment *string `json:"comment,omitempty"`
+
+	// Emoji A single emoji
+	Emoji *string `json:"emoji,omitempty"`
+}
+
+// NotificationPreview defines model for NotificationPreview.
+type NotificationPreview struct {
+	Channel string `json:"channel"`
+
+	// Headline The headline as rendered by the template, or the built-in one without a template
+	Headline string `json:"headline"`
+	Kind     string `json:"kind"`
+	Market   string `json:"market"`
+
+	// Sent Whether the sample was posted to the channel
+	Sent bool    `json:"sent"`
+	Url  *string `json:"url,omitempty"`
+}
+
+// NotificationPreviewRequest defines model for NotificationPreviewRequest.
+type NotificationPreviewRequest struct {
+	// Channel Channel whose template lays the notification out, discord by default
+	Channel *NotificationPreviewRequestChannel `json:"channel,omitempty"`
+	Kind    NotificationPreviewRequestKind     `json:"kind"`
+
+	// Send Post the sample to the channel
+	Send *bool `json:"send,omitempty"`
+
+	// Template Go template to use instead of the channel's, e.g. "{{.username}} {{.side}} {{usd .value}}"
+	Template *string `json:"template,omitempty"`
+}
+
+// NotificationPreviewRequestChannel Channel whose template lays the notification out, discord by default
+type NotificationPreviewRequestChannel string
+
+// NotificationPreviewRequestKind defines model for NotificationPreviewRequest.Kind.
+type NotificationPreviewRequestKind string
+
+// OfficialPnlDataPoint defines model for OfficialPnlDataPoint.
+type OfficialPnlDataPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	TotalPnl  float64   `json:"totalPnl"`
+	Volume    *float64  `json:"volume,omitempty"`
+}
+
+// OwnerAccount defines model for OwnerAccount.
+type OwnerAccount struct {
+	Addresses []string `json:"addresses"`
+
+	// Ghost Whether the account is hidden publicly, its addresses included
+	Ghost      bool       `json:"ghost"`
+	LastSynced *time.Time `json:"lastSynced,omitempty"`
+
+	// Reconciliation Stored trade values checked against price times size
+	Reconciliation TradeReconciliation `json:"reconciliation"`
+	Username       string              `json:"username"`
+}
+
+// Pagination defines model for Pagination.
+type Pagination struct {
+	// Limit Set for paged lists
+	Limit *int `json:"limit,omitempty"`
+
+	// NextCursor Offset of the next page, unset on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Offset Set for paged lists
+	Offset *int `json:"offset,omitempty"`
+
+	// Total Items in the whole list. Lists only capped by a limit, without an offset, count the items returned.
+	Total int `json:"total"`
+}
+
+// PersonaAccount defines model for PersonaAccount.
+type PersonaAccount struct {
+	// Addresses Empty for ghost users
+	Addresses     []string `json:"addresses"`
+	OpenPositions *int     `json:"openPositions,omitempty"`
+	ProfileImage  *string  `json:"profileImage,omitempty"`
+	RealizedPnl   float64  `json:"realizedPnl"`
+	TotalPnl      float64  `json:"totalPnl"`
+	TotalTrades   *int     `json:"totalTrades,omitempty"`
+	UnrealizedPnl float64  `json:"unrealizedPnl"`
+	Username      string   `json:"username"`
+
+	// Verified Whether ownership of the account was proven through a claim
+	Verified *bool `json:"verified,omitempty"`
+
+	// Volume Value of all trades, in USDC
+	Volume  *float64 `json:"volume,omitempty"`
+	WinRate *float64 `json:"winRate,omitempty"`
+}
+
+// PersonaDetail defines model for PersonaDetail.
+type PersonaDetail struct {
+	DisplayName string `json:"displayName"`
+
+	// Identities Polymarket profile identity of each account, evidence linking the accounts
+	Identities    *[]AccountIdentity `json:"identities,omitempty"`
+	Image         *string            `json:"image,omitempty"`
+	OpenPositions *int               `json:"openPositions,omitempty"`
+	RealizedPnl   float64            `json:"realizedPnl"`
+	Slug          string             `json:"slug"`
+	Style         *PersonaStyle      `json:"style,omitempty"`
+	TotalPnl      float64            `json:"totalPnl"`
+	TotalTrades   *int               `json:"totalTrades,omitempty"`
+	UnrealizedPnl float64            `json:"unrealizedPnl"`
+	Usernames     []string           `json:"usernames"`
+
+	// VerifiedUsernames Accounts whose ownership was proven through a claim
+	VerifiedUsernames *[]string `json:"verifiedUsernames,omitempty"`
+
+	// Volume Value of all trades, in USDC
+	Volume  *float64 `json:"volume,omitempty"`
+	WinRate *float64 `json:"winRate,omitempty"`
+
+	// XUsernames Distinct X/Twitter handles linked from the persona's accounts
+	XUsernames *[]string `json:"xUsernames,omitempty"`
+}
+
+// PersonaLeaderboardEntry defines model for PersonaLeaderboardEntry.
+type PersonaLeaderboardEntry struct {
+	DisplayName   string    `json:"displayName"`
+	Image         *string   `json:"image,omitempty"`
+	OpenPositions *int      `json:"openPositions,omitempty"`
+	Rank          int       `json:"rank"`
+	RealizedPnl   float64   `json:"realizedPnl"`
+	Slug          string    `json:"slug"`
+	TotalPnl      float64   `json:"totalPnl"`
+	UnrealizedPnl float64   `json:"unrealizedPnl"`
+	Usernames     *[]string `json:"usernames,omitempty"`
+
+	// Volume Value of all trades, in USDC
+	Volume  *float64 `json:"volume,omitempty"`
+	WinRate *float64 `json:"winRate,omitempty"`
+}
+
+// PersonaOwnerDetail defines model for PersonaOwnerDetail.
+type PersonaOwnerDetail struct {
+	Accounts []OwnerAccount `json:"accounts"`
+	Slug     string         `json:"slug"`
+}
+
+// PersonaPosition defines model for PersonaPosition.
+type PersonaPosition struct {
+	AvgPrice float64 `json:"avgPrice"`
+
+	// Concentrated The market holds more than concentration.thresholdPercent of the user's open exposure
+	Concentrated *bool      `json:"concentrated,omitempty"`
+	ConditionId  *string    `json:"conditionId,omitempty"`
+	CurrentPrice float64    `json:"currentPrice"`
+	CurrentValue *float64   `json:"currentValue,omitempty"`
+	EndDate      *time.Time `json:"endDate,omitempty"`
+
+	// EntryTradeId ID of the trade that opened the holding
+	EntryTradeId *string `json:"entryTradeId,omitempty"`
+
+	// FirstEnteredAt When the holding was opened, by the buy that last took the account from no position in the outcome to one. Absent when the stored trade history doesn't reach that buy.
+	FirstEnteredAt *time.Time `json:"firstEnteredAt,omitempty"`
+	Id             string     `json:"id"`
+	InitialValue   *float64   `json:"initialValue,omitempty"`
+	MarketSlug     *string    `json:"marketSlug,omitempty"`
+	MarketTitle    string     `json:"marketTitle"`
+	Outcome        string     `json:"outcome"`
+
+	// PnlIfLoses PnL at resolution if the held outcome loses
+	PnlIfLoses *float64 `json:"pnlIfLoses,omitempty"`
+
+	// PnlIfWins PnL at resolution if the held outcome wins, paying out $1 a share
+	PnlIfWins *float64 `json:"pnlIfWins,omitempty"`
+
+	// PortfolioShare Share of the user's open exposure held in this position's market, both outcomes counted
+	PortfolioShare       *float64 `json:"portfolioShare,omitempty"`
+	Size                 float64  `json:"size"`
+	UnrealizedPnl        float64  `json:"unrealizedPnl"`
+	UnrealizedPnlPercent *float64 `json:"unrealizedPnlPercent,omitempty"`
+	Username             string   `json:"username"`
+}
+
+// PersonaResult defines model for PersonaResult.
+type PersonaResult struct {
+	ConditionId  string     `json:"conditionId"`
+	EndDate      *time.Time `json:"endDate,omitempty"`
+	Id           string     `json:"id"`
+	InitialValue *float64   `json:"initialValue,omitempty"`
+	MarketSlug   *string    `json:"marketSlug,omitempty"`
+	MarketTitle  string     `json:"marketTitle"`
+	Outcome      string     `json:"outcome"`
+
+	// PriceSeries Price of the held outcome from the first buy to resolution, oldest first, ending at the settlement price when known
+	PriceSeries    *[]PricePoint `json:"priceSeries,omitempty"`
+	RealizedPnl    float64       `json:"realizedPnl"`
+	ResolutionDate *time.Time    `json:"resolutionDate,omitempty"`
+
+	// SettledPnl Exact PnL at settlement, (settlementPrice - avgPrice) * size summed over held outcomes
+	SettledPnl *float64 `json:"settledPnl,omitempty"`
+
+	// SettlementPrice Final settlement price (0 or 1) of the held outcome, captured when the market resolved
+	SettlementPrice *float64 `json:"settlementPrice,omitempty"`
+	Username        string   `json:"username"`
 }
 
-// Get user's trade history
-// (GET /users/{username}/trades)
-func (_ Unimplemented) GetUserTrades(w http.ResponseWriter, r *http.Request, username string, params GetUserTradesParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// PersonaResultsResponse defines model for PersonaResultsResponse.
+type PersonaResultsResponse struct {
+	Limit   *int            `json:"limit,omitempty"`
+	Offset  *int            `json:"offset,omitempty"`
+	Results []PersonaResult `json:"results"`
+	Total   int             `json:"total"`
+}
+
+// PersonaStyle defines model for PersonaStyle.
+type PersonaStyle struct {
+	// AvgHoldHours Size-weighted average time from entry to exit or resolution
+	AvgHoldHours *float64 `json:"avgHoldHours,omitempty"`
+
+	// AvgPositionSize Average USDC cost of a position
+	AvgPositionSize float64 `json:"avgPositionSize"`
+
+	// EntryTiming How long before the market's end date positions are entered
+	EntryTiming *EntryTimingStats `json:"entryTiming,omitempty"`
+
+	// FavouriteCategory Market category with the most buy volume
+	FavouriteCategory *string `json:"favouriteCategory,omitempty"`
+
+	// HoldTime Hold times of FIFO-matched lots, from entry to sell or market resolution
+	HoldTime *HoldTimeStats `json:"holdTime,omitempty"`
+
+	// LongShotRatio Share of buys entered at a price of 0.2 or lower
+	LongShotRatio float64 `json:"longShotRatio"`
+
+	// Positions Distinct positions entered across all accounts
+	Positions int `json:"positions"`
+}
+
+// PersonaSummary defines model for PersonaSummary.
+type PersonaSummary struct {
+	DisplayName string   `json:"displayName"`
+	Image       *string  `json:"image,omitempty"`
+	Slug        string   `json:"slug"`
+	Usernames   []string `json:"usernames"`
+}
+
+// PersonaToken defines model for PersonaToken.
+type PersonaToken struct {
+	CreatedAt  time.Time  `json:"createdAt"`
+	Id         int64      `json:"id"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       string     `json:"name"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// PersonaTokenRequest defines model for PersonaTokenRequest.
+type PersonaTokenRequest struct {
+	// Name What the token is for, e.g. who it was given to
+	Name string `json:"name"`
+}
+
+// PersonaTokensResponse defines model for PersonaTokensResponse.
+type PersonaTokensResponse struct {
+	Tokens []PersonaToken `json:"tokens"`
+}
+
+// PnlBenchmark A benchmark at the timestamps of the data points, as its PnL change since the first one. Compare it against the change in the user's totalPnl over the same points.
+type PnlBenchmark struct {
+	DataPoints []BenchmarkDataPoint `json:"dataPoints"`
+
+	// Members Users averaged by the benchmark
+	Members int    `json:"members"`
+	Name    string `json:"name"`
+}
+
+// PnlDataPoint defines model for PnlDataPoint.
+type PnlDataPoint struct {
+	RealizedPnl   float64   `json:"realizedPnl"`
+	Timestamp     time.Time `json:"timestamp"`
+	TotalPnl      float64   `json:"totalPnl"`
+	UnrealizedPnl float64   `json:"unrealizedPnl"`
+}
+
+// PnlHistory defines model for PnlHistory.
+type PnlHistory struct {
+	// Benchmark A benchmark at the timestamps of the data points, as its PnL change since the first one. Compare it against the change in the user's totalPnl over the same points.
+	Benchmark  *PnlBenchmark  `json:"benchmark,omitempty"`
+	DataPoints []PnlDataPoint `json:"dataPoints"`
+
+	// OfficialDataPoints Official PnL scraped from Polymarket, recorded whenever it changed
+	OfficialDataPoints *[]OfficialPnlDataPoint `json:"officialDataPoints,omitempty"`
+	Username           string                  `json:"username"`
+}
+
+// Position defines model for Position.
+type Position struct {
+	AvgPrice float64 `json:"avgPrice"`
+
+	// Concentrated The market holds more than concentration.thresholdPercent of the user's open exposure
+	Concentrated *bool      `json:"concentrated,omitempty"`
+	ConditionId  *string    `json:"conditionId,omitempty"`
+	CurrentPrice float64    `json:"currentPrice"`
+	CurrentValue *float64   `json:"currentValue,omitempty"`
+	EndDate      *time.Time `json:"endDate,omitempty"`
+
+	// EntryTradeId ID of the trade that opened the holding
+	EntryTradeId *string `json:"entryTradeId,omitempty"`
+
+	// FirstEnteredAt When the holding was opened, by the buy that last took the user from no position in the outcome to one. Absent when the stored trade history doesn't reach that buy.
+	FirstEnteredAt *time.Time `json:"firstEnteredAt,omitempty"`
+	Id             string     `json:"id"`
+	InitialValue   *float64   `json:"initialValue,omitempty"`
+	MarketSlug     *string    `json:"marketSlug,omitempty"`
+	MarketTitle    string     `json:"marketTitle"`
+	Outcome        string     `json:"outcome"`
+
+	// PnlIfLoses PnL at resolution if the held outcome loses
+	PnlIfLoses *float64 `json:"pnlIfLoses,omitempty"`
+
+	// PnlIfWins PnL at resolution if the held outcome wins, paying out $1 a share
+	PnlIfWins *float64 `json:"pnlIfWins,omitempty"`
+
+	// PortfolioShare Share of the user's open exposure held in this position's market, both outcomes counted
+	PortfolioShare       *float64 `json:"portfolioShare,omitempty"`
+	Size                 float64  `json:"size"`
+	UnrealizedPnl        float64  `json:"unrealizedPnl"`
+	UnrealizedPnlPercent *float64 `json:"unrealizedPnlPercent,omitempty"`
+}
+
+// PricePoint defines model for PricePoint.
+type PricePoint struct {
+	Price     float64   `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Reaction defines model for Reaction.
+type Reaction struct {
+	// Author Display name of the API key that posted the reaction
+	Author string `json:"author"`
+
+	// Comment Set for comments
+	Comment     *string   `json:"comment,omitempty"`
+	ConditionId string    `json:"conditionId"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// Emoji Set for emoji reactions
+	Emoji *string `json:"emoji,omitempty"`
+	Id    int64   `json:"id"`
+
+	// TradeId Trade the reaction is on, unset for reactions on a result
+	TradeId *string `json:"tradeId,omitempty"`
+
+	// Username User whose trade or result the reaction is on
+	Username string `json:"username"`
+}
+
+// RecomputeJob defines model for RecomputeJob.
+type RecomputeJob struct {
+	// Badges Badges newly awarded
+	Badges     int        `json:"badges"`
+	Errors     []string   `json:"errors"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Id         string     `json:"id"`
+
+	// Milestones Milestones newly recorded
+	Milestones int `json:"milestones"`
+
+	// SnapshotsCreated PnL snapshots rebuilt from trades
+	SnapshotsCreated int       `json:"snapshotsCreated"`
+	StartedAt        time.Time `json:"startedAt"`
+
+	// Status Completed jobs may still have failed for some users, listed in errors
+	Status RecomputeJobStatus `json:"status"`
+
+	// TradesClassified Trades whose position change was updated
+	TradesClassified int `json:"tradesClassified"`
+
+	// Users Users to recompute
+	Users int `json:"users"`
+
+	// UsersDone Users recomputed so far, including those that failed
+	UsersDone int `json:"usersDone"`
+}
+
+// RecomputeJobStatus Completed jobs may still have failed for some users, listed in errors
+type RecomputeJobStatus string
+
+// Result defines model for Result.
+type Result struct {
+	ConditionId  string     `json:"conditionId"`
+	EndDate      *time.Time `json:"endDate,omitempty"`
+	Id           string     `json:"id"`
+	InitialValue *float64   `json:"initialValue,omitempty"`
+	MarketSlug   *string    `json:"marketSlug,omitempty"`
+	MarketTitle  string     `json:"marketTitle"`
+	Outcome      string     `json:"outcome"`
+
+	// PriceSeries Price of the held outcome from the first buy to resolution, oldest first, ending at the settlement price when known
+	PriceSeries    *[]PricePoint `json:"priceSeries,omitempty"`
+	RealizedPnl    float64       `json:"realizedPnl"`
+	ResolutionDate *time.Time    `json:"resolutionDate,omitempty"`
+
+	// SettledPnl Exact PnL at settlement, (settlementPrice - avgPrice) * size summed over held outcomes
+	SettledPnl *float64 `json:"settledPnl,omitempty"`
+
+	// SettlementPrice Final settlement price (0 or 1) of the held outcome, captured when the market resolved
+	SettlementPrice *float64 `json:"settlementPrice,omitempty"`
+}
+
+// ResultDetail defines model for ResultDetail.
+type ResultDetail struct {
+	ConditionId string      `json:"conditionId"`
+	Lots        []ResultLot `json:"lots"`
+	MarketSlug  *string     `json:"marketSlug,omitempty"`
+	MarketTitle string      `json:"marketTitle"`
+
+	// OpenShares Shares bought but neither sold nor settled yet
+	OpenShares float64 `json:"openShares"`
+
+	// Reactions Comments and emoji reactions on the result, oldest first. Only set when reactions are enabled.
+	Reactions *[]Reaction `json:"reactions,omitempty"`
+
+	// RealizedPnl Sum of the closed lots' PnL
+	RealizedPnl float64            `json:"realizedPnl"`
+	Settlements []ResultSettlement `json:"settlements"`
+
+	// Trades Trades in the market, oldest first
+	Trades []Trade `json:"trades"`
+}
+
+// ResultLot A FIFO lot, or part of one, matched against the sell or resolution that closed it
+type ResultLot struct {
+	// ClosePrice Sell price, or settlement price when closed by resolution
+	ClosePrice   float64    `json:"closePrice"`
+	CloseTradeId *string    `json:"closeTradeId,omitempty"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+
+	// ClosedBy sell or resolution
+	ClosedBy    string     `json:"closedBy"`
+	HoldHours   *float64   `json:"holdHours,omitempty"`
+	OpenPrice   float64    `json:"openPrice"`
+	OpenTradeId *string    `json:"openTradeId,omitempty"`
+	OpenedAt    *time.Time `json:"openedAt,omitempty"`
+	Outcome     string     `json:"outcome"`
+	Pnl         float64    `json:"pnl"`
+	Shares      float64    `json:"shares"`
+}
+
+// ResultSettlement A position held when the market resolved
+type ResultSettlement struct {
+	Address         string    `json:"address"`
+	AvgPrice        float64   `json:"avgPrice"`
+	Outcome         *string   `json:"outcome,omitempty"`
+	Pnl             float64   `json:"pnl"`
+	ResolvedAt      time.Time `json:"resolvedAt"`
+	SettlementPrice float64   `json:"settlementPrice"`
+	Size            float64   `json:"size"`
+}
+
+// ResultsResponse defines model for ResultsResponse.
+type ResultsResponse struct {
+	Limit   *int     `json:"limit,omitempty"`
+	Offset  *int     `json:"offset,omitempty"`
+	Results []Result `json:"results"`
+	Total   int      `json:"total"`
+}
+
+// RosterApplyResult defines model for RosterApplyResult.
+type RosterApplyResult struct {
+	Changes []RosterChange `json:"changes"`
+	DryRun  bool           `json:"dryRun"`
+	Prune   bool           `json:"prune"`
+}
+
+// RosterChange defines model for RosterChange.
+type RosterChange struct {
+	// Action create_persona, update_persona, delete_persona, create_user, move_user, delete_user, add_address, remove_address, set_ghost or set_group
+	Action string  `json:"action"`
+	Detail *string `json:"detail,omitempty"`
+
+	// Target Persona slug or username
+	Target string `json:"target"`
+}
+
+// RosterChangeLogEntry defines model for RosterChangeLogEntry.
+type RosterChangeLogEntry struct {
+	// Action One of the RosterChange actions
+	Action    string                     `json:"action"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	Detail    *string                    `json:"detail,omitempty"`
+	Id        string                     `json:"id"`
+	Source    RosterChangeLogEntrySource `json:"source"`
+
+	// Target Persona slug or username
+	Target string `json:"target"`
+}
+
+// RosterChangeLogEntrySource defines model for RosterChangeLogEntry.Source.
+type RosterChangeLogEntrySource string
+
+// RosterChangesResponse defines model for RosterChangesResponse.
+type RosterChangesResponse struct {
+	Changes []RosterChangeLogEntry `json:"changes"`
+	Limit   *int                   `json:"limit,omitempty"`
+	Offset  *int                   `json:"offset,omitempty"`
+	Total   int                    `json:"total"`
+}
+
+// SavedExport defines model for SavedExport.
+type SavedExport struct {
+	// Key Key of the file in the blob store
+	Key string `json:"key"`
+
+	// Location Where the blob store keeps the file, a path or URL
+	Location string `json:"location"`
+	Rows     int    `json:"rows"`
+
+	// Size File size in bytes
+	Size int64 `json:"size"`
+}
+
+// SyncCrashes Panics recovered during sync since startup. The sync loop restarts after each.
+type SyncCrashes struct {
+	Count       int        `json:"count"`
+	LastCrashAt *time.Time `json:"lastCrashAt,omitempty"`
+	LastError   *string    `json:"lastError,omitempty"`
+}
+
+// SyncError defines model for SyncError.
+type SyncError struct {
+	Address   *string   `json:"address,omitempty"`
+	Message   string    `json:"message"`
+	Phase     string    `json:"phase"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SyncRun defines model for SyncRun.
+type SyncRun struct {
+	// ApiCalls Polymarket API calls made
+	ApiCalls int `json:"apiCalls"`
+
+	// DeferredUsers Users left for the next run because the API call budget ran out
+	DeferredUsers int   `json:"deferredUsers"`
+	DurationMs    int64 `json:"durationMs"`
+
+	// Errors Sync errors recorded, listed per user by /sync/status
+	Errors     int       `json:"errors"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Id         int64     `json:"id"`
+
+	// RowsWritten Positions, trades, settlements and snapshots written
+	RowsWritten int            `json:"rowsWritten"`
+	StartedAt   time.Time      `json:"startedAt"`
+	Trigger     SyncRunTrigger `json:"trigger"`
+	UserRuns    []SyncRunUser  `json:"userRuns"`
+
+	// Users Users synced
+	Users int `json:"users"`
+}
+
+// SyncRunTrigger defines model for SyncRun.Trigger.
+type SyncRunTrigger string
+
+// SyncRunUser defines model for SyncRunUser.
+type SyncRunUser struct {
+	ApiCalls   int   `json:"apiCalls"`
+	DurationMs int64 `json:"durationMs"`
+	Errors     int   `json:"errors"`
+
+	// Failure Why the user's sync stopped early, if it did
+	Failure     *string   `json:"failure,omitempty"`
+	RowsWritten int       `json:"rowsWritten"`
+	StartedAt   time.Time `json:"startedAt"`
+	Username    string    `json:"username"`
+}
+
+// SyncStatus defines model for SyncStatus.
+type SyncStatus struct {
+	// Crashes Panics recovered during sync since startup. The sync loop restarts after each.
+	Crashes *SyncCrashes `json:"crashes,omitempty"`
+
+	// Leader Whether this instance performs sync. Other instances sharing the database only serve reads.
+	Leader *bool            `json:"leader,omitempty"`
+	Users  []UserSyncStatus `json:"users"`
+}
+
+// TimelineEntry defines model for TimelineEntry.
+type TimelineEntry struct {
+	Badge     *UserBadge        `json:"badge,omitempty"`
+	Milestone *UserMilestone    `json:"milestone,omitempty"`
+	Time      time.Time         `json:"time"`
+	Type      TimelineEntryType `json:"type"`
+}
+
+// TimelineEntryType defines model for TimelineEntry.Type.
+type TimelineEntryType string
+
+// Trade defines model for Trade.
+type Trade struct {
+	// Book The order book of the traded outcome shortly after the trade, the market consensus to judge the trade's price against. Only recorded for trades synced within sync.bookMaxAgeMinutes of being made.
+	Book               *TradeBook `json:"book,omitempty"`
+	ConditionId        *string    `json:"conditionId,omitempty"`
+	Id                 string     `json:"id"`
+	MarketSlug         *string    `json:"marketSlug,omitempty"`
+	MarketTitle        string     `json:"marketTitle"`
+	Outcome            string     `json:"outcome"`
+	PersonaDisplayName *string    `json:"personaDisplayName,omitempty"`
+	PersonaSlug        *string    `json:"personaSlug,omitempty"`
+
+	// PositionChange How the trade moved the user's position in its outcome. Unset for sells of positions bought before the tracked history.
+	PositionChange *TradePositionChange `json:"positionChange,omitempty"`
+	Price          float64              `json:"price"`
+	ProfileImage   *string              `json:"profileImage,omitempty"`
+
+	// Reactions Comments and emoji reactions on the trade, oldest first. Only set in feeds when reactions are enabled and the trade has any.
+	Reactions *[]Reaction `json:"reactions,omitempty"`
+	Side      TradeSide   `json:"side"`
+	Size      float64     `json:"size"`
+	Timestamp time.Time   `json:"timestamp"`
+	Username  *string     `json:"username,omitempty"`
+	Value     float64     `json:"value"`
+}
+
+// TradePositionChange How the trade moved the user's position in its outcome. Unset for sells of positions bought before the tracked history.
+type TradePositionChange string
+
+// TradeSide defines model for Trade.Side.
+type TradeSide string
+
+// TradeBackfillResult defines model for TradeBackfillResult.
+type TradeBackfillResult struct {
+	// Addresses Per address breakdown, empty for ghost users
+	Addresses     []AddressTradeBackfill `json:"addresses"`
+	TradesFetched int                    `json:"tradesFetched"`
+
+	// TradesInserted Trades that weren't stored before
+	TradesInserted int    `json:"tradesInserted"`
+	Username       string `json:"username"`
+}
+
+// TradeBook The order book of the traded outcome shortly after the trade, the market consensus to judge the trade's price against. Only recorded for trades synced within sync.bookMaxAgeMinutes of being made.
+type TradeBook struct {
+	BestAsk    *float64  `json:"bestAsk,omitempty"`
+	BestBid    *float64  `json:"bestBid,omitempty"`
+	CapturedAt time.Time `json:"capturedAt"`
+
+	// Midpoint Midpoint of the best bid and ask, the market's implied probability. Unset when a side of the book is empty.
+	Midpoint *float64 `json:"midpoint,omitempty"`
+}
+
+// TradeDiscrepancy defines model for TradeDiscrepancy.
+type TradeDiscrepancy struct {
+	Kind        TradeDiscrepancyKind `json:"kind"`
+	MarketTitle *string              `json:"marketTitle,omitempty"`
+
+	// Recomputed Price times size
+	Recomputed *float64   `json:"recomputed,omitempty"`
+	Timestamp  *time.Time `json:"timestamp,omitempty"`
+	TradeId    int64      `json:"tradeId"`
+	Value      *float64   `json:"value,omitempty"`
+}
+
+// TradeDiscrepancyKind defines model for TradeDiscrepancy.Kind.
+type TradeDiscrepancyKind string
+
+// TradeImportError defines model for TradeImportError.
+type TradeImportError struct {
+	Message string `json:"message"`
+
+	// Row Line of the file the row starts on, the header being line 1
+	Row int `json:"row"`
+}
+
+// TradeImportReport defines model for TradeImportReport.
+type TradeImportReport struct {
+	DryRun bool `json:"dryRun"`
+
+	// Duplicates Valid rows skipped because the trade is already stored or repeated in the file
+	Duplicates int `json:"duplicates"`
+
+	// Errors Why rows failed validation, the first 100 only
+	Errors []TradeImportError `json:"errors"`
+
+	// Imported Trades inserted, or that would be on a dry run
+	Imported int `json:"imported"`
+
+	// Invalid Rows skipped because they failed validation
+	Invalid int `json:"invalid"`
+
+	// Rows Data rows in the file
+	Rows int `json:"rows"`
+
+	// Skipped Rows of a Polymarket export that aren't trades, such as deposits and redemptions
+	Skipped  int    `json:"skipped"`
+	Username string `json:"username"`
+}
+
+// TradeReconciliation Stored trade values checked against price times size
+type TradeReconciliation struct {
+	Checked       int                `json:"checked"`
+	Discrepancies []TradeDiscrepancy `json:"discrepancies"`
+}
+
+// TradesResponse defines model for TradesResponse.
+type TradesResponse struct {
+	Limit *int `json:"limit,omitempty"`
+
+	// Markets Trades grouped by market, set instead of trades when groupBy is market. total then counts markets.
+	Markets *[]MarketTradeGroup `json:"markets,omitempty"`
+	Offset  *int                `json:"offset,omitempty"`
+	Total   int                 `json:"total"`
+	Trades  []Trade             `json:"trades"`
+}
+
+// UpdatePersonaRequest defines model for UpdatePersonaRequest.
+type UpdatePersonaRequest struct {
+	DisplayName *string `json:"displayName,omitempty"`
+	Image       *string `json:"image,omitempty"`
+}
+
+// UpdateUserRequest defines model for UpdateUserRequest.
+type UpdateUserRequest struct {
+	AddAddresses *[]string `json:"addAddresses,omitempty"`
+	Ghost        *bool     `json:"ghost,omitempty"`
+
+	// Persona Slug of the persona to move the user to, empty to remove them from theirs
+	Persona *string `json:"persona,omitempty"`
+
+	// RemoveAddresses Addresses to stop syncing; their positions are deleted, their trades kept
+	RemoveAddresses *[]string `json:"removeAddresses,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// Addresses Empty for ghost users
+	Addresses []string `json:"addresses"`
+
+	// Ghost Only shown to admin keys
+	Ghost        *bool             `json:"ghost,omitempty"`
+	LastSynced   *time.Time        `json:"lastSynced,omitempty"`
+	ProfileImage *string           `json:"profileImage,omitempty"`
+	Stats        *UserSummaryStats `json:"stats,omitempty"`
+	Username     string            `json:"username"`
+}
+
+// UserBadge defines model for UserBadge.
+type UserBadge struct {
+	// AwardedAt When the achievement was reached
+	AwardedAt   time.Time `json:"awardedAt"`
+	Description string    `json:"description"`
+
+	// Detail What earned the badge, e.g. the market of a big win
+	Detail *string `json:"detail,omitempty"`
+
+	// Id Badge identifier, one of big_win, win_streak, drawdown_survivor, daily_trader
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserDetail defines model for UserDetail.
+type UserDetail struct {
+	// Addresses Empty for ghost users
+	Addresses []string       `json:"addresses"`
+	Edge      *UserEdgeStats `json:"edge,omitempty"`
+
+	// EntryTiming How long before the market's end date positions are entered
+	EntryTiming *EntryTimingStats `json:"entryTiming,omitempty"`
+
+	// HoldTime Hold times of FIFO-matched lots, from entry to sell or market resolution
+	HoldTime *HoldTimeStats `json:"holdTime,omitempty"`
+
+	// IsActive Traded within the last presence.activeMinutes
+	IsActive      bool       `json:"isActive"`
+	LastSynced    *time.Time `json:"lastSynced,omitempty"`
+	LastTradeAt   *time.Time `json:"lastTradeAt,omitempty"`
+	OpenPositions *int       `json:"openPositions,omitempty"`
+	ProfileImage  *string    `json:"profileImage,omitempty"`
+	RealizedPnl   float64    `json:"realizedPnl"`
+	TotalPnl      float64    `json:"totalPnl"`
+	TotalTrades   *int       `json:"totalTrades,omitempty"`
+	UnrealizedPnl float64    `json:"unrealizedPnl"`
+	Username      string     `json:"username"`
+
+	// Verified Whether ownership of the account was proven through a claim
+	Verified   *bool      `json:"verified,omitempty"`
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+	Volume     *float64   `json:"volume,omitempty"`
+	WinRate    *float64   `json:"winRate,omitempty"`
+}
+
+// UserEdgeStats defines model for UserEdgeStats.
+type UserEdgeStats struct {
+	Categories []EdgeStats `json:"categories"`
+
+	// Overall Entry price statistics over resolved positions. Entry price is the implied probability paid,
+	// so edge is the realized win rate minus the average entry price.
+	Overall EdgeStats `json:"overall"`
+}
+
+// UserMilestone defines model for UserMilestone.
+type UserMilestone struct {
+	// Detail The days and PnL of a winning streak
+	Detail *string `json:"detail,omitempty"`
+
+	// Kind One of all_time_high, round_number, winning_streak
+	Kind       string    `json:"kind"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Title      string    `json:"title"`
+
+	// Value The PnL of an all-time high, the round number crossed, or the streak length in days
+	Value float64 `json:"value"`
+}
+
+// UserSummaryStats defines model for UserSummaryStats.
+type UserSummaryStats struct {
+	OpenPositions int     `json:"openPositions"`
+	RealizedPnl   float64 `json:"realizedPnl"`
+	TotalPnl      float64 `json:"totalPnl"`
+	TotalTrades   int     `json:"totalTrades"`
+	UnrealizedPnl float64 `json:"unrealizedPnl"`
+	Volume        float64 `json:"volume"`
+	WinRate       float64 `json:"winRate"`
+}
+
+// UserSyncStatus defines model for UserSyncStatus.
+type UserSyncStatus struct {
+	Errors     []SyncError `json:"errors"`
+	LastSynced *time.Time  `json:"lastSynced,omitempty"`
+
+	// RemovedTrades Stored trades flagged as no longer returned by Polymarket
+	RemovedTrades *int   `json:"removedTrades,omitempty"`
+	Username      string `json:"username"`
+}
+
+// UsersResponse defines model for UsersResponse.
+type UsersResponse struct {
+	Limit  *int   `json:"limit,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+	Total  int    `json:"total"`
+	Users  []User `json:"users"`
+}
+
+// ValidateConfigParams defines parameters for ValidateConfig.
+type ValidateConfigParams struct {
+	// Prune List the deletions of users, personas and addresses not in the roster too
+	Prune *bool `form:"prune,omitempty" json:"prune,omitempty"`
+}
+
+// ApplyRosterParams defines parameters for ApplyRoster.
+type ApplyRosterParams struct {
+	// Prune Also delete users, personas and addresses that are not in the roster
+	Prune *bool `form:"prune,omitempty" json:"prune,omitempty"`
+
+	// DryRun Report the changes without making them
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// GetEventStreamParams defines parameters for GetEventStream.
+type GetEventStreamParams struct {
+	// Username Only stream events of these users, comma separated
+	Username *string `form:"username,omitempty" json:"username,omitempty"`
+
+	// Persona Only stream events of these personas' users, comma separated
+	Persona *string `form:"persona,omitempty" json:"persona,omitempty"`
+}
+
+// GetFeedStreamParams defines parameters for GetFeedStream.
+type GetFeedStreamParams struct {
+	// Topics Topics to subscribe to on connect, comma separated
+	Topics *string `form:"topics,omitempty" json:"topics,omitempty"`
+}
+
+// GetGlobalLeaderboardParams defines parameters for GetGlobalLeaderboard.
+type GetGlobalLeaderboardParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetGroupEquityParams defines parameters for GetGroupEquity.
+type GetGroupEquityParams struct {
+	// Persona Restrict the group to the accounts of a single persona
+	Persona *string    `form:"persona,omitempty" json:"persona,omitempty"`
+	Start   *time.Time `form:"start,omitempty" json:"start,omitempty"`
+	End     *time.Time `form:"end,omitempty" json:"end,omitempty"`
+
+	// Netting Net each user's positions per market, so shares held on both outcomes count as locked in rather than as exposure. Affects open positions, open position value and unrealized PnL, not the PnL history.
+	Netting *bool `form:"netting,omitempty" json:"netting,omitempty"`
+}
+
+// GetLeaderboardParams defines parameters for GetLeaderboard.
+type GetLeaderboardParams struct {
+	// SortBy One of totalPnl, realizedPnl, unrealizedPnl, winRate, volume, or the name of a custom score from the leaderboard.scores config
+	SortBy        *string                            `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetLeaderboardParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+}
+
+// GetLeaderboardParamsSortDirection defines parameters for GetLeaderboard.
+type GetLeaderboardParamsSortDirection string
+
+// SearchMarketsParams defines parameters for SearchMarkets.
+type SearchMarketsParams struct {
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPersonaLeaderboardParams defines parameters for GetPersonaLeaderboard.
+type GetPersonaLeaderboardParams struct {
+	SortBy        *GetPersonaLeaderboardParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetPersonaLeaderboardParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+}
+
+// GetPersonaLeaderboardParamsSortBy defines parameters for GetPersonaLeaderboard.
+type GetPersonaLeaderboardParamsSortBy string
+
+// GetPersonaLeaderboardParamsSortDirection defines parameters for GetPersonaLeaderboard.
+type GetPersonaLeaderboardParamsSortDirection string
+
+// GetPersonaAvatarParams defines parameters for GetPersonaAvatar.
+type GetPersonaAvatarParams struct {
+	// Size Largest width or height in pixels, rounded up to 32, 64, 128 or 256. Omit for the original size.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// ExportPersonaTaxesParams defines parameters for ExportPersonaTaxes.
+type ExportPersonaTaxesParams struct {
+	// Year Only results resolved in this calendar year (UTC)
+	Year *int `form:"year,omitempty" json:"year,omitempty"`
+}
+
+// GetPersonaPositionsParams defines parameters for GetPersonaPositions.
+type GetPersonaPositionsParams struct {
+	SortBy        *GetPersonaPositionsParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetPersonaPositionsParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+
+	// IncludeDust Include dust positions, worth less than the configured dust value
+	IncludeDust *bool `form:"includeDust,omitempty" json:"includeDust,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetPersonaPositionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetPersonaPositionsParamsSortBy defines parameters for GetPersonaPositions.
+type GetPersonaPositionsParamsSortBy string
+
+// GetPersonaPositionsParamsSortDirection defines parameters for GetPersonaPositions.
+type GetPersonaPositionsParamsSortDirection string
+
+// GetPersonaPositionsParamsFormat defines parameters for GetPersonaPositions.
+type GetPersonaPositionsParamsFormat string
+
+// GetPersonaNetPositionsParams defines parameters for GetPersonaNetPositions.
+type GetPersonaNetPositionsParams struct {
+	// IncludeDust Include dust positions, worth less than the configured dust value
+	IncludeDust *bool `form:"includeDust,omitempty" json:"includeDust,omitempty"`
+}
+
+// GetPersonaResultsParams defines parameters for GetPersonaResults.
+type GetPersonaResultsParams struct {
+	Limit         *int                                  `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset        *int                                  `form:"offset,omitempty" json:"offset,omitempty"`
+	SortBy        *GetPersonaResultsParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetPersonaResultsParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetPersonaResultsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetPersonaResultsParamsSortBy defines parameters for GetPersonaResults.
+type GetPersonaResultsParamsSortBy string
+
+// GetPersonaResultsParamsSortDirection defines parameters for GetPersonaResults.
+type GetPersonaResultsParamsSortDirection string
+
+// GetPersonaResultsParamsFormat defines parameters for GetPersonaResults.
+type GetPersonaResultsParamsFormat string
+
+// GetPersonaTradesParams defines parameters for GetPersonaTrades.
+type GetPersonaTradesParams struct {
+	Limit         *int                                 `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset        *int                                 `form:"offset,omitempty" json:"offset,omitempty"`
+	SortBy        *GetPersonaTradesParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetPersonaTradesParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+
+	// MinValue Minimum trade notional in dollars
+	MinValue *float64 `form:"minValue,omitempty" json:"minValue,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetPersonaTradesParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetPersonaTradesParamsSortBy defines parameters for GetPersonaTrades.
+type GetPersonaTradesParamsSortBy string
+
+// GetPersonaTradesParamsSortDirection defines parameters for GetPersonaTrades.
+type GetPersonaTradesParamsSortDirection string
+
+// GetPersonaTradesParamsFormat defines parameters for GetPersonaTrades.
+type GetPersonaTradesParamsFormat string
+
+// GetRosterChangesParams defines parameters for GetRosterChanges.
+type GetRosterChangesParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetSentimentParams defines parameters for GetSentiment.
+type GetSentimentParams struct {
+	SortBy        *GetSentimentParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetSentimentParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+	Limit         *int                             `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSentimentParamsSortBy defines parameters for GetSentiment.
+type GetSentimentParamsSortBy string
+
+// GetSentimentParamsSortDirection defines parameters for GetSentiment.
+type GetSentimentParamsSortDirection string
+
+// GetSyncRunsParams defines parameters for GetSyncRuns.
+type GetSyncRunsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSyncStatusParams defines parameters for GetSyncStatus.
+type GetSyncStatusParams struct {
+	Username   *string `form:"username,omitempty" json:"username,omitempty"`
+	ErrorLimit *int    `form:"errorLimit,omitempty" json:"errorLimit,omitempty"`
+}
+
+// GetTradesParams defines parameters for GetTrades.
+type GetTradesParams struct {
+	Limit    *int                 `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset   *int                 `form:"offset,omitempty" json:"offset,omitempty"`
+	Username *string              `form:"username,omitempty" json:"username,omitempty"`
+	Side     *GetTradesParamsSide `form:"side,omitempty" json:"side,omitempty"`
+
+	// MinValue Minimum trade value, overrides the minValue mute rule
+	MinValue      *float64                      `form:"minValue,omitempty" json:"minValue,omitempty"`
+	SortBy        *GetTradesParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetTradesParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+
+	// Mute Apply the feed mute rules, set to false to see every trade
+	Mute *bool `form:"mute,omitempty" json:"mute,omitempty"`
+
+	// MuteUsers Usernames to hide, replaces the user mute rules
+	MuteUsers *[]string `form:"muteUsers,omitempty" json:"muteUsers,omitempty"`
+
+	// MuteCategories Market categories to hide, replaces the category mute rules
+	MuteCategories *[]string `form:"muteCategories,omitempty" json:"muteCategories,omitempty"`
+
+	// Start Only trades at or after this time. Defaults to 24 hours ago when grouping by market.
+	Start *time.Time `form:"start,omitempty" json:"start,omitempty"`
+
+	// End Only trades before this time
+	End *time.Time `form:"end,omitempty" json:"end,omitempty"`
+
+	// GroupBy Collapse the trades into one entry per market, returned in markets instead of trades. Markets sort by volume unless sortBy is given, where timestamp sorts by the latest trade, value by volume and size by shares traded. limit and offset page the markets.
+	GroupBy *GetTradesParamsGroupBy `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+
+	// Format csv returns the same trades as a CSV attachment, for spreadsheets. Can't be combined with groupBy.
+	Format *GetTradesParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetTradesParamsSide defines parameters for GetTrades.
+type GetTradesParamsSide string
+
+// GetTradesParamsSortBy defines parameters for GetTrades.
+type GetTradesParamsSortBy string
+
+// GetTradesParamsSortDirection defines parameters for GetTrades.
+type GetTradesParamsSortDirection string
+
+// GetTradesParamsGroupBy defines parameters for GetTrades.
+type GetTradesParamsGroupBy string
+
+// GetTradesParamsFormat defines parameters for GetTrades.
+type GetTradesParamsFormat string
+
+// ExportTradesParams defines parameters for ExportTrades.
+type ExportTradesParams struct {
+	Format        *ExportTradesParamsFormat        `form:"format,omitempty" json:"format,omitempty"`
+	Username      *string                          `form:"username,omitempty" json:"username,omitempty"`
+	Side          *ExportTradesParamsSide          `form:"side,omitempty" json:"side,omitempty"`
+	MinValue      *float64                         `form:"minValue,omitempty" json:"minValue,omitempty"`
+	SortBy        *ExportTradesParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *ExportTradesParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+}
+
+// ExportTradesParamsFormat defines parameters for ExportTrades.
+type ExportTradesParamsFormat string
+
+// ExportTradesParamsSide defines parameters for ExportTrades.
+type ExportTradesParamsSide string
+
+// ExportTradesParamsSortBy defines parameters for ExportTrades.
+type ExportTradesParamsSortBy string
+
+// ExportTradesParamsSortDirection defines parameters for ExportTrades.
+type ExportTradesParamsSortDirection string
+
+// SaveTradeExportParams defines parameters for SaveTradeExport.
+type SaveTradeExportParams struct {
+	Format        *SaveTradeExportParamsFormat        `form:"format,omitempty" json:"format,omitempty"`
+	Username      *string                             `form:"username,omitempty" json:"username,omitempty"`
+	Side          *SaveTradeExportParamsSide          `form:"side,omitempty" json:"side,omitempty"`
+	MinValue      *float64                            `form:"minValue,omitempty" json:"minValue,omitempty"`
+	SortBy        *SaveTradeExportParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *SaveTradeExportParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+}
+
+// SaveTradeExportParamsFormat defines parameters for SaveTradeExport.
+type SaveTradeExportParamsFormat string
+
+// SaveTradeExportParamsSide defines parameters for SaveTradeExport.
+type SaveTradeExportParamsSide string
+
+// SaveTradeExportParamsSortBy defines parameters for SaveTradeExport.
+type SaveTradeExportParamsSortBy string
+
+// SaveTradeExportParamsSortDirection defines parameters for SaveTradeExport.
+type SaveTradeExportParamsSortDirection string
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Limit 0 returns every user. v1 returns every user without a limit.
+	Limit         *int                         `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset        *int                         `form:"offset,omitempty" json:"offset,omitempty"`
+	SortBy        *GetUsersParamsSortBy        `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+	SortDirection *GetUsersParamsSortDirection `form:"sortDirection,omitempty" json:"sortDirection,omitempty"`
+	IncludeStats  *bool                        `form:"includeStats,omitempty" json:"includeStats,omitempty"`
+}
+
+// GetUsersParamsSortBy defines parameters for GetUsers.
+type GetUsersParamsSortBy string
+
+// GetUsersParamsSortDirection defines parameters for GetUsers.
+type GetUsersParamsSortDirection string
+
+// GetUserAvatarParams defines parameters for GetUserAvatar.
+type GetUserAvatarParams struct {
+	// Size Largest width or height in pixels, rounded up to 32, 64, 128 or 256. Omit for the original size.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// BackfillUserPnlParams defines parameters for BackfillUserPnl.
+type BackfillUserPnlParams struct {
+	// Replace Delete all existing snapshots, including synced ones, before writing the reconstructed history
+	Replace *bool `form:"replace,omitempty" json:"replace,omitempty"`
+}
+
+// GetUserCopySimulationParams defines parameters for GetUserCopySimulation.
+type GetUserCopySimulationParams struct {
+	Capital *float64   `form:"capital,omitempty" json:"capital,omitempty"`
+	Start   *time.Time `form:"start,omitempty" json:"start,omitempty"`
+}
+
+// GetUserPnlParams defines parameters for GetUserPnl.
+type GetUserPnlParams struct {
+	Start *time.Time `form:"start,omitempty" json:"start,omitempty"`
+	End   *time.Time `form:"end,omitempty" json:"end,omitempty"`
+
+	// Benchmark Include a benchmark series: usdc (holding USDC, flat at zero), tracked (the average of the other tracked users), or the name of a benchmark from the benchmarks config
+	Benchmark *string `form:"benchmark,omitempty" json:"benchmark,omitempty"`
+
+	// Format csv returns a CSV attachment with a row per data point of each series
+	Format *GetUserPnlParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetUserPnlParamsFormat defines parameters for GetUserPnl.
+type GetUserPnlParamsFormat string
+
+// GetUserPositionsParams defines parameters for GetUserPositions.
+type GetUserPositionsParams struct {
+	// IncludeDust Include dust positions, worth less than the configured dust value
+	IncludeDust *bool `form:"includeDust,omitempty" json:"includeDust,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetUserPositionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetUserPositionsParamsFormat defines parameters for GetUserPositions.
+type GetUserPositionsParamsFormat string
+
+// GetUserNetPositionsParams defines parameters for GetUserNetPositions.
+type GetUserNetPositionsParams struct {
+	// IncludeDust Include dust positions, worth less than the configured dust value
+	IncludeDust *bool `form:"includeDust,omitempty" json:"includeDust,omitempty"`
+}
+
+// GetUserResultsParams defines parameters for GetUserResults.
+type GetUserResultsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetUserResultsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetUserResultsParamsFormat defines parameters for GetUserResults.
+type GetUserResultsParamsFormat string
+
+// GetUserTimelineParams defines parameters for GetUserTimeline.
+type GetUserTimelineParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUserTradesParams defines parameters for GetUserTrades.
+type GetUserTradesParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// MinValue Minimum trade notional in dollars
+	MinValue *float64 `form:"minValue,omitempty" json:"minValue,omitempty"`
+
+	// Format csv returns the same rows as a CSV attachment, for spreadsheets
+	Format *GetUserTradesParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetUserTradesParamsFormat defines parameters for GetUserTrades.
+type GetUserTradesParamsFormat string
+
+// ImportUserTradesParams defines parameters for ImportUserTrades.
+type ImportUserTradesParams struct {
+	// Format columns for a CSV mapped by the tradeImport.columns config, polymarket for Polymarket's account history export
+	Format *ImportUserTradesParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Address The user's address a Polymarket export belongs to, required for users with more than one address
+	Address *string `form:"address,omitempty" json:"address,omitempty"`
+
+	// DryRun Validate the file and report what would be imported without importing it
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ImportUserTradesParamsFormat defines parameters for ImportUserTrades.
+type ImportUserTradesParamsFormat string
+
+// CreateApiKeyJSONRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody = ApiKeyRequest
+
+// PreviewNotificationJSONRequestBody defines body for PreviewNotification for application/json ContentType.
+type PreviewNotificationJSONRequestBody = NotificationPreviewRequest
+
+// CreatePersonaTokenJSONRequestBody defines body for CreatePersonaToken for application/json ContentType.
+type CreatePersonaTokenJSONRequestBody = PersonaTokenRequest
+
+// SetFeedMuteRulesJSONRequestBody defines body for SetFeedMuteRules for application/json ContentType.
+type SetFeedMuteRulesJSONRequestBody = MuteRules
+
+// CreatePersonaJSONRequestBody defines body for CreatePersona for application/json ContentType.
+type CreatePersonaJSONRequestBody = CreatePersonaRequest
+
+// UpdatePersonaJSONRequestBody defines body for UpdatePersona for application/json ContentType.
+type UpdatePersonaJSONRequestBody = UpdatePersonaRequest
+
+// AddTradeReactionJSONRequestBody defines body for AddTradeReaction for application/json ContentType.
+type AddTradeReactionJSONRequestBody = NewReaction
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = CreateUserRequest
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UpdateUserRequest
+
+// SetUserGhostJSONRequestBody defines body for SetUserGhost for application/json ContentType.
+type SetUserGhostJSONRequestBody = GhostModeRequest
+
+// SetUserAddressGroupJSONRequestBody defines body for SetUserAddressGroup for application/json ContentType.
+type SetUserAddressGroupJSONRequestBody = AddressGroupRequest
+
+// AddResultReactionJSONRequestBody defines body for AddResultReaction for application/json ContentType.
+type AddResultReactionJSONRequestBody = NewReaction
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List the API keys issued through the admin API, revoked ones included
+	// (GET /admin/api-keys)
+	GetApiKeys(w http.ResponseWriter, r *http.Request)
+	// Issue an API key
+	// (POST /admin/api-keys)
+	CreateApiKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key issued through the admin API
+	// (DELETE /admin/api-keys/{id})
+	RevokeApiKey(w http.ResponseWriter, r *http.Request, id int64)
+	// Get the running configuration, with secrets redacted
+	// (GET /admin/config)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Check an uploaded config file without applying it
+	// (POST /admin/config/validate)
+	ValidateConfig(w http.ResponseWriter, r *http.Request, params ValidateConfigParams)
+	// Lay out a sample notification as a channel would, optionally posting it
+	// (POST /admin/notifications/preview)
+	PreviewNotification(w http.ResponseWriter, r *http.Request)
+	// List the tokens issued for a persona, revoked ones included
+	// (GET /admin/personas/{slug}/tokens)
+	GetPersonaTokens(w http.ResponseWriter, r *http.Request, slug string)
+	// Issue a token for a persona's owner
+	// (POST /admin/personas/{slug}/tokens)
+	CreatePersonaToken(w http.ResponseWriter, r *http.Request, slug string)
+	// Revoke a persona token
+	// (DELETE /admin/personas/{slug}/tokens/{id})
+	RevokePersonaToken(w http.ResponseWriter, r *http.Request, slug string, id int64)
+	// Recompute all derived stats in the background
+	// (POST /admin/recompute)
+	StartRecompute(w http.ResponseWriter, r *http.Request)
+	// Get the progress of a recompute job
+	// (GET /admin/recompute/{id})
+	GetRecomputeJob(w http.ResponseWriter, r *http.Request, id string)
+	// Export tracked users and personas in the config.yaml schema
+	// (GET /admin/roster)
+	ExportRoster(w http.ResponseWriter, r *http.Request)
+	// Apply an uploaded roster, creating and updating users and personas to match it
+	// (PUT /admin/roster)
+	ApplyRoster(w http.ResponseWriter, r *http.Request, params ApplyRosterParams)
+	// Stream live events as Server-Sent Events
+	// (GET /events)
+	GetEventStream(w http.ResponseWriter, r *http.Request, params GetEventStreamParams)
+	// Rank tracked users by PnL within a single event
+	// (GET /events/{slug}/leaderboard)
+	GetEventLeaderboard(w http.ResponseWriter, r *http.Request, slug string)
+	// Get the mute rules applied to the trade feed
+	// (GET /feed/mute)
+	GetFeedMuteRules(w http.ResponseWriter, r *http.Request)
+	// Replace the mute rules defined through the API
+	// (PUT /feed/mute)
+	SetFeedMuteRules(w http.ResponseWriter, r *http.Request)
+	// Stream live updates over a WebSocket
+	// (GET /feed/stream)
+	GetFeedStream(w http.ResponseWriter, r *http.Request, params GetFeedStreamParams)
+	// Get Polymarket's global PnL leaderboard and where tracked users rank on it
+	// (GET /global-leaderboard)
+	GetGlobalLeaderboard(w http.ResponseWriter, r *http.Request, params GetGlobalLeaderboardParams)
+	// Get combined open exposure, PnL and PnL history across all tracked users or a persona
+	// (GET /group/equity)
+	GetGroupEquity(w http.ResponseWriter, r *http.Request, params GetGroupEquityParams)
+	// Get leaderboard of all users
+	// (GET /leaderboard)
+	GetLeaderboard(w http.ResponseWriter, r *http.Request, params GetLeaderboardParams)
+	// Get the most degenerate users (highest all-time volume)
+	// (GET /leaderboard/volume)
+	GetVolumeLeaderboard(w http.ResponseWriter, r *http.Request)
+	// Search markets by title with tracked-user holder counts and side lean
+	// (GET /markets/search)
+	SearchMarkets(w http.ResponseWriter, r *http.Request, params SearchMarketsParams)
+	// Get the canonical metadata of a traded market, cached from the Gamma API
+	// (GET /markets/{conditionId})
+	GetMarket(w http.ResponseWriter, r *http.Request, conditionId string)
+	// Get all personas (real people mapped to usernames)
+	// (GET /personas)
+	GetPersonas(w http.ResponseWriter, r *http.Request)
+	// Create a persona
+	// (POST /personas)
+	CreatePersona(w http.ResponseWriter, r *http.Request)
+	// Get leaderboard by persona (aggregated stats)
+	// (GET /personas/leaderboard)
+	GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request, params GetPersonaLeaderboardParams)
+	// Delete a persona, keeping its accounts tracked without one
+	// (DELETE /personas/{slug})
+	DeletePersona(w http.ResponseWriter, r *http.Request, slug string)
+	// Get persona details with aggregated stats
+	// (GET /personas/{slug})
+	GetPersona(w http.ResponseWriter, r *http.Request, slug string)
+	// Change a persona's display name or image
+	// (PATCH /personas/{slug})
+	UpdatePersona(w http.ResponseWriter, r *http.Request, slug string)
+	// Get all accounts for a persona with individual stats
+	// (GET /personas/{slug}/accounts)
+	GetPersonaAccounts(w http.ResponseWriter, r *http.Request, slug string)
+	// Get a persona's image through the caching image proxy
+	// (GET /personas/{slug}/avatar)
+	GetPersonaAvatar(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaAvatarParams)
+	// Get a banner compositing the avatars of a persona's accounts
+	// (GET /personas/{slug}/banner)
+	GetPersonaBanner(w http.ResponseWriter, r *http.Request, slug string)
+	// Get the open exposure and PnL at resolution across all accounts for a persona
+	// (GET /personas/{slug}/exposure)
+	GetPersonaExposure(w http.ResponseWriter, r *http.Request, slug string)
+	// Get details about a persona's accounts that the public API hides
+	// (GET /personas/{slug}/owner)
+	GetPersonaOwnerDetail(w http.ResponseWriter, r *http.Request, slug string)
+	// Export a persona's resolved results as CSV for tax reporting
+	// (GET /personas/{slug}/owner/tax-export)
+	ExportPersonaTaxes(w http.ResponseWriter, r *http.Request, slug string, params ExportPersonaTaxesParams)
+	// Get combined positions across all accounts for a persona
+	// (GET /personas/{slug}/positions)
+	GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaPositionsParams)
+	// Get positions across all accounts for a persona netted per market
+	// (GET /personas/{slug}/positions/net)
+	GetPersonaNetPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaNetPositionsParams)
+	// Get combined resolved positions (results) across all accounts for a persona
+	// (GET /personas/{slug}/results)
+	GetPersonaResults(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaResultsParams)
+	// Get combined trades across all accounts for a persona
+	// (GET /personas/{slug}/trades)
+	GetPersonaTrades(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaTradesParams)
+	// Detach a user from a persona, keeping them tracked without one
+	// (DELETE /personas/{slug}/users/{username})
+	DetachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string)
+	// Attach a tracked user to a persona, moving them from any other
+	// (PUT /personas/{slug}/users/{username})
+	AttachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string)
+	// Get the PnL of any Polymarket address
+	// (GET /public/address/{address}/pnl)
+	GetPublicAddressPnl(w http.ResponseWriter, r *http.Request, address string)
+	// Delete a reaction posted with the same API key's display name
+	// (DELETE /reactions/{id})
+	DeleteReaction(w http.ResponseWriter, r *http.Request, id int64)
+	// Get the log of changes to tracked users and personas, newest first
+	// (GET /roster/changes)
+	GetRosterChanges(w http.ResponseWriter, r *http.Request, params GetRosterChangesParams)
+	// Get group sentiment aggregated from open positions across all tracked users
+	// (GET /sentiment)
+	GetSentiment(w http.ResponseWriter, r *http.Request, params GetSentimentParams)
+	// Trigger a sync of all user data
+	// (POST /sync)
+	TriggerSync(w http.ResponseWriter, r *http.Request)
+	// Get recent sync runs with their durations, API calls and rows written
+	// (GET /sync/runs)
+	GetSyncRuns(w http.ResponseWriter, r *http.Request, params GetSyncRunsParams)
+	// Get per-user sync status with recent sync errors
+	// (GET /sync/status)
+	GetSyncStatus(w http.ResponseWriter, r *http.Request, params GetSyncStatusParams)
+	// Get all recent trades with filtering
+	// (GET /trades)
+	GetTrades(w http.ResponseWriter, r *http.Request, params GetTradesParams)
+	// Stream all trades matching the filters as CSV or newline-delimited JSON
+	// (GET /trades/export)
+	ExportTrades(w http.ResponseWriter, r *http.Request, params ExportTradesParams)
+	// Write all trades matching the filters to the blob store as CSV or newline-delimited JSON
+	// (POST /trades/export)
+	SaveTradeExport(w http.ResponseWriter, r *http.Request, params SaveTradeExportParams)
+	// Get the comments and emoji reactions posted on a trade
+	// (GET /trades/{tradeId}/reactions)
+	GetTradeReactions(w http.ResponseWriter, r *http.Request, tradeId string)
+	// Post a comment or emoji reaction on a trade
+	// (POST /trades/{tradeId}/reactions)
+	AddTradeReaction(w http.ResponseWriter, r *http.Request, tradeId string)
+	// Get tracked users with paging and optional summary stats
+	// (GET /users)
+	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+	// Start tracking a user
+	// (POST /users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Stop tracking a user, deleting their stored data
+	// (DELETE /users/{username})
+	DeleteUser(w http.ResponseWriter, r *http.Request, username string)
+	// Get user details
+	// (GET /users/{username})
+	GetUser(w http.ResponseWriter, r *http.Request, username string)
+	// Change a tracked user's addresses, persona or ghost mode
+	// (PATCH /users/{username})
+	UpdateUser(w http.ResponseWriter, r *http.Request, username string)
+	// Get a user's Polymarket profile image through the caching image proxy
+	// (GET /users/{username}/avatar)
+	GetUserAvatar(w http.ResponseWriter, r *http.Request, username string, params GetUserAvatarParams)
+	// Backfill PNL history from trade data using FIFO cost basis
+	// (POST /users/{username}/backfill)
+	BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string, params BackfillUserPnlParams)
+	// Fetch a user's full trade history and store the trades missing from it
+	// (POST /users/{username}/backfill-trades)
+	BackfillUserTrades(w http.ResponseWriter, r *http.Request, username string)
+	// Get the achievements a user has been awarded
+	// (GET /users/{username}/badges)
+	GetUserBadges(w http.ResponseWriter, r *http.Request, username string)
+	// Start a claim of a user's Polymarket account
+	// (POST /users/{username}/claim)
+	ClaimUser(w http.ResponseWriter, r *http.Request, username string)
+	// Check the user's Polymarket bios for their claim's nonce
+	// (POST /users/{username}/claim/verify)
+	VerifyUserClaim(w http.ResponseWriter, r *http.Request, username string)
+	// Simulate copy trading a user's trades with a given bankroll
+	// (GET /users/{username}/copy-sim)
+	GetUserCopySimulation(w http.ResponseWriter, r *http.Request, username string, params GetUserCopySimulationParams)
+	// Get a user's open exposure and PnL at resolution
+	// (GET /users/{username}/exposure)
+	GetUserExposure(w http.ResponseWriter, r *http.Request, username string)
+	// Enable or disable ghost mode for a user
+	// (PUT /users/{username}/ghost)
+	SetUserGhost(w http.ResponseWriter, r *http.Request, username string)
+	// Get stats for each address group of a user
+	// (GET /users/{username}/groups)
+	GetUserAddressGroups(w http.ResponseWriter, r *http.Request, username string)
+	// Set the addresses in one of a user's address groups
+	// (PUT /users/{username}/groups/{group})
+	SetUserAddressGroup(w http.ResponseWriter, r *http.Request, username string, group string)
+	// Get user's PNL history
+	// (GET /users/{username}/pnl)
+	GetUserPnl(w http.ResponseWriter, r *http.Request, username string, params GetUserPnlParams)
+	// Get user's current positions
+	// (GET /users/{username}/positions)
+	GetUserPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserPositionsParams)
+	// Get user's positions netted per market
+	// (GET /users/{username}/positions/net)
+	GetUserNetPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserNetPositionsParams)
+	// Get user's resolved positions (results)
+	// (GET /users/{username}/results)
+	GetUserResults(w http.ResponseWriter, r *http.Request, username string, params GetUserResultsParams)
+	// Get the trades and FIFO lots behind a user's result in one market
+	// (GET /users/{username}/results/{conditionId})
+	GetUserResultDetail(w http.ResponseWriter, r *http.Request, username string, conditionId string)
+	// Get the comments and emoji reactions posted on a user's result in one market
+	// (GET /users/{username}/results/{conditionId}/reactions)
+	GetResultReactions(w http.ResponseWriter, r *http.Request, username string, conditionId string)
+	// Post a comment or emoji reaction on a user's result in one market
+	// (POST /users/{username}/results/{conditionId}/reactions)
+	AddResultReaction(w http.ResponseWriter, r *http.Request, username string, conditionId string)
+	// Get a user's milestones and badges, newest first
+	// (GET /users/{username}/timeline)
+	GetUserTimeline(w http.ResponseWriter, r *http.Request, username string, params GetUserTimelineParams)
+	// Get user's trade history
+	// (GET /users/{username}/trades)
+	GetUserTrades(w http.ResponseWriter, r *http.Request, username string, params GetUserTradesParams)
+	// Import historical trades from a CSV file
+	// (POST /users/{username}/trades/import)
+	ImportUserTrades(w http.ResponseWriter, r *http.Request, username string, params ImportUserTradesParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List the API keys issued through the admin API, revoked ones included
+// (GET /admin/api-keys)
+func (_ Unimplemented) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue an API key
+// (POST /admin/api-keys)
+func (_ Unimplemented) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key issued through the admin API
+// (DELETE /admin/api-keys/{id})
+func (_ Unimplemented) RevokeApiKey(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the running configuration, with secrets redacted
+// (GET /admin/config)
+func (_ Unimplemented) GetConfig(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check an uploaded config file without applying it
+// (POST /admin/config/validate)
+func (_ Unimplemented) ValidateConfig(w http.ResponseWriter, r *http.Request, params ValidateConfigParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lay out a sample notification as a channel would, optionally posting it
+// (POST /admin/notifications/preview)
+func (_ Unimplemented) PreviewNotification(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the tokens issued for a persona, revoked ones included
+// (GET /admin/personas/{slug}/tokens)
+func (_ Unimplemented) GetPersonaTokens(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue a token for a persona's owner
+// (POST /admin/personas/{slug}/tokens)
+func (_ Unimplemented) CreatePersonaToken(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a persona token
+// (DELETE /admin/personas/{slug}/tokens/{id})
+func (_ Unimplemented) RevokePersonaToken(w http.ResponseWriter, r *http.Request, slug string, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Recompute all derived stats in the background
+// (POST /admin/recompute)
+func (_ Unimplemented) StartRecompute(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of a recompute job
+// (GET /admin/recompute/{id})
+func (_ Unimplemented) GetRecomputeJob(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export tracked users and personas in the config.yaml schema
+// (GET /admin/roster)
+func (_ Unimplemented) ExportRoster(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Apply an uploaded roster, creating and updating users and personas to match it
+// (PUT /admin/roster)
+func (_ Unimplemented) ApplyRoster(w http.ResponseWriter, r *http.Request, params ApplyRosterParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream live events as Server-Sent Events
+// (GET /events)
+func (_ Unimplemented) GetEventStream(w http.ResponseWriter, r *http.Request, params GetEventStreamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rank tracked users by PnL within a single event
+// (GET /events/{slug}/leaderboard)
+func (_ Unimplemented) GetEventLeaderboard(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the mute rules applied to the trade feed
+// (GET /feed/mute)
+func (_ Unimplemented) GetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace the mute rules defined through the API
+// (PUT /feed/mute)
+func (_ Unimplemented) SetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream live updates over a WebSocket
+// (GET /feed/stream)
+func (_ Unimplemented) GetFeedStream(w http.ResponseWriter, r *http.Request, params GetFeedStreamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Polymarket's global PnL leaderboard and where tracked users rank on it
+// (GET /global-leaderboard)
+func (_ Unimplemented) GetGlobalLeaderboard(w http.ResponseWriter, r *http.Request, params GetGlobalLeaderboardParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get combined open exposure, PnL and PnL history across all tracked users or a persona
+// (GET /group/equity)
+func (_ Unimplemented) GetGroupEquity(w http.ResponseWriter, r *http.Request, params GetGroupEquityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get leaderboard of all users
+// (GET /leaderboard)
+func (_ Unimplemented) GetLeaderboard(w http.ResponseWriter, r *http.Request, params GetLeaderboardParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the most degenerate users (highest all-time volume)
+// (GET /leaderboard/volume)
+func (_ Unimplemented) GetVolumeLeaderboard(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search markets by title with tracked-user holder counts and side lean
+// (GET /markets/search)
+func (_ Unimplemented) SearchMarkets(w http.ResponseWriter, r *http.Request, params SearchMarketsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the canonical metadata of a traded market, cached from the Gamma API
+// (GET /markets/{conditionId})
+func (_ Unimplemented) GetMarket(w http.ResponseWriter, r *http.Request, conditionId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all personas (real people mapped to usernames)
+// (GET /personas)
+func (_ Unimplemented) GetPersonas(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a persona
+// (POST /personas)
+func (_ Unimplemented) CreatePersona(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get leaderboard by persona (aggregated stats)
+// (GET /personas/leaderboard)
+func (_ Unimplemented) GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request, params GetPersonaLeaderboardParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a persona, keeping its accounts tracked without one
+// (DELETE /personas/{slug})
+func (_ Unimplemented) DeletePersona(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get persona details with aggregated stats
+// (GET /personas/{slug})
+func (_ Unimplemented) GetPersona(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change a persona's display name or image
+// (PATCH /personas/{slug})
+func (_ Unimplemented) UpdatePersona(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all accounts for a persona with individual stats
+// (GET /personas/{slug}/accounts)
+func (_ Unimplemented) GetPersonaAccounts(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a persona's image through the caching image proxy
+// (GET /personas/{slug}/avatar)
+func (_ Unimplemented) GetPersonaAvatar(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaAvatarParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a banner compositing the avatars of a persona's accounts
+// (GET /personas/{slug}/banner)
+func (_ Unimplemented) GetPersonaBanner(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the open exposure and PnL at resolution across all accounts for a persona
+// (GET /personas/{slug}/exposure)
+func (_ Unimplemented) GetPersonaExposure(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get details about a persona's accounts that the public API hides
+// (GET /personas/{slug}/owner)
+func (_ Unimplemented) GetPersonaOwnerDetail(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export a persona's resolved results as CSV for tax reporting
+// (GET /personas/{slug}/owner/tax-export)
+func (_ Unimplemented) ExportPersonaTaxes(w http.ResponseWriter, r *http.Request, slug string, params ExportPersonaTaxesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get combined positions across all accounts for a persona
+// (GET /personas/{slug}/positions)
+func (_ Unimplemented) GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaPositionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get positions across all accounts for a persona netted per market
+// (GET /personas/{slug}/positions/net)
+func (_ Unimplemented) GetPersonaNetPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaNetPositionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get combined resolved positions (results) across all accounts for a persona
+// (GET /personas/{slug}/results)
+func (_ Unimplemented) GetPersonaResults(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaResultsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get combined trades across all accounts for a persona
+// (GET /personas/{slug}/trades)
+func (_ Unimplemented) GetPersonaTrades(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaTradesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Detach a user from a persona, keeping them tracked without one
+// (DELETE /personas/{slug}/users/{username})
+func (_ Unimplemented) DetachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Attach a tracked user to a persona, moving them from any other
+// (PUT /personas/{slug}/users/{username})
+func (_ Unimplemented) AttachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the PnL of any Polymarket address
+// (GET /public/address/{address}/pnl)
+func (_ Unimplemented) GetPublicAddressPnl(w http.ResponseWriter, r *http.Request, address string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a reaction posted with the same API key's display name
+// (DELETE /reactions/{id})
+func (_ Unimplemented) DeleteReaction(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the log of changes to tracked users and personas, newest first
+// (GET /roster/changes)
+func (_ Unimplemented) GetRosterChanges(w http.ResponseWriter, r *http.Request, params GetRosterChangesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get group sentiment aggregated from open positions across all tracked users
+// (GET /sentiment)
+func (_ Unimplemented) GetSentiment(w http.ResponseWriter, r *http.Request, params GetSentimentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Trigger a sync of all user data
+// (POST /sync)
+func (_ Unimplemented) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get recent sync runs with their durations, API calls and rows written
+// (GET /sync/runs)
+func (_ Unimplemented) GetSyncRuns(w http.ResponseWriter, r *http.Request, params GetSyncRunsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get per-user sync status with recent sync errors
+// (GET /sync/status)
+func (_ Unimplemented) GetSyncStatus(w http.ResponseWriter, r *http.Request, params GetSyncStatusParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all recent trades with filtering
+// (GET /trades)
+func (_ Unimplemented) GetTrades(w http.ResponseWriter, r *http.Request, params GetTradesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream all trades matching the filters as CSV or newline-delimited JSON
+// (GET /trades/export)
+func (_ Unimplemented) ExportTrades(w http.ResponseWriter, r *http.Request, params ExportTradesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Write all trades matching the filters to the blob store as CSV or newline-delimited JSON
+// (POST /trades/export)
+func (_ Unimplemented) SaveTradeExport(w http.ResponseWriter, r *http.Request, params SaveTradeExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the comments and emoji reactions posted on a trade
+// (GET /trades/{tradeId}/reactions)
+func (_ Unimplemented) GetTradeReactions(w http.ResponseWriter, r *http.Request, tradeId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Post a comment or emoji reaction on a trade
+// (POST /trades/{tradeId}/reactions)
+func (_ Unimplemented) AddTradeReaction(w http.ResponseWriter, r *http.Request, tradeId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get tracked users with paging and optional summary stats
+// (GET /users)
+func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start tracking a user
+// (POST /users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stop tracking a user, deleting their stored data
+// (DELETE /users/{username})
+func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user details
+// (GET /users/{username})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change a tracked user's addresses, persona or ghost mode
+// (PATCH /users/{username})
+func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user's Polymarket profile image through the caching image proxy
+// (GET /users/{username}/avatar)
+func (_ Unimplemented) GetUserAvatar(w http.ResponseWriter, r *http.Request, username string, params GetUserAvatarParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Backfill PNL history from trade data using FIFO cost basis
+// (POST /users/{username}/backfill)
+func (_ Unimplemented) BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string, params BackfillUserPnlParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Fetch a user's full trade history and store the trades missing from it
+// (POST /users/{username}/backfill-trades)
+func (_ Unimplemented) BackfillUserTrades(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the achievements a user has been awarded
+// (GET /users/{username}/badges)
+func (_ Unimplemented) GetUserBadges(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start a claim of a user's Polymarket account
+// (POST /users/{username}/claim)
+func (_ Unimplemented) ClaimUser(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check the user's Polymarket bios for their claim's nonce
+// (POST /users/{username}/claim/verify)
+func (_ Unimplemented) VerifyUserClaim(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Simulate copy trading a user's trades with a given bankroll
+// (GET /users/{username}/copy-sim)
+func (_ Unimplemented) GetUserCopySimulation(w http.ResponseWriter, r *http.Request, username string, params GetUserCopySimulationParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user's open exposure and PnL at resolution
+// (GET /users/{username}/exposure)
+func (_ Unimplemented) GetUserExposure(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enable or disable ghost mode for a user
+// (PUT /users/{username}/ghost)
+func (_ Unimplemented) SetUserGhost(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get stats for each address group of a user
+// (GET /users/{username}/groups)
+func (_ Unimplemented) GetUserAddressGroups(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the addresses in one of a user's address groups
+// (PUT /users/{username}/groups/{group})
+func (_ Unimplemented) SetUserAddressGroup(w http.ResponseWriter, r *http.Request, username string, group string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user's PNL history
+// (GET /users/{username}/pnl)
+func (_ Unimplemented) GetUserPnl(w http.ResponseWriter, r *http.Request, username string, params GetUserPnlParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user's current positions
+// (GET /users/{username}/positions)
+func (_ Unimplemented) GetUserPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserPositionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user's positions netted per market
+// (GET /users/{username}/positions/net)
+func (_ Unimplemented) GetUserNetPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserNetPositionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user's resolved positions (results)
+// (GET /users/{username}/results)
+func (_ Unimplemented) GetUserResults(w http.ResponseWriter, r *http.Request, username string, params GetUserResultsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the trades and FIFO lots behind a user's result in one market
+// (GET /users/{username}/results/{conditionId})
+func (_ Unimplemented) GetUserResultDetail(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the comments and emoji reactions posted on a user's result in one market
+// (GET /users/{username}/results/{conditionId}/reactions)
+func (_ Unimplemented) GetResultReactions(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Post a comment or emoji reaction on a user's result in one market
+// (POST /users/{username}/results/{conditionId}/reactions)
+func (_ Unimplemented) AddResultReaction(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user's milestones and badges, newest first
+// (GET /users/{username}/timeline)
+func (_ Unimplemented) GetUserTimeline(w http.ResponseWriter, r *http.Request, username string, params GetUserTimelineParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user's trade history
+// (GET /users/{username}/trades)
+func (_ Unimplemented) GetUserTrades(w http.ResponseWriter, r *http.Request, username string, params GetUserTradesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import historical trades from a CSV file
+// (POST /users/{username}/trades/import)
+func (_ Unimplemented) ImportUserTrades(w http.ResponseWriter, r *http.Request, username string, params ImportUserTradesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateApiKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeApiKey(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfig(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ValidateConfig operation middleware
+func (siw *ServerInterfaceWrapper) ValidateConfig(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ValidateConfigParams
+
+	// ------------- Optional query parameter "prune" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prune", r.URL.Query(), &params.Prune)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prune", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ValidateConfig(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewNotification operation middleware
+func (siw *ServerInterfaceWrapper) PreviewNotification(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewNotification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaTokens(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaTokens(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePersonaToken operation middleware
+func (siw *ServerInterfaceWrapper) CreatePersonaToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePersonaToken(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokePersonaToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokePersonaToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokePersonaToken(w, r, slug, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartRecompute operation middleware
+func (siw *ServerInterfaceWrapper) StartRecompute(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartRecompute(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRecomputeJob operation middleware
+func (siw *ServerInterfaceWrapper) GetRecomputeJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRecomputeJob(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportRoster operation middleware
+func (siw *ServerInterfaceWrapper) ExportRoster(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportRoster(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ApplyRoster operation middleware
+func (siw *ServerInterfaceWrapper) ApplyRoster(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApplyRosterParams
+
+	// ------------- Optional query parameter "prune" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prune", r.URL.Query(), &params.Prune)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prune", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyRoster(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventStream operation middleware
+func (siw *ServerInterfaceWrapper) GetEventStream(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventStreamParams
+
+	// ------------- Optional query parameter "username" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "persona" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "persona", r.URL.Query(), &params.Persona)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "persona", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventStream(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetEventLeaderboard(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventLeaderboard(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFeedMuteRules operation middleware
+func (siw *ServerInterfaceWrapper) GetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFeedMuteRules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetFeedMuteRules operation middleware
+func (siw *ServerInterfaceWrapper) SetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetFeedMuteRules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFeedStream operation middleware
+func (siw *ServerInterfaceWrapper) GetFeedStream(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeedStreamParams
+
+	// ------------- Optional query parameter "topics" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "topics", r.URL.Query(), &params.Topics)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "topics", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFeedStream(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGlobalLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetGlobalLeaderboard(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGlobalLeaderboardParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGlobalLeaderboard(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGroupEquity operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupEquity(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupEquityParams
+
+	// ------------- Optional query parameter "persona" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "persona", r.URL.Query(), &params.Persona)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "persona", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "start" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "end" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end", r.URL.Query(), &params.End)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "netting" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "netting", r.URL.Query(), &params.Netting)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "netting", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroupEquity(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLeaderboardParams
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLeaderboard(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVolumeLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetVolumeLeaderboard(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVolumeLeaderboard(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchMarkets operation middleware
+func (siw *ServerInterfaceWrapper) SearchMarkets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchMarketsParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchMarkets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMarket operation middleware
+func (siw *ServerInterfaceWrapper) GetMarket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conditionId" -------------
+	var conditionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conditionId", chi.URLParam(r, "conditionId"), &conditionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conditionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMarket(w, r, conditionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonas operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonas(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonas(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePersona operation middleware
+func (siw *ServerInterfaceWrapper) CreatePersona(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePersona(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaLeaderboardParams
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaLeaderboard(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeletePersona operation middleware
+func (siw *ServerInterfaceWrapper) DeletePersona(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePersona(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersona operation middleware
+func (siw *ServerInterfaceWrapper) GetPersona(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersona(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdatePersona operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePersona(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePersona(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaAccounts operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaAccounts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaAccounts(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaAvatar operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaAvatarParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaAvatar(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaBanner operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaBanner(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaBanner(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaExposure operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaExposure(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaExposure(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaOwnerDetail operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaOwnerDetail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaOwnerDetail(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportPersonaTaxes operation middleware
+func (siw *ServerInterfaceWrapper) ExportPersonaTaxes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportPersonaTaxesParams
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportPersonaTaxes(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaPositions operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaPositions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaPositionsParams
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeDust" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeDust", r.URL.Query(), &params.IncludeDust)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeDust", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaPositions(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaNetPositions operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaNetPositions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaNetPositionsParams
+
+	// ------------- Optional query parameter "includeDust" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeDust", r.URL.Query(), &params.IncludeDust)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeDust", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaNetPositions(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaResults operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaResults(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaResultsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaResults(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPersonaTrades operation middleware
+func (siw *ServerInterfaceWrapper) GetPersonaTrades(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPersonaTradesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPersonaTrades(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DetachPersonaUser operation middleware
+func (siw *ServerInterfaceWrapper) DetachPersonaUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DetachPersonaUser(w, r, slug, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AttachPersonaUser operation middleware
+func (siw *ServerInterfaceWrapper) AttachPersonaUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AttachPersonaUser(w, r, slug, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPublicAddressPnl operation middleware
+func (siw *ServerInterfaceWrapper) GetPublicAddressPnl(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "address" -------------
+	var address string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "address", chi.URLParam(r, "address"), &address, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "address", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPublicAddressPnl(w, r, address)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteReaction operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReaction(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRosterChanges operation middleware
+func (siw *ServerInterfaceWrapper) GetRosterChanges(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRosterChangesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRosterChanges(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSentiment operation middleware
+func (siw *ServerInterfaceWrapper) GetSentiment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSentimentParams
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSentiment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TriggerSync operation middleware
+func (siw *ServerInterfaceWrapper) TriggerSync(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerSync(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSyncRuns operation middleware
+func (siw *ServerInterfaceWrapper) GetSyncRuns(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSyncRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSyncRuns(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSyncStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetSyncStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSyncStatusParams
+
+	// ------------- Optional query parameter "username" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "errorLimit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "errorLimit", r.URL.Query(), &params.ErrorLimit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "errorLimit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSyncStatus(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTrades operation middleware
+func (siw *ServerInterfaceWrapper) GetTrades(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTradesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "username" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "side" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "side", r.URL.Query(), &params.Side)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "side", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "mute" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mute", r.URL.Query(), &params.Mute)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mute", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "muteUsers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "muteUsers", r.URL.Query(), &params.MuteUsers)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "muteUsers", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "muteCategories" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "muteCategories", r.URL.Query(), &params.MuteCategories)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "muteCategories", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "start" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "end" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end", r.URL.Query(), &params.End)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "groupBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupBy", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTrades(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportTrades operation middleware
+func (siw *ServerInterfaceWrapper) ExportTrades(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportTradesParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "username" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "side" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "side", r.URL.Query(), &params.Side)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "side", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTrades(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SaveTradeExport operation middleware
+func (siw *ServerInterfaceWrapper) SaveTradeExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SaveTradeExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "username" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "side" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "side", r.URL.Query(), &params.Side)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "side", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SaveTradeExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTradeReactions operation middleware
+func (siw *ServerInterfaceWrapper) GetTradeReactions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tradeId" -------------
+	var tradeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tradeId", chi.URLParam(r, "tradeId"), &tradeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tradeId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTradeReactions(w, r, tradeId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddTradeReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddTradeReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tradeId" -------------
+	var tradeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tradeId", chi.URLParam(r, "tradeId"), &tradeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tradeId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddTradeReaction(w, r, tradeId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortDirection" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeStats" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeStats", r.URL.Query(), &params.IncludeStats)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeStats", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUser(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) GetUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserAvatarParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserAvatar(w, r, username, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BackfillUserPnl operation middleware
+func (siw *ServerInterfaceWrapper) BackfillUserPnl(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BackfillUserPnlParams
+
+	// ------------- Optional query parameter "replace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "replace", r.URL.Query(), &params.Replace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "replace", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BackfillUserPnl(w, r, username, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BackfillUserTrades operation middleware
+func (siw *ServerInterfaceWrapper) BackfillUserTrades(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BackfillUserTrades(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserBadges operation middleware
+func (siw *ServerInterfaceWrapper) GetUserBadges(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserBadges(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
-
-// GetLeaderboard operation middleware
-func (siw *ServerInterfaceWrapper) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+// ClaimUser operation middleware
+func (siw *ServerInterfaceWrapper) ClaimUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetLeaderboardParams
-
-	// ------------- Optional query parameter "sortBy" -------------
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sortDirection" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
-		return
-	}
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLeaderboard(w, r, params)
+		siw.Handler.ClaimUser(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -567,11 +5338,28 @@ func (siw *ServerInterfaceWrapper) GetLeaderboard(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonas operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonas(w http.ResponseWriter, r *http.Request) {
+// VerifyUserClaim operation middleware
+func (siw *ServerInterfaceWrapper) VerifyUserClaim(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonas(w, r)
+		siw.Handler.VerifyUserClaim(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -581,32 +5369,47 @@ func (siw *ServerInterfaceWrapper) GetPersonas(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonaLeaderboard operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonaLeaderboard(w http.ResponseWriter, r *http.Request) {
+// GetUserCopySimulation operation middleware
+func (siw *ServerInterfaceWrapper) GetUserCopySimulation(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPersonaLeaderboardParams
+	var params GetUserCopySimulationParams
 
-	// ------------- Optional query parameter "sortBy" -------------
+	// ------------- Optional query parameter "capital" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	err = runtime.BindQueryParameter("form", true, false, "capital", r.URL.Query(), &params.Capital)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "capital", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sortDirection" -------------
+	// ------------- Optional query parameter "start" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonaLeaderboard(w, r, params)
+		siw.Handler.GetUserCopySimulation(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -616,22 +5419,28 @@ func (siw *ServerInterfaceWrapper) GetPersonaLeaderboard(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersona operation middleware
-func (siw *ServerInterfaceWrapper) GetPersona(w http.ResponseWriter, r *http.Request) {
+// GetUserExposure operation middleware
+func (siw *ServerInterfaceWrapper) GetUserExposure(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "slug" -------------
-	var slug string
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersona(w, r, slug)
+		siw.Handler.GetUserExposure(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -641,22 +5450,28 @@ func (siw *ServerInterfaceWrapper) GetPersona(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonaAccounts operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonaAccounts(w http.ResponseWriter, r *http.Request) {
+// SetUserGhost operation middleware
+func (siw *ServerInterfaceWrapper) SetUserGhost(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "slug" -------------
-	var slug string
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonaAccounts(w, r, slug)
+		siw.Handler.SetUserGhost(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -666,22 +5481,28 @@ func (siw *ServerInterfaceWrapper) GetPersonaAccounts(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonaPositions operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonaPositions(w http.ResponseWriter, r *http.Request) {
+// GetUserAddressGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetUserAddressGroups(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "slug" -------------
-	var slug string
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonaPositions(w, r, slug)
+		siw.Handler.GetUserAddressGroups(w, r, username)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -691,41 +5512,37 @@ func (siw *ServerInterfaceWrapper) GetPersonaPositions(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonaResults operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonaResults(w http.ResponseWriter, r *http.Request) {
+// SetUserAddressGroup operation middleware
+func (siw *ServerInterfaceWrapper) SetUserAddressGroup(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "slug" -------------
-	var slug string
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPersonaResultsParams
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "group" -------------
+	var group string
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "group", chi.URLParam(r, "group"), &group, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
-	}
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonaResults(w, r, slug, params)
+		siw.Handler.SetUserAddressGroup(w, r, username, group)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -735,55 +5552,63 @@ func (siw *ServerInterfaceWrapper) GetPersonaResults(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetPersonaTrades operation middleware
-func (siw *ServerInterfaceWrapper) GetPersonaTrades(w http.ResponseWriter, r *http.Request) {
+// GetUserPnl operation middleware
+func (siw *ServerInterfaceWrapper) GetUserPnl(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "slug" -------------
-	var slug string
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPersonaTradesParams
+	var params GetUserPnlParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "start" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "end" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "end", r.URL.Query(), &params.End)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPersonaTrades(w, r, slug, params)
-	}))
+	// ------------- Optional query parameter "benchmark" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "benchmark", r.URL.Query(), &params.Benchmark)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "benchmark", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "format" -------------
 
-// TriggerSync operation middleware
-func (siw *ServerInterfaceWrapper) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TriggerSync(w, r)
+		siw.Handler.GetUserPnl(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -793,86 +5618,147 @@ func (siw *ServerInterfaceWrapper) TriggerSync(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetTrades operation middleware
-func (siw *ServerInterfaceWrapper) GetTrades(w http.ResponseWriter, r *http.Request) {
+// GetUserPositions operation middleware
+func (siw *ServerInterfaceWrapper) GetUserPositions(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTradesParams
+	var params GetUserPositionsParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "includeDust" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "includeDust", r.URL.Query(), &params.IncludeDust)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeDust", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "format" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "username" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserPositions(w, r, username, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "username", r.URL.Query(), &params.Username)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserNetPositions operation middleware
+func (siw *ServerInterfaceWrapper) GetUserNetPositions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "side" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "side", r.URL.Query(), &params.Side)
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserNetPositionsParams
+
+	// ------------- Optional query parameter "includeDust" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeDust", r.URL.Query(), &params.IncludeDust)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "side", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeDust", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "minValue" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserNetPositions(w, r, username, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserResults operation middleware
+func (siw *ServerInterfaceWrapper) GetUserResults(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sortBy" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserResultsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "sortDirection" -------------
+	// ------------- Optional query parameter "offset" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sortDirection", r.URL.Query(), &params.SortDirection)
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortDirection", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTrades(w, r, params)
-	}))
+	// ------------- Optional query parameter "format" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r)
+		siw.Handler.GetUserResults(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -882,8 +5768,8 @@ func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// GetUserResultDetail operation middleware
+func (siw *ServerInterfaceWrapper) GetUserResultDetail(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -896,8 +5782,23 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// ------------- Path parameter "conditionId" -------------
+	var conditionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conditionId", chi.URLParam(r, "conditionId"), &conditionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conditionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r, username)
+		siw.Handler.GetUserResultDetail(w, r, username, conditionId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -907,8 +5808,8 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// BackfillUserPnl operation middleware
-func (siw *ServerInterfaceWrapper) BackfillUserPnl(w http.ResponseWriter, r *http.Request) {
+// GetResultReactions operation middleware
+func (siw *ServerInterfaceWrapper) GetResultReactions(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -921,8 +5822,23 @@ func (siw *ServerInterfaceWrapper) BackfillUserPnl(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// ------------- Path parameter "conditionId" -------------
+	var conditionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conditionId", chi.URLParam(r, "conditionId"), &conditionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conditionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.BackfillUserPnl(w, r, username)
+		siw.Handler.GetResultReactions(w, r, username, conditionId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -932,8 +5848,8 @@ func (siw *ServerInterfaceWrapper) BackfillUserPnl(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetUserPnl operation middleware
-func (siw *ServerInterfaceWrapper) GetUserPnl(w http.ResponseWriter, r *http.Request) {
+// AddResultReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddResultReaction(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -946,27 +5862,17 @@ func (siw *ServerInterfaceWrapper) GetUserPnl(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUserPnlParams
-
-	// ------------- Optional query parameter "start" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "start", r.URL.Query(), &params.Start)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "end" -------------
+	// ------------- Path parameter "conditionId" -------------
+	var conditionId string
 
-	err = runtime.BindQueryParameter("form", true, false, "end", r.URL.Query(), &params.End)
+	err = runtime.BindStyledParameterWithOptions("simple", "conditionId", chi.URLParam(r, "conditionId"), &conditionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conditionId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserPnl(w, r, username, params)
+		siw.Handler.AddResultReaction(w, r, username, conditionId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -976,8 +5882,8 @@ func (siw *ServerInterfaceWrapper) GetUserPnl(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetUserPositions operation middleware
-func (siw *ServerInterfaceWrapper) GetUserPositions(w http.ResponseWriter, r *http.Request) {
+// GetUserTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetUserTimeline(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -990,8 +5896,25 @@ func (siw *ServerInterfaceWrapper) GetUserPositions(w http.ResponseWriter, r *ht
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserTimelineParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserPositions(w, r, username)
+		siw.Handler.GetUserTimeline(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1001,8 +5924,8 @@ func (siw *ServerInterfaceWrapper) GetUserPositions(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetUserResults operation middleware
-func (siw *ServerInterfaceWrapper) GetUserResults(w http.ResponseWriter, r *http.Request) {
+// GetUserTrades operation middleware
+func (siw *ServerInterfaceWrapper) GetUserTrades(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1015,8 +5938,14 @@ func (siw *ServerInterfaceWrapper) GetUserResults(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"read"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUserResultsParams
+	var params GetUserTradesParams
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -1034,8 +5963,24 @@ func (siw *ServerInterfaceWrapper) GetUserResults(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "minValue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minValue", r.URL.Query(), &params.MinValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minValue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserResults(w, r, username, params)
+		siw.Handler.GetUserTrades(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1045,8 +5990,8 @@ func (siw *ServerInterfaceWrapper) GetUserResults(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetUserTrades operation middleware
-func (siw *ServerInterfaceWrapper) GetUserTrades(w http.ResponseWriter, r *http.Request) {
+// ImportUserTrades operation middleware
+func (siw *ServerInterfaceWrapper) ImportUserTrades(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1059,27 +6004,41 @@ func (siw *ServerInterfaceWrapper) GetUserTrades(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUserTradesParams
+	var params ImportUserTradesParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "format" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "address" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "address", r.URL.Query(), &params.Address)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "address", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserTrades(w, r, username, params)
+		siw.Handler.ImportUserTrades(w, r, username, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1202,57 +6161,246 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/api-keys", wrapper.GetApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/api-keys", wrapper.CreateApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/api-keys/{id}", wrapper.RevokeApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/config", wrapper.GetConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/config/validate", wrapper.ValidateConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/notifications/preview", wrapper.PreviewNotification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/personas/{slug}/tokens", wrapper.GetPersonaTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/personas/{slug}/tokens", wrapper.CreatePersonaToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/personas/{slug}/tokens/{id}", wrapper.RevokePersonaToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/recompute", wrapper.StartRecompute)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/recompute/{id}", wrapper.GetRecomputeJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/roster", wrapper.ExportRoster)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/roster", wrapper.ApplyRoster)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events", wrapper.GetEventStream)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/{slug}/leaderboard", wrapper.GetEventLeaderboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feed/mute", wrapper.GetFeedMuteRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/feed/mute", wrapper.SetFeedMuteRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feed/stream", wrapper.GetFeedStream)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/global-leaderboard", wrapper.GetGlobalLeaderboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/group/equity", wrapper.GetGroupEquity)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/leaderboard", wrapper.GetLeaderboard)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/leaderboard/volume", wrapper.GetVolumeLeaderboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/markets/search", wrapper.SearchMarkets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/markets/{conditionId}", wrapper.GetMarket)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas", wrapper.GetPersonas)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/personas", wrapper.CreatePersona)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/leaderboard", wrapper.GetPersonaLeaderboard)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/personas/{slug}", wrapper.DeletePersona)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/{slug}", wrapper.GetPersona)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/personas/{slug}", wrapper.UpdatePersona)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/{slug}/accounts", wrapper.GetPersonaAccounts)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/avatar", wrapper.GetPersonaAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/banner", wrapper.GetPersonaBanner)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/exposure", wrapper.GetPersonaExposure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/owner", wrapper.GetPersonaOwnerDetail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/owner/tax-export", wrapper.ExportPersonaTaxes)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/{slug}/positions", wrapper.GetPersonaPositions)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/personas/{slug}/positions/net", wrapper.GetPersonaNetPositions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/{slug}/results", wrapper.GetPersonaResults)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/personas/{slug}/trades", wrapper.GetPersonaTrades)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/personas/{slug}/users/{username}", wrapper.DetachPersonaUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/personas/{slug}/users/{username}", wrapper.AttachPersonaUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/public/address/{address}/pnl", wrapper.GetPublicAddressPnl)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/reactions/{id}", wrapper.DeleteReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roster/changes", wrapper.GetRosterChanges)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sentiment", wrapper.GetSentiment)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/sync", wrapper.TriggerSync)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sync/runs", wrapper.GetSyncRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sync/status", wrapper.GetSyncStatus)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/trades", wrapper.GetTrades)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trades/export", wrapper.ExportTrades)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/trades/export", wrapper.SaveTradeExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trades/{tradeId}/reactions", wrapper.GetTradeReactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/trades/{tradeId}/reactions", wrapper.AddTradeReaction)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users", wrapper.GetUsers)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{username}", wrapper.DeleteUser)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{username}", wrapper.GetUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/{username}", wrapper.UpdateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/avatar", wrapper.GetUserAvatar)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/users/{username}/backfill", wrapper.BackfillUserPnl)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{username}/backfill-trades", wrapper.BackfillUserTrades)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/badges", wrapper.GetUserBadges)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{username}/claim", wrapper.ClaimUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{username}/claim/verify", wrapper.VerifyUserClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/copy-sim", wrapper.GetUserCopySimulation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/exposure", wrapper.GetUserExposure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{username}/ghost", wrapper.SetUserGhost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/groups", wrapper.GetUserAddressGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{username}/groups/{group}", wrapper.SetUserAddressGroup)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{username}/pnl", wrapper.GetUserPnl)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{username}/positions", wrapper.GetUserPositions)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/positions/net", wrapper.GetUserNetPositions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{username}/results", wrapper.GetUserResults)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/results/{conditionId}", wrapper.GetUserResultDetail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/results/{conditionId}/reactions", wrapper.GetResultReactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{username}/results/{conditionId}/reactions", wrapper.AddResultReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}/timeline", wrapper.GetUserTimeline)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{username}/trades", wrapper.GetUserTrades)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{username}/trades/import", wrapper.ImportUserTrades)
+	})
 
 	return r
 }
@@ -1260,40 +6408,334 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbTW/bOhb9KwRngNcCTuy+126yS5u2EyBojXwMMGizoKVrmw1FqiTlQA383wekJIuW",
-	"KFly7NQpskpsk5fkPed+8Mh+wIGIYsGBa4VPHrAK5hAR++97EtxNKWOXoBKmzTuxFDFITcF+zuEelL6W",
-	"JIQzosG8NRUyIhqf4JBoONI0AjzAOo0Bn2ClJeUzvBxgwcLtJipOYjUXWn2QQDSEZmY+iHINM5BmlBaa",
-	"sEsgjP6CcMzZun2RTJhjnCfRJJ9m9qPGUgSgVJPtRIHkJALn02J7ywGW8DOh0sz9Vo6sW/YcxLPr29Ue",
-	"xeQHBNosfwEkBDkRRIYfuZZpHRQRAx8LRTUVXPnPEINUgpMzqmJG0i/+06yGXbFk5v9ciillcB6Rmd+A",
-	"JPzOvwPZHxzjne7DE95/iRZoB/ie8ssaVRtMVYhg3TBY40NxmHVPVLftI8A4A+U0CETCPTFJwlAaktkX",
-	"VEOkvMfJ3yBSktRGZAfWbIR736Da4TZrNGzxkFB34C4x2QX0Z6AJZXXkww3hTBuB6wB+f7+qprRxSKD3",
-	"jJJH0MG6Y7AGkruNXRBjc23YL0V2mO13RZ7nwY28QHgp8nhaFLB5asViNpY0gI6OCQQPraXz0OuRIJES",
-	"uO5lMpvyX8KSrlOAh/16RurfLeVUU8L6LB0ReQe6sR/KPr6mmjXEUKID0RB5iv6CfXLanTMGGUDWOzyu",
-	"PlZoTMP1Lsf1R3n6/KyDkn0V5vSgdtPFZBNT/0wO9aeFBCVYYhzVzx3bc8JFppkhveBXl6BiwRXUecBo",
-	"RLW/JonpVIFuqlfW7lr+/7eEKT7B/xqWt+VhflUerrPRUxtsEvetVS0E+cLFjJazXyVRRHZb4xuL7lYV",
-	"sV//4z0pZ2dEk7GgvovOFrcNGoHSJIq7E33v7UbFS+UWH1f5OfsPVVp4+VG4tAe/XSA89N5KE3H24T3C",
-	"S9fy0rVs27X4itAeu5GXNuR3tCE+kHfTXhxKX/E0DYXVVvqTt4GLeyTWzmTr7pl+o+apaOY74ElkHP7+",
-	"5n94gK8+Xlw4vt4qYW7RrbRKmYvO2cEXWG5j0pxJQ/MnzvNmnlizdRuJt/soa4yN4jlM5/jLImNTZ5sb",
-	"bQuxGwVyZ1I9I0pfpTzInlB1Y8ZGHm/VwpUHaDp0k1b9hEd/ebJx6E82lraFmQp7OwEVSBpnnT8eC5Zm",
-	"2QbFOYRISxLcgURH6J7oYI5SkUgUCQ4pmiSS21xlqwsepxLQ6fjcJCGQKjP55nh0PCp4QWKKT/A/x6Pj",
-	"f0ziInpu3T9kpZJuXs+yVGMITIqKiD+DdgR3O12SCDRIhU++PWBqVvuZgEzxAGcwYCWkfm9eZxkmO/CU",
-	"2ObR9V9RTTq6tETz1tMlNW/ljEoIrKv9OzJgOLsh9pV907POre1vbCq3Tvx7NMrbCJ237SSOGQ2sB4c/",
-	"VHa1K5ftlJFrjzjqyXk5qJDIRcl8qgrlxECIHKiRmCLCGDL8VnboMO8lVBsLxsWYp3BARf3pcnyqtDnZ",
-	"6ih1H5hDFx+jV4ZZKAYRM0ARiWMIkRZoJdW8XvdM11ipP6N6CZnbJ2TMNpGTT3VjZEMETdKCSOgVmc0k",
-	"zIiGEClNdI04D4ols2UHzjQQxaRrB5xMXyzrlJYJuBjt2vkdfJ53Py2eDe0IZcB4O3rrKYD5OC40moqE",
-	"+/wfr9tC91TPUdX5Xt8PSfYtki7p7bQYepBg9ImE4qszPQJg5afH4GSybGEITYVEZAWdhYzykC5omBDW",
-	"BlnstrIbMCvb3mcP2koO7oDaBxFNKIcQlb56DG5BzRwigRRKtSDqx87RfzYgd7nSbfaAW0N5y67b3rL2",
-	"bjTwKE1+M/nV3GvHZ+YJ8nBVz2vjjNUiF7snT92u6bTsvl5vy6dSzthAp+tCojgINr0ZPVM6VQSrNhrl",
-	"0OyEOpmtriRRKQ+s4CKUhxPXks5mIK/MoJqn/q5v1Aw05UhqqO4xN4UIMku6lycUEp3vZjNFW7l5GCmq",
-	"wYyjg/QOj1wrLed1EpCbrEWUZw9/XIsdZJ1trlyOELy6cznvLYp90F/P+m71uDxwylgRtra3m1KmYSWn",
-	"1vtCCQFw3TJlmIkSLXF0Ywc8RUtm1eweykOpp9QPnql5oau52H+HD0V0LTcdulNZc2L1MK6Ijjrucd2N",
-	"zaIbLod2UFsFSdas+Hw7nOQ/8XErxvoqlxAIrrRMAq3Q3H6rhAaEofGXC3vdz37UQvmswNMwOJhLwQUT",
-	"MzOUpcff+Y0ChT6df/qKXn2iUumjc36U/fM10a9RIJRGE6KoQlqggLAgYUQDKjQbs9zxd/4ZuGEAKBQS",
-	"ylK0+hmN4VmQRGYSXdSm4UGFOsUvm4wLMznoGVKo8vMsD42KEaaXiBlYNSAJDF7ThLG0M7MG+F227wbz",
-	"U0JZrT9YfWqYkhEnRVMpoowktklAiWWO5UVJgQauxtmTj7ZcsFcsm0qX6Y4aKm/btxf81oCH/W3t9Q5V",
-	"fpfMp5CU2DYkoL8Uqg7yQNtF1bAA95I0fkvIdpM1eugZNhqd62iTm/PvLVWG1p3dQYYwS/bRIHYZT3+g",
-	"DtFBgLjsrjt0qvt/qVbJoYEam69rZvEecsITEeMPlhQs2oWc0AR1VlHnbp5WIBcFMIlk+AQPSUyHizd4",
-	"ebv8fwAAAP//qrS8YvU9AAA=",
+	"H4sIAAAAAAAC/+y9a5PbNrYo+ldQfU+V7VO02k5m5p7rqf3Br2S8x05c3XZydu1O+UAkJGGaAjgA2G1N",
+	"yv/91loLIEEKkCh1t+3M8ZfELZJ4rBcW1vP3k1KvG62Ecvbkye8ntlyJNcd/Pi1L3Sr3vOZyDX83RjfC",
+	"OCnwaWkEd6J66uCPhTZr7k6enFTciYdOrsVJceI2jTh5cmKdkWp58qk4ER8baYQ95BOlVSng9UrY0sjG",
+	"Sa1Onpy8Ex8dc5o1rWNSMbcSbC410wumlYD/wS+tFeaeZW91vVlzcykca4xeyFrY1EzwtuJrnGz08FNx",
+	"YsQ/W2lEdfLkv/s3w/KKCBjxLn/rptHzf4jSwTQeqK8qoZx0m224zqVOLKE4CWsbAmJ7c8wvbWuAxoq2",
+	"0mqzTg7fNtWh6NwBseLk4/vo6XDN//v03bV0Thi24qqqBauluhQV4BPQFvahDZPOAl5PiukY6feRhH5V",
+	"GWHtj0a3zTboOT2lP6QTa5vcmv+BG8M38HfZGiOU+4XXrRhCT7fzOgKdatdzYfLIxGUh/grY/cVJq5bw",
+	"k6guTthCG9YtkF1Lt9KtY5zhGyn06Eaot9pKGDzeiFROLGkZRvBa/ktUb1W9vZofXv3wMwtvsLfqNdNX",
+	"wiCKcM57ljnDK+SmCVt22vHaTzT19Xc0fnLtrRqtfsKgV7pu11NxdC3VGXfT3h7Ro6fFnp6i7Q+hPt7H",
+	"iJrGWBzCpV9jt7V9RH8m/tkK626J9kfb7sfYsQyPreTsySnhfGoPFE0HCcuCXa8EHSJ+HWzFLePhpSOZ",
+	"q/GPO7kwXMxzwjO7gsd4cjVCsSZC9QQa9St8tebLtBg+nEesbk3qyP11JYxAIIEoKPVaWLYwev2E6cVC",
+	"lpLX7D4+3QLyPct4XSOumHXc2QdMmwvVbZXdt+16LSocLkbDPcs8N/RwKbKC0M/24EKlEHag+LmZdBlC",
+	"7mnYPL1QMK3qDWuMsLAzpD0COpO2A+YU/KfZ7xBhM5YuQ5rtiGHAhDt4G+XSM15eLmR9IJfTUfKDcOVK",
+	"VNEbEUPRK6+UFcal38kDZDD61lDJLTXy72JzK3qvrAbvSuX+8qeTYmv1xUnNrXtvjxN1Cda/0peHjWVL",
+	"3eBg/8OIxcmTk//ntL8cnPqbwSkB5hxfHUNcVid+RWGwWDfOgzl7LKUF+a8rDQrS9Yo7lBeXYgO8s9Dm",
+	"TnY12FB+E+dhnuFijeAVCEB9bRn8W6olLvrp21cF49VaKsZrq8Mr5YqrJbwj8TKh2jUsAT5EjWIt1clv",
+	"iU3SEuyZsI1WVmxD8lJshmf7fmjsPfBxzBRAggw4E7atU2gV18I6FBcvtnSsXRSq6+q4D63ijV1pZ58T",
+	"OaZFTPfW+aVsGlFtY/NMlFpZZ9rSiYp17zOlHbs20jmh2FyUvLWC2Y0qBy/xGvC4YWXQpNdJMYDi++zg",
+	"84eE2lujS2FtbodHXXPHIyfAmYBdYiNJWhGqXIHG8II7/lZLlaCXZjoQ5FpYx9fNVNIY7br/vjhpMitG",
+	"i8gvwsiFLDnRxY6jbqQM0AN2vdKWjBYlN0aKCmUC2hMKZoXXC65wEoLlll68EiXI91hxT84lwmzB/MGu",
+	"hRFsQach46pifqyT4oBr787rf7fw/uFc61pwFT996o7EUkSbEYi2IJJEnlYLuXxlbZsWkglj00oARlwQ",
+	"3BK+hfOGz3Xr/O3hUunrpOK5FtbmtHPr/JPhhA03VrD7//X0zWuQIY5/fFCwSpS6Euw+3hdssHFdGw2r",
+	"2jSiYK3CRcBpiCo2XC0kwJTd98u3zMGRWWl1z7E1v4R9KSsKxmu0mxnm9FK4lTAPorMHl3NSnNAKAOR+",
+	"3JPf9uGJNtgDIY+QX2hMqVXuzBDGaGNTygB3zDrdWIRIicOxWuNJO2PPgSgAdUJVlnmFYSGNhY/4UuAN",
+	"gtHgs5Ni2vkYE1GCNYy2TpjncJKn+NI/YLxp6k2gKlr3PcvoY3at27pCJEXyINqgtITfqUs+i9aUWjMN",
+	"liR+PyOtCAB7UiSY+pobBTSWuHcbPa/FekB9gLAEvgpm23LFuB1Q862gZUSaAXierKL1p4m02ZzLdVtn",
+	"5H3JG+n41EOqCifddI3s5T9b6Tb9EZnA4EIqXtN7E9dhhGuNelu6ie/bktcpU4ZupDDMrrgRls11u1w5",
+	"1oRfEMuoQRj/bJptwzpuDri74AwWl7Lz/hhpdrekHQXcB/gMMRFDebTK8ZIGhJGkQlS23gpjteLZO1Ml",
+	"bVPzzU+5s1lmrUW2bpfb6H2tr4UpuRWsFs4JYwtWyaV08H9uVyDIVMVaVQljS21S7pXxsQDzFIOF5rcL",
+	"joRpZsvRucDrWrjeVFSwRx/ZQsMtS1RsvmF/esRW4iNcuAwvYV8HqT/LlaYFbUvChhC0vaTzul2Gk9u/",
+	"1Hmq2FzUGo9ofWvuqd0G2ZfVUpw77hKwe6mcAROVLMlkJ62TpSUHgBFW11ei6m1yMxa/L+kcluumBrW2",
+	"MXrO57KWbsMaLqviQlnNRLXs3ux8DNdSMcOdYGupWnrGr4SBQ1r0E8zQwDcig6slLuEtvDBRmPGr5Wtt",
+	"7THf/SrVwZ+V3ImlNgn98g1ZS8MLTKqFMCa2h3p7qpOuDq4hXtfeKQQvAGJ4XbN5W14Kl6IfAPjElV6K",
+	"ut78ADzhj7qRX6ita/Z3eAdI41KwhX+1Q/l8Q7ppQCd3OVxOOwlqHTg85cIiakw/PcSHg2/bCWbFDpPR",
+	"7P7jbq2xa2ZInB4VYzAnGRS+eifXUi0zfPo3fc1AarC5WGgjImK5Z0HhZaj+95Z2OI6FcsKIKsVDL/jG",
+	"PsORXqqELgiPiSgJhU6nJyzYtZDLlSNSmLdg8LDur/Avy/jCeS9itz70iIPO9y9h9DSSgAXIlNT/CV8B",
+	"IYuzSeWXR54Cxumi1s19UuzDdpgpiaCRUpZQC+1qIvGJQxS327RyiKCn4GKT27wSyr0WvBJmrrmptvcZ",
+	"oWOSNhsNhkSeOmAFzHruNZLd2+lfLXbjC99zRvD1m/5anjQ17Vr9W1UPFXG5FgcqqvumQAtnD5Xfu8s4",
+	"fV2cgG3xIXxYC6TiRtUPKfYhaR6erj7gU7+n6LskOD822rYmcSP4eeDLRP0wGOs3ZHjgjhSJFt6YsWfC",
+	"OnpNGwtnoRVB0WCCl6vuCCSRo1tX6rVgc/hMG/9VOA1Xuq6EKZgRcF27EvBVmD5aFQmlIB6CbRbPpQrW",
+	"9whGftxLpyDsUwoILOQ5tyIZSQEO08F+mVwwcSXMJmzLD21DMBPt4J5lC36lWzNNJsJ+nnErUzdwLqte",
+	"WTjC0Rw7CnMObb2eSyWqzmd7I8/2BAf7MT5aJJTbQBRfcqmsi7B1hMd27H7dhnKM1W33bUx1o72l+PUH",
+	"Iao3rRNnbe1l3fCwQuvJPsnUD4ChT9bp9QGfjFUpmrIbKLdqktnP9XrNVeL4yamqtp3Dn3M0bbaq+zMd",
+	"INDI8kbRL7SIbqTde8meP3PuNfVdEIU78TN8MRyUGbvoijeNUKKisAl8k3ljrH1CVpkPUi2FdfBO4NEP",
+	"2n/U/VDW2gq4uxEffAiysEAn14cFlzX8AcfFB4tur4LhTj7wa24q+HMta2GdVuKDAYmOo8ECpFp+QFuP",
+	"qNh9HqI2yUKLC/QqIwVMlGIGgL4Sb6RqXRQAopWguJJSq1LAyLhwXgvjYFzPwEpc1xvkWDC8rklp5ooN",
+	"vpq5lREWXnorDPx8oYYxpSjRhD/+ClItpbOs5gZg2cEtE4xSD3WpQ1WmM64u84bcyOEwJogN46wkJmIB",
+	"ZUgXxmjT0UVqxR3yplDmm+7lKAxqr1IV3jtcoeo4d2S6xt/pikJbY9fcskrU8krg/VobvE3DzbmTDRWj",
+	"8RAw/a8H2YaOU/EysquiuKpSKyVQxNyzYYnEGJxiBsSDwjP4fa4Y6YK4CeKpB8WFiuiO3TdcXfovyV1E",
+	"VAAfS4XW8UArGSo+UKdMycMfV9q6N7oSWQNf1sw2moLeS85R6zmvb/X6sjVk9hJTy7V0afVFLxZWZJ6h",
+	"43zX5XaJK/Bmdcus0yb2Eg+t3uja3WYPeoDEYeGi7scEuihIs0YhPGPv6Q1R62vkJpqNxdS04leCKY0f",
+	"k0dZrwWruXWTfWoEVJBtSW9zHJ8+lmveMxYvCDjde7jBM2qFi20kdLrg85NikpjJWAUCqgKmO7T2gJ9E",
+	"k0RAB0XM7Y1xDfwZ9o+yQEEGgG5tIJ1kpsBkdXp//Cmgc6+rZdvl6CLi7E5rtwKnO4GkANWcq01q/QeE",
+	"eY/QisstosDBBjXrHcHVEdVuixXr5DoEG23v0esSfSwrNwJco1pluKxAzkIWkxDT4QNypcGQXHLPNjUv",
+	"BeNrrZbxKB7bsSCPHRaqTi8RxoX5gIm6MF/4Ea2AEa31MRAFE7UVrNkYgQeVoy+m3foCuYzyImLBRLTc",
+	"ecNDko96zWQso0D0DDeflI5HuVWIKHrsJunC6LbpnbEHWAveq0HSRXcDRZ0zYy8ICRmHmAuGTujMXR5W",
+	"sJLWkV+CrXRr6o13M0x2y2/Zy0ay/UbGBVDe7sjAEPnxbiGw/jNEoONRnvGEaHNbNBebPkL20aGCllZa",
+	"TLKATI5m32EO2eta/5uuq3dyLZ4haaed6tryOgPems9FnfLP1BXD2H8Deja7f9E+evR9+XhVsMerh4+r",
+	"gj2uHj6+Ltjj64eP1wXDx+Lx+kEyhBXjK4451mh1RbSJbrRdsMg6nfymMAoN0sQerjnFEtba2YIOh85F",
+	"ZAVwqBmQUetNJSOx6MXKVDV8hLOEZBlgLadLw6LZfW1Yw42z4ZcHjGweQ7/RKuw9eZqsBVd/020qSu2N",
+	"4NHXAweZx8QkqbUWlYzmOJQQYgII0E5RAIZQVbkUCB8lOS2A3IrSCJdWLy6FpxBV+awYuwIo4+kuHZzo",
+	"GGxZ7dXHYUndXPkd+dCZd/pSJIK4di3VwSfHLrY4cWHGnedkvLrxDmmEnXvcf5+Q9ikaz5K3wcoTu1S9",
+	"BpU2uiWVSHgfRzkk42T6KfxiT1STfy3jMLzRVeWIJDqKg6IbHG6O128HmJgwyBBBvwzifslezmgeH0PZ",
+	"GuHDsOJLw4zeKYDZvLyBH0Zp4j0RfSY1JR85/plydP1VL85ymKZn7OG8yDi77Vs24krq1p4lrznwa2yk",
+	"INtcwfi8TxTsosWuuYW7Il3OkodRnp4PRfExdyQP3m6qJNSkdS/VlaiTeVMhlQmVfFZL65hQVQMqHCt5",
+	"XYejWfgR/sOZVswY6Hl40JLlF7/z0Sb0LYVj9o4DekhjPEErPqoPaBmGZ/gXLAIeNXwpKma6peFeUg5h",
+	"WEb6JIEViYqRhuPvYT8YYVcqmTBC1q2BrQ7TN+iC610tGQMXPJ5o3ypOlkIJc2hqY8OXUvFJ9v3+zS2t",
+	"BGA1GGu4mhTtUNDcG+F4gPQ48qYPt9taNWl2SVi7lY9O8uoqZIJbp5vG2xFghNTpV2pFgv5VlZxSqOqw",
+	"hLVd4S/+6Tvp6rQQ9ZbPQzC50G0q5usHXltBsudHvl5zVmmBkgf04QhQBUUn+qBFYBWKbWZwVRSZlAEf",
+	"xjFd4yes/0yfJTMvonDASbgdKPmqCyyxwuFuuGOPk0u3Ocy4LFKupYIEg7D6vdI0Jqkwbke7g9jDDo4B",
+	"jTEF5LknWkk66zahRMlUbYDXpCrKUjDt6eQIm0A2zmiw2u5SOlwyxWBkVEidBbm/ByfoPgS16kUnfdEa",
+	"YuW/BFuJuiLbm7SBZiZmNch/HaWu9JOEnfqxwg7ygDsX3JSrXFrVPsG1E7DADREVjYLA6EFkvhedgxzX",
+	"nIRtxl+uzgOeptySad85FqXHeel5M7FEBJryCoMudD4V/96L/Fy3yk0JSh4Ii3iHg4Fi8unXE215Fxkp",
+	"ODvUHdNQBltfGzJtgMZ5qVPy4yfhWPcOiIr/fvi4YI9/e8LuowTRvcsSeCOcOw9ZeIp2bTyxwg4esFPv",
+	"T4F3ZhfqMVsLrqy31QZOImBjLRGaw3I40GQlCvbIf9GZbuE10CXAmtHU0quyU63ZhxAzvD+9RNYB1J0m",
+	"6Gi+wfk4wlue3NGUkSkUNm83GT/FL8EtARCGCPQCcP/+/MXzqTGcuzkJ9fqDrSxHmWZuyHdKuAyMnrUb",
+	"776phbVkH8a/Q7zZlVc4h7ceODDmLebLyolE2nDjZCkbnnRyUVjD9UqTqloNtNn5xpfrmerkiqjmbT9t",
+	"WnTU9RTygfcOpZ+gXozyzigzk7Y5kb+70ms5s3mn30dwSxoicnWRfoldeDTaQbs99AQko/cmCIUeDRGx",
+	"dqvttKsBxw1ZaURge2RJTBU7JcoE7MTMdRthGgOSPIg6brXCyAQEJYG8I6aZzADJVKG/yUoMqdh2V8P+",
+	"O3a/0bV0srQFs402zhasNJvG6YKJUiu9xkdlWzuMBdWhfML0cMG1zLm94yVea+NWJDIxYhXuHtN4uXML",
+	"5wenlD0ruqChQ5JhPyVw8pNwb6PIz61Us7fpm+TLxUKgpyHOEgwCUQl/cbCFD30sjQCeD5cLjcfFlaeZ",
+	"Wzhup6RUzLVbscENfP+0FMRwUProqMjojnMjBhP8HeKUp2bWHWioWolqKarzXQcPXpe1OgZUtUhWlIiS",
+	"eHxgiFSYG4Rqb9o7S+SRgeCvIT+ItuMByIyohFgjniGDSIQKqIZu08Xnu40mgCurJM6jCKlFC2m6tKUU",
+	"7tLqwk/dYIC0g8wbW+6gEZTBzDaEMHJrRWk3lVg33v57m6e/P8kjQh0SwzDJZlSAdRxkggT5W1LiXZ+J",
+	"XCbKU4ylFgqz1bhiYq3/IZnx7xdMfOSlqzehfPX1SkLOW2sdm6Mxcsu/4IdLsZw2LsxWMAxL7YsrR57C",
+	"Nf8o1+2a1UIt3SpFHbjI1F6sVMta0CaSrvlt4GjXVct6C14wcZ04rFdcKQqjSUgZXtVSZcJFw1NK01MV",
+	"hvf7NHQn1k2NWcneoTNvZe0eSoXA7uuHhvdSkLiUqtrBvMlHNpOV05uhLV83NSUlNNrGWPKASBmeW1NP",
+	"MB13A+DKI/B1S/YL/G0asrLx+RHOtoscKVH70mMBuKyGHHKUWNEkDOtoVdKW2iDeKrHgJF9D3qt/mExu",
+	"DdgZ58iO0pniX7wVPTWaFSk/yFttXYy0CZjqCGo7oFX3AHEadK7gJun8+zTuPVswMVvO2MXJ77/Pgm72",
+	"6RP7/feZlRX9q7UVm6EY/fTp4mR/qIxU8cZ7tP/sA3wH4ZpbCD849fxgf/Px4dtxWvtOz/PP10oYX4r/",
+	"1urAd6kqeY7nNCVY3VayqoRiTTuvZVlvCvI8h5mZVGXdVqLKhtqco+t3OhIMXFZKWctJ3lq8sZ4NP7l5",
+	"FZoAo63VpHD0duBaHmKoS6sZnX0+y4G89LW0ziY1QSU+uuetsdokvBaYv9HrVR8dDhfUKh0HR1Fxu22N",
+	"rcvsOW55meyfV0CJwd5yvdI1xRLMGMRRWApJKzl6qucbDJlYS1f0J5xitLDCp7TDMEjdPiRCVLP95TBo",
+	"bUl8UQDWNK4a3frWjdsgaJA+WIgOns57U+LIbr9W+tfQ02ByGc60VNIgCe1KNoHig4hCrcToKww6MlC3",
+	"DRINsRdMSiTdlZHvRjFeGSF0g0AvT+QvhOOyPqL0GrV9SZqjEt0J/OsYfYRVMDxyINETnpXUOyXYP/zT",
+	"yXkZ4140Cb7K14o7vL3IBFxnoyqs29RiYvzsOb77lbHngbpEYNz38eeja5jHttewe0beybkHLOGr4Oio",
+	"k1ACBi+kdVKVjo17CtnQVKiroebjgyHhLsEmh5V6SNQvjDF9GxJmfyz38WUep7DubQZD55j6M4YaH8p+",
+	"X+Np5kNqk8R3c4LDK1HuXOtYZmoQxeCClQCwnVTXy2+1m33H8qc5G6a5BHwhkFzebogexBjjqRVE2I4C",
+	"IkcFlN6CD+EuvAHotsFD9FUCfK9eBDgg81BBaLLOdJWUBhG2o8iHl1S7cGcVAD8GHoOhjo03BUIpQpwS",
+	"r29O68uByotnhdJdJmm4a4XgHKeZVmLGno4C8uM04y5bNsTJYrEbmnXebsbxNfvbxmz/rKST/CB37e15",
+	"IFK1Al4tXuvktS5ZTgtRhP4gD9Va26nOIJzsV6mOnguKdBas4Rsfc8X+x2PGyRMxcQXauIWupT5Ph42e",
+	"d3GNGV4fho4GSrtn+8CT2EdGN/WpMRt2ejjWUWdp/I0Xa7eeQoI12aN729CT0/uivE+nE+4jgXjAwXds",
+	"YOzBovGPwc8AvnORjph4G3vjB4zVqdmUeIKCVkfcWDDq3EPPCyYUymjvc6Voe3SP4fzDlhrTagzAd9kK",
+	"A4cTe7/0w5BMW0l7P1+Ck495SdXvuWD3+z8IxA9ZIOwH7H9SSKjvkYcluGPQTxSdoxkSAe9S8XobE/ep",
+	"CuaDFNYLMDc6dCZ2Z+GwWEA1PUDlOPGwy+3bC4uDJMGOHlpHVnOiYIHpyvNgOdlI8glB4WHiYr/Z9jwY",
+	"WLaUZ8iyzyS1gy/9YZfKHup7OxkEQlcDQHyU6PkeJP9Pq7UeFPt0gMlTPydcwLCgK2ULhnN1ejFpX2d7",
+	"bxuOcUlu0Eyx6Ip04vnkEu+YboTsor207MIOk8Hz73xBvCnVELp11Votz1fancGNZIeqgoWyfVVwxqmV",
+	"ppfzj2bfAdpqfS3MVAUpsitkjDXdO/2spdEW24TG1pk91N1PtU0o493vovx2vea3a2DJWjyOMkccZnza",
+	"sdNM2YN/346WO7pT7u5KGQPs4N6UXqmhihHUltJ78SHWXJJrZSnRPru/t3k2Ky5e444zC5dx8Nnja1Ds",
+	"IUU/dnJ1qu4a+6VCiObhYdABO999V+MAM7kxZ9sWjFt0kGPvYcyLZ1aC/6PXOfFq/lyvGxBr0g3KFPlP",
+	"5KBmWLCZ9X3NMVOGZszldB/YtinR3DBZshXEZzZFwR+sXURTB7u0c3uSIhWuV37mvfWRdgeFHOE6vfMw",
+	"ksOvuFOiSY6wr6r6b2QTShWCi1hkTwm1np2O6yC2twqbD/95saMu3M9xDUBbGt4EF0vvvSyYEaU2lb8V",
+	"YMisdJ4FJ7erSwYjHdaFMu8M3kfr32zJ32zJR9qSASffDMnfDMnfDMl3YEhO6fN3aSDubYqJUk7TZeWt",
+	"9qTGeVOrjZMPRqdW61apUEdfUg1LgQVSe/r2FZXlAzkTotKpSV/X32I7SyqXi+ADHv0LNv3xnrPp8Ntp",
+	"Jm8hrGaYe2FvdL91udPpnT+TeshRRn5cjLtbAub79zlFOyvtb18QQog9zqiNHycx97QLsqeWHWbW3Xdn",
+	"iBpeN60T/6nnmfYmCbGL3Uysb8/he4YkId63gZ4ezrGQStrVMSaOfCOMVI3P7pnfR1CEkxvZaqGfPIq6",
+	"t5gRmLjiXS0hXzYxLvVSOWSv1nHXpstAN7UAEfAPPYeTZcOsk3VN5f6pgwhSsoUzEejFFl19M8W61soh",
+	"McO0WAEJCcqPDAcSjpPMxfDtcmtubSaO9Z1PjkUW6PQtf98Htc03DshW/87eutF/5Uk5//ULrURuhO7z",
+	"ilnNFtwUPqyfAjaRaUHMegDsNXgie3pcxXguunLK/ZISsEuQXBH4cUDYHZOl2fubu/Sbu/Sbu/SLuUtT",
+	"QuF23KDE2rkYwH0MXusDjFE01WudNofekF8bQdXCbDYh3feon7eOKSExEcLqumJKG4/Tim3ExPzuXpNM",
+	"HaCo+GLjjpHeGVKJSF0bcvyM/YwlmUIed/8RdfDl85qydSYCm76ewOojaLXrLiGR6iQCku9N7+fR88eh",
+	"pHHefZltsGWz2sCgJs0QslNhNurOlXGA7OK7oZ04Isqir3aCPDMEU54xgVsSnhQowA94wfzmhhs0D2ol",
+	"Chaq8seekFCNP7JvoA7i8SsTmebwJCMFz2E0lHw4e/pg8kPPNwe7//HLyACYKeR6iKpLXzxL+Oi3IZPz",
+	"xk8vv+9j7Q+wFcD7u3ZMVshDdrzH6DZxXcc3n4hMMoH+e6AUMXmFzjodjvK8EImHBEt09wA8fnccttO7",
+	"bR1o+b8doIeVHnSX29Zc7rAsad8pa9veNl5JQG+0qzyCv3wU1ucJvzrT1gnztGnqTfZmRT0apy8ch8w3",
+	"CK3M5qxVqZ6KwBCtEhPaLfoxwgdFt8j8HnNF8XMlU8jK9MHnbRX+It//XYlaxH/791srTMHW+ir8079H",
+	"f/Cq+tB1kDMCX+v+tsJ9oARkOss+hO5GW0xWdTry1iPHzTKV/O0DKxiE78D4cfGznUa53u5KI++D8Gu9",
+	"zHUTzED6Z9XdUuOB2A4D6RFW2R0wy1z1rW5NOeh+3/WL5o1MG4xuG/pkEh2hoFvaXltoBM4dEu0mHN6h",
+	"+w66j+6rOeyXvUu+nfMrUb382Gjjso2Ehqj6u9gEaqQkaDq857Wek4M0RVy1LnmauH8NbRKjEdilEI3t",
+	"pigg5JGDt8yw92evU+MbfZ3JlEyX0PoBVg6PYPnzjRvaHXL+hGRTo25rfhV+yiSwN6p8brhdJa1RXMmS",
+	"jJLU/rhqYXfYJMIHU6FJsW1mDEIT8Pda64YZgQ9Cv2HwQ8+2rwm5QtUUBIjLOrRm7UtjyG+1r1B+q1wW",
+	"IN0g05W9qIX21rNmxW36ya26+XCWfiW5zfmDfLS1Rj7ndb27xgA4+qCNCpj1q7SBuxILYQwloWfN5LVY",
+	"kDerK5diWsXmouStFZ1LEWZi87ZaCuxZAyax9JQtxby8sRMdcL07aHQzBeqlh50bpvNNNIJOALiTngKd",
+	"n3ZW9e0ZjnceTVg+cPSvRjon1I4qh0WXaxxZCtCm1LuHrv0ot+QSckYu4evo8PWW95PiBM6hqiWfxZqr",
+	"diD7h27Ls/aA4FNP0UBYuVivLB1SK5yJTpSwu6EXJcL0gBJ7/8qQI4qe04aY7MgygsEODsb97uTiW2WU",
+	"BIlzWbcmGcy8iQNQ6Kjw3XAEN1i2ChojsUpWuWMzIu9bocyjIv5iNA8wuw+DObSddy7TcTB9d/zuo/Rw",
+	"UlMPhEqYXbXDJHXS4XBMN8IAqAgfM/YzvhKeWoxCCiVhKu74nFvhGxYKc4VBAZWdpYsLBg6bxK5AtxEs",
+	"9hlLafAUQCFtpZZKZO4t6KOcshgMIhh46ad81bntwyF+gJTEH3oR2c/rPasJuTg+9eGpnzcJGzREb8NE",
+	"68tJNuxn8OKEOBtZ3WH3jV0dG2/c2NGfkr19Ydyu9jqKEoUbfxXLtDhGU7qum8+Mve/idLAQP9xJ+sSl",
+	"4EDqO6517TcobHMWhT3oBiUKr/zRsw42zuSpeUhk2ZSaZjfxTSHMsq4pqdhCiMru8FHh4D30Vxym29yK",
+	"58rKasB7z97/10lxcv7y9eskWA+IaTwiN2F3FbZj257QoRpdC/LBjlTwNFheyRZ7la2YT5KBl5cLWdc5",
+	"u+OOgn1vhQm1KtncCH5ZUef/fXX8dlYjo/EGS8v7/n6g/mYZAwa+8kpZYdyOoCF0fF0LIyC82sdeE0dn",
+	"431uVPxyuPKtZeYR5WX9dvqANhXcYiD4PI6F7wNN7EobV2/8zT1i6cgfUmplhbIthjz9A+5o/YsgINGT",
+	"532Invu7DA+8+BE0SQEPHYRRNYGFveEfny5D42BY5VyAcgKXzlSO1VxY99ReTuRUePuZrCa+HcIvDuqC",
+	"I6ltaCraj54EyMNa2FySyOP2MobxPcvkuqkl3D2NnvO5rKXbhDMGxSfHFk3dYIBSaYmjZsfUQ+/3mqWr",
+	"F9KWRjRclakm36OiymtprVTLD6G7glRXvJZV97f4WApR2Q9e9vSxb0lpvE9viD7PhFVRA3o/2x0lnPV+",
+	"2AkXrKOlfJimyJdmJo/wutHGZcxZu0xWRl8nejbK3uqPdlb4h9HXzNv6tCICXuHVxHMt1lh/vP+SDTPu",
+	"Nl5FOzoTaevwLj9V1Ta1LLlLHU6/AF3CViyzl5Lq4UbmKNJEpGW8NoJXmyD50fXfoDU/2JwBLgdZneC+",
+	"jBP72FhkEU4RfX243+NHj/A+dlAsSoz9ZKFOeLzjsJP+mPFl8OHk020NoKHo88pswHKX3K7n9USP5gyQ",
+	"N9sAyJq/EnkJ3HEC4z48+KkzC8NSEJHJU6AbgjbP6dTv7GotVFe1rBKo45NS3DeksLenEHSeU2/M7xA3",
+	"IOoe5v0md9okUgW7t42icX7ZFTWUL1cC7y0hSKjZlq5jZxV+kDFLdUeKPMCftXUa7Y278msYT5iFzHHh",
+	"BEQ3+Tgz9A9TaFOINaN7UV/LP4SnC0VvP9uA6KG3Z76do4Onvpirn3J2RNs5alaYzuw93NcXB9ndYsRc",
+	"n7iQdRm+R0d/VwQnU33h2Oocn7Izgi0oOx2vqqc3bAuQiLagPSYYFd3Ui7hwLSjnYLzos0yxAxneuDB2",
+	"PDxcd9Hl0th0JwB49Wn+dtc9goGt0w1q81It/0qjRnYQboQPsKgK/9CT/KVo3A17iGXs4ndZRz7Tw4FM",
+	"Hit9rQAkvFpLBVly9ta6M+zv0BfaXu81yFI5m64K0A1vrb9l8PIs2GRHyKHMrZ1J1bxcSXFFYaLX2LCH",
+	"+wvx1ECSaNjfdwWaJCqzCG5CgjgaaH1llug6jFrDXC4hxzfv20tkr/ly7QspTBEaOc3l8sO1VNCFQX2w",
+	"DkwlBasMvwaDyQfbmit5pU3BKi7rzQdkHXNATZwdBW7iBRYRXnIIzZb+vUt2ExMN+y+rZV/X6obluo6u",
+	"pyXtU2xNmFEHOtNH3x/ECCtUKWYcv/M2kFuTGUd19f3WKONLN8rwMx2CtAM6In3BfhkRh+SETM/Hezq1",
+	"TtI4B1JhS+m9EobX9QFjjIARBijipeU29iZ2OI4U1cxx9A7dsxu6akKWGx481xJTZBmdFbt60SVjOHld",
+	"fwAq+rCSy1XBjG5V9YGwXYSxP+TH1iUWSTiIOF3WiHeVbrUJGw/7xT73ODSjFZMVqlUVo1UzrAzYmS2E",
+	"B4zvWggGAoDhEQZS3xjPBV9Kv/NdjpMtDWsL3XfSF+VrkJ+fSwhNljZDSA933a+gW3gWnTuiORLFBvZF",
+	"dWQtdMc1bUN/9btMultsz7FsUfPlUoDvgSnNaq2WwnStvcBG0ZvCbs+ctcMkBcC9/TSOHQaLw6NXJsas",
+	"5K0VmHBTtka6zTmM24Vu/T0V2vwUS6vg9RxDccwMr5J/FxtLKQf4mxG8gp9Q5GklmLS2FVWnXZziR6e8",
+	"kQ/hDjq7UOelbgRdxuFj8kDBS39FCRuuq2j0LkvROEpBNlibjdM39JxcchIWS4b/cKF4cvK/Hz59++rh",
+	"3zEmOcCM9vnpE9qIF3p3U6sQaEGREoY9ZNeQoMg2ujVsrZXYsHlr1IW6UNDRjglFDjaIbeSNty4YT08g",
+	"+Tl2vnuprkStm5BsyOvaq+Ls/wj/6D+cacX/mcHIPzeCor8sIzx3zbMQShYgyZTAyIUYcqTWdzAIbhE6",
+	"lC4UQYL9sxVmwxpu+Fo4zDdRFdlIpKV4CFxkl3M8JgLlDzma+OnbVxcKUIx1yKhkGHZQ7ggFCRUmxrZ+",
+	"gM4NNaBnOuwTN2MDlrXpN1VcKHDaNc7XooDCL0I5tED7FoK97elSqIIGoYIyABQYzDewDHWDiH68SnDy",
+	"dmMw/PcE1V5LJPF49mj2KFxFeCNPnpx8P3s0+/6kOIEgfGSgEYnDTz6to9sX+OVOfhTuKULeUl4Zkga+",
+	"/t2jRz5p3fkkQd6QdV1qdfoPS7YDkgV7IxNoik6UIb2POJu2b0fJxrF8OHny371kgMy5tVQnv336rTix",
+	"oQLxCdJ9VIbJjnl/QBwF8xVxGdac6VqGUlCUS6ZWWBI/lEgz2/B1za41VGGF8wI90H5GGJISAbzk0OT4",
+	"98fJShhR+GwK2QVC1YJfBYZq+NJ7+Ic4o9ojBNMTkrbgxdfV5pYRFmy5n4ZCHWTBpy1qeXxrk79C+D2N",
+	"ROMWEjyMAVF/IjodlTYk3w8z3Q4OIyNcAggwT0Y4wIinTn+X1SeauhakqQ3xdIa01eGpE2oWV4BHBPBr",
+	"f0Cg9WkI5yKC2f7sl9+2sPKndHqQJ3uCX+YdpSF4r1XVwdCjjUfg28mEMWyJqyJptX3xCQ3YSTbXGg1H",
+	"3IXUmyK0vu7ZuQiHT8yzBNYZOxelEc6y+70AQlmNxQmtvdamogvm+7PXrDQCLZS8tg/oODh7+eLp83cv",
+	"X9CxZIVLMeyPwj0PeXcHyFhY55BrxkrlFms8HwCHW/ZfT9+8Phh/PwqSob7a1BDk1A+XWQ83IypeOlFt",
+	"Y/HUu65Jc02K09eaV3YLNSFfTVcb2AL8m85r73Dvke2dzI022MWxc8mjy0U6pA6plqRF0GkQXpFLpQ1U",
+	"EPVT+1KkuCAMbbCalVQLu+q7DfhIh5aqSqsqnO++aleQ3D6vjwE2scClBEhp67o9rPllUrb/4mHW0ctI",
+	"aozgF047FEEUd7oIZcTC0rwyGzxSwNYewn5FTuugsqL61QukkJvcU6BnrpMnC15bsW2eIxE05UCaRN37",
+	"Dp3bU1EI4L900RYheX2bx/p3usozYMGnAwdg7QMPPGEBLclLcTATPgcPPcjQtvFCzhMqxhp1Pal7Cos5",
+	"UGlwoxAg7GljxJUU13k+PBOqQorGqMKHbcPiAciuBuaenhG69vp9H/6xWoS3r+6D7rVQt585ULWVCJpP",
+	"EDK0mU5PV1URKss3NUYdhZqaOl4IEHGKn97S1n+KtnNHKlM8hZ/1IP3p0V2uJEXH73qoDrDNLau5xAjY",
+	"qfpVQTeZDscoS0PZ5YYb68tqApVldY542XZw5+tMlwHZEge2wjGK1/jzo+8yyoL/wAiwNni3Zbzbwy8Y",
+	"fMOQ8XKw492kKOkLpnFBvK43SLrbzBoE9envkFz/6bRvOJG7sg06V0xSLX3Pk7xyORa+v90hgaYbbyRI",
+	"9G18e966GmboKHx0vP7aHas0b9BeF3RdD+UyDrw6vgvjsVo4O+qojL4x0ijhAf3Zm29aYBy2RSj42oy9",
+	"crd/u4xRdIcEdvtiONV45ovcXwcQTIlf393msHvsndG8v/F6Eh2QeqDP/TJr4pX47omr+IL3a0Lsvht2",
+	"wBicbPjBza/bQ2NjjK2+DG9WBTz3BSqgULGvlEyNG+Aol5ZVwsir0KskbrNgSf0l02lrhXlC4mVUSVgv",
+	"GLVf6LNnoO7eQ1iass60eDyDVzO0bYhSAynq2+fHhIz9guKP6H7TF9+dsbNWYfsizNhZyI+wDWyFpA2j",
+	"YGH4JSwevm50jUGkvfwEKMC2GqOXRlibkpQIsrOowvGINr67NYkyqAiekCXdc+bTtIns/r+UA6WjhTiC",
+	"39/0jyC9MBiv645GMNSuu8bz8nJpOsIeU2QnMnK6zmDvt2BF+5yKznS8/UPPCWcJUfGfet5Lh4IO+q71",
+	"oRHY0AbLiw9q0KAGjTGkx5p/Au3T/c8M1xqhEq0IWRRS6SIquHTn9i+aJhi+2P2UKQRD7SyzouwsJtGt",
+	"9cHB4HrpkyJ8+vK2bShvfkRtsU0AjcrZBZjttAE9ra32EcR7LD8hbWPbBHR75p9i27TgU0Z6q1iwXaz5",
+	"pVdG15kFdKke/xcYoLbrGKbsux6Ea14Jdn8rDwl+foBx7ugXjhC8V8H0rx1I+7jcgZGKBvLFBAG9QINY",
+	"eRD+SDBHt9pwKRZXodpx0gfwlDnx0dFbDzGWiUosb7CkCn5LGknF7Wquuaksm4sVWK8aoz/KwAiVJuOE",
+	"tRfqVzE/1+WlcPaJz+25z/0/IDiRFI8HBf7jYei8AO9Qsypf9SYE+T+ADV6oRtUPceOC3Xc+YivoLsxx",
+	"0PnQRQxfPZixl6Ad4frvWVJX4HxW7CX8dI77fEPpgLML9atnoYWsHUCUtK9YBN2zHhjI8QQnUWW8E9Ec",
+	"+8QNBfIT2P0EFM5pO/FT6vWaMytgHMrLSvF2XFtmlx4/ffpAUvcOW4j/7ORmSsIWUR54coF5AD/3uyuo",
+	"WVWrnKx9/XAJTyFdSyslSmezTP0UvsNuBkSgoMcGqwXeN4Dl1CZQT1bxgDUthKj8kvAa4E1yJCk6OUC0",
+	"w2p5JTqys+wcvTYPz2HdSGI25vBwdaTSPcipu/RAHOB19O4fzuy1tYMEGeA7LAZJDjn05ujW2CvmXF2O",
+	"VBKIX1OvQ4w9B21xWXt0EV4A16drf03M4eEHIao3rRNnbS3uNGhkOFECVvCQGXga+x66ginBtbyt2K77",
+	"D3F5vTeBZD7AIauanadAcPt2rNHOP58eshfslOtXRVDcq13Erx58w2xq7tsUR3irxEKqUURBF0uAdNxL",
+	"4aQa8b5Z+hommnHWKQBeNxAgyUzofWS78oxON7K0sTy27RyGneNITy4UWkAu2kePvi/DCYd/iVjr8S+A",
+	"dPIP7wdzRNOXLIzMG6Aj+HtYkOj8QvX2OfjRPijo/0/gLn4/tGMem2cehFqIT65XvO5mhiAmt2LcXaha",
+	"8ND12fvkMOyOY4kD30gVdZxYTj0J2j3duUJLNUzPBbFjQCBhLO6DGXuOsLPBNuShOt9cKOsb9QAV0qEC",
+	"VZZgLl8LISSWlwLOmv41rxt1r2U0nf6DfYrOO49q3aOYYdtR5s/fqfoF7e4g9eIxWaNHFrprSd0ovKzq",
+	"abYx2ulS11k+/Em7AZEP3Haqc1jjSnec7IEbNEW9duMR1y1rPef1w/RxnvKFNESIBgm7j3O9Z0NIZJfZ",
+	"AAQUjUsuStuXHNogg7D78N8ZrSM6Z7HbxIOi90XTG0SSkV2yOysztPPjeOCMCjLCP4VpJ++wjx89SlUC",
+	"SY/jY7qTAz2aZKy+vUNiGxSJg4JeGiozW+fxAO8eMVvoVj7aeqTREAJVd3PE+gCncFK6zS4VBvP8X9Jr",
+	"e6TAmQAmLUkc4viB/Xz2mpfKXpnq7xLH3jQyyEej3knSW7GzPnJ6NKGqo8YaSRXhiHdGhQAtgKGv56Cp",
+	"q6/1rbxUqq8ucrRG1ErFDPc1Ozl61EPD3hl7uliI0vk+vtFJOfib6nKQ3aFLeAGaKlBbDlfxUGawj9cf",
+	"AUkJ56jX4qHWp7tiuohsk7F/6znqRESmng0O9BgOeLMMIw76JhcIvpBz58HIOKaZoRtgyKSxJ5HYdOKF",
+	"b6egTfdf8IlPBYvynAo2SHsqmM9rKhilNXXBJaGJL2dla51eM1tqE3UajJY9w0fW60dZCrLauGebNAHF",
+	"WVpTZYA27oU0IvRSSI0KcIkKZ3L8C39M1FG9KbFOyhKK0Jhps7BNyq/H99+ETvPeWzgAKj7GJkHC8TFC",
+	"2Z1ElluUeNpn5+UI8hd8Y0iWXz388JAkHcnvMHMn1taxSiyFgl17Wx67DzmlwrpeFaNByEtySjLenlrB",
+	"TbnKwu4cH1OhHTtNafrnUe7+6ZrXd3ehMR1Qb4hAkuuQlLB1gImcyj4SFEdaOg4XHgKyMYPIq7wkjx/i",
+	"zREub8KEYkl4t5QVCjc1xOnvUb3jT1lN3iM1VM2cb8Z+MCNYidVJ8FnoikG+eelsMNWS0UZb4fsjw3iw",
+	"Xfh+IaIufGylW1Nvwo1cGqq3ZYOS6DUHsaEWy9QJLaPQvwmpnPsNicM+hV+HPZGW/0Y4XnHH01SDG8zp",
+	"APQYVQCPo41wI8oK8qHkSitZ8pqt/YR0VnrEB23Pj9Mdmj9yuCJ3lplgop8Q3Gg/i3T1k/mM9EmyVVos",
+	"cdNtZRtecMqEx+w+KB+sEbqpBVtzLPTndNc/yT7Ihww+DVcM4ALuHMEWefrt+3fb8YDIS6e/h6E/7Q3v",
+	"uyPb5WCOLxSEN0ZsPrjUN6A6LAYvYZ95Q/VdsTmW1026TNEsC57FmVBDB0s2gsejPcpKgWJnIZRHfJTW",
+	"HW5nJZyNVfWOwibq7B6mB9tIpmrKQa+dXOLAK/vJLgL/Tlr2NuAnK4v+0z12mugpnOSBBu/z5dKIJXch",
+	"7OvBiHBINA1jQnMETUe/bzEk1TApzAZG9XoAd33DpNB2KyHvXuCsvbz7LD7DHfdtgkJ1u1Jk6zKPl1qT",
+	"ly0HCgcCYhz8Dv3fQmJbZwkLql8I8PEdQfYIiz9s/oIv+LaDpahykb2REaYZjkVSf8x1MEMDV4RUIz1R",
+	"V77LGCCFG8FaRZ6XpGI8qB76h4r9T9Y9/czu0/16R/Cfdgftl9A77lpihAaoUQ6Brzfr7WyGUX3Z1Glx",
+	"GiTKBFUj6MhfpxQ55Oz2OznkyO7gdBMBA7eVMNAw8YNkjVSVvJJVy+sga5Iou+KOm7y7nQJQ45ynyLmM",
+	"pFCwBa9roGQIGQ9uFl9LkV6BexecOJgPcKH8qslnv+KWCrw8H40bBb4CNfjWPNIyK53An42oUOHDm1nG",
+	"VhCQRNu8q6SV0VWTm6Wwjl3LinqrroSEpklSsUZ+FLX19enA2oJ+qe+/K9hf/lSwx9/9L3j9uz//ZcZ+",
+	"Xsu+vaQ2cikVYFL+S8xyZmsqWr610EMMZQj50/855IbOzTSXiptNwvK9XRYG4R0IZGRJoqIAXFX0ACQV",
+	"PKPIDGSK71OJoWce3RT6EWidCAzH7GZYGNxRRUOlc1bZWldYj9KH38N3L9/xpU90loq9Wjz8SSvxEG14",
+	"01kVEU6lyLyozCS6Yp1RsOjVFX49j0xmCn4KcCt1M7wfDuVAxJsEjDi4BoZAhQ+fQCTtJi0I5lwpYXaE",
+	"7/6/f/lfH7/781/Yf759+SMwNNof5xv6v5O1sFT8twHceg4n8v5LQf5GaGwUVWXoBME9OxYXpqDCHNJ5",
+	"UCrsVVzqWhvWyPIy2KqCKRJYYMZ+9Fbw6kL5Pl5jWquiyEwSfbYPwzbCw3+3LHlGkPpCBxdx6D8asbwx",
+	"k9JGbsCkX46z4hP0zyktzO9twF3BSZI8Tdk8fLEmj7RaDqgEraY9p/ULSDFT8LlOUIRehlf/eHGxYeWp",
+	"eNju2Q00HDz5Yhd2573mjhlhdd1S9n7vw04rRGkkUXJsTuD94I/eUTJtMaoWR9c65qKs4HS5pD2V9Fi6",
+	"kN6MvaIMdetHC0lBeuFrj3c7Bthw6moCTyly0G41Ptkt236GPfoL8h/1fh/vIUGa+Hh4xZ92O+tTrCO6",
+	"GN+wtjLy/ESMz6n8xLYIIT0Xx+xLCq5kJewOuj11/OND6uvz1ZPwz4oabMWaAVe986mvTqOt85WQo/if",
+	"4CJEdQDZ/ip9RFNGX8hS5x+F/UwKv28NaLF8WVghQUtaVvJaqIobthHcsPvv3z1/kNHg4YWbavCY0FLa",
+	"q0MzMP3quWXPz3+5W7YgPA14oQOaGayDhuUffbEwD/5tlmjiutN7Dty4cvIdljA4xFMy9n4EB8X4d6zU",
+	"rdwvXSdC7Esf/vT3P6GqF1T/Oe41+AUdKcW2hQyPNFa11sXhgBTKXgtrKYZwFMqOr/ddGLdX7ou5vGit",
+	"u2H2a2mvfE0BG6pYCWrMxi3jSJnk110L5euI2Qbbi68Excqk1udV9DRI8ajrQer/BEb+wr6pwC/p1kXH",
+	"SZsu5rFn3Hy8mG0b6hTHPABvJTaym/lY9bEb4FSJ/Bnsdzo2ooWJ8LRTrOESrxiL3TG3eAcJLdR+NpXw",
+	"UfR9iGftTU/UsXqnqveTcHcvCr9yxv8sfBQBeoqR+CfhxmxxrAtqOokzJZx3bngCSxO9P54nHLNeofis",
+	"h+yOwME/32HGxjFHfn999Yd1EPxbD0adZoZnfjjsv6bj/d/98JxwZnrij2rk3fyo7FTkL3dmbi8BwuRw",
+	"qw+OPUb7Zpv7qibSi1+JPLnTFLBjBErf5rvng/i3q/iW8DWJizdSyXW79jYqpR1W3cQGR7quuckJhLVU",
+	"3c0nkRGV7bnzTVyNWtferpjy/Py5RRNNe6wQGkfg7qqFCJbFcuUXh710PmMpxKj6yldlc50Qr9tZ+WAP",
+	"aBbF5qd3Eh6jDc0Shcl0M1MRYm/KjOur+LLqa674kpq0RpE0F+qI6DvYYkj9R3dlIhIPGxdnIvDSNcbc",
+	"N/q7Of2FsPzPRn+3GqZFNMB4Rzi+L3ZMYGt91dEXEZ/yPZK8DERnw6l3KZ3+7v/x6bRRdaSObYn5pnXC",
+	"ol1+QWUIuJlLZ7jZBPcU04pVYs1h2yG1RxMwJDKVX/WMeT35QkX5RjgqW4hrtqa+sTNG1aMoxOM6dFOO",
+	"nCXSMqEQkn348V8vFL7aNRTiJjYqUKzCurXoGbbI7VO8Gxdqy73hIxZpBkzCQ73R36b9/IWv5hTqj7x6",
+	"2zG772WWNNXgHn1bcroA7kyo/ZXXtXAdHu4/+sgWuq71NdmJ/vSIrcRHVq644SUM0Tkihlzuv/9qmDwC",
+	"QIrBqaVm5B/dG5s5eG8a83cdhXKsPyDHQTrId4l0kLOOThj0IhPY78YIZzY+yQ624zuBQrCNKLWqsCTl",
+	"Gbz08OnClwdMhhZF3ecGERCh4/g+p6WLO5XG/RN7yIEICU3R9heQpkj0M//+V9RVKSzpmAD/fSTRjT2I",
+	"YPF9MCBySq8FSCEBxkl41MFz0EZhJ7a6EP/wbRi/zzLi666t2iic12MRPjCnvhDQrlDQcQ1IXnmyxQJB",
+	"8A8YFXfpfxlUMi18yMJaVyJ+QpUPQlmh6NDo0hHjFipRGyFtBo7qp29fzdiP/RRdOx9Vdf92Ou7ZjnDG",
+	"wrw+e2UR9wnMSWUq+elret64mMyf/6C1ZAZQ2NUNgl4MGMjE+dR6iRV9ezTlC/MWTInrYa/BUyuUk2u/",
+	"qZwl6bx76Zay286DyzVKb/O/4b+DgYKyty0MFZZwXmrzpQ0xt0GpnzX5PqBvWqz9Q39moXhhPYVsU+Do",
+	"jThPB2XQsHBNtmiKJ8WNKuOC/UMyfGfkcknNlzOl50flwzaq3FseHl+SWMAHzkU6XriijqhSWcexwviK",
+	"d11fK+74nNvD+1v59fsCuHFxDubT2T0ITk27Ox4DFn3WqptLUCgKseYfwYoIRAp/kU3x5MnjL0WxfnNT",
+	"KBWRB8BKyLUhmfqi8TZ80B3y0rDK9/mzBR720A6YpCaaMq+NdC40l0Ds2K7z9y78+P7gkzA0tTJw6lvs",
+	"pv36M9f92Ic/v/kcyjwEc9rf+4EBIJ0hSLU9bD8aYTTGs+8zjojb7yvZ6ST5qr2lN6EeK6vhd+EEfPb+",
+	"v06Kk/OXr18f4YpAj0mBVRONDBG4wevQFzW9dffE/z2uHypD70K56g6ktmBWYJ4PBm7AP6wQfbX0Kgt0",
+	"auiSWCldH/dHfr33ZIgqKAThFsxQKVvbGw/7he5Yx3vfQT9xcIwAsx1Xlan4UnInltrI7Or8C5tpK3ze",
+	"DXdLy0Q7XfDEOCwh520Z0jKgyBl7Ebr8Os2++xNWBbKMLzXZ9VAPw1TCTYhzyqz/+MKK+SV3nYv8ajNT",
+	"304Vxufg3Gys6OtXWyYVVqoVTChnNoNyjF2HI6n8bxYVO+xtv/ADzFio6wS829cOY63C4CqSIkxaSsUp",
+	"QnXOICvwBRtSgGruhHWh8xMKkWhIKkH1L/zJB63hm9XMm7XgBTobsGcdSU9aXr54IxLASNAF4UIfHx+C",
+	"Eghzild3xp5zcBPNRe9ixKPZL3D2ze3ru9jUAa4IHmpN4MXFzvp/HpC+BCCZ1cauYTSKES7RxD5CQiYp",
+	"2mtPmWX1etTpnvSJM4wBMCIoz0jRi7rFniHU4HqDz4FseltVuFlhCVU829q6vlAUJ4PnmrRMwVlGUZ7A",
+	"0GLdlTRNpTQcotXtpEBAcE+A9JeqkHim6wVfRlP7pmPdQdWijw899ndy/dHSAemWEZv5ZiQHBYakKprz",
+	"XuKsQ3FF5DLfUscnjmgDl+laKvGwEsEf95/nP/+0o9MrvxTRcRENiL/5Vjx+OzP2jibFfnxBQlDDV3rD",
+	"nl4ouRXLPK/13Pd3jcslh+M92a+QXwmEJAmDb2Lgmxg4QgzcXok+oMfK02Im9de43uJ0UCDYnx99n8op",
+	"j9lGxiVIDjZf/mqkE3uFiC9iEs26X6xEisXv+P9X1afeQ7rXaHPWvTnFOeon+PqK4oRtTDF8dlue2CH8",
+	"bId7dLvwqV6vRciLFmv9Dxm5V72PVKtQCTV/KpwRfLFITQgz6GJKIsG+GPhvd8eSXKhcquy7lehGYbJb",
+	"KJ0rMOKwGhMt51KA7vg2NKm32SCU1OnytKoG9He35Hf7xcN+Etc9zX3eWqXDeccRGIg4E7HDnmph8Yu3",
+	"E3/wLkRUxzFoGR662zCVHQEMb7GIQWBXWOKQWQdMCjKWPG25S9vV48HFH18OfIJmCLz+z2HzKJcK+EE6",
+	"Vskq2H8avpRqOWOvOWwQ+kkhvK4NbyiaTirGFdPzf4jS9XEW6PAN8QfJ6IKiz3qvimmhBsGIuDPq61G3",
+	"5b5d1IxdPU783AWdccJqzo7xR8p8iBTRLnG6/8lXH30Ki6i5Ra+WqIKDfkotWl9/33/R3cV1I1ScQHk3",
+	"JWv5zT36PjsSvFn26+mKgpS9K2zkrS8c5z3ruRZZ4KaHJlckpLTxNseUVjAIKEG+JVYnU2XjE0/8R1GB",
+	"zvRtsY8nDx3uOxMQFbcF7125KWtRRFxnBFqu8yW+fUT33dX3hgm+UHFv3FuqtCbA8cB63kV8Eg5qcn65",
+	"Gt+4j1DQ25PbwTeUc6AP+hppE6ksOvqyaSrjgm3kW76z2syTUw9uKWcg52K/k8rM7+8yev/c99CLEVzQ",
+	"PvxFVJpQuYliavLFmD83Fm73AMhXaXpvx0WajgmxaEejHFVrmfnw8z6e1Ih9Ij9foPkzIOyuKjQffHI8",
+	"uvuTwxdlJhn5tZwcdyo9uhLNo7bxUZh106ch9RHX6SNkuwBwUsQcUD/3KLr9VkP3Dmro3m15ziGRR7U5",
+	"B7Vc8ykq8Vu3UQXXs0GUqzJYyLSquFvsATWtoW5tHNA7NhCWWlln2tJZ31YR+y29/ek1YKQxuhQkQyKr",
+	"c7kyWulaL+HVGgxzWGn7h1c//Mzu/yCNdQ9fqYf0j59b9wBL47E5txKN0yWvy7bmLi6U99Pr2YUKFXAt",
+	"q7iEpDnFG7vS1O60bNdtTbel0WfPNsxfBqMvjCi1qfo+vXTwXYrGFaGZc9i4qKLv0OKxwG5gYOoMnSa7",
+	"6BrBBDe1FNSPCS0q9ymKie5imy5X1ogrqVvLAhIepI7VZ/4h0GMyRe7OZFTIvalroktYfgeGgtG9G38k",
+	"ZUErEM4eDuAfCW6HISQ9wDISiiAlvp6LfIB/aIqXqjNMb2BtXVTWmW1L4AoIjdhMVu+ypX798AsujzlQ",
+	"u++BYwO1kmKHBlRsmtYiByN/9qy4R2Y87ON1c6Jj2dbceO5qYik1DGLpilZvKIUPSam7adJVAZwHkkax",
+	"FyqMg2971eAetnevRdgk2j4X3CB/MT6QnXjAoGX0/uNHxaNHj/xSHhQXymoCRNxPNuwZhQRETq2D4oR9",
+	"C3np5JV0mxl75dg1l11ZBtMqFVhkH3cfUAHmq7vt4No/J6vcep7IHXHfD3DY98c3bNPzXWBFIDHrwtkR",
+	"3LaevHwV+CwnVrtSGp/hYzzWBHjuyRSCfg3kG+p5qSrGrzmehNxRw1UNYGoEZfnPcoZ7Gv7rpddJDt1u",
+	"I1M8uk89nAjuE926e2/x1LZ9JcWV8C5dutiDvjkXQgX0ZIigrLlc54XwK2tbIAKmEKvhuhAKFWPJZOY0",
+	"a9quRMBcalBdIo/wlvJ5obz2aQtPUdcrWXrtExYEbHglDGninRMJf9kwoapGS+Vm7Dm8i73UpQG/MQ4V",
+	"Aqf/CoPWAheCXK0qau4Ff3URw2TbG0YboQ6RjDbCCf+4ViXfjQd3kU4LJBgRURxFkmSg5R6NWJNz++7B",
+	"Q1egPEmeErLzlImiMZQO9XcZvSDRNKS7vi588DBG2Qo9la2EulAcyReAzaX3kjYxUO5Z4gQKRsB/spIr",
+	"Kh+Aad291QsZQZXiQoVJZuw5VKAnoRpCEEJUO7ohv3/UuYk7CZqgw18QOIAIwuUfkhpx6bgT/32yID2V",
+	"dw0oxQr+Nz3of9JDpKINSjovR/L+fkQeYkx7FbTe3GJdip9660AZuq6E2z4SVc9BI6bDhaVFLVChjRpL",
+	"D6g4x4C62Ty0cp1VDc5ARG5sPKPXPDriRw8Jq7gTBbMlr0UVIr7xTfikEfySVaKp9QZKSEWX+DVvujAz",
+	"1HnmXF0aXdcz9qwN1WpqGdoO8ysuazTylNyuSCUSdW0vFHbKjqJWTQibIGqCzHO8VttoZaG9NjvzAQLc",
+	"mwuAadyGla25EjsiEp7rZnMuyZgwMWLo2Bt36gpc8kY6XmfDEx4VN4j+PC6v6E6FyBDaqXRUeiqqAQL3",
+	"2sMDHI87Bf2cZJfr+r6PmMX3liDTYiDxDE9OaZoDSzqoY85XdyTcqGvOXlW5g/+EvjkZNKDHAKb21eeG",
+	"KxiHN3Vt//HYmHvnmfiIISdeqPtKXVErYqj4pSqUSMtaz3m48kEaaDIEnjCPk//hnGe46jcaQiz/8L4z",
+	"NgfIHEKeB5gCXmINN6ZNcI7F9YLIXJSPhTilIkL5BnbDWkO2qWXELtdYOM1nYFIRI9vOHzbauIWupbad",
+	"FxiMa304D47mk8B8MLvTS6pe5I/ci5NW4WuiujihD0Jq2IXyq+H1NSgbtl0HnSCIUe14vSs40K/qR9r8",
+	"H9vUEO9lkrUhRinFaxUeeR6uNzQ84JBIebEZ1c/XXfx20uPp7/j/T1mBehbnb68FaCddwCp+GlFe5xGp",
+	"N8EUQWsBuau0u1A+0Gcu8EbREd5fGVdMrBtHoaj+ImejSfJCd4CVO1f1hkMt/aRfXIbHQLhDMf652CS6",
+	"y7V4Chwk/wtmhK/GSWOG1tB9hERUXvH2z4nzYBPs+EKqYIzjI5OIlwQZHh2WOk0K2Lt2Kd5ucYO7q1cQ",
+	"erdwNheqXMHVm1lhpLBPWGurkt0PV8335y+eF2xRc8e4Y/8SRj8oOg3xPvXUFMZH98Kf5H4YhOc+KELZ",
+	"1ZDoEs/b25/CTyEVJ1tYoHvz5CAXb1xIYFw1IFxuQms79BOi2baz0hGA/tgdLVT9N++QvkEmcORa3VsZ",
+	"oMdzsihAJswPTELxJGl+n9InDrn+oM5ItxNO8K0t2h+jLdod9EPDA/G4vi45ZvC9AuNRd7PEzjZmb49o",
+	"UPaU3u2EoRGVEGvSp//HY3a94g6rYFAEDHjE8V6E4UPwm5+JonLhRd/TG3ucGeo7TsYGsnLcoCUawP/g",
+	"fmj/plz/x+2JNikc/J7tR8p1Pdtikgltzyg4enrPs9vUF79cJb9vrXT6ZOBba/l1diedvqZyx64mX7vZ",
+	"4/R3cAZK4opP2bPk5ceGq8pSMlxbO1Sg0fDWlwgbRgJZuHyqUlAXF4w9qzU2zhYXKngahBFUzAETnZxm",
+	"wcLcjdibvmfsNXxPxms8yri7UP1z737TlmJ+KGS4cSh5rXCupv4wjZHBTx65I2lBFwoC/SstLAKdzINg",
+	"4LZ4yoE1XnYl5zDoVYhdhj6irwNas9+iGSZC61fjRxnAI9vQ2if/HBDErnRfjS8in0xYkn8VSK0ny7lY",
+	"SWzG33MULMUbKaYcNENOmlZFhDZ8WBmRfwMa+eJVSVLJPndSqGQnNeXSlJ8OWv3DFIPiILW8FNF0WoVK",
+	"kqk6IUMK+0MR2Le6I5+x7ghyBLIBUuq/RQGSw0W5k2tRSyWyetAbWQvrKJIfQCGcKF0cXRfMWqpPSxgF",
+	"Jz+B5BM04bKVXK4suw96jA9RFhjWs3lQ+NJWxjqGjQsQnQvKn8PRCX6W0nqupY/Id0bwS7zT/7lgjx/B",
+	"wwv1/SO4hQIsMY+oArcl1SyXOO5b9XqHEvMuwOQruyF9bd0DApxeKkcG032HVviACeWMFFutBPZIAQLP",
+	"zaNO1hFJqz4MfLtdyza37C1t/zkyQL6+PsDfeuX++xVNRn46ok9u7sY+SJfZyV+nch1KHmeSIZQVxg1j",
+	"XQmvGLTrPV6+mJ3R1wWzbblC7KtQ6LUxouKY29hswCr7HOon+fM+XNCdDhFfVHrBpzXjb69wjbPSf0ZG",
+	"z8LnT1RR/Xj4gpaCp9SwrDNr6hZXFRzBNN6M/Qp7IJD+RxPd2qNyrqGwepwHEk5gP7pesP7jWanXT9gc",
+	"43ZDZC5ul8CN3SbRx2lCSWl7iaG9UUUwtwUgXwEEpSnAR7pazFinuIWhusAjnLoblx6G++kgUzCRmhqH",
+	"a8YF05X1StCK21Xhbe6+8vuMvfLb66Yxwle2DpkC8w0lt5aylsh0lI1iXWQwSekLNPJnEvkjGefpjgLO",
+	"gPTXvGloL/toNKIn+Lzf4z2bIaSsw3p3AV+aNy7i2/3Sr2FSqfx3W1kkjEcLD/Q+F7VWS2C7ggXg4h6j",
+	"alprytHjpBz70bIb7JuMHoCcX4D2uRM9u1ImOpWcXXHHrkNeQeC9rvgW/UAJUpk1VWYDTZsO95fk7peT",
+	"T4jPFw76rqffM5Er4kvPPWB3HFIgVjBSE3BRoEnX0wYRJJNdqiZFdGjN1mDtBPF1SJLL4+9TZXvqzhuI",
+	"ZEdVMWZr/hFQ8WzjhB1f/fYGF/mdR4Uc0mfhyacdIwNY/MC4JBJZralPnpyc8kaeXj0++fTbp/9/ALZL",
+	"wZSEtgEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
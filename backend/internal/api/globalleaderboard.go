@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// GetGlobalLeaderboard returns a page of Polymarket's global PnL leaderboard as last stored by
+// sync, and the global rank of each tracked user
+func (h *APIHandler) GetGlobalLeaderboard(w http.ResponseWriter, r *http.Request, params GetGlobalLeaderboardParams) {
+	ctx := r.Context()
+
+	limit := 100
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	stored, total, err := h.storage.GetGlobalLeaderboard(ctx, limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get global leaderboard")
+		respondError(w, http.StatusInternalServerError, "Failed to get global leaderboard")
+		return
+	}
+
+	// Ghost users are left out, their addresses shouldn't be tied to them publicly
+	stats, err := h.storage.GetLeaderboard(ctx, "totalPnl", "desc")
+	if err != nil {
+		h.log.WithError(err).Error("failed to get leaderboard")
+		respondError(w, http.StatusInternalServerError, "Failed to get global leaderboard")
+		return
+	}
+
+	users, err := h.storage.GetUsers(ctx)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get users")
+		respondError(w, http.StatusInternalServerError, "Failed to get global leaderboard")
+		return
+	}
+	officialPnl := make(map[string]*float64, len(users))
+	for _, user := range users {
+		officialPnl[user.Username] = user.OfficialPnl
+	}
+
+	owners := make(map[string]string)
+	tracked := make([]GlobalRank, 0, len(stats))
+	for _, stat := range stats {
+		for _, address := range stat.Addresses {
+			owners[strings.ToLower(address)] = stat.Username
+		}
+
+		// Polymarket ranks by its own PnL figure, so that is compared where it is known
+		pnl := stat.TotalPnl
+		if official := officialPnl[stat.Username]; official != nil {
+			pnl = *official
+		}
+
+		rank, err := h.storage.GetGlobalRank(ctx, stat.Addresses, pnl)
+		if err != nil {
+			h.log.WithError(err).WithField("username", stat.Username).Error("failed to get global rank")
+			respondError(w, http.StatusInternalServerError, "Failed to get global leaderboard")
+			return
+		}
+
+		entry := GlobalRank{Username: stat.Username, Pnl: pnl}
+		if rank != nil {
+			entry.Rank = &rank.Rank
+			entry.Estimated = rank.Estimated
+		}
+		tracked = append(tracked, entry)
+	}
+	sort.SliceStable(tracked, func(i, j int) bool {
+		a, b := tracked[i], tracked[j]
+		if (a.Rank == nil) != (b.Rank == nil) {
+			return a.Rank != nil
+		}
+		if a.Rank != nil && *a.Rank != *b.Rank {
+			return *a.Rank < *b.Rank
+		}
+		return a.Pnl > b.Pnl
+	})
+
+	entries := make([]GlobalLeaderboardEntry, 0, len(stored))
+	for _, e := range stored {
+		entry := GlobalLeaderboardEntry{
+			Rank:         e.Rank,
+			Address:      e.Address,
+			Name:         e.Name,
+			ProfileImage: e.ProfileImage,
+			Pnl:          e.Pnl,
+			Volume:       e.Volume,
+		}
+		if username, ok := owners[e.Address]; ok {
+			entry.Username = &username
+		}
+		entries = append(entries, entry)
+	}
+
+	response := GlobalLeaderboard{
+		Entries: entries,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		Tracked: tracked,
+	}
+
+	// Every entry is stored by the same fetch, so any of them tells when it was
+	first := stored
+	if len(first) == 0 && total > 0 {
+		if first, _, err = h.storage.GetGlobalLeaderboard(ctx, 1, 0); err != nil {
+			h.log.WithError(err).Error("failed to get global leaderboard")
+			respondError(w, http.StatusInternalServerError, "Failed to get global leaderboard")
+			return
+		}
+	}
+	if len(first) > 0 {
+		response.UpdatedAt = &first[0].FetchedAt
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// GetUserAddressGroups returns stats for each address group of a user
+func (h *APIHandler) GetUserAddressGroups(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	groups, err := h.storage.GetUserAddressGroupStats(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get address group stats")
+		respondError(w, http.StatusInternalServerError, "Failed to get address group stats")
+		return
+	}
+
+	response := toAPIAddressGroups(groups)
+	h.respondList(w, r, response, response, unpaged(len(response)))
+}
+
+// SetUserAddressGroup replaces the addresses in one of a user's address groups
+func (h *APIHandler) SetUserAddressGroup(w http.ResponseWriter, r *http.Request, username string, group string) {
+	ctx := r.Context()
+
+	var req AddressGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if group == "" || group == storage.UngroupedAddressGroup {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid group name: %q", group))
+		return
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	addresses, err := h.storage.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user addresses")
+		respondError(w, http.StatusInternalServerError, "Failed to get user addresses")
+		return
+	}
+
+	known := make(map[string]bool, len(addresses))
+	for _, addr := range addresses {
+		known[addr.Address] = true
+	}
+	for _, address := range req.Addresses {
+		if !known[address] {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Address %s does not belong to %s", address, username))
+			return
+		}
+	}
+
+	changes := make([]roster.Change, 0)
+	defer func() {
+		if err := h.roster.Record(ctx, roster.SourceAPI, changes); err != nil {
+			h.log.WithError(err).WithField("username", username).Error("failed to record roster changes")
+		}
+	}()
+
+	for _, addr := range addresses {
+		inGroup := addr.Group != nil && *addr.Group == group
+		listed := slices.Contains(req.Addresses, addr.Address)
+		if inGroup == listed {
+			continue
+		}
+
+		var target *string
+		detail := addr.Address + " -> no group"
+		if listed {
+			target = &group
+			detail = addr.Address + " -> group " + group
+		}
+		if err := h.storage.SetAddressGroup(ctx, user.ID, addr.Address, target); err != nil {
+			h.log.WithError(err).WithField("username", username).Error("failed to set address group")
+			respondError(w, http.StatusInternalServerError, "Failed to set address group")
+			return
+		}
+		changes = append(changes, roster.Change{Action: roster.ActionSetGroup, Target: username, Detail: detail})
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"username":  username,
+		"group":     group,
+		"addresses": len(req.Addresses),
+	}).Info("updated address group")
+
+	groups, err := h.storage.GetUserAddressGroupStats(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get address group stats")
+		respondError(w, http.StatusInternalServerError, "Failed to get address group stats")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toAPIAddressGroups(groups))
+}
+
+// toAPIAddressGroups converts storage address group stats to the API representation
+func toAPIAddressGroups(groups []*storage.AddressGroupStats) []AddressGroup {
+	result := make([]AddressGroup, len(groups))
+	for i, group := range groups {
+		result[i] = AddressGroup{
+			Name:          group.Name,
+			Addresses:     group.Addresses,
+			TotalPnl:      group.TotalPnl,
+			RealizedPnl:   group.RealizedPnl,
+			UnrealizedPnl: group.UnrealizedPnl,
+			CurrentValue:  group.CurrentValue,
+			OpenPositions: group.OpenPositions,
+			TotalTrades:   group.TotalTrades,
+			WinRate:       group.WinRate,
+			Volume:        group.Volume,
+		}
+	}
+	return result
+}
@@ -1,25 +1,60 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/samcm/pyre/internal/avatars"
 	"github.com/samcm/pyre/internal/backfill"
+	"github.com/samcm/pyre/internal/benchmark"
+	"github.com/samcm/pyre/internal/blobstore"
+	"github.com/samcm/pyre/internal/claims"
+	"github.com/samcm/pyre/internal/concentration"
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/netting"
+	"github.com/samcm/pyre/internal/notify"
 	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/presence"
+	"github.com/samcm/pyre/internal/recompute"
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/scoring"
 	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/stream"
 	"github.com/sirupsen/logrus"
 )
 
 // APIHandler implements the ServerInterface
 type APIHandler struct {
-	storage  storage.Storage
-	sync     polymarket.Service
-	backfill backfill.Service
-	log      logrus.FieldLogger
+	storage         storage.Storage
+	sync            polymarket.Service
+	backfill        backfill.Service
+	roster          roster.Service
+	feedMute        *storage.MuteRules // mute rules from config, combined with those set through the API
+	scores          []*scoring.Score   // custom leaderboard metrics from config
+	avatars         avatars.Proxy
+	public          *PublicAPI // nil when the public API is disabled
+	reactions       *Reactions // nil when reactions are disabled
+	claims          claims.Service
+	blobs           blobstore.Store // nil when no blob store is configured
+	benchmarks      benchmark.Service
+	presence        presence.Service
+	concentration   concentration.Service
+	recompute       recompute.Service
+	config          *config.Config // as loaded at startup
+	tradeImport     *TradeImport
+	stream          stream.Service // nil when the feed stream is disabled
+	notifications   notify.Service // nil when notifications are disabled
+	limiter         *rateLimiter   // public API requests
+	reactionLimiter *rateLimiter   // reaction posts
+	log             logrus.FieldLogger
 }
 
 var _ ServerInterface = (*APIHandler)(nil)
@@ -29,13 +64,56 @@ func NewHandler(
 	storage storage.Storage,
 	sync polymarket.Service,
 	backfill backfill.Service,
+	roster roster.Service,
+	feedMute *storage.MuteRules,
+	scores []*scoring.Score,
+	avatars avatars.Proxy,
+	public *PublicAPI,
+	reactions *Reactions,
+	claims claims.Service,
+	stream stream.Service,
+	notifications notify.Service,
+	tradeImport *TradeImport,
+	blobs blobstore.Store,
+	benchmarks benchmark.Service,
+	presence presence.Service,
+	concentration concentration.Service,
+	recompute recompute.Service,
+	cfg *config.Config,
 	log logrus.FieldLogger,
 ) *APIHandler {
+	var limiter *rateLimiter
+	if public != nil {
+		limiter = newRateLimiter(public.RequestsPerMinute)
+	}
+	var reactionLimiter *rateLimiter
+	if reactions != nil {
+		reactionLimiter = newRateLimiter(reactions.RequestsPerMinute)
+	}
+
 	return &APIHandler{
-		storage:  storage,
-		sync:     sync,
-		backfill: backfill,
-		log:      log.WithField("package", "api"),
+		storage:         storage,
+		sync:            sync,
+		backfill:        backfill,
+		roster:          roster,
+		feedMute:        feedMute,
+		scores:          scores,
+		avatars:         avatars,
+		public:          public,
+		reactions:       reactions,
+		claims:          claims,
+		stream:          stream,
+		notifications:   notifications,
+		tradeImport:     tradeImport,
+		blobs:           blobs,
+		benchmarks:      benchmarks,
+		presence:        presence,
+		concentration:   concentration,
+		recompute:       recompute,
+		config:          cfg,
+		limiter:         limiter,
+		reactionLimiter: reactionLimiter,
+		log:             log.WithField("package", "api"),
 	}
 }
 
@@ -45,7 +123,11 @@ func (h *APIHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request, para
 
 	sortBy := "totalPnl"
 	if params.SortBy != nil {
-		sortBy = string(*params.SortBy)
+		sortBy = *params.SortBy
+	}
+	if !slices.Contains(scoring.BuiltinSorts, sortBy) && h.score(sortBy) == nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Unknown sort option: %s", sortBy))
+		return
 	}
 
 	sortDirection := "desc"
@@ -61,9 +143,34 @@ func (h *APIHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request, para
 	}
 
 	// Sort leaderboard
-	h.sortLeaderboard(stats, sortBy, sortDirection)
+	scores := h.computeScores(stats)
+	h.sortLeaderboard(stats, scores, sortBy, sortDirection)
 
-	// Convert to API response
+	leaderboard := h.buildLeaderboard(ctx, stats, scores)
+	h.respondList(w, r, leaderboard, leaderboard, unpaged(len(leaderboard)))
+}
+
+// GetVolumeLeaderboard returns the leaderboard of users ranked by all-time volume
+func (h *APIHandler) GetVolumeLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats, err := h.storage.GetLeaderboard(ctx, "volume", "desc")
+	if err != nil {
+		h.log.WithError(err).Error("failed to get volume leaderboard")
+		respondError(w, http.StatusInternalServerError, "Failed to get volume leaderboard")
+		return
+	}
+
+	scores := h.computeScores(stats)
+	h.sortLeaderboard(stats, scores, "volume", "desc")
+
+	leaderboard := h.buildLeaderboard(ctx, stats, scores)
+	h.respondList(w, r, leaderboard, leaderboard, unpaged(len(leaderboard)))
+}
+
+// buildLeaderboard converts sorted user stats into ranked leaderboard entries
+// and attaches each user's custom scores
+func (h *APIHandler) buildLeaderboard(ctx context.Context, stats []*storage.UserStats, scores map[string]map[string]float64) []LeaderboardEntry {
 	leaderboard := make([]LeaderboardEntry, len(stats))
 	for i, stat := range stats {
 		entry := LeaderboardEntry{
@@ -79,9 +186,18 @@ func (h *APIHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request, para
 		if stat.WinRate > 0 {
 			entry.WinRate = &stat.WinRate
 		}
+		if stat.Volume > 0 {
+			entry.Volume = &stat.Volume
+		}
 		if stat.ProfileImage != nil {
 			entry.ProfileImage = stat.ProfileImage
 		}
+		if userScores, ok := scores[stat.Username]; ok {
+			entry.Scores = &userScores
+		}
+		active := h.presence.Active(stat.LastTradeAt)
+		entry.IsActive = &active
+		entry.LastTradeAt = stat.LastTradeAt
 
 		// Get persona info for this user
 		user, err := h.storage.GetUser(ctx, stat.Username)
@@ -96,13 +212,18 @@ func (h *APIHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request, para
 		leaderboard[i] = entry
 	}
 
-	respondJSON(w, http.StatusOK, leaderboard)
+	return leaderboard
 }
 
 // TriggerSync triggers a manual sync
 func (h *APIHandler) TriggerSync(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if !h.sync.IsLeader() {
+		respondError(w, http.StatusConflict, "Sync is performed by another instance")
+		return
+	}
+
 	// Trigger sync in background
 	go func() {
 		if err := h.sync.TriggerSync(ctx); err != nil {
@@ -113,17 +234,211 @@ func (h *APIHandler) TriggerSync(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
-// GetUsers returns all tracked users
-func (h *APIHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+// GetSyncStatus returns per-user sync status along with recent sync errors
+func (h *APIHandler) GetSyncStatus(w http.ResponseWriter, r *http.Request, params GetSyncStatusParams) {
 	ctx := r.Context()
 
-	dbUsers, err := h.storage.GetUsers(ctx)
+	errorLimit := 20
+	if params.ErrorLimit != nil {
+		errorLimit = *params.ErrorLimit
+	}
+
+	var dbUsers []*storage.User
+	if params.Username != nil {
+		user, err := h.storage.GetUser(ctx, *params.Username)
+		if err != nil {
+			h.log.WithError(err).WithField("username", *params.Username).Error("failed to get user")
+			respondStorageError(w, err, "User not found", "Failed to get user")
+			return
+		}
+		dbUsers = []*storage.User{user}
+	} else {
+		users, err := h.storage.GetUsers(ctx)
+		if err != nil {
+			h.log.WithError(err).Error("failed to get users")
+			respondError(w, http.StatusInternalServerError, "Failed to get sync status")
+			return
+		}
+		dbUsers = users
+	}
+
+	statuses := make([]UserSyncStatus, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		dbErrors, err := h.storage.GetUserSyncErrors(ctx, dbUser.ID, errorLimit)
+		if err != nil {
+			h.log.WithError(err).WithField("username", dbUser.Username).Error("failed to get sync errors")
+			respondError(w, http.StatusInternalServerError, "Failed to get sync status")
+			return
+		}
+
+		syncErrors := make([]SyncError, 0, len(dbErrors))
+		for _, e := range dbErrors {
+			syncErrors = append(syncErrors, SyncError{
+				Timestamp: e.Timestamp,
+				Phase:     e.Phase,
+				Address:   e.Address,
+				Message:   e.Message,
+			})
+		}
+
+		removedTrades, err := h.storage.CountRemovedTrades(ctx, dbUser.ID)
+		if err != nil {
+			h.log.WithError(err).WithField("username", dbUser.Username).Error("failed to count removed trades")
+			respondError(w, http.StatusInternalServerError, "Failed to get sync status")
+			return
+		}
+
+		status := UserSyncStatus{
+			Username:   dbUser.Username,
+			LastSynced: dbUser.LastSynced,
+			Errors:     syncErrors,
+		}
+		if removedTrades > 0 {
+			status.RemovedTrades = &removedTrades
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	leader := h.sync.IsLeader()
+	crashes := h.sync.Crashes()
+	syncCrashes := SyncCrashes{Count: crashes.Count, LastCrashAt: crashes.LastAt}
+	if crashes.LastError != "" {
+		syncCrashes.LastError = &crashes.LastError
+	}
+	respondJSON(w, http.StatusOK, SyncStatus{Leader: &leader, Users: statuses, Crashes: &syncCrashes})
+}
+
+// GetSyncRuns returns the most recent sync runs, newest first
+func (h *APIHandler) GetSyncRuns(w http.ResponseWriter, r *http.Request, params GetSyncRunsParams) {
+	ctx := r.Context()
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > 500 {
+		respondError(w, http.StatusBadRequest, "Limit must be between 1 and 500")
+		return
+	}
+
+	dbRuns, err := h.storage.GetSyncRuns(ctx, limit)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get sync runs")
+		respondError(w, http.StatusInternalServerError, "Failed to get sync runs")
+		return
+	}
+
+	runs := make([]SyncRun, 0, len(dbRuns))
+	for _, dbRun := range dbRuns {
+		userRuns := make([]SyncRunUser, 0, len(dbRun.UserRuns))
+		for _, userRun := range dbRun.UserRuns {
+			userRuns = append(userRuns, SyncRunUser{
+				Username:    userRun.Username,
+				StartedAt:   userRun.StartedAt,
+				DurationMs:  userRun.Duration.Milliseconds(),
+				ApiCalls:    userRun.APICalls,
+				RowsWritten: userRun.RowsWritten,
+				Errors:      userRun.Errors,
+				Failure:     userRun.Failure,
+			})
+		}
+
+		runs = append(runs, SyncRun{
+			Id:            dbRun.ID,
+			Trigger:       SyncRunTrigger(dbRun.Trigger),
+			StartedAt:     dbRun.StartedAt,
+			FinishedAt:    dbRun.FinishedAt,
+			DurationMs:    dbRun.Duration.Milliseconds(),
+			Users:         dbRun.Users,
+			DeferredUsers: dbRun.DeferredUsers,
+			ApiCalls:      dbRun.APICalls,
+			RowsWritten:   dbRun.RowsWritten,
+			Errors:        dbRun.Errors,
+			UserRuns:      userRuns,
+		})
+	}
+
+	h.respondList(w, r, runs, runs, unpaged(len(runs)))
+}
+
+// GetUsers returns a page of tracked users, optionally with summary stats
+func (h *APIHandler) GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams) {
+	ctx := r.Context()
+
+	// Ghost users are only listed to admins, the group shouldn't learn who is tracked in secret
+	admin := h.isAdmin(r)
+	filters := storage.UserFilters{
+		Limit:         100,
+		Offset:        0,
+		SortBy:        "username",
+		SortDirection: "asc",
+		IncludeGhosts: admin,
+	}
+
+	// v1 returned every user before paging, and its bare array can't say rows were left out
+	if version := requestVersion(r); version != nil && version.Name == "v1" {
+		filters.Limit = 0
+	}
+
+	if params.Limit != nil {
+		filters.Limit = *params.Limit
+	}
+
+	if params.Offset != nil {
+		filters.Offset = *params.Offset
+	}
+
+	if filters.Limit < 0 || filters.Offset < 0 {
+		respondError(w, http.StatusBadRequest, "Limit and offset must not be negative")
+		return
+	}
+
+	if params.SortBy != nil {
+		filters.SortBy = string(*params.SortBy)
+	}
+
+	if params.SortDirection != nil {
+		filters.SortDirection = string(*params.SortDirection)
+	}
+
+	includeStats := params.IncludeStats != nil && *params.IncludeStats
+
+	var users []User
+	var total int
+	var err error
+	if includeStats || slices.Contains(storage.UserStatSorts, filters.SortBy) {
+		users, total, err = h.usersWithStats(ctx, filters, includeStats)
+	} else {
+		users, total, err = h.users(ctx, filters)
+	}
 	if err != nil {
 		h.log.WithError(err).Error("failed to get users")
 		respondError(w, http.StatusInternalServerError, "Failed to get users")
 		return
 	}
 
+	response := UsersResponse{
+		Users: users,
+		Total: total,
+	}
+	if filters.Limit > 0 {
+		response.Limit = &filters.Limit
+	}
+	if filters.Offset > 0 {
+		response.Offset = &filters.Offset
+	}
+
+	h.respondList(w, r, response, users, listPage{total: total, count: len(users), limit: filters.Limit, offset: filters.Offset})
+}
+
+// users returns a page of users sorted by a user column, and the total count
+func (h *APIHandler) users(ctx context.Context, filters storage.UserFilters) ([]User, int, error) {
+	dbUsers, total, err := h.storage.GetUsersPage(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	users := make([]User, 0, len(dbUsers))
 	for _, dbUser := range dbUsers {
 		addresses, err := h.storage.GetUserAddresses(ctx, dbUser.ID)
@@ -137,21 +452,133 @@ func (h *APIHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 			addressList[i] = addr.Address
 		}
 
-		user := User{
-			Username:  dbUser.Username,
-			Addresses: addressList,
-		}
-		if dbUser.LastSynced != nil {
-			user.LastSynced = dbUser.LastSynced
+		users = append(users, toAPIUser(dbUser, addressList, filters.IncludeGhosts))
+	}
+
+	return users, total, nil
+}
+
+// usersWithStats returns a page of users from the aggregated stats the leaderboard uses, which
+// can also sort by them, and the total count. The stats are only included with includeStats.
+func (h *APIHandler) usersWithStats(ctx context.Context, filters storage.UserFilters, includeStats bool) ([]User, int, error) {
+	page, total, err := h.storage.GetUserStatsPage(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]User, 0, len(page))
+	for _, stats := range page {
+		dbUser := &storage.User{
+			Username:     stats.Username,
+			LastSynced:   stats.LastSynced,
+			ProfileImage: stats.ProfileImage,
+			Ghost:        stats.Ghost,
+		}
+		user := toAPIUser(dbUser, stats.Addresses, filters.IncludeGhosts)
+		if includeStats {
+			user.Stats = &UserSummaryStats{
+				TotalPnl:      stats.TotalPnl,
+				RealizedPnl:   stats.RealizedPnl,
+				UnrealizedPnl: stats.UnrealizedPnl,
+				OpenPositions: stats.OpenPositions,
+				TotalTrades:   stats.TotalTrades,
+				WinRate:       stats.WinRate,
+				Volume:        stats.Volume,
+			}
 		}
-		if dbUser.ProfileImage != nil {
-			user.ProfileImage = dbUser.ProfileImage
+		users = append(users, user)
+	}
+
+	return users, total, nil
+}
+
+// SetUserGhost enables or disables ghost mode for a user
+func (h *APIHandler) SetUserGhost(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	var req GhostModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	if err := h.storage.UpdateUserGhost(ctx, user.ID, req.Ghost); err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to update ghost mode")
+		respondError(w, http.StatusInternalServerError, "Failed to update ghost mode")
+		return
+	}
+	if user.Ghost != req.Ghost {
+		change := roster.Change{Action: roster.ActionSetGhost, Target: username, Detail: fmt.Sprintf("%t", req.Ghost)}
+		if err := h.roster.Record(ctx, roster.SourceAPI, []roster.Change{change}); err != nil {
+			h.log.WithError(err).WithField("username", username).Error("failed to record roster change")
 		}
+	}
+	user.Ghost = req.Ghost
 
-		users = append(users, user)
+	addresses, err := h.storage.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user addresses")
+		respondError(w, http.StatusInternalServerError, "Failed to get user addresses")
+		return
 	}
 
-	respondJSON(w, http.StatusOK, users)
+	addressList := make([]string, len(addresses))
+	for i, addr := range addresses {
+		addressList[i] = addr.Address
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"username": username,
+		"ghost":    req.Ghost,
+	}).Info("updated ghost mode")
+
+	respondJSON(w, http.StatusOK, toAPIUser(user, addressList, true))
+}
+
+// toAPIUser converts a storage user to the API representation. Whether it's a ghost is only
+// shown with showGhost, for admins.
+func toAPIUser(dbUser *storage.User, addresses []string, showGhost bool) User {
+	user := User{
+		Username:  dbUser.Username,
+		Addresses: publicAddresses(dbUser, addresses),
+	}
+	if dbUser.LastSynced != nil {
+		user.LastSynced = dbUser.LastSynced
+	}
+	if dbUser.ProfileImage != nil {
+		user.ProfileImage = dbUser.ProfileImage
+	}
+	if showGhost && dbUser.Ghost {
+		user.Ghost = &dbUser.Ghost
+	}
+	return user
+}
+
+// publicAddresses returns a user's addresses as the public API shows them. Ghost users'
+// addresses are left out, only the persona's owner sees them.
+func publicAddresses(user *storage.User, addresses []string) []string {
+	if user.Ghost {
+		return []string{}
+	}
+	return addresses
+}
+
+// publicUsers returns the users the public API shows, leaving out ghost users
+func publicUsers(users []*storage.User) []*storage.User {
+	public := make([]*storage.User, 0, len(users))
+	for _, user := range users {
+		if !user.Ghost {
+			public = append(public, user)
+		}
+	}
+	return public
 }
 
 // GetUser returns details for a specific user
@@ -161,7 +588,7 @@ func (h *APIHandler) GetUser(w http.ResponseWriter, r *http.Request, username st
 	stats, err := h.storage.GetUserStats(ctx, username)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get user stats")
-		respondError(w, http.StatusNotFound, "User not found")
+		respondStorageError(w, err, "User not found", "Failed to get user stats")
 		return
 	}
 
@@ -182,24 +609,125 @@ func (h *APIHandler) GetUser(w http.ResponseWriter, r *http.Request, username st
 	if stats.WinRate > 0 {
 		detail.WinRate = &stats.WinRate
 	}
+	if stats.Volume > 0 {
+		detail.Volume = &stats.Volume
+	}
 	if stats.LastSynced != nil {
 		detail.LastSynced = stats.LastSynced
 	}
+	detail.LastTradeAt = stats.LastTradeAt
+	detail.IsActive = h.presence.Active(stats.LastTradeAt)
 	if stats.ProfileImage != nil {
 		detail.ProfileImage = stats.ProfileImage
 	}
 
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+	if user.VerifiedAt != nil {
+		verified := true
+		detail.Verified = &verified
+		detail.VerifiedAt = user.VerifiedAt
+	}
+	detail.Addresses = publicAddresses(user, detail.Addresses)
+
+	edge, err := h.storage.GetUserEdgeStats(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get edge stats")
+		respondError(w, http.StatusInternalServerError, "Failed to get user details")
+		return
+	}
+
+	if edge.Overall.Resolved > 0 {
+		categories := make([]EdgeStats, len(edge.Categories))
+		for i, c := range edge.Categories {
+			categories[i] = toAPIEdgeStats(c)
+		}
+		detail.Edge = &UserEdgeStats{
+			Overall:    toAPIEdgeStats(edge.Overall),
+			Categories: categories,
+		}
+	}
+
+	holdTime, err := h.storage.GetUserHoldTimeStats(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get hold time stats")
+		respondError(w, http.StatusInternalServerError, "Failed to get user details")
+		return
+	}
+
+	if holdTime.Disposals > 0 {
+		apiHoldTime := toAPIHoldTimeStats(holdTime)
+		detail.HoldTime = &apiHoldTime
+	}
+
+	entryTiming, err := h.storage.GetUserEntryTiming(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get entry timing")
+		respondError(w, http.StatusInternalServerError, "Failed to get user details")
+		return
+	}
+
+	if entryTiming.Entries > 0 {
+		detail.EntryTiming = &EntryTimingStats{
+			Entries:          entryTiming.Entries,
+			AvgDaysBeforeEnd: entryTiming.AvgDaysBeforeEnd,
+		}
+	}
+
 	respondJSON(w, http.StatusOK, detail)
 }
 
+// toAPIHoldTimeStats converts storage hold time stats to the API representation
+func toAPIHoldTimeStats(stats *storage.HoldTimeStats) HoldTimeStats {
+	buckets := make([]HoldTimeBucket, len(stats.Buckets))
+	for i, bucket := range stats.Buckets {
+		buckets[i] = HoldTimeBucket{
+			Label:     bucket.Label,
+			Disposals: bucket.Disposals,
+			Shares:    bucket.Shares,
+		}
+	}
+
+	return HoldTimeStats{
+		Disposals:   stats.Disposals,
+		MedianHours: stats.MedianHours,
+		MeanHours:   stats.MeanHours,
+		Buckets:     buckets,
+	}
+}
+
+// toAPIEdgeStats converts storage edge stats to the API representation
+func toAPIEdgeStats(stats *storage.EdgeStats) EdgeStats {
+	return EdgeStats{
+		Category:          stats.Category,
+		Resolved:          stats.Resolved,
+		Wins:              stats.Wins,
+		Losses:            stats.Losses,
+		WinRate:           stats.WinRate,
+		AvgEntryPrice:     stats.AvgEntryPrice,
+		AvgWinEntryPrice:  stats.AvgWinEntryPrice,
+		AvgLossEntryPrice: stats.AvgLossEntryPrice,
+		Edge:              stats.Edge,
+		KellyFraction:     stats.KellyFraction,
+	}
+}
+
 // GetUserPnl returns PNL history for a user
 func (h *APIHandler) GetUserPnl(w http.ResponseWriter, r *http.Request, username string, params GetUserPnlParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	user, err := h.storage.GetUser(ctx, username)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get user")
-		respondError(w, http.StatusNotFound, "User not found")
+		respondStorageError(w, err, "User not found", "Failed to get user")
 		return
 	}
 
@@ -211,6 +739,11 @@ func (h *APIHandler) GetUserPnl(w http.ResponseWriter, r *http.Request, username
 		end = params.End
 	}
 
+	if params.Benchmark != nil && !slices.Contains(h.benchmarks.Names(), *params.Benchmark) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Unknown benchmark: %s", *params.Benchmark))
+		return
+	}
+
 	snapshots, err := h.storage.GetUserPnlHistory(ctx, user.ID, start, end)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get pnl history")
@@ -220,110 +753,192 @@ func (h *APIHandler) GetUserPnl(w http.ResponseWriter, r *http.Request, username
 
 	dataPoints := make([]PnlDataPoint, len(snapshots))
 	for i, snap := range snapshots {
-		dataPoint := PnlDataPoint{
+		dataPoints[i] = toAPIPnlDataPoint(snap)
+	}
+
+	officialSnapshots, err := h.storage.GetUserOfficialPnlHistory(ctx, user.ID, start, end)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get official pnl history")
+		respondError(w, http.StatusInternalServerError, "Failed to get PNL history")
+		return
+	}
+
+	officialDataPoints := make([]OfficialPnlDataPoint, len(officialSnapshots))
+	for i, snap := range officialSnapshots {
+		officialDataPoints[i] = OfficialPnlDataPoint{
 			Timestamp: snap.Timestamp,
+			TotalPnl:  snap.Pnl,
+			Volume:    snap.Volume,
 		}
-		if snap.TotalPnl != nil {
-			dataPoint.TotalPnl = *snap.TotalPnl
+	}
+
+	history := PnlHistory{
+		Username:           username,
+		DataPoints:         dataPoints,
+		OfficialDataPoints: &officialDataPoints,
+	}
+
+	if params.Benchmark != nil {
+		at := make([]time.Time, len(snapshots))
+		for i, snap := range snapshots {
+			at[i] = snap.Timestamp
+		}
+
+		points, members, err := h.benchmarks.Series(ctx, *params.Benchmark, user.ID, at)
+		if err != nil {
+			h.log.WithError(err).WithField("benchmark", *params.Benchmark).Error("failed to get benchmark")
+			respondError(w, http.StatusInternalServerError, "Failed to get PNL history")
+			return
 		}
-		if snap.RealizedPnl != nil {
-			dataPoint.RealizedPnl = *snap.RealizedPnl
+
+		bench := PnlBenchmark{
+			Name:       *params.Benchmark,
+			Members:    members,
+			DataPoints: make([]BenchmarkDataPoint, len(points)),
 		}
-		if snap.UnrealizedPnl != nil {
-			dataPoint.UnrealizedPnl = *snap.UnrealizedPnl
+		for i, point := range points {
+			bench.DataPoints[i] = BenchmarkDataPoint{Timestamp: point.Timestamp, Pnl: point.Pnl}
 		}
-		dataPoints[i] = dataPoint
+		history.Benchmark = &bench
 	}
 
-	history := PnlHistory{
-		Username:   username,
-		DataPoints: dataPoints,
+	if wantsCSV(params.Format) {
+		h.respondCSV(w, user.Username+"-pnl.csv", pnlCSVHeader, pnlCSVRows(history))
+		return
 	}
 
 	respondJSON(w, http.StatusOK, history)
 }
 
 // GetUserPositions returns current positions for a user
-func (h *APIHandler) GetUserPositions(w http.ResponseWriter, r *http.Request, username string) {
+func (h *APIHandler) GetUserPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserPositionsParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	user, err := h.storage.GetUser(ctx, username)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get user")
-		respondError(w, http.StatusNotFound, "User not found")
+		respondStorageError(w, err, "User not found", "Failed to get user")
 		return
 	}
 
-	dbPositions, err := h.storage.GetUserPositions(ctx, user.ID)
+	includeDust := params.IncludeDust != nil && *params.IncludeDust
+
+	dbPositions, err := h.storage.GetUserOpenPositions(ctx, user.ID, includeDust)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get positions")
 		respondError(w, http.StatusInternalServerError, "Failed to get positions")
 		return
 	}
 
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbPositions))
+		for _, pos := range dbPositions {
+			rows = append(rows, positionCSVRow(&storage.PositionWithUsername{Position: *pos, Username: user.Username}))
+		}
+		h.respondCSV(w, user.Username+"-positions.csv", positionCSVHeader, rows)
+		return
+	}
+
+	markets := h.concentration.Assess(dbPositions)
 	positions := make([]Position, 0, len(dbPositions))
 	for _, pos := range dbPositions {
-		position := Position{
-			Id:            fmt.Sprintf("%d", pos.ID),
-			MarketTitle:   "",
-			Outcome:       "",
-			Size:          0,
-			AvgPrice:      0,
-			CurrentPrice:  0,
-			UnrealizedPnl: 0,
-		}
+		position := toAPIPosition(pos)
+		position.PortfolioShare, position.Concentrated = marketConcentration(markets, pos.ConditionID)
+		positions = append(positions, position)
+	}
 
-		if pos.ConditionID != "" {
-			position.ConditionId = &pos.ConditionID
-		}
-		if pos.MarketTitle != nil {
-			position.MarketTitle = *pos.MarketTitle
-		}
-		if pos.MarketSlug != nil {
-			position.MarketSlug = pos.MarketSlug
-		}
-		if pos.Outcome != nil {
-			position.Outcome = *pos.Outcome
-		}
-		if pos.Size != nil {
-			position.Size = *pos.Size
-		}
-		if pos.AvgPrice != nil {
-			position.AvgPrice = *pos.AvgPrice
-		}
-		if pos.CurrentPrice != nil {
-			position.CurrentPrice = *pos.CurrentPrice
-		}
-		if pos.InitialValue != nil {
-			position.InitialValue = pos.InitialValue
-		}
-		if pos.CurrentValue != nil {
-			position.CurrentValue = pos.CurrentValue
-		}
-		if pos.UnrealizedPnl != nil {
-			position.UnrealizedPnl = *pos.UnrealizedPnl
-		}
-		if pos.UnrealizedPnlPercent != nil {
-			position.UnrealizedPnlPercent = pos.UnrealizedPnlPercent
-		}
-		if pos.EndDate != nil {
-			position.EndDate = pos.EndDate
-		}
+	h.respondList(w, r, positions, positions, unpaged(len(positions)))
+}
 
-		positions = append(positions, position)
+// marketConcentration returns the share of open exposure held in a market and, when the
+// market is concentrated, the flag to set on its positions
+func marketConcentration(markets map[string]concentration.Market, conditionID string) (*float64, *bool) {
+	market, ok := markets[conditionID]
+	if !ok {
+		return nil, nil
+	}
+	if !market.Concentrated {
+		return &market.Share, nil
+	}
+	concentrated := true
+	return &market.Share, &concentrated
+}
+
+// toAPIPosition converts a storage position to the API representation
+func toAPIPosition(pos *storage.Position) Position {
+	position := Position{
+		Id:            fmt.Sprintf("%d", pos.ID),
+		MarketTitle:   "",
+		Outcome:       "",
+		Size:          0,
+		AvgPrice:      0,
+		CurrentPrice:  0,
+		UnrealizedPnl: 0,
 	}
 
-	respondJSON(w, http.StatusOK, positions)
+	if pos.ConditionID != "" {
+		position.ConditionId = &pos.ConditionID
+	}
+	if pos.MarketTitle != nil {
+		position.MarketTitle = *pos.MarketTitle
+	}
+	if pos.MarketSlug != nil {
+		position.MarketSlug = pos.MarketSlug
+	}
+	if pos.Outcome != nil {
+		position.Outcome = *pos.Outcome
+	}
+	if pos.Size != nil {
+		position.Size = *pos.Size
+	}
+	if pos.AvgPrice != nil {
+		position.AvgPrice = *pos.AvgPrice
+	}
+	if pos.CurrentPrice != nil {
+		position.CurrentPrice = *pos.CurrentPrice
+	}
+	if pos.InitialValue != nil {
+		position.InitialValue = pos.InitialValue
+	}
+	if pos.CurrentValue != nil {
+		position.CurrentValue = pos.CurrentValue
+	}
+	if pos.UnrealizedPnl != nil {
+		position.UnrealizedPnl = *pos.UnrealizedPnl
+	}
+	if pos.UnrealizedPnlPercent != nil {
+		position.UnrealizedPnlPercent = pos.UnrealizedPnlPercent
+	}
+	if pos.EndDate != nil {
+		position.EndDate = pos.EndDate
+	}
+	if pos.Size != nil {
+		ifWins, ifLoses := netting.ResolutionPnl(pos)
+		position.PnlIfWins = &ifWins
+		position.PnlIfLoses = &ifLoses
+	}
+	position.FirstEnteredAt = pos.FirstEnteredAt
+	position.EntryTradeId = pos.EntryTradeID
+
+	return position
 }
 
 // GetUserTrades returns trade history for a user
 func (h *APIHandler) GetUserTrades(w http.ResponseWriter, r *http.Request, username string, params GetUserTradesParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	user, err := h.storage.GetUser(ctx, username)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get user")
-		respondError(w, http.StatusNotFound, "User not found")
+		respondStorageError(w, err, "User not found", "Failed to get user")
 		return
 	}
 
@@ -337,13 +952,22 @@ func (h *APIHandler) GetUserTrades(w http.ResponseWriter, r *http.Request, usern
 		offset = *params.Offset
 	}
 
-	dbTrades, total, err := h.storage.GetUserTrades(ctx, user.ID, limit, offset)
+	dbTrades, total, err := h.storage.GetUserTrades(ctx, user.ID, limit, offset, params.MinValue)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get trades")
 		respondError(w, http.StatusInternalServerError, "Failed to get trades")
 		return
 	}
 
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbTrades))
+		for _, t := range dbTrades {
+			rows = append(rows, tradeCSVRow(&storage.TradeWithUsername{Trade: *t, Username: user.Username}))
+		}
+		h.respondCSV(w, user.Username+"-trades.csv", tradeExportHeader, rows)
+		return
+	}
+
 	// Get persona info for this user (once, since all trades are from the same user)
 	personaInfo, err := h.storage.GetUserPersonaInfo(ctx, user.ID)
 	if err != nil {
@@ -388,6 +1012,11 @@ func (h *APIHandler) GetUserTrades(w http.ResponseWriter, r *http.Request, usern
 				trade.Side = TradeSideSELL
 			}
 		}
+		if t.PositionChange != nil {
+			change := TradePositionChange(*t.PositionChange)
+			trade.PositionChange = &change
+		}
+		trade.Book = toAPITradeBook(t)
 		if t.Price != nil {
 			trade.Price = *t.Price
 		}
@@ -411,6 +1040,7 @@ func (h *APIHandler) GetUserTrades(w http.ResponseWriter, r *http.Request, usern
 
 		trades = append(trades, trade)
 	}
+	h.attachTradeReactions(r, trades)
 
 	response := TradesResponse{
 		Trades: trades,
@@ -423,13 +1053,17 @@ func (h *APIHandler) GetUserTrades(w http.ResponseWriter, r *http.Request, usern
 		response.Offset = &offset
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	h.respondList(w, r, response, trades, listPage{total: total, count: len(trades), limit: limit, offset: offset})
 }
 
 // GetTrades returns all recent trades with filtering
 func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params GetTradesParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	// Build filters from query parameters
 	filters := storage.TradeFilters{
 		Limit:         50,
@@ -467,6 +1101,31 @@ func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params Ge
 		filters.SortDirection = string(*params.SortDirection)
 	}
 
+	filters.Start = params.Start
+	filters.End = params.End
+
+	h.applyMuteRules(ctx, &filters, params)
+
+	if params.GroupBy != nil {
+		if *params.GroupBy != Market {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Unknown groupBy option: %s", *params.GroupBy))
+			return
+		}
+		if wantsCSV(params.Format) {
+			respondError(w, http.StatusBadRequest, "Grouped trades can't be returned as CSV")
+			return
+		}
+		if params.SortBy == nil {
+			filters.SortBy = ""
+		}
+		if filters.Start == nil {
+			start := time.Now().Add(-defaultTradeGroupWindow)
+			filters.Start = &start
+		}
+		h.respondTradeMarketGroups(w, r, filters)
+		return
+	}
+
 	dbTrades, total, err := h.storage.GetAllTrades(ctx, filters)
 	if err != nil {
 		h.log.WithError(err).Error("failed to get all trades")
@@ -474,6 +1133,15 @@ func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params Ge
 		return
 	}
 
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbTrades))
+		for _, t := range dbTrades {
+			rows = append(rows, tradeCSVRow(t))
+		}
+		h.respondCSV(w, "trades.csv", tradeExportHeader, rows)
+		return
+	}
+
 	// Cache for user lookups to avoid repeated queries
 	userCache := make(map[int64]*storage.User, len(dbTrades))
 	personaCache := make(map[int64]*storage.PersonaInfo, len(dbTrades))
@@ -519,6 +1187,11 @@ func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params Ge
 				trade.Side = TradeSideSELL
 			}
 		}
+		if t.PositionChange != nil {
+			change := TradePositionChange(*t.PositionChange)
+			trade.PositionChange = &change
+		}
+		trade.Book = toAPITradeBook(&t.Trade)
 		if t.Price != nil {
 			trade.Price = *t.Price
 		}
@@ -560,6 +1233,7 @@ func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params Ge
 
 		trades = append(trades, trade)
 	}
+	h.attachTradeReactions(r, trades)
 
 	response := TradesResponse{
 		Trades: trades,
@@ -572,11 +1246,11 @@ func (h *APIHandler) GetTrades(w http.ResponseWriter, r *http.Request, params Ge
 		response.Offset = &filters.Offset
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	h.respondList(w, r, response, trades, listPage{total: total, count: len(trades), limit: filters.Limit, offset: filters.Offset})
 }
 
-// sortLeaderboard sorts the leaderboard by the specified field and direction
-func (h *APIHandler) sortLeaderboard(stats []*storage.UserStats, sortBy, sortDirection string) {
+// sortLeaderboard sorts the leaderboard by the specified field or custom score and direction
+func (h *APIHandler) sortLeaderboard(stats []*storage.UserStats, scores map[string]map[string]float64, sortBy, sortDirection string) {
 	sort.Slice(stats, func(i, j int) bool {
 		var less bool
 		switch sortBy {
@@ -588,8 +1262,14 @@ func (h *APIHandler) sortLeaderboard(stats []*storage.UserStats, sortBy, sortDir
 			less = stats[i].UnrealizedPnl < stats[j].UnrealizedPnl
 		case "winRate":
 			less = stats[i].WinRate < stats[j].WinRate
+		case "volume":
+			less = stats[i].Volume < stats[j].Volume
 		default:
-			less = stats[i].TotalPnl < stats[j].TotalPnl
+			if h.score(sortBy) != nil {
+				less = scores[stats[i].Username][sortBy] < scores[stats[j].Username][sortBy]
+			} else {
+				less = stats[i].TotalPnl < stats[j].TotalPnl
+			}
 		}
 
 		if sortDirection == "asc" {
@@ -617,6 +1297,19 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	})
 }
 
+// respondStorageError sends the error response for a failed storage call, mapping its domain
+// errors to status codes: notFound answers ErrNotFound and failed any unexpected error
+func respondStorageError(w http.ResponseWriter, err error, notFound, failed string) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		respondError(w, http.StatusNotFound, notFound)
+	case errors.Is(err, storage.ErrConflict):
+		respondError(w, http.StatusConflict, "Conflicts with an existing record")
+	default:
+		respondError(w, http.StatusInternalServerError, failed)
+	}
+}
+
 // parseIntParam parses an integer query parameter
 func parseIntParam(r *http.Request, param string, defaultValue int) int {
 	value := r.URL.Query().Get(param)
@@ -630,23 +1323,63 @@ func parseIntParam(r *http.Request, param string, defaultValue int) int {
 	return intValue
 }
 
+// GetUserCopySimulation simulates copy trading a user's trades with a given bankroll
+func (h *APIHandler) GetUserCopySimulation(w http.ResponseWriter, r *http.Request, username string, params GetUserCopySimulationParams) {
+	ctx := r.Context()
+
+	capital := 1000.0
+	if params.Capital != nil {
+		capital = *params.Capital
+	}
+	if capital <= 0 {
+		respondError(w, http.StatusBadRequest, "Capital must be positive")
+		return
+	}
+
+	sim, err := h.backfill.SimulateCopyTrading(ctx, username, capital, params.Start)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to simulate copy trading")
+
+		respondStorageError(w, err, "User not found", "Failed to simulate copy trading")
+		return
+	}
+
+	dataPoints := make([]EquityDataPoint, len(sim.DataPoints))
+	for i, point := range sim.DataPoints {
+		dataPoints[i] = EquityDataPoint{
+			Timestamp: point.Timestamp,
+			Equity:    point.Equity,
+			Cash:      point.Cash,
+		}
+	}
+
+	response := CopySimulation{
+		Username:      sim.Username,
+		Capital:       sim.Capital,
+		Start:         sim.Start,
+		Scale:         sim.Scale,
+		FinalEquity:   sim.FinalEquity,
+		ReturnPct:     (sim.FinalEquity - sim.Capital) / sim.Capital * 100,
+		TradesCopied:  sim.TradesCopied,
+		TradesSkipped: sim.TradesSkipped,
+		DataPoints:    dataPoints,
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
 // BackfillUserPnl backfills PnL history from trade data for a user
-func (h *APIHandler) BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string) {
+func (h *APIHandler) BackfillUserPnl(w http.ResponseWriter, r *http.Request, username string, params BackfillUserPnlParams) {
 	ctx := r.Context()
 
 	h.log.WithField("username", username).Info("starting PnL backfill")
 
-	result, err := h.backfill.BackfillUser(ctx, username)
+	replace := params.Replace != nil && *params.Replace
+	result, err := h.backfill.BackfillUser(ctx, username, replace)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to backfill PnL")
 
-		// Check if it's a user not found error
-		if err.Error() == fmt.Sprintf("failed to get user: user not found: %s", username) {
-			respondError(w, http.StatusNotFound, "User not found")
-			return
-		}
-
-		respondError(w, http.StatusInternalServerError, "Failed to backfill PnL")
+		respondStorageError(w, err, "User not found", "Failed to backfill PnL")
 		return
 	}
 
@@ -654,14 +1387,50 @@ func (h *APIHandler) BackfillUserPnl(w http.ResponseWriter, r *http.Request, use
 		Username:         result.Username,
 		TradesProcessed:  result.TradesProcessed,
 		SnapshotsCreated: result.SnapshotsCreated,
+		SnapshotsSkipped: result.SnapshotsSkipped,
 		TotalRealizedPnl: result.TotalRealizedPnl,
 	}
 
 	if result.OldestTradeDate != nil {
 		response.OldestTradeDate = result.OldestTradeDate
 	}
-	if result.NewestTradeDate != nil {
-		response.NewestTradeDate = result.NewestTradeDate
+	if result.NewestTradeDate != nil {
+		response.NewestTradeDate = result.NewestTradeDate
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// BackfillUserTrades fetches a user's full trade history and stores the trades sync missed
+func (h *APIHandler) BackfillUserTrades(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	result, err := h.sync.BackfillTrades(ctx, username)
+	if errors.Is(err, polymarket.ErrNotLeader) {
+		respondError(w, http.StatusConflict, "Sync is performed by another instance")
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to backfill trades")
+		respondStorageError(w, err, "User not found", "Failed to backfill trades")
+		return
+	}
+
+	response := TradeBackfillResult{
+		Username:       result.Username,
+		Addresses:      make([]AddressTradeBackfill, 0, len(result.Addresses)),
+		TradesFetched:  result.Fetched,
+		TradesInserted: result.Inserted,
+	}
+	// Like everywhere else in the public API, ghost users' addresses aren't shown
+	if !result.Ghost {
+		for _, addr := range result.Addresses {
+			response.Addresses = append(response.Addresses, AddressTradeBackfill{
+				Address:        addr.Address,
+				TradesFetched:  addr.Fetched,
+				TradesInserted: addr.Inserted,
+			})
+		}
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -680,30 +1449,16 @@ func (h *APIHandler) GetPersonas(w http.ResponseWriter, r *http.Request) {
 
 	personas := make([]PersonaSummary, 0, len(dbPersonas))
 	for _, p := range dbPersonas {
-		// Get users for this persona
-		users, err := h.storage.GetPersonaUsers(ctx, p.ID)
+		summary, err := h.personaSummary(ctx, p)
 		if err != nil {
 			h.log.WithError(err).WithField("persona", p.Slug).Error("failed to get persona users")
 			continue
 		}
 
-		usernames := make([]string, len(users))
-		for i, u := range users {
-			usernames[i] = u.Username
-		}
-
-		summary := PersonaSummary{
-			Slug:        p.Slug,
-			DisplayName: p.DisplayName,
-			Usernames:   usernames,
-		}
-		if p.Image != nil {
-			summary.Image = p.Image
-		}
 		personas = append(personas, summary)
 	}
 
-	respondJSON(w, http.StatusOK, personas)
+	h.respondList(w, r, personas, personas, unpaged(len(personas)))
 }
 
 // GetPersona returns details for a specific persona
@@ -713,7 +1468,7 @@ func (h *APIHandler) GetPersona(w http.ResponseWriter, r *http.Request, slug str
 	stats, err := h.storage.GetPersonaStats(ctx, slug)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona stats")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona stats")
 		return
 	}
 
@@ -735,10 +1490,89 @@ func (h *APIHandler) GetPersona(w http.ResponseWriter, r *http.Request, slug str
 	if stats.WinRate > 0 {
 		detail.WinRate = &stats.WinRate
 	}
+	if stats.Volume > 0 {
+		detail.Volume = &stats.Volume
+	}
 	if stats.Image != nil {
 		detail.Image = stats.Image
 	}
 
+	style, err := h.storage.GetPersonaStyle(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona style")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona style")
+		return
+	}
+	detail.Style = &PersonaStyle{
+		Positions:         style.Positions,
+		AvgHoldHours:      style.AvgHoldHours,
+		AvgPositionSize:   style.AvgPositionSize,
+		FavouriteCategory: style.FavouriteCategory,
+		LongShotRatio:     style.LongShotRatio,
+	}
+	if style.HoldTime.Disposals > 0 {
+		holdTime := toAPIHoldTimeStats(style.HoldTime)
+		detail.Style.HoldTime = &holdTime
+	}
+	if style.EntryTiming.Entries > 0 {
+		detail.Style.EntryTiming = &EntryTimingStats{
+			Entries:          style.EntryTiming.Entries,
+			AvgDaysBeforeEnd: style.EntryTiming.AvgDaysBeforeEnd,
+		}
+	}
+
+	identities, err := h.storage.GetPersonaIdentities(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona identities")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona identities")
+		return
+	}
+	if len(identities) > 0 {
+		apiIdentities := make([]AccountIdentity, 0, len(identities))
+		xUsernames := make([]string, 0)
+		for _, identity := range identities {
+			apiIdentities = append(apiIdentities, AccountIdentity{
+				Username:  identity.Username,
+				Name:      identity.Name,
+				Pseudonym: identity.Pseudonym,
+				Bio:       identity.Bio,
+				XUsername: identity.XUsername,
+				UpdatedAt: identity.UpdatedAt,
+			})
+			if identity.XUsername != nil && !slices.ContainsFunc(xUsernames, func(handle string) bool {
+				return strings.EqualFold(handle, *identity.XUsername)
+			}) {
+				xUsernames = append(xUsernames, *identity.XUsername)
+			}
+		}
+		detail.Identities = &apiIdentities
+		if len(xUsernames) > 0 {
+			detail.XUsernames = &xUsernames
+		}
+	}
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona")
+		return
+	}
+	users, err := h.storage.GetPersonaUsers(ctx, persona.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona users")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona users")
+		return
+	}
+	verified := make([]string, 0)
+	for _, user := range publicUsers(users) {
+		if user.VerifiedAt != nil {
+			verified = append(verified, user.Username)
+		}
+	}
+	if len(verified) > 0 {
+		detail.VerifiedUsernames = &verified
+	}
+
 	respondJSON(w, http.StatusOK, detail)
 }
 
@@ -749,7 +1583,7 @@ func (h *APIHandler) GetPersonaAccounts(w http.ResponseWriter, r *http.Request,
 	persona, err := h.storage.GetPersona(ctx, slug)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
 		return
 	}
 
@@ -760,6 +1594,7 @@ func (h *APIHandler) GetPersonaAccounts(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	users = publicUsers(users)
 	accounts := make([]PersonaAccount, 0, len(users))
 	for _, user := range users {
 		stats, err := h.storage.GetUserStats(ctx, user.Username)
@@ -770,7 +1605,7 @@ func (h *APIHandler) GetPersonaAccounts(w http.ResponseWriter, r *http.Request,
 
 		account := PersonaAccount{
 			Username:      stats.Username,
-			Addresses:     stats.Addresses,
+			Addresses:     publicAddresses(user, stats.Addresses),
 			TotalPnl:      stats.TotalPnl,
 			RealizedPnl:   stats.RealizedPnl,
 			UnrealizedPnl: stats.UnrealizedPnl,
@@ -785,14 +1620,21 @@ func (h *APIHandler) GetPersonaAccounts(w http.ResponseWriter, r *http.Request,
 		if stats.WinRate > 0 {
 			account.WinRate = &stats.WinRate
 		}
+		if stats.Volume > 0 {
+			account.Volume = &stats.Volume
+		}
 		if stats.ProfileImage != nil {
 			account.ProfileImage = stats.ProfileImage
 		}
+		if user.VerifiedAt != nil {
+			verified := true
+			account.Verified = &verified
+		}
 
 		accounts = append(accounts, account)
 	}
 
-	respondJSON(w, http.StatusOK, accounts)
+	h.respondList(w, r, accounts, accounts, unpaged(len(accounts)))
 }
 
 // GetPersonaLeaderboard returns the leaderboard of all personas
@@ -837,13 +1679,16 @@ func (h *APIHandler) GetPersonaLeaderboard(w http.ResponseWriter, r *http.Reques
 		if stat.WinRate > 0 {
 			entry.WinRate = &stat.WinRate
 		}
+		if stat.Volume > 0 {
+			entry.Volume = &stat.Volume
+		}
 		if stat.Image != nil {
 			entry.Image = stat.Image
 		}
 		leaderboard[i] = entry
 	}
 
-	respondJSON(w, http.StatusOK, leaderboard)
+	h.respondList(w, r, leaderboard, leaderboard, unpaged(len(leaderboard)))
 }
 
 // sortPersonaLeaderboard sorts the persona leaderboard by the specified field and direction
@@ -871,16 +1716,51 @@ func (h *APIHandler) sortPersonaLeaderboard(stats []*storage.PersonaStats, sortB
 }
 
 // GetPersonaPositions returns combined positions across all accounts for a persona
-func (h *APIHandler) GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string) {
+func (h *APIHandler) GetPersonaPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaPositionsParams) {
 	ctx := r.Context()
 
-	dbPositions, err := h.storage.GetPersonaPositions(ctx, slug)
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
+	sortBy := ""
+	if params.SortBy != nil {
+		sortBy = string(*params.SortBy)
+	}
+
+	sortDirection := "desc"
+	if params.SortDirection != nil {
+		sortDirection = string(*params.SortDirection)
+	}
+
+	includeDust := params.IncludeDust != nil && *params.IncludeDust
+
+	dbPositions, err := h.storage.GetPersonaPositions(ctx, slug, sortBy, sortDirection, includeDust)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona positions")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona positions")
+		return
+	}
+
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbPositions))
+		for _, pos := range dbPositions {
+			rows = append(rows, positionCSVRow(pos))
+		}
+		h.respondCSV(w, slug+"-positions.csv", positionCSVHeader, rows)
 		return
 	}
 
+	// Concentration is measured within each account
+	userPositions := make(map[string][]*storage.Position)
+	for _, pos := range dbPositions {
+		userPositions[pos.Username] = append(userPositions[pos.Username], &pos.Position)
+	}
+	userMarkets := make(map[string]map[string]concentration.Market, len(userPositions))
+	for username, held := range userPositions {
+		userMarkets[username] = h.concentration.Assess(held)
+	}
+
 	positions := make([]PersonaPosition, 0, len(dbPositions))
 	for _, pos := range dbPositions {
 		position := PersonaPosition{
@@ -930,17 +1810,29 @@ func (h *APIHandler) GetPersonaPositions(w http.ResponseWriter, r *http.Request,
 		if pos.EndDate != nil {
 			position.EndDate = pos.EndDate
 		}
+		if pos.Size != nil {
+			ifWins, ifLoses := netting.ResolutionPnl(&pos.Position)
+			position.PnlIfWins = &ifWins
+			position.PnlIfLoses = &ifLoses
+		}
+		position.FirstEnteredAt = pos.FirstEnteredAt
+		position.EntryTradeId = pos.EntryTradeID
+		position.PortfolioShare, position.Concentrated = marketConcentration(userMarkets[pos.Username], pos.ConditionID)
 
 		positions = append(positions, position)
 	}
 
-	respondJSON(w, http.StatusOK, positions)
+	h.respondList(w, r, positions, positions, unpaged(len(positions)))
 }
 
 // GetPersonaTrades returns combined trades across all accounts for a persona
 func (h *APIHandler) GetPersonaTrades(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaTradesParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	limit := 100
 	if params.Limit != nil {
 		limit = *params.Limit
@@ -951,18 +1843,37 @@ func (h *APIHandler) GetPersonaTrades(w http.ResponseWriter, r *http.Request, sl
 		offset = *params.Offset
 	}
 
+	sortBy := ""
+	if params.SortBy != nil {
+		sortBy = string(*params.SortBy)
+	}
+
+	sortDirection := "desc"
+	if params.SortDirection != nil {
+		sortDirection = string(*params.SortDirection)
+	}
+
 	// Get persona info upfront (all trades will share this)
 	persona, err := h.storage.GetPersona(ctx, slug)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
 		return
 	}
 
-	dbTrades, total, err := h.storage.GetPersonaTrades(ctx, slug, limit, offset)
+	dbTrades, total, err := h.storage.GetPersonaTrades(ctx, slug, limit, offset, sortBy, sortDirection, params.MinValue)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona trades")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona trades")
+		return
+	}
+
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbTrades))
+		for _, t := range dbTrades {
+			rows = append(rows, tradeCSVRow(t))
+		}
+		h.respondCSV(w, persona.Slug+"-trades.csv", tradeExportHeader, rows)
 		return
 	}
 
@@ -1008,6 +1919,11 @@ func (h *APIHandler) GetPersonaTrades(w http.ResponseWriter, r *http.Request, sl
 				trade.Side = TradeSideSELL
 			}
 		}
+		if t.PositionChange != nil {
+			change := TradePositionChange(*t.PositionChange)
+			trade.PositionChange = &change
+		}
+		trade.Book = toAPITradeBook(&t.Trade)
 		if t.Price != nil {
 			trade.Price = *t.Price
 		}
@@ -1038,6 +1954,7 @@ func (h *APIHandler) GetPersonaTrades(w http.ResponseWriter, r *http.Request, sl
 
 		trades = append(trades, trade)
 	}
+	h.attachTradeReactions(r, trades)
 
 	response := TradesResponse{
 		Trades: trades,
@@ -1050,17 +1967,21 @@ func (h *APIHandler) GetPersonaTrades(w http.ResponseWriter, r *http.Request, sl
 		response.Offset = &offset
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	h.respondList(w, r, response, trades, listPage{total: total, count: len(trades), limit: limit, offset: offset})
 }
 
 // GetUserResults returns resolved positions (results) for a user
 func (h *APIHandler) GetUserResults(w http.ResponseWriter, r *http.Request, username string, params GetUserResultsParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	user, err := h.storage.GetUser(ctx, username)
 	if err != nil {
 		h.log.WithError(err).WithField("username", username).Error("failed to get user")
-		respondError(w, http.StatusNotFound, "User not found")
+		respondStorageError(w, err, "User not found", "Failed to get user")
 		return
 	}
 
@@ -1081,6 +2002,15 @@ func (h *APIHandler) GetUserResults(w http.ResponseWriter, r *http.Request, user
 		return
 	}
 
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbResults))
+		for _, result := range dbResults {
+			rows = append(rows, resultCSVRow(&storage.ResultWithUsername{Result: *result, Username: user.Username}))
+		}
+		h.respondCSV(w, user.Username+"-results.csv", resultCSVHeader, rows)
+		return
+	}
+
 	results := make([]Result, 0, len(dbResults))
 	for _, r := range dbResults {
 		result := Result{
@@ -1109,6 +2039,16 @@ func (h *APIHandler) GetUserResults(w http.ResponseWriter, r *http.Request, user
 		if r.ResolutionDate != nil {
 			result.ResolutionDate = r.ResolutionDate
 		}
+		if r.SettlementPrice != nil {
+			result.SettlementPrice = r.SettlementPrice
+		}
+		if r.SettledPnl != nil {
+			result.SettledPnl = r.SettledPnl
+		}
+		if len(r.PriceSeries) > 0 {
+			series := toAPIPriceSeries(r.PriceSeries)
+			result.PriceSeries = &series
+		}
 
 		results = append(results, result)
 	}
@@ -1124,13 +2064,17 @@ func (h *APIHandler) GetUserResults(w http.ResponseWriter, r *http.Request, user
 		response.Offset = &offset
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	h.respondList(w, r, response, results, listPage{total: total, count: len(results), limit: limit, offset: offset})
 }
 
 // GetPersonaResults returns resolved positions (results) across all accounts for a persona
 func (h *APIHandler) GetPersonaResults(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaResultsParams) {
 	ctx := r.Context()
 
+	if !supportedFormat(w, params.Format, "json", "csv") {
+		return
+	}
+
 	limit := 50
 	if params.Limit != nil {
 		limit = *params.Limit
@@ -1141,10 +2085,29 @@ func (h *APIHandler) GetPersonaResults(w http.ResponseWriter, r *http.Request, s
 		offset = *params.Offset
 	}
 
-	dbResults, total, err := h.storage.GetPersonaResults(ctx, slug, limit, offset)
+	sortBy := ""
+	if params.SortBy != nil {
+		sortBy = string(*params.SortBy)
+	}
+
+	sortDirection := "desc"
+	if params.SortDirection != nil {
+		sortDirection = string(*params.SortDirection)
+	}
+
+	dbResults, total, err := h.storage.GetPersonaResults(ctx, slug, limit, offset, sortBy, sortDirection, false)
 	if err != nil {
 		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona results")
-		respondError(w, http.StatusNotFound, "Persona not found")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona results")
+		return
+	}
+
+	if wantsCSV(params.Format) {
+		rows := make([][]string, 0, len(dbResults))
+		for _, result := range dbResults {
+			rows = append(rows, resultCSVRow(result))
+		}
+		h.respondCSV(w, slug+"-results.csv", resultCSVHeader, rows)
 		return
 	}
 
@@ -1177,6 +2140,16 @@ func (h *APIHandler) GetPersonaResults(w http.ResponseWriter, r *http.Request, s
 		if r.ResolutionDate != nil {
 			result.ResolutionDate = r.ResolutionDate
 		}
+		if r.SettlementPrice != nil {
+			result.SettlementPrice = r.SettlementPrice
+		}
+		if r.SettledPnl != nil {
+			result.SettledPnl = r.SettledPnl
+		}
+		if len(r.PriceSeries) > 0 {
+			series := toAPIPriceSeries(r.PriceSeries)
+			result.PriceSeries = &series
+		}
 
 		results = append(results, result)
 	}
@@ -1192,5 +2165,290 @@ func (h *APIHandler) GetPersonaResults(w http.ResponseWriter, r *http.Request, s
 		response.Offset = &offset
 	}
 
+	h.respondList(w, r, response, results, listPage{total: total, count: len(results), limit: limit, offset: offset})
+}
+
+// SearchMarkets searches markets by title and returns tracked-user holder counts and side lean
+func (h *APIHandler) SearchMarkets(w http.ResponseWriter, r *http.Request, params SearchMarketsParams) {
+	ctx := r.Context()
+
+	if params.Q == "" {
+		respondError(w, http.StatusBadRequest, "Search query is required")
+		return
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	dbResults, err := h.storage.SearchMarkets(ctx, params.Q, limit)
+	if err != nil {
+		h.log.WithError(err).WithField("query", params.Q).Error("failed to search markets")
+		respondError(w, http.StatusInternalServerError, "Failed to search markets")
+		return
+	}
+
+	results := make([]MarketSearchResult, 0, len(dbResults))
+	for _, m := range dbResults {
+		result := MarketSearchResult{
+			ConditionId: m.ConditionID,
+			MarketTitle: "",
+			MarketSlug:  m.MarketSlug,
+			TradeCount:  m.TradeCount,
+			Holders:     m.Holders,
+			TotalSize:   m.TotalSize,
+			Outcomes:    make([]MarketOutcomeStats, 0, len(m.Outcomes)),
+		}
+		if m.MarketTitle != nil {
+			result.MarketTitle = *m.MarketTitle
+		}
+
+		for _, o := range m.Outcomes {
+			outcome := MarketOutcomeStats{
+				Outcome: o.Outcome,
+				Holders: o.Holders,
+				Size:    o.Size,
+			}
+			if m.TotalSize > 0 {
+				outcome.Share = o.Size / m.TotalSize
+			}
+			result.Outcomes = append(result.Outcomes, outcome)
+		}
+
+		// Outcomes are ordered by size, so the first one is the group's lean
+		if len(result.Outcomes) > 0 && m.TotalSize > 0 {
+			result.LeanOutcome = &result.Outcomes[0].Outcome
+			result.LeanShare = &result.Outcomes[0].Share
+		}
+
+		results = append(results, result)
+	}
+
+	h.respondList(w, r, results, results, unpaged(len(results)))
+}
+
+// GetSentiment returns group sentiment aggregated from open positions across all tracked users
+func (h *APIHandler) GetSentiment(w http.ResponseWriter, r *http.Request, params GetSentimentParams) {
+	ctx := r.Context()
+
+	sortBy := "totalSize"
+	if params.SortBy != nil {
+		sortBy = string(*params.SortBy)
+	}
+
+	sortDirection := "desc"
+	if params.SortDirection != nil {
+		sortDirection = string(*params.SortDirection)
+	}
+
+	limit := 50
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	dbSentiment, err := h.storage.GetMarketSentiment(ctx)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get market sentiment")
+		respondError(w, http.StatusInternalServerError, "Failed to get sentiment")
+		return
+	}
+
+	sentiment := make([]MarketSentiment, 0, len(dbSentiment))
+	for _, m := range dbSentiment {
+		market := MarketSentiment{
+			ConditionId: m.ConditionID,
+			MarketTitle: "",
+			MarketSlug:  m.MarketSlug,
+			Holders:     m.Holders,
+			TotalSize:   m.TotalSize,
+			TotalValue:  m.TotalValue,
+			Outcomes:    make([]MarketOutcomeStats, 0, len(m.Outcomes)),
+		}
+		if m.MarketTitle != nil {
+			market.MarketTitle = *m.MarketTitle
+		}
+
+		for _, o := range m.Outcomes {
+			outcome := MarketOutcomeStats{
+				Outcome: o.Outcome,
+				Holders: o.Holders,
+				Size:    o.Size,
+			}
+			if m.TotalSize > 0 {
+				outcome.Share = o.Size / m.TotalSize
+			}
+			market.Outcomes = append(market.Outcomes, outcome)
+		}
+
+		// Outcomes are ordered by size, so the first one is the group's lean
+		if len(market.Outcomes) > 0 && m.TotalSize > 0 {
+			market.LeanOutcome = &market.Outcomes[0].Outcome
+			market.SentimentScore = 2*market.Outcomes[0].Share - 1
+		}
+
+		sentiment = append(sentiment, market)
+	}
+
+	sort.Slice(sentiment, func(i, j int) bool {
+		var less bool
+		switch sortBy {
+		case "totalValue":
+			less = sentiment[i].TotalValue < sentiment[j].TotalValue
+		case "holders":
+			less = sentiment[i].Holders < sentiment[j].Holders
+		case "sentimentScore":
+			less = sentiment[i].SentimentScore < sentiment[j].SentimentScore
+		default:
+			less = sentiment[i].TotalSize < sentiment[j].TotalSize
+		}
+
+		if sortDirection == "asc" {
+			return less
+		}
+		return !less
+	})
+
+	if limit > 0 && len(sentiment) > limit {
+		sentiment = sentiment[:limit]
+	}
+
+	h.respondList(w, r, sentiment, sentiment, unpaged(len(sentiment)))
+}
+
+// GetEventLeaderboard ranks tracked users by PnL within a single event
+func (h *APIHandler) GetEventLeaderboard(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	if _, err := h.storage.GetEvent(ctx, slug); err != nil {
+		h.log.WithError(err).WithField("event", slug).Error("failed to get event")
+		respondStorageError(w, err, "Event not found", "Failed to get event")
+		return
+	}
+
+	stats, err := h.storage.GetEventLeaderboard(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("event", slug).Error("failed to get event leaderboard")
+		respondError(w, http.StatusInternalServerError, "Failed to get event leaderboard")
+		return
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(stats))
+	for i, stat := range stats {
+		entry := LeaderboardEntry{
+			Rank:          i + 1,
+			Username:      stat.Username,
+			ProfileImage:  stat.ProfileImage,
+			TotalPnl:      stat.TotalPnl,
+			RealizedPnl:   stat.RealizedPnl,
+			UnrealizedPnl: stat.UnrealizedPnl,
+		}
+
+		if stat.OpenPositions > 0 {
+			entry.OpenPositions = &stat.OpenPositions
+		}
+		if stat.WinRate > 0 {
+			entry.WinRate = &stat.WinRate
+		}
+		if stat.Volume > 0 {
+			entry.Volume = &stat.Volume
+		}
+
+		entries = append(entries, entry)
+	}
+
+	respondJSON(w, http.StatusOK, EventLeaderboard{
+		EventSlug: slug,
+		Entries:   entries,
+	})
+}
+
+// GetGroupEquity returns the combined exposure and PnL of all tracked users, or of a persona
+func (h *APIHandler) GetGroupEquity(w http.ResponseWriter, r *http.Request, params GetGroupEquityParams) {
+	ctx := r.Context()
+
+	var users []*storage.User
+	if params.Persona != nil && *params.Persona != "" {
+		persona, err := h.storage.GetPersona(ctx, *params.Persona)
+		if err != nil {
+			h.log.WithError(err).WithField("persona", *params.Persona).Error("failed to get persona")
+			respondStorageError(w, err, "Persona not found", "Failed to get persona")
+			return
+		}
+
+		users, err = h.storage.GetPersonaUsers(ctx, persona.ID)
+		if err != nil {
+			h.log.WithError(err).WithField("persona", *params.Persona).Error("failed to get persona users")
+			respondError(w, http.StatusInternalServerError, "Failed to get group equity")
+			return
+		}
+	} else {
+		var err error
+		users, err = h.storage.GetUsers(ctx)
+		if err != nil {
+			h.log.WithError(err).Error("failed to get users")
+			respondError(w, http.StatusInternalServerError, "Failed to get group equity")
+			return
+		}
+	}
+	// Ghost users are tracked but never counted publicly
+	users = publicUsers(users)
+
+	equity, err := h.storage.GetGroupEquity(ctx, users, params.Start, params.End)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get group equity")
+		respondError(w, http.StatusInternalServerError, "Failed to get group equity")
+		return
+	}
+
+	if params.Netting != nil && *params.Netting {
+		if err := h.netEquity(ctx, equity, users); err != nil {
+			h.log.WithError(err).Error("failed to net group equity")
+			respondError(w, http.StatusInternalServerError, "Failed to get group equity")
+			return
+		}
+	}
+
+	bestCasePnl, worstCasePnl, err := h.resolutionScenarios(ctx, users)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get group resolution scenarios")
+		respondError(w, http.StatusInternalServerError, "Failed to get group equity")
+		return
+	}
+
+	dataPoints := make([]PnlDataPoint, len(equity.DataPoints))
+	for i, point := range equity.DataPoints {
+		dataPoints[i] = PnlDataPoint{
+			Timestamp:     point.Timestamp,
+			TotalPnl:      point.TotalPnl,
+			RealizedPnl:   point.RealizedPnl,
+			UnrealizedPnl: point.UnrealizedPnl,
+		}
+	}
+
+	response := GroupEquity{
+		Users:             equity.Users,
+		OpenPositions:     equity.OpenPositions,
+		OpenPositionValue: equity.OpenPositionValue,
+		TotalPnl:          equity.TotalPnl,
+		RealizedPnl:       equity.RealizedPnl,
+		UnrealizedPnl:     equity.UnrealizedPnl,
+		BestCasePnl:       bestCasePnl,
+		WorstCasePnl:      worstCasePnl,
+		DataPoints:        dataPoints,
+	}
+	if params.Persona != nil && *params.Persona != "" {
+		response.Persona = params.Persona
+	}
+
 	respondJSON(w, http.StatusOK, response)
 }
+
+// toAPIPriceSeries converts a storage price series to its API form
+func toAPIPriceSeries(points []*storage.PricePoint) []PricePoint {
+	series := make([]PricePoint, 0, len(points))
+	for _, point := range points {
+		series = append(series, PricePoint{Timestamp: point.Timestamp, Price: point.Price})
+	}
+	return series
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetMarket returns the cached Gamma metadata of a market traded by tracked users
+func (h *APIHandler) GetMarket(w http.ResponseWriter, r *http.Request, conditionId string) {
+	market, err := h.storage.GetMarket(r.Context(), conditionId)
+	if err != nil {
+		h.log.WithError(err).WithField("condition_id", conditionId).Error("failed to get market")
+		respondStorageError(w, err, "Market not cached yet", "Failed to get market")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toAPIMarketMetadata(market))
+}
+
+// toAPIMarketMetadata converts a cached market to the API representation
+func toAPIMarketMetadata(market *storage.Market) MarketMetadata {
+	outcomes := make([]MarketOutcome, len(market.Outcomes))
+	for i, outcome := range market.Outcomes {
+		outcomes[i] = MarketOutcome{Name: outcome.Name, Price: outcome.Price}
+	}
+
+	return MarketMetadata{
+		ConditionId:    market.ConditionID,
+		Title:          market.Title,
+		Slug:           market.Slug,
+		EventSlug:      market.EventSlug,
+		EventTitle:     market.EventTitle,
+		Category:       market.Category,
+		EndDate:        market.EndDate,
+		Closed:         market.Closed,
+		Resolved:       market.Resolved,
+		WinningOutcome: market.WinningOutcome,
+		Outcomes:       outcomes,
+		Found:          market.Found,
+		FetchedAt:      market.FetchedAt,
+	}
+}
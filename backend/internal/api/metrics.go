@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Metrics serves sync health and cache hit rates in the Prometheus text exposition format
+func (h *APIHandler) Metrics(w http.ResponseWriter, _ *http.Request) {
+	crashes := h.sync.Crashes()
+	statsCache := h.storage.UserStatsCacheStats()
+
+	leader := 0
+	if h.sync.IsLeader() {
+		leader = 1
+	}
+	var lastCrash float64
+	if crashes.LastAt != nil {
+		lastCrash = float64(crashes.LastAt.UnixMilli()) / 1000
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprintf(w, `# HELP pyre_sync_crashes_total Panics recovered during sync.
+# TYPE pyre_sync_crashes_total counter
+pyre_sync_crashes_total %d
+# HELP pyre_sync_last_crash_timestamp_seconds Time of the last panic recovered during sync, 0 if none.
+# TYPE pyre_sync_last_crash_timestamp_seconds gauge
+pyre_sync_last_crash_timestamp_seconds %.3f
+# HELP pyre_sync_leader Whether this instance performs sync.
+# TYPE pyre_sync_leader gauge
+pyre_sync_leader %d
+# HELP pyre_user_stats_cache_hits_total User stats served with FIFO totals cached since the user's last sync.
+# TYPE pyre_user_stats_cache_hits_total counter
+pyre_user_stats_cache_hits_total %d
+# HELP pyre_user_stats_cache_misses_total User stats whose FIFO totals were recomputed from trades.
+# TYPE pyre_user_stats_cache_misses_total counter
+pyre_user_stats_cache_misses_total %d
+`, crashes.Count, lastCrash, leader, statsCache.Hits, statsCache.Misses)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetFeedMuteRules returns the trade feed mute rules from config and those set through the API
+func (h *APIHandler) GetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+	custom, err := h.storage.GetMuteRules(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to get mute rules")
+		respondError(w, http.StatusInternalServerError, "Failed to get mute rules")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, FeedMuteRules{
+		Config: toAPIMuteRules(h.feedMute),
+		Custom: toAPIMuteRules(custom),
+	})
+}
+
+// SetFeedMuteRules replaces the trade feed mute rules set through the API
+func (h *APIHandler) SetFeedMuteRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req MuteRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	rules := &storage.MuteRules{
+		MinValue:   req.MinValue,
+		Users:      make([]string, 0),
+		Categories: make([]string, 0),
+	}
+	if rules.MinValue != nil && *rules.MinValue < 0 {
+		respondError(w, http.StatusBadRequest, "Mute min value must not be negative")
+		return
+	}
+	if req.Users != nil {
+		rules.Users = *req.Users
+	}
+	if req.Categories != nil {
+		for _, category := range *req.Categories {
+			if !storage.IsMarketCategory(category) {
+				respondError(w, http.StatusBadRequest, fmt.Sprintf("Unknown market category: %s", category))
+				return
+			}
+		}
+		rules.Categories = *req.Categories
+	}
+
+	if err := h.storage.SetMuteRules(ctx, rules); err != nil {
+		h.log.WithError(err).Error("failed to set mute rules")
+		respondError(w, http.StatusInternalServerError, "Failed to set mute rules")
+		return
+	}
+
+	h.log.WithField("rules", req).Info("updated feed mute rules")
+
+	respondJSON(w, http.StatusOK, FeedMuteRules{
+		Config: toAPIMuteRules(h.feedMute),
+		Custom: toAPIMuteRules(rules),
+	})
+}
+
+// applyMuteRules adds the feed mute rules to the trade filters. Clients can disable the
+// rules entirely with mute=false, or replace parts of them with their own query params.
+func (h *APIHandler) applyMuteRules(ctx context.Context, filters *storage.TradeFilters, params GetTradesParams) {
+	if params.Mute != nil && !*params.Mute {
+		return
+	}
+
+	rules := []*storage.MuteRules{h.feedMute}
+	custom, err := h.storage.GetMuteRules(ctx)
+	if err != nil {
+		// Fall back to the config rules rather than failing the feed
+		h.log.WithError(err).Warn("failed to get mute rules")
+	} else {
+		rules = append(rules, custom)
+	}
+
+	var minValue float64
+	var users, categories []string
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		if rule.MinValue != nil && *rule.MinValue > minValue {
+			minValue = *rule.MinValue
+		}
+		users = append(users, rule.Users...)
+		categories = append(categories, rule.Categories...)
+	}
+
+	if params.MinValue == nil && minValue > 0 {
+		filters.MinValue = &minValue
+	}
+
+	if params.MuteUsers != nil {
+		users = *params.MuteUsers
+	}
+	filters.MutedUsers = users
+
+	if params.MuteCategories != nil {
+		categories = *params.MuteCategories
+	}
+	filters.MutedCategories = categories
+}
+
+// toAPIMuteRules converts storage mute rules to the API representation
+func toAPIMuteRules(rules *storage.MuteRules) MuteRules {
+	users := make([]string, 0)
+	categories := make([]string, 0)
+	result := MuteRules{
+		Users:      &users,
+		Categories: &categories,
+	}
+	if rules == nil {
+		return result
+	}
+
+	if rules.MinValue != nil && *rules.MinValue > 0 {
+		result.MinValue = rules.MinValue
+	}
+	users = append(users, rules.Users...)
+	categories = append(categories, rules.Categories...)
+
+	return result
+}
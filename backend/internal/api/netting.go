@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/netting"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetUserNetPositions returns a user's positions netted per market
+func (h *APIHandler) GetUserNetPositions(w http.ResponseWriter, r *http.Request, username string, params GetUserNetPositionsParams) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	includeDust := params.IncludeDust != nil && *params.IncludeDust
+
+	positions, err := h.storage.GetUserOpenPositions(ctx, user.ID, includeDust)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get positions")
+		respondError(w, http.StatusInternalServerError, "Failed to get positions")
+		return
+	}
+
+	net := toAPINetPositions(netting.Net(positions))
+	h.respondList(w, r, net, net, unpaged(len(net)))
+}
+
+// GetPersonaNetPositions returns a persona's positions across all accounts netted per market
+func (h *APIHandler) GetPersonaNetPositions(w http.ResponseWriter, r *http.Request, slug string, params GetPersonaNetPositionsParams) {
+	includeDust := params.IncludeDust != nil && *params.IncludeDust
+
+	dbPositions, err := h.storage.GetPersonaPositions(r.Context(), slug, "", "desc", includeDust)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona positions")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona positions")
+		return
+	}
+
+	positions := make([]*storage.Position, 0, len(dbPositions))
+	for _, pos := range dbPositions {
+		positions = append(positions, &pos.Position)
+	}
+
+	net := toAPINetPositions(netting.Net(positions))
+	h.respondList(w, r, net, net, unpaged(len(net)))
+}
+
+// netEquity replaces the open positions, exposure and unrealized PnL of group equity with
+// those of each user's positions netted per market. Users are netted separately, as one
+// user's hedge doesn't offset another's exposure. Like the un-netted stats, dust counts
+// towards PnL but not towards open positions and exposure.
+func (h *APIHandler) netEquity(ctx context.Context, equity *storage.GroupEquity, users []*storage.User) error {
+	equity.OpenPositions = 0
+	equity.OpenPositionValue = 0
+	equity.UnrealizedPnl = 0
+
+	for _, user := range users {
+		positions, err := h.storage.GetUserOpenPositions(ctx, user.ID, false)
+		if err != nil {
+			return err
+		}
+		open, exposure, _ := netting.Totals(netting.Net(positions))
+
+		positions, err = h.storage.GetUserOpenPositions(ctx, user.ID, true)
+		if err != nil {
+			return err
+		}
+		_, _, unrealizedPnl := netting.Totals(netting.Net(positions))
+
+		equity.OpenPositions += open
+		equity.OpenPositionValue += exposure
+		equity.UnrealizedPnl += unrealizedPnl
+	}
+
+	equity.TotalPnl = equity.RealizedPnl + equity.UnrealizedPnl
+
+	return nil
+}
+
+// toAPINetPositions converts net positions to the API representation
+func toAPINetPositions(positions []*netting.Position) []NetPosition {
+	out := make([]NetPosition, 0, len(positions))
+	for _, pos := range positions {
+		position := NetPosition{
+			ConditionId:   pos.ConditionID,
+			MarketSlug:    pos.MarketSlug,
+			Size:          pos.Size,
+			AvgPrice:      pos.AvgPrice,
+			CurrentPrice:  pos.CurrentPrice,
+			HedgedSize:    pos.HedgedSize,
+			LockedValue:   pos.LockedValue(),
+			CostBasis:     pos.CostBasis,
+			CurrentValue:  pos.CurrentValue,
+			UnrealizedPnl: pos.UnrealizedPnl,
+			EndDate:       pos.EndDate,
+			Legs:          pos.Legs,
+		}
+		if pos.MarketTitle != nil {
+			position.MarketTitle = *pos.MarketTitle
+		}
+		if pos.Outcome != "" {
+			position.Outcome = &pos.Outcome
+		}
+		out = append(out, position)
+	}
+
+	return out
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/notify"
+)
+
+// PreviewNotification lays out a sample notification with a channel's template, or one to try,
+// and posts it to the channel when asked
+func (h *APIHandler) PreviewNotification(w http.ResponseWriter, r *http.Request) {
+	if h.notifications == nil {
+		respondError(w, http.StatusNotFound, "Notifications are disabled")
+		return
+	}
+
+	var req NotificationPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	kind, ok := notify.ParseKind(string(req.Kind))
+	if !ok {
+		respondError(w, http.StatusBadRequest, "Kind must be trade, position_opened or position_closed")
+		return
+	}
+	channel := string(Discord)
+	if req.Channel != nil {
+		channel = string(*req.Channel)
+	}
+	send := req.Send != nil && *req.Send
+
+	var source string
+	if req.Template != nil {
+		source = *req.Template
+	}
+
+	notification, err := h.notifications.Preview(r.Context(), channel, kind, source, send)
+	switch {
+	case errors.Is(err, notify.ErrUnknownChannel):
+		respondError(w, http.StatusNotFound, "Notification channel is not set up")
+		return
+	case errors.Is(err, notify.ErrInvalidTemplate):
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.log.WithError(err).WithField("channel", channel).Error("failed to send sample notification")
+		respondError(w, http.StatusBadGateway, "Failed to send notification")
+		return
+	}
+
+	preview := NotificationPreview{
+		Channel:  channel,
+		Kind:     string(notification.Kind),
+		Headline: notification.Headline,
+		Market:   notification.Market,
+		Sent:     send,
+	}
+	if notification.URL != "" {
+		preview.Url = &notification.URL
+	}
+
+	respondJSON(w, http.StatusOK, preview)
+}
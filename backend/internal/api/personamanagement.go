@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// personaSlugPattern matches the slugs personas can be created with through the API
+var personaSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// reservedPersonaSlugs are taken by other routes under /personas
+var reservedPersonaSlugs = []string{"leaderboard"}
+
+// CreatePersona creates a persona, without accounts until users are attached
+func (h *APIHandler) CreatePersona(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreatePersonaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.DisplayName = strings.TrimSpace(req.DisplayName)
+	if !personaSlugPattern.MatchString(req.Slug) || slices.Contains(reservedPersonaSlugs, req.Slug) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid persona slug: %q", req.Slug))
+		return
+	}
+	if req.DisplayName == "" {
+		respondError(w, http.StatusBadRequest, "Display name is required")
+		return
+	}
+
+	var persona *storage.Persona
+	var err error
+	if req.Image != nil && *req.Image != "" {
+		persona, err = h.storage.CreatePersonaWithImage(ctx, req.Slug, req.DisplayName, *req.Image)
+	} else {
+		persona, err = h.storage.CreatePersona(ctx, req.Slug, req.DisplayName)
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("slug", req.Slug).Error("failed to create persona")
+		respondStorageError(w, err, "Persona not found", "Failed to create persona")
+		return
+	}
+	h.recordRosterChanges(r, []roster.Change{{Action: roster.ActionCreatePersona, Target: persona.Slug, Detail: persona.DisplayName}})
+
+	h.log.WithField("slug", persona.Slug).Info("created persona")
+
+	h.respondPersonaSummary(w, r, persona, http.StatusCreated)
+}
+
+// UpdatePersona changes a persona's display name or image
+func (h *APIHandler) UpdatePersona(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	var req UpdatePersonaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.DisplayName != nil {
+		name := strings.TrimSpace(*req.DisplayName)
+		if name == "" {
+			respondError(w, http.StatusBadRequest, "Display name must not be empty")
+			return
+		}
+		req.DisplayName = &name
+	}
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	var changes []roster.Change
+	if req.DisplayName != nil && *req.DisplayName != persona.DisplayName {
+		if err := h.storage.UpdatePersonaDisplayName(ctx, persona.ID, *req.DisplayName); err != nil {
+			h.log.WithError(err).WithField("slug", slug).Error("failed to update persona")
+			respondError(w, http.StatusInternalServerError, "Failed to update persona")
+			return
+		}
+		persona.DisplayName = *req.DisplayName
+		changes = append(changes, roster.Change{Action: roster.ActionUpdatePersona, Target: slug, Detail: "displayName: " + *req.DisplayName})
+	}
+	if req.Image != nil && (persona.Image == nil || *persona.Image != *req.Image) {
+		if err := h.storage.UpdatePersonaImage(ctx, persona.ID, *req.Image); err != nil {
+			h.recordRosterChanges(r, changes)
+			h.log.WithError(err).WithField("slug", slug).Error("failed to update persona")
+			respondError(w, http.StatusInternalServerError, "Failed to update persona")
+			return
+		}
+		persona.Image = req.Image
+		changes = append(changes, roster.Change{Action: roster.ActionUpdatePersona, Target: slug, Detail: "image: " + *req.Image})
+	}
+	h.recordRosterChanges(r, changes)
+
+	h.respondPersonaSummary(w, r, persona, http.StatusOK)
+}
+
+// DeletePersona deletes a persona, leaving its accounts tracked without one
+func (h *APIHandler) DeletePersona(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	if err := h.storage.DeletePersona(ctx, persona.ID); err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to delete persona")
+		respondError(w, http.StatusInternalServerError, "Failed to delete persona")
+		return
+	}
+	h.recordRosterChanges(r, []roster.Change{{Action: roster.ActionDeletePersona, Target: slug}})
+
+	h.log.WithField("slug", slug).Info("deleted persona")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AttachPersonaUser moves a user into a persona
+func (h *APIHandler) AttachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string) {
+	h.movePersonaUser(w, r, slug, username, true)
+}
+
+// DetachPersonaUser removes a user from a persona, keeping them tracked
+func (h *APIHandler) DetachPersonaUser(w http.ResponseWriter, r *http.Request, slug string, username string) {
+	h.movePersonaUser(w, r, slug, username, false)
+}
+
+// movePersonaUser attaches a user to the persona, or detaches them from it
+func (h *APIHandler) movePersonaUser(w http.ResponseWriter, r *http.Request, slug, username string, attach bool) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	current, err := h.storage.GetUserPersonaInfo(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user persona")
+		respondError(w, http.StatusInternalServerError, "Failed to get user persona")
+		return
+	}
+	currentSlug := ""
+	if current != nil {
+		currentSlug = current.Slug
+	}
+
+	target := ""
+	switch {
+	case attach:
+		target = slug
+	case currentSlug != slug:
+		respondError(w, http.StatusNotFound, fmt.Sprintf("User %s is not in persona %s", username, slug))
+		return
+	}
+
+	if currentSlug != target {
+		if attach {
+			err = h.storage.UpdateUserPersona(ctx, user.ID, persona.ID)
+		} else {
+			err = h.storage.ClearUserPersona(ctx, user.ID)
+		}
+		if err != nil {
+			h.log.WithError(err).WithField("username", username).Error("failed to update user persona")
+			respondError(w, http.StatusInternalServerError, "Failed to update user persona")
+			return
+		}
+		h.recordRosterChanges(r, []roster.Change{{
+			Action: roster.ActionMoveUser,
+			Target: username,
+			Detail: fmt.Sprintf("%s -> %s", roster.PersonaDetail(currentSlug), roster.PersonaDetail(target)),
+		}})
+	}
+
+	h.respondPersonaSummary(w, r, persona, http.StatusOK)
+}
+
+// respondPersonaSummary sends a persona with its current accounts
+func (h *APIHandler) respondPersonaSummary(w http.ResponseWriter, r *http.Request, persona *storage.Persona, status int) {
+	summary, err := h.personaSummary(r.Context(), persona)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", persona.Slug).Error("failed to get persona users")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona accounts")
+		return
+	}
+	respondJSON(w, status, summary)
+}
+
+// personaSummary converts a persona to its summary, with the usernames of its accounts
+func (h *APIHandler) personaSummary(ctx context.Context, persona *storage.Persona) (PersonaSummary, error) {
+	users, err := h.storage.GetPersonaUsers(ctx, persona.ID)
+	if err != nil {
+		return PersonaSummary{}, err
+	}
+
+	summary := PersonaSummary{
+		Slug:        persona.Slug,
+		DisplayName: persona.DisplayName,
+		Usernames:   make([]string, len(users)),
+		Image:       persona.Image,
+	}
+	for i, user := range users {
+		summary.Usernames[i] = user.Username
+	}
+	return summary, nil
+}
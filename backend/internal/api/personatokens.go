@@ -0,0 +1,307 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/tradecheck"
+)
+
+const (
+	// personaTokenPrefix makes persona tokens recognisable, e.g. in a leaked config
+	personaTokenPrefix = "pyre_pt_"
+	// reconcileTolerance is how far in dollars a trade's value may be from price times size
+	reconcileTolerance = 0.01
+	// taxExportPageSize is how many results are read at a time for a tax export
+	taxExportPageSize = 500
+)
+
+// taxExportHeader is the CSV header row for tax exports
+var taxExportHeader = []string{
+	"username", "conditionId", "marketTitle", "outcome", "cost", "realizedPnl", "resolvedAt",
+}
+
+// GetPersonaTokens lists the tokens issued for a persona
+func (h *APIHandler) GetPersonaTokens(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	tokens, err := h.storage.GetPersonaTokens(ctx, persona.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona tokens")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona tokens")
+		return
+	}
+
+	response := PersonaTokensResponse{Tokens: make([]PersonaToken, len(tokens))}
+	for i, token := range tokens {
+		response.Tokens[i] = toAPIPersonaToken(token)
+	}
+
+	h.respondList(w, r, response, response.Tokens, unpaged(len(response.Tokens)))
+}
+
+// CreatePersonaToken issues a token for a persona's owner. Only its hash is stored, so the
+// token is returned this once.
+func (h *APIHandler) CreatePersonaToken(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	var req PersonaTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "Token name is required")
+		return
+	}
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		h.log.WithError(err).Error("failed to generate persona token")
+		respondError(w, http.StatusInternalServerError, "Failed to create persona token")
+		return
+	}
+	secret := personaTokenPrefix + hex.EncodeToString(b)
+
+	token, err := h.storage.CreatePersonaToken(ctx, persona.ID, req.Name, hashToken(secret))
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to create persona token")
+		respondError(w, http.StatusInternalServerError, "Failed to create persona token")
+		return
+	}
+
+	h.log.WithField("slug", slug).WithField("token", token.ID).Info("issued persona token")
+
+	respondJSON(w, http.StatusCreated, IssuedPersonaToken{
+		Token:  toAPIPersonaToken(token),
+		Secret: secret,
+	})
+}
+
+// RevokePersonaToken revokes one of a persona's tokens
+func (h *APIHandler) RevokePersonaToken(w http.ResponseWriter, r *http.Request, slug string, id int64) {
+	ctx := r.Context()
+
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondStorageError(w, err, "Persona not found", "Failed to get persona")
+		return
+	}
+
+	if err := h.storage.RevokePersonaToken(ctx, persona.ID, id); err != nil {
+		h.log.WithError(err).WithField("slug", slug).WithField("token", id).Error("failed to revoke persona token")
+		respondStorageError(w, err, "Token not found", "Failed to revoke persona token")
+		return
+	}
+
+	h.log.WithField("slug", slug).WithField("token", id).Info("revoked persona token")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPersonaOwnerDetail returns the details of a persona's accounts only its owner may see
+func (h *APIHandler) GetPersonaOwnerDetail(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+
+	persona, ok := h.personaOwner(w, r, slug)
+	if !ok {
+		return
+	}
+
+	users, err := h.storage.GetPersonaUsers(ctx, persona.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona users")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona accounts")
+		return
+	}
+
+	detail := PersonaOwnerDetail{Slug: persona.Slug, Accounts: make([]OwnerAccount, 0, len(users))}
+	for _, user := range users {
+		addresses, err := h.storage.GetUserAddresses(ctx, user.ID)
+		if err != nil {
+			h.log.WithError(err).WithField("username", user.Username).Error("failed to get user addresses")
+			respondError(w, http.StatusInternalServerError, "Failed to get persona accounts")
+			return
+		}
+
+		trades, err := h.storage.GetUserTradesChronological(ctx, user.ID)
+		if err != nil {
+			h.log.WithError(err).WithField("username", user.Username).Error("failed to get trades")
+			respondError(w, http.StatusInternalServerError, "Failed to get persona accounts")
+			return
+		}
+
+		account := OwnerAccount{
+			Username:       user.Username,
+			Addresses:      make([]string, len(addresses)),
+			Ghost:          user.Ghost,
+			LastSynced:     user.LastSynced,
+			Reconciliation: toAPITradeReconciliation(tradecheck.Verify(trades, nil, reconcileTolerance)),
+		}
+		for i, addr := range addresses {
+			account.Addresses[i] = addr.Address
+		}
+
+		detail.Accounts = append(detail.Accounts, account)
+	}
+
+	respondJSON(w, http.StatusOK, detail)
+}
+
+// ExportPersonaTaxes writes a persona's resolved results as CSV
+func (h *APIHandler) ExportPersonaTaxes(w http.ResponseWriter, r *http.Request, slug string, params ExportPersonaTaxesParams) {
+	ctx := r.Context()
+
+	persona, ok := h.personaOwner(w, r, slug)
+	if !ok {
+		return
+	}
+
+	// Results are read before anything is written, so a failure can still be reported
+	var results []*storage.ResultWithUsername
+	for offset := 0; ; offset += taxExportPageSize {
+		page, total, err := h.storage.GetPersonaResults(ctx, persona.Slug, taxExportPageSize, offset, "resolutionDate", "asc", true)
+		if err != nil {
+			h.log.WithError(err).WithField("slug", slug).Error("failed to get persona results")
+			respondError(w, http.StatusInternalServerError, "Failed to export results")
+			return
+		}
+		results = append(results, page...)
+		if len(page) == 0 || offset+len(page) >= total {
+			break
+		}
+	}
+
+	filename := persona.Slug + "-results.csv"
+	if params.Year != nil {
+		filename = fmt.Sprintf("%s-results-%d.csv", persona.Slug, *params.Year)
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(taxExportHeader); err != nil {
+		h.log.WithError(err).Error("failed to write tax export")
+		return
+	}
+	for _, result := range results {
+		if params.Year != nil && (result.ResolutionDate == nil || result.ResolutionDate.UTC().Year() != *params.Year) {
+			continue
+		}
+
+		if err := cw.Write([]string{
+			result.Username,
+			result.ConditionID,
+			stringOrEmpty(result.MarketTitle),
+			stringOrEmpty(result.Outcome),
+			floatOrEmpty(result.InitialValue),
+			floatOrEmpty(&result.RealizedPnl),
+			timeOrEmpty(result.ResolutionDate),
+		}); err != nil {
+			h.log.WithError(err).Error("failed to write tax export")
+			return
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		h.log.WithError(err).Error("failed to write tax export")
+	}
+}
+
+// personaOwner returns the persona when the request carries one of its unrevoked tokens,
+// otherwise responding 401
+func (h *APIHandler) personaOwner(w http.ResponseWriter, r *http.Request, slug string) (*storage.Persona, bool) {
+	ctx := r.Context()
+
+	secret := requestAPIKey(r)
+	if secret == "" {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown token")
+		return nil, false
+	}
+
+	// Unknown personas look like a wrong token, the response doesn't say which it was
+	persona, err := h.storage.GetPersona(ctx, slug)
+	if errors.Is(err, storage.ErrNotFound) {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown token")
+		return nil, false
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona")
+		return nil, false
+	}
+
+	token, err := h.storage.UsePersonaToken(ctx, hashToken(secret))
+	if errors.Is(err, storage.ErrNotFound) || (err == nil && token.PersonaID != persona.ID) {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown token")
+		return nil, false
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to check persona token")
+		respondError(w, http.StatusInternalServerError, "Failed to check token")
+		return nil, false
+	}
+
+	return persona, true
+}
+
+// hashPersonaToken returns the hash a persona token is stored and looked up by
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// toAPIPersonaToken converts a storage persona token to the API representation
+func toAPIPersonaToken(token *storage.PersonaToken) PersonaToken {
+	return PersonaToken{
+		Id:         token.ID,
+		Name:       token.Name,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+	}
+}
+
+// toAPITradeReconciliation converts a trade value check to the API representation
+func toAPITradeReconciliation(report *tradecheck.Report) TradeReconciliation {
+	reconciliation := TradeReconciliation{
+		Checked:       report.Checked,
+		Discrepancies: make([]TradeDiscrepancy, len(report.Discrepancies)),
+	}
+	for i, d := range report.Discrepancies {
+		reconciliation.Discrepancies[i] = TradeDiscrepancy{
+			TradeId:     d.Trade.ID,
+			Timestamp:   d.Trade.Timestamp,
+			MarketTitle: d.Trade.MarketTitle,
+			Kind:        TradeDiscrepancyKind(d.Kind),
+			Value:       d.Trade.Value,
+			Recomputed:  d.Recomputed,
+		}
+	}
+	return reconciliation
+}
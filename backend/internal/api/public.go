@@ -0,0 +1,163 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/lookup"
+)
+
+// PublicAPI configures the public address PnL endpoint
+type PublicAPI struct {
+	Lookup            lookup.Service
+	Keys              []string // API keys accepted, any request is accepted when empty
+	RequestsPerMinute int      // per API key, or per client IP without keys. 0 is unlimited.
+}
+
+// GetPublicAddressPnl returns the PnL of an arbitrary address
+func (h *APIHandler) GetPublicAddressPnl(w http.ResponseWriter, r *http.Request, address string) {
+	if h.public == nil {
+		respondError(w, http.StatusNotFound, "Public API is disabled")
+		return
+	}
+
+	client, ok := h.publicClient(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown API key")
+		return
+	}
+
+	if wait := h.limiter.allow(client); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds()+0.999)))
+		respondError(w, http.StatusTooManyRequests, "Rate limit exceeded")
+		return
+	}
+
+	pnl, err := h.public.Lookup.AddressPnl(r.Context(), address)
+	if errors.Is(err, lookup.ErrInvalidAddress) {
+		respondError(w, http.StatusBadRequest, "Invalid address")
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("address", address).Error("failed to look up address pnl")
+		respondError(w, http.StatusBadGateway, "Failed to fetch address from Polymarket")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, AddressPnl{
+		Address:       pnl.Address,
+		Name:          pnl.Name,
+		ProfileImage:  pnl.ProfileImage,
+		TotalPnl:      pnl.TotalPnl,
+		RealizedPnl:   pnl.RealizedPnl,
+		UnrealizedPnl: pnl.UnrealizedPnl,
+		OpenPositions: pnl.OpenPositions,
+		PositionValue: pnl.PositionValue,
+		Volume:        pnl.Volume,
+		Source:        pnl.Source,
+		ComputedAt:    pnl.ComputedAt,
+	})
+}
+
+// publicClient identifies the caller of the public API for rate limiting: its API key when
+// keys are configured, otherwise its IP. ok is false when a key is required but not valid.
+func (h *APIHandler) publicClient(r *http.Request) (string, bool) {
+	if len(h.public.Keys) == 0 {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			return r.RemoteAddr, true
+		}
+		return host, true
+	}
+
+	key := requestAPIKey(r)
+	if key == "" {
+		return "", false
+	}
+
+	for _, valid := range h.public.Keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(valid)) == 1 {
+			return "key:" + valid, true
+		}
+	}
+
+	return "", false
+}
+
+// requestAPIKey returns the API key sent in the X-API-Key header or the apiKey query parameter
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	return r.URL.Query().Get("apiKey")
+}
+
+// rateLimiter allows a number of requests per client in each one-minute window
+type rateLimiter struct {
+	limit int
+
+	mu         sync.Mutex
+	windows    map[string]*rateWindow
+	lastPruned time.Time
+}
+
+// rateWindow counts a client's requests in the current window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter creates a limiter allowing limit requests per client per minute, 0 for no limit
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// allow counts a request by a client, returning how long to wait if it is over the limit
+func (l *rateLimiter) allow(client string) time.Duration {
+	if l.limit <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	win, ok := l.windows[client]
+	if !ok || now.Sub(win.start) >= time.Minute {
+		l.prune(now)
+		win = &rateWindow{start: now}
+		l.windows[client] = win
+	}
+
+	if win.count >= l.limit {
+		return win.start.Add(time.Minute).Sub(now)
+	}
+	win.count++
+
+	return 0
+}
+
+// prune drops windows that have ended, at most once a minute, so idle clients don't
+// accumulate. Callers hold l.mu.
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < time.Minute {
+		return
+	}
+	l.lastPruned = now
+
+	for client, win := range l.windows {
+		if now.Sub(win.start) >= time.Minute {
+			delete(l.windows, client)
+		}
+	}
+}
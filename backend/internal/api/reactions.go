@@ -0,0 +1,295 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// maxEmojiRunes bounds an emoji reaction, enough for flags and sequences joined with
+// zero-width joiners and skin tone modifiers
+const maxEmojiRunes = 16
+
+// Reactions configures comments and emoji reactions on trades and results
+type Reactions struct {
+	Authors           map[string]string // API key to the display name its reactions are posted as
+	MaxCommentLength  int               // in characters
+	RequestsPerMinute int               // posts per API key, 0 is unlimited
+}
+
+// GetTradeReactions returns the reactions posted on a trade
+func (h *APIHandler) GetTradeReactions(w http.ResponseWriter, r *http.Request, tradeId string) {
+	if h.reactions == nil {
+		respondError(w, http.StatusNotFound, "Reactions are disabled")
+		return
+	}
+
+	reactions, err := h.storage.GetTradeReactions(r.Context(), []string{tradeId})
+	if err != nil {
+		h.log.WithError(err).WithField("trade_id", tradeId).Error("failed to get trade reactions")
+		respondError(w, http.StatusInternalServerError, "Failed to get reactions")
+		return
+	}
+
+	response := toAPIReactions(reactions[tradeId])
+	h.respondList(w, r, response, response, unpaged(len(response)))
+}
+
+// AddTradeReaction posts a comment or emoji reaction on a trade
+func (h *APIHandler) AddTradeReaction(w http.ResponseWriter, r *http.Request, tradeId string) {
+	reaction, ok := h.newReaction(w, r)
+	if !ok {
+		return
+	}
+	reaction.TradeID = &tradeId
+
+	h.addReaction(w, r, reaction, "Trade not found")
+}
+
+// GetResultReactions returns the reactions posted on a user's result in one market
+func (h *APIHandler) GetResultReactions(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	ctx := r.Context()
+
+	if h.reactions == nil {
+		respondError(w, http.StatusNotFound, "Reactions are disabled")
+		return
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	reactions, err := h.storage.GetResultReactions(ctx, user.ID, conditionId)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).WithField("condition_id", conditionId).Error("failed to get result reactions")
+		respondError(w, http.StatusInternalServerError, "Failed to get reactions")
+		return
+	}
+
+	response := toAPIReactions(reactions)
+	h.respondList(w, r, response, response, unpaged(len(response)))
+}
+
+// AddResultReaction posts a comment or emoji reaction on a user's result in one market
+func (h *APIHandler) AddResultReaction(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	reaction, ok := h.newReaction(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.storage.GetUser(r.Context(), username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+	reaction.UserID = user.ID
+	reaction.ConditionID = conditionId
+
+	h.addReaction(w, r, reaction, "Result not found")
+}
+
+// DeleteReaction removes a reaction posted under the caller's display name
+func (h *APIHandler) DeleteReaction(w http.ResponseWriter, r *http.Request, id int64) {
+	if h.reactions == nil {
+		respondError(w, http.StatusNotFound, "Reactions are disabled")
+		return
+	}
+
+	author, ok := h.reactionAuthor(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown API key")
+		return
+	}
+
+	deleted, err := h.storage.DeleteReaction(r.Context(), id, author)
+	if err != nil {
+		h.log.WithError(err).WithField("reaction_id", id).Error("failed to delete reaction")
+		respondError(w, http.StatusInternalServerError, "Failed to delete reaction")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Reaction not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newReaction authenticates, rate limits and validates a posted reaction, responding with the
+// error when it is rejected
+func (h *APIHandler) newReaction(w http.ResponseWriter, r *http.Request) (*storage.Reaction, bool) {
+	if h.reactions == nil {
+		respondError(w, http.StatusNotFound, "Reactions are disabled")
+		return nil, false
+	}
+
+	author, ok := h.reactionAuthor(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Missing or unknown API key")
+		return nil, false
+	}
+
+	if wait := h.reactionLimiter.allow(author); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds()+0.999)))
+		respondError(w, http.StatusTooManyRequests, "Rate limit exceeded")
+		return nil, false
+	}
+
+	var req NewReaction
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+
+	reaction := &storage.Reaction{Author: author}
+	switch {
+	case req.Emoji != nil && req.Comment != nil:
+		respondError(w, http.StatusBadRequest, "A reaction is either an emoji or a comment, not both")
+		return nil, false
+
+	case req.Emoji != nil:
+		emoji := strings.TrimSpace(*req.Emoji)
+		if !isEmoji(emoji) {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid emoji: %q", *req.Emoji))
+			return nil, false
+		}
+		reaction.Emoji = &emoji
+
+	case req.Comment != nil:
+		comment := strings.TrimSpace(*req.Comment)
+		if comment == "" {
+			respondError(w, http.StatusBadRequest, "Comment is empty")
+			return nil, false
+		}
+		if n := utf8.RuneCountInString(comment); n > h.reactions.MaxCommentLength {
+			respondError(w, http.StatusBadRequest,
+				fmt.Sprintf("Comment is %d characters, the limit is %d", n, h.reactions.MaxCommentLength))
+			return nil, false
+		}
+		reaction.Comment = &comment
+
+	default:
+		respondError(w, http.StatusBadRequest, "An emoji or a comment is required")
+		return nil, false
+	}
+
+	return reaction, true
+}
+
+// addReaction stores a validated reaction, responding with it or notFound when its target
+// doesn't exist
+func (h *APIHandler) addReaction(w http.ResponseWriter, r *http.Request, reaction *storage.Reaction, notFound string) {
+	added, err := h.storage.AddReaction(r.Context(), reaction)
+	if err != nil {
+		h.log.WithError(err).WithField("author", reaction.Author).Error("failed to add reaction")
+		respondError(w, http.StatusInternalServerError, "Failed to add reaction")
+		return
+	}
+	if !added {
+		respondError(w, http.StatusNotFound, notFound)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toAPIReaction(reaction))
+}
+
+// reactionAuthor returns the display name of the API key sent with a request, ok is false
+// when there is no key or it isn't one of the configured authors
+func (h *APIHandler) reactionAuthor(r *http.Request) (string, bool) {
+	key := requestAPIKey(r)
+	if key == "" {
+		return "", false
+	}
+
+	for valid, name := range h.reactions.Authors {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(valid)) == 1 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// isEmoji reports whether s looks like a single emoji: symbols, optionally joined or modified
+// by zero-width joiners, variation selectors and skin tones, with no letters, digits or spaces
+func isEmoji(s string) bool {
+	if s == "" || utf8.RuneCountInString(s) > maxEmojiRunes {
+		return false
+	}
+
+	symbol := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.So, r):
+			symbol = true
+		case unicode.In(r, unicode.Sk, unicode.Mn, unicode.Me, unicode.Cf):
+		default:
+			return false
+		}
+	}
+
+	return symbol
+}
+
+// attachTradeReactions sets the reactions of feed trades, when reactions are enabled
+func (h *APIHandler) attachTradeReactions(r *http.Request, trades []Trade) {
+	if h.reactions == nil || len(trades) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(trades))
+	for _, trade := range trades {
+		if trade.Id != "" {
+			ids = append(ids, trade.Id)
+		}
+	}
+
+	reactions, err := h.storage.GetTradeReactions(r.Context(), ids)
+	if err != nil {
+		// The feed is still useful without them
+		h.log.WithError(err).Warn("failed to get trade reactions")
+		return
+	}
+
+	for i := range trades {
+		if found := reactions[trades[i].Id]; len(found) > 0 {
+			apiReactions := toAPIReactions(found)
+			trades[i].Reactions = &apiReactions
+		}
+	}
+}
+
+// toAPIReactions converts storage reactions to the API representation
+func toAPIReactions(reactions []*storage.Reaction) []Reaction {
+	out := make([]Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		out = append(out, toAPIReaction(reaction))
+	}
+
+	return out
+}
+
+// toAPIReaction converts a storage reaction to the API representation
+func toAPIReaction(reaction *storage.Reaction) Reaction {
+	return Reaction{
+		Id:          reaction.ID,
+		Author:      reaction.Author,
+		Emoji:       reaction.Emoji,
+		Comment:     reaction.Comment,
+		TradeId:     reaction.TradeID,
+		Username:    reaction.Username,
+		ConditionId: reaction.ConditionID,
+		CreatedAt:   reaction.CreatedAt,
+	}
+}
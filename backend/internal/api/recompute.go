@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/recompute"
+)
+
+// StartRecompute starts recomputing every user's derived stats
+func (h *APIHandler) StartRecompute(w http.ResponseWriter, r *http.Request) {
+	job, err := h.recompute.Run()
+	if errors.Is(err, recompute.ErrRunning) {
+		respondError(w, http.StatusConflict, "A recompute is already running")
+		return
+	}
+	if err != nil {
+		h.log.WithError(err).Error("failed to start recompute")
+		respondError(w, http.StatusInternalServerError, "Failed to start recompute")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, toAPIRecomputeJob(job))
+}
+
+// GetRecomputeJob returns the progress of a recompute job
+func (h *APIHandler) GetRecomputeJob(w http.ResponseWriter, _ *http.Request, id string) {
+	job := h.recompute.Job(id)
+	if job == nil {
+		respondError(w, http.StatusNotFound, "Recompute job not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toAPIRecomputeJob(job))
+}
+
+// toAPIRecomputeJob converts a recompute job to the API representation
+func toAPIRecomputeJob(job *recompute.Job) RecomputeJob {
+	return RecomputeJob{
+		Id:               job.ID,
+		Status:           RecomputeJobStatus(job.Status),
+		StartedAt:        job.StartedAt,
+		FinishedAt:       job.FinishedAt,
+		Users:            job.Users,
+		UsersDone:        job.UsersDone,
+		TradesClassified: job.TradesClassified,
+		SnapshotsCreated: job.SnapshotsCreated,
+		Badges:           job.Badges,
+		Milestones:       job.Milestones,
+		Errors:           job.Errors,
+	}
+}
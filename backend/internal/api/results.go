@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// GetUserResultDetail returns the trades, FIFO lots and resolution behind a user's result in one market
+func (h *APIHandler) GetUserResultDetail(w http.ResponseWriter, r *http.Request, username string, conditionId string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	detail, err := h.storage.GetUserResultDetail(ctx, user.ID, conditionId)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).WithField("condition_id", conditionId).Warn("failed to get result detail")
+		respondStorageError(w, err, "Result not found", "Failed to get result")
+		return
+	}
+
+	response := ResultDetail{
+		ConditionId: detail.ConditionID,
+		MarketSlug:  detail.MarketSlug,
+		RealizedPnl: detail.RealizedPnl,
+		OpenShares:  detail.OpenShares,
+		Trades:      make([]Trade, 0, len(detail.Trades)),
+		Lots:        make([]ResultLot, 0, len(detail.Lots)),
+		Settlements: make([]ResultSettlement, 0, len(detail.Settlements)),
+	}
+	if detail.MarketTitle != nil {
+		response.MarketTitle = *detail.MarketTitle
+	}
+
+	for _, t := range detail.Trades {
+		response.Trades = append(response.Trades, toAPITrade(t))
+	}
+
+	for _, lot := range detail.Lots {
+		apiLot := ResultLot{
+			Outcome:      lot.Outcome,
+			Shares:       lot.Shares,
+			OpenPrice:    lot.OpenPrice,
+			ClosePrice:   lot.ClosePrice,
+			Pnl:          lot.Pnl,
+			OpenedAt:     lot.OpenedAt,
+			ClosedAt:     lot.ClosedAt,
+			OpenTradeId:  lot.OpenTradeID,
+			CloseTradeId: lot.CloseTradeID,
+			ClosedBy:     "sell",
+		}
+		if lot.Settled {
+			apiLot.ClosedBy = "resolution"
+		}
+		if lot.Hold != nil {
+			hours := lot.Hold.Hours()
+			apiLot.HoldHours = &hours
+		}
+		response.Lots = append(response.Lots, apiLot)
+	}
+
+	for _, st := range detail.Settlements {
+		response.Settlements = append(response.Settlements, ResultSettlement{
+			Address:         st.Address,
+			Outcome:         st.Outcome,
+			Size:            st.Size,
+			AvgPrice:        st.AvgPrice,
+			SettlementPrice: st.SettlementPrice,
+			Pnl:             st.Pnl,
+			ResolvedAt:      st.ResolvedAt,
+		})
+	}
+
+	if h.reactions != nil {
+		reactions, err := h.storage.GetResultReactions(ctx, user.ID, conditionId)
+		if err != nil {
+			h.log.WithError(err).WithField("username", username).WithField("condition_id", conditionId).Error("failed to get result reactions")
+			respondError(w, http.StatusInternalServerError, "Failed to get reactions")
+			return
+		}
+		apiReactions := toAPIReactions(reactions)
+		response.Reactions = &apiReactions
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// toAPITrade converts a storage trade to the API representation
+func toAPITrade(t *storage.Trade) Trade {
+	trade := Trade{
+		Id:          "",
+		ConditionId: t.ConditionID,
+		MarketSlug:  t.MarketSlug,
+		Side:        TradeSideBUY,
+	}
+
+	if t.TradeID != nil {
+		trade.Id = *t.TradeID
+	}
+	if t.Timestamp != nil {
+		trade.Timestamp = *t.Timestamp
+	}
+	if t.MarketTitle != nil {
+		trade.MarketTitle = *t.MarketTitle
+	}
+	if t.Outcome != nil {
+		trade.Outcome = *t.Outcome
+	}
+	if t.Side != nil && *t.Side == "SELL" {
+		trade.Side = TradeSideSELL
+	}
+	if t.PositionChange != nil {
+		change := TradePositionChange(*t.PositionChange)
+		trade.PositionChange = &change
+	}
+	trade.Book = toAPITradeBook(t)
+	if t.Price != nil {
+		trade.Price = *t.Price
+	}
+	if t.Size != nil {
+		trade.Size = *t.Size
+	}
+	if t.Value != nil {
+		trade.Value = *t.Value
+	}
+
+	return trade
+}
+
+// toAPITradeBook converts the order book stored with a trade, nil when none was
+func toAPITradeBook(t *storage.Trade) *TradeBook {
+	if t.BookCapturedAt == nil {
+		return nil
+	}
+	return &TradeBook{
+		Midpoint:   t.BookMidpoint,
+		BestBid:    t.BookBestBid,
+		BestAsk:    t.BookBestAsk,
+		CapturedAt: *t.BookCapturedAt,
+	}
+}
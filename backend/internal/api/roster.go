@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportRoster returns the tracked users and personas in the config.yaml schema
+func (h *APIHandler) ExportRoster(w http.ResponseWriter, r *http.Request) {
+	exported, err := h.roster.Export(r.Context())
+	if err != nil {
+		h.log.WithError(err).Error("failed to export roster")
+		respondError(w, http.StatusInternalServerError, "Failed to export roster")
+		return
+	}
+
+	var body bytes.Buffer
+	encoder := yaml.NewEncoder(&body)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(exported); err != nil {
+		h.log.WithError(err).Error("failed to encode roster")
+		respondError(w, http.StatusInternalServerError, "Failed to export roster")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", `attachment; filename="roster.yaml"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
+// ApplyRoster applies an uploaded roster, reporting the changes made
+func (h *APIHandler) ApplyRoster(w http.ResponseWriter, r *http.Request, params ApplyRosterParams) {
+	ctx := r.Context()
+
+	var uploaded config.Roster
+	decoder := yaml.NewDecoder(r.Body)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&uploaded); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid roster: %v", err))
+		return
+	}
+	if err := uploaded.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid roster: %v", err))
+		return
+	}
+
+	opts := roster.ApplyOptions{}
+	if params.Prune != nil {
+		opts.Prune = *params.Prune
+	}
+	if params.DryRun != nil {
+		opts.DryRun = *params.DryRun
+	}
+
+	changes, err := h.roster.Apply(ctx, &uploaded, opts)
+	if err != nil {
+		// Changes made before the failure are kept
+		h.log.WithError(err).WithField("applied", len(changes)).Error("failed to apply roster")
+		respondError(w, http.StatusInternalServerError, "Failed to apply roster")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RosterApplyResult{
+		DryRun:  opts.DryRun,
+		Prune:   opts.Prune,
+		Changes: toAPIRosterChanges(changes),
+	})
+}
+
+// GetRosterChanges returns the roster change log, newest first
+func (h *APIHandler) GetRosterChanges(w http.ResponseWriter, r *http.Request, params GetRosterChangesParams) {
+	limit := 50
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	filters := storage.RosterChangeFilters{Limit: limit, Offset: offset}
+	// Ghost mode is private: only admins see it toggled, or anything about ghost users
+	if !h.isAdmin(r) {
+		filters.ExcludeActions = []string{roster.ActionSetGhost}
+		filters.ExcludeGhosts = true
+	}
+
+	logged, total, err := h.storage.GetRosterChanges(r.Context(), filters)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get roster changes")
+		respondError(w, http.StatusInternalServerError, "Failed to get roster changes")
+		return
+	}
+
+	changes := make([]RosterChangeLogEntry, len(logged))
+	for i, change := range logged {
+		changes[i] = RosterChangeLogEntry{
+			Id:        strconv.FormatInt(change.ID, 10),
+			Action:    change.Action,
+			Target:    change.Target,
+			Detail:    change.Detail,
+			Source:    RosterChangeLogEntrySource(change.Source),
+			CreatedAt: change.CreatedAt,
+		}
+	}
+
+	response := RosterChangesResponse{
+		Changes: changes,
+		Total:   total,
+		Limit:   &limit,
+		Offset:  &offset,
+	}
+	h.respondList(w, r, response, changes, listPage{total: total, count: len(changes), limit: limit, offset: offset})
+}
+
+// toAPIRosterChanges converts the changes made applying a roster
+func toAPIRosterChanges(changes []roster.Change) []RosterChange {
+	out := make([]RosterChange, len(changes))
+	for i, change := range changes {
+		out[i] = RosterChange{
+			Action: change.Action,
+			Target: change.Target,
+		}
+		if change.Detail != "" {
+			out[i].Detail = &change.Detail
+		}
+	}
+	return out
+}
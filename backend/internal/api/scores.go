@@ -0,0 +1,45 @@
+package api
+
+import (
+	"github.com/samcm/pyre/internal/scoring"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// score returns the configured custom score with the given name, or nil
+func (h *APIHandler) score(name string) *scoring.Score {
+	for _, s := range h.scores {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// computeScores evaluates every custom score for each user: username -> score name -> value.
+// Nil when no custom scores are configured.
+func (h *APIHandler) computeScores(stats []*storage.UserStats) map[string]map[string]float64 {
+	if len(h.scores) == 0 {
+		return nil
+	}
+
+	scores := make(map[string]map[string]float64, len(stats))
+	for _, stat := range stats {
+		vars := map[string]float64{
+			"totalPnl":      stat.TotalPnl,
+			"realizedPnl":   stat.RealizedPnl,
+			"unrealizedPnl": stat.UnrealizedPnl,
+			"winRate":       stat.WinRate,
+			"volume":        stat.Volume,
+			"openPositions": float64(stat.OpenPositions),
+			"totalTrades":   float64(stat.TotalTrades),
+		}
+
+		userScores := make(map[string]float64, len(h.scores))
+		for _, s := range h.scores {
+			userScores[s.Name] = s.Formula.Eval(vars)
+		}
+		scores[stat.Username] = userScores
+	}
+
+	return scores
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/stream"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// streamPingInterval is how often idle clients are pinged
+	streamPingInterval = 30 * time.Second
+	// streamReadTimeout closes connections that send nothing, not even a pong, for this long
+	streamReadTimeout = 2 * streamPingInterval
+	// streamWriteTimeout limits writing a single message to a client
+	streamWriteTimeout = 10 * time.Second
+	// streamMaxCommandBytes is the largest command a client can send
+	streamMaxCommandBytes = 16 << 10
+)
+
+// Feed stream message types
+const (
+	streamMessageSubscribed  = "subscribed"
+	streamMessageEvent       = "event"
+	streamMessageLeaderboard = "leaderboard"
+	streamMessageError       = "error"
+)
+
+// GetFeedStream upgrades to a WebSocket delivering updates for the client's topics
+func (h *APIHandler) GetFeedStream(w http.ResponseWriter, r *http.Request, params GetFeedStreamParams) {
+	if h.stream == nil {
+		respondError(w, http.StatusNotFound, "Feed stream is disabled")
+		return
+	}
+
+	// Topics are checked before upgrading so a bad request gets a plain HTTP error
+	topics := splitList(params.Topics)
+	if len(topics) > stream.MaxTopics {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("At most %d topics can be subscribed to", stream.MaxTopics))
+		return
+	}
+	for _, topic := range topics {
+		if err := h.stream.ValidateTopic(r.Context(), topic); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	conn, rw, _, err := ws.UpgradeHTTP(r, hijacker{w})
+	if err != nil {
+		// The upgrader has already responded
+		h.log.WithError(err).Debug("failed to upgrade feed stream")
+		return
+	}
+
+	// Deadlines set by the HTTP server for the request carry over to the hijacked connection
+	_ = conn.SetDeadline(time.Time{})
+
+	sub := h.stream.Subscribe()
+	if err := sub.Add(topics...); err != nil {
+		sub.Close()
+		conn.Close()
+		return
+	}
+
+	c := &streamConn{
+		conn: conn,
+		sub:  sub,
+		log:  h.log.WithField("remote_addr", r.RemoteAddr),
+	}
+	var src io.Reader = conn
+	if rw != nil {
+		// Anything the client sent straight after the handshake is buffered here
+		src = rw.Reader
+	}
+
+	// The connection outlives the request's timeout, so it runs on its own context
+	h.serveStream(context.WithoutCancel(r.Context()), c, src)
+}
+
+// hijacker lets the WebSocket upgrade hijack the connection through writers that wrap the
+// original, such as the version and access log middleware
+type hijacker struct {
+	http.ResponseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(h.ResponseWriter).Hijack()
+}
+
+// streamConn is a feed stream client
+type streamConn struct {
+	conn net.Conn
+	sub  *stream.Subscription
+	log  logrus.FieldLogger
+
+	writeMu sync.Mutex
+}
+
+// serveStream reads the client's commands while writing its messages until either side closes
+func (h *APIHandler) serveStream(ctx context.Context, c *streamConn, src io.Reader) {
+	defer c.conn.Close()
+	defer c.sub.Close()
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		h.readStream(ctx, c, src)
+	}()
+
+	if err := c.send(h.subscribedMessage(c.sub)); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-readDone:
+			return
+
+		case msg, ok := <-c.sub.Messages():
+			if !ok {
+				// The stream service stopped
+				_ = c.writeFrame(ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusGoingAway, "server shutting down")))
+				return
+			}
+			if err := c.send(toAPIStreamMessage(msg)); err != nil {
+				return
+			}
+
+		case <-ping.C:
+			if err := c.writeFrame(ws.NewPingFrame(nil)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readStream handles the client's frames until it disconnects, closes or misbehaves
+func (h *APIHandler) readStream(ctx context.Context, c *streamConn, src io.Reader) {
+	rd := wsutil.NewServerSideReader(src)
+
+	for {
+		_ = c.conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+
+		hdr, err := rd.NextFrame()
+		if err != nil {
+			return
+		}
+
+		if hdr.OpCode.IsControl() {
+			// Responses to pings and closes are written whole, never interleaved with messages
+			var reply bytes.Buffer
+			handler := wsutil.ControlHandler{Src: rd, Dst: &reply, State: ws.StateServerSide, DisableSrcCiphering: true}
+			err := handler.Handle(hdr)
+			if reply.Len() > 0 {
+				_ = c.write(reply.Bytes())
+			}
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		if hdr.Length > streamMaxCommandBytes {
+			_ = c.writeFrame(ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusMessageTooBig, "command too large")))
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(rd, streamMaxCommandBytes+1))
+		if err != nil {
+			return
+		}
+		if len(data) > streamMaxCommandBytes {
+			_ = c.writeFrame(ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusMessageTooBig, "command too large")))
+			return
+		}
+
+		if err := c.send(h.handleStreamCommand(ctx, c.sub, data)); err != nil {
+			return
+		}
+	}
+}
+
+// handleStreamCommand applies a subscribe or unsubscribe command, returning the reply
+func (h *APIHandler) handleStreamCommand(ctx context.Context, sub *stream.Subscription, data []byte) FeedStreamMessage {
+	var cmd FeedStreamCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return streamErrorMessage("Invalid command: " + err.Error())
+	}
+
+	switch cmd.Action {
+	case "subscribe":
+		for _, topic := range cmd.Topics {
+			if err := h.stream.ValidateTopic(ctx, topic); err != nil {
+				return streamErrorMessage(err.Error())
+			}
+		}
+		if err := sub.Add(cmd.Topics...); err != nil {
+			return streamErrorMessage(err.Error())
+		}
+
+	case "unsubscribe":
+		sub.Remove(cmd.Topics...)
+
+	default:
+		return streamErrorMessage(fmt.Sprintf("Unknown action: %q (expected subscribe or unsubscribe)", cmd.Action))
+	}
+
+	return h.subscribedMessage(sub)
+}
+
+// subscribedMessage lists a subscription's topics
+func (h *APIHandler) subscribedMessage(sub *stream.Subscription) FeedStreamMessage {
+	topics := sub.Topics()
+	return FeedStreamMessage{Type: streamMessageSubscribed, Topics: &topics}
+}
+
+// streamErrorMessage reports a failed command
+func streamErrorMessage(message string) FeedStreamMessage {
+	return FeedStreamMessage{Type: streamMessageError, Message: &message}
+}
+
+// toAPIStreamMessage converts a stream message to the API representation
+func toAPIStreamMessage(msg *stream.Message) FeedStreamMessage {
+	out := FeedStreamMessage{
+		Type:   streamMessageEvent,
+		Topics: &msg.Topics,
+		Time:   &msg.Time,
+	}
+
+	if msg.Leaderboard != nil {
+		out.Type = streamMessageLeaderboard
+		changes := make([]LeaderboardRankChange, 0, len(msg.Leaderboard))
+		for _, change := range msg.Leaderboard {
+			entry := LeaderboardRankChange{
+				Username: change.Username,
+				Rank:     change.Rank,
+				TotalPnl: change.TotalPnl,
+			}
+			if change.PreviousRank > 0 {
+				entry.PreviousRank = &change.PreviousRank
+			}
+			changes = append(changes, entry)
+		}
+		out.Leaderboard = &changes
+		return out
+	}
+
+	out.Username = &msg.Username
+	if event := msg.Event; event != nil {
+		eventType := string(event.Type)
+		out.Event = &eventType
+
+		switch {
+		case event.Trade != nil:
+			trade := toAPITrade(event.Trade)
+			out.Trade = &trade
+		case event.Position != nil:
+			position := toAPIPosition(event.Position)
+			if event.Type == events.ConcentrationAlert {
+				concentrated := true
+				position.PortfolioShare = &event.Share
+				position.Concentrated = &concentrated
+			}
+			out.Position = &position
+		case event.Badge != nil:
+			badge := toAPIBadge(event.Badge)
+			out.Badge = &badge
+		case event.Milestone != nil:
+			milestone := toAPIMilestone(event.Milestone)
+			out.Milestone = &milestone
+		}
+	}
+
+	return out
+}
+
+// send writes a message to the client as a text frame
+func (c *streamConn) send(msg FeedStreamMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		c.log.WithError(err).Error("failed to encode feed stream message")
+		return err
+	}
+
+	return c.writeFrame(ws.NewTextFrame(data))
+}
+
+// writeFrame writes a single frame to the client
+func (c *streamConn) writeFrame(frame ws.Frame) error {
+	var buf bytes.Buffer
+	if err := ws.WriteFrame(&buf, frame); err != nil {
+		return err
+	}
+
+	return c.write(buf.Bytes())
+}
+
+// write sends encoded frames to the client. Writes are serialized so frames from the message
+// loop and control replies from the read loop never interleave.
+func (c *streamConn) write(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := c.conn.Write(data); err != nil {
+		if !errors.Is(err, net.ErrClosed) {
+			c.log.WithError(err).Debug("failed to write to feed stream")
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// defaultTradeGroupWindow is how far back the trade feed grouped by market looks when no start
+// is given
+const defaultTradeGroupWindow = 24 * time.Hour
+
+// respondTradeMarketGroups responds with the trades matching filters grouped by market
+func (h *APIHandler) respondTradeMarketGroups(w http.ResponseWriter, r *http.Request, filters storage.TradeFilters) {
+	ctx := r.Context()
+
+	groups, total, err := h.storage.GetTradeMarketGroups(ctx, filters)
+	if err != nil {
+		h.log.WithError(err).Error("failed to get trade market groups")
+		respondError(w, http.StatusInternalServerError, "Failed to get trades")
+		return
+	}
+
+	// Cache for user lookups, the same users tend to trade several of the markets
+	userCache := make(map[int64]*storage.User)
+
+	markets := make([]MarketTradeGroup, 0, len(groups))
+	for _, g := range groups {
+		market := MarketTradeGroup{
+			ConditionId:  g.ConditionID,
+			MarketSlug:   g.MarketSlug,
+			Trades:       g.Trades,
+			BuyValue:     g.BuyValue,
+			SellValue:    g.SellValue,
+			NetValue:     g.BuyValue - g.SellValue,
+			Volume:       g.Volume(),
+			Size:         g.Size,
+			Participants: make([]MarketTradeParticipant, 0, len(g.Participants)),
+		}
+		if g.MarketTitle != nil {
+			market.MarketTitle = *g.MarketTitle
+		}
+		if g.FirstTradeAt != nil {
+			market.FirstTradeAt = *g.FirstTradeAt
+		}
+		if g.LastTradeAt != nil {
+			market.LastTradeAt = *g.LastTradeAt
+		}
+
+		for _, p := range g.Participants {
+			participant := MarketTradeParticipant{
+				Username:  p.Username,
+				Trades:    p.Trades,
+				BuyValue:  p.BuyValue,
+				SellValue: p.SellValue,
+				NetValue:  p.BuyValue - p.SellValue,
+			}
+
+			user, ok := userCache[p.UserID]
+			if !ok {
+				user, err = h.storage.GetUser(ctx, p.Username)
+				if err == nil {
+					userCache[p.UserID] = user
+				}
+			}
+			if user != nil && user.ProfileImage != nil {
+				participant.ProfileImage = user.ProfileImage
+			}
+
+			market.Participants = append(market.Participants, participant)
+		}
+
+		markets = append(markets, market)
+	}
+
+	response := TradesResponse{
+		Trades:  []Trade{},
+		Markets: &markets,
+		Total:   total,
+	}
+	if filters.Limit > 0 {
+		response.Limit = &filters.Limit
+	}
+	if filters.Offset > 0 {
+		response.Offset = &filters.Offset
+	}
+
+	h.respondList(w, r, response, markets, listPage{total: total, count: len(markets), limit: filters.Limit, offset: filters.Offset})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/tradeimport"
+	"github.com/sirupsen/logrus"
+)
+
+// maxReportedImportErrors bounds the row errors listed in an import report
+const maxReportedImportErrors = 100
+
+// TradeImport configures CSV trade imports
+type TradeImport struct {
+	Columns tradeimport.Columns
+	MaxRows int // rows accepted per file, 0 is unlimited
+}
+
+// ImportUserTrades imports historical trades for a user from an uploaded CSV file
+func (h *APIHandler) ImportUserTrades(w http.ResponseWriter, r *http.Request, username string, params ImportUserTradesParams) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	addresses, err := h.storage.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user addresses")
+		respondError(w, http.StatusInternalServerError, "Failed to import trades")
+		return
+	}
+
+	var parsed *tradeimport.Result
+	switch {
+	case params.Format == nil || *params.Format == Columns:
+		parsed, err = tradeimport.Parse(r.Body, h.tradeImport.Columns, user, addresses, h.tradeImport.MaxRows)
+	case *params.Format == Polymarket:
+		address := ""
+		if params.Address != nil {
+			address = *params.Address
+		}
+		markets := func(titles []string) (map[string]*storage.MarketSearchResult, error) {
+			return h.storage.GetMarketsByTitle(ctx, titles)
+		}
+		parsed, err = tradeimport.ParseExport(r.Body, user, addresses, address, markets, h.tradeImport.MaxRows)
+	default:
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid format: %s", *params.Format))
+		return
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		if errors.Is(err, tradeimport.ErrMarketLookup) {
+			h.log.WithError(err).WithField("username", username).Error("failed to import trades")
+			respondError(w, http.StatusInternalServerError, "Failed to import trades")
+			return
+		}
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid file: %v", err))
+		return
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	imported, err := h.storage.ImportTrades(ctx, parsed.Trades, dryRun)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to import trades")
+		respondError(w, http.StatusInternalServerError, "Failed to import trades")
+		return
+	}
+
+	report := TradeImportReport{
+		Username:   user.Username,
+		DryRun:     dryRun,
+		Rows:       parsed.Rows,
+		Imported:   imported,
+		Duplicates: len(parsed.Trades) - imported,
+		Invalid:    len(parsed.Errors),
+		Skipped:    parsed.Skipped,
+		Errors:     make([]TradeImportError, 0, min(len(parsed.Errors), maxReportedImportErrors)),
+	}
+	for _, rowErr := range parsed.Errors[:min(len(parsed.Errors), maxReportedImportErrors)] {
+		report.Errors = append(report.Errors, TradeImportError{
+			Row:     rowErr.Row,
+			Message: rowErr.Message,
+		})
+	}
+
+	if !dryRun {
+		h.log.WithFields(logrus.Fields{
+			"username":   user.Username,
+			"imported":   report.Imported,
+			"duplicates": report.Duplicates,
+			"invalid":    report.Invalid,
+		}).Info("imported trades")
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
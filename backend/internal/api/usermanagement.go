@@ -0,0 +1,281 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samcm/pyre/internal/lookup"
+	"github.com/samcm/pyre/internal/roster"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// CreateUser starts tracking a user. Sync reads users from storage, so they're synced from
+// the next cycle.
+func (h *APIHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" {
+		respondError(w, http.StatusBadRequest, "Username is required")
+		return
+	}
+	if len(req.Addresses) == 0 {
+		respondError(w, http.StatusBadRequest, "At least one address is required")
+		return
+	}
+	addresses, ok := validAddresses(w, req.Addresses)
+	if !ok {
+		return
+	}
+
+	var persona *storage.Persona
+	if req.Persona != nil && *req.Persona != "" {
+		var found bool
+		if persona, found = h.requestPersona(w, r, *req.Persona); !found {
+			return
+		}
+	}
+
+	var user *storage.User
+	var err error
+	if persona != nil {
+		user, err = h.storage.CreateUserWithPersona(ctx, req.Username, addresses, persona.ID)
+	} else {
+		user, err = h.storage.CreateUser(ctx, req.Username, addresses)
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("username", req.Username).Error("failed to create user")
+		respondStorageError(w, err, "User not found", "Failed to create user")
+		return
+	}
+
+	slug := ""
+	if persona != nil {
+		slug = persona.Slug
+	}
+	changes := []roster.Change{{Action: roster.ActionCreateUser, Target: user.Username, Detail: roster.PersonaDetail(slug)}}
+
+	if req.Ghost != nil && *req.Ghost {
+		if err := h.storage.UpdateUserGhost(ctx, user.ID, true); err != nil {
+			h.recordRosterChanges(r, changes)
+			h.log.WithError(err).WithField("username", user.Username).Error("failed to update ghost mode")
+			respondError(w, http.StatusInternalServerError, "Failed to update ghost mode")
+			return
+		}
+		user.Ghost = true
+		changes = append(changes, roster.Change{Action: roster.ActionSetGhost, Target: user.Username, Detail: "true"})
+	}
+	h.recordRosterChanges(r, changes)
+
+	h.log.WithField("username", user.Username).WithField("addresses", len(addresses)).Info("created user")
+
+	respondJSON(w, http.StatusCreated, toAPIUser(user, addresses, true))
+}
+
+// UpdateUser changes a user's addresses, persona or ghost mode. The request is checked in full
+// before anything is changed; a failure part way keeps the changes already made.
+func (h *APIHandler) UpdateUser(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var add, remove []string
+	if req.AddAddresses != nil {
+		var ok bool
+		if add, ok = validAddresses(w, *req.AddAddresses); !ok {
+			return
+		}
+	}
+	if req.RemoveAddresses != nil {
+		remove = *req.RemoveAddresses
+	}
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	stored, err := h.userAddressList(r, user)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user addresses")
+		return
+	}
+
+	current, err := h.storage.GetUserPersonaInfo(ctx, user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user persona")
+		respondError(w, http.StatusInternalServerError, "Failed to get user persona")
+		return
+	}
+	currentSlug := ""
+	if current != nil {
+		currentSlug = current.Slug
+	}
+
+	var persona *storage.Persona
+	if req.Persona != nil && *req.Persona != "" && *req.Persona != currentSlug {
+		var found bool
+		if persona, found = h.requestPersona(w, r, *req.Persona); !found {
+			return
+		}
+	}
+
+	changes := make([]roster.Change, 0)
+	fail := func(err error, msg string) {
+		h.recordRosterChanges(r, changes)
+		h.log.WithError(err).WithField("username", username).Error("failed to update user")
+		respondStorageError(w, err, "User not found", msg)
+	}
+
+	if req.Persona != nil && *req.Persona != currentSlug {
+		if persona != nil {
+			err = h.storage.UpdateUserPersona(ctx, user.ID, persona.ID)
+		} else {
+			err = h.storage.ClearUserPersona(ctx, user.ID)
+		}
+		if err != nil {
+			fail(err, "Failed to update user persona")
+			return
+		}
+		changes = append(changes, roster.Change{
+			Action: roster.ActionMoveUser,
+			Target: username,
+			Detail: fmt.Sprintf("%s -> %s", roster.PersonaDetail(currentSlug), roster.PersonaDetail(*req.Persona)),
+		})
+	}
+
+	for _, address := range add {
+		if slices.Contains(stored, address) {
+			continue
+		}
+		if err := h.storage.AddUserAddress(ctx, user.ID, address); err != nil {
+			fail(err, "Failed to add address")
+			return
+		}
+		stored = append(stored, address)
+		changes = append(changes, roster.Change{Action: roster.ActionAddAddress, Target: username, Detail: address})
+	}
+
+	for _, address := range remove {
+		if !slices.Contains(stored, address) {
+			continue
+		}
+		if err := h.storage.RemoveUserAddress(ctx, user.ID, address); err != nil {
+			fail(err, "Failed to remove address")
+			return
+		}
+		stored = slices.DeleteFunc(stored, func(a string) bool { return a == address })
+		changes = append(changes, roster.Change{Action: roster.ActionRemoveAddress, Target: username, Detail: address})
+	}
+
+	if req.Ghost != nil && *req.Ghost != user.Ghost {
+		if err := h.storage.UpdateUserGhost(ctx, user.ID, *req.Ghost); err != nil {
+			fail(err, "Failed to update ghost mode")
+			return
+		}
+		user.Ghost = *req.Ghost
+		changes = append(changes, roster.Change{Action: roster.ActionSetGhost, Target: username, Detail: fmt.Sprintf("%t", *req.Ghost)})
+	}
+
+	h.recordRosterChanges(r, changes)
+
+	h.log.WithField("username", username).WithField("changes", len(changes)).Info("updated user")
+
+	respondJSON(w, http.StatusOK, toAPIUser(user, stored, true))
+}
+
+// DeleteUser stops tracking a user, deleting everything stored for them
+func (h *APIHandler) DeleteUser(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	user, err := h.storage.GetUser(ctx, username)
+	if err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to get user")
+		respondStorageError(w, err, "User not found", "Failed to get user")
+		return
+	}
+
+	if err := h.storage.DeleteUser(ctx, user.ID); err != nil {
+		h.log.WithError(err).WithField("username", username).Error("failed to delete user")
+		respondError(w, http.StatusInternalServerError, "Failed to delete user")
+		return
+	}
+	h.recordRosterChanges(r, []roster.Change{{Action: roster.ActionDeleteUser, Target: username}})
+
+	h.log.WithField("username", username).Info("deleted user")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validAddresses returns addresses trimmed and without duplicates, responding 400 when one
+// isn't a wallet address
+func validAddresses(w http.ResponseWriter, addresses []string) ([]string, bool) {
+	valid := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if !lookup.ValidAddress(address) {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid address: %q", address))
+			return nil, false
+		}
+		if !slices.Contains(valid, address) {
+			valid = append(valid, address)
+		}
+	}
+	return valid, true
+}
+
+// requestPersona returns the persona named in a request, responding 400 when there is none
+func (h *APIHandler) requestPersona(w http.ResponseWriter, r *http.Request, slug string) (*storage.Persona, bool) {
+	persona, err := h.storage.GetPersona(r.Context(), slug)
+	if errors.Is(err, storage.ErrNotFound) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Unknown persona: %s", slug))
+		return nil, false
+	}
+	if err != nil {
+		h.log.WithError(err).WithField("slug", slug).Error("failed to get persona")
+		respondError(w, http.StatusInternalServerError, "Failed to get persona")
+		return nil, false
+	}
+	return persona, true
+}
+
+// userAddressList returns a user's addresses
+func (h *APIHandler) userAddressList(r *http.Request, user *storage.User) ([]string, error) {
+	addresses, err := h.storage.GetUserAddresses(r.Context(), user.ID)
+	if err != nil {
+		h.log.WithError(err).WithField("username", user.Username).Error("failed to get user addresses")
+		return nil, err
+	}
+
+	list := make([]string, len(addresses))
+	for i, addr := range addresses {
+		list[i] = addr.Address
+	}
+	return list, nil
+}
+
+// recordRosterChanges adds changes made through the API to the roster change log. The
+// changes are already made, so a failure to log them is only logged.
+func (h *APIHandler) recordRosterChanges(r *http.Request, changes []roster.Change) {
+	if len(changes) == 0 {
+		return
+	}
+	if err := h.roster.Record(r.Context(), roster.SourceAPI, changes); err != nil {
+		h.log.WithError(err).Error("failed to record roster changes")
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ResponseShim converts a JSON response body produced by the shared handlers into the
+// shape a given API version promises. It receives the decoded body and returns the
+// value to encode in its place.
+type ResponseShim func(body any) (any, error)
+
+// APIVersion is a version of the HTTP API mounted at /api/{Name}. All versions are
+// served by the same handlers; breaking response changes are expressed as shims on the
+// versions that need them, keyed by operation ("GET /users/{username}").
+type APIVersion struct {
+	Name       string
+	Deprecated *time.Time // sent as the Deprecation header once set
+	Sunset     *time.Time // sent as the Sunset header once set
+	Successor  string     // version to link to from deprecated responses
+	Shims      map[string]ResponseShim
+}
+
+// Versions lists the mounted API versions, oldest first.
+//
+// v1 is frozen: its responses only ever gain optional fields. Breaking changes ship in
+// v2 by changing the handlers and adding a shim to v1 that restores the old shape.
+var Versions = []*APIVersion{
+	{Name: "v1", Successor: "v2", Shims: map[string]ResponseShim{
+		"GET /users": usersArray,
+	}},
+	{Name: "v2", Shims: map[string]ResponseShim{}},
+}
+
+// DefaultVersion serves unversioned /api requests without an Accept-Version header
+const DefaultVersion = "v1"
+
+// VersionHeader names the version that served a response
+const VersionHeader = "API-Version"
+
+// acceptVersionHeader lets clients of unversioned /api paths pick a version
+const acceptVersionHeader = "Accept-Version"
+
+// LookupVersion finds a mounted version by name, accepting "v2" or "2"
+func LookupVersion(name string) *APIVersion {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name != "" && !strings.HasPrefix(name, "v") {
+		name = "v" + name
+	}
+
+	for _, version := range Versions {
+		if version.Name == name {
+			return version
+		}
+	}
+
+	return nil
+}
+
+// VersionRouter returns the routes of the API for a single version
+func (h *APIHandler) VersionRouter(version *APIVersion) http.Handler {
+	r := chi.NewRouter()
+	r.Use(version.middleware(deprecatedOperations()))
+	HandlerWithOptions(h, ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: []MiddlewareFunc{h.authorize},
+	})
+	return r
+}
+
+// NegotiatingRouter serves unversioned /api paths with the version requested in the
+// Accept-Version header, or DefaultVersion when none is given
+func (h *APIHandler) NegotiatingRouter() http.Handler {
+	routers := make(map[string]http.Handler, len(Versions))
+	for _, version := range Versions {
+		routers[version.Name] = h.VersionRouter(version)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested := r.Header.Get(acceptVersionHeader)
+		if requested == "" {
+			requested = DefaultVersion
+		}
+
+		version := LookupVersion(requested)
+		if version == nil {
+			respondError(w, http.StatusNotAcceptable, fmt.Sprintf("Unsupported API version: %s", requested))
+			return
+		}
+
+		w.Header().Add("Vary", acceptVersionHeader)
+		routers[version.Name].ServeHTTP(w, r)
+	})
+}
+
+// deprecatedOperations returns the operations marked deprecated in the OpenAPI spec
+func deprecatedOperations() map[string]bool {
+	deprecated := make(map[string]bool)
+
+	spec, err := GetSwagger()
+	if err != nil {
+		return deprecated
+	}
+
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			if operation.Deprecated {
+				deprecated[method+" "+path] = true
+			}
+		}
+	}
+
+	return deprecated
+}
+
+// middleware sets version and deprecation headers and applies response shims
+func (v *APIVersion) middleware(deprecated map[string]bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vw := &versionWriter{
+				ResponseWriter: w,
+				request:        r,
+				version:        v,
+				deprecated:     deprecated,
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(vw, r.WithContext(context.WithValue(r.Context(), versionContextKey{}, v)))
+
+			if vw.shim != nil {
+				vw.flushShimmed()
+			}
+		})
+	}
+}
+
+// versionContextKey holds the APIVersion serving a request in its context
+type versionContextKey struct{}
+
+// requestVersion returns the API version serving a request, nil outside the version routers
+func requestVersion(r *http.Request) *APIVersion {
+	version, _ := r.Context().Value(versionContextKey{}).(*APIVersion)
+	return version
+}
+
+// versionWriter adds version headers once the route is known, buffering the body of
+// responses that need a shim
+type versionWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	version     *APIVersion
+	deprecated  map[string]bool
+	wroteHeader bool
+	status      int
+	shim        ResponseShim
+	buf         bytes.Buffer
+}
+
+// operation returns the matched operation in the form "GET /users/{username}"
+func (w *versionWriter) operation() string {
+	rctx := chi.RouteContext(w.request.Context())
+	if rctx == nil || len(rctx.RoutePatterns) == 0 {
+		return ""
+	}
+	// The last pattern belongs to the version router, relative to its mount point
+	return w.request.Method + " " + rctx.RoutePatterns[len(rctx.RoutePatterns)-1]
+}
+
+func (w *versionWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	header := w.Header()
+	header.Set(VersionHeader, w.version.Name)
+
+	operation := w.operation()
+	if w.version.Deprecated != nil || w.deprecated[operation] {
+		if w.version.Deprecated != nil {
+			header.Set("Deprecation", fmt.Sprintf("@%d", w.version.Deprecated.Unix()))
+		} else {
+			header.Set("Deprecation", "true")
+		}
+		if w.version.Successor != "" {
+			header.Add("Link", fmt.Sprintf(`</api/%s>; rel="successor-version"`, w.version.Successor))
+		}
+	}
+	if w.version.Sunset != nil {
+		header.Set("Sunset", w.version.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if shim, ok := w.version.Shims[operation]; ok && strings.HasPrefix(header.Get("Content-Type"), "application/json") {
+		// The body is rewritten after the handler returns, so the length is unknown until then
+		w.shim = shim
+		header.Del("Content-Length")
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *versionWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.shim != nil {
+		return w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *versionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flushShimmed rewrites the buffered body with the version's shim and sends it
+func (w *versionWriter) flushShimmed() {
+	body := w.buf.Bytes()
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if shimmed, err := w.shim(decoded); err == nil {
+			if encoded, err := json.Marshal(shimmed); err == nil {
+				body = encoded
+			}
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// usersArray restores v1's bare array of users from the paged users response. List
+// envelopes, which clients opt into, are left as they are.
+func usersArray(body any) (any, error) {
+	if page, ok := body.(map[string]any); ok {
+		if users, ok := page["users"]; ok {
+			return users, nil
+		}
+	}
+	return body, nil
+}
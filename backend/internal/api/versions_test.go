@@ -0,0 +1,206 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestUsersArray(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "paged users become a bare array",
+			body: `{"users":[{"username":"alice"},{"username":"bob"}],"total":2,"limit":100}`,
+			want: `[{"username":"alice"},{"username":"bob"}]`,
+		},
+		{
+			name: "no users become an empty array",
+			body: `{"users":[],"total":0}`,
+			want: `[]`,
+		},
+		{
+			name: "list envelopes are left alone",
+			body: `{"data":[{"username":"alice"}],"pagination":{"total":1},"generatedAt":"2026-01-01T00:00:00Z"}`,
+			want: `{"data":[{"username":"alice"}],"pagination":{"total":1},"generatedAt":"2026-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "errors are left alone",
+			body: `{"error":"Limit and offset must not be negative"}`,
+			want: `{"error":"Limit and offset must not be negative"}`,
+		},
+		{
+			name: "arrays are left alone",
+			body: `[{"username":"alice"}]`,
+			want: `[{"username":"alice"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body, want any
+			if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := usersArray(body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestVersionMiddleware(t *testing.T) {
+	deprecatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	v1 := &APIVersion{Name: "v1", Successor: "v2", Shims: map[string]ResponseShim{"GET /users": usersArray}}
+	v2 := &APIVersion{Name: "v2", Shims: map[string]ResponseShim{}}
+	retired := &APIVersion{Name: "v0", Successor: "v1", Deprecated: &deprecatedAt, Sunset: &sunsetAt, Shims: map[string]ResponseShim{}}
+
+	paged := `{"users":[{"username":"alice"}],"total":1}`
+
+	tests := []struct {
+		name        string
+		version     *APIVersion
+		path        string
+		contentType string
+		status      int
+		body        string
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "v1 shims its operations",
+			version:     v1,
+			path:        "/users",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        paged,
+			wantBody:    `[{"username":"alice"}]`,
+			wantHeaders: map[string]string{VersionHeader: "v1", "Deprecation": "", "Link": ""},
+		},
+		{
+			name:        "v2 serves the handlers' response",
+			version:     v2,
+			path:        "/users",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        paged,
+			wantBody:    paged,
+			wantHeaders: map[string]string{VersionHeader: "v2"},
+		},
+		{
+			name:        "shims keep the status",
+			version:     v1,
+			path:        "/users",
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			body:        `{"error":"Limit and offset must not be negative"}`,
+			wantBody:    `{"error":"Limit and offset must not be negative"}`,
+		},
+		{
+			name:        "non-JSON responses aren't shimmed",
+			version:     v1,
+			path:        "/users",
+			contentType: "text/csv",
+			status:      http.StatusOK,
+			body:        "username\nalice\n",
+			wantBody:    "username\nalice\n",
+		},
+		{
+			name:        "other operations aren't shimmed",
+			version:     v1,
+			path:        "/trades",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        paged,
+			wantBody:    paged,
+		},
+		{
+			name:        "bodies that don't decode are sent as they are",
+			version:     v1,
+			path:        "/users",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{"users":`,
+			wantBody:    `{"users":`,
+		},
+		{
+			name:        "deprecated operations link the successor",
+			version:     v1,
+			path:        "/legacy",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{}`,
+			wantBody:    `{}`,
+			wantHeaders: map[string]string{"Deprecation": "true", "Link": `</api/v2>; rel="successor-version"`},
+		},
+		{
+			name:        "deprecated versions send their dates",
+			version:     retired,
+			path:        "/trades",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        `{}`,
+			wantBody:    `{}`,
+			wantHeaders: map[string]string{
+				VersionHeader: "v0",
+				"Deprecation": "@1767225600",
+				"Sunset":      "Wed, 01 Jul 2026 00:00:00 GMT",
+				"Link":        `</api/v1>; rel="successor-version"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var served *APIVersion
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				served = requestVersion(r)
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}
+
+			r := chi.NewRouter()
+			r.Use(tt.version.middleware(map[string]bool{"GET /legacy": true}))
+			r.Get("/users", handler)
+			r.Get("/trades", handler)
+			r.Get("/legacy", handler)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("got status %d, want %d", w.Code, tt.status)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("got body %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			for name, want := range tt.wantHeaders {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("got %s header %q, want %q", name, got, want)
+				}
+			}
+			if served != tt.version {
+				t.Errorf("handler saw version %v, want %s", served, tt.version.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package avatars
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"time"
+
+	"golang.org/x/image/draw"
+)
+
+// Banners are BannerWidth by BannerHeight pixels, showing at most BannerTiles members
+const (
+	BannerWidth  = 768
+	BannerHeight = 256
+	BannerTiles  = 6
+)
+
+// collageVersion changes the cache key of every banner when their layout changes
+const collageVersion = "1"
+
+// Member is a persona member shown on a banner
+type Member struct {
+	Name     string // picks the color of the member's tile when it has no image
+	ImageURL string // empty when the member has no image
+}
+
+// Collage returns a banner of side by side tiles, one per member up to BannerTiles, each
+// filled with the member's avatar or, when it has none or it can't be fetched, a color
+// picked from its name. Banners are cached like avatars and rebuilt when they'd be refetched.
+func (p *proxy) Collage(ctx context.Context, members []Member) (*Image, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("banner needs at least one member")
+	}
+	if len(members) > BannerTiles {
+		members = members[:BannerTiles]
+	}
+
+	key := collageKey(members)
+	if img := p.memory.get(key); img != nil && p.fresh(img) {
+		return img, nil
+	}
+
+	unlock := p.lock(key)
+	defer unlock()
+
+	if img := p.memory.get(key); img != nil && p.fresh(img) {
+		return img, nil
+	}
+
+	cached, err := p.readDisk(key)
+	if err != nil {
+		p.log.WithError(err).Warn("failed to read cached banner")
+	}
+	if cached != nil && p.fresh(cached) {
+		p.memory.put(key, cached)
+		return cached, nil
+	}
+
+	banner := image.NewRGBA(image.Rect(0, 0, BannerWidth, BannerHeight))
+	for i, member := range members {
+		tile := image.Rect(i*BannerWidth/len(members), 0, (i+1)*BannerWidth/len(members), BannerHeight)
+		if src := p.memberImage(ctx, member); src != nil {
+			draw.CatmullRom.Scale(banner, tile, src, coverCrop(src.Bounds(), tile), draw.Src, nil)
+		} else {
+			draw.Draw(banner, tile, image.NewUniform(nameColor(member.Name)), image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, banner, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode banner: %w", err)
+	}
+
+	img, err := newImage(buf.Bytes(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.writeDisk(key, img.Data); err != nil {
+		p.log.WithError(err).Warn("failed to cache banner on disk")
+	}
+	p.memory.put(key, img)
+
+	return img, nil
+}
+
+// memberImage decodes a member's avatar, nil when it has none or it can't be used
+func (p *proxy) memberImage(ctx context.Context, member Member) image.Image {
+	if member.ImageURL == "" {
+		return nil
+	}
+
+	avatar, err := p.Get(ctx, member.ImageURL, Sizes[len(Sizes)-1])
+	if err != nil {
+		p.log.WithError(err).WithField("url", member.ImageURL).Debug("failed to get banner avatar")
+		return nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(avatar.Data))
+	if err != nil {
+		p.log.WithError(err).WithField("url", member.ImageURL).Debug("failed to decode banner avatar")
+		return nil
+	}
+	return src
+}
+
+// coverCrop returns the centered part of src with the aspect ratio of dst, so scaling it
+// fills dst without distortion
+func coverCrop(src, dst image.Rectangle) image.Rectangle {
+	w, h := src.Dx(), src.Dy()
+	if w*dst.Dy() > h*dst.Dx() {
+		crop := max(h*dst.Dx()/dst.Dy(), 1)
+		x := src.Min.X + (w-crop)/2
+		return image.Rect(x, src.Min.Y, x+crop, src.Max.Y)
+	}
+	crop := max(w*dst.Dy()/dst.Dx(), 1)
+	y := src.Min.Y + (h-crop)/2
+	return image.Rect(src.Min.X, y, src.Max.X, y+crop)
+}
+
+// nameColor picks a muted color from a name, the same one every time
+func nameColor(name string) color.RGBA {
+	sum := sha256.Sum256([]byte(name))
+	return color.RGBA{R: 64 + sum[0]%128, G: 64 + sum[1]%128, B: 64 + sum[2]%128, A: 255}
+}
+
+// collageKey identifies a banner of members
+func collageKey(members []Member) string {
+	h := sha256.New()
+	h.Write([]byte(collageVersion))
+	for _, member := range members {
+		fmt.Fprintf(h, "\x00%s\x00%s", member.Name, member.ImageURL)
+	}
+	return "banner-" + hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,65 @@
+package avatars
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a fixed-size in-memory cache of avatars, evicting the least recently used
+type lru struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+// lruEntry is a cached avatar with its key, so evictions can remove it from the map
+type lruEntry struct {
+	key   string
+	image *Image
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns a cached avatar, or nil
+func (c *lru) get(key string) *Image {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).image
+}
+
+// put caches an avatar, evicting the least recently used one when full
+func (c *lru) put(key string, image *Image) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry).image = image
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, image: image})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
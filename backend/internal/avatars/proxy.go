@@ -0,0 +1,368 @@
+package avatars
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/fetch"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
+
+// Sizes are the widths avatars can be resized to. Requested sizes are rounded up to one of
+// these so the cache holds a bounded number of variants per image.
+var Sizes = []int{32, 64, 128, 256}
+
+// ErrNotImage is returned when the upstream URL doesn't serve an image
+var ErrNotImage = errors.New("upstream did not return an image")
+
+// Options configures the avatar proxy
+type Options struct {
+	CacheDir      string        // directory holding fetched and resized avatars
+	MemoryEntries int           // avatars kept in memory, 0 disables the memory cache
+	RefreshAfter  time.Duration // how long a cached avatar is served before it is fetched again
+	MaxBytes      int64         // largest upstream image accepted
+	Timeout       time.Duration // limit on fetching an upstream image
+}
+
+// Image is an avatar ready to serve
+type Image struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+	ModTime     time.Time // when the avatar was last fetched from upstream
+}
+
+// Proxy fetches, resizes and caches remote avatars
+type Proxy interface {
+	// Get returns the avatar at a remote URL scaled to fit within size pixels, or at its
+	// original size when size is 0. When the upstream URL fails, the last cached copy is
+	// served for as long as it is kept on disk.
+	Get(ctx context.Context, rawURL string, size int) (*Image, error)
+	// Collage returns a banner compositing the avatars of up to BannerTiles members
+	Collage(ctx context.Context, members []Member) (*Image, error)
+}
+
+// proxy implements Proxy with a disk cache fronted by an in-memory LRU
+type proxy struct {
+	opts   Options
+	pages  fetch.Queue
+	memory *lru
+	log    logrus.FieldLogger
+
+	mu    sync.Mutex
+	locks map[string]*keyLock // per cache key, so concurrent requests fetch once
+}
+
+// keyLock serializes fetches of a single cache key
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+var _ Proxy = (*proxy)(nil)
+
+// NewProxy creates an avatar proxy caching under opts.CacheDir and fetching through pages
+func NewProxy(opts Options, pages fetch.Queue, log logrus.FieldLogger) (Proxy, error) {
+	if err := os.MkdirAll(opts.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create avatar cache directory: %w", err)
+	}
+
+	return &proxy{
+		opts:   opts,
+		pages:  pages,
+		memory: newLRU(opts.MemoryEntries),
+		log:    log.WithField("package", "avatars"),
+		locks:  make(map[string]*keyLock),
+	}, nil
+}
+
+// NormalizeSize rounds a requested size up to the nearest supported size. 0 means the original size.
+func NormalizeSize(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	for _, s := range Sizes {
+		if size <= s {
+			return s
+		}
+	}
+	return Sizes[len(Sizes)-1]
+}
+
+// Get returns the avatar at a remote URL scaled to fit within size pixels
+func (p *proxy) Get(ctx context.Context, rawURL string, size int) (*Image, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid avatar url: %s", rawURL)
+	}
+
+	size = NormalizeSize(size)
+	key := cacheKey(rawURL, size)
+
+	if img := p.memory.get(key); img != nil && p.fresh(img) {
+		return img, nil
+	}
+
+	unlock := p.lock(key)
+	defer unlock()
+
+	// Another request may have fetched it while we waited
+	if img := p.memory.get(key); img != nil && p.fresh(img) {
+		return img, nil
+	}
+
+	cached, err := p.readDisk(key)
+	if err != nil {
+		p.log.WithError(err).WithField("url", rawURL).Warn("failed to read cached avatar")
+	}
+	if cached != nil && p.fresh(cached) {
+		p.memory.put(key, cached)
+		return cached, nil
+	}
+
+	original, err := p.fetch(ctx, rawURL)
+	if err != nil {
+		return p.fallback(rawURL, size, cached, err)
+	}
+
+	// Keep the original too, so other sizes can still be produced if upstream goes away
+	if size != 0 {
+		if err := p.writeDisk(cacheKey(rawURL, 0), original); err != nil {
+			p.log.WithError(err).WithField("url", rawURL).Warn("failed to cache original avatar on disk")
+		}
+	}
+
+	return p.store(rawURL, size, original, time.Now())
+}
+
+// fallback serves a cached copy when upstream can't be fetched: the stale avatar, or one
+// resized from the cached original when this size was never cached
+func (p *proxy) fallback(rawURL string, size int, cached *Image, fetchErr error) (*Image, error) {
+	log := p.log.WithError(fetchErr).WithField("url", rawURL)
+
+	if cached == nil && size != 0 {
+		original, err := p.readDisk(cacheKey(rawURL, 0))
+		if err != nil {
+			log.WithField("read_error", err).Warn("failed to read cached original avatar")
+		}
+		if original != nil {
+			log.Warn("failed to fetch avatar, resizing cached original")
+			return p.store(rawURL, size, original.Data, time.Now())
+		}
+	}
+
+	if cached == nil {
+		return nil, fetchErr
+	}
+
+	// Keep serving the stale copy, and don't try upstream again until the next refresh
+	log.Warn("failed to refresh avatar, serving cached copy")
+	cached.ModTime = time.Now()
+	if err := os.Chtimes(p.path(cacheKey(rawURL, size)), cached.ModTime, cached.ModTime); err != nil {
+		p.log.WithError(err).WithField("url", rawURL).Warn("failed to touch cached avatar")
+	}
+	p.memory.put(cacheKey(rawURL, size), cached)
+
+	return cached, nil
+}
+
+// store resizes an original avatar and caches the result on disk and in memory
+func (p *proxy) store(rawURL string, size int, original []byte, modTime time.Time) (*Image, error) {
+	data, err := resize(original, size)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := newImage(data, modTime)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(rawURL, size)
+	if err := p.writeDisk(key, img.Data); err != nil {
+		p.log.WithError(err).WithField("url", rawURL).Warn("failed to cache avatar on disk")
+	}
+	p.memory.put(key, img)
+
+	return img, nil
+}
+
+// fresh reports whether a cached avatar is recent enough to serve without refetching
+func (p *proxy) fresh(img *Image) bool {
+	return time.Since(img.ModTime) < p.opts.RefreshAfter
+}
+
+// lock acquires the fetch lock of a cache key
+func (p *proxy) lock(key string) func() {
+	p.mu.Lock()
+	l, ok := p.locks[key]
+	if !ok {
+		l = &keyLock{}
+		p.locks[key] = l
+	}
+	l.refs++
+	p.mu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		p.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(p.locks, key)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// fetch downloads an avatar
+func (p *proxy) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	resp, err := p.pages.Get(ctx, fetch.Request{
+		URL:      rawURL,
+		Accept:   "image/*",
+		MaxBytes: p.opts.MaxBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch avatar: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code fetching avatar: %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(http.DetectContentType(resp.Body), "image/") {
+		return nil, ErrNotImage
+	}
+
+	return resp.Body, nil
+}
+
+// resize scales image data to fit within size pixels, keeping its aspect ratio. Images
+// already small enough, and any that can't be decoded, are returned unchanged.
+func resize(data []byte, size int) ([]byte, error) {
+	if size == 0 {
+		return data, nil
+	}
+
+	// Image types without a registered decoder, such as BMP or ICO, are served as-is
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return data, nil
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return data, nil
+	}
+	if w >= h {
+		w, h = size, max(h*size/w, 1)
+	} else {
+		w, h = max(w*size/h, 1), size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	switch format {
+	case "png", "gif", "webp":
+		// Formats that may be transparent
+		err = png.Encode(&buf, dst)
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resized avatar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newImage wraps image data with the headers needed to serve it
+func newImage(data []byte, modTime time.Time) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, ErrNotImage
+	}
+
+	sum := sha256.Sum256(data)
+	return &Image{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:8]) + `"`,
+		ModTime:     modTime,
+	}, nil
+}
+
+// readDisk loads a cached avatar, returning nil if it isn't cached
+func (p *proxy) readDisk(key string) (*Image, error) {
+	path := p.path(key)
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newImage(data, info.ModTime())
+}
+
+// writeDisk stores an avatar in the disk cache, replacing any previous copy atomically
+func (p *proxy) writeDisk(key string, data []byte) error {
+	tmp, err := os.CreateTemp(p.opts.CacheDir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p.path(key))
+}
+
+// path is where a cache key is stored on disk
+func (p *proxy) path(key string) string {
+	return filepath.Join(p.opts.CacheDir, key)
+}
+
+// cacheKey identifies a URL at a size
+func cacheKey(rawURL string, size int) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return hex.EncodeToString(sum[:16]) + "-" + strconv.Itoa(size)
+}
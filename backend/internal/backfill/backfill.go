@@ -15,6 +15,7 @@ type Result struct {
 	Username         string
 	TradesProcessed  int
 	SnapshotsCreated int
+	SnapshotsSkipped int // reconstructed snapshots not written because synced snapshots cover them
 	TotalRealizedPnl float64
 	OldestTradeDate  *time.Time
 	NewestTradeDate  *time.Time
@@ -22,7 +23,10 @@ type Result struct {
 
 // Service provides PnL backfill functionality
 type Service interface {
-	BackfillUser(ctx context.Context, username string) (*Result, error)
+	// BackfillUser reconstructs PnL history from trades. By default it only fills the history
+	// before the earliest synced snapshot; replace deletes all existing snapshots first.
+	BackfillUser(ctx context.Context, username string, replace bool) (*Result, error)
+	SimulateCopyTrading(ctx context.Context, username string, capital float64, start *time.Time) (*CopySimulation, error)
 }
 
 // service implements the backfill Service
@@ -54,8 +58,11 @@ type positionKey struct {
 }
 
 // BackfillUser reconstructs PnL history from trade data for a user
-func (s *service) BackfillUser(ctx context.Context, username string) (*Result, error) {
-	s.log.WithField("username", username).Info("starting backfill")
+func (s *service) BackfillUser(ctx context.Context, username string, replace bool) (*Result, error) {
+	s.log.WithFields(logrus.Fields{
+		"username": username,
+		"replace":  replace,
+	}).Info("starting backfill")
 
 	// Get user
 	user, err := s.storage.GetUser(ctx, username)
@@ -78,11 +85,6 @@ func (s *service) BackfillUser(ctx context.Context, username string) (*Result, e
 		}, nil
 	}
 
-	// Delete existing PnL snapshots
-	if err := s.storage.DeleteUserPnlSnapshots(ctx, user.ID); err != nil {
-		return nil, fmt.Errorf("failed to delete existing snapshots: %w", err)
-	}
-
 	// Track cost basis per position using FIFO
 	// Key: conditionID + outcome
 	costBasis := make(map[positionKey][]lot, 32)
@@ -160,15 +162,27 @@ func (s *service) BackfillUser(ctx context.Context, username string) (*Result, e
 		return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
 	})
 
-	// Bulk insert snapshots
-	if err := s.storage.BulkInsertPnlSnapshots(ctx, snapshots); err != nil {
-		return nil, fmt.Errorf("failed to insert snapshots: %w", err)
+	created := len(snapshots)
+	if replace {
+		if err := s.storage.DeleteUserPnlSnapshots(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete existing snapshots: %w", err)
+		}
+		if err := s.storage.BulkInsertPnlSnapshots(ctx, snapshots); err != nil {
+			return nil, fmt.Errorf("failed to insert snapshots: %w", err)
+		}
+	} else {
+		// Keep synced snapshots, filling only the history before them
+		created, err = s.storage.MergeBackfilledPnlSnapshots(ctx, user.ID, snapshots)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge snapshots: %w", err)
+		}
 	}
 
 	result := &Result{
 		Username:         username,
 		TradesProcessed:  len(trades),
-		SnapshotsCreated: len(snapshots),
+		SnapshotsCreated: created,
+		SnapshotsSkipped: len(snapshots) - created,
 		TotalRealizedPnl: cumulativeRealizedPnl,
 		OldestTradeDate:  oldestDate,
 		NewestTradeDate:  newestDate,
@@ -178,6 +192,7 @@ func (s *service) BackfillUser(ctx context.Context, username string) (*Result, e
 		"username":          username,
 		"trades_processed":  result.TradesProcessed,
 		"snapshots_created": result.SnapshotsCreated,
+		"snapshots_skipped": result.SnapshotsSkipped,
 		"total_realized":    result.TotalRealizedPnl,
 	}).Info("backfill completed")
 
@@ -241,6 +256,7 @@ func (s *service) createSnapshots(userID int64, dailyPnl map[time.Time]float64)
 			TotalPnl:      &pnl,
 			RealizedPnl:   &pnl,
 			UnrealizedPnl: &zero,
+			Source:        storage.SnapshotSourceBackfill,
 		})
 	}
 
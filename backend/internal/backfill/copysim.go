@@ -0,0 +1,214 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// CopySimulation contains the results of a what-if copy trading simulation
+type CopySimulation struct {
+	Username      string
+	Capital       float64
+	Start         *time.Time
+	Scale         float64 // Copier shares per trader share
+	FinalEquity   float64
+	TradesCopied  int
+	TradesSkipped int
+	DataPoints    []*EquityPoint
+}
+
+// EquityPoint is a single point on the simulated equity curve
+type EquityPoint struct {
+	Timestamp time.Time
+	Equity    float64
+	Cash      float64
+}
+
+// SimulateCopyTrading replays a user's trades from start with a bankroll of capital.
+// Trades are scaled so that the trader's peak deployed cost basis maps to the full
+// bankroll. Buys are clipped to available cash and sells close the same fraction
+// of the copier's holding as the trader closed of theirs. Holdings are marked at
+// the last traded price, then at the current position price for the final point.
+func (s *service) SimulateCopyTrading(ctx context.Context, username string, capital float64, start *time.Time) (*CopySimulation, error) {
+	user, err := s.storage.GetUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	allTrades, err := s.storage.GetUserTradesChronological(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	positions, err := s.storage.GetUserPositions(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	result := &CopySimulation{
+		Username:    username,
+		Capital:     capital,
+		Start:       start,
+		FinalEquity: capital,
+		DataPoints:  make([]*EquityPoint, 0, 64),
+	}
+
+	// Only trades with complete data inside the window can be copied
+	trades := make([]*storage.Trade, 0, len(allTrades))
+	for _, trade := range allTrades {
+		if trade.Timestamp == nil || trade.ConditionID == nil || trade.Outcome == nil ||
+			trade.Side == nil || trade.Price == nil || trade.Size == nil {
+			continue
+		}
+		if start != nil && trade.Timestamp.Before(*start) {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+
+	if len(trades) == 0 || capital <= 0 {
+		return result, nil
+	}
+
+	result.Scale = capital / peakDeployed(trades)
+
+	var (
+		cash       = capital
+		traderHeld = make(map[positionKey]float64, 32)
+		copierHeld = make(map[positionKey]float64, 32)
+		lastPrice  = make(map[positionKey]float64, 32)
+		daily      = make(map[time.Time]*EquityPoint, 64)
+	)
+
+	equity := func() float64 {
+		total := cash
+		for key, shares := range copierHeld {
+			total += shares * lastPrice[key]
+		}
+
+		return total
+	}
+
+	for _, trade := range trades {
+		key := positionKey{conditionID: *trade.ConditionID, outcome: *trade.Outcome}
+		price := *trade.Price
+		size := *trade.Size
+		lastPrice[key] = price
+
+		switch *trade.Side {
+		case "BUY":
+			traderHeld[key] += size
+
+			shares := size * result.Scale
+			if price > 0 && shares*price > cash {
+				shares = cash / price
+			}
+			if shares <= 0 {
+				result.TradesSkipped++
+
+				break
+			}
+			cash -= shares * price
+			copierHeld[key] += shares
+			result.TradesCopied++
+
+		case "SELL":
+			held := traderHeld[key]
+			if held <= 0 || copierHeld[key] <= 0 {
+				// Selling a holding bought before the window, nothing to copy
+				result.TradesSkipped++
+
+				break
+			}
+
+			fraction := math.Min(size/held, 1)
+			shares := copierHeld[key] * fraction
+			cash += shares * price
+			copierHeld[key] -= shares
+			traderHeld[key] = math.Max(held-size, 0)
+			result.TradesCopied++
+		}
+
+		day := trade.Timestamp.Truncate(24 * time.Hour)
+		daily[day] = &EquityPoint{Timestamp: day, Equity: equity(), Cash: cash}
+	}
+
+	for _, point := range daily {
+		result.DataPoints = append(result.DataPoints, point)
+	}
+	sort.Slice(result.DataPoints, func(i, j int) bool {
+		return result.DataPoints[i].Timestamp.Before(result.DataPoints[j].Timestamp)
+	})
+
+	// Mark remaining holdings at current prices, which also settles resolved markets
+	for _, pos := range positions {
+		if pos.Outcome == nil || pos.CurrentPrice == nil {
+			continue
+		}
+		key := positionKey{conditionID: pos.ConditionID, outcome: *pos.Outcome}
+		if _, exists := copierHeld[key]; exists {
+			lastPrice[key] = *pos.CurrentPrice
+		}
+	}
+
+	result.FinalEquity = equity()
+	result.DataPoints = append(result.DataPoints, &EquityPoint{
+		Timestamp: time.Now().UTC(),
+		Equity:    result.FinalEquity,
+		Cash:      cash,
+	})
+
+	s.log.WithFields(logrus.Fields{
+		"username":     username,
+		"capital":      capital,
+		"final_equity": result.FinalEquity,
+		"copied":       result.TradesCopied,
+	}).Debug("copy simulation completed")
+
+	return result, nil
+}
+
+// peakDeployed returns the largest cost basis the trader had outstanding at any point
+func peakDeployed(trades []*storage.Trade) float64 {
+	var deployed, peak float64
+
+	costBasis := make(map[positionKey][]lot, 32)
+	for _, trade := range trades {
+		key := positionKey{conditionID: *trade.ConditionID, outcome: *trade.Outcome}
+		price := *trade.Price
+		size := *trade.Size
+
+		switch *trade.Side {
+		case "BUY":
+			costBasis[key] = append(costBasis[key], lot{price: price, size: size})
+			deployed += price * size
+		case "SELL":
+			lots := costBasis[key]
+			remaining := size
+			for remaining > 0 && len(lots) > 0 {
+				used := math.Min(lots[0].size, remaining)
+				deployed -= used * lots[0].price
+				lots[0].size -= used
+				remaining -= used
+				if lots[0].size <= 0 {
+					lots = lots[1:]
+				}
+			}
+			costBasis[key] = lots
+		}
+
+		peak = math.Max(peak, deployed)
+	}
+
+	if peak <= 0 {
+		return 1
+	}
+
+	return peak
+}
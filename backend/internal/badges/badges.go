@@ -0,0 +1,186 @@
+package badges
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// Badge identifiers
+const (
+	BigWin            = "big_win"
+	WinStreak         = "win_streak"
+	DrawdownSurvivor  = "drawdown_survivor"
+	DailyTraderStreak = "daily_trader"
+)
+
+const (
+	bigWinThreshold   = 10000.0 // realized PnL in a single market
+	winStreakLength   = 10      // consecutive profitable markets
+	drawdownFraction  = 0.9     // share of peak PnL given back
+	dailyStreakLength = 30      // consecutive UTC days with a trade
+)
+
+// Definition describes a badge
+type Definition struct {
+	ID          string
+	Name        string
+	Description string
+
+	// check reports when the badge was earned and what earned it, or ok false if it hasn't been
+	check func(h *history) (awardedAt time.Time, detail string, ok bool)
+}
+
+// Definitions lists every badge in display order
+var Definitions = []*Definition{
+	{
+		ID:          BigWin,
+		Name:        "Big Win",
+		Description: "Realized a profit of $10,000 or more in a single market",
+		check:       checkBigWin,
+	},
+	{
+		ID:          WinStreak,
+		Name:        "Hot Streak",
+		Description: "Closed 10 markets in a row at a profit",
+		check:       checkWinStreak,
+	},
+	{
+		ID:          DrawdownSurvivor,
+		Name:        "Comeback",
+		Description: "Gave back 90% of peak PnL, then recovered to a new high",
+		check:       checkDrawdownSurvivor,
+	},
+	{
+		ID:          DailyTraderStreak,
+		Name:        "Every Day",
+		Description: "Traded on 30 consecutive days (UTC)",
+		check:       checkDailyTrader,
+	},
+}
+
+// Lookup returns the definition of a badge, or nil if it is unknown
+func Lookup(id string) *Definition {
+	for _, def := range Definitions {
+		if def.ID == id {
+			return def
+		}
+	}
+	return nil
+}
+
+// history is the trading record badges are evaluated against
+type history struct {
+	results   []*storage.Result // resolved markets
+	snapshots []*storage.PnlSnapshot
+	trades    []*storage.Trade // oldest first
+}
+
+// checkBigWin finds the first market with realized PnL of at least bigWinThreshold
+func checkBigWin(h *history) (time.Time, string, bool) {
+	var best *storage.Result
+	for _, r := range h.results {
+		if r.RealizedPnl < bigWinThreshold || r.ResolutionDate == nil {
+			continue
+		}
+		if best == nil || r.ResolutionDate.Before(*best.ResolutionDate) {
+			best = r
+		}
+	}
+	if best == nil {
+		return time.Time{}, "", false
+	}
+
+	market := best.ConditionID
+	if best.MarketTitle != nil {
+		market = *best.MarketTitle
+	}
+	return *best.ResolutionDate, fmt.Sprintf("$%.2f on %s", best.RealizedPnl, market), true
+}
+
+// checkWinStreak finds the first run of winStreakLength profitable markets in resolution order
+func checkWinStreak(h *history) (time.Time, string, bool) {
+	resolved := make([]*storage.Result, 0, len(h.results))
+	for _, r := range h.results {
+		if r.ResolutionDate != nil {
+			resolved = append(resolved, r)
+		}
+	}
+	sort.SliceStable(resolved, func(i, j int) bool {
+		return resolved[i].ResolutionDate.Before(*resolved[j].ResolutionDate)
+	})
+
+	streak := 0
+	for _, r := range resolved {
+		if r.RealizedPnl <= 0 {
+			streak = 0
+			continue
+		}
+		streak++
+		if streak == winStreakLength {
+			return *r.ResolutionDate, fmt.Sprintf("%d profitable markets in a row", winStreakLength), true
+		}
+	}
+
+	return time.Time{}, "", false
+}
+
+// checkDrawdownSurvivor finds the first recovery to a new PnL high after giving back
+// drawdownFraction of a positive peak
+func checkDrawdownSurvivor(h *history) (time.Time, string, bool) {
+	var peak float64
+	var drawdownPeak *float64 // peak PnL when the drawdown was reached
+
+	for _, snap := range h.snapshots {
+		if snap.TotalPnl == nil {
+			continue
+		}
+		pnl := *snap.TotalPnl
+
+		if drawdownPeak != nil && pnl > *drawdownPeak {
+			return snap.Timestamp, fmt.Sprintf("recovered from a drawdown off a $%.2f peak", *drawdownPeak), true
+		}
+
+		if pnl > peak {
+			peak = pnl
+		}
+		if drawdownPeak == nil && peak > 0 && peak-pnl >= peak*drawdownFraction {
+			p := peak
+			drawdownPeak = &p
+		}
+	}
+
+	return time.Time{}, "", false
+}
+
+// checkDailyTrader finds the first run of dailyStreakLength consecutive UTC days with a trade
+func checkDailyTrader(h *history) (time.Time, string, bool) {
+	var lastDay time.Time
+	streak := 0
+
+	for _, t := range h.trades {
+		if t.Timestamp == nil {
+			continue
+		}
+		ts := t.Timestamp.UTC()
+		day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+
+		switch {
+		case day.Equal(lastDay):
+			continue
+		case day.Equal(lastDay.AddDate(0, 0, 1)):
+			streak++
+		default:
+			streak = 1
+		}
+		lastDay = day
+
+		if streak == dailyStreakLength {
+			return ts, fmt.Sprintf("%d consecutive days ending %s", dailyStreakLength, day.Format("2006-01-02")), true
+		}
+	}
+
+	return time.Time{}, "", false
+}
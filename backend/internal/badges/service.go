@@ -0,0 +1,146 @@
+package badges
+
+import (
+	"context"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Service awards badges as users' trading records change
+type Service interface {
+	// Start evaluates a user's badges each time the user is synced
+	Start(ctx context.Context) error
+	Stop() error
+	// Evaluate awards any badges a user has earned but doesn't hold yet, returning the new ones
+	Evaluate(ctx context.Context, userID int64) ([]*storage.UserBadge, error)
+}
+
+// service implements the badge Service
+type service struct {
+	storage     storage.Storage
+	bus         events.Bus
+	log         logrus.FieldLogger
+	unsubscribe func()
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new badge service. New badges are announced on the bus.
+func NewService(storage storage.Storage, bus events.Bus, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		bus:     bus,
+		log:     log.WithField("package", "badges"),
+	}
+}
+
+// Start subscribes to user syncs
+func (s *service) Start(_ context.Context) error {
+	s.unsubscribe = s.bus.Subscribe("badges", func(ctx context.Context, event events.Event) {
+		if _, err := s.Evaluate(ctx, event.UserID); err != nil {
+			s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to evaluate badges")
+		}
+	}, events.UserSynced)
+
+	return nil
+}
+
+// Stop unsubscribes from user syncs
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// Evaluate awards any badges a user has earned but doesn't hold yet
+func (s *service) Evaluate(ctx context.Context, userID int64) ([]*storage.UserBadge, error) {
+	held, err := s.storage.GetUserBadges(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]*Definition, 0, len(Definitions))
+	for _, def := range Definitions {
+		if !holds(held, def.ID) {
+			pending = append(pending, def)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	h, err := s.loadHistory(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var awarded []*storage.UserBadge
+	for _, def := range pending {
+		awardedAt, detail, ok := def.check(h)
+		if !ok {
+			continue
+		}
+
+		badge := &storage.UserBadge{
+			UserID:    userID,
+			Badge:     def.ID,
+			AwardedAt: awardedAt,
+			Detail:    &detail,
+		}
+		isNew, err := s.storage.AwardBadge(ctx, badge)
+		if err != nil {
+			return awarded, err
+		}
+		if !isNew {
+			continue // awarded concurrently by another instance
+		}
+
+		s.log.WithFields(logrus.Fields{
+			"user_id": userID,
+			"badge":   def.ID,
+			"detail":  detail,
+		}).Info("badge awarded")
+		s.bus.Publish(ctx, events.Event{Type: events.BadgeAwarded, UserID: userID, Badge: badge})
+
+		awarded = append(awarded, badge)
+	}
+
+	return awarded, nil
+}
+
+// loadHistory reads the trading record badges are checked against
+func (s *service) loadHistory(ctx context.Context, userID int64) (*history, error) {
+	_, total, err := s.storage.GetUserResults(ctx, userID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	results, _, err := s.storage.GetUserResults(ctx, userID, total, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.storage.GetUserPnlHistory(ctx, userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := s.storage.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &history{results: results, snapshots: snapshots, trades: trades}, nil
+}
+
+// holds reports whether a badge is among those held
+func holds(held []*storage.UserBadge, id string) bool {
+	for _, b := range held {
+		if b.Badge == id {
+			return true
+		}
+	}
+	return false
+}
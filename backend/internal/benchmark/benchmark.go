@@ -0,0 +1,170 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Built-in benchmarks
+const (
+	USDC    = "usdc"    // holding USDC instead, which gains nothing
+	Tracked = "tracked" // the average of every tracked user other than the one compared
+)
+
+// Builtins are the names of the built-in benchmarks
+var Builtins = []string{USDC, Tracked}
+
+// ErrUnknown is returned for a benchmark that isn't built in or configured
+var ErrUnknown = errors.New("unknown benchmark")
+
+// Definition is a configured benchmark, the average of a group of tracked users
+type Definition struct {
+	Name  string
+	Users []string
+}
+
+// Point is a benchmark's PnL change since the start of a series
+type Point struct {
+	Timestamp time.Time
+	Pnl       float64
+}
+
+// Service computes benchmark series to compare a user's PnL history against
+type Service interface {
+	// Names lists the benchmarks, built-in ones first
+	Names() []string
+	// Series returns a benchmark's PnL at each of the times, ordered oldest first, as the change
+	// since the first of them, and the number of users averaged. excludeUserID is left out of
+	// the benchmark, so a user isn't compared against themselves.
+	Series(ctx context.Context, name string, excludeUserID int64, at []time.Time) ([]Point, int, error)
+}
+
+// service implements Service
+type service struct {
+	storage     storage.Storage
+	definitions map[string]Definition
+	names       []string
+	log         logrus.FieldLogger
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new benchmark service with the configured benchmarks
+func NewService(storage storage.Storage, definitions []Definition, log logrus.FieldLogger) Service {
+	s := &service{
+		storage:     storage,
+		definitions: make(map[string]Definition, len(definitions)),
+		names:       append([]string{}, Builtins...),
+		log:         log.WithField("package", "benchmark"),
+	}
+	for _, def := range definitions {
+		s.definitions[def.Name] = def
+		s.names = append(s.names, def.Name)
+	}
+	return s
+}
+
+// Names lists the benchmarks
+func (s *service) Names() []string {
+	return s.names
+}
+
+// Series computes a benchmark series
+func (s *service) Series(ctx context.Context, name string, excludeUserID int64, at []time.Time) ([]Point, int, error) {
+	members, err := s.members(ctx, name, excludeUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	points := make([]Point, len(at))
+	for i, t := range at {
+		points[i].Timestamp = t
+	}
+	if len(at) == 0 || len(members) == 0 {
+		return points, len(members), nil
+	}
+
+	end := at[len(at)-1]
+	for _, userID := range members {
+		snapshots, err := s.storage.GetUserPnlHistory(ctx, userID, nil, &end)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get pnl history of benchmark user %d: %w", userID, err)
+		}
+
+		baseline := pnlAt(snapshots, at[0])
+		for i, t := range at {
+			points[i].Pnl += pnlAt(snapshots, t) - baseline
+		}
+	}
+
+	for i := range points {
+		points[i].Pnl /= float64(len(members))
+	}
+
+	return points, len(members), nil
+}
+
+// members resolves the IDs of the users averaged by a benchmark
+func (s *service) members(ctx context.Context, name string, excludeUserID int64) ([]int64, error) {
+	switch name {
+	case USDC:
+		return nil, nil
+	case Tracked:
+		users, err := s.storage.GetUsers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		ids := make([]int64, 0, len(users))
+		for _, user := range users {
+			if user.ID != excludeUserID && !user.Ghost {
+				ids = append(ids, user.ID)
+			}
+		}
+		return ids, nil
+	}
+
+	def, ok := s.definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknown, name)
+	}
+
+	ids := make([]int64, 0, len(def.Users))
+	for _, username := range def.Users {
+		user, err := s.storage.GetUser(ctx, username)
+		if errors.Is(err, storage.ErrNotFound) {
+			// Users can leave the roster without the benchmark being updated
+			s.log.WithFields(logrus.Fields{
+				"benchmark": name,
+				"username":  username,
+			}).Debug("skipping benchmark user")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user %s: %w", username, err)
+		}
+		if user.ID != excludeUserID {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids, nil
+}
+
+// pnlAt returns the total PnL of the last snapshot at or before t, 0 before the first one.
+// snapshots must be ordered oldest first.
+func pnlAt(snapshots []*storage.PnlSnapshot, t time.Time) float64 {
+	i := sort.Search(len(snapshots), func(i int) bool {
+		return snapshots[i].Timestamp.After(t)
+	})
+	for i--; i >= 0; i-- {
+		if snapshots[i].TotalPnl != nil {
+			return *snapshots[i].TotalPnl
+		}
+	}
+	return 0
+}
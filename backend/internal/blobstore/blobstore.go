@@ -0,0 +1,97 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Blob store backends
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// gcsEndpoint is Google Cloud Storage's S3-compatible XML API
+const gcsEndpoint = "storage.googleapis.com"
+
+// ErrNotFound is returned when a key has no object
+var ErrNotFound = errors.New("object not found")
+
+// Options configures the blob store
+type Options struct {
+	Backend   string // local, s3 or gcs
+	Prefix    string // prepended to every key, e.g. to share a bucket
+	Dir       string // root directory for the local backend
+	Bucket    string // bucket for the s3 and gcs backends
+	Endpoint  string // S3-compatible endpoint, defaults to AWS for s3 and Google for gcs
+	Region    string
+	AccessKey string // for gcs, an HMAC key of a service account
+	SecretKey string
+	Insecure  bool // connect over plain HTTP, e.g. to a local MinIO
+}
+
+// Object describes a stored object
+type Object struct {
+	Key      string // relative to the configured prefix
+	Size     int64
+	Modified time.Time
+}
+
+// Store keeps files produced by pyre, such as exports and backups, in one configured place.
+// Keys are slash-separated paths.
+type Store interface {
+	// Put stores an object, replacing any under the same key. size is -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens an object for reading, or returns ErrNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns the objects whose keys start with prefix, ordered by key
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes an object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Location describes where a key is stored, for logs and responses
+	Location(key string) string
+}
+
+// New creates the blob store for the configured backend
+func New(opts Options, log logrus.FieldLogger) (Store, error) {
+	log = log.WithFields(logrus.Fields{"package": "blobstore", "backend": opts.Backend})
+
+	switch opts.Backend {
+	case BackendLocal:
+		return newLocalStore(opts.Dir, opts.Prefix, log)
+	case BackendS3:
+		return newS3Store(opts, log)
+	case BackendGCS:
+		if opts.Endpoint == "" {
+			opts.Endpoint = gcsEndpoint
+		}
+		return newS3Store(opts, log)
+	default:
+		return nil, fmt.Errorf("unknown blob store backend: %s", opts.Backend)
+	}
+}
+
+// cleanKey validates a key and normalizes it to a relative slash-separated path
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.TrimSpace(key))
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return strings.TrimPrefix(cleaned, "/"), nil
+}
+
+// joinKey prepends the configured prefix to a cleaned key
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + key
+}
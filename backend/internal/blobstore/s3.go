@@ -0,0 +1,153 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/sirupsen/logrus"
+)
+
+// awsEndpoint is the default endpoint of the s3 backend
+const awsEndpoint = "s3.amazonaws.com"
+
+// s3Store keeps objects in a bucket of S3 or an S3-compatible service, which includes Google
+// Cloud Storage through its interoperability API
+type s3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+	log    logrus.FieldLogger
+}
+
+var _ Store = (*s3Store)(nil)
+
+func newS3Store(opts Options, log logrus.FieldLogger) (Store, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("blob store bucket is required for the %s backend", opts.Backend)
+	}
+
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = awsEndpoint
+	}
+
+	// Without static keys, fall back to the standard AWS environment variables and instance roles
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.IAM{},
+	})
+	if opts.AccessKey != "" {
+		creds = credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, "")
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: !opts.Insecure,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", opts.Backend, err)
+	}
+
+	return &s3Store{
+		client: client,
+		bucket: opts.Bucket,
+		prefix: strings.Trim(opts.Prefix, "/"),
+		log:    log,
+	}, nil
+}
+
+// Put uploads the object, in parts when it is large or its size is unknown
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, objectKey, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	return nil
+}
+
+// Get opens the object for reading
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	// Objects are fetched lazily, so a missing key only shows up once it is read or stat'd
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	return obj, nil
+}
+
+// List pages through the bucket listing under the prefix
+func (s *s3Store) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects := make([]Object, 0)
+	for info := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    joinKey(s.prefix, prefix),
+		Recursive: true,
+	}) {
+		if info.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", info.Err)
+		}
+
+		key := info.Key
+		if s.prefix != "" {
+			key = strings.TrimPrefix(key, s.prefix+"/")
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size, Modified: info.LastModified})
+	}
+
+	return objects, nil
+}
+
+// Delete removes the object
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, objectKey, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// Location returns the object's URL
+func (s *s3Store) Location(key string) string {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return key
+	}
+	return fmt.Sprintf("%s/%s/%s", s.client.EndpointURL(), s.bucket, objectKey)
+}
+
+// objectKey maps a key to its name in the bucket
+func (s *s3Store) objectKey(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return joinKey(s.prefix, cleaned), nil
+}
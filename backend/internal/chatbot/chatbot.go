@@ -0,0 +1,136 @@
+package chatbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Tone colors a reply by whether it reports a gain or a loss
+type Tone int
+
+const (
+	ToneNeutral Tone = iota
+	ToneGain
+	ToneLoss
+)
+
+// Reply is the answer to a command, rendered by each chat platform in its own format
+type Reply struct {
+	Title   string
+	Summary string
+	Fields  []Field
+	Footer  string
+	Tone    Tone
+}
+
+// Field is a labelled value of a reply. Inline fields are short enough to sit side by side.
+type Field struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// Command describes a chat command, for platforms that register their commands
+type Command struct {
+	Name        string
+	Description string
+	Args        []Arg // positional, in order
+}
+
+// Arg is an argument of a command
+type Arg struct {
+	Name        string
+	Description string
+	Required    bool
+	Choices     []Choice // the values accepted, any value is accepted when empty
+}
+
+// Choice is an accepted value of an argument
+type Choice struct {
+	Name  string
+	Value string
+}
+
+// UserError is a failure reported back to the person who ran the command as is, such as an
+// unknown username. Other errors are logged and replaced with a generic message.
+type UserError string
+
+func (e UserError) Error() string { return string(e) }
+
+// Service answers the chat commands shared by the Discord and Telegram bots from storage
+type Service interface {
+	// Commands lists the commands Run answers
+	Commands() []Command
+	// Run answers a command. args holds the values of the command's arguments by name.
+	Run(ctx context.Context, name string, args map[string]string) (*Reply, error)
+}
+
+// service implements Service
+type service struct {
+	storage storage.Storage
+	log     logrus.FieldLogger
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new chat command service
+func NewService(storage storage.Storage, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		log:     log.WithField("package", "chatbot"),
+	}
+}
+
+// Commands lists the commands Run answers
+func (s *service) Commands() []Command {
+	return commands
+}
+
+// Run answers a command
+func (s *service) Run(ctx context.Context, name string, args map[string]string) (*Reply, error) {
+	handler, ok := s.handlers()[name]
+	if !ok {
+		return nil, UserError(fmt.Sprintf("Unknown command: %s", name))
+	}
+
+	for _, cmd := range commands {
+		if cmd.Name != name {
+			continue
+		}
+		for _, arg := range cmd.Args {
+			if arg.Required && args[arg.Name] == "" {
+				return nil, UserError(fmt.Sprintf("Usage: %s", Usage(cmd)))
+			}
+		}
+	}
+
+	return handler(ctx, args)
+}
+
+// Usage describes how to run a command, e.g. "/pnl <username>"
+func Usage(cmd Command) string {
+	usage := "/" + cmd.Name
+	for _, arg := range cmd.Args {
+		if arg.Required {
+			usage += " <" + arg.Name + ">"
+		} else {
+			usage += " [" + arg.Name + "]"
+		}
+	}
+	return usage
+}
+
+// PositionalArgs assigns values to a command's arguments in order, for platforms where
+// commands are typed as text, e.g. "/pnl alice". Values beyond the last argument are ignored.
+func PositionalArgs(cmd Command, values []string) map[string]string {
+	args := make(map[string]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		if i < len(values) {
+			args[arg.Name] = values[i]
+		}
+	}
+	return args
+}
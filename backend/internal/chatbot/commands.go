@@ -0,0 +1,411 @@
+package chatbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+const (
+	// listLimit is the number of entries shown by commands listing users, positions or trades
+	listLimit = 10
+	// dayWindow is the period /whoiswinning compares PnL over
+	dayWindow = 24 * time.Hour
+)
+
+var usernameArg = Arg{Name: "username", Description: "Polymarket username", Required: true}
+
+// commands are the commands Run answers
+var commands = []Command{
+	{
+		Name:        "pnl",
+		Description: "Show a user's PnL",
+		Args:        []Arg{usernameArg},
+	},
+	{
+		Name:        "leaderboard",
+		Description: "Show the top users",
+		Args: []Arg{{
+			Name:        "sort",
+			Description: "What to rank users by",
+			Choices: []Choice{
+				{Name: "Total PnL", Value: "totalPnl"},
+				{Name: "Realized PnL", Value: "realizedPnl"},
+				{Name: "Unrealized PnL", Value: "unrealizedPnl"},
+				{Name: "Win rate", Value: "winRate"},
+				{Name: "Volume", Value: "volume"},
+			},
+		}},
+	},
+	{
+		Name:        "positions",
+		Description: "Show a user's largest open positions",
+		Args:        []Arg{usernameArg},
+	},
+	{
+		Name:        "whoiswinning",
+		Description: "Show who leads overall and who gained and lost the most today",
+	},
+	{
+		Name:        "lasttrades",
+		Description: "Show the latest trades",
+		Args:        []Arg{{Name: "username", Description: "Only this user's trades"}},
+	},
+}
+
+// commandHandler answers a command
+type commandHandler func(ctx context.Context, args map[string]string) (*Reply, error)
+
+// handlers maps command names to their handlers
+func (s *service) handlers() map[string]commandHandler {
+	return map[string]commandHandler{
+		"pnl":          s.pnl,
+		"leaderboard":  s.leaderboard,
+		"positions":    s.positions,
+		"whoiswinning": s.whoIsWinning,
+		"lasttrades":   s.lastTrades,
+	}
+}
+
+// pnl answers with a user's total, realized and unrealized PnL
+func (s *service) pnl(ctx context.Context, args map[string]string) (*Reply, error) {
+	stats, err := s.storage.GetUserStats(ctx, args["username"])
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, unknownUser(args["username"])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []Field{
+		{Name: "Realized", Value: formatSignedUSD(stats.RealizedPnl), Inline: true},
+		{Name: "Unrealized", Value: formatSignedUSD(stats.UnrealizedPnl), Inline: true},
+	}
+	if stats.WinRate > 0 {
+		fields = append(fields, Field{Name: "Win rate", Value: fmt.Sprintf("%.1f%%", stats.WinRate*100), Inline: true})
+	}
+	if stats.Volume > 0 {
+		fields = append(fields, Field{Name: "Volume", Value: formatUSD(stats.Volume), Inline: true})
+	}
+	fields = append(fields, Field{Name: "Open positions", Value: fmt.Sprintf("%d", stats.OpenPositions), Inline: true})
+
+	return &Reply{
+		Title:   stats.Username,
+		Summary: "Total PnL " + formatSignedUSD(stats.TotalPnl),
+		Fields:  fields,
+		Tone:    pnlTone(stats.TotalPnl),
+	}, nil
+}
+
+// leaderboard answers with the top users
+func (s *service) leaderboard(ctx context.Context, args map[string]string) (*Reply, error) {
+	sortBy := args["sort"]
+	if sortBy == "" {
+		sortBy = "totalPnl"
+	}
+
+	metric, ok := leaderboardMetrics[sortBy]
+	if !ok {
+		return nil, UserError(fmt.Sprintf("Unknown sort: %s", sortBy))
+	}
+
+	stats, err := s.storage.GetLeaderboard(ctx, sortBy, "desc")
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, UserError("No users are tracked yet.")
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return metric(stats[i]) > metric(stats[j]) })
+
+	fields := make([]Field, 0, listLimit)
+	for i, entry := range stats[:min(len(stats), listLimit)] {
+		value := formatSignedUSD(entry.TotalPnl)
+		switch sortBy {
+		case "realizedPnl":
+			value = formatSignedUSD(entry.RealizedPnl) + " realized"
+		case "unrealizedPnl":
+			value = formatSignedUSD(entry.UnrealizedPnl) + " unrealized"
+		case "winRate":
+			value = fmt.Sprintf("%.1f%% wins", entry.WinRate*100)
+		case "volume":
+			value = formatUSD(entry.Volume) + " volume"
+		}
+		fields = append(fields, Field{Name: fmt.Sprintf("%d. %s", i+1, entry.Username), Value: value})
+	}
+
+	return &Reply{
+		Title:  "Leaderboard",
+		Fields: fields,
+		Footer: "Sorted by " + sortBy,
+	}, nil
+}
+
+// leaderboardMetrics are the values the leaderboard command can rank users by
+var leaderboardMetrics = map[string]func(*storage.UserStats) float64{
+	"totalPnl":      func(u *storage.UserStats) float64 { return u.TotalPnl },
+	"realizedPnl":   func(u *storage.UserStats) float64 { return u.RealizedPnl },
+	"unrealizedPnl": func(u *storage.UserStats) float64 { return u.UnrealizedPnl },
+	"winRate":       func(u *storage.UserStats) float64 { return u.WinRate },
+	"volume":        func(u *storage.UserStats) float64 { return u.Volume },
+}
+
+// positions answers with a user's largest open positions by value, leaving out dust
+func (s *service) positions(ctx context.Context, args map[string]string) (*Reply, error) {
+	username := args["username"]
+	user, err := s.storage.GetUser(ctx, username)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, unknownUser(username)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.storage.GetUserOpenPositions(ctx, user.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 {
+		return nil, UserError(username + " has no open positions.")
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool { return positionValue(positions[i]) > positionValue(positions[j]) })
+
+	var total, unrealized float64
+	for _, pos := range positions {
+		total += positionValue(pos)
+		unrealized += deref(pos.UnrealizedPnl)
+	}
+
+	fields := make([]Field, 0, listLimit)
+	for _, pos := range positions[:min(len(positions), listLimit)] {
+		fields = append(fields, Field{
+			Name: truncate(pos.DisplayTitle(), 250),
+			Value: fmt.Sprintf("%s · %.0f shares @ %.0f¢ · %s (%s)",
+				deref(pos.Outcome), deref(pos.Size), deref(pos.CurrentPrice)*100,
+				formatUSD(positionValue(pos)), formatSignedUSD(deref(pos.UnrealizedPnl))),
+		})
+	}
+
+	return &Reply{
+		Title:   username + "'s positions",
+		Summary: fmt.Sprintf("%d open, worth %s · unrealized %s", len(positions), formatUSD(total), formatSignedUSD(unrealized)),
+		Fields:  fields,
+		Tone:    pnlTone(unrealized),
+	}, nil
+}
+
+// whoIsWinning answers with the leader by total PnL and the biggest gain and loss of the last
+// day, measured against each user's latest PnL snapshot from before then
+func (s *service) whoIsWinning(ctx context.Context, args map[string]string) (*Reply, error) {
+	stats, err := s.storage.GetLeaderboard(ctx, "totalPnl", "desc")
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, UserError("No users are tracked yet.")
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].TotalPnl > stats[j].TotalPnl })
+	leader := stats[0]
+
+	// Snapshots are only searched a week back, users without one are left out of the day's moves
+	cutoff := time.Now().Add(-dayWindow)
+	since := cutoff.Add(-7 * dayWindow)
+
+	var best, worst *storage.UserStats
+	var bestChange, worstChange float64
+	for _, entry := range stats {
+		user, err := s.storage.GetUser(ctx, entry.Username)
+		if err != nil {
+			return nil, err
+		}
+		history, err := s.storage.GetUserPnlHistory(ctx, user.ID, &since, &cutoff)
+		if err != nil {
+			return nil, err
+		}
+		if len(history) == 0 || history[len(history)-1].TotalPnl == nil {
+			continue
+		}
+
+		change := entry.TotalPnl - *history[len(history)-1].TotalPnl
+		if best == nil || change > bestChange {
+			best, bestChange = entry, change
+		}
+		if worst == nil || change < worstChange {
+			worst, worstChange = entry, change
+		}
+	}
+
+	fields := []Field{{Name: "Leader", Value: leader.Username + " " + formatSignedUSD(leader.TotalPnl), Inline: true}}
+	if best != nil && bestChange > 0 {
+		fields = append(fields, Field{Name: "Best day", Value: best.Username + " " + formatSignedUSD(bestChange), Inline: true})
+	}
+	if worst != nil && worstChange < 0 {
+		fields = append(fields, Field{Name: "Worst day", Value: worst.Username + " " + formatSignedUSD(worstChange), Inline: true})
+	}
+
+	return &Reply{
+		Title:   "Who's winning",
+		Summary: fmt.Sprintf("%s leads with %s", leader.Username, formatSignedUSD(leader.TotalPnl)),
+		Fields:  fields,
+		Footer:  "Daily moves over the last 24 hours",
+		Tone:    pnlTone(leader.TotalPnl),
+	}, nil
+}
+
+// lastTrades answers with the latest trades, of one user or of everyone on the public feed
+func (s *service) lastTrades(ctx context.Context, args map[string]string) (*Reply, error) {
+	filters := storage.TradeFilters{
+		Limit:         listLimit,
+		SortBy:        "timestamp",
+		SortDirection: "desc",
+	}
+
+	title := "Latest trades"
+	if username := args["username"]; username != "" {
+		if _, err := s.storage.GetUser(ctx, username); errors.Is(err, storage.ErrNotFound) {
+			return nil, unknownUser(username)
+		} else if err != nil {
+			return nil, err
+		}
+		filters.Username = &username
+		filters.IncludeGhosts = true
+		title = username + "'s latest trades"
+	}
+
+	trades, _, err := s.storage.GetAllTrades(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) == 0 {
+		return nil, UserError("No trades yet.")
+	}
+
+	fields := make([]Field, 0, len(trades))
+	for _, trade := range trades {
+		name := tradeAction(&trade.Trade)
+		if filters.Username == nil {
+			name = trade.Username + " · " + name
+		}
+		value := fmt.Sprintf("%s · %.0f shares @ %.0f¢ · %s",
+			truncate(trade.DisplayTitle(), 200), deref(trade.Size), deref(trade.Price)*100, formatUSD(deref(trade.Value)))
+		if trade.Timestamp != nil {
+			value += " · " + formatAge(time.Since(*trade.Timestamp))
+		}
+		fields = append(fields, Field{Name: name, Value: value})
+	}
+
+	return &Reply{
+		Title:  title,
+		Fields: fields,
+	}, nil
+}
+
+// tradeAction describes what a trade did, e.g. "Added to Yes", falling back to its side when
+// it wasn't classified
+func tradeAction(trade *storage.Trade) string {
+	outcome := deref(trade.Outcome)
+	switch deref(trade.PositionChange) {
+	case storage.PositionChangeOpen:
+		return "Opened " + outcome
+	case storage.PositionChangeAdd:
+		return "Added to " + outcome
+	case storage.PositionChangeTrim:
+		return "Trimmed " + outcome
+	case storage.PositionChangeClose:
+		return "Closed " + outcome
+	default:
+		return deref(trade.Side) + " " + outcome
+	}
+}
+
+// unknownUser is the reply to a command naming a user who isn't tracked
+func unknownUser(username string) error {
+	return UserError(fmt.Sprintf("No tracked user named %q.", username))
+}
+
+// positionValue is the current value of a position, estimated from its price when not synced
+func positionValue(pos *storage.Position) float64 {
+	if pos.CurrentValue != nil {
+		return *pos.CurrentValue
+	}
+	return deref(pos.Size) * deref(pos.CurrentPrice)
+}
+
+// pnlTone picks the tone of a reply reporting a gain or loss
+func pnlTone(pnl float64) Tone {
+	switch {
+	case pnl > 0:
+		return ToneGain
+	case pnl < 0:
+		return ToneLoss
+	default:
+		return ToneNeutral
+	}
+}
+
+// formatSignedUSD formats a dollar amount with its sign, e.g. +$1,234.56
+func formatSignedUSD(v float64) string {
+	if v >= 0 {
+		return "+" + formatUSD(v)
+	}
+	return formatUSD(v)
+}
+
+// formatUSD formats a dollar amount with thousands separators, e.g. -$1,234.56
+func formatUSD(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+
+	cents := int64(math.Round(math.Abs(v) * 100))
+	digits := fmt.Sprintf("%d", cents/100)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+
+	return fmt.Sprintf("%s$%s.%02d", sign, b.String(), cents%100)
+}
+
+// formatAge describes how long ago something happened, e.g. 5m ago
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < dayWindow:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/dayWindow))
+	}
+}
+
+// truncate shortens s to at most n runes, as chat platforms limit the length of labels
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// deref returns the value of an optional field, or its zero value when unset
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,200 @@
+package claims
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// claimTTL is how long a claim's nonce can be verified before a new claim is needed
+	claimTTL = 24 * time.Hour
+	// verifyCooldown limits how often a user's profiles are fetched to check a claim
+	verifyCooldown = 30 * time.Second
+	// noncePrefix makes nonces recognisable in a bio
+	noncePrefix = "pyre-"
+)
+
+var (
+	// ErrNoClaim is returned when verifying a user without a pending claim
+	ErrNoClaim = errors.New("no pending claim")
+	// ErrClaimExpired is returned when verifying a claim older than its TTL
+	ErrClaimExpired = errors.New("claim expired")
+	// ErrProfilesUnavailable is returned when none of the user's profiles could be fetched
+	ErrProfilesUnavailable = errors.New("no profile could be fetched")
+)
+
+// CooldownError is returned when a user's claim was checked too recently
+type CooldownError struct {
+	Wait time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("claim was checked recently, retry in %s", e.Wait.Round(time.Second))
+}
+
+// Verification is the outcome of checking a claim
+type Verification struct {
+	Verified   bool
+	Address    string    // address whose bio carried the nonce, set when verified
+	VerifiedAt time.Time // set when verified
+	Checked    []string  // addresses whose profiles were checked
+}
+
+// Service runs the account claim workflow: a claim issues a nonce, which the account owner puts
+// in the bio of one of the user's Polymarket profiles to prove ownership
+type Service interface {
+	// Claim starts a claim of a user's account, returning the pending claim if there is one
+	Claim(ctx context.Context, user *storage.User) (*storage.UserClaim, error)
+	// Verify checks the bios of a user's profiles for their claim's nonce, marking the user
+	// verified when one carries it
+	Verify(ctx context.Context, user *storage.User) (*Verification, error)
+}
+
+// service implements Service against the Polymarket profile API
+type service struct {
+	storage storage.Storage
+	client  polymarket.Client
+	log     logrus.FieldLogger
+
+	mu          sync.Mutex
+	lastChecked map[int64]time.Time
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new claims service
+func NewService(storage storage.Storage, client polymarket.Client, log logrus.FieldLogger) Service {
+	return &service{
+		storage:     storage,
+		client:      client,
+		log:         log.WithField("package", "claims"),
+		lastChecked: make(map[int64]time.Time),
+	}
+}
+
+// Claim starts a claim of a user's account, returning the pending claim if there is one
+func (s *service) Claim(ctx context.Context, user *storage.User) (*storage.UserClaim, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	claim, err := s.storage.CreateUserClaim(ctx, &storage.UserClaim{
+		UserID:    user.ID,
+		Nonce:     nonce,
+		CreatedAt: now,
+		ExpiresAt: now.Add(claimTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.Nonce == nonce {
+		s.log.WithField("username", user.Username).Info("account claim started")
+	}
+
+	return claim, nil
+}
+
+// Verify checks the bios of a user's profiles for their claim's nonce, marking the user verified
+// when one carries it
+func (s *service) Verify(ctx context.Context, user *storage.User) (*Verification, error) {
+	claim, err := s.storage.GetUserClaim(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if claim == nil {
+		return nil, ErrNoClaim
+	}
+	if !time.Now().Before(claim.ExpiresAt) {
+		return nil, ErrClaimExpired
+	}
+
+	if err := s.checkCooldown(user.ID); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.storage.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Verification{Checked: make([]string, 0, len(addresses))}
+	var lastErr error
+	for _, addr := range addresses {
+		profile, err := s.client.GetPublicProfile(ctx, addr.Address)
+		if err != nil {
+			s.log.WithError(err).WithField("address", addr.Address).Warn("failed to fetch profile for claim")
+			lastErr = err
+			continue
+		}
+		result.Checked = append(result.Checked, addr.Address)
+
+		if profile == nil || !strings.Contains(profile.Bio, claim.Nonce) {
+			continue
+		}
+
+		verifiedAt := time.Now().UTC().Truncate(time.Second)
+		if err := s.storage.VerifyUser(ctx, user.ID, addr.Address, verifiedAt); err != nil {
+			return nil, err
+		}
+		s.log.WithFields(logrus.Fields{
+			"username": user.Username,
+			"address":  addr.Address,
+		}).Info("account claim verified")
+
+		result.Verified = true
+		result.Address = addr.Address
+		result.VerifiedAt = verifiedAt
+		return result, nil
+	}
+
+	// Only an error when no profile could be checked at all
+	if len(result.Checked) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProfilesUnavailable, lastErr)
+	}
+
+	return result, nil
+}
+
+// checkCooldown records a verification attempt for a user, failing if the last one was too recent
+func (s *service) checkCooldown(userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if last, ok := s.lastChecked[userID]; ok && now.Sub(last) < verifyCooldown {
+		return &CooldownError{Wait: verifyCooldown - now.Sub(last)}
+	}
+
+	// Drop attempts past the cooldown so the map doesn't grow with every user ever checked
+	for id, last := range s.lastChecked {
+		if now.Sub(last) >= verifyCooldown {
+			delete(s.lastChecked, id)
+		}
+	}
+	s.lastChecked[userID] = now
+
+	return nil
+}
+
+// newNonce generates a random claim nonce
+func newNonce() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return noncePrefix + hex.EncodeToString(b), nil
+}
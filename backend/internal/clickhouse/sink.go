@@ -0,0 +1,426 @@
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// requestTimeout limits a single statement sent to ClickHouse
+	requestTimeout = 30 * time.Second
+	// maxBufferedBatches is how many batches are held while ClickHouse is unreachable before
+	// the oldest rows are dropped
+	maxBufferedBatches = 20
+)
+
+// Options configures the ClickHouse sink
+type Options struct {
+	URL           string // HTTP interface, e.g. http://localhost:8123
+	Database      string
+	Username      string
+	Password      string
+	BatchSize     int           // rows buffered per table before a flush
+	FlushInterval time.Duration // longest rows wait in the buffer
+	CreateTables  bool          // create the tables when missing
+}
+
+// Sink mirrors trades and PnL snapshots from the event bus into ClickHouse for analytics.
+// SQLite stays the operational store; the sink only ever inserts.
+type Sink interface {
+	// Start subscribes to the event bus and begins flushing batches
+	Start(ctx context.Context) error
+	// Stop unsubscribes and flushes the rows still buffered
+	Stop() error
+}
+
+// sink implements Sink over the ClickHouse HTTP interface
+type sink struct {
+	opts    Options
+	storage storage.Storage
+	bus     events.Bus
+	http    *http.Client
+	log     logrus.FieldLogger
+
+	mu        sync.Mutex
+	trades    []tradeRow
+	snapshots []snapshotRow
+	usernames map[int64]string // user ID -> username, filled on first use
+	// tablesReady is set once the tables are known to exist, guarded by flushMu
+	tablesReady bool
+	flushMu     sync.Mutex
+
+	flushNow    chan struct{}
+	unsubscribe func()
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+var _ Sink = (*sink)(nil)
+
+// NewSink creates a new ClickHouse sink. Usernames are looked up in storage so the mirrored
+// rows can be queried without the SQLite users table.
+func NewSink(opts Options, storage storage.Storage, bus events.Bus, log logrus.FieldLogger) Sink {
+	return &sink{
+		opts:        opts,
+		storage:     storage,
+		bus:         bus,
+		http:        &http.Client{Timeout: requestTimeout},
+		log:         log.WithField("package", "clickhouse"),
+		usernames:   make(map[int64]string),
+		tablesReady: !opts.CreateTables,
+		flushNow:    make(chan struct{}, 1),
+	}
+}
+
+// tradeRow is a trade as inserted into ClickHouse
+type tradeRow struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	Username       string     `json:"username"`
+	Address        string     `json:"address"`
+	TradeID        *string    `json:"trade_id"`
+	ConditionID    *string    `json:"condition_id"`
+	MarketTitle    *string    `json:"market_title"`
+	MarketSlug     *string    `json:"market_slug"`
+	EventSlug      *string    `json:"event_slug"`
+	Outcome        *string    `json:"outcome"`
+	Side           *string    `json:"side"`
+	Price          *float64   `json:"price"`
+	Size           *float64   `json:"size"`
+	Value          *float64   `json:"value"`
+	Timestamp      *time.Time `json:"timestamp"`
+	PositionChange *string    `json:"position_change"`
+	BookMidpoint   *float64   `json:"book_midpoint"`
+	BookBestBid    *float64   `json:"book_best_bid"`
+	BookBestAsk    *float64   `json:"book_best_ask"`
+	SyncedAt       time.Time  `json:"synced_at"`
+}
+
+// snapshotRow is a PnL snapshot as inserted into ClickHouse
+type snapshotRow struct {
+	UserID        int64     `json:"user_id"`
+	Username      string    `json:"username"`
+	Timestamp     time.Time `json:"timestamp"`
+	TotalPnl      *float64  `json:"total_pnl"`
+	RealizedPnl   *float64  `json:"realized_pnl"`
+	UnrealizedPnl *float64  `json:"unrealized_pnl"`
+	Source        string    `json:"source"`
+}
+
+// Tables are ReplacingMergeTree so rows inserted twice, e.g. by every instance receiving the
+// same event from a shared broker, collapse into one
+var tableDDL = []string{
+	`CREATE TABLE IF NOT EXISTS trades (
+		id Int64,
+		user_id Int64,
+		username LowCardinality(String),
+		address String,
+		trade_id Nullable(String),
+		condition_id Nullable(String),
+		market_title Nullable(String),
+		market_slug Nullable(String),
+		event_slug Nullable(String),
+		outcome Nullable(String),
+		side LowCardinality(Nullable(String)),
+		price Nullable(Float64),
+		size Nullable(Float64),
+		value Nullable(Float64),
+		timestamp Nullable(DateTime),
+		position_change LowCardinality(Nullable(String)),
+		book_midpoint Nullable(Float64),
+		book_best_bid Nullable(Float64),
+		book_best_ask Nullable(Float64),
+		synced_at DateTime
+	) ENGINE = ReplacingMergeTree
+	ORDER BY (user_id, id)`,
+	`CREATE TABLE IF NOT EXISTS pnl_snapshots (
+		user_id Int64,
+		username LowCardinality(String),
+		timestamp DateTime,
+		total_pnl Nullable(Float64),
+		realized_pnl Nullable(Float64),
+		unrealized_pnl Nullable(Float64),
+		source LowCardinality(String)
+	) ENGINE = ReplacingMergeTree
+	ORDER BY (user_id, timestamp, source)`,
+}
+
+// Start subscribes to ingested trades and user syncs, which carry the PnL snapshot taken
+func (s *sink) Start(ctx context.Context) error {
+	s.log.WithFields(logrus.Fields{
+		"url":      s.opts.URL,
+		"database": s.opts.Database,
+	}).Info("starting clickhouse sink")
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.unsubscribe = s.bus.Subscribe("clickhouse", s.handle, events.TradeIngested, events.UserSynced)
+
+	s.wg.Add(1)
+	go s.flushLoop(ctx)
+
+	return nil
+}
+
+// Stop unsubscribes from the bus and flushes what is left
+func (s *sink) Stop() error {
+	s.log.Info("stopping clickhouse sink")
+
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	s.flush(ctx)
+
+	s.log.Info("clickhouse sink stopped")
+	return nil
+}
+
+// handle buffers the row an event carries
+func (s *sink) handle(ctx context.Context, event events.Event) {
+	username := s.username(ctx, event.UserID)
+
+	s.mu.Lock()
+	switch {
+	case event.Type == events.TradeIngested && event.Trade != nil:
+		t := event.Trade
+		s.trades = append(s.trades, tradeRow{
+			ID:             t.ID,
+			UserID:         t.UserID,
+			Username:       username,
+			Address:        t.Address,
+			TradeID:        t.TradeID,
+			ConditionID:    t.ConditionID,
+			MarketTitle:    t.MarketTitle,
+			MarketSlug:     t.MarketSlug,
+			EventSlug:      t.EventSlug,
+			Outcome:        t.Outcome,
+			Side:           t.Side,
+			Price:          t.Price,
+			Size:           t.Size,
+			Value:          t.Value,
+			Timestamp:      t.Timestamp,
+			PositionChange: t.PositionChange,
+			BookMidpoint:   t.BookMidpoint,
+			BookBestBid:    t.BookBestBid,
+			BookBestAsk:    t.BookBestAsk,
+			SyncedAt:       event.Time,
+		})
+	case event.Type == events.UserSynced && event.Snapshot != nil:
+		snap := event.Snapshot
+		source := snap.Source
+		if source == "" {
+			source = storage.SnapshotSourceSync
+		}
+		s.snapshots = append(s.snapshots, snapshotRow{
+			UserID:        snap.UserID,
+			Username:      username,
+			Timestamp:     snap.Timestamp,
+			TotalPnl:      snap.TotalPnl,
+			RealizedPnl:   snap.RealizedPnl,
+			UnrealizedPnl: snap.UnrealizedPnl,
+			Source:        source,
+		})
+	}
+	full := len(s.trades) >= s.opts.BatchSize || len(s.snapshots) >= s.opts.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.flushNow <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// username resolves a user ID, reloading the users when it isn't known yet
+func (s *sink) username(ctx context.Context, userID int64) string {
+	s.mu.Lock()
+	name, ok := s.usernames[userID]
+	s.mu.Unlock()
+	if ok {
+		return name
+	}
+
+	users, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to load usernames")
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range users {
+		s.usernames[user.ID] = user.Username
+	}
+	return s.usernames[userID]
+}
+
+// flushLoop flushes every FlushInterval, and as soon as a batch fills up
+func (s *sink) flushLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.flushNow:
+		}
+		s.flush(ctx)
+	}
+}
+
+// flush inserts the buffered rows. Rows that fail to insert go back to the buffer for the next
+// flush, up to maxBufferedBatches per table.
+func (s *sink) flush(ctx context.Context) {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	trades, snapshots := s.trades, s.snapshots
+	s.trades, s.snapshots = nil, nil
+	s.mu.Unlock()
+
+	if len(trades) == 0 && len(snapshots) == 0 {
+		return
+	}
+
+	if !s.tablesReady {
+		if err := s.createTables(ctx); err != nil {
+			s.log.WithError(err).Warn("failed to create clickhouse tables")
+			s.requeue(trades, snapshots)
+			return
+		}
+		s.tablesReady = true
+	}
+
+	if len(trades) > 0 {
+		if err := insert(ctx, s, "trades", trades); err != nil {
+			s.log.WithError(err).WithField("rows", len(trades)).Warn("failed to insert trades into clickhouse")
+		} else {
+			s.log.WithField("rows", len(trades)).Debug("inserted trades into clickhouse")
+			trades = nil
+		}
+	}
+
+	if len(snapshots) > 0 {
+		if err := insert(ctx, s, "pnl_snapshots", snapshots); err != nil {
+			s.log.WithError(err).WithField("rows", len(snapshots)).Warn("failed to insert pnl snapshots into clickhouse")
+		} else {
+			s.log.WithField("rows", len(snapshots)).Debug("inserted pnl snapshots into clickhouse")
+			snapshots = nil
+		}
+	}
+
+	s.requeue(trades, snapshots)
+}
+
+// requeue puts rows that failed to insert back in front of the rows buffered since, dropping
+// the oldest beyond maxBufferedBatches
+func (s *sink) requeue(trades []tradeRow, snapshots []snapshotRow) {
+	if len(trades) == 0 && len(snapshots) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := maxBufferedBatches * s.opts.BatchSize
+	s.trades = capRows(s, "trades", append(trades, s.trades...), limit)
+	s.snapshots = capRows(s, "pnl_snapshots", append(snapshots, s.snapshots...), limit)
+}
+
+// capRows keeps the newest limit rows
+func capRows[T any](s *sink, table string, rows []T, limit int) []T {
+	if len(rows) <= limit {
+		return rows
+	}
+	s.log.WithFields(logrus.Fields{
+		"table":   table,
+		"dropped": len(rows) - limit,
+	}).Warn("clickhouse buffer full, dropping oldest rows")
+	return rows[len(rows)-limit:]
+}
+
+// createTables creates the mirrored tables when missing
+func (s *sink) createTables(ctx context.Context) error {
+	for _, ddl := range tableDDL {
+		if err := s.exec(ctx, ddl, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert sends rows to a table as JSONEachRow
+func insert[T any](ctx context.Context, s *sink, table string, rows []T) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for i := range rows {
+		if err := enc.Encode(&rows[i]); err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+	}
+
+	return s.exec(ctx, fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", table), &body)
+}
+
+// exec runs a statement. With data, the statement goes in the query string and data is the
+// request body, as ClickHouse expects for inserts.
+func (s *sink) exec(ctx context.Context, statement string, data io.Reader) error {
+	params := url.Values{}
+	params.Set("database", s.opts.Database)
+	// Timestamps are sent as RFC 3339
+	params.Set("date_time_input_format", "best_effort")
+
+	body := data
+	if body == nil {
+		body = strings.NewReader(statement)
+	} else {
+		params.Set("query", statement)
+	}
+
+	endpoint := strings.TrimRight(s.opts.URL, "/") + "/?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.opts.Username != "" {
+		req.Header.Set("X-ClickHouse-User", s.opts.Username)
+		req.Header.Set("X-ClickHouse-Key", s.opts.Password)
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package concentration
+
+import (
+	"context"
+	"sync"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Market is how much of a user's open exposure is held in one market
+type Market struct {
+	Value        float64 // current value of the user's positions in the market, both outcomes
+	Share        float64 // fraction of the user's open exposure
+	Concentrated bool    // the share exceeds the configured threshold
+}
+
+// Service flags users holding too much of their portfolio in a single market, and announces
+// when a market becomes concentrated
+type Service interface {
+	// Start checks each synced user's open positions for newly concentrated markets
+	Start(ctx context.Context) error
+	Stop() error
+	// Assess measures the exposure of a user's open positions per market, keyed by condition ID
+	Assess(positions []*storage.Position) map[string]Market
+}
+
+// service implements the concentration Service
+type service struct {
+	storage     storage.Storage
+	bus         events.Bus
+	threshold   float64 // share of open exposure above which a market is concentrated
+	minExposure float64 // open exposure below which no market is concentrated
+	log         logrus.FieldLogger
+	unsubscribe func()
+
+	mu sync.Mutex
+	// flagged holds each assessed user's concentrated markets as of their last sync
+	flagged map[int64]map[string]bool
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new concentration service. A market is concentrated when it holds more
+// than thresholdPercent of a user's open exposure, and the exposure is at least minExposure USDC.
+func NewService(storage storage.Storage, bus events.Bus, thresholdPercent, minExposure float64, log logrus.FieldLogger) Service {
+	return &service{
+		storage:     storage,
+		bus:         bus,
+		threshold:   thresholdPercent / 100,
+		minExposure: minExposure,
+		log:         log.WithField("package", "concentration"),
+		flagged:     make(map[int64]map[string]bool),
+	}
+}
+
+// Start subscribes to user syncs
+func (s *service) Start(_ context.Context) error {
+	s.unsubscribe = s.bus.Subscribe("concentration", s.handle, events.UserSynced)
+	return nil
+}
+
+// Stop unsubscribes from user syncs
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// Assess measures the exposure of open positions per market
+func (s *service) Assess(positions []*storage.Position) map[string]Market {
+	markets := make(map[string]Market)
+	var total float64
+	for _, pos := range positions {
+		if pos.CurrentValue == nil || *pos.CurrentValue <= 0 {
+			continue
+		}
+		market := markets[pos.ConditionID]
+		market.Value += *pos.CurrentValue
+		markets[pos.ConditionID] = market
+		total += *pos.CurrentValue
+	}
+
+	for conditionID, market := range markets {
+		market.Share = market.Value / total
+		market.Concentrated = total >= s.minExposure && market.Share > s.threshold
+		markets[conditionID] = market
+	}
+
+	return markets
+}
+
+// handle announces the markets that became concentrated since a user's previous sync. The
+// first sync assessed after startup only records them, they may have been announced before.
+func (s *service) handle(ctx context.Context, event events.Event) {
+	positions, err := s.storage.GetUserOpenPositions(ctx, event.UserID, false)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to get open positions")
+		return
+	}
+
+	markets := s.Assess(positions)
+	concentrated := make(map[string]bool)
+	for conditionID, market := range markets {
+		if market.Concentrated {
+			concentrated[conditionID] = true
+		}
+	}
+
+	s.mu.Lock()
+	previous, assessed := s.flagged[event.UserID]
+	s.flagged[event.UserID] = concentrated
+	s.mu.Unlock()
+	if !assessed {
+		return
+	}
+
+	for conditionID := range concentrated {
+		if previous[conditionID] {
+			continue
+		}
+
+		// The alert carries the largest position held in the market
+		var largest *storage.Position
+		for _, pos := range positions {
+			if pos.ConditionID == conditionID && pos.CurrentValue != nil &&
+				(largest == nil || *pos.CurrentValue > *largest.CurrentValue) {
+				largest = pos
+			}
+		}
+		share := markets[conditionID].Share
+
+		s.log.WithFields(logrus.Fields{
+			"user_id":      event.UserID,
+			"condition_id": conditionID,
+			"share":        share,
+		}).Info("position concentration exceeded threshold")
+
+		s.bus.Publish(ctx, events.Event{
+			Type:     events.ConcentrationAlert,
+			UserID:   event.UserID,
+			Address:  largest.Address,
+			Position: largest,
+			Share:    share,
+		})
+	}
+}
@@ -1,53 +1,381 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/samcm/pyre/internal/benchmark"
+	"github.com/samcm/pyre/internal/notify"
+	"github.com/samcm/pyre/internal/scoring"
 	"github.com/spf13/viper"
 )
 
 // PersonaConfig represents a persona (real person) with multiple usernames
 type PersonaConfig struct {
-	DisplayName string              `mapstructure:"displayName"`
-	Image       string              `mapstructure:"image"`     // custom image URL for the persona
-	Usernames   map[string][]string `mapstructure:"usernames"` // username -> []address
+	DisplayName string              `mapstructure:"displayName" yaml:"displayName"`
+	Image       string              `mapstructure:"image" yaml:"image,omitempty"` // custom image URL for the persona
+	Usernames   map[string][]string `mapstructure:"usernames" yaml:"usernames"`   // username -> []address
+}
+
+// Roster is the set of tracked users and personas. It is part of the config file, and
+// can also be exported and applied through the admin API using the same YAML schema.
+type Roster struct {
+	Users    map[string][]string      `mapstructure:"users" yaml:"users,omitempty"`       // username -> []address (legacy)
+	Personas map[string]PersonaConfig `mapstructure:"personas" yaml:"personas,omitempty"` // slug -> PersonaConfig
+	Ghosts   []string                 `mapstructure:"ghosts" yaml:"ghosts,omitempty"`     // usernames hidden from public leaderboards and the trade feed
+
+	// AddressGroups splits a user's addresses into named sub-portfolios: username -> group -> []address
+	AddressGroups map[string]map[string][]string `mapstructure:"addressGroups" yaml:"addressGroups,omitempty"`
 }
 
 // Config represents the application configuration
 type Config struct {
-	Server   ServerConfig             `mapstructure:"server"`
-	Database DatabaseConfig           `mapstructure:"database"`
-	Users    map[string][]string      `mapstructure:"users"`    // username -> []address (legacy)
-	Personas map[string]PersonaConfig `mapstructure:"personas"` // slug -> PersonaConfig
-	Sync     SyncConfig               `mapstructure:"sync"`
+	Roster        `mapstructure:",squash"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Positions     PositionsConfig     `mapstructure:"positions"`
+	Snapshots     SnapshotsConfig     `mapstructure:"snapshots"`
+	Sync          SyncConfig          `mapstructure:"sync"`
+	Replication   ReplicationConfig   `mapstructure:"replication"`
+	Feed          FeedConfig          `mapstructure:"feed"`
+	Events        EventsConfig        `mapstructure:"events"`
+	ClickHouse    ClickHouseConfig    `mapstructure:"clickhouse"`
+	Presence      PresenceConfig      `mapstructure:"presence"`
+	Concentration ConcentrationConfig `mapstructure:"concentration"`
+	Leaderboard   LeaderboardConfig   `mapstructure:"leaderboard"`
+	Benchmarks    []BenchmarkConfig   `mapstructure:"benchmarks"` // PnL histories can be compared against
+	Avatars       AvatarsConfig       `mapstructure:"avatars"`
+	Fetch         FetchConfig         `mapstructure:"fetch"`
+	TradeImport   TradeImportConfig   `mapstructure:"tradeImport"`
+	PublicAPI     PublicAPIConfig     `mapstructure:"publicApi"`
+	Blobstore     BlobstoreConfig     `mapstructure:"blobstore"`
+	Backup        BackupConfig        `mapstructure:"backup"`
+	Discord       DiscordConfig       `mapstructure:"discord"`
+	Telegram      TelegramConfig      `mapstructure:"telegram"`
+	Notifications NotificationsConfig `mapstructure:"notifications"`
 }
 
 // ServerConfig contains HTTP server configuration
 type ServerConfig struct {
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Host                 string               `mapstructure:"host"`
+	Port                 int                  `mapstructure:"port"`
+	MaxBodyBytes         int64                `mapstructure:"maxBodyBytes"`         // maximum request body size
+	RequestTimeout       time.Duration        `mapstructure:"requestTimeout"`       // default per-request timeout
+	RouteTimeouts        []RouteTimeoutConfig `mapstructure:"routeTimeouts"`        // per-route overrides of requestTimeout
+	SlowRequestThreshold time.Duration        `mapstructure:"slowRequestThreshold"` // requests slower than this are logged, 0 disables
+	AccessLog            AccessLogConfig      `mapstructure:"accessLog"`
+	AdminKeys            []string             `mapstructure:"adminKeys" redact:"true"` // accepted by every endpoint, and needed by those changing or operating the server
+	ReadKeys             []string             `mapstructure:"readKeys" redact:"true"`  // accepted by the endpoints reading the API, needed with RequireKey
+	RequireKey           bool                 `mapstructure:"requireKey"`              // reads need a read or admin key
+}
+
+// AccessLogConfig contains structured request logging configuration
+type AccessLogConfig struct {
+	Enabled      bool                   `mapstructure:"enabled"`
+	RedactParams []string               `mapstructure:"redactParams"` // query parameters whose values are never logged
+	Routes       []AccessLogRouteConfig `mapstructure:"routes"`       // per-route overrides, longest prefix wins
+}
+
+// AccessLogRouteConfig overrides access logging for paths starting with Prefix
+type AccessLogRouteConfig struct {
+	Prefix     string   `mapstructure:"prefix"`
+	Enabled    *bool    `mapstructure:"enabled"`
+	SampleRate *float64 `mapstructure:"sampleRate"` // fraction of successful requests logged, 0-1
+}
+
+// RouteTimeoutConfig overrides the request timeout for paths starting with Prefix
+type RouteTimeoutConfig struct {
+	Prefix  string        `mapstructure:"prefix"`
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 // DatabaseConfig contains database configuration
 type DatabaseConfig struct {
-	Path string `mapstructure:"path"`
+	Path            string `mapstructure:"path"`
+	ReadConnections int    `mapstructure:"readConnections"` // read-only connections for read queries, 0 shares the writer connection
+}
+
+// PositionsConfig contains open position configuration
+type PositionsConfig struct {
+	DustValue float64 `mapstructure:"dustValue"` // current value in USDC below which a position is dust, 0 disables
+}
+
+// SnapshotsConfig contains PnL snapshot retention configuration
+type SnapshotsConfig struct {
+	RetentionInterval time.Duration        `mapstructure:"retentionInterval"` // how often old snapshots are downsampled, 0 disables
+	Retention         []SnapshotTierConfig `mapstructure:"retention"`         // downsampling tiers, ordered by age
+}
+
+// SnapshotTierConfig thins snapshots older than After to one per user every Every
+type SnapshotTierConfig struct {
+	After time.Duration `mapstructure:"after"`
+	Every time.Duration `mapstructure:"every"`
+}
+
+// ReplicationConfig contains continuous database replication configuration
+type ReplicationConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	LitestreamPath   string        `mapstructure:"litestreamPath"`          // path to the litestream binary
+	ReplicaURL       string        `mapstructure:"replicaUrl" redact:"url"` // e.g. s3://bucket/pyre
+	SyncInterval     time.Duration `mapstructure:"syncInterval"`            // how often WAL changes are shipped
+	SnapshotInterval time.Duration `mapstructure:"snapshotInterval"`        // how often full snapshots are taken
+	Retention        time.Duration `mapstructure:"retention"`               // how far back point-in-time restore can go
+	RestoreOnStart   bool          `mapstructure:"restoreOnStart"`          // restore from the replica when the database is missing
+	RestoreTimestamp string        `mapstructure:"restoreTimestamp"`        // optional RFC 3339 point in time to restore to
 }
 
 // SyncConfig contains sync service configuration
 type SyncConfig struct {
-	IntervalMinutes int `mapstructure:"intervalMinutes"`
+	Enabled                bool `mapstructure:"enabled"` // periodic syncing from Polymarket, disable to serve only stored data
+	IntervalMinutes        int  `mapstructure:"intervalMinutes"`
+	ErrorHistory           int  `mapstructure:"errorHistory"`           // number of recent sync errors kept per user
+	RunHistory             int  `mapstructure:"runHistory"`             // number of recent sync runs kept
+	ReconcileIntervalHours int  `mapstructure:"reconcileIntervalHours"` // how often trades are checked against a full re-fetch, 0 disables
+	LeaseSeconds           int  `mapstructure:"leaseSeconds"`           // how long the sync lease lasts without renewal, 0 disables
+	CallBudget             int  `mapstructure:"callBudget"`             // Polymarket API calls allowed per sync cycle, 0 is unlimited
+	BookMaxAgeMinutes      int  `mapstructure:"bookMaxAgeMinutes"`      // how recent a new trade must be to store the order book with it, 0 disables
+	GlobalLeaderboardHours int  `mapstructure:"globalLeaderboardHours"` // how often Polymarket's global leaderboard is stored, 0 disables
+	GlobalLeaderboardSize  int  `mapstructure:"globalLeaderboardSize"`  // number of top global traders stored
+	DormantDays            int  `mapstructure:"dormantDays"`            // days without a trade before an address is dormant, 0 disables
+	DormantCheckEvery      int  `mapstructure:"dormantCheckEvery"`      // dormant addresses are synced every this many cycles
+
+	Browser BrowserConfig `mapstructure:"browser"`
+}
+
+// BrowserConfig contains the headless Chrome fallback for scraping official PnL
+type BrowserConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	ExecPath      string        `mapstructure:"execPath"`      // Chrome or Chromium binary, looked up on PATH when empty
+	Timeout       time.Duration `mapstructure:"timeout"`       // limit on loading a profile and extracting its stats
+	MaxConcurrent int           `mapstructure:"maxConcurrent"` // browsers running at once
+	MaxHeapMB     int           `mapstructure:"maxHeapMb"`     // JavaScript heap limit per browser
+	NoSandbox     bool          `mapstructure:"noSandbox"`     // required when running as root in a container
+}
+
+// FeedConfig contains trade feed configuration
+type FeedConfig struct {
+	Mute      MuteConfig      `mapstructure:"mute"`
+	Stream    StreamConfig    `mapstructure:"stream"`
+	Reactions ReactionsConfig `mapstructure:"reactions"`
+}
+
+// MuteConfig hides matching trades from the trade feed
+type MuteConfig struct {
+	MinValue   float64  `mapstructure:"minValue"`   // hide trades worth less than this
+	Users      []string `mapstructure:"users"`      // hide trades by these usernames
+	Categories []string `mapstructure:"categories"` // hide trades in these market categories
+}
+
+// StreamConfig contains the live feed WebSocket configuration
+type StreamConfig struct {
+	Enabled             bool          `mapstructure:"enabled"`
+	WhaleThreshold      float64       `mapstructure:"whaleThreshold"`      // trades worth at least this (in USDC) are sent to trades:whale subscribers
+	LeaderboardInterval time.Duration `mapstructure:"leaderboardInterval"` // how often the leaderboard is checked for rank changes after syncs
+	BufferSize          int           `mapstructure:"bufferSize"`          // messages a client can fall behind by before new ones are dropped
+}
+
+// ReactionsConfig contains the configuration of comments and emoji reactions on trades and results
+type ReactionsConfig struct {
+	Enabled           bool                   `mapstructure:"enabled"`
+	Authors           []ReactionAuthorConfig `mapstructure:"authors"`           // who can post, reading needs no key
+	MaxCommentLength  int                    `mapstructure:"maxCommentLength"`  // in characters
+	RequestsPerMinute int                    `mapstructure:"requestsPerMinute"` // posts per API key, 0 is unlimited
+}
+
+// ReactionAuthorConfig is an API key allowed to post reactions and the name they are shown under
+type ReactionAuthorConfig struct {
+	Name string `mapstructure:"name"`
+	Key  string `mapstructure:"key" redact:"true"`
+}
+
+// EventsConfig contains event bus configuration
+type EventsConfig struct {
+	Backend    string `mapstructure:"backend"`          // memory, nats or redis
+	URL        string `mapstructure:"url" redact:"url"` // broker URL, e.g. nats://localhost:4222 or redis://localhost:6379/0
+	Subject    string `mapstructure:"subject"`          // NATS subject or Redis channel events are published on
+	BufferSize int    `mapstructure:"bufferSize"`       // events each subscriber can fall behind by
+}
+
+// ClickHouseConfig contains the ClickHouse analytics sink configuration
+type ClickHouseConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	URL           string        `mapstructure:"url" redact:"url"` // HTTP interface, e.g. http://localhost:8123
+	Database      string        `mapstructure:"database"`
+	Username      string        `mapstructure:"username"`
+	Password      string        `mapstructure:"password" redact:"true"`
+	BatchSize     int           `mapstructure:"batchSize"`     // rows buffered per table before a flush
+	FlushInterval time.Duration `mapstructure:"flushInterval"` // longest rows wait in the buffer
+	CreateTables  bool          `mapstructure:"createTables"`  // create the tables when missing
+}
+
+// PresenceConfig contains the "now trading" indicator configuration
+type PresenceConfig struct {
+	ActiveMinutes int `mapstructure:"activeMinutes"` // users are active for this long after a trade
+}
+
+// ConcentrationConfig contains the open position concentration alerts configuration
+type ConcentrationConfig struct {
+	ThresholdPercent float64 `mapstructure:"thresholdPercent"` // share of open exposure in one market that is concentrated
+	MinExposure      float64 `mapstructure:"minExposure"`      // open exposure in USDC below which nothing is flagged
 }
 
+// LeaderboardConfig contains leaderboard configuration
+type LeaderboardConfig struct {
+	Scores []ScoreConfig `mapstructure:"scores"` // custom metrics the leaderboard can be sorted by
+}
+
+// ScoreConfig defines a custom leaderboard metric as an arithmetic formula over leaderboard stats
+type ScoreConfig struct {
+	Name    string `mapstructure:"name"`    // used as the leaderboard sortBy value
+	Formula string `mapstructure:"formula"` // e.g. totalPnl*0.7 + winRate*10000*0.3
+}
+
+// BenchmarkConfig defines a PnL benchmark as the average of a group of tracked users
+type BenchmarkConfig struct {
+	Name  string   `mapstructure:"name"`  // used as the PnL history benchmark value
+	Users []string `mapstructure:"users"` // usernames of tracked users
+}
+
+// AvatarsConfig contains the avatar image proxy configuration
+type AvatarsConfig struct {
+	CacheDir      string        `mapstructure:"cacheDir"`      // directory holding fetched and resized avatars
+	MemoryEntries int           `mapstructure:"memoryEntries"` // avatars kept in memory, 0 disables the memory cache
+	RefreshAfter  time.Duration `mapstructure:"refreshAfter"`  // how long a cached avatar is served before it is fetched again
+	MaxBytes      int64         `mapstructure:"maxBytes"`      // largest upstream image accepted
+	Timeout       time.Duration `mapstructure:"timeout"`       // limit on fetching an upstream image
+}
+
+// FetchConfig contains the configuration of the queue shared by everything fetching pages and
+// images from the web, such as profile scraping and the avatar proxy
+type FetchConfig struct {
+	RequestsPerSecond float64           `mapstructure:"requestsPerSecond"` // per host, 0 is unlimited
+	Hosts             []FetchHostConfig `mapstructure:"hosts"`             // per-host overrides of requestsPerSecond
+	ReuseFor          time.Duration     `mapstructure:"reuseFor"`          // how long a fetched page is served to later requests for it, 0 disables
+	Timeout           time.Duration     `mapstructure:"timeout"`           // limit on a fetch, including time queued for its host
+}
+
+// FetchHostConfig overrides the fetch rate limit of one host
+type FetchHostConfig struct {
+	Host              string  `mapstructure:"host"` // e.g. polymarket.com
+	RequestsPerSecond float64 `mapstructure:"requestsPerSecond"`
+}
+
+// TradeImportConfig contains the CSV trade import configuration
+type TradeImportConfig struct {
+	MaxRows int                      `mapstructure:"maxRows"` // rows accepted per file, 0 is unlimited
+	Columns TradeImportColumnsConfig `mapstructure:"columns"`
+}
+
+// TradeImportColumnsConfig is the CSV header of the column holding each trade field. Headers
+// are matched case-insensitively, and optional fields can be set to "" to ignore their column.
+type TradeImportColumnsConfig struct {
+	ID          string `mapstructure:"id"`
+	Timestamp   string `mapstructure:"timestamp"`
+	Address     string `mapstructure:"address"`
+	ConditionID string `mapstructure:"conditionId"`
+	MarketTitle string `mapstructure:"marketTitle"`
+	MarketSlug  string `mapstructure:"marketSlug"`
+	Outcome     string `mapstructure:"outcome"`
+	Side        string `mapstructure:"side"`
+	Price       string `mapstructure:"price"`
+	Size        string `mapstructure:"size"`
+	Value       string `mapstructure:"value"`
+}
+
+// PublicAPIConfig contains the public address PnL endpoint configuration
+type PublicAPIConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	Keys              []string      `mapstructure:"keys" redact:"true"` // API keys accepted, any request is accepted when empty
+	CacheTTL          time.Duration `mapstructure:"cacheTtl"`           // how long a computed address PnL is served
+	CacheEntries      int           `mapstructure:"cacheEntries"`       // addresses kept in the cache
+	RequestsPerMinute int           `mapstructure:"requestsPerMinute"`  // per API key, or per client IP without keys. 0 is unlimited.
+}
+
+// BlobstoreConfig contains the configuration of the object storage holding saved exports and
+// database backups
+type BlobstoreConfig struct {
+	Backend   string `mapstructure:"backend"`                 // local, s3 or gcs. Empty disables the blob store.
+	Prefix    string `mapstructure:"prefix"`                  // prepended to every key, e.g. to share a bucket
+	Dir       string `mapstructure:"dir"`                     // root directory for the local backend
+	Bucket    string `mapstructure:"bucket"`                  // bucket for the s3 and gcs backends
+	Endpoint  string `mapstructure:"endpoint"`                // S3-compatible endpoint, defaults to AWS for s3 and Google for gcs
+	Region    string `mapstructure:"region"`                  // bucket region, detected when empty
+	AccessKey string `mapstructure:"accessKey" redact:"true"` // static credentials; s3 falls back to the AWS environment and instance role
+	SecretKey string `mapstructure:"secretKey" redact:"true"`
+	Insecure  bool   `mapstructure:"insecure"` // connect over plain HTTP, e.g. to a local MinIO
+}
+
+// BackupConfig contains the configuration of the backup command
+type BackupConfig struct {
+	Keep int `mapstructure:"keep"` // backups kept in the blob store, older ones are deleted. 0 keeps all.
+}
+
+// DiscordConfig contains the Discord slash command bot configuration
+type DiscordConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	ApplicationID string `mapstructure:"applicationId"`
+	PublicKey     string `mapstructure:"publicKey"`              // from the application's General Information page
+	BotToken      string `mapstructure:"botToken" redact:"true"` // used to register the slash commands
+	GuildID       string `mapstructure:"guildId"`                // register the commands in this server only, where they appear immediately
+}
+
+// TelegramConfig contains the Telegram bot configuration
+type TelegramConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	BotToken string `mapstructure:"botToken" redact:"true"` // from @BotFather
+}
+
+// NotificationsConfig contains the alerts sent when tracked users trade or open and close positions
+type NotificationsConfig struct {
+	Enabled          bool                              `mapstructure:"enabled"`
+	Discord          DiscordWebhookConfig              `mapstructure:"discord"`
+	MaxTradeAge      time.Duration                     `mapstructure:"maxTradeAge"`      // trades made longer ago than this when synced are history, not announced
+	Trades           bool                              `mapstructure:"trades"`           // announce trades worth at least minTradeValue
+	PositionsOpened  bool                              `mapstructure:"positionsOpened"`  // announce opened positions worth at least minPositionValue
+	PositionsClosed  bool                              `mapstructure:"positionsClosed"`  // announce closed positions that cost at least minPositionValue
+	MinTradeValue    float64                           `mapstructure:"minTradeValue"`    // in USDC
+	MinPositionValue float64                           `mapstructure:"minPositionValue"` // in USDC
+	Personas         map[string]NotificationThresholds `mapstructure:"personas"`         // overrides by persona slug
+	Markets          map[string]NotificationThresholds `mapstructure:"markets"`          // overrides by condition ID or market slug, taking precedence over personas
+}
+
+// NotificationThresholds overrides the notification thresholds, unset ones are inherited
+type NotificationThresholds struct {
+	MinTradeValue    *float64 `mapstructure:"minTradeValue"`
+	MinPositionValue *float64 `mapstructure:"minPositionValue"`
+}
+
+// DiscordWebhookConfig contains the Discord channel webhook notifications are posted to
+type DiscordWebhookConfig struct {
+	WebhookURL string            `mapstructure:"webhookUrl" redact:"true"` // from the channel's Integrations settings
+	Templates  map[string]string `mapstructure:"templates"`                // Go templates of headlines by trade, positionOpened and positionClosed
+}
+
+// Stages a config file is checked in, in order
+const (
+	StageParse    = "parse"    // YAML syntax
+	StageDecode   = "decode"   // values of the wrong type
+	StageValidate = "validate" // settings that don't make sense, or together
+)
+
+// CheckError is a problem that stops a config file from loading, with the stage it was found in
+type CheckError struct {
+	Stage string
+	Err   error
+}
+
+func (e *CheckError) Error() string { return e.Err.Error() }
+
+func (e *CheckError) Unwrap() error { return e.Err }
+
 // Load loads configuration from a file
 func Load(configPath string) (*Config, error) {
-	v := viper.New()
-
-	// Set defaults
-	v.SetDefault("server.host", "0.0.0.0")
-	v.SetDefault("server.port", 8080)
-	v.SetDefault("database.path", "./data/pyre.db")
-	v.SetDefault("sync.intervalMinutes", 5)
+	v := newViper()
 
 	// Set config file path
 	if configPath != "" {
@@ -59,11 +387,6 @@ func Load(configPath string) (*Config, error) {
 		v.AddConfigPath("./config")
 	}
 
-	// Enable environment variables
-	v.SetEnvPrefix("BLACKHOLE")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
-
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -72,18 +395,157 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Unmarshal config
-	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	cfg, _, err := decode(v)
+	if err != nil {
+		var checkErr *CheckError
+		if errors.As(err, &checkErr) && checkErr.Stage == StageValidate {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Validate config
+	return cfg, nil
+}
+
+// Parse loads configuration from the YAML of a config file as Load would, with the same
+// defaults and environment overrides. It also returns the keys the config doesn't know,
+// which Load ignores. Errors are *CheckError.
+func Parse(data []byte) (*Config, []string, error) {
+	v := newViper()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, nil, &CheckError{Stage: StageParse, Err: err}
+	}
+
+	return decode(v)
+}
+
+// decode unmarshals and validates the configuration read by v, returning the keys it didn't use
+func decode(v *viper.Viper) (*Config, []string, error) {
+	var (
+		cfg      Config
+		metadata mapstructure.Metadata
+	)
+	if err := v.Unmarshal(&cfg, func(dc *mapstructure.DecoderConfig) {
+		dc.Metadata = &metadata
+	}); err != nil {
+		return nil, nil, &CheckError{Stage: StageDecode, Err: err}
+	}
+
 	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+		return nil, metadata.Unused, &CheckError{Stage: StageValidate, Err: err}
 	}
 
-	return &cfg, nil
+	return &cfg, metadata.Unused, nil
+}
+
+// newViper creates a viper instance with the defaults and environment overrides of every
+// config file
+func newViper() *viper.Viper {
+	v := viper.New()
+
+	// Set defaults
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", 8080)
+	v.SetDefault("server.maxBodyBytes", 1<<20)
+	v.SetDefault("server.requestTimeout", "60s")
+	v.SetDefault("server.routeTimeouts", []map[string]any{
+		{"prefix": "/api/v1/trades/export", "timeout": "10m"},
+	})
+	v.SetDefault("server.slowRequestThreshold", "2s")
+	v.SetDefault("server.accessLog.enabled", true)
+	v.SetDefault("server.accessLog.redactParams", []string{"apiKey", "api_key", "key", "token", "access_token", "secret"})
+	v.SetDefault("database.path", "./data/pyre.db")
+	v.SetDefault("positions.dustValue", 0.1)
+	v.SetDefault("snapshots.retentionInterval", "1h")
+	v.SetDefault("snapshots.retention", []map[string]any{
+		{"after": "168h", "every": "1h"},
+		{"after": "2160h", "every": "24h"},
+	})
+	v.SetDefault("sync.enabled", true)
+	v.SetDefault("sync.intervalMinutes", 5)
+	v.SetDefault("sync.errorHistory", 20)
+	v.SetDefault("sync.runHistory", 500)
+	v.SetDefault("sync.reconcileIntervalHours", 24)
+	v.SetDefault("sync.leaseSeconds", 60)
+	v.SetDefault("sync.callBudget", 0)
+	v.SetDefault("sync.bookMaxAgeMinutes", 10)
+	v.SetDefault("sync.globalLeaderboardHours", 24)
+	v.SetDefault("sync.globalLeaderboardSize", 1000)
+	v.SetDefault("sync.dormantDays", 0)
+	v.SetDefault("sync.dormantCheckEvery", 12)
+	v.SetDefault("sync.browser.enabled", false)
+	v.SetDefault("sync.browser.timeout", "30s")
+	v.SetDefault("sync.browser.maxConcurrent", 1)
+	v.SetDefault("sync.browser.maxHeapMb", 256)
+	v.SetDefault("replication.enabled", false)
+	v.SetDefault("replication.litestreamPath", "litestream")
+	v.SetDefault("replication.syncInterval", "1s")
+	v.SetDefault("replication.snapshotInterval", "24h")
+	v.SetDefault("replication.retention", "72h")
+	v.SetDefault("replication.restoreOnStart", true)
+	v.SetDefault("events.backend", "memory")
+	v.SetDefault("events.subject", "pyre.events")
+	v.SetDefault("events.bufferSize", 1024)
+	v.SetDefault("presence.activeMinutes", 15)
+	v.SetDefault("concentration.thresholdPercent", 50.0)
+	v.SetDefault("concentration.minExposure", 100.0)
+	v.SetDefault("clickhouse.enabled", false)
+	v.SetDefault("clickhouse.url", "http://localhost:8123")
+	v.SetDefault("clickhouse.database", "default")
+	v.SetDefault("clickhouse.batchSize", 1000)
+	v.SetDefault("clickhouse.flushInterval", "10s")
+	v.SetDefault("clickhouse.createTables", true)
+	v.SetDefault("feed.stream.enabled", true)
+	v.SetDefault("feed.stream.whaleThreshold", 10000)
+	v.SetDefault("feed.stream.leaderboardInterval", "30s")
+	v.SetDefault("feed.stream.bufferSize", 256)
+	v.SetDefault("feed.reactions.enabled", false)
+	v.SetDefault("feed.reactions.maxCommentLength", 280)
+	v.SetDefault("feed.reactions.requestsPerMinute", 10)
+	v.SetDefault("avatars.cacheDir", "./data/avatars")
+	v.SetDefault("avatars.memoryEntries", 512)
+	v.SetDefault("avatars.refreshAfter", "24h")
+	v.SetDefault("avatars.maxBytes", 5<<20)
+	v.SetDefault("avatars.timeout", "10s")
+	v.SetDefault("fetch.requestsPerSecond", 2)
+	v.SetDefault("fetch.reuseFor", "1m")
+	v.SetDefault("fetch.timeout", "1m")
+	v.SetDefault("tradeImport.maxRows", 100000)
+	v.SetDefault("tradeImport.columns.id", "id")
+	v.SetDefault("tradeImport.columns.timestamp", "timestamp")
+	v.SetDefault("tradeImport.columns.address", "address")
+	v.SetDefault("tradeImport.columns.conditionId", "conditionId")
+	v.SetDefault("tradeImport.columns.marketTitle", "marketTitle")
+	v.SetDefault("tradeImport.columns.marketSlug", "marketSlug")
+	v.SetDefault("tradeImport.columns.outcome", "outcome")
+	v.SetDefault("tradeImport.columns.side", "side")
+	v.SetDefault("tradeImport.columns.price", "price")
+	v.SetDefault("tradeImport.columns.size", "size")
+	v.SetDefault("tradeImport.columns.value", "value")
+	v.SetDefault("publicApi.enabled", false)
+	v.SetDefault("blobstore.backend", "")
+	v.SetDefault("blobstore.dir", "./data/blobs")
+	v.SetDefault("backup.keep", 7)
+	v.SetDefault("discord.enabled", false)
+	v.SetDefault("telegram.enabled", false)
+	v.SetDefault("notifications.enabled", false)
+	v.SetDefault("notifications.maxTradeAge", "1h")
+	v.SetDefault("notifications.trades", true)
+	v.SetDefault("notifications.positionsOpened", true)
+	v.SetDefault("notifications.positionsClosed", true)
+	v.SetDefault("notifications.minTradeValue", 10000.0)
+	v.SetDefault("notifications.minPositionValue", 10000.0)
+	v.SetDefault("publicApi.cacheTtl", "5m")
+	v.SetDefault("publicApi.cacheEntries", 1000)
+	v.SetDefault("publicApi.requestsPerMinute", 30)
+
+	// Enable environment variables
+	v.SetEnvPrefix("BLACKHOLE")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	return v
 }
 
 // Validate validates the configuration
@@ -92,21 +554,425 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
 
+	if c.Server.MaxBodyBytes <= 0 {
+		return fmt.Errorf("server max body bytes must be positive, got: %d", c.Server.MaxBodyBytes)
+	}
+
+	if c.Server.RequestTimeout <= 0 {
+		return fmt.Errorf("server request timeout must be positive, got: %s", c.Server.RequestTimeout)
+	}
+
+	for i, route := range c.Server.RouteTimeouts {
+		if !strings.HasPrefix(route.Prefix, "/") {
+			return fmt.Errorf("route timeout %d prefix must start with /, got: %q", i, route.Prefix)
+		}
+		if route.Timeout <= 0 {
+			return fmt.Errorf("route timeout for %s must be positive, got: %s", route.Prefix, route.Timeout)
+		}
+	}
+
+	for i, key := range c.Server.AdminKeys {
+		if key == "" {
+			return fmt.Errorf("server admin key %d is empty", i)
+		}
+	}
+	for i, key := range c.Server.ReadKeys {
+		if key == "" {
+			return fmt.Errorf("server read key %d is empty", i)
+		}
+	}
+
+	if c.Server.SlowRequestThreshold < 0 {
+		return fmt.Errorf("server slow request threshold must not be negative, got: %s", c.Server.SlowRequestThreshold)
+	}
+
+	for i, route := range c.Server.AccessLog.Routes {
+		if !strings.HasPrefix(route.Prefix, "/") {
+			return fmt.Errorf("access log route %d prefix must start with /, got: %q", i, route.Prefix)
+		}
+		if route.SampleRate != nil && (*route.SampleRate < 0 || *route.SampleRate > 1) {
+			return fmt.Errorf("access log sample rate for %s must be between 0 and 1, got: %f", route.Prefix, *route.SampleRate)
+		}
+	}
+
 	if c.Database.Path == "" {
 		return fmt.Errorf("database path is required")
 	}
 
+	if c.Database.ReadConnections < 0 {
+		return fmt.Errorf("database read connections must not be negative, got: %d", c.Database.ReadConnections)
+	}
+
+	if c.Positions.DustValue < 0 {
+		return fmt.Errorf("positions dust value must not be negative, got: %f", c.Positions.DustValue)
+	}
+
+	if c.Snapshots.RetentionInterval < 0 {
+		return fmt.Errorf("snapshot retention interval must not be negative, got: %s", c.Snapshots.RetentionInterval)
+	}
+
+	for i, tier := range c.Snapshots.Retention {
+		if tier.After < 0 {
+			return fmt.Errorf("snapshot retention tier %d age must not be negative, got: %s", i, tier.After)
+		}
+		if tier.Every <= 0 {
+			return fmt.Errorf("snapshot retention tier %d interval must be positive, got: %s", i, tier.Every)
+		}
+		if i > 0 {
+			prev := c.Snapshots.Retention[i-1]
+			if tier.After <= prev.After {
+				return fmt.Errorf("snapshot retention tier %d must apply to older snapshots than tier %d", i, i-1)
+			}
+			if tier.Every < prev.Every {
+				return fmt.Errorf("snapshot retention tier %d interval must not be shorter than tier %d's", i, i-1)
+			}
+		}
+	}
+
 	if c.Sync.IntervalMinutes <= 0 {
 		return fmt.Errorf("sync interval must be positive, got: %d", c.Sync.IntervalMinutes)
 	}
 
+	if c.Sync.ErrorHistory <= 0 {
+		return fmt.Errorf("sync error history must be positive, got: %d", c.Sync.ErrorHistory)
+	}
+
+	if c.Sync.RunHistory <= 0 {
+		return fmt.Errorf("sync run history must be positive, got: %d", c.Sync.RunHistory)
+	}
+
+	if c.Replication.Enabled {
+		if c.Replication.ReplicaURL == "" {
+			return fmt.Errorf("replication replica URL is required when replication is enabled")
+		}
+		if c.Replication.LitestreamPath == "" {
+			return fmt.Errorf("replication litestream path is required when replication is enabled")
+		}
+		if c.Replication.SyncInterval <= 0 || c.Replication.SnapshotInterval <= 0 || c.Replication.Retention <= 0 {
+			return fmt.Errorf("replication intervals and retention must be positive")
+		}
+		if c.Replication.RestoreTimestamp != "" {
+			if _, err := time.Parse(time.RFC3339, c.Replication.RestoreTimestamp); err != nil {
+				return fmt.Errorf("invalid replication restore timestamp: %w", err)
+			}
+		}
+	}
+
+	if c.Sync.ReconcileIntervalHours < 0 {
+		return fmt.Errorf("sync reconcile interval must not be negative, got: %d", c.Sync.ReconcileIntervalHours)
+	}
+
+	if c.Sync.LeaseSeconds < 0 {
+		return fmt.Errorf("sync lease seconds must not be negative, got: %d", c.Sync.LeaseSeconds)
+	}
+
+	if c.Sync.CallBudget < 0 {
+		return fmt.Errorf("sync call budget must not be negative, got: %d", c.Sync.CallBudget)
+	}
+
+	if c.Sync.BookMaxAgeMinutes < 0 {
+		return fmt.Errorf("sync book max age must not be negative, got: %d", c.Sync.BookMaxAgeMinutes)
+	}
+
+	if c.Sync.GlobalLeaderboardHours < 0 {
+		return fmt.Errorf("sync global leaderboard hours must not be negative, got: %d", c.Sync.GlobalLeaderboardHours)
+	}
+
+	if c.Sync.GlobalLeaderboardSize < 0 {
+		return fmt.Errorf("sync global leaderboard size must not be negative, got: %d", c.Sync.GlobalLeaderboardSize)
+	}
+
+	if c.Sync.DormantDays < 0 {
+		return fmt.Errorf("sync dormant days must not be negative, got: %d", c.Sync.DormantDays)
+	}
+
+	if c.Sync.DormantCheckEvery <= 0 {
+		return fmt.Errorf("sync dormant check interval must be positive, got: %d", c.Sync.DormantCheckEvery)
+	}
+
+	if c.Sync.Browser.Enabled {
+		if c.Sync.Browser.Timeout <= 0 {
+			return fmt.Errorf("sync browser timeout must be positive")
+		}
+		if c.Sync.Browser.MaxConcurrent <= 0 || c.Sync.Browser.MaxHeapMB <= 0 {
+			return fmt.Errorf("sync browser max concurrent and max heap must be positive")
+		}
+	}
+
+	if c.Feed.Mute.MinValue < 0 {
+		return fmt.Errorf("feed mute min value must not be negative, got: %f", c.Feed.Mute.MinValue)
+	}
+
+	if c.Feed.Stream.Enabled {
+		if c.Feed.Stream.WhaleThreshold < 0 {
+			return fmt.Errorf("feed stream whale threshold must not be negative, got: %f", c.Feed.Stream.WhaleThreshold)
+		}
+		if c.Feed.Stream.LeaderboardInterval <= 0 {
+			return fmt.Errorf("feed stream leaderboard interval must be positive, got: %s", c.Feed.Stream.LeaderboardInterval)
+		}
+		if c.Feed.Stream.BufferSize <= 0 {
+			return fmt.Errorf("feed stream buffer size must be positive, got: %d", c.Feed.Stream.BufferSize)
+		}
+	}
+
+	if c.Feed.Reactions.Enabled {
+		if c.Feed.Reactions.MaxCommentLength <= 0 {
+			return fmt.Errorf("feed reactions max comment length must be positive, got: %d", c.Feed.Reactions.MaxCommentLength)
+		}
+		if c.Feed.Reactions.RequestsPerMinute < 0 {
+			return fmt.Errorf("feed reactions requests per minute must not be negative, got: %d", c.Feed.Reactions.RequestsPerMinute)
+		}
+
+		names := make(map[string]bool, len(c.Feed.Reactions.Authors))
+		keys := make(map[string]bool, len(c.Feed.Reactions.Authors))
+		for i, author := range c.Feed.Reactions.Authors {
+			if author.Name == "" {
+				return fmt.Errorf("feed reactions author %d name is required", i)
+			}
+			if author.Key == "" {
+				return fmt.Errorf("feed reactions author %s key is required", author.Name)
+			}
+			if names[author.Name] {
+				return fmt.Errorf("duplicate feed reactions author: %s", author.Name)
+			}
+			if keys[author.Key] {
+				return fmt.Errorf("feed reactions author %s reuses another author's key", author.Name)
+			}
+			names[author.Name] = true
+			keys[author.Key] = true
+		}
+	}
+
+	switch c.Events.Backend {
+	case "memory":
+	case "nats", "redis":
+		if c.Events.URL == "" {
+			return fmt.Errorf("events URL is required for the %s backend", c.Events.Backend)
+		}
+		if c.Events.Subject == "" {
+			return fmt.Errorf("events subject is required for the %s backend", c.Events.Backend)
+		}
+	default:
+		return fmt.Errorf("unknown events backend: %s (expected memory, nats or redis)", c.Events.Backend)
+	}
+
+	if c.Events.BufferSize <= 0 {
+		return fmt.Errorf("events buffer size must be positive, got: %d", c.Events.BufferSize)
+	}
+
+	if c.Presence.ActiveMinutes <= 0 {
+		return fmt.Errorf("presence active minutes must be positive, got: %d", c.Presence.ActiveMinutes)
+	}
+
+	if c.Concentration.ThresholdPercent <= 0 || c.Concentration.ThresholdPercent > 100 {
+		return fmt.Errorf("concentration threshold must be between 0 and 100 percent, got: %v", c.Concentration.ThresholdPercent)
+	}
+
+	if c.Concentration.MinExposure < 0 {
+		return fmt.Errorf("concentration min exposure must not be negative, got: %v", c.Concentration.MinExposure)
+	}
+
+	if c.ClickHouse.Enabled {
+		if c.ClickHouse.URL == "" || c.ClickHouse.Database == "" {
+			return fmt.Errorf("clickhouse URL and database are required when the clickhouse sink is enabled")
+		}
+		if c.ClickHouse.BatchSize <= 0 || c.ClickHouse.FlushInterval <= 0 {
+			return fmt.Errorf("clickhouse batch size and flush interval must be positive")
+		}
+	}
+
+	if c.Avatars.CacheDir == "" {
+		return fmt.Errorf("avatars cache dir is required")
+	}
+
+	if c.Avatars.MemoryEntries < 0 {
+		return fmt.Errorf("avatars memory entries must not be negative, got: %d", c.Avatars.MemoryEntries)
+	}
+
+	if c.Avatars.RefreshAfter <= 0 {
+		return fmt.Errorf("avatars refresh after must be positive, got: %s", c.Avatars.RefreshAfter)
+	}
+
+	if c.Avatars.MaxBytes <= 0 {
+		return fmt.Errorf("avatars max bytes must be positive, got: %d", c.Avatars.MaxBytes)
+	}
+
+	if c.Avatars.Timeout <= 0 {
+		return fmt.Errorf("avatars timeout must be positive, got: %s", c.Avatars.Timeout)
+	}
+
+	if c.Fetch.RequestsPerSecond < 0 {
+		return fmt.Errorf("fetch requests per second must not be negative, got: %f", c.Fetch.RequestsPerSecond)
+	}
+
+	for _, host := range c.Fetch.Hosts {
+		if host.Host == "" {
+			return fmt.Errorf("fetch host override is missing a host")
+		}
+		if host.RequestsPerSecond < 0 {
+			return fmt.Errorf("fetch requests per second for %s must not be negative, got: %f", host.Host, host.RequestsPerSecond)
+		}
+	}
+
+	if c.Fetch.ReuseFor < 0 {
+		return fmt.Errorf("fetch reuse for must not be negative, got: %s", c.Fetch.ReuseFor)
+	}
+
+	if c.Fetch.Timeout <= 0 {
+		return fmt.Errorf("fetch timeout must be positive, got: %s", c.Fetch.Timeout)
+	}
+
+	if c.TradeImport.MaxRows < 0 {
+		return fmt.Errorf("trade import max rows must not be negative, got: %d", c.TradeImport.MaxRows)
+	}
+
+	columns := c.TradeImport.Columns
+	for name, header := range map[string]string{
+		"timestamp":   columns.Timestamp,
+		"conditionId": columns.ConditionID,
+		"side":        columns.Side,
+		"price":       columns.Price,
+		"size":        columns.Size,
+	} {
+		if header == "" {
+			return fmt.Errorf("trade import column for %s is required", name)
+		}
+	}
+
+	if c.PublicAPI.CacheTTL < 0 {
+		return fmt.Errorf("public api cache ttl must not be negative, got: %s", c.PublicAPI.CacheTTL)
+	}
+
+	if c.PublicAPI.CacheEntries < 0 {
+		return fmt.Errorf("public api cache entries must not be negative, got: %d", c.PublicAPI.CacheEntries)
+	}
+
+	if c.PublicAPI.RequestsPerMinute < 0 {
+		return fmt.Errorf("public api requests per minute must not be negative, got: %d", c.PublicAPI.RequestsPerMinute)
+	}
+
+	for i, key := range c.PublicAPI.Keys {
+		if key == "" {
+			return fmt.Errorf("public api key %d is empty", i)
+		}
+	}
+
+	switch c.Blobstore.Backend {
+	case "":
+	case "local":
+		if c.Blobstore.Dir == "" {
+			return fmt.Errorf("blob store dir is required for the local backend")
+		}
+	case "s3", "gcs":
+		if c.Blobstore.Bucket == "" {
+			return fmt.Errorf("blob store bucket is required for the %s backend", c.Blobstore.Backend)
+		}
+		if c.Blobstore.Backend == "gcs" && c.Blobstore.AccessKey == "" {
+			return fmt.Errorf("blob store access key is required for the gcs backend")
+		}
+		if c.Blobstore.AccessKey != "" && c.Blobstore.SecretKey == "" {
+			return fmt.Errorf("blob store secret key is required with an access key")
+		}
+	default:
+		return fmt.Errorf("unknown blob store backend: %s (expected local, s3 or gcs)", c.Blobstore.Backend)
+	}
+
+	if c.Backup.Keep < 0 {
+		return fmt.Errorf("backup keep must not be negative, got: %d", c.Backup.Keep)
+	}
+
+	if c.Discord.Enabled {
+		if c.Discord.ApplicationID == "" {
+			return fmt.Errorf("discord application id is required")
+		}
+		if c.Discord.PublicKey == "" {
+			return fmt.Errorf("discord public key is required")
+		}
+		if c.Discord.BotToken == "" {
+			return fmt.Errorf("discord bot token is required")
+		}
+	}
+
+	if c.Telegram.Enabled && c.Telegram.BotToken == "" {
+		return fmt.Errorf("telegram bot token is required")
+	}
+
+	if c.Notifications.Enabled {
+		if c.Notifications.Discord.WebhookURL == "" {
+			return fmt.Errorf("notifications discord webhook url is required")
+		}
+		if !strings.HasPrefix(c.Notifications.Discord.WebhookURL, "https://") {
+			return fmt.Errorf("notifications discord webhook url must be https")
+		}
+		if c.Notifications.MaxTradeAge <= 0 {
+			return fmt.Errorf("notifications max trade age must be positive, got: %s", c.Notifications.MaxTradeAge)
+		}
+	}
+
+	if _, err := notify.ParseTemplates(c.Notifications.Discord.Templates); err != nil {
+		return fmt.Errorf("notifications discord templates: %w", err)
+	}
+
+	if c.Notifications.MinTradeValue < 0 || c.Notifications.MinPositionValue < 0 {
+		return fmt.Errorf("notification thresholds must not be negative")
+	}
+	for _, overrides := range []map[string]NotificationThresholds{c.Notifications.Personas, c.Notifications.Markets} {
+		for key, thresholds := range overrides {
+			if (thresholds.MinTradeValue != nil && *thresholds.MinTradeValue < 0) ||
+				(thresholds.MinPositionValue != nil && *thresholds.MinPositionValue < 0) {
+				return fmt.Errorf("notification thresholds for %s must not be negative", key)
+			}
+		}
+	}
+
+	scoreNames := make(map[string]bool, len(c.Leaderboard.Scores))
+	for i, score := range c.Leaderboard.Scores {
+		if score.Name == "" {
+			return fmt.Errorf("leaderboard score %d name is required", i)
+		}
+		if slices.Contains(scoring.BuiltinSorts, score.Name) {
+			return fmt.Errorf("leaderboard score %s conflicts with a built-in sort", score.Name)
+		}
+		if scoreNames[score.Name] {
+			return fmt.Errorf("duplicate leaderboard score: %s", score.Name)
+		}
+		scoreNames[score.Name] = true
+
+		if _, err := scoring.NewScore(score.Name, score.Formula); err != nil {
+			return err
+		}
+	}
+
+	benchmarkNames := make(map[string]bool, len(c.Benchmarks))
+	for i, bench := range c.Benchmarks {
+		if bench.Name == "" {
+			return fmt.Errorf("benchmark %d name is required", i)
+		}
+		if slices.Contains(benchmark.Builtins, bench.Name) {
+			return fmt.Errorf("benchmark %s conflicts with a built-in benchmark", bench.Name)
+		}
+		if benchmarkNames[bench.Name] {
+			return fmt.Errorf("duplicate benchmark: %s", bench.Name)
+		}
+		benchmarkNames[bench.Name] = true
+
+		if len(bench.Users) == 0 {
+			return fmt.Errorf("benchmark %s needs at least one user", bench.Name)
+		}
+	}
+
+	return c.Roster.Validate()
+}
+
+// Validate validates the users, personas and ghosts of a roster
+func (r *Roster) Validate() error {
 	// Need either users or personas configured
-	if len(c.Users) == 0 && len(c.Personas) == 0 {
+	if len(r.Users) == 0 && len(r.Personas) == 0 {
 		return fmt.Errorf("at least one user or persona must be configured")
 	}
 
 	// Validate legacy users
-	for username, addresses := range c.Users {
+	for username, addresses := range r.Users {
 		if username == "" {
 			return fmt.Errorf("empty username is not allowed")
 		}
@@ -121,7 +987,8 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate personas
-	for slug, persona := range c.Personas {
+	owners := make(map[string]string)
+	for slug, persona := range r.Personas {
 		if slug == "" {
 			return fmt.Errorf("empty persona slug is not allowed")
 		}
@@ -135,6 +1002,13 @@ func (c *Config) Validate() error {
 			if username == "" {
 				return fmt.Errorf("persona %s has empty username", slug)
 			}
+			if owner, ok := owners[username]; ok {
+				return fmt.Errorf("user %s belongs to both persona %s and persona %s", username, owner, slug)
+			}
+			if _, ok := r.Users[username]; ok {
+				return fmt.Errorf("user %s is configured both as a user and in persona %s", username, slug)
+			}
+			owners[username] = slug
 			if len(addresses) == 0 {
 				return fmt.Errorf("persona %s user %s has no addresses configured", slug, username)
 			}
@@ -146,21 +1020,54 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate ghosts reference configured users
+	allUsers := r.GetAllUsers()
+	for _, username := range r.Ghosts {
+		if _, ok := allUsers[username]; !ok {
+			return fmt.Errorf("ghost user %s is not configured", username)
+		}
+	}
+
+	// Validate address groups reference configured users and their addresses
+	for username, groups := range r.AddressGroups {
+		addresses, ok := allUsers[username]
+		if !ok {
+			return fmt.Errorf("address groups user %s is not configured", username)
+		}
+
+		grouped := make(map[string]string)
+		for group, members := range groups {
+			// "ungrouped" is reserved for addresses that belong to no group
+			if group == "" || group == "ungrouped" {
+				return fmt.Errorf("user %s has invalid address group name %q", username, group)
+			}
+			for _, address := range members {
+				if !slices.Contains(addresses, address) {
+					return fmt.Errorf("address group %s of user %s has unknown address %s", group, username, address)
+				}
+				if other, ok := grouped[address]; ok {
+					return fmt.Errorf("address %s of user %s is in both group %s and group %s", address, username, other, group)
+				}
+				grouped[address] = group
+			}
+		}
+	}
+
 	return nil
 }
 
 // GetAllUsers returns all users from both legacy users config and personas
 // Returns a map of username -> addresses
-func (c *Config) GetAllUsers() map[string][]string {
-	allUsers := make(map[string][]string, len(c.Users))
+func (r *Roster) GetAllUsers() map[string][]string {
+	allUsers := make(map[string][]string, len(r.Users))
 
 	// Add legacy users
-	for username, addresses := range c.Users {
+	for username, addresses := range r.Users {
 		allUsers[username] = addresses
 	}
 
 	// Add users from personas
-	for _, persona := range c.Personas {
+	for _, persona := range r.Personas {
 		for username, addresses := range persona.Usernames {
 			allUsers[username] = addresses
 		}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// redacted replaces the value of a secret setting
+const redacted = "REDACTED"
+
+// Redacted returns the configuration in the config file schema, keyed as in config.yaml, with
+// the values of secret settings (fields tagged redact:"true") replaced. Unset secrets stay
+// empty, so it shows which are configured. URLs that can carry credentials are tagged
+// redact:"url" and keep everything but the password.
+func (c *Config) Redacted() map[string]any {
+	return redactStruct(reflect.ValueOf(*c))
+}
+
+// redactStruct converts a config struct into a map keyed by its mapstructure tags
+func redactStruct(v reflect.Value) map[string]any {
+	out := make(map[string]any)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if opts == "squash" {
+			for key, value := range redactStruct(v.Field(i)) {
+				out[key] = value
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		switch field.Tag.Get("redact") {
+		case "true":
+			out[name] = redactSecret(v.Field(i))
+		case "url":
+			out[name] = redactURL(v.Field(i).String())
+		default:
+			out[name] = redactValue(v.Field(i))
+		}
+	}
+	return out
+}
+
+// redactValue converts a setting into plain maps, slices and values
+func redactValue(v reflect.Value) any {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return redactStruct(v)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+// redactSecret hides a secret setting, keeping empty values and the length of lists
+func redactSecret(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactSecret(v.Index(i))
+		}
+		return out
+	default:
+		if v.IsZero() {
+			return v.Interface()
+		}
+		return redacted
+	}
+}
+
+// redactURL hides the password of a URL. URLs that don't parse are hidden entirely, they may
+// still hold one.
+func redactURL(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"github.com/samcm/pyre/internal/chatbot"
+)
+
+const (
+	colorGain    = 0x2ecc71
+	colorLoss    = 0xe74c3c
+	colorNeutral = 0x5865f2
+
+	// Application command option types
+	optionString = 3
+)
+
+// command is a slash command definition, as registered with Discord
+type command struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []commandOption `json:"options,omitempty"`
+}
+
+type commandOption struct {
+	Type        int            `json:"type"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Required    bool           `json:"required,omitempty"`
+	Choices     []optionChoice `json:"choices,omitempty"`
+}
+
+type optionChoice struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// embed is a Discord message embed
+type embed struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color"`
+	Fields      []embedField `json:"fields,omitempty"`
+	Footer      *embedFooter `json:"footer,omitempty"`
+}
+
+type embedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type embedFooter struct {
+	Text string `json:"text"`
+}
+
+// slashCommands converts the chat commands to slash command definitions
+func slashCommands(cmds []chatbot.Command) []command {
+	definitions := make([]command, 0, len(cmds))
+	for _, cmd := range cmds {
+		def := command{Name: cmd.Name, Description: cmd.Description}
+		for _, arg := range cmd.Args {
+			opt := commandOption{
+				Type:        optionString,
+				Name:        arg.Name,
+				Description: arg.Description,
+				Required:    arg.Required,
+			}
+			for _, choice := range arg.Choices {
+				opt.Choices = append(opt.Choices, optionChoice{Name: choice.Name, Value: choice.Value})
+			}
+			def.Options = append(def.Options, opt)
+		}
+		definitions = append(definitions, def)
+	}
+	return definitions
+}
+
+// toEmbed renders a reply as an embed
+func toEmbed(reply *chatbot.Reply) embed {
+	e := embed{
+		Title:       reply.Title,
+		Description: reply.Summary,
+		Color:       colorNeutral,
+	}
+
+	switch reply.Tone {
+	case chatbot.ToneGain:
+		e.Color = colorGain
+	case chatbot.ToneLoss:
+		e.Color = colorLoss
+	}
+
+	for _, field := range reply.Fields {
+		e.Fields = append(e.Fields, embedField{Name: field.Name, Value: field.Value, Inline: field.Inline})
+	}
+	if reply.Footer != "" {
+		e.Footer = &embedFooter{Text: reply.Footer}
+	}
+
+	return e
+}
@@ -0,0 +1,227 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/samcm/pyre/internal/chatbot"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// discordAPI is the base URL of Discord's REST API
+	discordAPI = "https://discord.com/api/v10"
+	// replyTimeout keeps replies inside the three seconds Discord waits for an interaction response
+	replyTimeout = 2500 * time.Millisecond
+)
+
+// Interaction and response types of the Discord interactions API
+const (
+	interactionPing               = 1
+	interactionApplicationCommand = 2
+
+	responsePong                     = 1
+	responseChannelMessageWithSource = 4
+
+	// messageEphemeral shows a reply only to the user who ran the command
+	messageEphemeral = 1 << 6
+)
+
+// Options configures the Discord bot
+type Options struct {
+	ApplicationID string
+	PublicKey     string // hex-encoded Ed25519 key interaction requests are signed with
+	BotToken      string // used to register the slash commands
+	GuildID       string // registers the commands in one server only, where they appear immediately
+}
+
+// Bot answers Discord slash commands. Discord delivers commands as signed HTTP requests to
+// the bot's interactions endpoint, which is served by ServeHTTP.
+type Bot interface {
+	http.Handler
+	// Start registers the slash commands with Discord
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// bot implements Bot on the shared chat commands
+type bot struct {
+	opts      Options
+	publicKey ed25519.PublicKey
+	commands  chatbot.Service
+	http      *http.Client
+	log       logrus.FieldLogger
+}
+
+var _ Bot = (*bot)(nil)
+
+// NewBot creates a new Discord bot
+func NewBot(opts Options, commands chatbot.Service, log logrus.FieldLogger) (Bot, error) {
+	key, err := hex.DecodeString(opts.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid discord public key")
+	}
+
+	return &bot{
+		opts:      opts,
+		publicKey: ed25519.PublicKey(key),
+		commands:  commands,
+		http:      &http.Client{Timeout: 30 * time.Second},
+		log:       log.WithField("package", "discord"),
+	}, nil
+}
+
+// Start registers the slash commands, replacing any the application had. A failure is logged
+// rather than returned, so the bot still answers commands registered earlier.
+func (b *bot) Start(ctx context.Context) error {
+	b.log.Info("starting discord bot")
+
+	path := fmt.Sprintf("/applications/%s/commands", b.opts.ApplicationID)
+	if b.opts.GuildID != "" {
+		path = fmt.Sprintf("/applications/%s/guilds/%s/commands", b.opts.ApplicationID, b.opts.GuildID)
+	}
+
+	definitions := slashCommands(b.commands.Commands())
+	if err := b.request(ctx, http.MethodPut, path, definitions); err != nil {
+		b.log.WithError(err).Warn("failed to register slash commands")
+	} else {
+		b.log.WithField("commands", len(definitions)).Info("registered slash commands")
+	}
+
+	return nil
+}
+
+// Stop stops the bot
+func (b *bot) Stop() error {
+	b.log.Info("discord bot stopped")
+	return nil
+}
+
+// interaction is the part of a Discord interaction the bot reads
+type interaction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string          `json:"name"`
+			Value json.RawMessage `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+}
+
+// args returns the values of the command's string options by name
+func (i *interaction) args() map[string]string {
+	args := make(map[string]string, len(i.Data.Options))
+	for _, opt := range i.Data.Options {
+		var value string
+		if err := json.Unmarshal(opt.Value, &value); err == nil {
+			args[opt.Name] = value
+		}
+	}
+	return args
+}
+
+// response is an interaction response
+type response struct {
+	Type int           `json:"type"`
+	Data *responseData `json:"data,omitempty"`
+}
+
+type responseData struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []embed `json:"embeds,omitempty"`
+	Flags   int     `json:"flags,omitempty"`
+}
+
+// ServeHTTP handles the interactions endpoint. Discord rejects endpoints that accept requests
+// with invalid signatures, so those are refused before anything else.
+func (b *bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+	if err != nil || !ed25519.Verify(b.publicKey, append([]byte(r.Header.Get("X-Signature-Timestamp")), body...), signature) {
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+		return
+	}
+
+	var in interaction
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, "invalid interaction", http.StatusBadRequest)
+		return
+	}
+
+	var resp response
+	switch in.Type {
+	case interactionPing:
+		resp = response{Type: responsePong}
+	case interactionApplicationCommand:
+		ctx, cancel := context.WithTimeout(r.Context(), replyTimeout)
+		defer cancel()
+		resp = response{Type: responseChannelMessageWithSource, Data: b.runCommand(ctx, &in)}
+	default:
+		http.Error(w, "unsupported interaction type", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		b.log.WithError(err).Debug("failed to write interaction response")
+	}
+}
+
+// runCommand answers a slash command. Failures are replied to the caller only.
+func (b *bot) runCommand(ctx context.Context, in *interaction) *responseData {
+	log := b.log.WithField("command", in.Data.Name)
+
+	reply, err := b.commands.Run(ctx, in.Data.Name, in.args())
+	if err != nil {
+		var msg chatbot.UserError
+		if errors.As(err, &msg) {
+			return &responseData{Content: string(msg), Flags: messageEphemeral}
+		}
+		log.WithError(err).Warn("command failed")
+		return &responseData{Content: "Something went wrong, try again later.", Flags: messageEphemeral}
+	}
+
+	log.Debug("answered command")
+	return &responseData{Embeds: []embed{toEmbed(reply)}}
+}
+
+// request sends an authenticated request to the Discord REST API
+func (b *bot) request(ctx context.Context, method, path string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, discordAPI+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bot "+b.opts.BotToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("discord returned status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Event bus backends
+const (
+	BackendMemory = "memory"
+	BackendNATS   = "nats"
+	BackendRedis  = "redis"
+)
+
+// Options configures the event bus
+type Options struct {
+	Backend    string // memory, nats or redis
+	URL        string // broker URL for the nats and redis backends
+	Subject    string // NATS subject or Redis channel events are published on
+	BufferSize int    // events each subscriber can fall behind by
+}
+
+// transport carries encoded events between instances through a message broker
+type transport interface {
+	publish(ctx context.Context, data []byte) error
+	// subscribe delivers every message published on the subject, including this instance's own
+	subscribe(deliver func(data []byte)) error
+	close() error
+}
+
+// NewBus creates the event bus for the configured backend. The nats and redis backends
+// share events between instances, so subscribers on every instance see events published
+// by whichever instance runs sync.
+func NewBus(opts Options, log logrus.FieldLogger) (Bus, error) {
+	var (
+		t   transport
+		err error
+	)
+
+	switch opts.Backend {
+	case BackendMemory, "":
+		return NewMemoryBus(opts.BufferSize, log), nil
+	case BackendNATS:
+		t, err = newNATSTransport(opts.URL, opts.Subject)
+	case BackendRedis:
+		t, err = newRedisTransport(opts.URL, opts.Subject)
+	default:
+		return nil, fmt.Errorf("unknown event bus backend: %s", opts.Backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect %s event bus: %w", opts.Backend, err)
+	}
+
+	bus := &brokerBus{
+		memoryBus: NewMemoryBus(opts.BufferSize, log).(*memoryBus),
+		transport: t,
+		backend:   opts.Backend,
+	}
+
+	// Events from the broker, including those published here, fan out to local subscribers
+	if err := t.subscribe(bus.receive); err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("failed to subscribe to %s event bus: %w", opts.Backend, err)
+	}
+
+	bus.log.WithFields(logrus.Fields{
+		"backend": opts.Backend,
+		"subject": opts.Subject,
+	}).Info("connected event bus")
+
+	return bus, nil
+}
+
+// brokerBus publishes events to a message broker and delivers the events it receives
+// from the broker to local subscribers
+type brokerBus struct {
+	*memoryBus
+	transport transport
+	backend   string
+}
+
+var _ Bus = (*brokerBus)(nil)
+
+// Publish sends an event to the broker. If the broker is unreachable the event is still
+// delivered to local subscribers.
+func (b *brokerBus) Publish(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		b.log.WithError(err).WithField("type", event.Type).Error("failed to encode event")
+		return
+	}
+
+	if err := b.transport.publish(ctx, data); err != nil {
+		b.log.WithError(err).WithFields(logrus.Fields{
+			"backend": b.backend,
+			"type":    event.Type,
+		}).Warn("failed to publish event to broker, delivering locally")
+		b.memoryBus.Publish(ctx, event)
+	}
+}
+
+// receive decodes an event from the broker and delivers it to local subscribers
+func (b *brokerBus) receive(data []byte) {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		b.log.WithError(err).WithField("backend", b.backend).Warn("failed to decode event from broker")
+		return
+	}
+
+	b.memoryBus.Publish(context.Background(), event)
+}
+
+// Close disconnects from the broker, then stops local delivery
+func (b *brokerBus) Close() error {
+	if err := b.transport.close(); err != nil {
+		b.log.WithError(err).WithField("backend", b.backend).Warn("failed to close event bus connection")
+	}
+	return b.memoryBus.Close()
+}
@@ -0,0 +1,211 @@
+package events
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Type identifies what happened
+type Type string
+
+// Event types published by the sync service
+const (
+	TradeIngested  Type = "trade_ingested"
+	PositionOpened Type = "position_opened"
+	PositionClosed Type = "position_closed"
+	SyncFailed     Type = "sync_failed"
+	MarketResolved Type = "market_resolved"
+	UserSynced     Type = "user_synced"
+)
+
+// Event types published by the badge engine
+const (
+	BadgeAwarded Type = "badge_awarded"
+)
+
+// Event types published by the milestone detector
+const (
+	MilestoneReached Type = "milestone_reached"
+)
+
+// Event types published by the presence tracker
+const (
+	TradingStarted Type = "trading_started"
+)
+
+// Event types published by the concentration checker
+const (
+	ConcentrationAlert Type = "concentration_alert"
+)
+
+// Event is a single occurrence published on the bus. Only the fields relevant to the
+// event type are set.
+type Event struct {
+	Type    Type      `json:"type"`
+	Time    time.Time `json:"time"`
+	UserID  int64     `json:"userId"`
+	Address string    `json:"address,omitempty"`
+
+	Trade      *storage.Trade              `json:"trade,omitempty"`      // TradeIngested, TradingStarted
+	Position   *storage.Position           `json:"position,omitempty"`   // PositionOpened, PositionClosed, ConcentrationAlert
+	Settlement *storage.PositionSettlement `json:"settlement,omitempty"` // MarketResolved
+	SyncError  *storage.SyncError          `json:"syncError,omitempty"`  // SyncFailed
+	Badge      *storage.UserBadge          `json:"badge,omitempty"`      // BadgeAwarded
+	Milestone  *storage.UserMilestone      `json:"milestone,omitempty"`  // MilestoneReached
+	Snapshot   *storage.PnlSnapshot        `json:"snapshot,omitempty"`   // UserSynced, the PnL snapshot taken by the sync
+	Share      float64                     `json:"share,omitempty"`      // ConcentrationAlert, the market's share of open exposure
+}
+
+// Handler consumes events delivered to a subscription
+type Handler func(ctx context.Context, event Event)
+
+// Bus delivers published events to subscribers
+type Bus interface {
+	// Publish sends an event to every subscriber of its type. It never blocks on slow subscribers.
+	Publish(ctx context.Context, event Event)
+	// Subscribe registers a handler for the given event types, or all types when none are given.
+	// Each subscription receives events in order on its own goroutine.
+	Subscribe(name string, handler Handler, types ...Type) (unsubscribe func())
+	// Close stops delivery once queued events have been handled
+	Close() error
+}
+
+// subscription is a registered handler with its queue of undelivered events
+type subscription struct {
+	name    string
+	handler Handler
+	types   []Type
+	queue   chan Event
+}
+
+// memoryBus is an in-process Bus
+type memoryBus struct {
+	bufferSize int
+	log        logrus.FieldLogger
+
+	mu     sync.RWMutex
+	subs   map[*subscription]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ Bus = (*memoryBus)(nil)
+
+// NewMemoryBus creates an in-process event bus. Each subscriber buffers up to bufferSize
+// events; events published while a subscriber's buffer is full are dropped for it.
+func NewMemoryBus(bufferSize int, log logrus.FieldLogger) Bus {
+	return &memoryBus{
+		bufferSize: bufferSize,
+		log:        log.WithField("package", "events"),
+		subs:       make(map[*subscription]struct{}),
+	}
+}
+
+// Publish sends an event to every subscriber of its type
+func (b *memoryBus) Publish(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	for sub := range b.subs {
+		if len(sub.types) > 0 && !slices.Contains(sub.types, event.Type) {
+			continue
+		}
+
+		select {
+		case sub.queue <- event:
+		default:
+			b.log.WithFields(logrus.Fields{
+				"subscriber": sub.name,
+				"type":       event.Type,
+			}).Warn("subscriber queue full, dropping event")
+		}
+	}
+}
+
+// Subscribe registers a handler for the given event types
+func (b *memoryBus) Subscribe(name string, handler Handler, types ...Type) func() {
+	sub := &subscription{
+		name:    name,
+		handler: handler,
+		types:   types,
+		queue:   make(chan Event, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return func() {}
+	}
+	b.subs[sub] = struct{}{}
+
+	b.wg.Add(1)
+	go b.deliver(sub)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subs[sub]; ok {
+				delete(b.subs, sub)
+				close(sub.queue)
+			}
+		})
+	}
+}
+
+// deliver hands queued events to a subscription's handler until its queue is closed
+func (b *memoryBus) deliver(sub *subscription) {
+	defer b.wg.Done()
+
+	for event := range sub.queue {
+		b.handle(sub, event)
+	}
+}
+
+// handle runs a handler, recovering from panics so one subscriber cannot stop delivery
+func (b *memoryBus) handle(sub *subscription, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.WithFields(logrus.Fields{
+				"subscriber": sub.name,
+				"type":       event.Type,
+				"panic":      r,
+			}).Error("event handler panicked")
+		}
+	}()
+
+	sub.handler(context.Background(), event)
+}
+
+// Close stops delivery once queued events have been handled
+func (b *memoryBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return nil
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogHandler returns a handler that logs every event at debug level
+func LogHandler(log logrus.FieldLogger) Handler {
+	log = log.WithField("package", "events")
+
+	return func(_ context.Context, event Event) {
+		fields := logrus.Fields{
+			"type":    event.Type,
+			"user_id": event.UserID,
+		}
+		if event.Address != "" {
+			fields["address"] = event.Address
+		}
+
+		switch {
+		case event.Trade != nil && event.Trade.ConditionID != nil:
+			fields["condition_id"] = *event.Trade.ConditionID
+		case event.Position != nil:
+			fields["condition_id"] = event.Position.ConditionID
+		case event.Settlement != nil:
+			fields["condition_id"] = event.Settlement.ConditionID
+			fields["settlement_price"] = event.Settlement.SettlementPrice
+		case event.SyncError != nil:
+			fields["phase"] = event.SyncError.Phase
+		case event.Badge != nil:
+			fields["badge"] = event.Badge.Badge
+		case event.Milestone != nil:
+			fields["milestone"] = event.Milestone.Kind
+			fields["key"] = event.Milestone.Key
+		}
+
+		log.WithFields(fields).Debug("event")
+	}
+}
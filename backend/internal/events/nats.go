@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsTransport carries events over a NATS subject
+type natsTransport struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSTransport(url, subject string) (transport, error) {
+	conn, err := nats.Connect(url, nats.Name("pyre"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	return &natsTransport{conn: conn, subject: subject}, nil
+}
+
+func (t *natsTransport) publish(_ context.Context, data []byte) error {
+	return t.conn.Publish(t.subject, data)
+}
+
+func (t *natsTransport) subscribe(deliver func(data []byte)) error {
+	_, err := t.conn.Subscribe(t.subject, func(msg *nats.Msg) {
+		deliver(msg.Data)
+	})
+	return err
+}
+
+func (t *natsTransport) close() error {
+	// Drain delivers messages already received before closing the connection
+	return t.conn.Drain()
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTransport carries events over a Redis pub/sub channel
+type redisTransport struct {
+	client  *redis.Client
+	channel string
+	pubsub  *redis.PubSub
+	wg      sync.WaitGroup
+}
+
+func newRedisTransport(url, channel string) (transport, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &redisTransport{client: client, channel: channel}, nil
+}
+
+func (t *redisTransport) publish(ctx context.Context, data []byte) error {
+	return t.client.Publish(ctx, t.channel, data).Err()
+}
+
+func (t *redisTransport) subscribe(deliver func(data []byte)) error {
+	ctx := context.Background()
+
+	t.pubsub = t.client.Subscribe(ctx, t.channel)
+	// Wait for the subscription to be confirmed so no events published after startup are missed
+	if _, err := t.pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for msg := range t.pubsub.Channel() {
+			deliver([]byte(msg.Payload))
+		}
+	}()
+
+	return nil
+}
+
+func (t *redisTransport) close() error {
+	if t.pubsub != nil {
+		if err := t.pubsub.Close(); err != nil {
+			return err
+		}
+		t.wg.Wait()
+	}
+	return t.client.Close()
+}
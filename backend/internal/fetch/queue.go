@@ -0,0 +1,210 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Options configures the fetch queue
+type Options struct {
+	RequestsPerSecond float64            // per host, 0 is unlimited
+	Hosts             map[string]float64 // per-host overrides of RequestsPerSecond, keyed by hostname
+	ReuseFor          time.Duration      // how long a successful response is served to later requests, 0 disables
+	Timeout           time.Duration      // limit on a fetch, including time queued for its host
+}
+
+// Request is a page or image to fetch
+type Request struct {
+	URL       string
+	Accept    string
+	UserAgent string
+	MaxBytes  int64 // largest body accepted, 0 is unlimited
+}
+
+// Response is a fetched page or image. It may be shared with other callers, so its body must
+// not be modified.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	FetchedAt   time.Time
+}
+
+// Queue fetches pages and images for several subsystems. Requests are paced per host, and
+// requests for the same URL share one fetch while it is in flight and for a short while after.
+type Queue interface {
+	// Get fetches a URL, waiting for its host's turn
+	Get(ctx context.Context, req Request) (*Response, error)
+}
+
+// queue implements Queue with a minimum interval between requests to each host
+type queue struct {
+	opts   Options
+	client *http.Client
+	log    logrus.FieldLogger
+
+	mu       sync.Mutex
+	next     map[string]time.Time // earliest time of the next request to a host
+	inflight map[string]*call
+	recent   map[string]*Response
+}
+
+// call is a fetch shared by every request for its key
+type call struct {
+	done chan struct{}
+	resp *Response
+	err  error
+}
+
+var _ Queue = (*queue)(nil)
+
+// NewQueue creates a new fetch queue
+func NewQueue(opts Options, log logrus.FieldLogger) Queue {
+	return &queue{
+		opts:     opts,
+		client:   &http.Client{},
+		log:      log.WithField("package", "fetch"),
+		next:     make(map[string]time.Time),
+		inflight: make(map[string]*call),
+		recent:   make(map[string]*Response),
+	}
+}
+
+// Get fetches a URL, waiting for its host's turn. A request for a URL already being fetched
+// waits for that fetch instead of making another.
+func (q *queue) Get(ctx context.Context, req Request) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid fetch url: %s", req.URL)
+	}
+
+	key := fmt.Sprintf("%s|%s|%d", req.URL, req.Accept, req.MaxBytes)
+
+	q.mu.Lock()
+	if resp, ok := q.recent[key]; ok && time.Since(resp.FetchedAt) < q.opts.ReuseFor {
+		q.mu.Unlock()
+		q.log.WithField("url", req.URL).Debug("reusing recent fetch")
+		return resp, nil
+	}
+	c, ok := q.inflight[key]
+	if !ok {
+		c = &call{done: make(chan struct{})}
+		q.inflight[key] = c
+		go q.run(key, strings.ToLower(u.Hostname()), req, c)
+	} else {
+		q.log.WithField("url", req.URL).Debug("joining in-flight fetch")
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.resp, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run performs a shared fetch. It isn't tied to any one caller's context, so a caller giving
+// up doesn't fail the others waiting on it.
+func (q *queue) run(key, host string, req Request, c *call) {
+	ctx, cancel := context.WithTimeout(context.Background(), q.opts.Timeout)
+	defer cancel()
+
+	c.resp, c.err = q.fetch(ctx, host, req)
+
+	q.mu.Lock()
+	delete(q.inflight, key)
+	if c.err == nil && c.resp.StatusCode == http.StatusOK && q.opts.ReuseFor > 0 {
+		for k, resp := range q.recent {
+			if time.Since(resp.FetchedAt) >= q.opts.ReuseFor {
+				delete(q.recent, k)
+			}
+		}
+		q.recent[key] = c.resp
+	}
+	q.mu.Unlock()
+
+	close(c.done)
+}
+
+// fetch waits for the host's turn, then downloads the URL
+func (q *queue) fetch(ctx context.Context, host string, req Request) (*Response, error) {
+	if wait := q.reserve(host); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for %s: %w", host, ctx.Err())
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if req.Accept != "" {
+		httpReq.Header.Set("Accept", req.Accept)
+	}
+	if req.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", req.UserAgent)
+	}
+
+	resp, err := q.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if req.MaxBytes > 0 {
+		body = io.LimitReader(resp.Body, req.MaxBytes+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if req.MaxBytes > 0 && int64(len(data)) > req.MaxBytes {
+		return nil, fmt.Errorf("response larger than %d bytes", req.MaxBytes)
+	}
+
+	return &Response{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        data,
+		FetchedAt:   time.Now(),
+	}, nil
+}
+
+// reserve takes the next request slot of a host, returning how long to wait for it
+func (q *queue) reserve(host string) time.Duration {
+	rate := q.opts.RequestsPerSecond
+	if override, ok := q.opts.Hosts[host]; ok {
+		rate = override
+	}
+	if rate <= 0 {
+		return 0
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	slot := q.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	q.next[host] = slot.Add(interval)
+
+	return slot.Sub(now)
+}
@@ -0,0 +1,90 @@
+// Package loadgen generates synthetic databases and replays API traffic against a running
+// instance, for capacity planning before tracking many more users.
+package loadgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/samcm/pyre/internal/seed"
+)
+
+// GenerateOptions sizes a synthetic database
+type GenerateOptions struct {
+	Seed           uint64
+	Days           int // length of the history, and so the daily PnL snapshots of each user
+	Users          int
+	Personas       int // personas the users are spread over, the rest of the users have none
+	Markets        int
+	MarketsPerUser int // markets each user trades, a few trades each
+	Stake          float64
+}
+
+// Validate checks the options describe a database that can be generated
+func (o GenerateOptions) Validate() error {
+	switch {
+	case o.Days <= 0:
+		return fmt.Errorf("days must be positive, got: %d", o.Days)
+	case o.Users <= 0:
+		return fmt.Errorf("users must be positive, got: %d", o.Users)
+	case o.Markets <= 0:
+		return fmt.Errorf("markets must be positive, got: %d", o.Markets)
+	case o.MarketsPerUser <= 0 || o.MarketsPerUser > o.Markets:
+		return fmt.Errorf("markets per user must be between 1 and %d, got: %d", o.Markets, o.MarketsPerUser)
+	case o.Personas < 0 || o.Personas > o.Users/2:
+		// Personas pair up users
+		return fmt.Errorf("personas must be between 0 and %d, got: %d", o.Users/2, o.Personas)
+	case o.Stake <= 0:
+		return fmt.Errorf("stake must be positive, got: %g", o.Stake)
+	}
+	return nil
+}
+
+// Fixture describes a synthetic database as a seed fixture: numbered markets and users, the
+// first users paired up into personas, with stakes and skill varying between users
+func Fixture(opts GenerateOptions) (*seed.Fixture, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	f := &seed.Fixture{
+		Seed:     opts.Seed,
+		Days:     opts.Days,
+		Markets:  make([]seed.MarketFixture, opts.Markets),
+		Personas: make([]seed.PersonaFixture, opts.Personas),
+		Users:    make([]seed.UserFixture, opts.Users),
+	}
+
+	for i := range f.Markets {
+		f.Markets[i] = seed.MarketFixture{
+			Title: fmt.Sprintf("Load test market %d?", i+1),
+			Slug:  fmt.Sprintf("loadgen-market-%d", i+1),
+		}
+	}
+	for i := range f.Personas {
+		f.Personas[i] = seed.PersonaFixture{
+			Slug:        fmt.Sprintf("loadgen-%d", i+1),
+			DisplayName: fmt.Sprintf("Load test %d", i+1),
+		}
+	}
+	for i := range f.Users {
+		u := seed.UserFixture{
+			Username: fmt.Sprintf("loadgen%05d", i+1),
+			Markets:  opts.MarketsPerUser,
+			// A few whales among many small traders
+			Stake: opts.Stake * (0.2 + rng.ExpFloat64()),
+			Skill: 0.3 + 0.4*rng.Float64(),
+		}
+		// Two accounts per persona, while there are personas left
+		if opts.Personas > 0 && i < 2*opts.Personas {
+			u.Persona = f.Personas[i/2].Slug
+		}
+		f.Users[i] = u
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,300 @@
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReplayOptions configures replayed API traffic
+type ReplayOptions struct {
+	Target      string // base URL of the instance, e.g. http://localhost:8080
+	APIKey      string // sent as X-API-Key, for instances with server.requireKey
+	Concurrency int    // requests in flight at once
+	Duration    time.Duration
+	Rate        float64 // requests per second across all workers, unlimited when 0
+	Seed        uint64
+}
+
+// pattern is a kind of request the frontend makes, weighted by how often it's made
+type pattern struct {
+	name   string
+	weight int
+	path   func(rng *rand.Rand, t *targets) string // "" when there's nothing to request
+}
+
+// patterns approximate the frontend's traffic: mostly the feed, the leaderboard and user pages
+var patterns = []pattern{
+	{"leaderboard", 15, func(*rand.Rand, *targets) string { return "/leaderboard" }},
+	{"feed", 20, func(*rand.Rand, *targets) string { return "/trades?limit=50" }},
+	{"user", 15, func(rng *rand.Rand, t *targets) string { return t.user(rng, "") }},
+	{"user-trades", 10, func(rng *rand.Rand, t *targets) string { return t.user(rng, "/trades?limit=50") }},
+	{"user-positions", 10, func(rng *rand.Rand, t *targets) string { return t.user(rng, "/positions") }},
+	{"user-pnl", 10, func(rng *rand.Rand, t *targets) string { return t.user(rng, "/pnl") }},
+	{"user-results", 5, func(rng *rand.Rand, t *targets) string { return t.user(rng, "/results") }},
+	{"personas", 5, func(*rand.Rand, *targets) string { return "/personas" }},
+	{"persona", 5, func(rng *rand.Rand, t *targets) string { return t.persona(rng, "") }},
+	{"persona-trades", 5, func(rng *rand.Rand, t *targets) string { return t.persona(rng, "/trades?limit=50") }},
+}
+
+// targets are the users and personas of the instance requests pick from
+type targets struct {
+	usernames []string
+	personas  []string
+}
+
+func (t *targets) user(rng *rand.Rand, suffix string) string {
+	if len(t.usernames) == 0 {
+		return ""
+	}
+	return "/users/" + url.PathEscape(t.usernames[rng.IntN(len(t.usernames))]) + suffix
+}
+
+func (t *targets) persona(rng *rand.Rand, suffix string) string {
+	if len(t.personas) == 0 {
+		return ""
+	}
+	return "/personas/" + url.PathEscape(t.personas[rng.IntN(len(t.personas))]) + suffix
+}
+
+// Stats are the measurements of one pattern
+type Stats struct {
+	Name      string
+	Requests  int
+	Errors    int // transport errors and non-2xx responses
+	latencies []time.Duration
+}
+
+// Percentile returns the latency below which a fraction p of the pattern's requests completed
+func (s *Stats) Percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	return s.latencies[min(int(p*float64(len(s.latencies))), len(s.latencies)-1)]
+}
+
+// Max returns the slowest request of the pattern
+func (s *Stats) Max() time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	return s.latencies[len(s.latencies)-1]
+}
+
+// Report is the outcome of a replay
+type Report struct {
+	Elapsed  time.Duration
+	Users    int
+	Personas int
+	Patterns []*Stats // in the order of the traffic mix
+}
+
+// Requests is the number of requests made
+func (r *Report) Requests() int {
+	total := 0
+	for _, s := range r.Patterns {
+		total += s.Requests
+	}
+	return total
+}
+
+// Errors is the number of requests that failed
+func (r *Report) Errors() int {
+	total := 0
+	for _, s := range r.Patterns {
+		total += s.Errors
+	}
+	return total
+}
+
+// result is a completed request
+type result struct {
+	pattern int
+	latency time.Duration
+	failed  bool
+}
+
+// Replay sends the traffic mix to an instance for the duration, and reports how it held up
+func Replay(ctx context.Context, opts ReplayOptions) (*Report, error) {
+	if opts.Concurrency <= 0 {
+		return nil, fmt.Errorf("concurrency must be positive, got: %d", opts.Concurrency)
+	}
+	if opts.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive, got: %s", opts.Duration)
+	}
+	if opts.Rate < 0 {
+		return nil, fmt.Errorf("rate must not be negative, got: %g", opts.Rate)
+	}
+	base := strings.TrimRight(opts.Target, "/") + "/api/v1"
+
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{MaxIdleConnsPerHost: opts.Concurrency},
+	}
+
+	t, err := discover(ctx, client, base, opts.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	// Workers take a token per request when the rate is limited
+	var tokens <-chan time.Time
+	if opts.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.Rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	total := 0
+	for _, p := range patterns {
+		total += p.weight
+	}
+
+	results := make(chan result, opts.Concurrency)
+	var wg sync.WaitGroup
+	for w := range opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(opts.Seed, uint64(w)))
+			for {
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+
+				i := pick(rng, total)
+				path := patterns[i].path(rng, t)
+				if path == "" {
+					continue
+				}
+
+				started := time.Now()
+				err := get(ctx, client, base+path, opts.APIKey, nil)
+				// Requests cut off by the end of the replay aren't measured
+				if ctx.Err() != nil {
+					return
+				}
+				results <- result{pattern: i, latency: time.Since(started), failed: err != nil}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := &Report{
+		Users:    len(t.usernames),
+		Personas: len(t.personas),
+		Patterns: make([]*Stats, len(patterns)),
+	}
+	for i, p := range patterns {
+		report.Patterns[i] = &Stats{Name: p.name}
+	}
+
+	started := time.Now()
+	for r := range results {
+		s := report.Patterns[r.pattern]
+		s.Requests++
+		if r.failed {
+			s.Errors++
+		}
+		s.latencies = append(s.latencies, r.latency)
+	}
+	report.Elapsed = time.Since(started)
+
+	for _, s := range report.Patterns {
+		slices.Sort(s.latencies)
+	}
+	return report, nil
+}
+
+// pick picks a pattern by weight
+func pick(rng *rand.Rand, total int) int {
+	n := rng.IntN(total)
+	for i, p := range patterns {
+		if n < p.weight {
+			return i
+		}
+		n -= p.weight
+	}
+	return len(patterns) - 1
+}
+
+// discover lists the instance's users and personas for requests to pick from
+func discover(ctx context.Context, client *http.Client, base, apiKey string) (*targets, error) {
+	var users struct {
+		Data []struct {
+			Username string `json:"username"`
+		} `json:"data"`
+	}
+	if err := get(ctx, client, base+"/users?envelope=true&limit=1000", apiKey, &users); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var personas struct {
+		Data []struct {
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}
+	if err := get(ctx, client, base+"/personas?envelope=true", apiKey, &personas); err != nil {
+		return nil, fmt.Errorf("failed to list personas: %w", err)
+	}
+
+	t := &targets{}
+	for _, u := range users.Data {
+		t.usernames = append(t.usernames, u.Username)
+	}
+	for _, p := range personas.Data {
+		t.personas = append(t.personas, p.Slug)
+	}
+	if len(t.usernames) == 0 {
+		return nil, fmt.Errorf("the instance tracks no users, generate a database first")
+	}
+	return t, nil
+}
+
+// get requests a path, decoding the response into out when given and discarding it otherwise
+func get(ctx context.Context, client *http.Client, target, apiKey string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
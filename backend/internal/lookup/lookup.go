@@ -0,0 +1,209 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/sirupsen/logrus"
+)
+
+// addressPattern matches a Polymarket (EVM) wallet address
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// PnL sources
+const (
+	SourceOfficial  = "official"  // all-time PnL and volume from the Polymarket profile page
+	SourcePositions = "positions" // summed from the address's current positions only
+)
+
+// AddressPnl is the PnL of an arbitrary Polymarket address, computed on demand
+type AddressPnl struct {
+	Address       string
+	Name          *string // Polymarket profile name, when the address has a profile
+	ProfileImage  *string
+	TotalPnl      float64
+	RealizedPnl   float64
+	UnrealizedPnl float64
+	OpenPositions int
+	PositionValue float64  // current value of open positions
+	Volume        *float64 // all-time volume, only known from the official source
+	Source        string
+	ComputedAt    time.Time
+}
+
+// Service computes PnL for addresses that aren't tracked
+type Service interface {
+	// AddressPnl returns the PnL of an address, cached for the service's TTL
+	AddressPnl(ctx context.Context, address string) (*AddressPnl, error)
+}
+
+// ValidAddress reports whether address is a wallet address
+func ValidAddress(address string) bool {
+	return addressPattern.MatchString(address)
+}
+
+// ErrInvalidAddress is returned for strings that aren't wallet addresses
+var ErrInvalidAddress = errors.New("invalid address")
+
+// service implements Service with a TTL cache in front of the Polymarket API
+type service struct {
+	client       polymarket.Client
+	ttl          time.Duration
+	cacheEntries int
+	log          logrus.FieldLogger
+
+	mu       sync.Mutex
+	cache    map[string]*AddressPnl
+	inflight map[string]*call
+}
+
+// call is a lookup in progress, shared by concurrent requests for the same address
+type call struct {
+	done   chan struct{}
+	result *AddressPnl
+	err    error
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates an address lookup service keeping up to cacheEntries results for ttl
+func NewService(client polymarket.Client, ttl time.Duration, cacheEntries int, log logrus.FieldLogger) Service {
+	return &service{
+		client:       client,
+		ttl:          ttl,
+		cacheEntries: cacheEntries,
+		log:          log.WithField("package", "lookup"),
+		cache:        make(map[string]*AddressPnl),
+		inflight:     make(map[string]*call),
+	}
+}
+
+// AddressPnl returns the PnL of an address
+func (s *service) AddressPnl(ctx context.Context, address string) (*AddressPnl, error) {
+	if !ValidAddress(address) {
+		return nil, ErrInvalidAddress
+	}
+	address = strings.ToLower(address)
+
+	s.mu.Lock()
+	if cached, ok := s.cache[address]; ok && time.Since(cached.ComputedAt) < s.ttl {
+		s.mu.Unlock()
+		return cached, nil
+	}
+	if c, ok := s.inflight[address]; ok {
+		s.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.result, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := &call{done: make(chan struct{})}
+	s.inflight[address] = c
+	s.mu.Unlock()
+
+	// Detached from the request so a cancelled caller doesn't fail the others waiting on it
+	c.result, c.err = s.compute(context.WithoutCancel(ctx), address)
+
+	s.mu.Lock()
+	delete(s.inflight, address)
+	if c.err == nil {
+		s.store(address, c.result)
+	}
+	s.mu.Unlock()
+	close(c.done)
+
+	return c.result, c.err
+}
+
+// store caches a result, making room by dropping expired entries and then the oldest.
+// Callers hold s.mu.
+func (s *service) store(address string, result *AddressPnl) {
+	if s.cacheEntries <= 0 {
+		return
+	}
+
+	if len(s.cache) >= s.cacheEntries {
+		var oldest string
+		for addr, cached := range s.cache {
+			if time.Since(cached.ComputedAt) >= s.ttl {
+				delete(s.cache, addr)
+				continue
+			}
+			if oldest == "" || cached.ComputedAt.Before(s.cache[oldest].ComputedAt) {
+				oldest = addr
+			}
+		}
+		if len(s.cache) >= s.cacheEntries && oldest != "" {
+			delete(s.cache, oldest)
+		}
+	}
+
+	s.cache[address] = result
+}
+
+// compute fetches an address's positions and, when it has a profile, its official stats
+func (s *service) compute(ctx context.Context, address string) (*AddressPnl, error) {
+	positions, err := s.client.GetPositions(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AddressPnl{
+		Address:    address,
+		Source:     SourcePositions,
+		ComputedAt: time.Now().UTC(),
+	}
+
+	var positionsRealized float64
+	for _, pos := range positions {
+		if pos.Size != nil && *pos.Size > 0 {
+			result.OpenPositions++
+		}
+		if pos.UnrealizedPnl != nil {
+			result.UnrealizedPnl += *pos.UnrealizedPnl
+		}
+		if pos.RealizedPnl != nil {
+			positionsRealized += *pos.RealizedPnl
+		}
+		if pos.CurrentValue != nil {
+			result.PositionValue += *pos.CurrentValue
+		}
+	}
+	result.RealizedPnl = positionsRealized
+	result.TotalPnl = positionsRealized + result.UnrealizedPnl
+
+	profile, err := s.client.GetUserProfile(ctx, address)
+	if err != nil {
+		s.log.WithError(err).WithField("address", address).Debug("failed to fetch profile")
+	}
+	if profile == nil || profile.Name == "" {
+		return result, nil
+	}
+
+	result.Name = &profile.Name
+	if profile.ProfileImage != "" {
+		result.ProfileImage = &profile.ProfileImage
+	}
+
+	stats, err := s.client.GetPortfolioStats(ctx, profile.Name, address)
+	if err != nil {
+		s.log.WithError(err).WithField("address", address).Debug("failed to fetch portfolio stats")
+		return result, nil
+	}
+	if stats != nil {
+		// Official PnL is the total (realized + unrealized), as for tracked users
+		result.TotalPnl = stats.TotalPnl
+		result.RealizedPnl = stats.TotalPnl - result.UnrealizedPnl
+		result.Volume = &stats.TotalVolume
+		result.Source = SourceOfficial
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package milestones
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// Milestone kinds
+const (
+	AllTimeHigh   = "all_time_high"
+	RoundNumber   = "round_number"
+	WinningStreak = "winning_streak"
+)
+
+const (
+	// athMinimum is the PnL below which new highs aren't milestones, so a new account's first
+	// few dollars don't each count
+	athMinimum = 100.0
+	dateFormat = "2006-01-02"
+)
+
+var (
+	// roundNumbers are the total PnL levels whose first crossing, up or down, is a milestone
+	roundNumbers = []float64{1000, 5000, 10000, 25000, 50000, 100000, 250000, 500000, 1000000}
+	// streakLengths are the runs of consecutive days with rising PnL that are milestones
+	streakLengths = []int{5, 10, 30}
+)
+
+// Detect finds the milestones in a PnL history, oldest first. snapshots must be ordered
+// oldest first.
+//
+// An all-time high is recorded at most once per UTC day, at the day's highest PnL. Winning
+// streaks count UTC days whose last snapshot is above the previous day's, so the current
+// day is left out until it is over.
+func Detect(snapshots []*storage.PnlSnapshot, now time.Time) []*storage.UserMilestone {
+	var (
+		found   []*storage.UserMilestone
+		peak    = math.Inf(-1)
+		lastATH *storage.UserMilestone
+		crossed = make(map[string]bool)
+	)
+
+	for _, snap := range snapshots {
+		if snap.TotalPnl == nil {
+			continue
+		}
+		pnl := *snap.TotalPnl
+		day := snap.Timestamp.UTC().Format(dateFormat)
+
+		if pnl > peak {
+			if pnl >= athMinimum {
+				if lastATH != nil && lastATH.Key == day {
+					lastATH.OccurredAt = snap.Timestamp
+					lastATH.Value = pnl
+				} else {
+					lastATH = &storage.UserMilestone{Kind: AllTimeHigh, Key: day, OccurredAt: snap.Timestamp, Value: pnl}
+					found = append(found, lastATH)
+				}
+			}
+			peak = pnl
+		}
+
+		for _, level := range roundNumbers {
+			for _, target := range []float64{level, -level} {
+				key := fmt.Sprintf("%.0f", target)
+				if crossed[key] || (target > 0 && pnl < target) || (target < 0 && pnl > target) {
+					continue
+				}
+				crossed[key] = true
+				found = append(found, &storage.UserMilestone{Kind: RoundNumber, Key: key, OccurredAt: snap.Timestamp, Value: target})
+			}
+		}
+	}
+
+	return append(found, detectStreaks(snapshots, now)...)
+}
+
+// dailyClose is the last PnL of a UTC day
+type dailyClose struct {
+	day  time.Time
+	at   time.Time
+	pnl  float64
+	open float64 // previous day's close
+}
+
+// detectStreaks finds runs of consecutive days closing above the day before
+func detectStreaks(snapshots []*storage.PnlSnapshot, now time.Time) []*storage.UserMilestone {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	var closes []dailyClose
+	for _, snap := range snapshots {
+		if snap.TotalPnl == nil {
+			continue
+		}
+		day := snap.Timestamp.UTC().Truncate(24 * time.Hour)
+		if !day.Before(today) {
+			break
+		}
+		if n := len(closes); n > 0 && closes[n-1].day.Equal(day) {
+			closes[n-1].at = snap.Timestamp
+			closes[n-1].pnl = *snap.TotalPnl
+			continue
+		}
+		closes = append(closes, dailyClose{day: day, at: snap.Timestamp, pnl: *snap.TotalPnl})
+	}
+
+	var found []*storage.UserMilestone
+	streak := 0
+	var start dailyClose
+	for i := 1; i < len(closes); i++ {
+		prev, cur := closes[i-1], closes[i]
+		// A day without snapshots breaks the streak, its change can't be told apart
+		if !cur.day.Equal(prev.day.Add(24*time.Hour)) || cur.pnl <= prev.pnl {
+			streak = 0
+			continue
+		}
+
+		if streak == 0 {
+			start = cur
+			start.open = prev.pnl
+		}
+		streak++
+
+		for _, length := range streakLengths {
+			if streak != length {
+				continue
+			}
+			detail := fmt.Sprintf("%s to %s, PnL $%.2f to $%.2f",
+				start.day.Format(dateFormat), cur.day.Format(dateFormat), start.open, cur.pnl)
+			found = append(found, &storage.UserMilestone{
+				Kind:       WinningStreak,
+				Key:        fmt.Sprintf("%d:%s", length, start.day.Format(dateFormat)),
+				OccurredAt: cur.at,
+				Value:      float64(length),
+				Detail:     &detail,
+			})
+		}
+	}
+
+	return found
+}
+
+// Title describes a milestone in a sentence
+func Title(m *storage.UserMilestone) string {
+	switch m.Kind {
+	case AllTimeHigh:
+		return fmt.Sprintf("New all-time high of $%.2f", m.Value)
+	case RoundNumber:
+		if m.Value < 0 {
+			return fmt.Sprintf("Total PnL fell to -$%.0f", -m.Value)
+		}
+		return fmt.Sprintf("Total PnL reached $%.0f", m.Value)
+	case WinningStreak:
+		return fmt.Sprintf("%.0f-day winning streak", m.Value)
+	default:
+		return m.Kind
+	}
+}
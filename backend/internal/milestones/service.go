@@ -0,0 +1,93 @@
+package milestones
+
+import (
+	"context"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// announceWindow limits announcements to milestones reached recently, so evaluating a
+// backfilled or newly tracked history doesn't announce every milestone in it
+const announceWindow = 24 * time.Hour
+
+// Service records users' PnL milestones as their histories grow
+type Service interface {
+	// Start evaluates a user's milestones each time the user is synced
+	Start(ctx context.Context) error
+	Stop() error
+	// Evaluate records the milestones in a user's PnL history, returning the new ones
+	Evaluate(ctx context.Context, userID int64) ([]*storage.UserMilestone, error)
+}
+
+// service implements the milestone Service
+type service struct {
+	storage     storage.Storage
+	bus         events.Bus
+	log         logrus.FieldLogger
+	unsubscribe func()
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new milestone service. New milestones reached recently are announced
+// on the bus.
+func NewService(storage storage.Storage, bus events.Bus, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		bus:     bus,
+		log:     log.WithField("package", "milestones"),
+	}
+}
+
+// Start subscribes to user syncs
+func (s *service) Start(_ context.Context) error {
+	s.unsubscribe = s.bus.Subscribe("milestones", func(ctx context.Context, event events.Event) {
+		if _, err := s.Evaluate(ctx, event.UserID); err != nil {
+			s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to evaluate milestones")
+		}
+	}, events.UserSynced)
+
+	return nil
+}
+
+// Stop unsubscribes from user syncs
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// Evaluate records the milestones in a user's PnL history
+func (s *service) Evaluate(ctx context.Context, userID int64) ([]*storage.UserMilestone, error) {
+	snapshots, err := s.storage.GetUserPnlHistory(ctx, userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	added, err := s.storage.SaveUserMilestones(ctx, userID, Detect(snapshots, now))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range added {
+		log := s.log.WithFields(logrus.Fields{
+			"user_id":   userID,
+			"milestone": m.Kind,
+			"key":       m.Key,
+		})
+		if now.Sub(m.OccurredAt) > announceWindow {
+			log.Debug("recorded past milestone")
+			continue
+		}
+
+		log.Info("milestone reached")
+		s.bus.Publish(ctx, events.Event{Type: events.MilestoneReached, UserID: userID, Milestone: m})
+	}
+
+	return added, nil
+}
@@ -0,0 +1,224 @@
+package netting
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// redemptionValue is what one share of each outcome of a binary market pays out together,
+// whichever outcome wins
+const redemptionValue = 1.0
+
+// sizeEpsilon absorbs float noise when both sides hold the same number of shares
+const sizeEpsilon = 1e-9
+
+// Position is the net holding in one market after pairing off shares of its two outcomes.
+// Buying NO is economically close to selling YES: a share of each redeems for $1 whatever
+// the result, so only the shares left over on the larger side carry any risk.
+type Position struct {
+	ConditionID  string
+	MarketTitle  *string
+	MarketSlug   *string
+	Outcome      string   // side of the net shares, empty when fully hedged
+	Size         float64  // net shares on Outcome
+	AvgPrice     *float64 // effective entry price of the net shares, nil when fully hedged
+	CurrentPrice *float64 // of Outcome
+	HedgedSize   float64  // shares held on both sides, each pair locks in redemptionValue
+	CostBasis    float64  // paid for both sides
+	// CurrentValue is the value of the net shares, the market's exposure. Hedged pairs are
+	// left out: they pay out the same whatever the result.
+	CurrentValue float64
+	// UnrealizedPnl values hedged pairs at redemption rather than at market prices, which
+	// rarely sum to exactly $1
+	UnrealizedPnl float64
+	EndDate       *time.Time
+	Legs          int // positions combined
+}
+
+// LockedValue is what the hedged pairs redeem for
+func (p *Position) LockedValue() float64 {
+	return p.HedgedSize * redemptionValue
+}
+
+// side is the combined holding of one outcome of a market
+type side struct {
+	outcome      string
+	size         float64
+	costBasis    float64
+	currentValue float64
+	legs         int
+	first        *storage.Position
+}
+
+// price returns the side's current price per share, or nil when it has no shares
+func (s *side) price() *float64 {
+	if s.first.CurrentPrice != nil {
+		return s.first.CurrentPrice
+	}
+	if s.size <= 0 {
+		return nil
+	}
+	price := s.currentValue / s.size
+	return &price
+}
+
+// Net pairs off the shares of complementary outcomes in each market. Positions in the same
+// market and outcome, e.g. across a persona's accounts, are combined first. Markets with more
+// than two outcomes can't be paired and keep one net position per outcome. The result is
+// ordered by exposure, largest first.
+func Net(positions []*storage.Position) []*Position {
+	order, markets := groupMarkets(positions)
+
+	netted := make([]*Position, 0, len(order))
+	for _, conditionID := range order {
+		sides := markets[conditionID]
+		if len(sides) != 2 {
+			for _, s := range sides {
+				netted = append(netted, single(s))
+			}
+			continue
+		}
+
+		netted = append(netted, pair(sides[0], sides[1]))
+	}
+
+	sort.SliceStable(netted, func(i, j int) bool {
+		return netted[i].CurrentValue > netted[j].CurrentValue
+	})
+
+	return netted
+}
+
+// groupMarkets combines positions into one side per market and outcome, returning the
+// markets in the order they were first seen
+func groupMarkets(positions []*storage.Position) ([]string, map[string][]*side) {
+	markets := make(map[string][]*side)
+	order := make([]string, 0)
+	for _, pos := range positions {
+		outcome := ""
+		if pos.Outcome != nil {
+			outcome = *pos.Outcome
+		}
+
+		sides, ok := markets[pos.ConditionID]
+		if !ok {
+			order = append(order, pos.ConditionID)
+		}
+
+		var match *side
+		for _, s := range sides {
+			if s.outcome == outcome {
+				match = s
+				break
+			}
+		}
+		if match == nil {
+			match = &side{outcome: outcome, first: pos}
+			markets[pos.ConditionID] = append(sides, match)
+		}
+
+		size := valueOr(pos.Size)
+		match.legs++
+		match.size += size
+		match.costBasis += initialValue(pos, size)
+		match.currentValue += currentValue(pos, size)
+	}
+
+	return order, markets
+}
+
+// Totals sums the open markets, exposure and unrealized PnL of net positions. Fully hedged
+// markets don't count as open.
+func Totals(positions []*Position) (open int, exposure, unrealizedPnl float64) {
+	for _, pos := range positions {
+		if pos.Size > sizeEpsilon {
+			open++
+		}
+		exposure += pos.CurrentValue
+		unrealizedPnl += pos.UnrealizedPnl
+	}
+
+	return open, exposure, unrealizedPnl
+}
+
+// single is an outcome's holding with nothing to pair it with
+func single(s *side) *Position {
+	pos := newPosition(s.first, s.legs)
+	pos.Outcome = s.outcome
+	pos.Size = s.size
+	pos.CurrentPrice = s.price()
+	pos.CostBasis = s.costBasis
+	pos.CurrentValue = s.currentValue
+	pos.UnrealizedPnl = s.currentValue - s.costBasis
+	if s.size > sizeEpsilon {
+		avg := s.costBasis / s.size
+		pos.AvgPrice = &avg
+	}
+
+	return pos
+}
+
+// pair nets the two outcomes of a binary market against each other
+func pair(a, b *side) *Position {
+	long, short := a, b
+	if b.size > a.size {
+		long, short = b, a
+	}
+
+	pos := newPosition(long.first, a.legs+b.legs)
+	pos.HedgedSize = short.size
+	pos.CostBasis = long.costBasis + short.costBasis
+
+	if net := long.size - short.size; net > sizeEpsilon {
+		pos.Outcome = long.outcome
+		pos.Size = net
+		pos.CurrentPrice = long.price()
+		pos.CurrentValue = long.currentValue * net / long.size
+
+		// What the net shares cost once the locked in redemption is credited back
+		avg := (pos.CostBasis - pos.LockedValue()) / net
+		pos.AvgPrice = &avg
+	}
+
+	pos.UnrealizedPnl = pos.CurrentValue + pos.LockedValue() - pos.CostBasis
+
+	return pos
+}
+
+// newPosition starts a net position with the market details of pos
+func newPosition(pos *storage.Position, legs int) *Position {
+	return &Position{
+		ConditionID: pos.ConditionID,
+		MarketTitle: pos.MarketTitle,
+		MarketSlug:  pos.MarketSlug,
+		EndDate:     pos.EndDate,
+		Legs:        legs,
+	}
+}
+
+// initialValue returns what was paid for a position, falling back to its size at the average price
+func initialValue(pos *storage.Position, size float64) float64 {
+	if pos.InitialValue != nil {
+		return *pos.InitialValue
+	}
+	return size * valueOr(pos.AvgPrice)
+}
+
+// currentValue returns what a position is worth, falling back to its size at the current price
+func currentValue(pos *storage.Position, size float64) float64 {
+	if pos.CurrentValue != nil {
+		return *pos.CurrentValue
+	}
+	return size * valueOr(pos.CurrentPrice)
+}
+
+// valueOr returns the value of v, or 0 when it is nil or not a number
+func valueOr(v *float64) float64 {
+	if v == nil || math.IsNaN(*v) {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,64 @@
+package netting
+
+import (
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// ResolutionPnl returns what a position makes if its outcome wins, paying out $1 a share, and
+// if it loses, relative to what it cost
+func ResolutionPnl(pos *storage.Position) (ifWins, ifLoses float64) {
+	size := valueOr(pos.Size)
+	cost := initialValue(pos, size)
+
+	return size*redemptionValue - cost, -cost
+}
+
+// Scenarios returns the best and worst case PnL of positions at resolution, relative to what
+// they cost. Each market resolves to whichever outcome is best, or worst, for the holder;
+// shares held on both outcomes of a market pay out whichever wins. Markets priced at 0 or 1
+// have already resolved and count as they did.
+func Scenarios(positions []*storage.Position) (best, worst float64) {
+	order, markets := groupMarkets(positions)
+	for _, conditionID := range order {
+		marketBest, marketWorst := marketScenarios(markets[conditionID])
+		best += marketBest
+		worst += marketWorst
+	}
+
+	return best, worst
+}
+
+// marketScenarios returns the best and worst case PnL of the outcomes held in one market
+func marketScenarios(sides []*side) (best, worst float64) {
+	var cost float64
+	for _, s := range sides {
+		cost += s.costBasis
+	}
+
+	// What the market pays out for each way it can still resolve
+	payouts := make([]float64, 0, len(sides)+1)
+	for _, s := range sides {
+		price := s.price()
+		switch {
+		case price != nil && *price >= redemptionValue:
+			payout := s.size * redemptionValue
+			return payout - cost, payout - cost
+		case price != nil && *price <= 0:
+			continue
+		}
+		payouts = append(payouts, s.size*redemptionValue)
+	}
+
+	// With one outcome held, or every held outcome already lost, an outcome not held can win
+	if len(sides) < 2 || len(payouts) == 0 {
+		payouts = append(payouts, 0)
+	}
+
+	best, worst = payouts[0], payouts[0]
+	for _, payout := range payouts[1:] {
+		best = max(best, payout)
+		worst = min(worst, payout)
+	}
+
+	return best - cost, worst - cost
+}
@@ -0,0 +1,168 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRetryAfter caps how long a rate limited webhook post waits before its one retry
+	maxRetryAfter = 10 * time.Second
+	// maxEmbedTitle is the most characters Discord takes in an embed title, which templated
+	// headlines can run past
+	maxEmbedTitle = 256
+	// Colour bars of notification embeds by kind
+	colorTrade  = 0x3498db
+	colorOpened = 0x2ecc71
+	colorClosed = 0xe67e22
+)
+
+// discordSink posts notifications to a Discord channel webhook
+type discordSink struct {
+	webhookURL string
+	http       *http.Client
+}
+
+var _ Sink = (*discordSink)(nil)
+
+// NewDiscordSink creates a sink posting to a Discord channel webhook
+func NewDiscordSink(webhookURL string) Sink {
+	return &discordSink{
+		webhookURL: webhookURL,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// webhookMessage is the body of a Discord webhook execution
+type webhookMessage struct {
+	Embeds          []embed         `json:"embeds"`
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+// allowedMentions stops usernames and market titles from pinging anyone
+type allowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// embed is a Discord rich embed
+type embed struct {
+	Title       string       `json:"title"`
+	URL         string       `json:"url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color"`
+	Timestamp   string       `json:"timestamp"`
+	Fields      []embedField `json:"fields,omitempty"`
+}
+
+// embedField is a name and value shown in an embed
+type embedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// Name identifies the sink in logs
+func (d *discordSink) Name() string {
+	return "discord"
+}
+
+// Send posts a notification as an embed, retrying once when rate limited
+func (d *discordSink) Send(ctx context.Context, notification *Notification) error {
+	body, err := json.Marshal(webhookMessage{
+		Embeds:          []embed{toEmbed(notification)},
+		AllowedMentions: allowedMentions{Parse: []string{}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook message: %w", err)
+	}
+
+	retryAfter, err := d.post(ctx, body)
+	if err == nil || retryAfter == 0 {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(retryAfter):
+	}
+	_, err = d.post(ctx, body)
+	return err
+}
+
+// post executes the webhook, returning how long to wait when rate limited
+func (d *discordSink) post(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		var limited struct {
+			RetryAfter float64 `json:"retry_after"` // seconds
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&limited)
+		wait := min(max(time.Duration(limited.RetryAfter*float64(time.Second)), time.Second), maxRetryAfter)
+		return wait, fmt.Errorf("webhook rate limited")
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return 0, fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return 0, nil
+}
+
+// toEmbed lays a notification out as a Discord embed
+func toEmbed(n *Notification) embed {
+	e := embed{
+		Title:       truncate(n.Headline, maxEmbedTitle),
+		URL:         n.URL,
+		Description: n.Market,
+		Timestamp:   n.Time.UTC().Format(time.RFC3339),
+		Fields:      make([]embedField, 0, 3),
+	}
+
+	switch n.Kind {
+	case KindPositionOpened:
+		e.Color = colorOpened
+	case KindPositionClosed:
+		e.Color = colorClosed
+	default:
+		e.Color = colorTrade
+	}
+
+	if n.Price != nil {
+		e.Fields = append(e.Fields, embedField{Name: "Price", Value: fmt.Sprintf("%.0f¢", *n.Price*100), Inline: true})
+	}
+	if n.Pnl != nil {
+		e.Fields = append(e.Fields, embedField{Name: "Realized PnL", Value: formatUSD(*n.Pnl), Inline: true})
+	}
+	if n.Persona != "" {
+		e.Fields = append(e.Fields, embedField{Name: "Persona", Value: n.Persona, Inline: true})
+	}
+
+	return e
+}
+
+// truncate cuts a string to at most limit runes, ending it with an ellipsis when cut
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,442 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// directoryTTL is how long the user directory is used before it is reloaded
+	directoryTTL = time.Minute
+	// polymarketEventURL is where markets are linked to, followed by a slug
+	polymarketEventURL = "https://polymarket.com/event/"
+)
+
+var (
+	// ErrUnknownChannel is returned when previewing notifications of a channel that isn't set up
+	ErrUnknownChannel = errors.New("unknown notification channel")
+	// ErrInvalidTemplate is returned when a previewed template doesn't parse or render
+	ErrInvalidTemplate = errors.New("invalid notification template")
+)
+
+// Kind is what a notification announces
+type Kind string
+
+// Notification kinds
+const (
+	KindTrade          Kind = "trade"
+	KindPositionOpened Kind = "position_opened"
+	KindPositionClosed Kind = "position_closed"
+)
+
+// Notification is an alert about a tracked user's activity
+type Notification struct {
+	Kind     Kind
+	Time     time.Time
+	Username string
+	Persona  string // slug, empty when the user has no persona
+	Headline string // e.g. "alice bought $12,500 of Yes", or as laid out by the channel's template
+	Market   string // market title
+	Outcome  string
+	Side     string // buy or sell, empty for positions
+	URL      string // the market on Polymarket, empty when unknown
+	Value    float64
+	Price    *float64
+	Pnl      *float64 // realized PnL of a closed position
+}
+
+// Sink delivers notifications to where people read them
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, notification *Notification) error
+}
+
+// Thresholds are the smallest trade and position values announced, in USDC. nil inherits.
+type Thresholds struct {
+	MinTradeValue    *float64
+	MinPositionValue *float64
+}
+
+// Options configures the notification service
+type Options struct {
+	Trades          bool
+	PositionsOpened bool
+	PositionsClosed bool
+	MaxTradeAge     time.Duration // trades made longer ago when synced are history, not announced
+	Default         Thresholds    // both set
+	Personas        map[string]Thresholds
+	Markets         map[string]Thresholds // by condition ID or market slug, over persona thresholds
+	Templates       map[string]Templates  // by sink name
+}
+
+// Service sends notifications to sinks when tracked users make big trades or open and close
+// big positions
+type Service interface {
+	// Start subscribes to trade and position events
+	Start(ctx context.Context) error
+	Stop() error
+	// Preview lays out a sample notification of a kind as a channel would, with source in
+	// place of the channel's template when given, and posts it to the channel when send is set
+	Preview(ctx context.Context, channel string, kind Kind, source string, send bool) (*Notification, error)
+}
+
+// recipient is a user as seen by notifications
+type recipient struct {
+	username string
+	persona  string
+	ghost    bool
+}
+
+// service implements the notify Service
+type service struct {
+	storage     storage.Storage
+	bus         events.Bus
+	sinks       []Sink
+	opts        Options
+	log         logrus.FieldLogger
+	unsubscribe func()
+
+	mu       sync.Mutex
+	users    map[int64]*recipient
+	loadedAt time.Time
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new notification service delivering to sinks
+func NewService(storage storage.Storage, bus events.Bus, sinks []Sink, opts Options, log logrus.FieldLogger) Service {
+	personas := make(map[string]Thresholds, len(opts.Personas))
+	for slug, thresholds := range opts.Personas {
+		personas[strings.ToLower(slug)] = thresholds
+	}
+	markets := make(map[string]Thresholds, len(opts.Markets))
+	for key, thresholds := range opts.Markets {
+		markets[strings.ToLower(key)] = thresholds
+	}
+	opts.Personas, opts.Markets = personas, markets
+
+	return &service{
+		storage: storage,
+		bus:     bus,
+		sinks:   sinks,
+		opts:    opts,
+		log:     log.WithField("package", "notify"),
+	}
+}
+
+// Start subscribes to the events notifications are sent for
+func (s *service) Start(_ context.Context) error {
+	types := make([]events.Type, 0, 3)
+	if s.opts.Trades {
+		types = append(types, events.TradeIngested)
+	}
+	if s.opts.PositionsOpened {
+		types = append(types, events.PositionOpened)
+	}
+	if s.opts.PositionsClosed {
+		types = append(types, events.PositionClosed)
+	}
+	if len(types) == 0 {
+		s.log.Warn("notifications are enabled but no events are announced")
+		return nil
+	}
+
+	s.unsubscribe = s.bus.Subscribe("notify", s.handle, types...)
+
+	sinks := make([]string, len(s.sinks))
+	for i, sink := range s.sinks {
+		sinks[i] = sink.Name()
+	}
+	s.log.WithFields(logrus.Fields{
+		"sinks":              strings.Join(sinks, ","),
+		"min_trade_value":    *s.opts.Default.MinTradeValue,
+		"min_position_value": *s.opts.Default.MinPositionValue,
+	}).Info("notification service started")
+	return nil
+}
+
+// Stop unsubscribes from events
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// handle turns an event worth announcing into a notification and sends it to every sink
+func (s *service) handle(ctx context.Context, event events.Event) {
+	user, err := s.recipient(ctx, event.UserID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to get user for notification")
+		return
+	}
+	// Ghost users are hidden from public feeds, so they're never announced
+	if user == nil || user.ghost {
+		return
+	}
+
+	var notification *Notification
+	switch event.Type {
+	case events.TradeIngested:
+		notification = s.tradeNotification(user, event.Trade)
+	case events.PositionOpened, events.PositionClosed:
+		notification = s.positionNotification(user, event.Type, event.Position)
+	}
+	if notification == nil {
+		return
+	}
+	notification.Username = user.username
+	notification.Persona = user.persona
+	if notification.Time.IsZero() {
+		notification.Time = event.Time
+	}
+
+	for _, sink := range s.sinks {
+		log := s.log.WithFields(logrus.Fields{
+			"sink":     sink.Name(),
+			"kind":     notification.Kind,
+			"username": user.username,
+		})
+
+		// A template that fails to render, e.g. on a nil value, falls back to the built-in
+		// headline rather than losing the notification
+		laidOut := *notification
+		headline, err := s.opts.Templates[sink.Name()].Headline(notification)
+		if err != nil {
+			log.WithError(err).Warn("failed to render notification template")
+		} else {
+			laidOut.Headline = headline
+		}
+
+		if err := sink.Send(ctx, &laidOut); err != nil {
+			log.WithError(err).Error("failed to send notification")
+		}
+	}
+}
+
+// Preview lays out a sample notification as a channel would, and optionally sends it
+func (s *service) Preview(ctx context.Context, channel string, kind Kind, source string, send bool) (*Notification, error) {
+	var sink Sink
+	for _, candidate := range s.sinks {
+		if candidate.Name() == channel {
+			sink = candidate
+		}
+	}
+	if sink == nil {
+		return nil, ErrUnknownChannel
+	}
+
+	notification := SampleNotification(kind)
+
+	var (
+		headline string
+		err      error
+	)
+	if source != "" {
+		tmpl, parseErr := ParseTemplate(string(kind), source)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, parseErr)
+		}
+		headline, err = Render(tmpl, notification)
+	} else {
+		headline, err = s.opts.Templates[channel].Headline(notification)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	notification.Headline = headline
+
+	if send {
+		if err := sink.Send(ctx, notification); err != nil {
+			return nil, fmt.Errorf("failed to send notification: %w", err)
+		}
+	}
+	return notification, nil
+}
+
+// tradeNotification announces a recent trade worth at least the trade threshold
+func (s *service) tradeNotification(user *recipient, trade *storage.Trade) *Notification {
+	if trade == nil || trade.Value == nil || trade.Timestamp == nil {
+		return nil
+	}
+	if time.Since(*trade.Timestamp) > s.opts.MaxTradeAge {
+		return nil
+	}
+
+	threshold := s.thresholds(user.persona, stringOrEmpty(trade.ConditionID), stringOrEmpty(trade.MarketSlug)).MinTradeValue
+	if *trade.Value < *threshold {
+		return nil
+	}
+
+	verb := "traded"
+	switch strings.ToUpper(stringOrEmpty(trade.Side)) {
+	case "BUY":
+		verb = "bought"
+	case "SELL":
+		verb = "sold"
+	}
+
+	return &Notification{
+		Kind:     KindTrade,
+		Time:     *trade.Timestamp,
+		Headline: fmt.Sprintf("%s %s %s of %s", user.username, verb, formatUSD(*trade.Value), outcomeOrUnknown(trade.Outcome)),
+		Market:   trade.DisplayTitle(),
+		Outcome:  stringOrEmpty(trade.Outcome),
+		Side:     strings.ToLower(stringOrEmpty(trade.Side)),
+		URL:      marketURL(trade.EventSlug, trade.MarketSlug),
+		Value:    *trade.Value,
+		Price:    trade.Price,
+	}
+}
+
+// positionNotification announces an opened or closed position worth at least the position
+// threshold. Opened positions are valued at what they're worth now, closed ones at their cost.
+func (s *service) positionNotification(user *recipient, typ events.Type, pos *storage.Position) *Notification {
+	if pos == nil {
+		return nil
+	}
+
+	value := pos.InitialValue
+	if typ == events.PositionOpened && pos.CurrentValue != nil {
+		value = pos.CurrentValue
+	}
+	if value == nil {
+		return nil
+	}
+
+	threshold := s.thresholds(user.persona, pos.ConditionID, stringOrEmpty(pos.MarketSlug)).MinPositionValue
+	if *value < *threshold {
+		return nil
+	}
+
+	notification := &Notification{
+		Market:  pos.DisplayTitle(),
+		Outcome: stringOrEmpty(pos.Outcome),
+		URL:     marketURL(nil, pos.MarketSlug),
+		Value:   *value,
+		Price:   pos.CurrentPrice,
+	}
+	if typ == events.PositionOpened {
+		notification.Kind = KindPositionOpened
+		notification.Headline = fmt.Sprintf("%s opened a %s position in %s", user.username, formatUSD(*value), outcomeOrUnknown(pos.Outcome))
+	} else {
+		notification.Kind = KindPositionClosed
+		notification.Headline = fmt.Sprintf("%s closed a %s position in %s", user.username, formatUSD(*value), outcomeOrUnknown(pos.Outcome))
+		notification.Pnl = pos.RealizedPnl
+	}
+	return notification
+}
+
+// thresholds returns the thresholds for a user's activity in a market: the market's, then the
+// persona's, then the defaults
+func (s *service) thresholds(persona, conditionID, marketSlug string) Thresholds {
+	resolved := s.opts.Default
+	layers := []Thresholds{s.opts.Personas[persona]}
+	if conditionID != "" {
+		layers = append(layers, s.opts.Markets[strings.ToLower(conditionID)])
+	}
+	if marketSlug != "" {
+		layers = append(layers, s.opts.Markets[strings.ToLower(marketSlug)])
+	}
+	for _, layer := range layers {
+		if layer.MinTradeValue != nil {
+			resolved.MinTradeValue = layer.MinTradeValue
+		}
+		if layer.MinPositionValue != nil {
+			resolved.MinPositionValue = layer.MinPositionValue
+		}
+	}
+	return resolved
+}
+
+// recipient returns the user an event belongs to, or nil if the user is no longer tracked
+func (s *service) recipient(ctx context.Context, userID int64) (*recipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[userID]; ok && time.Since(s.loadedAt) < directoryTTL {
+		return user, nil
+	}
+
+	users, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	personas, err := s.storage.GetPersonas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := make(map[int64]*recipient, len(users))
+	for _, user := range users {
+		directory[user.ID] = &recipient{username: user.Username, ghost: user.Ghost}
+	}
+	for _, persona := range personas {
+		members, err := s.storage.GetPersonaUsers(ctx, persona.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range members {
+			if r, ok := directory[user.ID]; ok {
+				r.persona = persona.Slug
+			}
+		}
+	}
+
+	s.users = directory
+	s.loadedAt = time.Now()
+	return directory[userID], nil
+}
+
+// marketURL links to a market on Polymarket, through its event's slug when known
+func marketURL(eventSlug, marketSlug *string) string {
+	for _, slug := range []*string{eventSlug, marketSlug} {
+		if slug != nil && *slug != "" {
+			return polymarketEventURL + *slug
+		}
+	}
+	return ""
+}
+
+// formatUSD formats a dollar amount with thousands separators, e.g. $12,500
+func formatUSD(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	digits := fmt.Sprintf("%.0f", value)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + "$" + b.String()
+}
+
+// outcomeOrUnknown returns an outcome's name for a headline
+func outcomeOrUnknown(outcome *string) string {
+	if outcome == nil || *outcome == "" {
+		return "an unknown outcome"
+	}
+	return *outcome
+}
+
+// stringOrEmpty dereferences an optional string
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
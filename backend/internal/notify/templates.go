@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Template keys in config, by the kind of notification they lay out
+var templateKinds = map[string]Kind{
+	"trade":          KindTrade,
+	"positionOpened": KindPositionOpened,
+	"positionClosed": KindPositionClosed,
+}
+
+// templateFuncs are the functions notification templates can call besides Go's builtins
+var templateFuncs = template.FuncMap{
+	"usd":   formatUSD,
+	"cents": func(price float64) string { return fmt.Sprintf("%.0f¢", price*100) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// Templates replace the headlines of a channel's notifications, by kind. Kinds without one
+// keep the built-in headline.
+type Templates map[Kind]*template.Template
+
+// ParseTemplates parses the Go templates of a channel, keyed by trade, positionOpened or
+// positionClosed
+func ParseTemplates(sources map[string]string) (Templates, error) {
+	templates := make(Templates, len(sources))
+	for key, source := range sources {
+		kind, ok := ParseKind(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown notification template %q, must be trade, positionOpened or positionClosed", key)
+		}
+		tmpl, err := ParseTemplate(key, source)
+		if err != nil {
+			return nil, err
+		}
+		templates[kind] = tmpl
+	}
+	return templates, nil
+}
+
+// ParseTemplate parses a notification template, erroring on references to unknown fields
+// when it is executed rather than printing "<no value>"
+func ParseTemplate(name, source string) (*template.Template, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("notification template %s is empty", name)
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse notification template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// Headline returns the headline of a notification, from its kind's template when there is one
+func (t Templates) Headline(n *Notification) (string, error) {
+	tmpl, ok := t[n.Kind]
+	if !ok {
+		return n.Headline, nil
+	}
+	return Render(tmpl, n)
+}
+
+// Render executes a notification template with the notification's fields
+func Render(tmpl *template.Template, n *Notification) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, templateData(n)); err != nil {
+		return "", fmt.Errorf("failed to render notification template %s: %w", tmpl.Name(), err)
+	}
+
+	headline := strings.Join(strings.Fields(b.String()), " ")
+	if headline == "" {
+		return "", fmt.Errorf("notification template %s rendered nothing", tmpl.Name())
+	}
+	return headline, nil
+}
+
+// templateData is what templates see of a notification. Keys are always present so that
+// templates can test optional ones, which are nil when unknown, with if or with.
+func templateData(n *Notification) map[string]any {
+	data := map[string]any{
+		"kind":     string(n.Kind),
+		"username": n.Username,
+		"persona":  n.Persona,
+		"market":   n.Market,
+		"outcome":  n.Outcome,
+		"side":     n.Side,
+		"value":    n.Value,
+		"price":    nil,
+		"pnl":      nil,
+		"url":      n.URL,
+		"headline": n.Headline,
+		"time":     n.Time,
+	}
+	if n.Price != nil {
+		data["price"] = *n.Price
+	}
+	if n.Pnl != nil {
+		data["pnl"] = *n.Pnl
+	}
+	return data
+}
+
+// SampleNotification is a made-up notification of a kind, for previewing templates
+func SampleNotification(kind Kind) *Notification {
+	price := 0.62
+	n := &Notification{
+		Kind:     kind,
+		Time:     time.Now().UTC().Truncate(time.Second),
+		Username: "alice",
+		Persona:  "whales",
+		Market:   "Will it rain in London tomorrow?",
+		Outcome:  "Yes",
+		URL:      polymarketEventURL + "will-it-rain-in-london-tomorrow",
+		Value:    12500,
+		Price:    &price,
+	}
+
+	switch kind {
+	case KindPositionOpened:
+		n.Headline = "alice opened a $12,500 position in Yes"
+	case KindPositionClosed:
+		pnl := 3400.0
+		n.Headline = "alice closed a $12,500 position in Yes"
+		n.Pnl = &pnl
+	default:
+		n.Side = "buy"
+		n.Headline = "alice bought $12,500 of Yes"
+	}
+	return n
+}
+
+// ParseKind parses the kind of a notification, as named by templates or the API. Case is
+// ignored, config keys come lowercased.
+func ParseKind(s string) (Kind, bool) {
+	for key, kind := range templateKinds {
+		if strings.EqualFold(key, s) || strings.EqualFold(string(kind), s) {
+			return kind, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+package polymarket
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// snapshotBook stores the current order book of the traded outcome with a newly synced trade.
+// Books are fetched once per token per address sync, through books. A failure only loses the
+// snapshot.
+func (s *service) snapshotBook(ctx context.Context, trade *storage.Trade, tokenID string, books map[string]*OrderBookResponse) {
+	book, ok := books[tokenID]
+	if !ok {
+		var err error
+		book, err = s.client.GetOrderBook(ctx, tokenID)
+		if err != nil {
+			s.log.WithError(err).WithField("token_id", tokenID).Debug("failed to fetch order book")
+			return
+		}
+		books[tokenID] = book
+	}
+
+	applyBook(trade, book, time.Now())
+	if trade.BookBestBid == nil && trade.BookBestAsk == nil {
+		return
+	}
+
+	if err := s.storage.UpdateTradeBook(ctx, trade); err != nil {
+		s.log.WithError(err).WithField("trade_id", trade.ID).Warn("failed to store trade order book")
+		return
+	}
+	s.countWrites(1)
+}
+
+// applyBook sets the best bid and ask of an order book on a trade, and their midpoint when the
+// book has both sides
+func applyBook(trade *storage.Trade, book *OrderBookResponse, capturedAt time.Time) {
+	trade.BookBestBid = bestPrice(book.Bids, func(a, b float64) bool { return a > b })
+	trade.BookBestAsk = bestPrice(book.Asks, func(a, b float64) bool { return a < b })
+	trade.BookMidpoint = nil
+	if trade.BookBestBid != nil && trade.BookBestAsk != nil {
+		midpoint := (*trade.BookBestBid + *trade.BookBestAsk) / 2
+		trade.BookMidpoint = &midpoint
+	}
+	trade.BookCapturedAt = &capturedAt
+}
+
+// bestPrice returns the best price of a side of a book, the CLOB API doesn't promise an order
+func bestPrice(levels []OrderBookLevel, better func(a, b float64) bool) *float64 {
+	var best *float64
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level.Price, 64)
+		if err != nil {
+			continue
+		}
+		if best == nil || better(price, *best) {
+			best = &price
+		}
+	}
+	return best
+}
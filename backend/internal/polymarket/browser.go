@@ -0,0 +1,108 @@
+package polymarket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/sirupsen/logrus"
+)
+
+// browserPollInterval is how often the rendered page is checked for portfolio stats
+const browserPollInterval = 500 * time.Millisecond
+
+// BrowserOptions configures the headless Chrome fallback used to read portfolio stats
+// when the profile page can't be scraped from its HTML
+type BrowserOptions struct {
+	Enabled       bool
+	ExecPath      string        // Chrome or Chromium binary, looked up on PATH when empty
+	Timeout       time.Duration // limit on loading a profile and extracting its stats
+	MaxConcurrent int           // browsers running at once
+	MaxHeapMB     int           // JavaScript heap limit per browser
+	NoSandbox     bool          // disable the Chrome sandbox, required when running as root in a container
+}
+
+// browserScraper reads portfolio stats from a profile page rendered by headless Chrome.
+// Each scrape runs in its own short-lived browser so a hung page can't leak into later syncs.
+type browserScraper struct {
+	opts BrowserOptions
+	sem  chan struct{}
+	log  logrus.FieldLogger
+}
+
+func newBrowserScraper(opts BrowserOptions, log logrus.FieldLogger) *browserScraper {
+	return &browserScraper{
+		opts: opts,
+		sem:  make(chan struct{}, max(opts.MaxConcurrent, 1)),
+		log:  log.WithField("provider", "browser"),
+	}
+}
+
+// GetPortfolioStats renders a user's profile page and extracts the portfolio stats once the
+// page's scripts have loaded them
+func (b *browserScraper) GetPortfolioStats(ctx context.Context, username, address string) (*PortfolioStats, error) {
+	select {
+	case b.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-b.sem }()
+
+	ctx, cancel := context.WithTimeout(ctx, b.opts.Timeout)
+	defer cancel()
+
+	allocOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent("Mozilla/5.0 (compatible; pyre/1.0)"),
+		chromedp.Flag("blink-settings", "imagesEnabled=false"),
+		chromedp.Flag("disable-extensions", true),
+		chromedp.Flag("js-flags", fmt.Sprintf("--max-old-space-size=%d", b.opts.MaxHeapMB)),
+	)
+	if b.opts.ExecPath != "" {
+		allocOpts = append(allocOpts, chromedp.ExecPath(b.opts.ExecPath))
+	}
+	if b.opts.NoSandbox {
+		allocOpts = append(allocOpts, chromedp.NoSandbox)
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, allocOpts...)
+	defer cancelAlloc()
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	profileURL := fmt.Sprintf("https://polymarket.com/profile/@%s", username)
+	if err := chromedp.Run(browserCtx, chromedp.Navigate(profileURL), chromedp.WaitReady("body")); err != nil {
+		return nil, fmt.Errorf("failed to load profile page in browser: %w", err)
+	}
+
+	// Stats are fetched by the page's scripts after load, so poll until they appear
+	ticker := time.NewTicker(browserPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var content string
+		if err := chromedp.Run(browserCtx, chromedp.OuterHTML("html", &content)); err != nil {
+			return nil, fmt.Errorf("failed to read rendered profile page: %w", err)
+		}
+
+		stats, err := parsePortfolioStats(content, address)
+		if err != nil {
+			return nil, err
+		}
+		if stats != nil {
+			b.log.WithFields(logrus.Fields{
+				"username": username,
+				"pnl":      stats.TotalPnl,
+				"volume":   stats.TotalVolume,
+			}).Debug("fetched portfolio stats with headless browser")
+			return stats, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no PnL data in rendered profile page: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
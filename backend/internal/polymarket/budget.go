@@ -0,0 +1,51 @@
+package polymarket
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrBudgetExhausted is returned for API calls made after the sync cycle's call budget is spent
+var ErrBudgetExhausted = errors.New("api call budget for this sync cycle exhausted")
+
+// callBudget limits the API calls made in one sync cycle
+type callBudget struct {
+	limit     int64 // calls per cycle, 0 for unlimited
+	remaining atomic.Int64
+	made      atomic.Int64 // calls made in the cycle
+}
+
+func newCallBudget(limit int) *callBudget {
+	b := &callBudget{limit: int64(limit)}
+	b.remaining.Store(b.limit)
+	return b
+}
+
+// reset restores the full budget at the start of a cycle
+func (b *callBudget) reset() {
+	b.remaining.Store(b.limit)
+	b.made.Store(0)
+}
+
+// spend takes one call from the budget, failing once it is spent
+func (b *callBudget) spend() error {
+	if b.limit > 0 && b.remaining.Add(-1) < 0 {
+		b.remaining.Store(0)
+		return ErrBudgetExhausted
+	}
+	b.made.Add(1)
+	return nil
+}
+
+// left reports the calls remaining in the cycle, and false when the budget is unlimited
+func (b *callBudget) left() (int, bool) {
+	if b.limit <= 0 {
+		return 0, false
+	}
+	return int(b.remaining.Load()), true
+}
+
+// calls reports the calls made in the cycle
+func (b *callBudget) calls() int {
+	return int(b.made.Load())
+}
@@ -0,0 +1,107 @@
+package polymarket
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCallBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		spend     int
+		wantSpent int // spends that succeed
+		wantLeft  int
+		limited   bool
+	}{
+		{name: "unlimited never runs out", limit: 0, spend: 1000, wantSpent: 1000, limited: false},
+		{name: "spends count down", limit: 10, spend: 3, wantSpent: 3, wantLeft: 7, limited: true},
+		{name: "spending the whole budget", limit: 5, spend: 5, wantSpent: 5, wantLeft: 0, limited: true},
+		{name: "spends past the budget fail", limit: 5, spend: 8, wantSpent: 5, wantLeft: 0, limited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newCallBudget(tt.limit)
+
+			spent := 0
+			for range tt.spend {
+				err := b.spend()
+				if err == nil {
+					spent++
+				} else if !errors.Is(err, ErrBudgetExhausted) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if spent != tt.wantSpent {
+				t.Errorf("got %d spends, want %d", spent, tt.wantSpent)
+			}
+			if calls := b.calls(); calls != tt.wantSpent {
+				t.Errorf("got %d calls made, want %d", calls, tt.wantSpent)
+			}
+			if left, limited := b.left(); left != tt.wantLeft || limited != tt.limited {
+				t.Errorf("got %d left, limited %v, want %d, %v", left, limited, tt.wantLeft, tt.limited)
+			}
+
+			b.reset()
+			if left, _ := b.left(); left != tt.limit {
+				t.Errorf("got %d left after reset, want %d", left, tt.limit)
+			}
+			if calls := b.calls(); calls != 0 {
+				t.Errorf("got %d calls made after reset, want 0", calls)
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	users := map[string][]string{
+		"alice": {"0x1"},
+		"bob":   {"0x2"},
+		"carol": {"0x3"},
+		"dave":  {"0x4"},
+	}
+
+	tests := []struct {
+		name     string
+		users    map[string][]string
+		nextUser string
+		want     []string
+	}{
+		{name: "no deferred user starts at the first", users: users, want: []string{"alice", "bob", "carol", "dave"}},
+		{name: "starts with the deferred user and wraps around", users: users, nextUser: "carol", want: []string{"carol", "dave", "alice", "bob"}},
+		{name: "the last user deferred goes first", users: users, nextUser: "dave", want: []string{"dave", "alice", "bob", "carol"}},
+		{name: "a removed deferred user is followed by the next", users: users, nextUser: "bryce", want: []string{"carol", "dave", "alice", "bob"}},
+		{name: "a removed deferred user past the last wraps to the first", users: users, nextUser: "zed", want: []string{"alice", "bob", "carol", "dave"}},
+		{name: "no users", users: map[string][]string{}, nextUser: "carol", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{nextUser: tt.nextUser}
+			if got := s.rotation(tt.users); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateCalls(t *testing.T) {
+	tests := []struct {
+		addresses int
+		want      int
+	}{
+		{addresses: 0, want: 3},
+		{addresses: 1, want: 5},
+		{addresses: 2, want: 9},
+		{addresses: 5, want: 21},
+	}
+
+	for _, tt := range tests {
+		if got := estimateCalls(tt.addresses); got != tt.want {
+			t.Errorf("estimateCalls(%d) = %d, want %d", tt.addresses, got, tt.want)
+		}
+	}
+}
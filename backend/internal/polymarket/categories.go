@@ -0,0 +1,82 @@
+package polymarket
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// categorizeBatch is the most markets categorized per sync cycle
+const categorizeBatch = 5 * marketsPageSize
+
+// categorizeMarkets stores the categories of markets traded by tracked users that don't have
+// one yet and aren't cached in markets, whose gamma category is used instead. Categories come
+// from the gamma API, and markets gamma has none for are classified from their title. Like the global leaderboard, it runs after the users of a cycle on
+// whatever is left of the call budget.
+func (s *service) categorizeMarkets(ctx context.Context) {
+	markets, err := s.storage.GetUncategorizedMarkets(ctx, categorizeBatch)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to get uncategorized markets")
+		return
+	}
+	if len(markets) == 0 {
+		return
+	}
+
+	pages := (len(markets) + marketsPageSize - 1) / marketsPageSize
+	if remaining, limited := s.client.BudgetRemaining(); limited && remaining < pages {
+		s.log.WithFields(logrus.Fields{
+			"pages":     pages,
+			"remaining": remaining,
+		}).Debug("deferring market categorization, not enough api call budget left")
+		return
+	}
+
+	conditionIDs := make([]string, len(markets))
+	for i, market := range markets {
+		conditionIDs[i] = market.ConditionID
+	}
+	// Without gamma's answer the markets are left for a later cycle rather than inferred
+	response, err := s.client.GetMarkets(ctx, conditionIDs)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to fetch market categories")
+		return
+	}
+
+	labels := make(map[string]string, len(response))
+	for _, market := range response {
+		label := market.Category
+		if label == "" && len(market.Events) > 0 {
+			label = market.Events[0].Category
+		}
+		labels[strings.ToLower(market.ConditionID)] = label
+	}
+
+	categorized := make([]*storage.CategorizedMarket, 0, len(markets))
+	var inferred int
+	for _, market := range markets {
+		entry := &storage.CategorizedMarket{
+			ConditionID: market.ConditionID,
+			Category:    storage.GammaCategory(labels[strings.ToLower(market.ConditionID)]),
+			Source:      storage.CategorySourceGamma,
+		}
+		if entry.Category == "" {
+			entry.Category = storage.MarketCategory(market.MarketTitle, market.MarketSlug)
+			entry.Source = storage.CategorySourceInferred
+			inferred++
+		}
+		categorized = append(categorized, entry)
+	}
+
+	if err := s.storage.SetMarketCategories(ctx, categorized); err != nil {
+		s.log.WithError(err).Warn("failed to store market categories")
+		return
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"markets":  len(categorized),
+		"inferred": inferred,
+	}).Info("categorized markets")
+}
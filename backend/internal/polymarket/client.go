@@ -3,6 +3,7 @@ package polymarket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,41 +12,78 @@ import (
 	"strings"
 	"time"
 
+	"github.com/samcm/pyre/internal/fetch"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	baseURL        = "https://data-api.polymarket.com"
+	gammaURL       = "https://gamma-api.polymarket.com"
+	clobURL        = "https://clob.polymarket.com"
 	defaultTimeout = 30 * time.Second
+
+	// recentTradesPageSize is the page size used when syncing recent trades
+	recentTradesPageSize = 100
+	// tradesPageSize is the page size used when fetching a full trade history
+	tradesPageSize = 500
+	// maxTradesOffset is the deepest offset the data API serves for trades
+	maxTradesOffset = 10000
+	// leaderboardPageSize is the most entries the data API serves per leaderboard page
+	leaderboardPageSize = 50
+	// marketsPageSize is the most markets looked up on the gamma API per request
+	marketsPageSize = 50
 )
 
 // Client defines the interface for Polymarket API operations
 type Client interface {
 	GetPositions(ctx context.Context, address string) (PositionsResponse, error)
-	GetTrades(ctx context.Context, address string, limit int) (TradesResponse, error)
+	GetTrades(ctx context.Context, address string, limit, offset int) (TradesResponse, error)
+	GetAllTrades(ctx context.Context, address string) (TradesResponse, error)
 	GetActivity(ctx context.Context, address string) (ActivitiesResponse, error)
 	GetUserProfile(ctx context.Context, address string) (*ProfileResponse, error)
+	GetPublicProfile(ctx context.Context, address string) (*PublicProfileResponse, error)
 	GetPortfolioStats(ctx context.Context, username string, address string) (*PortfolioStats, error)
+	GetOrderBook(ctx context.Context, tokenID string) (*OrderBookResponse, error)
+	GetLeaderboard(ctx context.Context, size int) (LeaderboardResponse, error)
+	GetMarkets(ctx context.Context, conditionIDs []string) (MarketsResponse, error)
+	GetEvents(ctx context.Context, slugs []string) (EventsResponse, error)
+
+	// ResetBudget restores the full API call budget at the start of a sync cycle
+	ResetBudget()
+	// BudgetRemaining reports the API calls left in the cycle, and false when the budget is unlimited
+	BudgetRemaining() (int, bool)
+	// CallsMade reports the API calls made since the budget was last reset
+	CallsMade() int
 }
 
 // client implements the Polymarket API client
 type client struct {
 	httpClient *http.Client
 	baseURL    string
+	pages      fetch.Queue     // profile pages, shared with other subsystems scraping polymarket.com
+	browser    *browserScraper // fallback for portfolio stats, nil when disabled
+	budget     *callBudget
 	log        logrus.FieldLogger
 }
 
 var _ Client = (*client)(nil)
 
-// NewClient creates a new Polymarket API client
-func NewClient(log logrus.FieldLogger) Client {
-	return &client{
+// NewClient creates a new Polymarket API client that makes at most callBudget requests per
+// sync cycle, or any number when callBudget is 0. Profile pages are fetched through pages.
+func NewClient(callBudget int, browser BrowserOptions, pages fetch.Queue, log logrus.FieldLogger) Client {
+	c := &client{
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		pages:   pages,
 		baseURL: baseURL,
+		budget:  newCallBudget(callBudget),
 		log:     log.WithField("package", "polymarket"),
 	}
+	if browser.Enabled {
+		c.browser = newBrowserScraper(browser, c.log)
+	}
+	return c
 }
 
 // GetPositions fetches positions for a given address
@@ -69,11 +107,13 @@ func (c *client) GetPositions(ctx context.Context, address string) (PositionsRes
 	return positions, nil
 }
 
-// GetTrades fetches trades for a given address
-func (c *client) GetTrades(ctx context.Context, address string, limit int) (TradesResponse, error) {
+// GetTrades fetches a page of trades for a given address, newest first, skipping the first
+// offset trades
+func (c *client) GetTrades(ctx context.Context, address string, limit, offset int) (TradesResponse, error) {
 	c.log.WithFields(logrus.Fields{
 		"address": address,
 		"limit":   limit,
+		"offset":  offset,
 	}).Debug("fetching trades")
 
 	endpoint := fmt.Sprintf("%s/trades", c.baseURL)
@@ -82,10 +122,13 @@ func (c *client) GetTrades(ctx context.Context, address string, limit int) (Trad
 	if limit > 0 {
 		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
+	if offset > 0 {
+		params.Add("offset", fmt.Sprintf("%d", offset))
+	}
 
 	var trades TradesResponse
 	if err := c.doRequest(ctx, endpoint, params, &trades); err != nil {
-		return nil, fmt.Errorf("failed to fetch trades for %s: %w", address, err)
+		return nil, fmt.Errorf("failed to fetch trades for %s at offset %d: %w", address, offset, err)
 	}
 
 	c.log.WithFields(logrus.Fields{
@@ -96,6 +139,31 @@ func (c *client) GetTrades(ctx context.Context, address string, limit int) (Trad
 	return trades, nil
 }
 
+// GetAllTrades fetches the full trade history for a given address, newest first.
+// The data API caps the offset it serves, so very long histories are truncated.
+func (c *client) GetAllTrades(ctx context.Context, address string) (TradesResponse, error) {
+	trades := make(TradesResponse, 0, tradesPageSize)
+
+	for offset := 0; offset <= maxTradesOffset; offset += tradesPageSize {
+		page, err := c.GetTrades(ctx, address, tradesPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, page...)
+		if len(page) < tradesPageSize {
+			break
+		}
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"address": address,
+		"count":   len(trades),
+	}).Debug("fetched full trade history")
+
+	return trades, nil
+}
+
 // GetActivity fetches activity for a given address
 func (c *client) GetActivity(ctx context.Context, address string) (ActivitiesResponse, error) {
 	c.log.WithField("address", address).Debug("fetching activity")
@@ -154,8 +222,149 @@ func (c *client) GetUserProfile(ctx context.Context, address string) (*ProfileRe
 	return profile, nil
 }
 
+// GetPublicProfile fetches the public profile for an address, which includes the linked
+// X/Twitter handle that activity-embedded profiles leave out
+func (c *client) GetPublicProfile(ctx context.Context, address string) (*PublicProfileResponse, error) {
+	c.log.WithField("address", address).Debug("fetching public profile")
+
+	endpoint := fmt.Sprintf("%s/public-profile", gammaURL)
+	params := url.Values{}
+	params.Add("address", address)
+
+	var profile PublicProfileResponse
+	if err := c.doRequest(ctx, endpoint, params, &profile); err != nil {
+		return nil, fmt.Errorf("failed to fetch public profile for %s: %w", address, err)
+	}
+
+	return &profile, nil
+}
+
+// GetOrderBook fetches the current order book of an outcome token from the CLOB
+func (c *client) GetOrderBook(ctx context.Context, tokenID string) (*OrderBookResponse, error) {
+	c.log.WithField("token_id", tokenID).Debug("fetching order book")
+
+	endpoint := fmt.Sprintf("%s/book", clobURL)
+	params := url.Values{}
+	params.Add("token_id", tokenID)
+
+	var book OrderBookResponse
+	if err := c.doRequest(ctx, endpoint, params, &book); err != nil {
+		return nil, fmt.Errorf("failed to fetch order book for %s: %w", tokenID, err)
+	}
+
+	return &book, nil
+}
+
+// GetLeaderboard fetches the top size traders of Polymarket's public all-time PnL
+// leaderboard, best first
+func (c *client) GetLeaderboard(ctx context.Context, size int) (LeaderboardResponse, error) {
+	endpoint := fmt.Sprintf("%s/v1/leaderboard", c.baseURL)
+	entries := make(LeaderboardResponse, 0, size)
+
+	for offset := 0; offset < size; offset += leaderboardPageSize {
+		params := url.Values{}
+		params.Add("timePeriod", "all")
+		params.Add("orderBy", "PNL")
+		params.Add("limit", fmt.Sprintf("%d", min(leaderboardPageSize, size-offset)))
+		params.Add("offset", fmt.Sprintf("%d", offset))
+
+		var page LeaderboardResponse
+		if err := c.doRequest(ctx, endpoint, params, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch leaderboard at offset %d: %w", offset, err)
+		}
+
+		entries = append(entries, page...)
+		if len(page) < min(leaderboardPageSize, size-offset) {
+			break
+		}
+	}
+
+	c.log.WithField("count", len(entries)).Debug("fetched leaderboard")
+
+	return entries, nil
+}
+
+// GetMarkets fetches markets from the gamma API by condition ID. Markets gamma doesn't know
+// are left out of the response.
+func (c *client) GetMarkets(ctx context.Context, conditionIDs []string) (MarketsResponse, error) {
+	endpoint := fmt.Sprintf("%s/markets", gammaURL)
+	markets := make(MarketsResponse, 0, len(conditionIDs))
+
+	for start := 0; start < len(conditionIDs); start += marketsPageSize {
+		batch := conditionIDs[start:min(start+marketsPageSize, len(conditionIDs))]
+
+		params := url.Values{}
+		for _, conditionID := range batch {
+			params.Add("condition_ids", conditionID)
+		}
+		params.Add("limit", fmt.Sprintf("%d", len(batch)))
+
+		var page MarketsResponse
+		if err := c.doRequest(ctx, endpoint, params, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch markets: %w", err)
+		}
+		markets = append(markets, page...)
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"requested": len(conditionIDs),
+		"found":     len(markets),
+	}).Debug("fetched markets")
+
+	return markets, nil
+}
+
+// GetEvents fetches events, with their markets, from the gamma API by slug. Events gamma
+// doesn't know are left out of the response.
+func (c *client) GetEvents(ctx context.Context, slugs []string) (EventsResponse, error) {
+	endpoint := fmt.Sprintf("%s/events", gammaURL)
+	events := make(EventsResponse, 0, len(slugs))
+
+	for start := 0; start < len(slugs); start += marketsPageSize {
+		batch := slugs[start:min(start+marketsPageSize, len(slugs))]
+
+		params := url.Values{}
+		for _, slug := range batch {
+			params.Add("slug", slug)
+		}
+		params.Add("limit", fmt.Sprintf("%d", len(batch)))
+
+		var page EventsResponse
+		if err := c.doRequest(ctx, endpoint, params, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch events: %w", err)
+		}
+		events = append(events, page...)
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"requested": len(slugs),
+		"found":     len(events),
+	}).Debug("fetched events")
+
+	return events, nil
+}
+
+// ResetBudget restores the full API call budget
+func (c *client) ResetBudget() {
+	c.budget.reset()
+}
+
+// BudgetRemaining reports the API calls left in the cycle
+func (c *client) BudgetRemaining() (int, bool) {
+	return c.budget.left()
+}
+
+// CallsMade reports the API calls made in the cycle
+func (c *client) CallsMade() int {
+	return c.budget.calls()
+}
+
 // doRequest performs an HTTP GET request and unmarshals the response
 func (c *client) doRequest(ctx context.Context, endpoint string, params url.Values, result any) error {
+	if err := c.budget.spend(); err != nil {
+		return err
+	}
+
 	// Build URL with query parameters
 	u, err := url.Parse(endpoint)
 	if err != nil {
@@ -199,41 +408,73 @@ func (c *client) doRequest(ctx context.Context, endpoint string, params url.Valu
 	return nil
 }
 
-// GetPortfolioStats fetches the all-time portfolio stats from Polymarket's profile page
-// This scrapes the embedded JSON data since the data API doesn't expose historical PnL
+// GetPortfolioStats fetches the all-time portfolio stats from Polymarket's profile page.
+// When the page can't be scraped and the browser fallback is enabled, the page is rendered
+// in headless Chrome instead.
 func (c *client) GetPortfolioStats(ctx context.Context, username, address string) (*PortfolioStats, error) {
+	stats, err := c.scrapePortfolioStats(ctx, username, address)
+	if (err == nil && stats != nil) || c.browser == nil {
+		return stats, err
+	}
+
+	if errors.Is(err, ErrBudgetExhausted) {
+		return nil, err
+	}
+	if err := c.budget.spend(); err != nil {
+		return nil, err
+	}
+
+	c.log.WithError(err).WithField("username", username).Info("falling back to headless browser for portfolio stats")
+	return c.browser.GetPortfolioStats(ctx, username, address)
+}
+
+// scrapePortfolioStats reads the portfolio stats from the profile page HTML
+// This scrapes the embedded JSON data since the data API doesn't expose historical PnL
+func (c *client) scrapePortfolioStats(ctx context.Context, username, address string) (*PortfolioStats, error) {
 	c.log.WithFields(logrus.Fields{
 		"username": username,
 		"address":  address,
 	}).Debug("fetching portfolio stats from profile page")
 
-	// Fetch the profile page HTML
-	profileURL := fmt.Sprintf("https://polymarket.com/profile/@%s", username)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, profileURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create profile request: %w", err)
+	if err := c.budget.spend(); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Accept", "text/html")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; pyre/1.0)")
-
-	resp, err := c.httpClient.Do(req)
+	// Fetch the profile page HTML
+	resp, err := c.pages.Get(ctx, fetch.Request{
+		URL:       fmt.Sprintf("https://polymarket.com/profile/@%s", username),
+		Accept:    "text/html",
+		UserAgent: "Mozilla/5.0 (compatible; pyre/1.0)",
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch profile page: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("profile page returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	stats, err := parsePortfolioStats(string(resp.Body), address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read profile page: %w", err)
+		return nil, err
+	}
+	if stats == nil {
+		c.log.WithField("username", username).Warn("could not find PnL data in profile page")
+		return nil, nil
 	}
 
-	htmlContent := string(body)
+	c.log.WithFields(logrus.Fields{
+		"username": username,
+		"pnl":      stats.TotalPnl,
+		"volume":   stats.TotalVolume,
+	}).Debug("fetched portfolio stats")
 
+	return stats, nil
+}
+
+// parsePortfolioStats extracts the portfolio stats embedded in a profile page, returning nil
+// when the page doesn't contain them
+func parsePortfolioStats(htmlContent, address string) (*PortfolioStats, error) {
 	// The PnL data is embedded in the page as part of React Query dehydrated state
 	// Look for the pattern: "amount":NUMBER,"pnl":NUMBER
 	pnlPattern := regexp.MustCompile(`"amount":([\d.-]+),"pnl":([\d.-]+)`)
@@ -247,7 +488,6 @@ func (c *client) GetPortfolioStats(ctx context.Context, username, address string
 		)
 		altMatches := positionsPattern.FindStringSubmatch(htmlContent)
 		if len(altMatches) < 2 {
-			c.log.WithField("username", username).Warn("could not find PnL data in profile page")
 			return nil, nil
 		}
 
@@ -269,16 +509,8 @@ func (c *client) GetPortfolioStats(ctx context.Context, username, address string
 		return nil, fmt.Errorf("failed to parse PnL: %w", err)
 	}
 
-	stats := &PortfolioStats{
+	return &PortfolioStats{
 		TotalPnl:    pnl,
 		TotalVolume: amount,
-	}
-
-	c.log.WithFields(logrus.Fields{
-		"username": username,
-		"pnl":      stats.TotalPnl,
-		"volume":   stats.TotalVolume,
-	}).Debug("fetched portfolio stats")
-
-	return stats, nil
+	}, nil
 }
@@ -0,0 +1,71 @@
+package polymarket
+
+import (
+	"context"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// refreshLeaderboard replaces the stored global leaderboard with Polymarket's current one once
+// leaderboardInterval has passed since the last refresh. It runs after the users of a cycle,
+// on whatever is left of the call budget, and waits for a later cycle when that isn't enough.
+func (s *service) refreshLeaderboard(ctx context.Context) {
+	// After a restart, the stored leaderboard tells when it was last refreshed
+	if s.lastLeaderboard.IsZero() && s.leaderboardInterval > 0 {
+		if stored, _, err := s.storage.GetGlobalLeaderboard(ctx, 1, 0); err == nil && len(stored) > 0 {
+			s.lastLeaderboard = stored[0].FetchedAt
+		}
+	}
+
+	if s.leaderboardInterval <= 0 || s.leaderboardSize <= 0 || time.Since(s.lastLeaderboard) < s.leaderboardInterval {
+		return
+	}
+
+	pages := (s.leaderboardSize + leaderboardPageSize - 1) / leaderboardPageSize
+	if remaining, limited := s.client.BudgetRemaining(); limited && remaining < pages {
+		s.log.WithFields(logrus.Fields{
+			"pages":     pages,
+			"remaining": remaining,
+		}).Debug("deferring global leaderboard refresh, not enough api call budget left")
+		return
+	}
+
+	response, err := s.client.GetLeaderboard(ctx, s.leaderboardSize)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to fetch global leaderboard")
+		return
+	}
+	// An empty page is more likely an API hiccup than an empty leaderboard
+	if len(response) == 0 {
+		return
+	}
+
+	fetchedAt := time.Now()
+	entries := make([]*storage.GlobalLeaderboardEntry, 0, len(response))
+	for i, item := range response {
+		entry := &storage.GlobalLeaderboardEntry{
+			Rank:      i + 1,
+			Address:   item.ProxyWallet,
+			Pnl:       item.Pnl,
+			Volume:    item.Volume,
+			FetchedAt: fetchedAt,
+		}
+		if item.UserName != "" {
+			entry.Name = &item.UserName
+		}
+		if item.ProfileImage != "" {
+			entry.ProfileImage = &item.ProfileImage
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := s.storage.ReplaceGlobalLeaderboard(ctx, entries); err != nil {
+		s.log.WithError(err).Warn("failed to store global leaderboard")
+		return
+	}
+	s.lastLeaderboard = fetchedAt
+
+	s.log.WithField("entries", len(entries)).Info("refreshed global leaderboard")
+}
@@ -0,0 +1,184 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// marketRefreshBatch is the most markets cached or refreshed per sync cycle
+	marketRefreshBatch = 5 * marketsPageSize
+	// marketRefreshInterval is how long a market still trading is cached before it is fetched
+	// again for its prices and whether it has closed
+	marketRefreshInterval = time.Hour
+)
+
+// refreshMarkets caches the gamma metadata of markets traded by tracked users: those not cached
+// yet, then those still trading that were fetched longest ago. Each market is fetched once
+// however many positions and trades reference it, marketsPageSize to a request, and cached
+// until marketRefreshInterval has passed, or for good once closed. Markets the markets
+// endpoint leaves out are looked up through their event. Like categorization, it runs after
+// the users of a cycle on whatever is left of the call budget.
+func (s *service) refreshMarkets(ctx context.Context) {
+	stale, err := s.storage.GetStaleMarkets(ctx, time.Now().Add(-marketRefreshInterval), marketRefreshBatch)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to get stale markets")
+		return
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	pages := (len(stale) + marketsPageSize - 1) / marketsPageSize
+	if remaining, limited := s.client.BudgetRemaining(); limited && remaining < pages {
+		s.log.WithFields(logrus.Fields{
+			"pages":     pages,
+			"remaining": remaining,
+		}).Debug("deferring market refresh, not enough api call budget left")
+		return
+	}
+
+	conditionIDs := make([]string, len(stale))
+	for i, market := range stale {
+		conditionIDs[i] = market.ConditionID
+	}
+	response, err := s.client.GetMarkets(ctx, conditionIDs)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to fetch markets")
+		return
+	}
+
+	found := make(map[string]MarketResponse, len(response))
+	for _, market := range response {
+		found[strings.ToLower(market.ConditionID)] = market
+	}
+
+	// Closed markets can drop out of the markets endpoint while their event still lists them
+	slugs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, market := range stale {
+		if _, ok := found[strings.ToLower(market.ConditionID)]; ok || market.EventSlug == nil || seen[*market.EventSlug] {
+			continue
+		}
+		seen[*market.EventSlug] = true
+		slugs = append(slugs, *market.EventSlug)
+	}
+	if len(slugs) > 0 {
+		if remaining, limited := s.client.BudgetRemaining(); !limited || remaining >= (len(slugs)+marketsPageSize-1)/marketsPageSize {
+			events, err := s.client.GetEvents(ctx, slugs)
+			if err != nil {
+				s.log.WithError(err).Warn("failed to fetch events of markets")
+			}
+			for _, event := range events {
+				for _, market := range event.Markets {
+					market.Events = []EventResponse{{Slug: event.Slug, Title: event.Title, Category: event.Category}}
+					if _, ok := found[strings.ToLower(market.ConditionID)]; !ok {
+						found[strings.ToLower(market.ConditionID)] = market
+					}
+				}
+			}
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	markets := make([]*storage.Market, 0, len(stale))
+	var missing int
+	for _, market := range stale {
+		gamma, ok := found[strings.ToLower(market.ConditionID)]
+		if !ok {
+			// Cached from its trades, so it's retried when it goes stale rather than every cycle
+			market.FetchedAt = now
+			markets = append(markets, market)
+			missing++
+			continue
+		}
+
+		markets = append(markets, toStorageMarket(market.ConditionID, gamma, now))
+	}
+
+	if err := s.storage.UpsertMarkets(ctx, markets); err != nil {
+		s.log.WithError(err).Warn("failed to store markets")
+		return
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"markets": len(markets),
+		"missing": missing,
+	}).Info("refreshed markets")
+}
+
+// toStorageMarket converts a gamma market to its cached form, keyed by the condition ID trades
+// have for it
+func toStorageMarket(conditionID string, gamma MarketResponse, fetchedAt time.Time) *storage.Market {
+	market := &storage.Market{
+		ConditionID: conditionID,
+		Title:       gamma.Question,
+		Slug:        nonEmpty(gamma.Slug),
+		Closed:      gamma.Closed,
+		Outcomes:    parseOutcomes(gamma.Outcomes, gamma.OutcomePrices),
+		Found:       true,
+		FetchedAt:   fetchedAt,
+	}
+
+	label := gamma.Category
+	if len(gamma.Events) > 0 {
+		market.EventSlug = nonEmpty(gamma.Events[0].Slug)
+		market.EventTitle = nonEmpty(gamma.Events[0].Title)
+		if label == "" {
+			label = gamma.Events[0].Category
+		}
+	}
+	market.Category = nonEmpty(storage.GammaCategory(label))
+
+	if endDate, err := time.Parse(time.RFC3339, gamma.EndDate); err == nil {
+		market.EndDate = &endDate
+	}
+
+	// A closed market has resolved once an outcome settles at 1
+	if market.Closed {
+		for _, outcome := range market.Outcomes {
+			if outcome.Price != nil && *outcome.Price >= 0.99 {
+				market.Resolved = true
+				market.WinningOutcome = &outcome.Name
+				break
+			}
+		}
+	}
+
+	return market
+}
+
+// parseOutcomes pairs a gamma market's outcomes with their prices. Prices that are missing or
+// don't parse are left unset.
+func parseOutcomes(names, prices string) []storage.MarketOutcome {
+	var outcomeNames, outcomePrices []string
+	if err := json.Unmarshal([]byte(names), &outcomeNames); err != nil {
+		return nil
+	}
+	_ = json.Unmarshal([]byte(prices), &outcomePrices)
+
+	outcomes := make([]storage.MarketOutcome, len(outcomeNames))
+	for i, name := range outcomeNames {
+		outcomes[i].Name = name
+		if i < len(outcomePrices) {
+			if price, err := strconv.ParseFloat(outcomePrices[i], 64); err == nil {
+				outcomes[i].Price = &price
+			}
+		}
+	}
+	return outcomes
+}
+
+// nonEmpty returns nil for an empty string, and a pointer to it otherwise
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -2,28 +2,132 @@ package polymarket
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/samcm/pyre/internal/events"
 	"github.com/samcm/pyre/internal/storage"
+	"github.com/samcm/pyre/internal/titles"
 	"github.com/sirupsen/logrus"
 )
 
+// syncLeaseName names the lease held by the instance that performs sync
+const syncLeaseName = "sync"
+
+// ErrNotLeader is returned when sync is requested on an instance that doesn't hold the sync lease
+var ErrNotLeader = errors.New("sync lease is held by another instance")
+
 // Service defines the interface for the sync service
 type Service interface {
 	Start(ctx context.Context) error
 	Stop() error
 	TriggerSync(ctx context.Context) error
+	// BackfillTrades fetches a user's full trade history and stores the trades missing from it
+	BackfillTrades(ctx context.Context, username string) (*TradeBackfill, error)
+	// IsLeader reports whether this instance performs sync. Instances sharing a database
+	// take turns through a lease; the others only serve reads.
+	IsLeader() bool
+	// Crashes reports the panics recovered during sync since startup
+	Crashes() Crashes
+}
+
+// TradeBackfill reports the trades fetched and newly stored by a trade backfill
+type TradeBackfill struct {
+	Username  string
+	Ghost     bool
+	Addresses []AddressBackfill
+	Fetched   int
+	Inserted  int
+}
+
+// AddressBackfill reports the trade backfill of one address
+type AddressBackfill struct {
+	Address  string
+	Fetched  int
+	Inserted int
 }
 
+// Crashes tallies the panics recovered during sync
+type Crashes struct {
+	Count     int
+	LastAt    *time.Time
+	LastError string
+}
+
+// Options configures the sync service
+type Options struct {
+	Interval            time.Duration // time between sync cycles
+	ErrorHistory        int           // recent sync errors kept per user
+	RunHistory          int           // recent sync runs kept
+	ReconcileInterval   time.Duration // how often stored trades are checked against a full re-fetch, 0 disables
+	LeaseTTL            time.Duration // how long the sync lease lasts without renewal, 0 disables the lease
+	BookMaxAge          time.Duration // how recent a new trade must be to store the order book with it, 0 disables
+	LeaderboardInterval time.Duration // how often Polymarket's global leaderboard is stored, 0 disables
+	LeaderboardSize     int           // top global traders stored
+	DormantAfter        time.Duration // how long an address can go without trading before it's dormant, 0 disables
+	DormantCheckEvery   int           // dormant addresses are synced every this many cycles
+}
+
+// syncLoopRestartDelay is how long the sync loop waits before restarting after a panic
+const syncLoopRestartDelay = 30 * time.Second
+
 // service implements the sync service
 type service struct {
-	client   Client
-	storage  storage.Storage
-	users    map[string][]string // username -> addresses
-	interval time.Duration
-	log      logrus.FieldLogger
+	client       Client
+	storage      storage.Storage
+	users        map[string][]string // username -> addresses
+	interval     time.Duration
+	errorHistory int
+	runHistory   int // sync runs kept
+	bus          events.Bus
+	log          logrus.FieldLogger
+
+	// reconcileInterval is how often stored trades are checked against a full re-fetch, 0 disables
+	reconcileInterval time.Duration
+	reconcileMu       sync.Mutex
+	lastReconciled    map[string]time.Time // address -> last reconciliation
+
+	// bookMaxAge is how old a newly synced trade can be for the current order book to be stored
+	// with it as the book at trade time, 0 disables book snapshots
+	bookMaxAge time.Duration
+
+	// leaderboardInterval is how often the top leaderboardSize traders of Polymarket's global
+	// leaderboard are stored, 0 disables. lastLeaderboard is guarded by cycleMu.
+	leaderboardInterval time.Duration
+	leaderboardSize     int
+	lastLeaderboard     time.Time
+
+	// dormantAfter is how long an address can go without trading before it's dormant, 0
+	// disables. Dormant addresses are only synced every dormantCheckEvery cycles; cycle counts
+	// sync cycles and is guarded by cycleMu.
+	dormantAfter      time.Duration
+	dormantCheckEvery int
+	cycle             int
+
+	// leaseTTL is how long the sync lease lasts without renewal, 0 disables the lease
+	leaseTTL    time.Duration
+	leaseHolder string
+	leader      atomic.Bool
+
+	// cycleMu serializes sync cycles, which share the client's call budget
+	cycleMu sync.Mutex
+	// nextUser is where the next cycle starts, the first user deferred when the call budget ran out
+	nextUser string
+	// userRun tallies the errors and writes of the user being synced, guarded by cycleMu
+	userRun *storage.SyncRunUser
+
+	crashMu sync.Mutex
+	crashes Crashes
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -34,14 +138,26 @@ type service struct {
 var _ Service = (*service)(nil)
 
 // NewService creates a new sync service
-func NewService(client Client, storage storage.Storage, users map[string][]string, intervalMinutes int, log logrus.FieldLogger) Service {
+func NewService(client Client, storage storage.Storage, users map[string][]string, opts Options, bus events.Bus, log logrus.FieldLogger) Service {
 	return &service{
-		client:   client,
-		storage:  storage,
-		users:    users,
-		interval: time.Duration(intervalMinutes) * time.Minute,
-		log:      log.WithField("package", "polymarket-service"),
-		done:     make(chan struct{}),
+		client:              client,
+		storage:             storage,
+		users:               users,
+		interval:            opts.Interval,
+		errorHistory:        opts.ErrorHistory,
+		runHistory:          opts.RunHistory,
+		bus:                 bus,
+		reconcileInterval:   opts.ReconcileInterval,
+		lastReconciled:      make(map[string]time.Time, len(users)),
+		bookMaxAge:          opts.BookMaxAge,
+		leaderboardInterval: opts.LeaderboardInterval,
+		leaderboardSize:     opts.LeaderboardSize,
+		dormantAfter:        opts.DormantAfter,
+		dormantCheckEvery:   opts.DormantCheckEvery,
+		leaseTTL:            opts.LeaseTTL,
+		leaseHolder:         leaseHolderID(),
+		log:                 log.WithField("package", "polymarket-service"),
+		done:                make(chan struct{}),
 	}
 }
 
@@ -56,15 +172,28 @@ func (s *service) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to ensure users: %w", err)
 	}
 
+	if s.leaseTTL > 0 {
+		s.renewLease(s.ctx)
+		if !s.IsLeader() {
+			s.log.WithField("holder", s.leaseHolder).Info("sync lease held by another instance, serving reads only")
+		}
+		s.wg.Add(1)
+		go s.leaseLoop()
+	} else {
+		s.leader.Store(true)
+	}
+
 	// Perform initial sync
-	s.log.Info("performing initial sync")
-	if err := s.syncAll(s.ctx); err != nil {
-		s.log.WithError(err).Error("initial sync failed")
+	if s.IsLeader() {
+		s.log.Info("performing initial sync")
+		if err := s.runSync(s.ctx, storage.SyncTriggerInitial); err != nil {
+			s.log.WithError(err).Error("initial sync failed")
+		}
 	}
 
 	// Start background sync goroutine
 	s.wg.Add(1)
-	go s.syncLoop()
+	go s.superviseSyncLoop()
 
 	s.log.WithField("interval", s.interval).Info("polymarket sync service started")
 	return nil
@@ -80,71 +209,534 @@ func (s *service) Stop() error {
 	}
 	s.wg.Wait()
 
+	// Hand the lease over now rather than making other instances wait for it to expire
+	if s.leaseTTL > 0 && s.leader.Load() {
+		if err := s.storage.ReleaseLease(context.Background(), syncLeaseName, s.leaseHolder); err != nil {
+			s.log.WithError(err).Warn("failed to release sync lease")
+		}
+	}
+
 	s.log.Info("polymarket sync service stopped")
 	return nil
 }
 
 // TriggerSync manually triggers a sync
 func (s *service) TriggerSync(ctx context.Context) error {
+	if !s.IsLeader() {
+		return ErrNotLeader
+	}
+
 	s.log.Info("manual sync triggered")
-	return s.syncAll(ctx)
+	return s.runSync(ctx, storage.SyncTriggerManual)
+}
+
+// BackfillTrades fetches the full trade history of each of a user's addresses, as far as the
+// data API serves it, and stores the trades the regular sync missed. It waits for a running
+// sync cycle and starts a fresh call budget, like a cycle does.
+func (s *service) BackfillTrades(ctx context.Context, username string) (*TradeBackfill, error) {
+	if !s.IsLeader() {
+		return nil, ErrNotLeader
+	}
+
+	user, err := s.storage.GetUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	addresses, err := s.storage.GetUserAddresses(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user addresses: %w", err)
+	}
+
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+
+	s.client.ResetBudget()
+	s.log.WithField("username", username).Info("starting trade backfill")
+
+	result := &TradeBackfill{Username: user.Username, Ghost: user.Ghost, Addresses: make([]AddressBackfill, 0, len(addresses))}
+	books := make(map[string]*OrderBookResponse)
+	for _, addr := range addresses {
+		trades, err := s.client.GetAllTrades(ctx, addr.Address)
+		if err != nil {
+			s.recordSyncError(ctx, user.ID, addr.Address, "backfill", err)
+			return nil, fmt.Errorf("failed to fetch trade history for %s: %w", addr.Address, err)
+		}
+
+		inserted := s.storeTrades(ctx, user.ID, addr.Address, trades, books)
+		result.Addresses = append(result.Addresses, AddressBackfill{
+			Address:  addr.Address,
+			Fetched:  len(trades),
+			Inserted: len(inserted),
+		})
+		result.Fetched += len(trades)
+		result.Inserted += len(inserted)
+	}
+
+	// Older trades change how the later ones moved their positions
+	if result.Inserted > 0 {
+		if _, err := s.storage.ClassifyTrades(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to classify trades: %w", err)
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"username": username,
+		"fetched":  result.Fetched,
+		"inserted": result.Inserted,
+	}).Info("trade backfill completed")
+
+	return result, nil
+}
+
+// IsLeader reports whether this instance holds the sync lease
+func (s *service) IsLeader() bool {
+	return s.leader.Load()
 }
 
-// syncLoop runs periodic syncs
-func (s *service) syncLoop() {
+// Crashes reports the panics recovered during sync
+func (s *service) Crashes() Crashes {
+	s.crashMu.Lock()
+	defer s.crashMu.Unlock()
+
+	return s.crashes
+}
+
+// recordCrash logs a recovered panic with its stack and counts it
+func (s *service) recordCrash(recovered any, where string) error {
+	err := fmt.Errorf("panic in %s: %v", where, recovered)
+	s.log.WithError(err).WithField("stack", string(debug.Stack())).Error("recovered from panic")
+
+	now := time.Now()
+	s.crashMu.Lock()
+	s.crashes.Count++
+	s.crashes.LastAt = &now
+	s.crashes.LastError = err.Error()
+	s.crashMu.Unlock()
+
+	return err
+}
+
+// runSync runs a sync cycle, turning a panic into an error so one bad user or response can't
+// take down the caller
+func (s *service) runSync(ctx context.Context, trigger string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = s.recordCrash(recovered, trigger+" sync")
+		}
+	}()
+
+	return s.syncAll(ctx, trigger)
+}
+
+// superviseSyncLoop runs the sync loop, restarting it after a delay when it panics
+func (s *service) superviseSyncLoop() {
 	defer s.wg.Done()
 
+	for !s.syncLoop() {
+		s.log.WithField("delay", syncLoopRestartDelay).Warn("restarting sync loop")
+		select {
+		case <-s.done:
+			return
+		case <-s.ctx.Done():
+			return
+		case <-time.After(syncLoopRestartDelay):
+		}
+	}
+}
+
+// syncLoop runs periodic syncs until the service stops, reporting whether it stopped cleanly
+// rather than by a panic
+func (s *service) syncLoop() (stopped bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = s.recordCrash(recovered, "sync loop")
+		}
+	}()
+
 	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-s.done:
-			return
+			return true
 		case <-s.ctx.Done():
-			return
+			return true
 		case <-ticker.C:
+			if !s.IsLeader() {
+				s.log.Debug("skipping scheduled sync, lease held by another instance")
+				continue
+			}
 			s.log.Info("starting scheduled sync")
-			if err := s.syncAll(s.ctx); err != nil {
+			if err := s.runSync(s.ctx, storage.SyncTriggerScheduled); err != nil {
 				s.log.WithError(err).Error("scheduled sync failed")
 			}
 		}
 	}
 }
 
+// leaseLoop renews the sync lease well before it expires, and takes it over once another
+// holder lets it expire. A sync already running when the lease is lost runs to completion.
+func (s *service) leaseLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.renewLease(s.ctx)
+		}
+	}
+}
+
+// renewLease acquires or renews the sync lease and records whether this instance holds it
+func (s *service) renewLease(ctx context.Context) {
+	acquired, err := s.storage.AcquireLease(ctx, syncLeaseName, s.leaseHolder, s.leaseTTL)
+	if err != nil {
+		// Without a confirmed lease, another instance may take over once it expires
+		s.log.WithError(err).Warn("failed to renew sync lease")
+		acquired = false
+	}
+
+	if was := s.leader.Swap(acquired); was != acquired {
+		fields := logrus.Fields{"holder": s.leaseHolder, "ttl": s.leaseTTL}
+		if acquired {
+			s.log.WithFields(fields).Info("acquired sync lease, this instance performs sync")
+		} else {
+			s.log.WithFields(fields).Warn("sync lease held by another instance, serving reads only")
+		}
+	}
+}
+
+// leaseHolderID identifies this process among instances sharing the database
+func leaseHolderID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
+
 // ensureUsers ensures all configured users exist in the database
 func (s *service) ensureUsers(ctx context.Context) error {
 	for username, addresses := range s.users {
 		_, err := s.storage.GetUser(ctx, username)
-		if err != nil {
-			// User doesn't exist, create it
+		if errors.Is(err, storage.ErrNotFound) {
 			s.log.WithField("username", username).Info("creating user")
 			if _, err := s.storage.CreateUser(ctx, username, addresses); err != nil {
 				return fmt.Errorf("failed to create user %s: %w", username, err)
 			}
+		} else if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", username, err)
 		}
 	}
 	return nil
 }
 
-// syncAll syncs data for all configured users
-func (s *service) syncAll(ctx context.Context) error {
-	s.log.WithField("users", len(s.users)).Info("syncing all users")
+// xHandlePattern finds an X/Twitter profile link in a bio
+var xHandlePattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9.-])(?:www\.)?(?:x|twitter)\.com/@?([a-z0-9_]{1,15})\b`)
 
-	for username, addresses := range s.users {
-		if err := s.syncUser(ctx, username, addresses); err != nil {
+// syncIdentity stores the public identity of a user's Polymarket profile. The X handle comes
+// from the public profile, or from a profile link in the bio when none is linked.
+func (s *service) syncIdentity(ctx context.Context, userID int64, address string, profile *ProfileResponse) {
+	identity := &storage.UserIdentity{UserID: userID}
+	set := func(field **string, value string) {
+		if value != "" {
+			*field = &value
+		}
+	}
+
+	if profile != nil {
+		set(&identity.Name, profile.Name)
+		set(&identity.Pseudonym, profile.Pseudonym)
+		set(&identity.Bio, profile.Bio)
+	}
+
+	public, err := s.client.GetPublicProfile(ctx, address)
+	if err != nil {
+		s.log.WithError(err).WithField("address", address).Debug("failed to fetch public profile")
+	} else if public != nil {
+		set(&identity.Name, public.Name)
+		set(&identity.Pseudonym, public.Pseudonym)
+		set(&identity.Bio, public.Bio)
+		set(&identity.XUsername, strings.TrimPrefix(public.XUsername, "@"))
+	}
+
+	if identity.XUsername == nil && identity.Bio != nil {
+		if matches := xHandlePattern.FindStringSubmatch(*identity.Bio); len(matches) == 2 {
+			set(&identity.XUsername, matches[1])
+		}
+	}
+
+	if identity.Name == nil && identity.Pseudonym == nil && identity.Bio == nil && identity.XUsername == nil {
+		return
+	}
+
+	if err := s.storage.UpdateUserIdentity(ctx, identity); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to update user identity")
+	}
+}
+
+// trackedUsers returns the users to sync. Users are read from storage so roster changes
+// made through the API are picked up without a restart.
+func (s *service) trackedUsers(ctx context.Context) (map[string][]string, error) {
+	users, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	tracked := make(map[string][]string, len(users))
+	for _, user := range users {
+		addresses, err := s.storage.GetUserAddresses(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get addresses of user %s: %w", user.Username, err)
+		}
+
+		tracked[user.Username] = make([]string, len(addresses))
+		for i, addr := range addresses {
+			tracked[user.Username][i] = addr.Address
+		}
+	}
+
+	return tracked, nil
+}
+
+// syncAll syncs data for all tracked users. When the client's call budget can't cover the
+// next user, that user and the rest are deferred to the next cycle, which starts with them.
+// Each cycle is recorded in the sync run history. When the users can't be read from storage
+// the cycle is skipped, rather than syncing a roster that may have changed since.
+func (s *service) syncAll(ctx context.Context, trigger string) error {
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+
+	users, err := s.trackedUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("skipping sync cycle: %w", err)
+	}
+
+	s.client.ResetBudget()
+	s.cycle++
+
+	run := &storage.SyncRun{
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+		UserRuns:  make([]*storage.SyncRunUser, 0),
+	}
+	defer s.recordSyncRun(ctx, run)
+
+	order := s.rotation(users)
+	s.log.WithField("users", len(users)).Info("syncing all users")
+
+	s.nextUser = ""
+	for i, username := range order {
+		addresses := users[username]
+		due := s.dueAddresses(ctx, addresses)
+		if skipped := len(addresses) - len(due); skipped > 0 {
+			s.log.WithFields(logrus.Fields{
+				"username": username,
+				"dormant":  skipped,
+			}).Debug("skipping dormant addresses this cycle")
+		}
+
+		// The first user always gets its turn, so a user too large for the budget can't block the rotation
+		if remaining, limited := s.client.BudgetRemaining(); limited && i > 0 && remaining < estimateCalls(len(due)) {
+			s.nextUser = username
+			run.DeferredUsers = len(order) - i
+			s.log.WithFields(logrus.Fields{
+				"deferred":  len(order) - i,
+				"remaining": remaining,
+				"next_user": username,
+			}).Warn("api call budget exhausted, deferring remaining users to the next cycle")
+			break
+		}
+
+		userRun := &storage.SyncRunUser{Username: username, StartedAt: time.Now()}
+		calls := s.client.CallsMade()
+		s.userRun = userRun
+
+		err := s.syncUser(ctx, username, addresses, due)
+
+		s.userRun = nil
+		userRun.Duration = time.Since(userRun.StartedAt)
+		userRun.APICalls = s.client.CallsMade() - calls
+		run.UserRuns = append(run.UserRuns, userRun)
+
+		if err != nil {
+			failure := err.Error()
+			userRun.Failure = &failure
 			s.log.WithError(err).WithField("username", username).Error("failed to sync user")
 			// Continue with other users even if one fails
 			continue
 		}
 	}
 
+	s.refreshLeaderboard(ctx)
+	s.refreshMarkets(ctx)
+	s.categorizeMarkets(ctx)
+
 	s.log.Info("sync completed for all users")
 	return nil
 }
 
-// syncUser syncs data for a single user
-func (s *service) syncUser(ctx context.Context, username string, addresses []string) error {
+// rotation orders users for a sync cycle, starting with the user deferred by the previous
+// cycle and wrapping around
+func (s *service) rotation(users map[string][]string) []string {
+	order := make([]string, 0, len(users))
+	for username := range users {
+		order = append(order, username)
+	}
+	slices.Sort(order)
+
+	start, _ := slices.BinarySearch(order, s.nextUser)
+	if start == len(order) {
+		start = 0
+	}
+
+	return append(order[start:], order[:start]...)
+}
+
+// recordSyncRun totals a finished sync cycle and adds it to the sync run history
+func (s *service) recordSyncRun(ctx context.Context, run *storage.SyncRun) {
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt)
+	run.APICalls = s.client.CallsMade()
+	for _, userRun := range run.UserRuns {
+		if userRun.Failure == nil {
+			run.Users++
+		}
+		run.RowsWritten += userRun.RowsWritten
+		run.Errors += userRun.Errors
+	}
+
+	// The run is recorded even when the cycle was cut short by its context being canceled
+	if err := s.storage.RecordSyncRun(context.WithoutCancel(ctx), run, s.runHistory); err != nil {
+		s.log.WithError(err).Warn("failed to record sync run")
+	}
+}
+
+// countWrites adds rows written to the sync run history entry of the user being synced
+func (s *service) countWrites(rows int) {
+	if s.userRun != nil {
+		s.userRun.RowsWritten += rows
+	}
+}
+
+// estimateCalls is the number of API calls a regular sync of a user with the given number of
+// addresses makes: the profile, public profile and profile page, then positions and recent
+// trades per address, plus the profile and profile page of every address after the first.
+// Further pages of recent trades and trade reconciliation aren't included.
+func estimateCalls(addresses int) int {
+	return 3 + 2*addresses + 2*max(addresses-1, 0)
+}
+
+// syncOfficialPnl fetches the official PnL of a user's addresses and stores their sum as the
+// user's. The first address uses the Polymarket username already fetched with the profile,
+// the others are looked up first, and are only refreshed when due. Addresses that fail keep
+// what they had, so a failure doesn't drop a wallet from the sum.
+func (s *service) syncOfficialPnl(ctx context.Context, userID int64, username string, addresses, due []string, polymarketUsername string) {
+	names := map[string]string{addresses[0]: polymarketUsername}
+	for _, address := range due {
+		if address == addresses[0] {
+			continue
+		}
+		profile, err := s.client.GetUserProfile(ctx, address)
+		if err != nil {
+			s.log.WithError(err).WithFields(logrus.Fields{
+				"username": username,
+				"address":  address,
+			}).Warn("failed to fetch address profile")
+			s.recordSyncError(ctx, userID, address, "profile", err)
+			continue
+		}
+		if profile != nil {
+			names[address] = profile.Name
+		}
+	}
+
+	var (
+		pnl, volume float64
+		updated     int
+	)
+	for _, address := range addresses {
+		// Use the Polymarket pseudonym (case-sensitive username), profile URLs need it
+		name := names[address]
+		if name == "" {
+			continue
+		}
+
+		stats, err := s.client.GetPortfolioStats(ctx, name, address)
+		if err != nil {
+			s.log.WithError(err).WithFields(logrus.Fields{
+				"username":           username,
+				"polymarketUsername": name,
+				"address":            address,
+			}).Warn("failed to fetch portfolio stats")
+			s.recordSyncError(ctx, userID, address, "official_pnl", err)
+			continue
+		}
+		if stats == nil {
+			continue
+		}
+
+		pnl, volume, err = s.storage.UpdateAddressOfficialPnl(ctx, userID, address, stats.TotalPnl, stats.TotalVolume)
+		if err != nil {
+			s.log.WithError(err).WithFields(logrus.Fields{
+				"username": username,
+				"address":  address,
+			}).Warn("failed to update official pnl")
+			continue
+		}
+		updated++
+	}
+
+	if updated > 0 {
+		s.log.WithFields(logrus.Fields{
+			"username":  username,
+			"addresses": updated,
+			"pnl":       pnl,
+			"volume":    volume,
+		}).Info("updated official PnL from Polymarket")
+	}
+}
+
+// dueAddresses returns the addresses to sync this cycle: all of them, except on cycles between
+// dormant checks, when addresses that haven't traded in dormantAfter are left out. Addresses
+// without stored trades are never dormant, they may not have been synced yet.
+func (s *service) dueAddresses(ctx context.Context, addresses []string) []string {
+	if s.dormantAfter <= 0 || s.dormantCheckEvery <= 1 || s.cycle%s.dormantCheckEvery == 0 {
+		return addresses
+	}
+
+	lastTrades, err := s.storage.GetAddressLastTrades(ctx, addresses)
+	if err != nil {
+		s.log.WithError(err).Warn("failed to get address activity, syncing every address")
+		return addresses
+	}
+
+	due := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if last, ok := lastTrades[address]; ok && time.Since(last) > s.dormantAfter {
+			continue
+		}
+		due = append(due, address)
+	}
+	return due
+}
+
+// syncUser syncs data for a single user. Positions and trades are synced for the due
+// addresses only; the others keep what was stored when they were last synced.
+func (s *service) syncUser(ctx context.Context, username string, addresses, due []string) error {
 	s.log.WithFields(logrus.Fields{
 		"username":  username,
 		"addresses": len(addresses),
@@ -161,6 +753,7 @@ func (s *service) syncUser(ctx context.Context, username string, addresses []str
 		profile, err := s.client.GetUserProfile(ctx, addresses[0])
 		if err != nil {
 			s.log.WithError(err).WithField("username", username).Warn("failed to fetch user profile")
+			s.recordSyncError(ctx, user.ID, addresses[0], "profile", err)
 		} else if profile != nil {
 			// Get the correct Polymarket username (case-sensitive)
 			// Use Name (public display name) which is used in profile URLs
@@ -171,41 +764,37 @@ func (s *service) syncUser(ctx context.Context, username string, addresses []str
 				}
 			}
 		}
-	}
 
-	// Fetch official PnL from Polymarket profile page (all-time accurate data)
-	// Use the Polymarket pseudonym (case-sensitive username) if available
-	if polymarketUsername != "" && len(addresses) > 0 {
-		portfolioStats, err := s.client.GetPortfolioStats(ctx, polymarketUsername, addresses[0])
-		if err != nil {
-			s.log.WithError(err).WithFields(logrus.Fields{
-				"username":           username,
-				"polymarketUsername": polymarketUsername,
-			}).Warn("failed to fetch portfolio stats")
-		} else if portfolioStats != nil {
-			if err := s.storage.UpdateUserOfficialPnl(ctx, user.ID, portfolioStats.TotalPnl, portfolioStats.TotalVolume); err != nil {
-				s.log.WithError(err).WithField("username", username).Warn("failed to update official pnl")
-			} else {
-				s.log.WithFields(logrus.Fields{
-					"username":           username,
-					"polymarketUsername": polymarketUsername,
-					"pnl":                portfolioStats.TotalPnl,
-					"volume":             portfolioStats.TotalVolume,
-				}).Info("updated official PnL from Polymarket")
-			}
+		if err == nil {
+			s.syncIdentity(ctx, user.ID, addresses[0], profile)
 		}
 	}
 
-	// Clear existing positions (we'll replace with fresh data)
-	if err := s.storage.DeleteUserPositions(ctx, user.ID); err != nil {
-		return fmt.Errorf("failed to delete existing positions: %w", err)
+	// Fetch official PnL from Polymarket profile pages (all-time accurate data)
+	if len(addresses) > 0 {
+		s.syncOfficialPnl(ctx, user.ID, username, addresses, due, polymarketUsername)
+	}
+
+	// Keep the previous positions to detect opened and closed positions
+	previous, err := s.storage.GetUserPositions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing positions: %w", err)
+	}
+
+	// Positions are synced per address, so drop any held at addresses no longer tracked
+	pruned, err := s.storage.PruneUserPositions(ctx, user.ID, addresses)
+	if err != nil {
+		return fmt.Errorf("failed to prune positions: %w", err)
 	}
+	s.countWrites(pruned)
 
 	var totalPositions, totalTrades int
+	synced := make(map[string]bool, len(addresses))
+	var ingested []*storage.Trade
 
-	// Sync each address
-	for _, address := range addresses {
-		positions, trades, err := s.syncAddress(ctx, user.ID, address)
+	// Sync each due address
+	for _, address := range due {
+		positions, trades, inserted, err := s.syncAddress(ctx, user.ID, address)
 		if err != nil {
 			s.log.WithError(err).WithFields(logrus.Fields{
 				"username": username,
@@ -215,11 +804,29 @@ func (s *service) syncUser(ctx context.Context, username string, addresses []str
 		}
 		totalPositions += positions
 		totalTrades += trades
+		ingested = append(ingested, inserted...)
+		synced[address] = true
+	}
+
+	// New trades are classified as they're stored, but reconciliation removing trades and
+	// trades stored before classification existed still need it
+	classified, err := s.storage.ClassifyTrades(ctx, user.ID)
+	if err != nil {
+		s.log.WithError(err).WithField("username", username).Error("failed to classify trades")
+		s.recordSyncError(ctx, user.ID, "", "trades", err)
+	}
+	s.countWrites(classified)
+
+	// The first sync of a user reports what it finds as existing state, not as new positions
+	if user.LastSynced != nil {
+		s.publishPositionChanges(ctx, user.ID, previous, synced, ingested, *user.LastSynced)
 	}
 
 	// Take PNL snapshot
-	if err := s.takePnlSnapshot(ctx, user.ID); err != nil {
+	snapshot, err := s.takePnlSnapshot(ctx, user.ID)
+	if err != nil {
 		s.log.WithError(err).WithField("username", username).Error("failed to take pnl snapshot")
+		s.recordSyncError(ctx, user.ID, "", "snapshot", err)
 	}
 
 	// Update last synced timestamp
@@ -227,6 +834,8 @@ func (s *service) syncUser(ctx context.Context, username string, addresses []str
 		return fmt.Errorf("failed to update last synced: %w", err)
 	}
 
+	s.bus.Publish(ctx, events.Event{Type: events.UserSynced, UserID: user.ID, Snapshot: snapshot})
+
 	s.log.WithFields(logrus.Fields{
 		"username":  username,
 		"positions": totalPositions,
@@ -236,17 +845,21 @@ func (s *service) syncUser(ctx context.Context, username string, addresses []str
 	return nil
 }
 
-// syncAddress syncs data for a single address
-func (s *service) syncAddress(ctx context.Context, userID int64, address string) (int, int, error) {
+// syncAddress syncs data for a single address, returning the number of positions and trades
+// fetched and the trades newly stored
+func (s *service) syncAddress(ctx context.Context, userID int64, address string) (int, int, []*storage.Trade, error) {
 	s.log.WithField("address", address).Debug("syncing address")
 
 	// Fetch positions
 	positions, err := s.client.GetPositions(ctx, address)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to fetch positions: %w", err)
+		s.recordSyncError(ctx, userID, address, "positions", err)
+		return 0, 0, nil, fmt.Errorf("failed to fetch positions: %w", err)
 	}
 
-	// Store positions
+	// Store positions in a single batch
+	dbPositions := make([]*storage.Position, 0, len(positions))
+	var settlements []*storage.PositionSettlement
 	for _, pos := range positions {
 		dbPos := &storage.Position{
 			UserID:               userID,
@@ -265,7 +878,9 @@ func (s *service) syncAddress(ctx context.Context, userID int64, address string)
 
 		// Market info is inline in the API response
 		if pos.Title != "" {
+			display := titles.Display(pos.Title)
 			dbPos.MarketTitle = &pos.Title
+			dbPos.MarketDisplayTitle = &display
 		}
 		if pos.Slug != "" {
 			dbPos.MarketSlug = &pos.Slug
@@ -281,79 +896,202 @@ func (s *service) syncAddress(ctx context.Context, userID int64, address string)
 			dbPos.Outcome = &pos.Outcome
 		}
 
-		if err := s.storage.UpsertPosition(ctx, dbPos); err != nil {
-			s.log.WithError(err).WithField("condition_id", pos.ConditionID).Error("failed to upsert position")
+		dbPositions = append(dbPositions, dbPos)
+
+		if settlement := positionSettlement(userID, address, pos); settlement != nil {
+			settlements = append(settlements, settlement)
 		}
 	}
 
-	// Fetch trades (limit to last 100)
-	trades, err := s.client.GetTrades(ctx, address, 100)
+	// Only positions that changed since the last sync are written
+	written, err := s.storage.SyncAddressPositions(ctx, userID, address, dbPositions)
 	if err != nil {
-		return len(positions), 0, fmt.Errorf("failed to fetch trades: %w", err)
+		s.log.WithError(err).WithField("address", address).Error("failed to sync positions")
 	}
+	s.countWrites(written)
 
-	// Store trades
-	for _, trade := range trades {
-		dbTrade := &storage.Trade{
-			UserID:  userID,
-			Address: address,
-			Price:   trade.Price,
-			Size:    trade.Size,
-		}
+	// Current prices build the hourly price history shown on results once markets resolve
+	recorded, err := s.storage.RecordPrices(ctx, dbPositions)
+	if err != nil {
+		s.log.WithError(err).WithField("address", address).Error("failed to record prices")
+	}
+	s.countWrites(recorded)
 
-		if trade.ID != "" {
-			dbTrade.TradeID = &trade.ID
-		}
-		if trade.ConditionID != "" {
-			dbTrade.ConditionID = &trade.ConditionID
-		}
-		if trade.Outcome != "" {
-			dbTrade.Outcome = &trade.Outcome
-		}
-		if trade.Side != "" {
-			dbTrade.Side = &trade.Side
-		}
-		if trade.Timestamp > 0 {
-			// Convert Unix timestamp to time.Time
-			ts := time.Unix(trade.Timestamp, 0)
-			dbTrade.Timestamp = &ts
-		}
+	resolved, err := s.storage.RecordPositionSettlements(ctx, settlements)
+	if err != nil {
+		s.log.WithError(err).WithField("address", address).Error("failed to record position settlements")
+	}
+	s.countWrites(len(resolved))
+	for _, settlement := range resolved {
+		s.bus.Publish(ctx, events.Event{
+			Type:       events.MarketResolved,
+			UserID:     userID,
+			Address:    address,
+			Settlement: settlement,
+		})
+	}
 
-		// Market info is inline
-		if trade.Title != "" {
-			dbTrade.MarketTitle = &trade.Title
-		}
-		if trade.Slug != "" {
-			dbTrade.MarketSlug = &trade.Slug
+	// Trades are paged newest first until a page holds nothing new, so a burst of trading
+	// between syncs isn't cut off at one page
+	var fetched int
+	var ingested []*storage.Trade
+	books := make(map[string]*OrderBookResponse)
+	for offset := 0; offset <= maxTradesOffset; offset += recentTradesPageSize {
+		trades, err := s.client.GetTrades(ctx, address, recentTradesPageSize, offset)
+		if err != nil {
+			s.recordSyncError(ctx, userID, address, "trades", err)
+			if offset == 0 {
+				return len(positions), 0, nil, fmt.Errorf("failed to fetch trades: %w", err)
+			}
+			// The pages already stored are kept, the rest is picked up by the next sync
+			break
 		}
+		fetched += len(trades)
 
-		// Calculate value if not present
-		if trade.Price != nil && trade.Size != nil {
-			value := *trade.Price * *trade.Size
-			dbTrade.Value = &value
+		inserted := s.storeTrades(ctx, userID, address, trades, books)
+		ingested = append(ingested, inserted...)
+		if len(inserted) == 0 || len(trades) < recentTradesPageSize {
+			break
 		}
+	}
 
-		if err := s.storage.InsertTrade(ctx, dbTrade); err != nil {
-			// Ignore duplicate trade errors
-			s.log.WithError(err).WithField("trade_id", trade.ID).Debug("failed to insert trade (likely duplicate)")
+	if s.reconcileDue(address) {
+		if err := s.reconcileAddress(ctx, userID, address); err != nil {
+			s.log.WithError(err).WithField("address", address).Warn("failed to reconcile trades")
+			s.recordSyncError(ctx, userID, address, "reconcile", err)
 		}
 	}
 
 	s.log.WithFields(logrus.Fields{
 		"address":   address,
 		"positions": len(positions),
-		"trades":    len(trades),
+		"trades":    fetched,
 	}).Debug("address sync completed")
 
-	return len(positions), len(trades), nil
+	return len(positions), fetched, ingested, nil
+}
+
+// storeTrades stores fetched trades of an address, returning those not already stored
+func (s *service) storeTrades(ctx context.Context, userID int64, address string, trades TradesResponse, books map[string]*OrderBookResponse) []*storage.Trade {
+	var ingested []*storage.Trade
+	for _, trade := range trades {
+		dbTrade := StorageTrade(userID, address, trade)
+
+		inserted, err := s.storage.InsertTrade(ctx, dbTrade)
+		if err != nil {
+			// Ignore duplicate trade errors
+			s.log.WithError(err).WithField("trade_id", trade.ID).Debug("failed to insert trade (likely duplicate)")
+			continue
+		}
+		if inserted {
+			s.countWrites(1)
+			ingested = append(ingested, dbTrade)
+			if s.bookMaxAge > 0 && trade.Asset != "" && dbTrade.Timestamp != nil && time.Since(*dbTrade.Timestamp) <= s.bookMaxAge {
+				s.snapshotBook(ctx, dbTrade, trade.Asset, books)
+			}
+			s.bus.Publish(ctx, events.Event{
+				Type:    events.TradeIngested,
+				UserID:  userID,
+				Address: address,
+				Trade:   dbTrade,
+			})
+		}
+	}
+	return ingested
+}
+
+// StorageTrade converts a trade fetched for an address to the stored representation
+func StorageTrade(userID int64, address string, trade TradeResponse) *storage.Trade {
+	dbTrade := &storage.Trade{
+		UserID:  userID,
+		Address: address,
+		Price:   trade.Price,
+		Size:    trade.Size,
+	}
+
+	if trade.ID != "" {
+		dbTrade.TradeID = &trade.ID
+	}
+	if trade.ConditionID != "" {
+		dbTrade.ConditionID = &trade.ConditionID
+	}
+	if trade.Outcome != "" {
+		dbTrade.Outcome = &trade.Outcome
+	}
+	if trade.Side != "" {
+		dbTrade.Side = &trade.Side
+	}
+	if trade.Timestamp > 0 {
+		// Convert Unix timestamp to time.Time
+		ts := time.Unix(trade.Timestamp, 0)
+		dbTrade.Timestamp = &ts
+	}
+
+	// Market info is inline
+	if trade.Title != "" {
+		display := titles.Display(trade.Title)
+		dbTrade.MarketTitle = &trade.Title
+		dbTrade.MarketDisplayTitle = &display
+	}
+	if trade.Slug != "" {
+		dbTrade.MarketSlug = &trade.Slug
+	}
+	if trade.EventSlug != "" {
+		dbTrade.EventSlug = &trade.EventSlug
+	}
+
+	// Calculate value if not present
+	if trade.Price != nil && trade.Size != nil {
+		value := *trade.Price * *trade.Size
+		dbTrade.Value = &value
+	}
+
+	return dbTrade
+}
+
+// positionSettlement archives a position of a resolved market at its final settlement price.
+// Resolved outcomes trade at (or within rounding of) 0 or 1, so the current price is snapped.
+func positionSettlement(userID int64, address string, pos PositionResponse) *storage.PositionSettlement {
+	if !pos.Redeemable || pos.CurrentPrice == nil || pos.Size == nil || pos.AvgPrice == nil {
+		return nil
+	}
+
+	settlementPrice := 0.0
+	if *pos.CurrentPrice >= 0.5 {
+		settlementPrice = 1.0
+	}
+
+	settlement := &storage.PositionSettlement{
+		UserID:          userID,
+		Address:         address,
+		ConditionID:     pos.ConditionID,
+		Asset:           pos.Asset,
+		Size:            *pos.Size,
+		AvgPrice:        *pos.AvgPrice,
+		SettlementPrice: settlementPrice,
+		Pnl:             (settlementPrice - *pos.AvgPrice) * *pos.Size,
+		ResolvedAt:      time.Now().UTC(),
+	}
+
+	if pos.Outcome != "" {
+		settlement.Outcome = &pos.Outcome
+	}
+	if pos.Title != "" {
+		settlement.MarketTitle = &pos.Title
+	}
+	if pos.Slug != "" {
+		settlement.MarketSlug = &pos.Slug
+	}
+
+	return settlement
 }
 
 // takePnlSnapshot takes a snapshot of current PNL for a user
-func (s *service) takePnlSnapshot(ctx context.Context, userID int64) error {
+func (s *service) takePnlSnapshot(ctx context.Context, userID int64) (*storage.PnlSnapshot, error) {
 	// Get all users and find the matching one
 	users, err := s.storage.GetUsers(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get users: %w", err)
+		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 
 	var username string
@@ -365,12 +1103,12 @@ func (s *service) takePnlSnapshot(ctx context.Context, userID int64) error {
 	}
 
 	if username == "" {
-		return fmt.Errorf("user not found with id %d", userID)
+		return nil, fmt.Errorf("user not found with id %d", userID)
 	}
 
 	stats, err := s.storage.GetUserStats(ctx, username)
 	if err != nil {
-		return fmt.Errorf("failed to get user stats: %w", err)
+		return nil, fmt.Errorf("failed to get user stats: %w", err)
 	}
 
 	snapshot := &storage.PnlSnapshot{
@@ -382,8 +1120,204 @@ func (s *service) takePnlSnapshot(ctx context.Context, userID int64) error {
 	}
 
 	if err := s.storage.InsertPnlSnapshot(ctx, snapshot); err != nil {
-		return fmt.Errorf("failed to insert pnl snapshot: %w", err)
+		return nil, fmt.Errorf("failed to insert pnl snapshot: %w", err)
+	}
+	s.countWrites(1)
+
+	return snapshot, nil
+}
+
+// reconcileDue reports whether an address is due for trade reconciliation and marks it as reconciled
+func (s *service) reconcileDue(address string) bool {
+	if s.reconcileInterval <= 0 {
+		return false
+	}
+
+	s.reconcileMu.Lock()
+	defer s.reconcileMu.Unlock()
+
+	if last, ok := s.lastReconciled[address]; ok && time.Since(last) < s.reconcileInterval {
+		return false
+	}
+	s.lastReconciled[address] = time.Now()
+
+	return true
+}
+
+// reconcileAddress re-fetches the full trade history for an address and tombstones stored
+// trades that Polymarket no longer returns
+func (s *service) reconcileAddress(ctx context.Context, userID int64, address string) error {
+	trades, err := s.client.GetAllTrades(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to fetch full trade history: %w", err)
+	}
+
+	// Without any upstream trades we can't tell a correction from an API hiccup
+	if len(trades) == 0 {
+		return nil
+	}
+
+	upstream := make(map[storage.TradeKey]struct{}, len(trades))
+	since := time.Now()
+	for _, trade := range trades {
+		if trade.Price == nil || trade.Size == nil || trade.Timestamp <= 0 {
+			continue
+		}
+
+		upstream[storage.TradeKey{
+			ConditionID: trade.ConditionID,
+			Timestamp:   trade.Timestamp,
+			Side:        trade.Side,
+			Size:        *trade.Size,
+			Price:       *trade.Price,
+		}] = struct{}{}
+
+		if ts := time.Unix(trade.Timestamp, 0); ts.Before(since) {
+			since = ts
+		}
+	}
+
+	// Only trades inside the re-fetched window are compared, older history may be truncated upstream
+	result, err := s.storage.ReconcileTrades(ctx, userID, address, since, upstream)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile trades: %w", err)
+	}
+	s.countWrites(result.Removed + result.Restored)
+
+	fields := logrus.Fields{
+		"address":  address,
+		"upstream": len(upstream),
+		"checked":  result.Checked,
+		"removed":  result.Removed,
+		"restored": result.Restored,
+	}
+	if result.Removed > 0 || result.Restored > 0 {
+		s.log.WithFields(fields).Warn("trades diverged from upstream")
+	} else {
+		s.log.WithFields(fields).Debug("trades reconciled")
 	}
 
 	return nil
 }
+
+// recordSyncError persists a sync failure so flaky addresses can be diagnosed from the API
+func (s *service) recordSyncError(ctx context.Context, userID int64, address, phase string, err error) {
+	syncErr := &storage.SyncError{
+		UserID:    userID,
+		Phase:     phase,
+		Message:   err.Error(),
+		Timestamp: time.Now(),
+	}
+	if address != "" {
+		syncErr.Address = &address
+	}
+	if s.userRun != nil {
+		s.userRun.Errors++
+	}
+
+	if err := s.storage.RecordSyncError(ctx, syncErr, s.errorHistory); err != nil {
+		s.log.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"phase":   phase,
+		}).Warn("failed to record sync error")
+	}
+
+	s.bus.Publish(ctx, events.Event{
+		Type:      events.SyncFailed,
+		Time:      syncErr.Timestamp,
+		UserID:    userID,
+		Address:   address,
+		SyncError: syncErr,
+	})
+}
+
+// publishPositionChanges compares a user's positions before and after a sync and publishes
+// opened and closed positions. Only addresses that synced are compared, so a failed fetch
+// isn't mistaken for every position of the address closing. Positions bought and sold off
+// between syncs never show in either, so closes are also inferred from the trades ingested
+// that were made since the last sync. Older ones are history, e.g. of a newly added address.
+func (s *service) publishPositionChanges(ctx context.Context, userID int64, previous []*storage.Position, synced map[string]bool, ingested []*storage.Trade, lastSynced time.Time) {
+	current, err := s.storage.GetUserPositions(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to get positions for change events")
+		return
+	}
+
+	type positionKey struct{ address, conditionID, asset string }
+	keyOf := func(pos *storage.Position) positionKey {
+		return positionKey{pos.Address, pos.ConditionID, pos.Asset}
+	}
+
+	before := make(map[positionKey]bool, len(previous))
+	for _, pos := range previous {
+		before[keyOf(pos)] = true
+	}
+	after := make(map[positionKey]bool, len(current))
+	for _, pos := range current {
+		after[keyOf(pos)] = true
+	}
+
+	for _, pos := range current {
+		if !before[keyOf(pos)] {
+			s.bus.Publish(ctx, events.Event{Type: events.PositionOpened, UserID: userID, Address: pos.Address, Position: pos})
+		}
+	}
+
+	// Outcomes whose close was seen in the diff, not to be published again from trades
+	type outcomeKey struct{ address, conditionID, outcome string }
+	closed := make(map[outcomeKey]bool)
+	for _, pos := range previous {
+		if synced[pos.Address] && !after[keyOf(pos)] {
+			s.bus.Publish(ctx, events.Event{Type: events.PositionClosed, UserID: userID, Address: pos.Address, Position: pos})
+			if pos.Outcome != nil {
+				closed[outcomeKey{pos.Address, pos.ConditionID, *pos.Outcome}] = true
+			}
+		}
+	}
+
+	ids := make([]int64, 0, len(ingested))
+	for _, trade := range ingested {
+		if trade.Timestamp != nil && trade.Timestamp.After(lastSynced) {
+			ids = append(ids, trade.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	holdings, err := s.storage.GetClosedHoldings(ctx, userID, ids)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Warn("failed to get closed holdings for change events")
+		return
+	}
+
+	for _, holding := range holdings {
+		trade := holding.Trade
+		key := outcomeKey{trade.Address, *trade.ConditionID, *trade.Outcome}
+		if closed[key] {
+			continue
+		}
+
+		size := 0.0
+		pos := &storage.Position{
+			UserID:      userID,
+			Address:     trade.Address,
+			ConditionID: *trade.ConditionID,
+			MarketTitle: trade.MarketTitle,
+			MarketSlug:  trade.MarketSlug,
+			Outcome:     trade.Outcome,
+			Size:        &size,
+			RealizedPnl: &holding.RealizedPnl,
+		}
+		if trade.Timestamp != nil {
+			pos.UpdatedAt = *trade.Timestamp
+		}
+
+		s.log.WithFields(logrus.Fields{
+			"user_id":      userID,
+			"condition_id": pos.ConditionID,
+			"realized_pnl": holding.RealizedPnl,
+		}).Debug("inferred position close from trades")
+		s.bus.Publish(ctx, events.Event{Type: events.PositionClosed, UserID: userID, Address: trade.Address, Position: pos})
+	}
+}
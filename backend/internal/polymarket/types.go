@@ -17,6 +17,8 @@ type PositionResponse struct {
 	// percentPnl is the unrealized PnL percent
 	UnrealizedPnlPercent *float64 `json:"percentPnl"`
 	RealizedPnl          *float64 `json:"realizedPnl"`
+	// Redeemable is set once the market has resolved and the outcome can be redeemed
+	Redeemable bool `json:"redeemable"`
 	// Market info is inline, not nested
 	Title   string `json:"title"`
 	Slug    string `json:"slug"`
@@ -34,6 +36,7 @@ type Market struct {
 type TradeResponse struct {
 	ID          string   `json:"id"`
 	ConditionID string   `json:"conditionId"`
+	Asset       string   `json:"asset"` // CLOB token ID of the outcome traded
 	Outcome     string   `json:"outcome"`
 	Side        string   `json:"side"` // BUY or SELL
 	Price       *float64 `json:"price"`
@@ -55,6 +58,7 @@ type ActivityResponse struct {
 	Side        string   `json:"side"`
 	Price       *float64 `json:"price"`
 	Size        *float64 `json:"size"`
+	UsdcSize    *float64 `json:"usdcSize"` // USDC amount of a trade
 	Timestamp   int64    `json:"timestamp"`
 	Title       string   `json:"title"`
 	Slug        string   `json:"slug"`
@@ -78,6 +82,14 @@ type ProfileResponse struct {
 	ProfileImageOptimized string `json:"profileImageOptimized"`
 }
 
+// PublicProfileResponse represents a profile from the Polymarket gamma API
+type PublicProfileResponse struct {
+	Name      string `json:"name"`
+	Pseudonym string `json:"pseudonym"`
+	Bio       string `json:"bio"`
+	XUsername string `json:"xUsername"`
+}
+
 // PortfolioStats represents the all-time portfolio statistics from Polymarket
 type PortfolioStats struct {
 	TotalPnl      float64 `json:"pnl"`
@@ -85,3 +97,59 @@ type PortfolioStats struct {
 	RealizedPnl   float64 `json:"realized"`
 	UnrealizedPnl float64 `json:"unrealized"`
 }
+
+// OrderBookResponse is an outcome token's order book from the CLOB API
+type OrderBookResponse struct {
+	AssetID string           `json:"asset_id"`
+	Bids    []OrderBookLevel `json:"bids"`
+	Asks    []OrderBookLevel `json:"asks"`
+}
+
+// OrderBookLevel is a price level of an order book. The CLOB API sends numbers as strings.
+type OrderBookLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// LeaderboardEntryResponse is a trader on Polymarket's public leaderboard
+type LeaderboardEntryResponse struct {
+	ProxyWallet  string  `json:"proxyWallet"`
+	UserName     string  `json:"userName"`
+	Volume       float64 `json:"vol"`
+	Pnl          float64 `json:"pnl"`
+	ProfileImage string  `json:"profileImage"`
+}
+
+// LeaderboardResponse is a page of the leaderboard, best first
+type LeaderboardResponse []LeaderboardEntryResponse
+
+// MarketResponse is a market from the gamma API. Newer markets often have no category of
+// their own, only through their event.
+type MarketResponse struct {
+	ConditionID string `json:"conditionId"`
+	Question    string `json:"question"`
+	Slug        string `json:"slug"`
+	Category    string `json:"category"`
+	EndDate     string `json:"endDate"` // RFC 3339, empty when the market has none
+	Closed      bool   `json:"closed"`
+	// Outcomes and OutcomePrices are JSON arrays encoded as strings, e.g. "[\"Yes\", \"No\"]"
+	// and "[\"0.62\", \"0.38\"]", in the same order
+	Outcomes      string          `json:"outcomes"`
+	OutcomePrices string          `json:"outcomePrices"`
+	Events        []EventResponse `json:"events"`
+}
+
+// MarketsResponse is a list of gamma API markets
+type MarketsResponse []MarketResponse
+
+// EventResponse is an event from the gamma API, grouping markets. Events embedded in a market
+// have no markets of their own.
+type EventResponse struct {
+	Slug     string           `json:"slug"`
+	Title    string           `json:"title"`
+	Category string           `json:"category"`
+	Markets  []MarketResponse `json:"markets"`
+}
+
+// EventsResponse is a list of gamma API events
+type EventsResponse []EventResponse
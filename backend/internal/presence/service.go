@@ -0,0 +1,106 @@
+package presence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Service tells which users are trading now, those who traded within the active window, and
+// announces when a user starts trading after a quiet spell
+type Service interface {
+	// Start watches ingested trades for users starting to trade
+	Start(ctx context.Context) error
+	Stop() error
+	// Active reports whether a user whose last trade was at lastTradeAt is trading now
+	Active(lastTradeAt *time.Time) bool
+}
+
+// service implements the presence Service
+type service struct {
+	storage     storage.Storage
+	bus         events.Bus
+	window      time.Duration
+	log         logrus.FieldLogger
+	unsubscribe func()
+
+	mu sync.Mutex
+	// started is when each user's current trading spell was announced to have started
+	started map[int64]time.Time
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new presence service. Users are active for activeMinutes after a trade,
+// and start trading when a trade comes in after activeMinutes without one.
+func NewService(storage storage.Storage, bus events.Bus, activeMinutes int, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		bus:     bus,
+		window:  time.Duration(activeMinutes) * time.Minute,
+		log:     log.WithField("package", "presence"),
+		started: make(map[int64]time.Time),
+	}
+}
+
+// Start subscribes to ingested trades
+func (s *service) Start(_ context.Context) error {
+	s.unsubscribe = s.bus.Subscribe("presence", s.handle, events.TradeIngested)
+	return nil
+}
+
+// Stop unsubscribes from ingested trades
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// Active reports whether lastTradeAt falls within the active window
+func (s *service) Active(lastTradeAt *time.Time) bool {
+	return lastTradeAt != nil && time.Since(*lastTradeAt) <= s.window
+}
+
+// handle announces a user starting to trade when a recent trade follows a quiet spell. A sync
+// can ingest several trades of the spell in any order, so a spell is announced only once.
+func (s *service) handle(ctx context.Context, event events.Event) {
+	trade := event.Trade
+	// Trades synced long after they were made, such as a new user's history, aren't news
+	if trade == nil || trade.Timestamp == nil || !s.Active(trade.Timestamp) {
+		return
+	}
+	at := *trade.Timestamp
+
+	s.mu.Lock()
+	started, ok := s.started[event.UserID]
+	s.mu.Unlock()
+	if ok && at.Sub(started) > -s.window && at.Sub(started) < s.window {
+		return
+	}
+
+	previous, err := s.storage.GetUserLastTradeBefore(ctx, event.UserID, at)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to get previous trade")
+		return
+	}
+	if previous != nil && at.Sub(*previous) <= s.window {
+		return
+	}
+
+	s.mu.Lock()
+	s.started[event.UserID] = at
+	s.mu.Unlock()
+
+	fields := logrus.Fields{"user_id": event.UserID}
+	if previous != nil {
+		fields["quiet_for"] = at.Sub(*previous).Round(time.Minute)
+	}
+	s.log.WithFields(fields).Info("user started trading")
+
+	s.bus.Publish(ctx, events.Event{Type: events.TradingStarted, UserID: event.UserID, Address: event.Address, Trade: trade})
+}
@@ -0,0 +1,230 @@
+package recompute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/backfill"
+	"github.com/samcm/pyre/internal/badges"
+	"github.com/samcm/pyre/internal/milestones"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Job statuses
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed" // finished, possibly with per-user errors
+	StatusFailed    = "failed"    // stopped before processing every user
+)
+
+// keepJobs is how many finished jobs are remembered
+const keepJobs = 20
+
+// ErrRunning is returned when a recompute is requested while one is running
+var ErrRunning = errors.New("a recompute is already running")
+
+// Job is a recompute of every user's derived stats
+type Job struct {
+	ID         string
+	Status     string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Users      int // users to recompute
+	UsersDone  int // users recomputed so far, including those that failed
+
+	TradesClassified int // trades whose position change was updated
+	SnapshotsCreated int // PnL snapshots rebuilt from trades
+	Badges           int // badges newly awarded
+	Milestones       int // milestones newly recorded
+
+	Errors []string // one per user whose recompute failed, and the job's own failure
+}
+
+// Service rebuilds the stats derived from stored trades, after data fixes or imports
+type Service interface {
+	Start(ctx context.Context) error
+	// Stop cancels a running recompute and waits for it to finish
+	Stop() error
+	// Run starts a recompute in the background, or returns ErrRunning
+	Run() (*Job, error)
+	// Job returns a copy of a recent job, nil when it's unknown
+	Job(id string) *Job
+}
+
+// service implements the recompute Service
+type service struct {
+	storage    storage.Storage
+	backfill   backfill.Service
+	badges     badges.Service
+	milestones milestones.Service
+	log        logrus.FieldLogger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	nextID int
+	jobs   []*Job // oldest first, the last one may be running
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new recompute service
+func NewService(storage storage.Storage, backfill backfill.Service, badges badges.Service, milestones milestones.Service, log logrus.FieldLogger) Service {
+	return &service{
+		storage:    storage,
+		backfill:   backfill,
+		badges:     badges,
+		milestones: milestones,
+		log:        log.WithField("package", "recompute"),
+	}
+}
+
+// Start prepares the context recomputes run in
+func (s *service) Start(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	return nil
+}
+
+// Stop cancels a running recompute and waits for it
+func (s *service) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+// Run starts recomputing every user
+func (s *service) Run() (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.jobs) > 0 && s.jobs[len(s.jobs)-1].Status == StatusRunning {
+		return nil, ErrRunning
+	}
+
+	s.nextID++
+	job := &Job{ID: strconv.Itoa(s.nextID), Status: StatusRunning, StartedAt: time.Now()}
+	s.jobs = append(s.jobs, job)
+	if len(s.jobs) > keepJobs {
+		s.jobs = s.jobs[len(s.jobs)-keepJobs:]
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.ctx, job)
+	}()
+
+	return copyJob(job), nil
+}
+
+// Job returns a copy of a recent job
+func (s *service) Job(id string) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.ID == id {
+			return copyJob(job)
+		}
+	}
+	return nil
+}
+
+// run recomputes each user in turn. A user failing is recorded and the rest carry on.
+func (s *service) run(ctx context.Context, job *Job) {
+	log := s.log.WithField("job", job.ID)
+	log.Info("recompute started")
+
+	users, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		s.finish(job, fmt.Errorf("failed to get users: %w", err))
+		log.WithError(err).Error("recompute failed")
+		return
+	}
+
+	s.update(func() { job.Users = len(users) })
+
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			s.finish(job, fmt.Errorf("recompute cancelled: %w", err))
+			log.Warn("recompute cancelled")
+			return
+		}
+
+		if err := s.recomputeUser(ctx, job, user); err != nil {
+			log.WithError(err).WithField("username", user.Username).Error("failed to recompute user")
+			s.update(func() { job.Errors = append(job.Errors, fmt.Sprintf("%s: %s", user.Username, err)) })
+		}
+		s.update(func() { job.UsersDone++ })
+	}
+
+	s.finish(job, nil)
+	log.WithField("users", len(users)).Info("recompute finished")
+}
+
+// recomputeUser reclassifies a user's trades, rebuilds their PnL history before the first
+// synced snapshot with FIFO cost basis, and re-evaluates their badges and milestones
+func (s *service) recomputeUser(ctx context.Context, job *Job, user *storage.User) error {
+	classified, err := s.storage.ClassifyTrades(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to classify trades: %w", err)
+	}
+	s.update(func() { job.TradesClassified += classified })
+
+	result, err := s.backfill.BackfillUser(ctx, user.Username, false)
+	if err != nil {
+		return fmt.Errorf("failed to backfill pnl history: %w", err)
+	}
+	s.update(func() { job.SnapshotsCreated += result.SnapshotsCreated })
+
+	awarded, err := s.badges.Evaluate(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate badges: %w", err)
+	}
+	s.update(func() { job.Badges += len(awarded) })
+
+	reached, err := s.milestones.Evaluate(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate milestones: %w", err)
+	}
+	s.update(func() { job.Milestones += len(reached) })
+
+	return nil
+}
+
+// update changes a job under the lock, as it's read by Job while running
+func (s *service) update(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+// finish marks a job done, failed when err is set
+func (s *service) finish(job *Job, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = StatusCompleted
+	if err != nil {
+		job.Status = StatusFailed
+		job.Errors = append(job.Errors, err.Error())
+	}
+}
+
+// copyJob copies a job so it can be read without the lock
+func copyJob(job *Job) *Job {
+	c := *job
+	c.Errors = append([]string{}, job.Errors...)
+	return &c
+}
@@ -0,0 +1,306 @@
+package replication
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// restartDelay is how long to wait before restarting litestream after it exits unexpectedly
+const restartDelay = 5 * time.Second
+
+// stopTimeout is how long to wait for litestream to flush and exit before killing it
+const stopTimeout = 10 * time.Second
+
+// Options configures continuous replication of the database
+type Options struct {
+	LitestreamPath   string
+	ReplicaURL       string
+	SyncInterval     time.Duration
+	SnapshotInterval time.Duration
+	Retention        time.Duration
+	RestoreOnStart   bool
+	RestoreTimestamp *time.Time // restore to this point in time instead of the latest state
+}
+
+// Service continuously replicates the SQLite database to object storage using Litestream
+type Service interface {
+	// Restore restores the database from the replica if the database file does not exist.
+	// It must be called before storage is started.
+	Restore(ctx context.Context) error
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// service runs litestream as a managed subprocess
+type service struct {
+	dbPath     string
+	opts       Options
+	configPath string
+	log        logrus.FieldLogger
+
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	stopping bool
+	done     chan struct{}
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new replication service
+func NewService(dbPath string, opts Options, log logrus.FieldLogger) Service {
+	return &service{
+		dbPath: dbPath,
+		opts:   opts,
+		log:    log.WithField("package", "replication"),
+	}
+}
+
+// litestreamConfig is the subset of the litestream config file used by pyre.
+// It is written as JSON, which litestream reads as YAML.
+type litestreamConfig struct {
+	DBs []litestreamDB `json:"dbs"`
+}
+
+type litestreamDB struct {
+	Path     string              `json:"path"`
+	Replicas []litestreamReplica `json:"replicas"`
+}
+
+type litestreamReplica struct {
+	URL              string `json:"url"`
+	SyncInterval     string `json:"sync-interval,omitempty"`
+	SnapshotInterval string `json:"snapshot-interval,omitempty"`
+	Retention        string `json:"retention,omitempty"`
+}
+
+// writeConfig writes the litestream config file if it has not been written yet
+func (s *service) writeConfig() error {
+	if s.configPath != "" {
+		return nil
+	}
+
+	if _, err := exec.LookPath(s.opts.LitestreamPath); err != nil {
+		return fmt.Errorf("litestream binary not found: %w", err)
+	}
+
+	dbPath, err := filepath.Abs(s.dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve database path: %w", err)
+	}
+
+	replica := litestreamReplica{URL: s.opts.ReplicaURL}
+	if s.opts.SyncInterval > 0 {
+		replica.SyncInterval = s.opts.SyncInterval.String()
+	}
+	if s.opts.SnapshotInterval > 0 {
+		replica.SnapshotInterval = s.opts.SnapshotInterval.String()
+	}
+	if s.opts.Retention > 0 {
+		replica.Retention = s.opts.Retention.String()
+	}
+
+	data, err := json.Marshal(litestreamConfig{
+		DBs: []litestreamDB{{Path: dbPath, Replicas: []litestreamReplica{replica}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal litestream config: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "pyre-litestream-*.yml")
+	if err != nil {
+		return fmt.Errorf("failed to create litestream config: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write litestream config: %w", err)
+	}
+
+	s.configPath = file.Name()
+	return nil
+}
+
+// Restore restores the database from the replica if the database file does not exist
+func (s *service) Restore(ctx context.Context) error {
+	if !s.opts.RestoreOnStart {
+		return nil
+	}
+
+	if _, err := os.Stat(s.dbPath); err == nil {
+		s.log.WithField("path", s.dbPath).Debug("database exists, skipping restore")
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to stat database: %w", err)
+	}
+
+	if err := s.writeConfig(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.dbPath), 0755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	args := []string{"restore", "-config", s.configPath, "-if-replica-exists"}
+	if s.opts.RestoreTimestamp != nil {
+		args = append(args, "-timestamp", s.opts.RestoreTimestamp.UTC().Format(time.RFC3339))
+	}
+	args = append(args, s.dbPath)
+
+	s.log.WithFields(logrus.Fields{
+		"path":      s.dbPath,
+		"replica":   s.opts.ReplicaURL,
+		"timestamp": s.opts.RestoreTimestamp,
+	}).Info("restoring database from replica")
+
+	cmd := exec.CommandContext(ctx, s.opts.LitestreamPath, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to restore database: %w: %s", err, output)
+	}
+
+	if _, err := os.Stat(s.dbPath); err != nil {
+		s.log.Info("no replica found, starting with an empty database")
+	} else {
+		s.log.Info("database restored from replica")
+	}
+
+	return nil
+}
+
+// Start starts continuous replication
+func (s *service) Start(ctx context.Context) error {
+	s.log.WithField("replica", s.opts.ReplicaURL).Info("starting replication")
+
+	if err := s.writeConfig(); err != nil {
+		return err
+	}
+
+	s.done = make(chan struct{})
+
+	s.mu.Lock()
+	err := s.startProcess()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go s.supervise()
+
+	return nil
+}
+
+// startProcess launches litestream replicate and forwards its output to the logger.
+// The caller must hold s.mu.
+func (s *service) startProcess() error {
+	cmd := exec.Command(s.opts.LitestreamPath, "replicate", "-config", s.configPath)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to attach litestream stdout: %w", err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start litestream: %w", err)
+	}
+
+	go s.forwardOutput(stdout)
+
+	s.cmd = cmd
+
+	return nil
+}
+
+// forwardOutput logs each line litestream writes
+func (s *service) forwardOutput(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		s.log.WithField("source", "litestream").Info(scanner.Text())
+	}
+}
+
+// supervise waits for litestream to exit and restarts it unless the service is stopping
+func (s *service) supervise() {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		cmd := s.cmd
+		s.mu.Unlock()
+
+		err := cmd.Wait()
+
+		s.mu.Lock()
+		stopping := s.stopping
+		s.mu.Unlock()
+
+		if stopping {
+			return
+		}
+
+		s.log.WithError(err).Error("litestream exited unexpectedly, restarting")
+		time.Sleep(restartDelay)
+
+		// Check and restart under the lock so Stop always signals the current process
+		s.mu.Lock()
+		if s.stopping {
+			s.mu.Unlock()
+			return
+		}
+		err = s.startProcess()
+		s.mu.Unlock()
+
+		if err != nil {
+			s.log.WithError(err).Error("failed to restart litestream")
+			return
+		}
+	}
+}
+
+// Stop stops replication, giving litestream time to flush pending changes
+func (s *service) Stop() error {
+	s.log.Info("stopping replication")
+
+	s.mu.Lock()
+	s.stopping = true
+	cmd := s.cmd
+	s.mu.Unlock()
+
+	if cmd != nil && cmd.Process != nil {
+		if err := cmd.Process.Signal(syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			s.log.WithError(err).Warn("failed to signal litestream")
+		}
+
+		select {
+		case <-s.done:
+		case <-time.After(stopTimeout):
+			s.log.Warn("litestream did not exit in time, killing")
+			if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+				return fmt.Errorf("failed to kill litestream: %w", err)
+			}
+			<-s.done
+		}
+	}
+
+	if s.configPath != "" {
+		if err := os.Remove(s.configPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.log.WithError(err).Warn("failed to remove litestream config")
+		}
+	}
+
+	s.log.Info("replication stopped")
+	return nil
+}
@@ -0,0 +1,98 @@
+package retention
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Service periodically downsamples old PnL snapshots, so the history of a user keeps growing
+// by a bounded number of snapshots per day instead of one per sync
+type Service interface {
+	// Start downsamples once and then every interval
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// service implements the retention Service
+type service struct {
+	storage  storage.Storage
+	tiers    []storage.SnapshotTier
+	interval time.Duration
+	log      logrus.FieldLogger
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new retention service applying tiers every interval
+func NewService(storage storage.Storage, tiers []storage.SnapshotTier, interval time.Duration, log logrus.FieldLogger) Service {
+	return &service{
+		storage:  storage,
+		tiers:    tiers,
+		interval: interval,
+		log:      log.WithField("package", "retention"),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins downsampling in the background
+func (s *service) Start(ctx context.Context) error {
+	s.wg.Add(1)
+	go s.run(ctx)
+
+	s.log.WithFields(logrus.Fields{
+		"tiers":    len(s.tiers),
+		"interval": s.interval.String(),
+	}).Info("retention service started")
+	return nil
+}
+
+// Stop waits for a running downsample to finish
+func (s *service) Stop() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
+
+// run downsamples on start and then on every tick
+func (s *service) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	s.downsample(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.downsample(ctx)
+		}
+	}
+}
+
+// downsample applies the tiers, logging how many snapshots were deleted
+func (s *service) downsample(ctx context.Context) {
+	start := time.Now()
+	deleted, err := s.storage.DownsamplePnlSnapshots(ctx, s.tiers, start.UTC())
+	if err != nil {
+		s.log.WithError(err).Error("failed to downsample pnl snapshots")
+		return
+	}
+	if deleted > 0 {
+		s.log.WithFields(logrus.Fields{
+			"deleted":  deleted,
+			"duration": time.Since(start).String(),
+		}).Info("downsampled pnl snapshots")
+	}
+}
@@ -0,0 +1,467 @@
+package roster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/samcm/pyre/internal/config"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Change actions reported when applying a roster
+const (
+	ActionCreatePersona = "create_persona"
+	ActionUpdatePersona = "update_persona"
+	ActionDeletePersona = "delete_persona"
+	ActionCreateUser    = "create_user"
+	ActionMoveUser      = "move_user"
+	ActionDeleteUser    = "delete_user"
+	ActionAddAddress    = "add_address"
+	ActionRemoveAddress = "remove_address"
+	ActionSetGhost      = "set_ghost"
+	ActionSetGroup      = "set_group"
+)
+
+// Sources of roster changes, recorded with them in the change log
+const (
+	SourceConfig = "config" // the config file, applied at startup
+	SourceAPI    = "api"
+)
+
+// Change is a single difference between the stored roster and an applied one
+type Change struct {
+	Action string
+	Target string // persona slug or username
+	Detail string
+}
+
+// ApplyOptions controls how a roster is applied
+type ApplyOptions struct {
+	// Prune deletes users, personas and addresses that are not in the roster.
+	// Without it, applying a roster only adds and updates.
+	Prune bool
+	// DryRun reports the changes without making them
+	DryRun bool
+	// Source is recorded with the changes made in the change log, SourceAPI when unset
+	Source string
+}
+
+// Service exports the stored users and personas as a roster, and applies rosters to storage
+type Service interface {
+	Export(ctx context.Context) (*config.Roster, error)
+	// Apply brings storage in line with a roster, logging the changes made
+	Apply(ctx context.Context, roster *config.Roster, opts ApplyOptions) ([]Change, error)
+	// Record adds changes made outside Apply to the change log
+	Record(ctx context.Context, source string, changes []Change) error
+}
+
+// service implements the roster Service
+type service struct {
+	storage storage.Storage
+	log     logrus.FieldLogger
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new roster service
+func NewService(storage storage.Storage, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		log:     log.WithField("package", "roster"),
+	}
+}
+
+// storedUser is a user as currently stored, with its persona and addresses
+type storedUser struct {
+	user      *storage.User
+	persona   string
+	addresses []string
+	groups    map[string]string // address -> group, for grouped addresses
+}
+
+// Export returns the stored users and personas in the config.yaml schema
+func (s *service) Export(ctx context.Context) (*config.Roster, error) {
+	personas, users, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := &config.Roster{
+		Users:    make(map[string][]string),
+		Personas: make(map[string]config.PersonaConfig, len(personas)),
+		Ghosts:   make([]string, 0),
+
+		AddressGroups: make(map[string]map[string][]string),
+	}
+
+	for slug, persona := range personas {
+		personaCfg := config.PersonaConfig{
+			DisplayName: persona.DisplayName,
+			Usernames:   make(map[string][]string),
+		}
+		if persona.Image != nil {
+			personaCfg.Image = *persona.Image
+		}
+		roster.Personas[slug] = personaCfg
+	}
+
+	for username, stored := range users {
+		if stored.persona != "" {
+			roster.Personas[stored.persona].Usernames[username] = stored.addresses
+		} else {
+			roster.Users[username] = stored.addresses
+		}
+		if stored.user.Ghost {
+			roster.Ghosts = append(roster.Ghosts, username)
+		}
+		for _, address := range stored.addresses {
+			group, ok := stored.groups[address]
+			if !ok {
+				continue
+			}
+			if roster.AddressGroups[username] == nil {
+				roster.AddressGroups[username] = make(map[string][]string)
+			}
+			roster.AddressGroups[username][group] = append(roster.AddressGroups[username][group], address)
+		}
+	}
+	sort.Strings(roster.Ghosts)
+
+	return roster, nil
+}
+
+// Apply brings storage in line with the roster and returns the changes made. Changes made
+// before a failure are logged too.
+func (s *service) Apply(ctx context.Context, roster *config.Roster, opts ApplyOptions) ([]Change, error) {
+	changes, err := s.apply(ctx, roster, opts)
+	if opts.DryRun {
+		return changes, err
+	}
+
+	// Each change is listed before it's made, so only the last one can have failed
+	made := changes
+	if err != nil && len(made) > 0 {
+		made = made[:len(made)-1]
+	}
+	source := opts.Source
+	if source == "" {
+		source = SourceAPI
+	}
+	if recordErr := s.Record(ctx, source, made); recordErr != nil {
+		s.log.WithError(recordErr).Error("failed to record roster changes")
+	}
+
+	return changes, err
+}
+
+// Record adds changes to the change log
+func (s *service) Record(ctx context.Context, source string, changes []Change) error {
+	logged := make([]*storage.RosterChange, len(changes))
+	for i, change := range changes {
+		logged[i] = &storage.RosterChange{Action: change.Action, Target: change.Target, Source: source}
+		if change.Detail != "" {
+			logged[i].Detail = &change.Detail
+		}
+	}
+	return s.storage.RecordRosterChanges(ctx, logged)
+}
+
+// apply makes the changes for Apply
+func (s *service) apply(ctx context.Context, roster *config.Roster, opts ApplyOptions) ([]Change, error) {
+	personas, users, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, 0)
+	record := func(action, target, detail string) {
+		changes = append(changes, Change{Action: action, Target: target, Detail: detail})
+	}
+
+	// Personas first so users can be linked to them
+	personaIDs := make(map[string]int64, len(roster.Personas))
+	for _, slug := range sortedKeys(roster.Personas) {
+		personaCfg := roster.Personas[slug]
+
+		persona, exists := personas[slug]
+		if !exists {
+			record(ActionCreatePersona, slug, personaCfg.DisplayName)
+			if opts.DryRun {
+				continue
+			}
+			if personaCfg.Image != "" {
+				persona, err = s.storage.CreatePersonaWithImage(ctx, slug, personaCfg.DisplayName, personaCfg.Image)
+			} else {
+				persona, err = s.storage.CreatePersona(ctx, slug, personaCfg.DisplayName)
+			}
+			if err != nil {
+				return changes, fmt.Errorf("failed to create persona %s: %w", slug, err)
+			}
+			personaIDs[slug] = persona.ID
+			continue
+		}
+		personaIDs[slug] = persona.ID
+
+		if persona.DisplayName != personaCfg.DisplayName {
+			record(ActionUpdatePersona, slug, "displayName: "+personaCfg.DisplayName)
+			if !opts.DryRun {
+				if err := s.storage.UpdatePersonaDisplayName(ctx, persona.ID, personaCfg.DisplayName); err != nil {
+					return changes, err
+				}
+			}
+		}
+		if personaCfg.Image != "" && (persona.Image == nil || *persona.Image != personaCfg.Image) {
+			record(ActionUpdatePersona, slug, "image: "+personaCfg.Image)
+			if !opts.DryRun {
+				if err := s.storage.UpdatePersonaImage(ctx, persona.ID, personaCfg.Image); err != nil {
+					return changes, err
+				}
+			}
+		}
+	}
+
+	// Users, with the persona each one should belong to
+	wanted := make(map[string]string)
+	for username := range roster.Users {
+		wanted[username] = ""
+	}
+	for slug, personaCfg := range roster.Personas {
+		for username := range personaCfg.Usernames {
+			wanted[username] = slug
+		}
+	}
+	allUsers := roster.GetAllUsers()
+
+	// Group each listed address should belong to, per user
+	wantedGroups := make(map[string]map[string]string, len(roster.AddressGroups))
+	for username, groups := range roster.AddressGroups {
+		wantedGroups[username] = make(map[string]string)
+		for group, addresses := range groups {
+			for _, address := range addresses {
+				wantedGroups[username][address] = group
+			}
+		}
+	}
+	applyGroups := func(username string, userID int64, addresses []string, current map[string]string) error {
+		for _, address := range addresses {
+			want, have := wantedGroups[username][address], current[address]
+			if want == have {
+				continue
+			}
+			record(ActionSetGroup, username, groupDetail(address, want))
+			if opts.DryRun {
+				continue
+			}
+
+			var group *string
+			if want != "" {
+				group = &want
+			}
+			if err := s.storage.SetAddressGroup(ctx, userID, address, group); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, username := range sortedKeys(allUsers) {
+		addresses := allUsers[username]
+		slug := wanted[username]
+		ghost := slices.Contains(roster.Ghosts, username)
+
+		stored, exists := users[username]
+		if !exists {
+			record(ActionCreateUser, username, PersonaDetail(slug))
+			if opts.DryRun {
+				if ghost {
+					record(ActionSetGhost, username, "true")
+				}
+				_ = applyGroups(username, 0, addresses, nil) // only records on a dry run
+				continue
+			}
+
+			var user *storage.User
+			if slug != "" {
+				user, err = s.storage.CreateUserWithPersona(ctx, username, addresses, personaIDs[slug])
+			} else {
+				user, err = s.storage.CreateUser(ctx, username, addresses)
+			}
+			if err != nil {
+				return changes, fmt.Errorf("failed to create user %s: %w", username, err)
+			}
+			if ghost {
+				record(ActionSetGhost, username, "true")
+				if err := s.storage.UpdateUserGhost(ctx, user.ID, true); err != nil {
+					return changes, err
+				}
+			}
+			if err := applyGroups(username, user.ID, addresses, nil); err != nil {
+				return changes, err
+			}
+			continue
+		}
+
+		if stored.persona != slug {
+			record(ActionMoveUser, username, fmt.Sprintf("%s -> %s", PersonaDetail(stored.persona), PersonaDetail(slug)))
+			if !opts.DryRun {
+				if slug != "" {
+					err = s.storage.UpdateUserPersona(ctx, stored.user.ID, personaIDs[slug])
+				} else {
+					err = s.storage.ClearUserPersona(ctx, stored.user.ID)
+				}
+				if err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		for _, address := range addresses {
+			if slices.Contains(stored.addresses, address) {
+				continue
+			}
+			record(ActionAddAddress, username, address)
+			if !opts.DryRun {
+				if err := s.storage.AddUserAddress(ctx, stored.user.ID, address); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		if opts.Prune {
+			for _, address := range stored.addresses {
+				if slices.Contains(addresses, address) {
+					continue
+				}
+				record(ActionRemoveAddress, username, address)
+				if !opts.DryRun {
+					if err := s.storage.RemoveUserAddress(ctx, stored.user.ID, address); err != nil {
+						return changes, err
+					}
+				}
+			}
+		}
+
+		if stored.user.Ghost != ghost {
+			record(ActionSetGhost, username, fmt.Sprintf("%t", ghost))
+			if !opts.DryRun {
+				if err := s.storage.UpdateUserGhost(ctx, stored.user.ID, ghost); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		if err := applyGroups(username, stored.user.ID, addresses, stored.groups); err != nil {
+			return changes, err
+		}
+	}
+
+	if opts.Prune {
+		for _, username := range sortedKeys(users) {
+			if _, ok := allUsers[username]; ok {
+				continue
+			}
+			record(ActionDeleteUser, username, "")
+			if !opts.DryRun {
+				if err := s.storage.DeleteUser(ctx, users[username].user.ID); err != nil {
+					return changes, err
+				}
+			}
+		}
+
+		for _, slug := range sortedKeys(personas) {
+			if _, ok := roster.Personas[slug]; ok {
+				continue
+			}
+			record(ActionDeletePersona, slug, "")
+			if !opts.DryRun {
+				if err := s.storage.DeletePersona(ctx, personas[slug].ID); err != nil {
+					return changes, err
+				}
+			}
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"changes": len(changes),
+		"prune":   opts.Prune,
+		"dry_run": opts.DryRun,
+	}).Info("applied roster")
+
+	return changes, nil
+}
+
+// load reads the stored personas by slug and users by username
+func (s *service) load(ctx context.Context) (map[string]*storage.Persona, map[string]*storedUser, error) {
+	personaList, err := s.storage.GetPersonas(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get personas: %w", err)
+	}
+
+	userList, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	users := make(map[string]*storedUser, len(userList))
+	for _, user := range userList {
+		addresses, err := s.storage.GetUserAddresses(ctx, user.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get addresses for %s: %w", user.Username, err)
+		}
+
+		stored := &storedUser{user: user, addresses: make([]string, len(addresses)), groups: make(map[string]string)}
+		for i, addr := range addresses {
+			stored.addresses[i] = addr.Address
+			if addr.Group != nil {
+				stored.groups[addr.Address] = *addr.Group
+			}
+		}
+		users[user.Username] = stored
+	}
+
+	personas := make(map[string]*storage.Persona, len(personaList))
+	for _, persona := range personaList {
+		personas[persona.Slug] = persona
+
+		members, err := s.storage.GetPersonaUsers(ctx, persona.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get users of persona %s: %w", persona.Slug, err)
+		}
+		for _, member := range members {
+			if stored, ok := users[member.Username]; ok {
+				stored.persona = persona.Slug
+			}
+		}
+	}
+
+	return personas, users, nil
+}
+
+// PersonaDetail describes a persona membership in change details
+func PersonaDetail(slug string) string {
+	if slug == "" {
+		return "no persona"
+	}
+	return "persona " + slug
+}
+
+// groupDetail describes an address group membership in change details
+func groupDetail(address, group string) string {
+	if group == "" {
+		return address + " -> no group"
+	}
+	return address + " -> group " + group
+}
+
+// sortedKeys returns the keys of a map in order, so changes are applied deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
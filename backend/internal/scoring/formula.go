@@ -0,0 +1,311 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Formula is a compiled arithmetic expression over named variables, e.g.
+// "totalPnl*0.7 + winRate*10000*0.3". It supports numbers, variables, + - * /,
+// unary minus, parentheses and the functions min, max and abs.
+type Formula struct {
+	src  string
+	root node
+}
+
+// Compile parses an expression, rejecting variables not in the allowed list
+func Compile(src string, variables []string) (*Formula, error) {
+	p := &parser{src: src, variables: variables}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset)
+	}
+
+	return &Formula{src: src, root: root}, nil
+}
+
+// Eval computes the formula's value. Missing variables count as zero, and division by
+// zero yields zero so a score is always a finite number.
+func (f *Formula) Eval(vars map[string]float64) float64 {
+	v := f.root.eval(vars)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+// String returns the source expression
+func (f *Formula) String() string {
+	return f.src
+}
+
+// node is an expression tree node
+type node interface {
+	eval(vars map[string]float64) float64
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) float64 { return float64(n) }
+
+type variableNode string
+
+func (n variableNode) eval(vars map[string]float64) float64 { return vars[string(n)] }
+
+type negateNode struct{ operand node }
+
+func (n negateNode) eval(vars map[string]float64) float64 { return -n.operand.eval(vars) }
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+func (n binaryNode) eval(vars map[string]float64) float64 {
+	l, r := n.left.eval(vars), n.right.eval(vars)
+	switch n.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	default:
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	}
+}
+
+type callNode struct {
+	fn   string
+	args []node
+}
+
+func (n callNode) eval(vars map[string]float64) float64 {
+	switch n.fn {
+	case "abs":
+		return math.Abs(n.args[0].eval(vars))
+	case "min":
+		v := n.args[0].eval(vars)
+		for _, arg := range n.args[1:] {
+			v = math.Min(v, arg.eval(vars))
+		}
+		return v
+	default:
+		v := n.args[0].eval(vars)
+		for _, arg := range n.args[1:] {
+			v = math.Max(v, arg.eval(vars))
+		}
+		return v
+	}
+}
+
+// functionArity is the minimum and maximum argument count of each supported function, -1 for unbounded
+var functionArity = map[string][2]int{
+	"abs": {1, 1},
+	"min": {2, -1},
+	"max": {2, -1},
+}
+
+type token struct {
+	text   string
+	offset int
+}
+
+// parser is a recursive descent parser over the tokenized expression
+type parser struct {
+	src       string
+	variables []string
+	tokens    []token
+	pos       int
+}
+
+func (p *parser) tokenize() error {
+	src := p.src
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("+-*/(),", c):
+			p.tokens = append(p.tokens, token{text: src[i : i+1], offset: i})
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			p.tokens = append(p.tokens, token{text: src[start:i], offset: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			p.tokens = append(p.tokens, token{text: src[start:i], offset: start})
+		default:
+			return fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+
+	if len(p.tokens) == 0 {
+		return fmt.Errorf("expression is empty")
+	}
+	return nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+func (p *parser) expect(text string) error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("expected %q at end of expression", text)
+	}
+	if p.tokens[p.pos].text != text {
+		return fmt.Errorf("expected %q at position %d, got %q", text, p.tokens[p.pos].offset, p.tokens[p.pos].text)
+	}
+	p.pos++
+	return nil
+}
+
+// parseExpr parses addition and subtraction
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op[0], left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseTerm parses multiplication and division
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == "*" || op == "/"; op = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op[0], left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses a leading minus or plus
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek() {
+	case "-":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negateNode{operand: operand}, nil
+	case "+":
+		p.pos++
+		return p.parseUnary()
+	}
+
+	return p.parsePrimary()
+}
+
+// parsePrimary parses numbers, variables, function calls and parenthesized expressions
+func (p *parser) parsePrimary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	c := rune(tok.text[0])
+	switch {
+	case tok.text == "(":
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case unicode.IsDigit(c) || c == '.':
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.offset)
+		}
+		return numberNode(v), nil
+	case unicode.IsLetter(c) || c == '_':
+		if p.peek() == "(" {
+			return p.parseCall(tok)
+		}
+		if !slices.Contains(p.variables, tok.text) {
+			return nil, fmt.Errorf("unknown variable %q at position %d, expected one of: %s", tok.text, tok.offset, strings.Join(p.variables, ", "))
+		}
+		return variableNode(tok.text), nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.offset)
+}
+
+// parseCall parses the argument list of a function call
+func (p *parser) parseCall(name token) (node, error) {
+	arity, ok := functionArity[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.offset)
+	}
+	p.pos++ // opening parenthesis
+
+	var args []node
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.peek() != "," {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("wrong number of arguments to %s at position %d: %d", name.text, name.offset, len(args))
+	}
+
+	return callNode{fn: name.text, args: args}, nil
+}
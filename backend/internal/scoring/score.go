@@ -0,0 +1,33 @@
+package scoring
+
+import "fmt"
+
+// Variables are the leaderboard stats a score formula can refer to
+var Variables = []string{
+	"totalPnl",
+	"realizedPnl",
+	"unrealizedPnl",
+	"winRate",
+	"volume",
+	"openPositions",
+	"totalTrades",
+}
+
+// BuiltinSorts are the leaderboard sort options that custom scores can't shadow
+var BuiltinSorts = []string{"totalPnl", "realizedPnl", "unrealizedPnl", "winRate", "volume"}
+
+// Score is a named custom leaderboard metric
+type Score struct {
+	Name    string
+	Formula *Formula
+}
+
+// NewScore compiles a named score formula over the leaderboard variables
+func NewScore(name, formula string) (*Score, error) {
+	f, err := Compile(formula, Variables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid formula for score %s: %w", name, err)
+	}
+
+	return &Score{Name: name, Formula: f}, nil
+}
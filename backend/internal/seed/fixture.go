@@ -0,0 +1,134 @@
+package seed
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture describes synthetic data to load for local development. Trades, positions and PnL
+// history are generated from it deterministically, so the same fixture and seed always
+// produce the same data relative to the time it is loaded.
+type Fixture struct {
+	Seed     uint64           `yaml:"seed"` // random seed
+	Days     int              `yaml:"days"` // length of the generated history, ending now
+	Markets  []MarketFixture  `yaml:"markets"`
+	Personas []PersonaFixture `yaml:"personas"`
+	Users    []UserFixture    `yaml:"users"`
+}
+
+// MarketFixture is a market users trade in
+type MarketFixture struct {
+	Title     string `yaml:"title"`
+	Slug      string `yaml:"slug"`
+	EventSlug string `yaml:"eventSlug"`
+	// EndDate is when the market resolves (YYYY-MM-DD). Markets without one are spread over the
+	// history, with some still open.
+	EndDate string `yaml:"endDate"`
+}
+
+// PersonaFixture is a persona grouping users
+type PersonaFixture struct {
+	Slug        string `yaml:"slug"`
+	DisplayName string `yaml:"displayName"`
+	Image       string `yaml:"image"`
+}
+
+// UserFixture is a synthetic trader
+type UserFixture struct {
+	Username     string   `yaml:"username"`
+	Addresses    []string `yaml:"addresses"` // generated from the username when empty
+	Persona      string   `yaml:"persona"`   // slug of one of the fixture's personas
+	ProfileImage string   `yaml:"profileImage"`
+	Markets      int      `yaml:"markets"` // number of markets traded, at most the fixture's market count
+	Stake        float64  `yaml:"stake"`   // average USDC put into a market
+	Skill        float64  `yaml:"skill"`   // chance of backing a market's winning outcome, 0 to 1
+}
+
+// Load reads a fixture file
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	f := &Fixture{Seed: 1, Days: 180}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+	for i := range f.Users {
+		if f.Users[i].Markets == 0 {
+			f.Users[i].Markets = len(f.Markets)
+		}
+		if f.Users[i].Stake == 0 {
+			f.Users[i].Stake = 100
+		}
+		if f.Users[i].Skill == 0 {
+			f.Users[i].Skill = 0.5
+		}
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Validate checks the fixture for errors
+func (f *Fixture) Validate() error {
+	if f.Days <= 0 {
+		return fmt.Errorf("days must be positive, got: %d", f.Days)
+	}
+	if len(f.Markets) == 0 {
+		return fmt.Errorf("at least one market is required")
+	}
+
+	slugs := make(map[string]bool, len(f.Markets))
+	for i, m := range f.Markets {
+		if m.Title == "" || m.Slug == "" {
+			return fmt.Errorf("market %d needs a title and slug", i)
+		}
+		if slugs[m.Slug] {
+			return fmt.Errorf("duplicate market slug: %s", m.Slug)
+		}
+		slugs[m.Slug] = true
+		if m.EndDate != "" {
+			if _, err := time.Parse(time.DateOnly, m.EndDate); err != nil {
+				return fmt.Errorf("market %s end date must be YYYY-MM-DD: %w", m.Slug, err)
+			}
+		}
+	}
+
+	personas := make(map[string]bool, len(f.Personas))
+	for i, p := range f.Personas {
+		if p.Slug == "" || p.DisplayName == "" {
+			return fmt.Errorf("persona %d needs a slug and display name", i)
+		}
+		personas[p.Slug] = true
+	}
+
+	usernames := make(map[string]bool, len(f.Users))
+	for i, u := range f.Users {
+		if u.Username == "" {
+			return fmt.Errorf("user %d username is required", i)
+		}
+		if usernames[u.Username] {
+			return fmt.Errorf("duplicate username: %s", u.Username)
+		}
+		usernames[u.Username] = true
+		if u.Persona != "" && !personas[u.Persona] {
+			return fmt.Errorf("user %s references unknown persona: %s", u.Username, u.Persona)
+		}
+		if u.Markets < 0 || u.Stake < 0 {
+			return fmt.Errorf("user %s markets and stake must not be negative", u.Username)
+		}
+		if u.Skill < 0 || u.Skill > 1 {
+			return fmt.Errorf("user %s skill must be between 0 and 1, got: %g", u.Username, u.Skill)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,480 @@
+package seed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// Summary counts what a fixture loaded
+type Summary struct {
+	Users       int
+	Trades      int
+	Positions   int
+	Settlements int
+	Snapshots   int
+}
+
+// Options controls how a fixture is loaded
+type Options struct {
+	// Replace deletes existing users with the fixture's usernames first. Without it, loading
+	// fails if any of them exist.
+	Replace bool
+	// Now is the end of the generated history, the current time when zero
+	Now time.Time
+}
+
+// market is a fixture market with its generated lifecycle
+type market struct {
+	MarketFixture
+	conditionID string
+	opens       time.Time
+	ends        time.Time
+	resolved    bool
+	winner      string  // outcome that wins
+	yesPrice    float64 // current (or final) price of Yes
+}
+
+// position is one user's generated holding in a market
+type position struct {
+	market   *market
+	address  string
+	outcome  string
+	size     float64
+	avgPrice float64
+	opened   time.Time
+	closed   *time.Time // sold or resolved, nil while open
+	exit     float64    // sale or settlement price, or the current price while open
+	settled  bool       // held to resolution rather than sold
+}
+
+// realized is the PnL locked in once the position closes
+func (p *position) realized() float64 {
+	return (p.exit - p.avgPrice) * p.size
+}
+
+// pnlAt is the PnL of the position at a time, moving linearly from entry to exit
+func (p *position) pnlAt(t, now time.Time) float64 {
+	end := now
+	if p.closed != nil {
+		end = *p.closed
+	}
+	frac := 1.0
+	if span := end.Sub(p.opened); span > 0 {
+		frac = math.Min(float64(t.Sub(p.opened))/float64(span), 1)
+	}
+	return (p.exit - p.avgPrice) * frac * p.size
+}
+
+// Apply loads a fixture into storage
+func Apply(ctx context.Context, store storage.Storage, f *Fixture, opts Options, log logrus.FieldLogger) (*Summary, error) {
+	log = log.WithField("package", "seed")
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC().Truncate(time.Minute)
+	start := now.AddDate(0, 0, -f.Days)
+
+	rng := rand.New(rand.NewPCG(f.Seed, f.Seed))
+	markets := generateMarkets(rng, f.Markets, start, now)
+
+	for _, u := range f.Users {
+		existing, err := store.GetUser(ctx, u.Username)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !opts.Replace {
+			return nil, fmt.Errorf("user %s already exists, load with replace to overwrite it", u.Username)
+		}
+		if err := store.DeleteUser(ctx, existing.ID); err != nil {
+			return nil, err
+		}
+		log.WithField("username", u.Username).Info("deleted existing user")
+	}
+
+	personaIDs := make(map[string]int64, len(f.Personas))
+	for _, p := range f.Personas {
+		persona, err := store.GetPersona(ctx, p.Slug)
+		if errors.Is(err, storage.ErrNotFound) {
+			persona, err = store.CreatePersonaWithImage(ctx, p.Slug, p.DisplayName, p.Image)
+		}
+		if err != nil {
+			return nil, err
+		}
+		personaIDs[p.Slug] = persona.ID
+	}
+
+	summary := &Summary{}
+	for _, u := range f.Users {
+		addresses := u.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{syntheticAddress(u.Username)}
+		}
+
+		var user *storage.User
+		var err error
+		if u.Persona != "" {
+			user, err = store.CreateUserWithPersona(ctx, u.Username, addresses, personaIDs[u.Persona])
+		} else {
+			user, err = store.CreateUser(ctx, u.Username, addresses)
+		}
+		if err != nil {
+			return summary, err
+		}
+		if u.ProfileImage != "" {
+			if err := store.UpdateUserProfileImage(ctx, user.ID, u.ProfileImage); err != nil {
+				return summary, err
+			}
+		}
+
+		positions := generatePositions(rng, u, addresses, markets, start, now)
+		if err := loadUser(ctx, store, user, rng, positions, start, now, summary); err != nil {
+			return summary, fmt.Errorf("failed to load user %s: %w", u.Username, err)
+		}
+
+		summary.Users++
+		log.WithFields(logrus.Fields{
+			"username":  u.Username,
+			"positions": len(positions),
+		}).Info("seeded user")
+	}
+
+	return summary, nil
+}
+
+// generateMarkets decides when each market runs, whether it has resolved and which outcome wins
+func generateMarkets(rng *rand.Rand, fixtures []MarketFixture, start, now time.Time) []*market {
+	days := now.Sub(start).Hours() / 24
+
+	markets := make([]*market, 0, len(fixtures))
+	for _, mf := range fixtures {
+		m := &market{MarketFixture: mf, conditionID: syntheticHash("market:" + mf.Slug)}
+
+		if mf.EndDate != "" {
+			// Already validated
+			m.ends, _ = time.Parse(time.DateOnly, mf.EndDate)
+		} else if rng.Float64() < 0.2 {
+			m.ends = now.Add(time.Duration(1+rng.IntN(60)) * 24 * time.Hour)
+		} else {
+			m.ends = start.Add(time.Duration((0.1 + 0.9*rng.Float64()) * days * float64(24*time.Hour))).Truncate(time.Hour)
+		}
+		m.opens = m.ends.Add(-time.Duration(14+rng.IntN(90)) * 24 * time.Hour)
+		if m.opens.Before(start) {
+			m.opens = start
+		}
+		m.resolved = !m.ends.After(now)
+
+		m.winner = "Yes"
+		if rng.IntN(2) == 0 {
+			m.winner = "No"
+		}
+
+		// Open markets lean towards the outcome that will win
+		switch {
+		case m.resolved && m.winner == "Yes":
+			m.yesPrice = 1
+		case m.resolved:
+			m.yesPrice = 0
+		case m.winner == "Yes":
+			m.yesPrice = round(0.55+0.35*rng.Float64(), 3)
+		default:
+			m.yesPrice = round(0.1+0.35*rng.Float64(), 3)
+		}
+
+		markets = append(markets, m)
+	}
+
+	return markets
+}
+
+// generatePositions picks the markets a user trades and how each position plays out
+func generatePositions(rng *rand.Rand, u UserFixture, addresses []string, markets []*market, start, now time.Time) []*position {
+	count := min(u.Markets, len(markets))
+	positions := make([]*position, 0, count)
+
+	for _, idx := range rng.Perm(len(markets))[:count] {
+		m := markets[idx]
+
+		entryFrom, entryTo := m.opens, m.ends
+		if entryFrom.Before(start) {
+			entryFrom = start
+		}
+		if entryTo.After(now) {
+			entryTo = now
+		}
+		entryTo = entryTo.Add(-6 * time.Hour)
+		if !entryTo.After(entryFrom) {
+			continue
+		}
+
+		outcome := m.winner
+		if rng.Float64() >= u.Skill {
+			outcome = other(m.winner)
+		}
+
+		avgPrice := round(0.1+0.8*rng.Float64(), 3)
+		stake := u.Stake * (0.3 + 1.4*rng.Float64())
+		pos := &position{
+			market:   m,
+			address:  addresses[rng.IntN(len(addresses))],
+			outcome:  outcome,
+			size:     round(stake/avgPrice, 2),
+			avgPrice: avgPrice,
+			opened:   entryFrom.Add(time.Duration(rng.Float64() * float64(entryTo.Sub(entryFrom)))).Truncate(time.Second),
+		}
+
+		won := outcome == m.winner
+		switch {
+		case !m.resolved:
+			pos.exit = m.yesPrice
+			if outcome == "No" {
+				pos.exit = round(1-m.yesPrice, 3)
+			}
+		case rng.IntN(2) == 0:
+			// Held to resolution
+			pos.closed = &m.ends
+			pos.settled = true
+			pos.exit = 0
+			if won {
+				pos.exit = 1
+			}
+		default:
+			// Sold before resolution
+			closed := pos.opened.Add(time.Duration(rng.Float64() * float64(m.ends.Sub(pos.opened)))).Truncate(time.Second)
+			pos.closed = &closed
+			if won {
+				pos.exit = round(avgPrice+(0.99-avgPrice)*(0.3+0.7*rng.Float64()), 3)
+			} else {
+				pos.exit = round(avgPrice*(0.1+0.8*rng.Float64()), 3)
+			}
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions
+}
+
+// loadUser stores a user's generated trades, positions, settlements and PnL history
+func loadUser(ctx context.Context, store storage.Storage, user *storage.User, rng *rand.Rand, positions []*position, start, now time.Time, summary *Summary) error {
+	var volume float64
+	rows := make([]*storage.Position, 0, len(positions))
+	var settlements []*storage.PositionSettlement
+
+	for _, p := range positions {
+		m := p.market
+		asset := syntheticHash("asset:" + m.Slug + ":" + p.outcome)
+
+		trades := buyTrades(rng, p, now)
+		if p.closed != nil && !p.settled {
+			trades = append(trades, newTrade(p, "SELL", p.exit, p.size, *p.closed))
+		}
+		for _, t := range trades {
+			t.UserID = user.ID
+			if _, err := store.InsertTrade(ctx, t); err != nil {
+				return err
+			}
+			volume += *t.Value
+		}
+		summary.Trades += len(trades)
+
+		// Positions are stored as Polymarket reports them: fully sold positions are gone, and
+		// those held to resolution stay until redeemed, valued at the settlement price
+		if p.closed != nil && !p.settled {
+			continue
+		}
+		rows = append(rows, &storage.Position{
+			UserID:               user.ID,
+			Address:              p.address,
+			ConditionID:          m.conditionID,
+			Asset:                asset,
+			MarketTitle:          &m.Title,
+			MarketSlug:           &m.Slug,
+			Outcome:              ptr(p.outcome),
+			Size:                 ptr(p.size),
+			AvgPrice:             ptr(p.avgPrice),
+			CurrentPrice:         ptr(p.exit),
+			InitialValue:         ptr(round(p.avgPrice*p.size, 2)),
+			CurrentValue:         ptr(round(p.exit*p.size, 2)),
+			UnrealizedPnl:        ptr(round(p.realized(), 2)),
+			UnrealizedPnlPercent: ptr(round((p.exit-p.avgPrice)/p.avgPrice*100, 2)),
+			RealizedPnl:          ptr(0.0),
+			EndDate:              ptr(m.ends),
+		})
+
+		if p.settled {
+			settlements = append(settlements, &storage.PositionSettlement{
+				UserID:          user.ID,
+				Address:         p.address,
+				ConditionID:     m.conditionID,
+				Asset:           asset,
+				Outcome:         ptr(p.outcome),
+				MarketTitle:     &m.Title,
+				MarketSlug:      &m.Slug,
+				Size:            p.size,
+				AvgPrice:        p.avgPrice,
+				SettlementPrice: p.exit,
+				Pnl:             round(p.realized(), 2),
+				ResolvedAt:      *p.closed,
+			})
+		}
+	}
+
+	if err := store.UpsertPositions(ctx, rows); err != nil {
+		return err
+	}
+	summary.Positions += len(rows)
+
+	recorded, err := store.RecordPositionSettlements(ctx, settlements)
+	if err != nil {
+		return err
+	}
+	summary.Settlements += len(recorded)
+
+	snapshots := dailySnapshots(user.ID, positions, start, now)
+	if err := store.BulkInsertPnlSnapshots(ctx, snapshots); err != nil {
+		return err
+	}
+	summary.Snapshots += len(snapshots)
+
+	last := snapshots[len(snapshots)-1]
+	if err := store.UpdateUserOfficialPnl(ctx, user.ID, *last.TotalPnl, round(volume, 2)); err != nil {
+		return err
+	}
+
+	return store.UpdateUserLastSynced(ctx, user.ID, now)
+}
+
+// buyTrades splits a position's entry into one to three buys over the following days,
+// priced around the position's average
+func buyTrades(rng *rand.Rand, p *position, now time.Time) []*storage.Trade {
+	end := now
+	if p.closed != nil {
+		end = *p.closed
+	}
+
+	n := 1 + rng.IntN(3)
+	trades := make([]*storage.Trade, 0, n)
+	remaining := p.size
+	at := p.opened
+	for i := 0; i < n; i++ {
+		size := round(p.size/float64(n), 2)
+		if i == n-1 {
+			size = round(remaining, 2)
+		}
+		remaining -= size
+
+		price := p.avgPrice
+		if n > 1 && !(i == n-1 && n%2 == 1) {
+			// Alternate around the average so the buys still average to it
+			spread := 0.02 * float64(1-2*(i%2))
+			price = round(math.Min(math.Max(p.avgPrice+spread, 0.01), 0.99), 3)
+		}
+
+		trades = append(trades, newTrade(p, "BUY", price, size, at))
+
+		at = at.Add(time.Duration(1+rng.IntN(48)) * time.Hour)
+		if at.After(end) {
+			at = end
+		}
+	}
+
+	return trades
+}
+
+// newTrade creates a trade in a position's market
+func newTrade(p *position, side string, price, size float64, at time.Time) *storage.Trade {
+	m := p.market
+	t := &storage.Trade{
+		Address:     p.address,
+		TradeID:     ptr(syntheticHash(fmt.Sprintf("trade:%s:%s:%s:%d", p.address, m.Slug, side, at.UnixNano()))),
+		ConditionID: &m.conditionID,
+		MarketTitle: &m.Title,
+		MarketSlug:  &m.Slug,
+		Outcome:     ptr(p.outcome),
+		Side:        &side,
+		Price:       &price,
+		Size:        &size,
+		Value:       ptr(round(price*size, 2)),
+		Timestamp:   &at,
+	}
+	if m.EventSlug != "" {
+		t.EventSlug = &m.EventSlug
+	}
+	return t
+}
+
+// dailySnapshots builds a PnL snapshot per day from start to now
+func dailySnapshots(userID int64, positions []*position, start, now time.Time) []*storage.PnlSnapshot {
+	var snapshots []*storage.PnlSnapshot
+	for t := start; ; t = t.Add(24 * time.Hour) {
+		if t.After(now) {
+			t = now
+		}
+
+		var realized, unrealized float64
+		for _, p := range positions {
+			switch {
+			case p.opened.After(t):
+			case p.closed != nil && !p.closed.After(t):
+				realized += p.realized()
+			default:
+				unrealized += p.pnlAt(t, now)
+			}
+		}
+
+		snapshots = append(snapshots, &storage.PnlSnapshot{
+			UserID:        userID,
+			Timestamp:     t,
+			TotalPnl:      ptr(round(realized+unrealized, 2)),
+			RealizedPnl:   ptr(round(realized, 2)),
+			UnrealizedPnl: ptr(round(unrealized, 2)),
+		})
+
+		if t.Equal(now) {
+			return snapshots
+		}
+	}
+}
+
+// syntheticAddress derives a wallet-shaped address from a username
+func syntheticAddress(username string) string {
+	return syntheticHash("address:" + username)[:42]
+}
+
+// syntheticHash derives a 0x-prefixed hex identifier from a string
+func syntheticHash(s string) string {
+	sum := sha256.Sum256([]byte("pyre-seed:" + s))
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// other returns the opposite binary outcome
+func other(outcome string) string {
+	if outcome == "Yes" {
+		return "No"
+	}
+	return "Yes"
+}
+
+// round rounds to the given number of decimal places
+func round(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
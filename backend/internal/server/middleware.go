@@ -0,0 +1,242 @@
+package server
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/samcm/pyre/internal/api"
+	"github.com/sirupsen/logrus"
+)
+
+// serverWriteTimeout is the default write deadline for responses. Routes with a longer
+// timeout extend the deadline for their own requests.
+const serverWriteTimeout = 30 * time.Second
+
+// maxBodyMiddleware limits request bodies to maxBytes
+func maxBodyMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeTimeout is a timeout-wrapped handler for requests under a path prefix
+type routeTimeout struct {
+	prefix  string
+	timeout time.Duration
+	handler http.Handler
+}
+
+// timeoutMiddleware applies defaultTimeout to requests, or the timeout of the longest
+// matching prefix in routes
+func timeoutMiddleware(defaultTimeout time.Duration, routes map[string]time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		overrides := make([]routeTimeout, 0, len(routes))
+		for prefix, timeout := range routes {
+			overrides = append(overrides, routeTimeout{
+				prefix:  prefix,
+				timeout: timeout,
+				handler: middleware.Timeout(timeout)(next),
+			})
+		}
+
+		// Longest prefix first so the most specific route wins
+		sort.Slice(overrides, func(i, j int) bool {
+			return len(overrides[i].prefix) > len(overrides[j].prefix)
+		})
+
+		defaultHandler := middleware.Timeout(defaultTimeout)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Streaming connections outlive any request timeout, and a timeout firing after a
+			// WebSocket is hijacked or an event stream started would try to write a 504 to it
+			if isLongLived(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			path := canonicalAPIPath(r.URL.Path)
+			for _, route := range overrides {
+				if !strings.HasPrefix(path, route.prefix) {
+					continue
+				}
+
+				if route.timeout > serverWriteTimeout {
+					// Best effort: not all writers support deadlines
+					_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(route.timeout))
+				}
+
+				route.handler.ServeHTTP(w, r)
+				return
+			}
+
+			defaultHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isWebSocketUpgrade reports whether a request opens a WebSocket connection
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// isLongLived reports whether a request opens a WebSocket connection or a Server-Sent Events
+// stream, both held open until the client leaves
+func isLongLived(r *http.Request) bool {
+	return isWebSocketUpgrade(r) || canonicalAPIPath(r.URL.Path) == "/api/v1/events"
+}
+
+// canonicalAPIPath maps API paths of every version, and unversioned /api paths, onto
+// their /api/v1 form so per-route settings apply to all versions
+func canonicalAPIPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/")
+	if !ok {
+		return path
+	}
+
+	for _, version := range api.Versions {
+		if rest == version.Name || strings.HasPrefix(rest, version.Name+"/") {
+			rest = strings.TrimPrefix(rest, version.Name)
+			return "/api/v1" + rest
+		}
+	}
+
+	return "/api/v1/" + rest
+}
+
+// slowRequestMiddleware logs requests that take longer than the configured threshold
+func (s *server) slowRequestMiddleware(next http.Handler) http.Handler {
+	if s.limits.SlowRequestThreshold <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		duration := time.Since(start)
+		// Streaming connections are expected to stay open
+		if duration < s.limits.SlowRequestThreshold || isLongLived(r) {
+			return
+		}
+
+		route := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		s.log.WithFields(logrus.Fields{
+			"method":      r.Method,
+			"route":       route,
+			"path":        r.URL.Path,
+			"status":      ww.Status(),
+			"bytes":       ww.BytesWritten(),
+			"duration_ms": duration.Milliseconds(),
+			"threshold":   s.limits.SlowRequestThreshold.String(),
+			"request_id":  middleware.GetReqID(r.Context()),
+		}).Warn("slow request")
+	})
+}
+
+// accessLogMiddleware writes a structured log line per request. Routes can be disabled or
+// sampled; server errors are always logged, and sensitive query parameters are redacted.
+func (s *server) accessLogMiddleware(next http.Handler) http.Handler {
+	if !s.accessLog.Enabled {
+		return next
+	}
+
+	routes := make([]AccessLogRoute, len(s.accessLog.Routes))
+	copy(routes, s.accessLog.Routes)
+
+	// Longest prefix first so the most specific route wins
+	sort.Slice(routes, func(i, j int) bool {
+		return len(routes[i].Prefix) > len(routes[j].Prefix)
+	})
+
+	redact := make(map[string]bool, len(s.accessLog.RedactParams))
+	for _, param := range s.accessLog.RedactParams {
+		redact[strings.ToLower(param)] = true
+	}
+
+	log := s.log.WithField("component", "access_log")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enabled, sampleRate := true, 1.0
+		path := canonicalAPIPath(r.URL.Path)
+		for _, route := range routes {
+			if strings.HasPrefix(path, route.Prefix) {
+				enabled, sampleRate = route.Enabled, route.SampleRate
+				break
+			}
+		}
+
+		if !enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status < http.StatusInternalServerError && sampleRate < 1 && rand.Float64() >= sampleRate {
+			return
+		}
+
+		fields := logrus.Fields{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"status":      status,
+			"bytes":       ww.BytesWritten(),
+			"duration_ms": time.Since(start).Milliseconds(),
+			"remote_addr": r.RemoteAddr,
+			"user_agent":  r.UserAgent(),
+			"request_id":  middleware.GetReqID(r.Context()),
+		}
+		if r.URL.RawQuery != "" {
+			fields["query"] = redactQuery(r.URL.Query(), redact)
+		}
+		if sampleRate < 1 {
+			fields["sample_rate"] = sampleRate
+		}
+
+		entry := log.WithFields(fields)
+		switch {
+		case status >= http.StatusInternalServerError:
+			entry.Error("request")
+		case status >= http.StatusBadRequest:
+			entry.Warn("request")
+		default:
+			entry.Info("request")
+		}
+	})
+}
+
+// redactQuery encodes query parameters with the values of sensitive ones replaced
+func redactQuery(query url.Values, redact map[string]bool) string {
+	for param, values := range query {
+		if !redact[strings.ToLower(param)] {
+			continue
+		}
+		for i := range values {
+			values[i] = "REDACTED"
+		}
+	}
+
+	return query.Encode()
+}
@@ -22,11 +22,36 @@ type Server interface {
 	Stop() error
 }
 
+// Limits configures request limits and slow request logging
+type Limits struct {
+	MaxBodyBytes         int64
+	RequestTimeout       time.Duration
+	RouteTimeouts        map[string]time.Duration // path prefix -> timeout, longest prefix wins
+	SlowRequestThreshold time.Duration            // 0 disables slow request logging
+}
+
+// AccessLog configures structured request logging
+type AccessLog struct {
+	Enabled      bool
+	RedactParams []string         // query parameters whose values are never logged
+	Routes       []AccessLogRoute // per-route overrides, longest prefix wins
+}
+
+// AccessLogRoute overrides access logging for paths starting with Prefix
+type AccessLogRoute struct {
+	Prefix     string
+	Enabled    bool
+	SampleRate float64 // fraction of successful requests logged
+}
+
 // server implements the HTTP server
 type server struct {
 	host       string
 	port       int
+	limits     Limits
+	accessLog  AccessLog
 	handler    *api.APIHandler
+	discord    http.Handler // nil when the Discord bot is disabled
 	frontend   embed.FS
 	httpServer *http.Server
 	log        logrus.FieldLogger
@@ -35,13 +60,25 @@ type server struct {
 var _ Server = (*server)(nil)
 
 // NewServer creates a new HTTP server
-func NewServer(host string, port int, handler *api.APIHandler, frontend embed.FS, log logrus.FieldLogger) Server {
+func NewServer(
+	host string,
+	port int,
+	limits Limits,
+	accessLog AccessLog,
+	handler *api.APIHandler,
+	discord http.Handler,
+	frontend embed.FS,
+	log logrus.FieldLogger,
+) Server {
 	return &server{
-		host:     host,
-		port:     port,
-		handler:  handler,
-		frontend: frontend,
-		log:      log.WithField("package", "server"),
+		host:      host,
+		port:      port,
+		limits:    limits,
+		accessLog: accessLog,
+		handler:   handler,
+		discord:   discord,
+		frontend:  frontend,
+		log:       log.WithField("package", "server"),
 	}
 }
 
@@ -58,17 +95,29 @@ func (s *server) Start(ctx context.Context) error {
 	// Add middleware
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(s.accessLogMiddleware)
+	r.Use(s.slowRequestMiddleware)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(maxBodyMiddleware(s.limits.MaxBodyBytes))
+	r.Use(timeoutMiddleware(s.limits.RequestTimeout, s.limits.RouteTimeouts))
 
 	// CORS middleware for development
 	r.Use(corsMiddleware)
 
-	// Mount API routes under /api/v1
-	r.Route("/api/v1", func(r chi.Router) {
-		api.HandlerFromMux(s.handler, r)
-	})
+	// Mount each API version under /api/{version}, and unversioned /api paths with
+	// version negotiation
+	for _, version := range api.Versions {
+		r.Mount("/api/"+version.Name, s.handler.VersionRouter(version))
+	}
+	r.Mount("/api", s.handler.NegotiatingRouter())
+
+	// Sync health for Prometheus scrapes
+	r.Get("/metrics", s.handler.Metrics)
+
+	// Discord delivers slash commands to the bot's interactions endpoint
+	if s.discord != nil {
+		r.Post("/discord/interactions", s.discord.ServeHTTP)
+	}
 
 	// Serve SPA for all other routes
 	r.Get("/*", s.spaHandler())
@@ -79,7 +128,7 @@ func (s *server) Start(ctx context.Context) error {
 		Addr:         addr,
 		Handler:      r,
 		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: serverWriteTimeout,
 		IdleTimeout:  120 * time.Second,
 	}
 
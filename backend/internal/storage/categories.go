@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// Expose the classifier to SQL so trades can be filtered by category in queries
+	sqlite.MustRegisterDeterministicScalarFunction("market_category", 2,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			var title, slug *string
+			if v, ok := args[0].(string); ok {
+				title = &v
+			}
+			if v, ok := args[1].(string); ok {
+				slug = &v
+			}
+			return MarketCategory(title, slug), nil
+		},
+	)
+}
+
+// marketCategorySQL returns an SQL expression for the category of the market a trade or position
+// references, qualified by alias (e.g. "t"): the cached Gamma category in markets, then the one
+// categorization stored, then one classified from the title and slug
+func marketCategorySQL(alias string) string {
+	return fmt.Sprintf("COALESCE("+
+		"(SELECT category FROM markets WHERE condition_id = %[1]s.condition_id), "+
+		"(SELECT category FROM market_categories WHERE condition_id = %[1]s.condition_id), "+
+		"market_category(%[1]s.market_title, %[1]s.market_slug))", alias)
+}
+
+// Market categories derived from market titles and slugs
+const (
+	CategoryPolitics  = "politics"
+	CategorySports    = "sports"
+	CategoryCrypto    = "crypto"
+	CategoryEconomics = "economics"
+	CategoryCulture   = "culture"
+	CategoryOther     = "other"
+)
+
+// Where a stored market category came from
+const (
+	CategorySourceGamma    = "gamma"    // the market's category on the Gamma API
+	CategorySourceInferred = "inferred" // classified from the title and slug, Gamma had none
+)
+
+// categoryKeywords maps each category to the keywords that identify it, checked in order
+var categoryKeywords = []struct {
+	category string
+	keywords []string
+}{
+	{CategoryCrypto, []string{
+		"bitcoin", "btc", "ethereum", "eth ", "solana", "crypto", "xrp", "dogecoin", "memecoin", "token", "airdrop",
+	}},
+	{CategoryEconomics, []string{
+		"fed ", "fed-", "interest rate", "inflation", "cpi", "gdp", "recession", "unemployment", "s&p", "nasdaq",
+		"stock", "tariff", "earnings", "merger", "ipo ",
+	}},
+	{CategoryPolitics, []string{
+		"election", "president", "trump", "biden", "senate", "congress", "governor", "prime minister", "parliament",
+		"democrat", "republican", "nominee", "primary", "vote", "mayor", "cabinet", "impeach", "minister",
+		"chancellor", "supreme court", "referendum",
+	}},
+	{CategorySports, []string{
+		" vs ", " vs. ", "-vs-", "nba", "nfl", "nhl", "mlb", "ufc", "premier league", "champions league", "la liga",
+		"world cup", "super bowl", "grand prix", "formula 1", "tennis", "playoffs", "championship", "stanley cup",
+		"ncaa", "olympic", "wimbledon", "grand slam", "bundesliga", "serie a", "mvp",
+	}},
+	{CategoryCulture, []string{
+		"oscar", "grammy", "movie", "album", "box office", "tiktok", "youtube", "spotify", "taylor swift", "mrbeast",
+		"elon", "tweet", "emmy", "golden globe", "netflix", "billboard", "eurovision", "celebrity", "blockbuster",
+	}},
+}
+
+// gammaCategoryLabels maps each category to fragments of the Gamma API's category labels
+// (e.g. "US-current-affairs", "Pop-Culture", "NBA Playoffs") that belong to it, checked in order
+var gammaCategoryLabels = []struct {
+	category string
+	labels   []string
+}{
+	{CategoryCrypto, []string{"crypto", "nft", "defi"}},
+	{CategoryEconomics, []string{"business", "econom", "financ", "stocks"}},
+	{CategoryPolitics, []string{"politic", "election", "current-affairs", "current affairs"}},
+	{CategorySports, []string{"sport", "nba", "nfl", "nhl", "mlb", "olympic", "soccer", "football", "chess", "tennis"}},
+	{CategoryCulture, []string{"culture", "entertainment", "music", "movie", "celebrit"}},
+}
+
+// GammaCategory maps a category label of the Gamma API to a market category, or returns an
+// empty string when the label is empty or belongs to none of them
+func GammaCategory(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if label == "" {
+		return ""
+	}
+	for _, entry := range gammaCategoryLabels {
+		for _, fragment := range entry.labels {
+			if strings.Contains(label, fragment) {
+				return entry.category
+			}
+		}
+	}
+	return ""
+}
+
+// IsMarketCategory reports whether category is one of the known market categories
+func IsMarketCategory(category string) bool {
+	if category == CategoryOther {
+		return true
+	}
+	for _, entry := range categoryKeywords {
+		if entry.category == category {
+			return true
+		}
+	}
+	return false
+}
+
+// MarketCategory classifies a market into a coarse category by keyword matching.
+// Polymarket's data API does not expose categories, so this is a best-effort heuristic used
+// when the Gamma API has no category for a market, or it hasn't been looked up yet.
+func MarketCategory(title, slug *string) string {
+	var text strings.Builder
+	text.WriteString(" ")
+	if title != nil {
+		text.WriteString(strings.ToLower(*title))
+	}
+	text.WriteString(" ")
+	if slug != nil {
+		text.WriteString(strings.ToLower(*slug))
+	}
+	text.WriteString(" ")
+
+	haystack := text.String()
+	for _, entry := range categoryKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(haystack, keyword) {
+				return entry.category
+			}
+		}
+	}
+
+	return CategoryOther
+}
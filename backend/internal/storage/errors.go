@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var (
+	// ErrNotFound is returned when the user, persona or other record asked for doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write collides with an existing record, such as a
+	// username or persona slug already taken
+	ErrConflict = errors.New("conflict")
+)
+
+// conflictError marks unique constraint violations as ErrConflict, keeping the driver error
+func conflictError(err error) error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return fmt.Errorf("%w: %w", ErrConflict, err)
+		}
+	}
+	return err
+}
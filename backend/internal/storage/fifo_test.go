@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fifoTrade builds a trade of size shares at price in a market's outcome, minutes after a fixed time
+func fifoTrade(id, conditionID, outcome, side string, price, size float64, minutes int) *Trade {
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	return &Trade{
+		TradeID:     &id,
+		ConditionID: &conditionID,
+		Outcome:     &outcome,
+		Side:        &side,
+		Price:       &price,
+		Size:        &size,
+		Timestamp:   &ts,
+	}
+}
+
+func TestMatchFIFO(t *testing.T) {
+	type disposal struct {
+		shares, pnl float64
+		open, close string
+		holdMinutes int
+		conditionID string
+		outcome     string
+		openPrice   float64
+		closePrice  float64
+	}
+
+	tests := []struct {
+		name      string
+		trades    []*Trade
+		disposals []disposal
+		open      map[fifoKey][]float64 // shares of the lots left open
+	}{
+		{
+			name:   "no trades",
+			trades: nil,
+			open:   map[fifoKey][]float64{},
+		},
+		{
+			name: "buys only stay open",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.4, 10, 0),
+				fifoTrade("b2", "m1", "Yes", "BUY", 0.5, 5, 1),
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {10, 5}},
+		},
+		{
+			name: "sell consumes the oldest lot first",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.4, 10, 0),
+				fifoTrade("b2", "m1", "Yes", "BUY", 0.6, 10, 10),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.5, 10, 30),
+			},
+			disposals: []disposal{
+				{shares: 10, pnl: 1, open: "b1", close: "s1", holdMinutes: 30, conditionID: "m1", outcome: "Yes", openPrice: 0.4, closePrice: 0.5},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {10}},
+		},
+		{
+			name: "sell spanning lots splits into disposals",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.4, 10, 0),
+				fifoTrade("b2", "m1", "Yes", "BUY", 0.6, 10, 10),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.5, 15, 30),
+			},
+			disposals: []disposal{
+				{shares: 10, pnl: 1, open: "b1", close: "s1", holdMinutes: 30, conditionID: "m1", outcome: "Yes", openPrice: 0.4, closePrice: 0.5},
+				{shares: 5, pnl: -0.5, open: "b2", close: "s1", holdMinutes: 20, conditionID: "m1", outcome: "Yes", openPrice: 0.6, closePrice: 0.5},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {5}},
+		},
+		{
+			name: "partial sells leave the rest of a lot",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.2, 10, 0),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.3, 4, 5),
+				fifoTrade("s2", "m1", "Yes", "SELL", 0.1, 4, 10),
+			},
+			disposals: []disposal{
+				{shares: 4, pnl: 0.4, open: "b1", close: "s1", holdMinutes: 5, conditionID: "m1", outcome: "Yes", openPrice: 0.2, closePrice: 0.3},
+				{shares: 4, pnl: -0.4, open: "b1", close: "s2", holdMinutes: 10, conditionID: "m1", outcome: "Yes", openPrice: 0.2, closePrice: 0.1},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {2}},
+		},
+		{
+			name: "selling more than held ignores the excess",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.5, 5, 0),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.7, 8, 1),
+			},
+			disposals: []disposal{
+				{shares: 5, pnl: 1, open: "b1", close: "s1", holdMinutes: 1, conditionID: "m1", outcome: "Yes", openPrice: 0.5, closePrice: 0.7},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {}},
+		},
+		{
+			name: "sell without a position has nothing to match",
+			trades: []*Trade{
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.7, 8, 0),
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {}},
+		},
+		{
+			name: "outcomes and markets are matched separately",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.3, 10, 0),
+				fifoTrade("b2", "m1", "No", "BUY", 0.6, 10, 1),
+				fifoTrade("b3", "m2", "Yes", "BUY", 0.1, 10, 2),
+				fifoTrade("s1", "m1", "No", "SELL", 0.8, 10, 3),
+			},
+			disposals: []disposal{
+				{shares: 10, pnl: 2, open: "b2", close: "s1", holdMinutes: 2, conditionID: "m1", outcome: "No", openPrice: 0.6, closePrice: 0.8},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {10}, {"m1", "No"}: {}, {"m2", "Yes"}: {10}},
+		},
+		{
+			name: "trades missing a side, price or size are skipped",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.5, 10, 0),
+				{ConditionID: ptr("m1"), Outcome: ptr("Yes"), Price: ptr(0.9), Size: ptr(10.0)},
+				{ConditionID: ptr("m1"), Outcome: ptr("Yes"), Side: ptr("SELL"), Size: ptr(10.0)},
+				{ConditionID: ptr("m1"), Outcome: ptr("Yes"), Side: ptr("SELL"), Price: ptr(0.9)},
+			},
+			open: map[fifoKey][]float64{{"m1", "Yes"}: {10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disposals, inventory := matchFIFO(tt.trades)
+
+			if len(disposals) != len(tt.disposals) {
+				t.Fatalf("got %d disposals, want %d: %+v", len(disposals), len(tt.disposals), disposals)
+			}
+			for i, want := range tt.disposals {
+				got := disposals[i]
+				if !approx(got.Shares, want.shares) || !approx(got.Pnl, want.pnl) {
+					t.Errorf("disposal %d: got %v shares for %v, want %v for %v", i, got.Shares, got.Pnl, want.shares, want.pnl)
+				}
+				if got.OpenTradeID == nil || *got.OpenTradeID != want.open || got.CloseTradeID == nil || *got.CloseTradeID != want.close {
+					t.Errorf("disposal %d: got trades %v to %v, want %s to %s", i, got.OpenTradeID, got.CloseTradeID, want.open, want.close)
+				}
+				if got.Hold == nil || *got.Hold != time.Duration(want.holdMinutes)*time.Minute {
+					t.Errorf("disposal %d: got hold %v, want %dm", i, got.Hold, want.holdMinutes)
+				}
+				if got.ConditionID != want.conditionID || got.Outcome != want.outcome {
+					t.Errorf("disposal %d: got %s %s, want %s %s", i, got.ConditionID, got.Outcome, want.conditionID, want.outcome)
+				}
+				if !approx(got.OpenPrice, want.openPrice) || !approx(got.ClosePrice, want.closePrice) {
+					t.Errorf("disposal %d: got prices %v to %v, want %v to %v", i, got.OpenPrice, got.ClosePrice, want.openPrice, want.closePrice)
+				}
+			}
+
+			if len(inventory) != len(tt.open) {
+				t.Fatalf("got %d positions, want %d: %+v", len(inventory), len(tt.open), inventory)
+			}
+			for key, want := range tt.open {
+				lots, ok := inventory[key]
+				if !ok {
+					t.Fatalf("position %v missing", key)
+				}
+				if len(lots) != len(want) {
+					t.Fatalf("position %v: got %d open lots, want %d", key, len(lots), len(want))
+				}
+				for i, shares := range want {
+					if !approx(lots[i].Shares, shares) {
+						t.Errorf("position %v lot %d: got %v shares, want %v", key, i, lots[i].Shares, shares)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRealizedPnlFIFO(t *testing.T) {
+	tests := []struct {
+		name        string
+		trades      []*Trade
+		realizedPnl float64
+		wins        int
+		totalClosed int
+	}{
+		{
+			name: "no disposals",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.4, 10, 0),
+			},
+		},
+		{
+			name: "a win and a loss",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.4, 10, 0),
+				fifoTrade("b2", "m1", "Yes", "BUY", 0.6, 10, 1),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.5, 20, 2),
+			},
+			realizedPnl: 0,
+			wins:        1,
+			totalClosed: 2,
+		},
+		{
+			name: "break-even disposals count as neither",
+			trades: []*Trade{
+				fifoTrade("b1", "m1", "Yes", "BUY", 0.5, 10, 0),
+				fifoTrade("s1", "m1", "Yes", "SELL", 0.5, 10, 1),
+				fifoTrade("b2", "m2", "No", "BUY", 0.2, 10, 2),
+				fifoTrade("s2", "m2", "No", "SELL", 0.9, 10, 3),
+			},
+			realizedPnl: 7,
+			wins:        1,
+			totalClosed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			realizedPnl, wins, totalClosed := realizedPnlFIFO(tt.trades)
+			if !approx(realizedPnl, tt.realizedPnl) || wins != tt.wins || totalClosed != tt.totalClosed {
+				t.Errorf("got %v, %d wins of %d, want %v, %d wins of %d", realizedPnl, wins, totalClosed, tt.realizedPnl, tt.wins, tt.totalClosed)
+			}
+		})
+	}
+}
+
+// approx reports whether two amounts are equal but for float rounding
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// ptr returns a pointer to a copy of v
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,167 +2,362 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"embed"
+	"encoding/hex"
 	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// migrations contains the database schema migrations
-var migrations = []string{
-	// Users table
-	`CREATE TABLE IF NOT EXISTS users (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		username TEXT UNIQUE NOT NULL,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-		last_synced DATETIME
-	)`,
-
-	// Addresses table (many-to-one with users)
-	`CREATE TABLE IF NOT EXISTS addresses (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		user_id INTEGER NOT NULL,
-		address TEXT NOT NULL,
-		FOREIGN KEY (user_id) REFERENCES users(id),
-		UNIQUE(user_id, address)
-	)`,
-
-	// Positions table (current snapshot)
-	`CREATE TABLE IF NOT EXISTS positions (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		user_id INTEGER NOT NULL,
-		address TEXT NOT NULL,
-		condition_id TEXT NOT NULL,
-		asset TEXT NOT NULL,
-		market_title TEXT,
-		market_slug TEXT,
-		outcome TEXT,
-		size REAL,
-		avg_price REAL,
-		current_price REAL,
-		initial_value REAL,
-		current_value REAL,
-		unrealized_pnl REAL,
-		unrealized_pnl_percent REAL,
-		realized_pnl REAL,
-		end_date DATETIME,
-		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-		FOREIGN KEY (user_id) REFERENCES users(id),
-		UNIQUE(user_id, address, condition_id, asset)
-	)`,
-
-	// Trades table (historical)
-	`CREATE TABLE IF NOT EXISTS trades (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		user_id INTEGER NOT NULL,
-		address TEXT NOT NULL,
-		trade_id TEXT,
-		condition_id TEXT NOT NULL,
-		market_title TEXT,
-		market_slug TEXT,
-		outcome TEXT,
-		side TEXT NOT NULL,
-		price REAL NOT NULL,
-		size REAL NOT NULL,
-		value REAL,
-		timestamp DATETIME NOT NULL,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-		FOREIGN KEY (user_id) REFERENCES users(id),
-		UNIQUE(user_id, condition_id, timestamp, side, size, price)
-	)`,
-
-	// PNL snapshots table (for historical charts)
-	`CREATE TABLE IF NOT EXISTS pnl_snapshots (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		user_id INTEGER NOT NULL,
-		timestamp DATETIME NOT NULL,
-		total_pnl REAL,
-		realized_pnl REAL,
-		unrealized_pnl REAL,
-		FOREIGN KEY (user_id) REFERENCES users(id)
-	)`,
-
-	// Indexes
-	`CREATE INDEX IF NOT EXISTS idx_positions_user ON positions(user_id)`,
-	`CREATE INDEX IF NOT EXISTS idx_trades_user ON trades(user_id)`,
-	`CREATE INDEX IF NOT EXISTS idx_trades_timestamp ON trades(timestamp)`,
-	`CREATE INDEX IF NOT EXISTS idx_pnl_snapshots_user_time ON pnl_snapshots(user_id, timestamp)`,
-
-	// Personas table
-	`CREATE TABLE IF NOT EXISTS personas (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		slug TEXT UNIQUE NOT NULL,
-		display_name TEXT NOT NULL,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-	)`,
-
-	// Add persona_id column to users table (nullable for backwards compatibility)
-	`ALTER TABLE users ADD COLUMN persona_id INTEGER REFERENCES personas(id)`,
-
-	// Index for user-persona relationship
-	`CREATE INDEX IF NOT EXISTS idx_users_persona ON users(persona_id)`,
-
-	// Add profile_image column to users table
-	`ALTER TABLE users ADD COLUMN profile_image TEXT`,
-
-	// Add image column to personas table
-	`ALTER TABLE personas ADD COLUMN image TEXT`,
-
-	// Add official PnL columns to users table (scraped from Polymarket profile page)
-	`ALTER TABLE users ADD COLUMN official_pnl REAL`,
-	`ALTER TABLE users ADD COLUMN official_volume REAL`,
-}
-
-// runMigrations executes all database migrations
-func runMigrations(ctx context.Context, db *sql.DB) error {
-	// Create migrations tracking table
-	_, err := db.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version INTEGER PRIMARY KEY,
-			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)
-	`)
+// migrationFiles holds the schema migrations as NNNN_name.up.sql / NNNN_name.down.sql pairs.
+// Versions must be contiguous from 1, and a migration's up file must never change once released:
+// applied migrations are verified against the checksum recorded when they ran.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationFilePattern matches a migration file name
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// irreversiblePrefix starts the first line of the down file of a migration that can't be
+// reverted, followed by the reason
+const irreversiblePrefix = "-- irreversible:"
+
+// Migration is a versioned schema change
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 of the up file
+	// Irreversible is why the migration can't be reverted, empty when it can. Down refuses to
+	// revert past it.
+	Irreversible string
+}
+
+// MigrationStatus is a migration and whether it has been applied
+type MigrationStatus struct {
+	*Migration
+	AppliedAt *time.Time
+	// Modified is set when the applied migration's checksum differs from the file's
+	Modified bool
+}
+
+// Migrator applies and reverts schema migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+// NewMigrator creates a migrator for a database using the embedded migrations
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
 	if err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// OpenMigrator opens the database at path for running migrations outside the server.
+// Close the returned migrator when done.
+func OpenMigrator(ctx context.Context, path string) (*Migrator, error) {
+	db, err := openDatabase(ctx, path)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get current version
-	var currentVersion int
-	err = db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&currentVersion)
+	m, err := NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Close closes the migrator's database
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Latest returns the version of the newest migration
+func (m *Migrator) Latest() int {
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// loadMigrations reads and checks the migration files
+func loadMigrations(files fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(files, "migrations")
 	if err != nil {
-		return fmt.Errorf("failed to get current migration version: %w", err)
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
 	}
 
-	// Apply pending migrations
-	for i := currentVersion; i < len(migrations); i++ {
-		version := i + 1
-		migration := migrations[i]
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
 
-		// Start transaction
-		tx, err := db.BeginTx(ctx, nil)
+		version, _ := strconv.Atoi(match[1])
+		data, err := fs.ReadFile(files, path.Join("migrations", entry.Name()))
 		if err != nil {
-			return fmt.Errorf("failed to begin transaction for migration %d: %w", version, err)
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
 		}
 
-		// Execute migration
-		if _, err := tx.ExecContext(ctx, migration); err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				return fmt.Errorf("failed to execute migration %d: %w (rollback error: %v)", version, err, rbErr)
-			}
-			return fmt.Errorf("failed to execute migration %d: %w", version, err)
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names: %s and %s", version, mig.Name, match[2])
 		}
 
-		// Record migration
-		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				return fmt.Errorf("failed to record migration %d: %w (rollback error: %v)", version, err, rbErr)
+		if match[3] == "up" {
+			sum := sha256.Sum256(data)
+			mig.Up = string(data)
+			mig.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			mig.Down = string(data)
+			if first, _, _ := strings.Cut(mig.Down, "\n"); strings.HasPrefix(first, irreversiblePrefix) {
+				mig.Irreversible = strings.TrimSpace(strings.TrimPrefix(first, irreversiblePrefix))
 			}
-			return fmt.Errorf("failed to record migration %d: %w", version, err)
 		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		migrations = append(migrations, mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, mig := range migrations {
+		if mig.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be contiguous from 1, missing %d", i+1)
+		}
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d (%s) needs both an up and a down file", mig.Version, mig.Name)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Status lists every migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status := &MigrationStatus{Migration: mig}
+		if row, ok := applied[mig.Version]; ok {
+			status.AppliedAt = &row.appliedAt
+			status.Modified = row.checksum != mig.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Up applies every pending migration in order, returning those applied
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	applied, err := m.verified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+
+		err := m.exec(ctx, mig.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, checksum, applied_at) VALUES (?, ?, "+sqlNow+")", mig.Version, mig.Checksum)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %d (%s): %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
 
-		// Commit transaction
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("failed to commit migration %d: %w", version, err)
+// Down reverts the most recently applied migrations, newest first, returning those reverted
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	applied, err := m.verified(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reverting from an older build would skip the newer migrations and break the schema
+	for version := range applied {
+		if version > m.Latest() {
+			return nil, fmt.Errorf("database has migration %d applied, newer than this build (latest %d)", version, m.Latest())
+		}
+	}
+
+	var pending []*Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(pending) < steps; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			pending = append(pending, m.migrations[i])
+		}
+	}
+
+	// Checked up front, so nothing is reverted when the steps would cross an irreversible one
+	for _, mig := range pending {
+		if mig.Irreversible != "" {
+			return nil, fmt.Errorf("migration %d (%s) can't be reverted: %s", mig.Version, mig.Name, mig.Irreversible)
+		}
+	}
+
+	var done []*Migration
+	for _, mig := range pending {
+		err := m.exec(ctx, mig.Down, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", mig.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to revert migration %d (%s): %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
+
+// exec runs a migration script and records it in one transaction
+func (m *Migrator) exec(ctx context.Context, script string, record func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	appliedAt time.Time
+	checksum  string
+}
+
+// verified returns the applied migrations after checking each still matches its file.
+// Migrations newer than this build, applied by a newer instance sharing the database, are ignored.
+func (m *Migrator) verified(ctx context.Context) (map[int]*appliedMigration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mig := range m.migrations {
+		row, ok := applied[mig.Version]
+		if ok && row.checksum != mig.Checksum {
+			return nil, fmt.Errorf("migration %d (%s) was modified after it was applied: checksum %s, file %s",
+				mig.Version, mig.Name, row.checksum, mig.Checksum)
+		}
+	}
+
+	return applied, nil
+}
+
+// applied reads the migrations recorded in the database
+func (m *Migrator) applied(ctx context.Context) (map[int]*appliedMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at, checksum FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]*appliedMigration)
+	for rows.Next() {
+		var version int
+		var checksum sql.NullString
+		row := &appliedMigration{}
+		if err := rows.Scan(&version, &row.appliedAt, &checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		row.checksum = checksum.String
+		applied[version] = row
+	}
+
+	return applied, rows.Err()
+}
+
+// ensureTable creates the migrations tracking table, adding checksums to tables created before
+// migrations had them. Migrations applied before then are trusted to match their files.
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			checksum TEXT
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	var hasChecksum bool
+	err = m.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) > 0 FROM pragma_table_info('schema_migrations') WHERE name = 'checksum'",
+	).Scan(&hasChecksum)
+	if err != nil {
+		return fmt.Errorf("failed to inspect migrations table: %w", err)
+	}
+	if !hasChecksum {
+		if _, err := m.db.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN checksum TEXT"); err != nil {
+			return fmt.Errorf("failed to add migration checksums: %w", err)
+		}
+	}
+
+	for _, mig := range m.migrations {
+		_, err := m.db.ExecContext(ctx,
+			"UPDATE schema_migrations SET checksum = ? WHERE version = ? AND checksum IS NULL",
+			mig.Checksum, mig.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record checksum of migration %d: %w", mig.Version, err)
 		}
 	}
 
 	return nil
 }
+
+// runMigrations applies all pending migrations
+func runMigrations(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Up(ctx)
+	return err
+}
@@ -0,0 +1,8 @@
+-- Addresses table (many-to-one with users)
+CREATE TABLE IF NOT EXISTS addresses (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	UNIQUE(user_id, address)
+);
@@ -0,0 +1,23 @@
+-- Positions table (current snapshot)
+CREATE TABLE IF NOT EXISTS positions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	condition_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	market_title TEXT,
+	market_slug TEXT,
+	outcome TEXT,
+	size REAL,
+	avg_price REAL,
+	current_price REAL,
+	initial_value REAL,
+	current_value REAL,
+	unrealized_pnl REAL,
+	unrealized_pnl_percent REAL,
+	realized_pnl REAL,
+	end_date DATETIME,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	UNIQUE(user_id, address, condition_id, asset)
+);
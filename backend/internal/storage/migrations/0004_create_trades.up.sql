@@ -0,0 +1,19 @@
+-- Trades table (historical)
+CREATE TABLE IF NOT EXISTS trades (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	trade_id TEXT,
+	condition_id TEXT NOT NULL,
+	market_title TEXT,
+	market_slug TEXT,
+	outcome TEXT,
+	side TEXT NOT NULL,
+	price REAL NOT NULL,
+	size REAL NOT NULL,
+	value REAL,
+	timestamp DATETIME NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	UNIQUE(user_id, condition_id, timestamp, side, size, price)
+);
@@ -0,0 +1,10 @@
+-- PNL snapshots table (for historical charts)
+CREATE TABLE IF NOT EXISTS pnl_snapshots (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	timestamp DATETIME NOT NULL,
+	total_pnl REAL,
+	realized_pnl REAL,
+	unrealized_pnl REAL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,2 @@
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_positions_user ON positions(user_id);
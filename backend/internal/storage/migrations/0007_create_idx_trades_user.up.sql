@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_trades_user ON trades(user_id);
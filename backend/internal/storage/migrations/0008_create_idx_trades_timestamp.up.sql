@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_trades_timestamp ON trades(timestamp);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pnl_snapshots_user_time;
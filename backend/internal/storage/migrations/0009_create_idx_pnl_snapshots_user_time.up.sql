@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pnl_snapshots_user_time ON pnl_snapshots(user_id, timestamp);
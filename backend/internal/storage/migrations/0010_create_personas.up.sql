@@ -0,0 +1,7 @@
+-- Personas table
+CREATE TABLE IF NOT EXISTS personas (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	slug TEXT UNIQUE NOT NULL,
+	display_name TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN persona_id;
@@ -0,0 +1,2 @@
+-- Add persona_id column to users table (nullable for backwards compatibility)
+ALTER TABLE users ADD COLUMN persona_id INTEGER REFERENCES personas(id);
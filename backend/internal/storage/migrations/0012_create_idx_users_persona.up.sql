@@ -0,0 +1,2 @@
+-- Index for user-persona relationship
+CREATE INDEX IF NOT EXISTS idx_users_persona ON users(persona_id);
@@ -0,0 +1,2 @@
+-- Add profile_image column to users table
+ALTER TABLE users ADD COLUMN profile_image TEXT;
@@ -0,0 +1 @@
+ALTER TABLE personas DROP COLUMN image;
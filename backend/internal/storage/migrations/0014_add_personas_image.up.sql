@@ -0,0 +1,2 @@
+-- Add image column to personas table
+ALTER TABLE personas ADD COLUMN image TEXT;
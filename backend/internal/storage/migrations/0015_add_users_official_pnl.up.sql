@@ -0,0 +1,2 @@
+-- Add official PnL columns to users table (scraped from Polymarket profile page)
+ALTER TABLE users ADD COLUMN official_pnl REAL;
@@ -0,0 +1,10 @@
+-- Sync errors table (last N failures per user for diagnosing flaky addresses)
+CREATE TABLE IF NOT EXISTS sync_errors (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	address TEXT,
+	phase TEXT NOT NULL,
+	message TEXT NOT NULL,
+	timestamp DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
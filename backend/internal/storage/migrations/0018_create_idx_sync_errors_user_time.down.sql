@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_sync_errors_user_time;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_sync_errors_user_time ON sync_errors(user_id, timestamp);
@@ -0,0 +1,9 @@
+-- Official PnL history (a row is only written when the scraped value changes)
+CREATE TABLE IF NOT EXISTS official_pnl_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	timestamp DATETIME NOT NULL,
+	pnl REAL NOT NULL,
+	volume REAL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_official_pnl_history_user_time;
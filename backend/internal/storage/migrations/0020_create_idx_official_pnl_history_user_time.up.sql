@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_official_pnl_history_user_time ON official_pnl_history(user_id, timestamp);
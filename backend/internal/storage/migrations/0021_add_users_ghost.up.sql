@@ -0,0 +1,2 @@
+-- Ghost mode: track a user without showing them on public leaderboards or the trade feed
+ALTER TABLE users ADD COLUMN ghost INTEGER NOT NULL DEFAULT 0;
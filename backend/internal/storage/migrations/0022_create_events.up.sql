@@ -0,0 +1,5 @@
+-- Events group markets; trades are attributed to an event by slug
+CREATE TABLE IF NOT EXISTS events (
+	slug TEXT PRIMARY KEY,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
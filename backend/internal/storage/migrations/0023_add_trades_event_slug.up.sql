@@ -0,0 +1 @@
+ALTER TABLE trades ADD COLUMN event_slug TEXT REFERENCES events(slug);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_trades_event_slug ON trades(event_slug);
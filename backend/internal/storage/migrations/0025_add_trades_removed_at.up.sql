@@ -0,0 +1,2 @@
+-- Tombstone for trades that are no longer returned upstream
+ALTER TABLE trades ADD COLUMN removed_at DATETIME;
@@ -0,0 +1,18 @@
+-- Positions archived at market resolution with their final settlement price
+CREATE TABLE IF NOT EXISTS position_settlements (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	condition_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	outcome TEXT,
+	market_title TEXT,
+	market_slug TEXT,
+	size REAL NOT NULL,
+	avg_price REAL NOT NULL,
+	settlement_price REAL NOT NULL,
+	pnl REAL NOT NULL,
+	resolved_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	UNIQUE(user_id, address, condition_id, asset)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_position_settlements_user_condition;
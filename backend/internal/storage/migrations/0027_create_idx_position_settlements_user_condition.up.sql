@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_position_settlements_user_condition ON position_settlements(user_id, condition_id);
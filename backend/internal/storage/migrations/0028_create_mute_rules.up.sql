@@ -0,0 +1,8 @@
+-- Trade feed mute rules defined through the API (kind is minValue, user or category)
+CREATE TABLE IF NOT EXISTS mute_rules (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind TEXT NOT NULL,
+	value TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(kind, value)
+);
@@ -0,0 +1 @@
+ALTER TABLE addresses DROP COLUMN group_name;
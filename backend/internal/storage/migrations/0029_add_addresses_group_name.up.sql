@@ -0,0 +1,2 @@
+-- Named address groups ("sub-portfolios") within a user
+ALTER TABLE addresses ADD COLUMN group_name TEXT;
@@ -0,0 +1,6 @@
+-- Leases granting one instance exclusive use of a shared job, such as sync
+CREATE TABLE IF NOT EXISTS leases (
+	name TEXT PRIMARY KEY,
+	holder TEXT NOT NULL,
+	expires_at INTEGER NOT NULL -- unix milliseconds
+);
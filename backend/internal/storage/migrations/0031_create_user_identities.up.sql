@@ -0,0 +1,10 @@
+-- Polymarket profile identity per user, evidence for linking alt accounts into personas
+CREATE TABLE IF NOT EXISTS user_identities (
+	user_id INTEGER PRIMARY KEY,
+	name TEXT,
+	pseudonym TEXT,
+	bio TEXT,
+	x_username TEXT,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,11 @@
+-- Achievements awarded to users, each at most once
+CREATE TABLE IF NOT EXISTS user_badges (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	badge TEXT NOT NULL,
+	awarded_at DATETIME NOT NULL,
+	detail TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(user_id, badge),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1 @@
+ALTER TABLE pnl_snapshots DROP COLUMN source;
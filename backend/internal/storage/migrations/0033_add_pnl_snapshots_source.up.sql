@@ -0,0 +1,2 @@
+-- Where a PnL snapshot came from: sync (recorded live) or backfill (reconstructed from trades)
+ALTER TABLE pnl_snapshots ADD COLUMN source TEXT NOT NULL DEFAULT 'sync';
@@ -0,0 +1,3 @@
+-- irreversible: converting to UTC dropped the zones timestamps were written in, and merged duplicate trades
+-- Builds before this migration only parse the time.String() format, not RFC 3339, so restore a
+-- backup taken before it instead of migrating down.
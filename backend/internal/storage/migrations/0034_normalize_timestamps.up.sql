@@ -0,0 +1,98 @@
+-- Normalize stored timestamps to RFC 3339 UTC (2006-01-02T15:04:05Z). Earlier builds wrote Go's
+-- time.String() format in the server's zone ("2006-01-02 15:04:05.999 -0700 MST m=+0.1") and
+-- SQLite's CURRENT_TIMESTAMP ("2006-01-02 15:04:05", UTC). Go-formatted values are first rewritten
+-- with an offset SQLite understands, then everything is converted to UTC. Values SQLite can't
+-- parse are left as they are.
+
+UPDATE users SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE users SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE users SET last_synced = substr(last_synced, 1, 19) || substr(last_synced, instr(substr(last_synced, 20), ' ') + 20, 3) || ':' || substr(last_synced, instr(substr(last_synced, 20), ' ') + 23, 2)
+WHERE last_synced GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE users SET last_synced = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', last_synced), last_synced)
+WHERE typeof(last_synced) = 'text' AND last_synced NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE positions SET end_date = substr(end_date, 1, 19) || substr(end_date, instr(substr(end_date, 20), ' ') + 20, 3) || ':' || substr(end_date, instr(substr(end_date, 20), ' ') + 23, 2)
+WHERE end_date GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE positions SET end_date = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', end_date), end_date)
+WHERE typeof(end_date) = 'text' AND end_date NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE positions SET updated_at = substr(updated_at, 1, 19) || substr(updated_at, instr(substr(updated_at, 20), ' ') + 20, 3) || ':' || substr(updated_at, instr(substr(updated_at, 20), ' ') + 23, 2)
+WHERE updated_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE positions SET updated_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', updated_at), updated_at)
+WHERE typeof(updated_at) = 'text' AND updated_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE OR IGNORE trades SET timestamp = substr(timestamp, 1, 19) || substr(timestamp, instr(substr(timestamp, 20), ' ') + 20, 3) || ':' || substr(timestamp, instr(substr(timestamp, 20), ' ') + 23, 2)
+WHERE timestamp GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE OR IGNORE trades SET timestamp = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', timestamp), timestamp)
+WHERE typeof(timestamp) = 'text' AND timestamp NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+-- The same trade stored twice in different zones collides once normalized, drop the leftover copy
+DELETE FROM trades WHERE timestamp NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', timestamp) IS NOT NULL;
+
+UPDATE trades SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE trades SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE trades SET removed_at = substr(removed_at, 1, 19) || substr(removed_at, instr(substr(removed_at, 20), ' ') + 20, 3) || ':' || substr(removed_at, instr(substr(removed_at, 20), ' ') + 23, 2)
+WHERE removed_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE trades SET removed_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', removed_at), removed_at)
+WHERE typeof(removed_at) = 'text' AND removed_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE pnl_snapshots SET timestamp = substr(timestamp, 1, 19) || substr(timestamp, instr(substr(timestamp, 20), ' ') + 20, 3) || ':' || substr(timestamp, instr(substr(timestamp, 20), ' ') + 23, 2)
+WHERE timestamp GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE pnl_snapshots SET timestamp = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', timestamp), timestamp)
+WHERE typeof(timestamp) = 'text' AND timestamp NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE personas SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE personas SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE sync_errors SET timestamp = substr(timestamp, 1, 19) || substr(timestamp, instr(substr(timestamp, 20), ' ') + 20, 3) || ':' || substr(timestamp, instr(substr(timestamp, 20), ' ') + 23, 2)
+WHERE timestamp GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE sync_errors SET timestamp = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', timestamp), timestamp)
+WHERE typeof(timestamp) = 'text' AND timestamp NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE official_pnl_history SET timestamp = substr(timestamp, 1, 19) || substr(timestamp, instr(substr(timestamp, 20), ' ') + 20, 3) || ':' || substr(timestamp, instr(substr(timestamp, 20), ' ') + 23, 2)
+WHERE timestamp GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE official_pnl_history SET timestamp = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', timestamp), timestamp)
+WHERE typeof(timestamp) = 'text' AND timestamp NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE events SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE events SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE position_settlements SET resolved_at = substr(resolved_at, 1, 19) || substr(resolved_at, instr(substr(resolved_at, 20), ' ') + 20, 3) || ':' || substr(resolved_at, instr(substr(resolved_at, 20), ' ') + 23, 2)
+WHERE resolved_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE position_settlements SET resolved_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', resolved_at), resolved_at)
+WHERE typeof(resolved_at) = 'text' AND resolved_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE mute_rules SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE mute_rules SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE user_identities SET updated_at = substr(updated_at, 1, 19) || substr(updated_at, instr(substr(updated_at, 20), ' ') + 20, 3) || ':' || substr(updated_at, instr(substr(updated_at, 20), ' ') + 23, 2)
+WHERE updated_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE user_identities SET updated_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', updated_at), updated_at)
+WHERE typeof(updated_at) = 'text' AND updated_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE user_badges SET awarded_at = substr(awarded_at, 1, 19) || substr(awarded_at, instr(substr(awarded_at, 20), ' ') + 20, 3) || ':' || substr(awarded_at, instr(substr(awarded_at, 20), ' ') + 23, 2)
+WHERE awarded_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE user_badges SET awarded_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', awarded_at), awarded_at)
+WHERE typeof(awarded_at) = 'text' AND awarded_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE user_badges SET created_at = substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2)
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE user_badges SET created_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', created_at), created_at)
+WHERE typeof(created_at) = 'text' AND created_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
+
+UPDATE schema_migrations SET applied_at = substr(applied_at, 1, 19) || substr(applied_at, instr(substr(applied_at, 20), ' ') + 20, 3) || ':' || substr(applied_at, instr(substr(applied_at, 20), ' ') + 23, 2)
+WHERE applied_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE schema_migrations SET applied_at = COALESCE(strftime('%Y-%m-%dT%H:%M:%SZ', applied_at), applied_at)
+WHERE typeof(applied_at) = 'text' AND applied_at NOT GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]:[0-9][0-9]:[0-9][0-9]Z';
@@ -0,0 +1,13 @@
+-- Comments and emoji reactions posted on trades, or on a user's result in a market when
+-- trade_id is NULL
+CREATE TABLE IF NOT EXISTS reactions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	trade_id TEXT,
+	condition_id TEXT NOT NULL,
+	author TEXT NOT NULL,
+	emoji TEXT,
+	comment TEXT,
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
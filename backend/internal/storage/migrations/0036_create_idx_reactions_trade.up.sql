@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_reactions_trade ON reactions(trade_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_reactions_user_condition;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_reactions_user_condition ON reactions(user_id, condition_id);
@@ -0,0 +1,9 @@
+-- Pending account ownership claims: the user proves ownership by putting the nonce in the
+-- bio of one of their Polymarket profiles
+CREATE TABLE IF NOT EXISTS user_claims (
+	user_id INTEGER PRIMARY KEY,
+	nonce TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
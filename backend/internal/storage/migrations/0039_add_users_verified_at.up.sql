@@ -0,0 +1,2 @@
+-- When the user proved ownership of the account through a claim
+ALTER TABLE users ADD COLUMN verified_at DATETIME;
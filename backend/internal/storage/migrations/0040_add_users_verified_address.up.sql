@@ -0,0 +1,2 @@
+-- Address whose Polymarket bio carried the claim nonce
+ALTER TABLE users ADD COLUMN verified_address TEXT;
@@ -0,0 +1,2 @@
+-- Set on trades imported from a file rather than synced, which reconciliation leaves alone
+ALTER TABLE trades ADD COLUMN imported_at DATETIME;
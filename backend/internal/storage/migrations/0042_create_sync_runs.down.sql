@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sync_run_users_run;
+DROP TABLE IF EXISTS sync_run_users;
+DROP TABLE IF EXISTS sync_runs;
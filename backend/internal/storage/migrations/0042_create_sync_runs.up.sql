@@ -0,0 +1,29 @@
+-- Sync run history (one row per sync cycle, for tracking sync performance over time)
+CREATE TABLE IF NOT EXISTS sync_runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	trigger TEXT NOT NULL,
+	started_at DATETIME NOT NULL,
+	finished_at DATETIME NOT NULL,
+	duration_ms INTEGER NOT NULL,
+	users INTEGER NOT NULL DEFAULT 0,
+	deferred_users INTEGER NOT NULL DEFAULT 0,
+	api_calls INTEGER NOT NULL DEFAULT 0,
+	rows_written INTEGER NOT NULL DEFAULT 0,
+	errors INTEGER NOT NULL DEFAULT 0
+);
+
+-- Per-user breakdown of each sync run
+CREATE TABLE IF NOT EXISTS sync_run_users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	run_id INTEGER NOT NULL,
+	username TEXT NOT NULL,
+	started_at DATETIME NOT NULL,
+	duration_ms INTEGER NOT NULL,
+	api_calls INTEGER NOT NULL DEFAULT 0,
+	rows_written INTEGER NOT NULL DEFAULT 0,
+	errors INTEGER NOT NULL DEFAULT 0,
+	failure TEXT,
+	FOREIGN KEY (run_id) REFERENCES sync_runs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_run_users_run ON sync_run_users(run_id);
@@ -0,0 +1,3 @@
+-- Hash of the synced position payload, so unchanged positions aren't rewritten and updated_at
+-- marks the last actual change
+ALTER TABLE positions ADD COLUMN payload_hash TEXT;
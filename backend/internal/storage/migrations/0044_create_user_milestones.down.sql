@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_milestones_user_occurred;
+DROP TABLE IF EXISTS user_milestones;
@@ -0,0 +1,16 @@
+-- PnL milestones reached by users: all-time highs, round numbers crossed and winning streaks.
+-- key distinguishes repeatable milestones of a kind, e.g. the day of an all-time high.
+CREATE TABLE IF NOT EXISTS user_milestones (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	kind TEXT NOT NULL,
+	key TEXT NOT NULL,
+	occurred_at DATETIME NOT NULL,
+	value REAL NOT NULL,
+	detail TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(user_id, kind, key),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_milestones_user_occurred ON user_milestones(user_id, occurred_at);
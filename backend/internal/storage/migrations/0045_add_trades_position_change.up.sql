@@ -0,0 +1,3 @@
+-- How a trade moved its position: open, add, trim or close. NULL until classified, and for
+-- sells of positions the stored history never bought
+ALTER TABLE trades ADD COLUMN position_change TEXT;
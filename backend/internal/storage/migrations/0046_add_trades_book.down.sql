@@ -0,0 +1,4 @@
+ALTER TABLE trades DROP COLUMN book_captured_at;
+ALTER TABLE trades DROP COLUMN book_best_ask;
+ALTER TABLE trades DROP COLUMN book_best_bid;
+ALTER TABLE trades DROP COLUMN book_midpoint;
@@ -0,0 +1,6 @@
+-- The traded outcome's order book when the trade was synced, set only for trades synced soon
+-- after they were made
+ALTER TABLE trades ADD COLUMN book_midpoint REAL;
+ALTER TABLE trades ADD COLUMN book_best_bid REAL;
+ALTER TABLE trades ADD COLUMN book_best_ask REAL;
+ALTER TABLE trades ADD COLUMN book_captured_at DATETIME;
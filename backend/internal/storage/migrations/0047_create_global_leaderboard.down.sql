@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_global_leaderboard_pnl;
+DROP INDEX IF EXISTS idx_global_leaderboard_address;
+DROP TABLE IF EXISTS global_leaderboard;
@@ -0,0 +1,14 @@
+-- Polymarket's public all-time PnL leaderboard, the top traders across all of Polymarket.
+-- Replaced wholesale on each refresh; rank is the position Polymarket reports.
+CREATE TABLE IF NOT EXISTS global_leaderboard (
+	rank INTEGER PRIMARY KEY,
+	address TEXT NOT NULL,
+	name TEXT,
+	pnl REAL NOT NULL,
+	volume REAL NOT NULL,
+	profile_image TEXT,
+	fetched_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_global_leaderboard_address ON global_leaderboard(address);
+CREATE INDEX IF NOT EXISTS idx_global_leaderboard_pnl ON global_leaderboard(pnl);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_trades_address_timestamp ON trades(address, timestamp);
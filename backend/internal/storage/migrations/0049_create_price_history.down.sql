@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_trades_condition_timestamp;
+DROP TABLE IF EXISTS price_history;
@@ -0,0 +1,11 @@
+-- Outcome prices seen in synced positions, the last one of each hour, so resolved results can
+-- show how the price travelled before resolving.
+CREATE TABLE IF NOT EXISTS price_history (
+	condition_id TEXT NOT NULL,
+	outcome TEXT NOT NULL,
+	hour DATETIME NOT NULL,
+	price REAL NOT NULL,
+	PRIMARY KEY (condition_id, outcome, hour)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_condition_timestamp ON trades(condition_id, timestamp);
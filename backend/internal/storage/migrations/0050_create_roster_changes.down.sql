@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_roster_changes_created;
+DROP TABLE IF EXISTS roster_changes;
@@ -0,0 +1,11 @@
+-- Log of changes made to the tracked users and personas, from config at startup or the API
+CREATE TABLE IF NOT EXISTS roster_changes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	action TEXT NOT NULL,
+	target TEXT NOT NULL,
+	detail TEXT,
+	source TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_roster_changes_created ON roster_changes(created_at);
@@ -0,0 +1 @@
+-- Backfilled values are left in place, they can't be told apart from synced ones
@@ -0,0 +1,3 @@
+-- Trade value is the notional in USDC, price times size. Fill it in for trades stored without one.
+UPDATE trades SET value = price * size
+WHERE value IS NULL AND price IS NOT NULL AND size IS NOT NULL;
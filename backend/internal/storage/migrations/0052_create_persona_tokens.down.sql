@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_persona_tokens_persona;
+DROP TABLE IF EXISTS persona_tokens;
@@ -0,0 +1,13 @@
+-- Tokens letting a persona's owner see details about their own accounts the public API hides.
+-- Only a hash of each token is kept.
+CREATE TABLE IF NOT EXISTS persona_tokens (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	persona_id INTEGER NOT NULL REFERENCES personas(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL,
+	last_used_at DATETIME,
+	revoked_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_persona_tokens_persona ON persona_tokens(persona_id);
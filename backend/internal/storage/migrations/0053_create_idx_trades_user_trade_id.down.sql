@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_trades_user_trade_id;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_trades_user_trade_id ON trades(user_id, trade_id);
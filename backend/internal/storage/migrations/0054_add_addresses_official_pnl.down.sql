@@ -0,0 +1,3 @@
+ALTER TABLE addresses DROP COLUMN official_updated_at;
+ALTER TABLE addresses DROP COLUMN official_volume;
+ALTER TABLE addresses DROP COLUMN official_pnl;
@@ -0,0 +1,5 @@
+-- Official PnL and volume from each address's Polymarket profile page; a user's official
+-- numbers are the sum over their addresses
+ALTER TABLE addresses ADD COLUMN official_pnl REAL;
+ALTER TABLE addresses ADD COLUMN official_volume REAL;
+ALTER TABLE addresses ADD COLUMN official_updated_at DATETIME;
@@ -0,0 +1,8 @@
+-- Categories of traded markets, from the Gamma API (source gamma) or inferred from the market
+-- title when Gamma has none (source inferred)
+CREATE TABLE IF NOT EXISTS market_categories (
+	condition_id TEXT PRIMARY KEY,
+	category TEXT NOT NULL,
+	source TEXT NOT NULL,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,11 @@
+-- API keys issued through the admin API, alongside those in config.yaml. The scope is read or
+-- admin. Only a hash of each key is kept.
+CREATE TABLE IF NOT EXISTS api_keys (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	scope TEXT NOT NULL,
+	key_hash TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL,
+	last_used_at DATETIME,
+	revoked_at DATETIME
+);
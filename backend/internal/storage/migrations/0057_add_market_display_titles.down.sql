@@ -0,0 +1,2 @@
+ALTER TABLE positions DROP COLUMN market_display_title;
+ALTER TABLE trades DROP COLUMN market_display_title;
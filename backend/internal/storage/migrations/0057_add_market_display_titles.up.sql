@@ -0,0 +1,7 @@
+-- Display-safe market titles, normalized from the raw ones at ingest: no emoji or invisible
+-- characters and a bounded length. display_title is registered by the storage package.
+ALTER TABLE trades ADD COLUMN market_display_title TEXT;
+ALTER TABLE positions ADD COLUMN market_display_title TEXT;
+
+UPDATE trades SET market_display_title = display_title(market_title) WHERE market_title IS NOT NULL;
+UPDATE positions SET market_display_title = display_title(market_title) WHERE market_title IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_markets_closed_fetched_at;
+DROP TABLE IF EXISTS markets;
@@ -0,0 +1,20 @@
+-- Canonical metadata of traded markets, cached from the Gamma API and referenced by the
+-- condition IDs of trades and positions. outcomes is a JSON array of {name, price}. Markets
+-- Gamma doesn't know have found 0 and the title and slug of their trades.
+CREATE TABLE IF NOT EXISTS markets (
+	condition_id TEXT PRIMARY KEY,
+	title TEXT NOT NULL,
+	slug TEXT,
+	event_slug TEXT,
+	event_title TEXT,
+	category TEXT,
+	end_date DATETIME,
+	closed INTEGER NOT NULL DEFAULT 0,
+	resolved INTEGER NOT NULL DEFAULT 0,
+	winning_outcome TEXT,
+	outcomes TEXT NOT NULL DEFAULT '[]',
+	found INTEGER NOT NULL DEFAULT 1,
+	fetched_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_markets_closed_fetched_at ON markets(closed, fetched_at);
@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO market_categories (condition_id, category, source, updated_at)
+SELECT condition_id, category, 'gamma', fetched_at FROM markets WHERE category IS NOT NULL;
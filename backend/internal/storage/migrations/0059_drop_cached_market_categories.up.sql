@@ -0,0 +1,5 @@
+-- Gamma categories of markets cached in markets are read from there, so drop their copies.
+-- market_categories keeps the markets categorization looked up before they were cached.
+DELETE FROM market_categories
+WHERE source = 'gamma'
+AND condition_id IN (SELECT condition_id FROM markets WHERE category IS NOT NULL);
@@ -0,0 +1,368 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// migrationFS builds migration files from name and content pairs
+func migrationFS(files map[string]string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(files))
+	for name, content := range files {
+		fsys["migrations/"+name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return fsys
+}
+
+// sha256Hex returns the hex sha256 of content, as migration checksums are recorded
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		wantErr      string
+		versions     []int
+		irreversible map[int]string
+	}{
+		{
+			name: "pairs are ordered by version",
+			files: map[string]string{
+				"0002_add_b.up.sql":   "CREATE TABLE b (id INTEGER);",
+				"0002_add_b.down.sql": "DROP TABLE b;",
+				"0001_add_a.up.sql":   "CREATE TABLE a (id INTEGER);",
+				"0001_add_a.down.sql": "DROP TABLE a;",
+			},
+			versions: []int{1, 2},
+		},
+		{
+			name: "irreversible down files give their reason",
+			files: map[string]string{
+				"0001_add_a.up.sql":   "CREATE TABLE a (id INTEGER);",
+				"0001_add_a.down.sql": "-- irreversible: the data is gone\nSELECT 1;",
+			},
+			versions:     []int{1},
+			irreversible: map[int]string{1: "the data is gone"},
+		},
+		{
+			name: "only the first line marks a migration irreversible",
+			files: map[string]string{
+				"0001_add_a.up.sql":   "CREATE TABLE a (id INTEGER);",
+				"0001_add_a.down.sql": "DROP TABLE a;\n-- irreversible: not really",
+			},
+			versions: []int{1},
+		},
+		{
+			name: "a missing down file is rejected",
+			files: map[string]string{
+				"0001_add_a.up.sql": "CREATE TABLE a (id INTEGER);",
+			},
+			wantErr: "needs both an up and a down file",
+		},
+		{
+			name: "a gap in versions is rejected",
+			files: map[string]string{
+				"0001_add_a.up.sql":   "CREATE TABLE a (id INTEGER);",
+				"0001_add_a.down.sql": "DROP TABLE a;",
+				"0003_add_c.up.sql":   "CREATE TABLE c (id INTEGER);",
+				"0003_add_c.down.sql": "DROP TABLE c;",
+			},
+			wantErr: "missing 2",
+		},
+		{
+			name: "files of a version must share a name",
+			files: map[string]string{
+				"0001_add_a.up.sql":      "CREATE TABLE a (id INTEGER);",
+				"0001_create_a.down.sql": "DROP TABLE a;",
+			},
+			wantErr: "different names",
+		},
+		{
+			name: "other file names are rejected",
+			files: map[string]string{
+				"0001_add_a.sql": "CREATE TABLE a (id INTEGER);",
+			},
+			wantErr: "invalid migration file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(migrationFS(tt.files))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("got %d migrations, want %d", len(migrations), len(tt.versions))
+			}
+			for i, mig := range migrations {
+				if mig.Version != tt.versions[i] {
+					t.Errorf("migration %d: got version %d, want %d", i, mig.Version, tt.versions[i])
+				}
+				if want := sha256Hex(mig.Up); mig.Checksum != want {
+					t.Errorf("migration %d: got checksum %s, want the up file's %s", mig.Version, mig.Checksum, want)
+				}
+				if mig.Irreversible != tt.irreversible[mig.Version] {
+					t.Errorf("migration %d: got irreversible %q, want %q", mig.Version, mig.Irreversible, tt.irreversible[mig.Version])
+				}
+			}
+		})
+	}
+}
+
+func TestMigratorChecksums(t *testing.T) {
+	released := map[string]string{
+		"0001_add_a.up.sql":   "CREATE TABLE a (id INTEGER);",
+		"0001_add_a.down.sql": "DROP TABLE a;",
+		"0002_add_b.up.sql":   "CREATE TABLE b (id INTEGER);",
+		"0002_add_b.down.sql": "DROP TABLE b;",
+	}
+
+	// with returns the released files with some replaced or added
+	with := func(changes map[string]string) map[string]string {
+		files := make(map[string]string, len(released)+len(changes))
+		for name, content := range released {
+			files[name] = content
+		}
+		for name, content := range changes {
+			files[name] = content
+		}
+		return files
+	}
+
+	tests := []struct {
+		name string
+		// applied are the files the database is migrated up with first
+		applied map[string]string
+		// setup runs against the database after it's migrated
+		setup func(t *testing.T, m *Migrator)
+		// files are those of the build under test
+		files     map[string]string
+		downSteps int // reverts this many migrations instead of migrating up, when set
+		wantErr   string
+		wantRows  map[int]string // version to recorded checksum after the run
+		modified  []int          // versions Status reports as modified
+	}{
+		{
+			name:     "up records the checksum of each up file",
+			files:    released,
+			wantRows: map[int]string{1: sha256Hex(released["0001_add_a.up.sql"]), 2: sha256Hex(released["0002_add_b.up.sql"])},
+		},
+		{
+			name:    "up applies only new migrations",
+			applied: released,
+			files: with(map[string]string{
+				"0003_add_c.up.sql":   "CREATE TABLE c (id INTEGER);",
+				"0003_add_c.down.sql": "DROP TABLE c;",
+			}),
+			wantRows: map[int]string{
+				1: sha256Hex(released["0001_add_a.up.sql"]),
+				2: sha256Hex(released["0002_add_b.up.sql"]),
+				3: sha256Hex("CREATE TABLE c (id INTEGER);"),
+			},
+		},
+		{
+			name:     "up refuses a modified migration",
+			applied:  released,
+			files:    with(map[string]string{"0002_add_b.up.sql": "CREATE TABLE b (id INTEGER, name TEXT);"}),
+			wantErr:  "migration 2 (add_b) was modified after it was applied",
+			modified: []int{2},
+		},
+		{
+			name:      "down refuses a modified migration",
+			applied:   released,
+			files:     with(map[string]string{"0001_add_a.up.sql": "CREATE TABLE a (id INTEGER, name TEXT);"}),
+			downSteps: 1,
+			wantErr:   "migration 1 (add_a) was modified after it was applied",
+			modified:  []int{1},
+		},
+		{
+			name:    "down files can change after release",
+			applied: released,
+			files:   with(map[string]string{"0002_add_b.down.sql": "DROP TABLE IF EXISTS b;"}),
+			wantRows: map[int]string{
+				1: sha256Hex(released["0001_add_a.up.sql"]),
+				2: sha256Hex(released["0002_add_b.up.sql"]),
+			},
+		},
+		{
+			name:    "checksums missing from older databases are recorded as they are",
+			applied: released,
+			setup: func(t *testing.T, m *Migrator) {
+				if _, err := m.db.Exec("UPDATE schema_migrations SET checksum = NULL"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			files: with(map[string]string{"0002_add_b.up.sql": "CREATE TABLE b (id INTEGER, name TEXT);"}),
+			wantRows: map[int]string{
+				1: sha256Hex(released["0001_add_a.up.sql"]),
+				2: sha256Hex("CREATE TABLE b (id INTEGER, name TEXT);"),
+			},
+		},
+		{
+			name:      "down reverts the newest migrations and forgets their checksums",
+			applied:   released,
+			files:     released,
+			downSteps: 1,
+			wantRows:  map[int]string{1: sha256Hex(released["0001_add_a.up.sql"])},
+		},
+		{
+			name:      "down refuses to cross an irreversible migration",
+			applied:   with(map[string]string{"0002_add_b.down.sql": "-- irreversible: b can't be dropped\nSELECT 1;"}),
+			files:     with(map[string]string{"0002_add_b.down.sql": "-- irreversible: b can't be dropped\nSELECT 1;"}),
+			downSteps: 2,
+			wantErr:   "migration 2 (add_b) can't be reverted: b can't be dropped",
+			wantRows: map[int]string{
+				1: sha256Hex(released["0001_add_a.up.sql"]),
+				2: sha256Hex(released["0002_add_b.up.sql"]),
+			},
+		},
+		{
+			name: "down refuses when a newer build migrated the database",
+			applied: with(map[string]string{
+				"0003_add_c.up.sql":   "CREATE TABLE c (id INTEGER);",
+				"0003_add_c.down.sql": "DROP TABLE c;",
+			}),
+			files:     released,
+			downSteps: 1,
+			wantErr:   "database has migration 3 applied, newer than this build (latest 2)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, err := openDatabase(ctx, filepath.Join(t.TempDir(), "pyre.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			migrator := func(files map[string]string) *Migrator {
+				migrations, err := loadMigrations(migrationFS(files))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return &Migrator{db: db, migrations: migrations}
+			}
+
+			if tt.applied != nil {
+				m := migrator(tt.applied)
+				if _, err := m.Up(ctx); err != nil {
+					t.Fatal(err)
+				}
+				if tt.setup != nil {
+					tt.setup(t, m)
+				}
+			}
+
+			m := migrator(tt.files)
+			if tt.downSteps > 0 {
+				_, err = m.Down(ctx, tt.downSteps)
+			} else {
+				_, err = m.Up(ctx)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantRows != nil {
+				applied, err := m.applied(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(applied) != len(tt.wantRows) {
+					t.Errorf("got %d applied migrations, want %d", len(applied), len(tt.wantRows))
+				}
+				for version, checksum := range tt.wantRows {
+					row, ok := applied[version]
+					if !ok {
+						t.Errorf("migration %d isn't applied", version)
+						continue
+					}
+					if row.checksum != checksum {
+						t.Errorf("migration %d: got checksum %s, want %s", version, row.checksum, checksum)
+					}
+				}
+			}
+
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, status := range statuses {
+				want := false
+				for _, version := range tt.modified {
+					want = want || version == status.Version
+				}
+				if status.Modified != want {
+					t.Errorf("migration %d: got modified %v, want %v", status.Version, status.Modified, want)
+				}
+			}
+		})
+	}
+}
+
+// TestEmbeddedMigrations applies the released migrations to a new database, reverts those that
+// can be reverted and applies them again, so every down file is exercised against its up file
+func TestEmbeddedMigrations(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := OpenMigrator(ctx, filepath.Join(t.TempDir(), "pyre.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate up: %v", err)
+	}
+
+	reversible := 0
+	for i := len(m.migrations) - 1; i >= 0 && m.migrations[i].Irreversible == ""; i-- {
+		reversible++
+	}
+	if reversible == 0 {
+		t.Skip("the newest migration is irreversible")
+	}
+
+	reverted, err := m.Down(ctx, reversible)
+	if err != nil {
+		t.Fatalf("failed to migrate down: %v", err)
+	}
+	if len(reverted) != reversible {
+		t.Fatalf("reverted %d migrations, want %d", len(reverted), reversible)
+	}
+
+	if _, err := m.Down(ctx, 1); err == nil || !strings.Contains(err.Error(), "can't be reverted") {
+		t.Fatalf("got error %v reverting an irreversible migration", err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("failed to migrate up again: %v", err)
+	}
+	if len(applied) != reversible {
+		t.Fatalf("applied %d migrations again, want %d", len(applied), reversible)
+	}
+}
@@ -6,20 +6,28 @@ import (
 
 // User represents a tracked user in the database
 type User struct {
-	ID             int64      `db:"id"`
-	Username       string     `db:"username"`
-	CreatedAt      time.Time  `db:"created_at"`
-	LastSynced     *time.Time `db:"last_synced"`
-	ProfileImage   *string    `db:"profile_image"`
-	OfficialPnl    *float64   `db:"official_pnl"`    // All-time PnL from Polymarket profile page
-	OfficialVolume *float64   `db:"official_volume"` // All-time volume from Polymarket profile page
+	ID              int64      `db:"id"`
+	Username        string     `db:"username"`
+	CreatedAt       time.Time  `db:"created_at"`
+	LastSynced      *time.Time `db:"last_synced"`
+	ProfileImage    *string    `db:"profile_image"`
+	OfficialPnl     *float64   `db:"official_pnl"`     // All-time PnL from Polymarket profile page
+	OfficialVolume  *float64   `db:"official_volume"`  // All-time volume from Polymarket profile page
+	Ghost           bool       `db:"ghost"`            // Tracked but hidden from public leaderboards and the trade feed
+	VerifiedAt      *time.Time `db:"verified_at"`      // When ownership of the account was proven through a claim
+	VerifiedAddress *string    `db:"verified_address"` // Address whose profile bio carried the claim nonce
 }
 
 // Address represents a wallet address associated with a user
 type Address struct {
-	ID      int64  `db:"id"`
-	UserID  int64  `db:"user_id"`
-	Address string `db:"address"`
+	ID      int64   `db:"id"`
+	UserID  int64   `db:"user_id"`
+	Address string  `db:"address"`
+	Group   *string `db:"group_name"` // named sub-portfolio the address belongs to, if any
+	// All-time PnL and volume from the address's Polymarket profile page, nil until fetched
+	OfficialPnl       *float64   `db:"official_pnl"`
+	OfficialVolume    *float64   `db:"official_volume"`
+	OfficialUpdatedAt *time.Time `db:"official_updated_at"`
 }
 
 // Position represents a current position in the database
@@ -30,6 +38,7 @@ type Position struct {
 	ConditionID          string     `db:"condition_id"`
 	Asset                string     `db:"asset"`
 	MarketTitle          *string    `db:"market_title"`
+	MarketDisplayTitle   *string    `db:"market_display_title"` // MarketTitle made safe to display, see titles.Display
 	MarketSlug           *string    `db:"market_slug"`
 	Outcome              *string    `db:"outcome"`
 	Size                 *float64   `db:"size"`
@@ -42,26 +51,50 @@ type Position struct {
 	RealizedPnl          *float64   `db:"realized_pnl"`
 	EndDate              *time.Time `db:"end_date"`
 	UpdatedAt            time.Time  `db:"updated_at"`
+
+	// The buy that opened the holding, the last trade of its outcome classified
+	// PositionChangeOpen. Only set for display, and nil when the stored history doesn't reach it.
+	FirstEnteredAt *time.Time
+	EntryTradeID   *string
 }
 
 // Trade represents a historical trade in the database
 type Trade struct {
-	ID          int64      `db:"id"`
-	UserID      int64      `db:"user_id"`
-	Address     string     `db:"address"`
-	TradeID     *string    `db:"trade_id"`
-	ConditionID *string    `db:"condition_id"`
-	MarketTitle *string    `db:"market_title"`
-	MarketSlug  *string    `db:"market_slug"`
-	Outcome     *string    `db:"outcome"`
-	Side        *string    `db:"side"`
-	Price       *float64   `db:"price"`
-	Size        *float64   `db:"size"`
-	Value       *float64   `db:"value"`
-	Timestamp   *time.Time `db:"timestamp"`
-	CreatedAt   time.Time  `db:"created_at"`
+	ID                 int64      `db:"id"`
+	UserID             int64      `db:"user_id"`
+	Address            string     `db:"address"`
+	TradeID            *string    `db:"trade_id"`
+	ConditionID        *string    `db:"condition_id"`
+	MarketTitle        *string    `db:"market_title"`
+	MarketDisplayTitle *string    `db:"market_display_title"` // MarketTitle made safe to display, see titles.Display
+	MarketSlug         *string    `db:"market_slug"`
+	EventSlug          *string    `db:"event_slug"`
+	Outcome            *string    `db:"outcome"`
+	Side               *string    `db:"side"`
+	Price              *float64   `db:"price"`
+	Size               *float64   `db:"size"`
+	Value              *float64   `db:"value"`
+	Timestamp          *time.Time `db:"timestamp"`
+	CreatedAt          time.Time  `db:"created_at"`
+	// PositionChange is how the trade moved its position, one of the PositionChange constants.
+	// nil for sells of positions the stored history never bought.
+	PositionChange *string `db:"position_change"`
+	// The order book of the traded outcome when the trade was synced, to judge the trade against
+	// the market consensus of the time. nil unless the trade was synced soon after it was made.
+	BookMidpoint   *float64   `db:"book_midpoint"`
+	BookBestBid    *float64   `db:"book_best_bid"`
+	BookBestAsk    *float64   `db:"book_best_ask"`
+	BookCapturedAt *time.Time `db:"book_captured_at"`
 }
 
+// Position changes, classified against the running position in the trade's market outcome
+const (
+	PositionChangeOpen  = "open"  // bought into a position not held before
+	PositionChangeAdd   = "add"   // bought more of a held position
+	PositionChangeTrim  = "trim"  // sold part of a held position
+	PositionChangeClose = "close" // sold the rest of a held position
+)
+
 // TradeWithUsername represents a trade with the associated username
 type TradeWithUsername struct {
 	Trade
@@ -70,13 +103,55 @@ type TradeWithUsername struct {
 
 // TradeFilters represents filtering options for trades
 type TradeFilters struct {
+	Limit           int
+	Offset          int
+	Username        *string
+	Side            *string
+	MinValue        *float64
+	Start           *time.Time // only trades at or after
+	End             *time.Time // only trades before
+	SortBy          string
+	SortDirection   string
+	IncludeGhosts   bool
+	MutedUsers      []string // usernames excluded from the results
+	MutedCategories []string // market categories excluded from the results
+}
+
+// TradeMarketGroup represents the trades matching a trade feed filter in one market
+type TradeMarketGroup struct {
+	ConditionID  string
+	MarketTitle  *string
+	MarketSlug   *string
+	Trades       int
+	BuyValue     float64
+	SellValue    float64
+	Size         float64 // shares traded
+	FirstTradeAt *time.Time
+	LastTradeAt  *time.Time
+	Participants []*TradeMarketParticipant // by volume, largest first
+}
+
+// Volume is the value of all the trades
+func (g *TradeMarketGroup) Volume() float64 {
+	return g.BuyValue + g.SellValue
+}
+
+// TradeMarketParticipant represents one user's trades within a TradeMarketGroup
+type TradeMarketParticipant struct {
+	UserID    int64
+	Username  string
+	Trades    int
+	BuyValue  float64
+	SellValue float64
+}
+
+// UserFilters represents paging and sorting options for listing users
+type UserFilters struct {
 	Limit         int
 	Offset        int
-	Username      *string
-	Side          *string
-	MinValue      *float64
-	SortBy        string
+	SortBy        string // a UserSortColumns key, or with GetUserStatsPage a UserStatSorts one
 	SortDirection string
+	IncludeGhosts bool // ghost users are left out unless set
 }
 
 // PnlSnapshot represents a point-in-time PNL snapshot
@@ -87,6 +162,74 @@ type PnlSnapshot struct {
 	TotalPnl      *float64  `db:"total_pnl"`
 	RealizedPnl   *float64  `db:"realized_pnl"`
 	UnrealizedPnl *float64  `db:"unrealized_pnl"`
+	Source        string    `db:"source"` // SnapshotSourceSync when empty
+}
+
+// SnapshotTier thins PnL snapshots older than After to the latest one per user and source in
+// each Every long bucket
+type SnapshotTier struct {
+	After time.Duration
+	Every time.Duration
+}
+
+// PnL snapshot sources
+const (
+	SnapshotSourceSync     = "sync"     // recorded live by a sync
+	SnapshotSourceBackfill = "backfill" // reconstructed from trade history
+)
+
+// OfficialPnlSnapshot represents a change in the official PnL scraped from Polymarket
+type OfficialPnlSnapshot struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Timestamp time.Time `db:"timestamp"`
+	Pnl       float64   `db:"pnl"`
+	Volume    *float64  `db:"volume"`
+}
+
+// SyncError represents a failure recorded during a user sync
+type SyncError struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Address   *string   `db:"address"`
+	Phase     string    `db:"phase"` // e.g. profile, official_pnl, positions, trades, snapshot
+	Message   string    `db:"message"`
+	Timestamp time.Time `db:"timestamp"`
+}
+
+// SyncRun represents one sync cycle across the tracked users
+type SyncRun struct {
+	ID            int64         `db:"id"`
+	Trigger       string        `db:"trigger"` // SyncTriggerInitial, SyncTriggerScheduled or SyncTriggerManual
+	StartedAt     time.Time     `db:"started_at"`
+	FinishedAt    time.Time     `db:"finished_at"`
+	Duration      time.Duration `db:"duration_ms"`
+	Users         int           `db:"users"`          // users synced
+	DeferredUsers int           `db:"deferred_users"` // users left for the next cycle when the call budget ran out
+	APICalls      int           `db:"api_calls"`
+	RowsWritten   int           `db:"rows_written"`
+	Errors        int           `db:"errors"`
+	UserRuns      []*SyncRunUser
+}
+
+// Sync run triggers
+const (
+	SyncTriggerInitial   = "initial"   // the sync at startup
+	SyncTriggerScheduled = "scheduled" // a sync on the interval
+	SyncTriggerManual    = "manual"    // a sync requested through the API
+)
+
+// SyncRunUser represents one user's part of a sync run
+type SyncRunUser struct {
+	ID          int64         `db:"id"`
+	RunID       int64         `db:"run_id"`
+	Username    string        `db:"username"`
+	StartedAt   time.Time     `db:"started_at"`
+	Duration    time.Duration `db:"duration_ms"`
+	APICalls    int           `db:"api_calls"`
+	RowsWritten int           `db:"rows_written"`
+	Errors      int           `db:"errors"`  // sync errors recorded, see SyncError
+	Failure     *string       `db:"failure"` // why the user's sync stopped early, if it did
 }
 
 // UserStats represents aggregated statistics for a user
@@ -100,7 +243,11 @@ type UserStats struct {
 	OpenPositions int
 	TotalTrades   int
 	WinRate       float64
+	Volume        float64
 	LastSynced    *time.Time
+	LastTradeAt   *time.Time // most recent trade, nil without trades
+	CreatedAt     time.Time  // when the user started being tracked
+	Ghost         bool
 }
 
 // Persona represents a real person mapped to multiple usernames
@@ -124,6 +271,7 @@ type PersonaStats struct {
 	OpenPositions int
 	TotalTrades   int
 	WinRate       float64
+	Volume        float64 // summed over users, each one's official volume or else the value of their tracked trades
 }
 
 // PersonaAccount represents a user account belonging to a persona with individual stats
@@ -160,16 +308,30 @@ type PersonaInfo struct {
 
 // Result represents a resolved position with win/loss information
 type Result struct {
-	ID             int64      `db:"id"`
-	UserID         int64      `db:"user_id"`
-	ConditionID    string     `db:"condition_id"`
-	MarketTitle    *string    `db:"market_title"`
-	MarketSlug     *string    `db:"market_slug"`
-	Outcome        *string    `db:"outcome"`
-	RealizedPnl    float64    `db:"realized_pnl"`
-	InitialValue   *float64   `db:"initial_value"`
-	EndDate        *time.Time `db:"end_date"`
-	ResolutionDate *time.Time `db:"resolution_date"` // When position was closed or market ended
+	ID                 int64      `db:"id"`
+	UserID             int64      `db:"user_id"`
+	ConditionID        string     `db:"condition_id"`
+	MarketTitle        *string    `db:"market_title"`
+	MarketDisplayTitle *string    `db:"market_display_title"` // MarketTitle made safe to display, see titles.Display
+	MarketSlug         *string    `db:"market_slug"`
+	Outcome            *string    `db:"outcome"`
+	RealizedPnl        float64    `db:"realized_pnl"`
+	InitialValue       *float64   `db:"initial_value"`
+	EndDate            *time.Time `db:"end_date"`
+	ResolutionDate     *time.Time `db:"resolution_date"` // When position was closed or market ended
+
+	SettlementPrice *float64 // Settlement price of the held outcome, if captured at resolution
+	SettledPnl      *float64 // Exact PnL at settlement, if captured at resolution
+
+	PriceSeries []*PricePoint // Price of the held outcome from the first buy to resolution, oldest first
+}
+
+// PricePoint is the price of a market outcome at a point in time
+type PricePoint struct {
+	ConditionID string
+	Outcome     string
+	Timestamp   time.Time
+	Price       float64
 }
 
 // ResultWithUsername represents a result with the associated username
@@ -180,6 +342,398 @@ type ResultWithUsername struct {
 
 // fifoLot represents a single lot of shares for FIFO cost basis tracking
 type fifoLot struct {
+	Shares   float64
+	Price    float64    // Price per share
+	OpenedAt *time.Time // Timestamp of the buy that opened the lot
+	TradeID  *string    // Polymarket ID of the buy that opened the lot
+}
+
+// fifoKey identifies a position within a single account's trade history
+type fifoKey struct {
+	conditionID string
+	outcome     string
+}
+
+// fifoDisposal is a lot, or part of one, closed by a sell or by market resolution
+type fifoDisposal struct {
 	Shares float64
-	Price  float64 // Price per share
+	Pnl    float64
+	Hold   *time.Duration // nil when the buy or close time is unknown
+
+	ConditionID  string
+	Outcome      string
+	OpenPrice    float64
+	ClosePrice   float64 // sell price, or settlement price when closed by resolution
+	OpenedAt     *time.Time
+	ClosedAt     *time.Time
+	OpenTradeID  *string
+	CloseTradeID *string // nil when closed by resolution
+}
+
+// MarketSearchResult represents a market matching a search with tracked-user activity counts
+type MarketSearchResult struct {
+	ConditionID string
+	MarketTitle *string
+	MarketSlug  *string
+	TradeCount  int
+	Holders     int     // distinct tracked users with an open position
+	TotalSize   float64 // total open position size held by tracked users
+	Outcomes    []*MarketOutcomeStats
+}
+
+// MarketOutcomeStats represents tracked open interest in a single outcome of a market
+type MarketOutcomeStats struct {
+	Outcome string
+	Holders int
+	Size    float64
+}
+
+// CategorizedMarket is the stored category of a market and where it came from
+type CategorizedMarket struct {
+	ConditionID string
+	Category    string
+	Source      string // CategorySourceGamma or CategorySourceInferred
+}
+
+// Market is the canonical metadata of a market, cached from the Gamma API. Trades and positions
+// reference it by condition ID.
+type Market struct {
+	ConditionID    string
+	Title          string
+	Slug           *string
+	EventSlug      *string
+	EventTitle     *string
+	Category       *string // as mapped by GammaCategory, nil when Gamma has none
+	EndDate        *time.Time
+	Closed         bool // no longer trading
+	Resolved       bool // closed with an outcome settled at 1
+	WinningOutcome *string
+	Outcomes       []MarketOutcome
+	Found          bool // false when Gamma doesn't know the market, titled from its trades
+	FetchedAt      time.Time
+}
+
+// MarketOutcome is an outcome of a market and its last price on Gamma
+type MarketOutcome struct {
+	Name  string   `json:"name"`
+	Price *float64 `json:"price,omitempty"`
+}
+
+// MarketSentiment represents tracked open interest across all users in a single market
+type MarketSentiment struct {
+	ConditionID string
+	MarketTitle *string
+	MarketSlug  *string
+	Holders     int
+	TotalSize   float64
+	TotalValue  float64
+	Outcomes    []*MarketOutcomeStats // ordered by size descending
+}
+
+// EdgeStats summarizes entry prices and outcomes of resolved positions
+type EdgeStats struct {
+	Category          string
+	Resolved          int
+	Wins              int
+	Losses            int
+	WinRate           float64
+	AvgEntryPrice     float64
+	AvgWinEntryPrice  *float64
+	AvgLossEntryPrice *float64
+	Edge              float64 // WinRate minus AvgEntryPrice, i.e. realized vs implied probability
+	KellyFraction     float64 // Full Kelly stake at AvgEntryPrice given WinRate, negative when there is no edge
+}
+
+// UserEdgeStats contains edge statistics overall and per market category
+type UserEdgeStats struct {
+	Overall    *EdgeStats
+	Categories []*EdgeStats // ordered by resolved count descending
+}
+
+// Event represents a Polymarket event grouping one or more markets
+type Event struct {
+	Slug      string    `db:"slug"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// EventUserStats represents a user's performance within a single event
+type EventUserStats struct {
+	UserID        int64
+	Username      string
+	ProfileImage  *string
+	TotalPnl      float64
+	RealizedPnl   float64
+	UnrealizedPnl float64
+	OpenPositions int
+	TotalTrades   int
+	WinRate       float64
+	Volume        float64
+}
+
+// TradeKey identifies a trade the same way the trades unique index does
+type TradeKey struct {
+	ConditionID string
+	Timestamp   int64 // Unix seconds
+	Side        string
+	Size        float64
+	Price       float64
+}
+
+// ReconcileResult summarizes a trade reconciliation pass for one address
+type ReconcileResult struct {
+	Checked  int // stored trades inside the reconciled window
+	Removed  int // newly tombstoned trades
+	Restored int // previously tombstoned trades seen upstream again
+}
+
+// ClosedHolding is a position in a market outcome sold off entirely by a trade
+type ClosedHolding struct {
+	Trade       *Trade  // the sell that closed the position
+	RealizedPnl float64 // FIFO PnL of the sells since the position was opened
+}
+
+// PositionSettlement is the archived state of a position captured when its market resolved
+type PositionSettlement struct {
+	UserID          int64
+	Address         string
+	ConditionID     string
+	Asset           string
+	Outcome         *string
+	MarketTitle     *string
+	MarketSlug      *string
+	Size            float64
+	AvgPrice        float64
+	SettlementPrice float64 // 0 or 1
+	Pnl             float64 // (SettlementPrice - AvgPrice) * Size
+	ResolvedAt      time.Time
+}
+
+// UserIdentity is the public identity of a user's Polymarket profile
+type UserIdentity struct {
+	UserID    int64
+	Username  string // tracked username
+	Name      *string
+	Pseudonym *string
+	Bio       *string
+	XUsername *string // X/Twitter handle linked to the profile
+	UpdatedAt time.Time
+}
+
+// UserClaim is a pending claim of a user's account, verified by finding the nonce in the bio of
+// one of the user's Polymarket profiles
+type UserClaim struct {
+	UserID    int64     `db:"user_id"`
+	Nonce     string    `db:"nonce"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// UserBadge is an achievement awarded to a user
+type UserBadge struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Badge     string    `db:"badge"`      // badge identifier, e.g. big_win
+	AwardedAt time.Time `db:"awarded_at"` // when the achievement was reached
+	Detail    *string   `db:"detail"`     // what earned it, e.g. the market of a big win
+	CreatedAt time.Time `db:"created_at"` // when the badge was recorded
+}
+
+// UserMilestone is a PnL milestone a user reached. Unlike badges, milestones of a kind repeat,
+// told apart by Key.
+type UserMilestone struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	Kind       string    `db:"kind"`        // e.g. all_time_high
+	Key        string    `db:"key"`         // identifies the milestone within its kind, e.g. the day of an all-time high
+	OccurredAt time.Time `db:"occurred_at"` // when it was reached
+	Value      float64   `db:"value"`       // the PnL, round number or streak length reached
+	Detail     *string   `db:"detail"`
+	CreatedAt  time.Time `db:"created_at"` // when the milestone was recorded
+}
+
+// RosterChange is a logged change to the tracked users and personas, such as a user added
+// to a persona or a persona renamed
+type RosterChange struct {
+	ID        int64     `db:"id"`
+	Action    string    `db:"action"` // one of the roster package's actions, e.g. create_user
+	Target    string    `db:"target"` // persona slug or username
+	Detail    *string   `db:"detail"`
+	Source    string    `db:"source"` // config or api
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// RosterChangeFilters contains filters for the roster change log
+type RosterChangeFilters struct {
+	Limit          int
+	Offset         int
+	ExcludeActions []string // e.g. set_ghost, for readers who mustn't learn who is a ghost
+	ExcludeGhosts  bool     // leaves out changes to users that are ghosts now
+}
+
+// PersonaToken lets a persona's owner read details about their own accounts. The token itself
+// is only known to the owner, its hash is stored.
+type PersonaToken struct {
+	ID         int64      `db:"id"`
+	PersonaID  int64      `db:"persona_id"`
+	Name       string     `db:"name"` // what the token is for, chosen when it's issued
+	CreatedAt  time.Time  `db:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+// Scopes of API keys. Admin keys are also accepted wherever a read key is.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeAdmin = "admin"
+)
+
+// APIKey is an API key issued through the admin API. The key itself is only known to whoever
+// it was issued to, its hash is stored.
+type APIKey struct {
+	ID         int64      `db:"id"`
+	Name       string     `db:"name"` // who or what the key is for, chosen when it's issued
+	Scope      string     `db:"scope"`
+	CreatedAt  time.Time  `db:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+// GlobalLeaderboardEntry is a trader on Polymarket's public all-time PnL leaderboard
+type GlobalLeaderboardEntry struct {
+	Rank         int       `db:"rank"`
+	Address      string    `db:"address"` // proxy wallet, lowercase
+	Name         *string   `db:"name"`    // Polymarket username, nil for anonymous traders
+	Pnl          float64   `db:"pnl"`
+	Volume       float64   `db:"volume"`
+	ProfileImage *string   `db:"profile_image"`
+	FetchedAt    time.Time `db:"fetched_at"`
+}
+
+// GlobalRank is where a PnL places on the stored global leaderboard
+type GlobalRank struct {
+	Rank int
+	// Estimated is set when none of the addresses is on the leaderboard and Rank is where
+	// the PnL would place among the stored entries
+	Estimated bool
+}
+
+// Reaction is a comment or emoji reaction posted on a trade or on a user's result in a market
+type Reaction struct {
+	ID          int64     `db:"id"`
+	UserID      int64     `db:"user_id"`      // user whose trade or result it is on
+	TradeID     *string   `db:"trade_id"`     // set for reactions on a trade, nil for reactions on a result
+	ConditionID string    `db:"condition_id"` // market of the trade or result
+	Author      string    `db:"author"`       // display name of the API key that posted it
+	Emoji       *string   `db:"emoji"`        // set for emoji reactions
+	Comment     *string   `db:"comment"`      // set for comments
+	CreatedAt   time.Time `db:"created_at"`
+	Username    string    `db:"username"` // joined from users when read
+}
+
+// ResultDetail is a user's result in a single market expanded into its trades, the FIFO
+// lots they were matched into and the market's resolution
+type ResultDetail struct {
+	ConditionID string
+	MarketTitle *string
+	MarketSlug  *string
+	Trades      []*Trade
+	Lots        []*ResultLot
+	Settlements []*PositionSettlement
+	RealizedPnl float64 // sum of the closed lots' PnL
+	OpenShares  float64 // shares bought but neither sold nor settled yet
+}
+
+// ResultLot is a FIFO lot, or part of one, matched against the sell or resolution that closed it
+type ResultLot struct {
+	Outcome      string
+	Shares       float64
+	OpenPrice    float64
+	ClosePrice   float64
+	Pnl          float64
+	OpenedAt     *time.Time
+	ClosedAt     *time.Time
+	Hold         *time.Duration
+	OpenTradeID  *string
+	CloseTradeID *string
+	Settled      bool // closed by market resolution rather than a sell
+}
+
+// GroupEquity is the combined exposure and PnL of a group of users
+type GroupEquity struct {
+	Users             int
+	OpenPositions     int
+	OpenPositionValue float64
+	TotalPnl          float64
+	RealizedPnl       float64
+	UnrealizedPnl     float64
+	DataPoints        []*GroupEquityPoint
+}
+
+// GroupEquityPoint is the combined PnL of a group of users at a point in time
+type GroupEquityPoint struct {
+	Timestamp     time.Time
+	TotalPnl      float64
+	RealizedPnl   float64
+	UnrealizedPnl float64
+}
+
+// MuteRules hide matching trades from the trade feed
+type MuteRules struct {
+	MinValue   *float64
+	Users      []string
+	Categories []string
+}
+
+// LongShotPrice is the entry price at or below which a buy counts as a long shot
+const LongShotPrice = 0.2
+
+// PersonaStyle describes how a persona trades, derived from trade history
+type PersonaStyle struct {
+	Positions         int      // distinct (account, market, outcome) positions entered
+	AvgHoldHours      *float64 // size-weighted time from entry to exit or resolution
+	HoldTime          *HoldTimeStats
+	AvgPositionSize   float64 // average USDC cost of a position
+	FavouriteCategory *string // market category with the most buy volume
+	LongShotRatio     float64 // share of buys entered at or below LongShotPrice
+	EntryTiming       *EntryTimingStats
+}
+
+// EntryTimingStats summarizes how long before a market's end date buys are entered
+type EntryTimingStats struct {
+	Entries          int      // buys in markets with a known end date
+	AvgDaysBeforeEnd *float64 // cost-weighted days from entry to end date, zero for buys after it
+}
+
+// HoldTimeStats summarizes how long positions are held, from FIFO-matched disposals
+type HoldTimeStats struct {
+	Disposals   int      // disposals with a known hold time
+	MedianHours *float64 // median hold time per disposal
+	MeanHours   *float64 // size-weighted mean hold time
+	Buckets     []*HoldTimeBucket
+}
+
+// HoldTimeBucket counts disposals whose hold time falls within a range
+type HoldTimeBucket struct {
+	Label     string
+	Disposals int
+	Shares    float64
+}
+
+// UngroupedAddressGroup names the group of a user's addresses that belong to no group
+const UngroupedAddressGroup = "ungrouped"
+
+// AddressGroupStats contains aggregated statistics for a named group of a user's addresses.
+// Realized PnL is FIFO over the group's trades, so groups sum to the user's trade-derived
+// totals rather than the official Polymarket PnL.
+type AddressGroupStats struct {
+	Name          string
+	Addresses     []string
+	TotalPnl      float64
+	RealizedPnl   float64
+	UnrealizedPnl float64
+	CurrentValue  float64
+	OpenPositions int
+	TotalTrades   int
+	WinRate       float64
+	Volume        float64
 }
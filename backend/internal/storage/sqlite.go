@@ -2,10 +2,17 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -16,40 +23,83 @@ import (
 type Storage interface {
 	Start(ctx context.Context) error
 	Stop() error
+	// Backup writes a consistent copy of the database to a new file at path
+	Backup(ctx context.Context, path string) error
 
 	// User operations
 	CreateUser(ctx context.Context, username string, addresses []string) (*User, error)
 	CreateUserWithPersona(ctx context.Context, username string, addresses []string, personaID int64) (*User, error)
 	GetUser(ctx context.Context, username string) (*User, error)
 	GetUsers(ctx context.Context) ([]*User, error)
+	GetLastSyncedAt(ctx context.Context) (*time.Time, error)
+	GetUsersPage(ctx context.Context, filters UserFilters) ([]*User, int, error)
+	GetUserStatsPage(ctx context.Context, filters UserFilters) ([]*UserStats, int, error)
 	UpdateUserLastSynced(ctx context.Context, userID int64, lastSynced time.Time) error
 	UpdateUserPersona(ctx context.Context, userID int64, personaID int64) error
+	UpdateUserIdentity(ctx context.Context, identity *UserIdentity) error
 	UpdateUserProfileImage(ctx context.Context, userID int64, profileImage string) error
 	UpdateUserOfficialPnl(ctx context.Context, userID int64, pnl, volume float64) error
+	UpdateAddressOfficialPnl(ctx context.Context, userID int64, address string, pnl, volume float64) (float64, float64, error)
+	UpdateUserGhost(ctx context.Context, userID int64, ghost bool) error
+	CreateUserClaim(ctx context.Context, claim *UserClaim) (*UserClaim, error)
+	GetUserClaim(ctx context.Context, userID int64) (*UserClaim, error)
+	VerifyUser(ctx context.Context, userID int64, address string, verifiedAt time.Time) error
+	ClearUserPersona(ctx context.Context, userID int64) error
+	AddUserAddress(ctx context.Context, userID int64, address string) error
+	RemoveUserAddress(ctx context.Context, userID int64, address string) error
+	SetAddressGroup(ctx context.Context, userID int64, address string, group *string) error
+	GetUserAddressGroupStats(ctx context.Context, userID int64) ([]*AddressGroupStats, error)
+	DeleteUser(ctx context.Context, userID int64) error
 
 	// Address operations
 	GetUserAddresses(ctx context.Context, userID int64) ([]*Address, error)
 
 	// Position operations
 	UpsertPosition(ctx context.Context, pos *Position) error
+	UpsertPositions(ctx context.Context, positions []*Position) error
 	GetUserPositions(ctx context.Context, userID int64) ([]*Position, error)
-	DeleteUserPositions(ctx context.Context, userID int64) error
+	GetUserOpenPositions(ctx context.Context, userID int64, includeDust bool) ([]*Position, error)
+	SyncAddressPositions(ctx context.Context, userID int64, address string, positions []*Position) (int, error)
+	PruneUserPositions(ctx context.Context, userID int64, addresses []string) (int, error)
+	RebuildUserPositions(ctx context.Context, userID int64) (int, error)
+	RecordPositionSettlements(ctx context.Context, settlements []*PositionSettlement) ([]*PositionSettlement, error)
+	RecordPrices(ctx context.Context, positions []*Position) (int, error)
 
 	// Trade operations
-	InsertTrade(ctx context.Context, trade *Trade) error
-	GetUserTrades(ctx context.Context, userID int64, limit, offset int) ([]*Trade, int, error)
+	InsertTrade(ctx context.Context, trade *Trade) (bool, error)
+	UpdateTradeBook(ctx context.Context, trade *Trade) error
+	ImportTrades(ctx context.Context, trades []*Trade, dryRun bool) (int, error)
+	GetUserTrades(ctx context.Context, userID int64, limit, offset int, minValue *float64) ([]*Trade, int, error)
 	GetAllTrades(ctx context.Context, filters TradeFilters) ([]*TradeWithUsername, int, error)
+	IterateTrades(ctx context.Context, filters TradeFilters, fn func(*TradeWithUsername) error) error
+	GetTradeMarketGroups(ctx context.Context, filters TradeFilters) ([]*TradeMarketGroup, int, error)
 	GetUserTradesChronological(ctx context.Context, userID int64) ([]*Trade, error)
+	ReconcileTrades(ctx context.Context, userID int64, address string, since time.Time, upstream map[TradeKey]struct{}) (*ReconcileResult, error)
+	CountRemovedTrades(ctx context.Context, userID int64) (int, error)
+	ClassifyTrades(ctx context.Context, userID int64) (int, error)
+	// GetClosedHoldings returns the holdings closed by the given trades of a user, leaving out
+	// trades that didn't sell the rest of a position
+	GetClosedHoldings(ctx context.Context, userID int64, tradeIDs []int64) ([]*ClosedHolding, error)
+	GetUserLastTradeBefore(ctx context.Context, userID int64, before time.Time) (*time.Time, error)
+	GetAddressLastTrades(ctx context.Context, addresses []string) (map[string]time.Time, error)
 
 	// PNL operations
 	InsertPnlSnapshot(ctx context.Context, snapshot *PnlSnapshot) error
 	GetUserPnlHistory(ctx context.Context, userID int64, start, end *time.Time) ([]*PnlSnapshot, error)
 	DeleteUserPnlSnapshots(ctx context.Context, userID int64) error
 	BulkInsertPnlSnapshots(ctx context.Context, snapshots []*PnlSnapshot) error
+	MergeBackfilledPnlSnapshots(ctx context.Context, userID int64, snapshots []*PnlSnapshot) (int, error)
+	DownsamplePnlSnapshots(ctx context.Context, tiers []SnapshotTier, now time.Time) (int, error)
+	GetUserOfficialPnlHistory(ctx context.Context, userID int64, start, end *time.Time) ([]*OfficialPnlSnapshot, error)
+	GetGroupEquity(ctx context.Context, users []*User, start, end *time.Time) (*GroupEquity, error)
+	GetMuteRules(ctx context.Context) (*MuteRules, error)
+	SetMuteRules(ctx context.Context, rules *MuteRules) error
 
 	// Aggregation operations
 	GetUserStats(ctx context.Context, username string) (*UserStats, error)
 	GetLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*UserStats, error)
+	// UserStatsCacheStats reports the hits and misses of the cache of users' FIFO totals
+	UserStatsCacheStats() CacheStats
 
 	// Persona operations
 	CreatePersona(ctx context.Context, slug, displayName string) (*Persona, error)
@@ -58,53 +108,197 @@ type Storage interface {
 	GetPersonas(ctx context.Context) ([]*Persona, error)
 	GetPersonaUsers(ctx context.Context, personaID int64) ([]*User, error)
 	GetPersonaStats(ctx context.Context, slug string) (*PersonaStats, error)
+	GetPersonaStyle(ctx context.Context, slug string) (*PersonaStyle, error)
+	GetPersonaIdentities(ctx context.Context, slug string) ([]*UserIdentity, error)
 	GetPersonaLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*PersonaStats, error)
-	GetPersonaPositions(ctx context.Context, slug string) ([]*PositionWithUsername, error)
-	GetPersonaTrades(ctx context.Context, slug string, limit, offset int) ([]*TradeWithUsername, int, error)
+	GetPersonaPositions(ctx context.Context, slug, sortBy, sortDirection string, includeDust bool) ([]*PositionWithUsername, error)
+	GetPersonaTrades(ctx context.Context, slug string, limit, offset int, sortBy, sortDirection string, minValue *float64) ([]*TradeWithUsername, int, error)
 	GetUserPersonaInfo(ctx context.Context, userID int64) (*PersonaInfo, error)
 	UpdatePersonaImage(ctx context.Context, personaID int64, image string) error
+	UpdatePersonaDisplayName(ctx context.Context, personaID int64, displayName string) error
+	DeletePersona(ctx context.Context, personaID int64) error
+
+	// Market operations
+	SearchMarkets(ctx context.Context, query string, limit int) ([]*MarketSearchResult, error)
+	GetMarketsByTitle(ctx context.Context, titles []string) (map[string]*MarketSearchResult, error)
+	GetUncategorizedMarkets(ctx context.Context, limit int) ([]*MarketSearchResult, error)
+	SetMarketCategories(ctx context.Context, markets []*CategorizedMarket) error
+	GetMarketSentiment(ctx context.Context) ([]*MarketSentiment, error)
+	// GetStaleMarkets returns up to limit markets of stored trades and positions that aren't
+	// cached, or that were still trading when last fetched before fetchedBefore
+	GetStaleMarkets(ctx context.Context, fetchedBefore time.Time, limit int) ([]*Market, error)
+	UpsertMarkets(ctx context.Context, markets []*Market) error
+	// GetMarket returns a cached market, ErrNotFound when it isn't cached
+	GetMarket(ctx context.Context, conditionID string) (*Market, error)
+
+	// Sync error operations
+	RecordSyncError(ctx context.Context, syncErr *SyncError, keep int) error
+	GetUserSyncErrors(ctx context.Context, userID int64, limit int) ([]*SyncError, error)
+
+	// Sync run operations
+	RecordSyncRun(ctx context.Context, run *SyncRun, keep int) error
+	GetSyncRuns(ctx context.Context, limit int) ([]*SyncRun, error)
+
+	// Lease operations
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+
+	// Badge operations
+	AwardBadge(ctx context.Context, badge *UserBadge) (bool, error)
+	GetUserBadges(ctx context.Context, userID int64) ([]*UserBadge, error)
+
+	// Milestone operations
+	SaveUserMilestones(ctx context.Context, userID int64, milestones []*UserMilestone) ([]*UserMilestone, error)
+	GetUserMilestones(ctx context.Context, userID int64, limit int) ([]*UserMilestone, error)
+	RecordRosterChanges(ctx context.Context, changes []*RosterChange) error
+	GetRosterChanges(ctx context.Context, filters RosterChangeFilters) ([]*RosterChange, int, error)
+
+	// Persona token operations
+	CreatePersonaToken(ctx context.Context, personaID int64, name, tokenHash string) (*PersonaToken, error)
+	GetPersonaTokens(ctx context.Context, personaID int64) ([]*PersonaToken, error)
+	// RevokePersonaToken revokes one of a persona's tokens, ErrNotFound when it has no such token
+	RevokePersonaToken(ctx context.Context, personaID, tokenID int64) error
+	// UsePersonaToken returns the unrevoked token with tokenHash and marks it used, ErrNotFound
+	// when there is none
+	UsePersonaToken(ctx context.Context, tokenHash string) (*PersonaToken, error)
+
+	// API key operations
+	CreateAPIKey(ctx context.Context, name, scope, keyHash string) (*APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*APIKey, error)
+	// RevokeAPIKey revokes an API key, ErrNotFound when there is no such key
+	RevokeAPIKey(ctx context.Context, id int64) error
+	// UseAPIKey returns the unrevoked key with keyHash and marks it used, ErrNotFound when there
+	// is none
+	UseAPIKey(ctx context.Context, keyHash string) (*APIKey, error)
+	// HasAPIKeys reports whether any unrevoked key has the scope
+	HasAPIKeys(ctx context.Context, scope string) (bool, error)
+
+	// Global leaderboard operations
+	ReplaceGlobalLeaderboard(ctx context.Context, entries []*GlobalLeaderboardEntry) error
+	GetGlobalLeaderboard(ctx context.Context, limit, offset int) ([]*GlobalLeaderboardEntry, int, error)
+	GetGlobalRank(ctx context.Context, addresses []string, pnl float64) (*GlobalRank, error)
+
+	// Reaction operations
+	AddReaction(ctx context.Context, reaction *Reaction) (bool, error)
+	GetTradeReactions(ctx context.Context, tradeIDs []string) (map[string][]*Reaction, error)
+	GetResultReactions(ctx context.Context, userID int64, conditionID string) ([]*Reaction, error)
+	DeleteReaction(ctx context.Context, id int64, author string) (bool, error)
 
 	// Results operations
 	GetUserResults(ctx context.Context, userID int64, limit, offset int) ([]*Result, int, error)
-	GetPersonaResults(ctx context.Context, slug string, limit, offset int) ([]*ResultWithUsername, int, error)
+	GetPersonaResults(ctx context.Context, slug string, limit, offset int, sortBy, sortDirection string, includeGhosts bool) ([]*ResultWithUsername, int, error)
+	GetUserEdgeStats(ctx context.Context, userID int64) (*UserEdgeStats, error)
+	GetUserHoldTimeStats(ctx context.Context, userID int64) (*HoldTimeStats, error)
+	GetUserEntryTiming(ctx context.Context, userID int64) (*EntryTimingStats, error)
+	GetUserResultDetail(ctx context.Context, userID int64, conditionID string) (*ResultDetail, error)
+
+	// Event operations
+	GetEvent(ctx context.Context, slug string) (*Event, error)
+	GetEventLeaderboard(ctx context.Context, slug string) ([]*EventUserStats, error)
+}
+
+// userColumns is the column list selected for User rows, in userScanDest order
+const userColumns = "id, username, created_at, last_synced, profile_image, official_pnl, official_volume, ghost, " +
+	"verified_at, verified_address"
+
+// userScanDest returns the scan destinations for a row selected with userColumns
+func userScanDest(user *User) []any {
+	return []any{
+		&user.ID, &user.Username, &user.CreatedAt, &user.LastSynced, &user.ProfileImage,
+		&user.OfficialPnl, &user.OfficialVolume, &user.Ghost, &user.VerifiedAt, &user.VerifiedAddress,
+	}
+}
+
+// timestampLayout is how timestamps are stored: RFC 3339 in UTC, to the second. Every stored
+// timestamp has the same fixed width, so comparing and ordering them as strings in SQL is
+// chronological.
+const timestampLayout = "2006-01-02T15:04:05Z"
+
+// sqlNow is the SQL expression for the current time in timestampLayout
+const sqlNow = "strftime('%Y-%m-%dT%H:%M:%SZ', 'now')"
+
+// formatTimestamp formats a time for storage
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+// formatNullTimestamp formats an optional time for storage, nil stays NULL
+func formatNullTimestamp(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatTimestamp(*t)
+}
+
+// parseNullTimestamp parses a stored timestamp that SQLite returns as a string, which it does
+// for aggregates of timestamp columns
+func parseNullTimestamp(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
 }
 
 // storage is the SQLite implementation of Storage
 type storage struct {
-	db   *sql.DB
-	path string
-	log  logrus.FieldLogger
+	db     *sql.DB // primary, used for writes and reads that must see them in the same transaction
+	reader *sql.DB // used by read-only methods; the primary unless a read pool is configured
+	path   string
+	log    logrus.FieldLogger
+
+	// readConnections sizes a separate read-only connection pool, 0 routes reads to the primary
+	readConnections int
+	// dustValue is the current value, in USDC, below which an open position is dust: left out of
+	// open position counts and exposure, and of position listings unless asked for
+	dustValue float64
+	// fifo caches the FIFO totals of user stats between syncs
+	fifo *fifoCache
 }
 
 var _ Storage = (*storage)(nil)
 
-// NewStorage creates a new Storage instance
-func NewStorage(path string, log logrus.FieldLogger) Storage {
+// NewStorage creates a new Storage instance. With readConnections > 0, read-only methods
+// use a pool of that many read-only connections alongside the single writer connection.
+func NewStorage(path string, readConnections int, dustValue float64, log logrus.FieldLogger) Storage {
 	return &storage{
-		path: path,
-		log:  log.WithField("package", "storage"),
+		path:            path,
+		readConnections: readConnections,
+		dustValue:       dustValue,
+		fifo:            newFIFOCache(),
+		log:             log.WithField("package", "storage"),
 	}
 }
 
-// Start initializes the database connection and runs migrations
-func (s *storage) Start(ctx context.Context) error {
-	s.log.Info("starting storage")
-
+// openDatabase opens the SQLite database at path, creating it if needed
+func openDatabase(ctx context.Context, path string) (*sql.DB, error) {
 	// Create directory if it doesn't exist
-	dir := filepath.Dir(s.path)
+	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create database directory: %w", err)
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
 	// Open database connection
-	db, err := sql.Open("sqlite", s.path)
+	db, err := sql.Open("sqlite", path)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Enable foreign keys
 	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = ON"); err != nil {
-		return fmt.Errorf("failed to enable foreign keys: %w", err)
+		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+
+	// WAL mode is required for continuous replication and lets readers run alongside the writer
+	if _, err := db.ExecContext(ctx, "PRAGMA journal_mode = WAL"); err != nil {
+		return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "PRAGMA busy_timeout = 5000"); err != nil {
+		return nil, fmt.Errorf("failed to set busy timeout: %w", err)
 	}
 
 	// Set connection pool settings
@@ -112,14 +306,47 @@ func (s *storage) Start(ctx context.Context) error {
 	db.SetMaxIdleConns(1)
 	db.SetConnMaxLifetime(0)
 
+	return db, nil
+}
+
+// Start initializes the database connection and runs migrations
+func (s *storage) Start(ctx context.Context) error {
+	s.log.Info("starting storage")
+
+	db, err := openDatabase(ctx, s.path)
+	if err != nil {
+		return err
+	}
+
 	s.db = db
+	s.reader = db
 
 	// Run migrations
 	if err := runMigrations(ctx, s.db); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	s.log.WithField("path", s.path).Info("storage started")
+	// WAL lets any number of readers run alongside the writer, so reads need not queue behind it
+	if s.readConnections > 0 {
+		reader, err := sql.Open("sqlite", s.path+"?_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+		if err != nil {
+			return fmt.Errorf("failed to open read pool: %w", err)
+		}
+		reader.SetMaxOpenConns(s.readConnections)
+		reader.SetMaxIdleConns(s.readConnections)
+		reader.SetConnMaxLifetime(0)
+
+		if err := reader.PingContext(ctx); err != nil {
+			reader.Close()
+			return fmt.Errorf("failed to connect read pool: %w", err)
+		}
+		s.reader = reader
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"path":             s.path,
+		"read_connections": s.readConnections,
+	}).Info("storage started")
 	return nil
 }
 
@@ -127,6 +354,12 @@ func (s *storage) Start(ctx context.Context) error {
 func (s *storage) Stop() error {
 	s.log.Info("stopping storage")
 
+	if s.reader != nil && s.reader != s.db {
+		if err := s.reader.Close(); err != nil {
+			return fmt.Errorf("failed to close read pool: %w", err)
+		}
+	}
+
 	if s.db != nil {
 		if err := s.db.Close(); err != nil {
 			return fmt.Errorf("failed to close database: %w", err)
@@ -137,6 +370,15 @@ func (s *storage) Stop() error {
 	return nil
 }
 
+// Backup writes a consistent, compacted copy of the database to path with VACUUM INTO.
+// path must not exist.
+func (s *storage) Backup(ctx context.Context, path string) error {
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
 // CreateUser creates a new user with addresses
 func (s *storage) CreateUser(ctx context.Context, username string, addresses []string) (*User, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -147,11 +389,11 @@ func (s *storage) CreateUser(ctx context.Context, username string, addresses []s
 
 	// Insert user
 	result, err := tx.ExecContext(ctx,
-		"INSERT INTO users (username, created_at) VALUES (?, CURRENT_TIMESTAMP)",
+		"INSERT INTO users (username, created_at) VALUES (?, "+sqlNow+")",
 		username,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert user: %w", err)
+		return nil, fmt.Errorf("failed to insert user: %w", conflictError(err))
 	}
 
 	userID, err := result.LastInsertId()
@@ -166,7 +408,7 @@ func (s *storage) CreateUser(ctx context.Context, username string, addresses []s
 			userID, addr,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert address: %w", err)
+			return nil, fmt.Errorf("failed to insert address: %w", conflictError(err))
 		}
 	}
 
@@ -180,13 +422,13 @@ func (s *storage) CreateUser(ctx context.Context, username string, addresses []s
 // GetUser retrieves a user by username
 func (s *storage) GetUser(ctx context.Context, username string) (*User, error) {
 	var user User
-	err := s.db.QueryRowContext(ctx,
-		"SELECT id, username, created_at, last_synced, profile_image, official_pnl, official_volume FROM users WHERE username = ?",
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT "+userColumns+" FROM users WHERE username = ?",
 		username,
-	).Scan(&user.ID, &user.Username, &user.CreatedAt, &user.LastSynced, &user.ProfileImage, &user.OfficialPnl, &user.OfficialVolume)
+	).Scan(userScanDest(&user)...)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found: %s", username)
+		return nil, fmt.Errorf("user %w: %s", ErrNotFound, username)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query user: %w", err)
@@ -195,10 +437,19 @@ func (s *storage) GetUser(ctx context.Context, username string) (*User, error) {
 	return &user, nil
 }
 
+// GetLastSyncedAt returns when a user was last synced, nil before the first sync
+func (s *storage) GetLastSyncedAt(ctx context.Context) (*time.Time, error) {
+	var lastSynced sql.NullString
+	if err := s.reader.QueryRowContext(ctx, "SELECT MAX(last_synced) FROM users").Scan(&lastSynced); err != nil {
+		return nil, fmt.Errorf("failed to get last sync time: %w", err)
+	}
+	return parseNullTimestamp(lastSynced), nil
+}
+
 // GetUsers retrieves all users
 func (s *storage) GetUsers(ctx context.Context) ([]*User, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, username, created_at, last_synced, profile_image, official_pnl, official_volume FROM users ORDER BY username",
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT "+userColumns+" FROM users ORDER BY username",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
@@ -208,7 +459,7 @@ func (s *storage) GetUsers(ctx context.Context) ([]*User, error) {
 	users := make([]*User, 0)
 	for rows.Next() {
 		var user User
-		if err := rows.Scan(&user.ID, &user.Username, &user.CreatedAt, &user.LastSynced, &user.ProfileImage, &user.OfficialPnl, &user.OfficialVolume); err != nil {
+		if err := rows.Scan(userScanDest(&user)...); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, &user)
@@ -221,22 +472,181 @@ func (s *storage) GetUsers(ctx context.Context) ([]*User, error) {
 	return users, nil
 }
 
+// UserSortColumns maps the sort keys of GetUsersPage to user columns
+var UserSortColumns = map[string]string{
+	"username":   "username",
+	"createdAt":  "created_at",
+	"lastSynced": "last_synced",
+}
+
+// UserStatSorts are the sort keys GetUserStatsPage accepts besides those of UserSortColumns
+var UserStatSorts = []string{"totalPnl", "realizedPnl", "unrealizedPnl", "volume", "totalTrades", "openPositions", "winRate"}
+
+// sqlLimit converts a page limit to SQLite's, where 0 means every row rather than none
+func sqlLimit(limit int) int {
+	if limit == 0 {
+		return -1
+	}
+	return limit
+}
+
+// GetUsersPage retrieves a sorted page of users along with the total count of users it pages
+// through. Ghost users are left out unless filters.IncludeGhosts is set, and a Limit of 0
+// returns every user.
+func (s *storage) GetUsersPage(ctx context.Context, filters UserFilters) ([]*User, int, error) {
+	where := ""
+	if !filters.IncludeGhosts {
+		where = "WHERE ghost = 0"
+	}
+
+	var total int
+	if err := s.reader.QueryRowContext(ctx, "SELECT COUNT(*) FROM users "+where).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	// Build ORDER BY clause from a fixed set of columns
+	sortColumn, ok := UserSortColumns[filters.SortBy]
+	if !ok {
+		sortColumn = UserSortColumns["username"]
+	}
+
+	sortOrder := "ASC"
+	if filters.SortDirection == "desc" {
+		sortOrder = "DESC"
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM users %s ORDER BY %s %s, id ASC LIMIT ? OFFSET ?",
+		userColumns, where, sortColumn, sortOrder,
+	)
+
+	rows, err := s.reader.QueryContext(ctx, query, sqlLimit(filters.Limit), filters.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*User, 0, min(max(filters.Limit, 0), total))
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(userScanDest(&user)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, total, nil
+}
+
 // UpdateUserLastSynced updates the last synced timestamp for a user
 func (s *storage) UpdateUserLastSynced(ctx context.Context, userID int64, lastSynced time.Time) error {
 	_, err := s.db.ExecContext(ctx,
 		"UPDATE users SET last_synced = ? WHERE id = ?",
-		lastSynced, userID,
+		formatTimestamp(lastSynced), userID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update user last synced: %w", err)
 	}
+	s.fifo.invalidate(userID)
+	return nil
+}
+
+// UpdateUserGhost sets whether a user is hidden from public leaderboards and the trade feed
+func (s *storage) UpdateUserGhost(ctx context.Context, userID int64, ghost bool) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE users SET ghost = ? WHERE id = ?",
+		ghost, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user ghost mode: %w", err)
+	}
+	return nil
+}
+
+// CreateUserClaim starts a claim of a user's account. While an earlier claim is still pending it
+// is kept, so the returned claim may not be the one passed in.
+func (s *storage) CreateUserClaim(ctx context.Context, claim *UserClaim) (*UserClaim, error) {
+	createdAt := formatTimestamp(claim.CreatedAt)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO user_claims (user_id, nonce, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			nonce = excluded.nonce,
+			created_at = excluded.created_at,
+			expires_at = excluded.expires_at
+		WHERE user_claims.expires_at <= ?
+	`, claim.UserID, claim.Nonce, createdAt, formatTimestamp(claim.ExpiresAt), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user claim: %w", err)
+	}
+
+	pending, err := s.getUserClaim(ctx, s.db, claim.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, fmt.Errorf("user claim for user %d was not stored", claim.UserID)
+	}
+
+	return pending, nil
+}
+
+// GetUserClaim retrieves a user's claim, nil when there is none. The claim may have expired.
+func (s *storage) GetUserClaim(ctx context.Context, userID int64) (*UserClaim, error) {
+	return s.getUserClaim(ctx, s.reader, userID)
+}
+
+// getUserClaim retrieves a user's claim through db, nil when there is none
+func (s *storage) getUserClaim(ctx context.Context, db *sql.DB, userID int64) (*UserClaim, error) {
+	var claim UserClaim
+	err := db.QueryRowContext(ctx,
+		"SELECT user_id, nonce, created_at, expires_at FROM user_claims WHERE user_id = ?",
+		userID,
+	).Scan(&claim.UserID, &claim.Nonce, &claim.CreatedAt, &claim.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // No claim started
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user claim: %w", err)
+	}
+
+	return &claim, nil
+}
+
+// VerifyUser marks a user's account as verified through address and removes their claim
+func (s *storage) VerifyUser(ctx context.Context, userID int64, address string, verifiedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE users SET verified_at = ?, verified_address = ? WHERE id = ?",
+		formatTimestamp(verifiedAt), address, userID,
+	); err != nil {
+		return fmt.Errorf("failed to mark user verified: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_claims WHERE user_id = ?", userID); err != nil {
+		return fmt.Errorf("failed to delete user claim: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
 // GetUserAddresses retrieves all addresses for a user
 func (s *storage) GetUserAddresses(ctx context.Context, userID int64) ([]*Address, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, user_id, address FROM addresses WHERE user_id = ?",
+	rows, err := s.reader.QueryContext(ctx,
+		`SELECT id, user_id, address, group_name, official_pnl, official_volume, official_updated_at
+		FROM addresses WHERE user_id = ?`,
 		userID,
 	)
 	if err != nil {
@@ -247,9 +657,11 @@ func (s *storage) GetUserAddresses(ctx context.Context, userID int64) ([]*Addres
 	addresses := make([]*Address, 0)
 	for rows.Next() {
 		var addr Address
-		if err := rows.Scan(&addr.ID, &addr.UserID, &addr.Address); err != nil {
+		var updatedAt sql.NullString
+		if err := rows.Scan(&addr.ID, &addr.UserID, &addr.Address, &addr.Group, &addr.OfficialPnl, &addr.OfficialVolume, &updatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan address: %w", err)
 		}
+		addr.OfficialUpdatedAt = parseNullTimestamp(updatedAt)
 		addresses = append(addresses, &addr)
 	}
 
@@ -262,1195 +674,5590 @@ func (s *storage) GetUserAddresses(ctx context.Context, userID int64) ([]*Addres
 
 // UpsertPosition inserts or updates a position
 func (s *storage) UpsertPosition(ctx context.Context, pos *Position) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO positions (
-			user_id, address, condition_id, asset, market_title, market_slug,
-			outcome, size, avg_price, current_price, initial_value, current_value,
-			unrealized_pnl, unrealized_pnl_percent, realized_pnl, end_date, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
-		ON CONFLICT(user_id, address, condition_id, asset) DO UPDATE SET
-			market_title = excluded.market_title,
-			market_slug = excluded.market_slug,
-			outcome = excluded.outcome,
-			size = excluded.size,
-			avg_price = excluded.avg_price,
-			current_price = excluded.current_price,
-			initial_value = excluded.initial_value,
-			current_value = excluded.current_value,
-			unrealized_pnl = excluded.unrealized_pnl,
-			unrealized_pnl_percent = excluded.unrealized_pnl_percent,
-			realized_pnl = excluded.realized_pnl,
-			end_date = excluded.end_date,
-			updated_at = CURRENT_TIMESTAMP
-	`,
-		pos.UserID, pos.Address, pos.ConditionID, pos.Asset, pos.MarketTitle, pos.MarketSlug,
-		pos.Outcome, pos.Size, pos.AvgPrice, pos.CurrentPrice, pos.InitialValue, pos.CurrentValue,
-		pos.UnrealizedPnl, pos.UnrealizedPnlPercent, pos.RealizedPnl, pos.EndDate,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to upsert position: %w", err)
-	}
-	return nil
+	return s.UpsertPositions(ctx, []*Position{pos})
 }
 
-// GetUserPositions retrieves all positions for a user
-func (s *storage) GetUserPositions(ctx context.Context, userID int64) ([]*Position, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, user_id, address, condition_id, asset, market_title, market_slug,
-			outcome, size, avg_price, current_price, initial_value, current_value,
-			unrealized_pnl, unrealized_pnl_percent, realized_pnl, end_date, updated_at
-		FROM positions
-		WHERE user_id = ?
-		ORDER BY updated_at DESC
-	`, userID)
+// positionUpsertBatchSize bounds rows per statement to stay well under SQLite's bound parameter limit
+const positionUpsertBatchSize = 500
+
+// UpsertPositions inserts or updates positions using multi-row statements in a single
+// transaction. Positions whose payload hasn't changed are left alone, keeping their updated_at.
+func (s *storage) UpsertPositions(ctx context.Context, positions []*Position) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query positions: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	positions := make([]*Position, 0)
-	for rows.Next() {
-		var pos Position
-		if err := rows.Scan(
-			&pos.ID, &pos.UserID, &pos.Address, &pos.ConditionID, &pos.Asset,
-			&pos.MarketTitle, &pos.MarketSlug, &pos.Outcome, &pos.Size, &pos.AvgPrice,
-			&pos.CurrentPrice, &pos.InitialValue, &pos.CurrentValue, &pos.UnrealizedPnl,
-			&pos.UnrealizedPnlPercent, &pos.RealizedPnl, &pos.EndDate, &pos.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan position: %w", err)
-		}
-		positions = append(positions, &pos)
+	if _, err := upsertPositions(ctx, tx, positions); err != nil {
+		return err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating positions: %w", err)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit positions: %w", err)
 	}
 
-	return positions, nil
+	return nil
 }
 
-// DeleteUserPositions deletes all positions for a user
-func (s *storage) DeleteUserPositions(ctx context.Context, userID int64) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM positions WHERE user_id = ?", userID)
+// SyncAddressPositions makes the stored positions of one of a user's addresses match a fresh
+// fetch: new and changed positions are written, positions no longer held are deleted, and
+// unchanged positions are left alone. It returns the number of rows written.
+func (s *storage) SyncAddressPositions(ctx context.Context, userID int64, address string, positions []*Position) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete positions: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return nil
-}
+	defer tx.Rollback()
 
-// InsertTrade inserts a new trade
-func (s *storage) InsertTrade(ctx context.Context, trade *Trade) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO trades (
-			user_id, address, trade_id, condition_id, market_title, market_slug,
-			outcome, side, price, size, value, timestamp, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
-		ON CONFLICT(user_id, condition_id, timestamp, side, size, price) DO NOTHING
-	`,
-		trade.UserID, trade.Address, trade.TradeID, trade.ConditionID, trade.MarketTitle,
-		trade.MarketSlug, trade.Outcome, trade.Side, trade.Price, trade.Size, trade.Value,
-		trade.Timestamp,
-	)
+	written, err := upsertPositions(ctx, tx, positions)
 	if err != nil {
-		return fmt.Errorf("failed to insert trade: %w", err)
+		return 0, err
 	}
-	return nil
-}
 
-// GetUserTrades retrieves trades for a user with pagination
-func (s *storage) GetUserTrades(ctx context.Context, userID int64, limit, offset int) ([]*Trade, int, error) {
-	// Get total count
-	var total int
-	err := s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM trades WHERE user_id = ?",
-		userID,
-	).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
+	type positionKey struct{ conditionID, asset string }
+	held := make(map[positionKey]bool, len(positions))
+	for _, pos := range positions {
+		held[positionKey{pos.ConditionID, pos.Asset}] = true
 	}
 
-	// Get trades with pagination
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, user_id, address, trade_id, condition_id, market_title, market_slug,
-			outcome, side, price, size, value, timestamp, created_at
-		FROM trades
-		WHERE user_id = ?
-		ORDER BY timestamp DESC
-		LIMIT ? OFFSET ?
-	`, userID, limit, offset)
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id, condition_id, asset FROM positions WHERE user_id = ? AND address = ?",
+		userID, address,
+	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query trades: %w", err)
+		return 0, fmt.Errorf("failed to query stored positions: %w", err)
 	}
-	defer rows.Close()
-
-	trades := make([]*Trade, 0, limit)
+	var stale []any
 	for rows.Next() {
-		var trade Trade
-		if err := rows.Scan(
-			&trade.ID, &trade.UserID, &trade.Address, &trade.TradeID, &trade.ConditionID,
-			&trade.MarketTitle, &trade.MarketSlug, &trade.Outcome, &trade.Side, &trade.Price,
-			&trade.Size, &trade.Value, &trade.Timestamp, &trade.CreatedAt,
+		var id int64
+		var key positionKey
+		if err := rows.Scan(&id, &key.conditionID, &key.asset); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan stored position: %w", err)
+		}
+		if !held[key] {
+			stale = append(stale, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating stored positions: %w", err)
+	}
+
+	for start := 0; start < len(stale); start += positionUpsertBatchSize {
+		batch := stale[start:min(start+positionUpsertBatchSize, len(stale))]
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM positions WHERE id IN ("+placeholders(len(batch))+")", batch...,
 		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
+			return 0, fmt.Errorf("failed to delete closed positions: %w", err)
 		}
-		trades = append(trades, &trade)
 	}
+	written += len(stale)
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit positions: %w", err)
 	}
 
-	return trades, total, nil
+	return written, nil
 }
 
-// GetAllTrades retrieves all trades across all users with filtering and pagination
-func (s *storage) GetAllTrades(ctx context.Context, filters TradeFilters) ([]*TradeWithUsername, int, error) {
-	// Build WHERE clause and args
-	whereConditions := make([]string, 0)
-	args := make([]any, 0)
+// upsertPositions writes positions whose payload hash differs from the stored one, returning
+// the number of rows inserted or updated
+func upsertPositions(ctx context.Context, tx *sql.Tx, positions []*Position) (int, error) {
+	var written int
+	for start := 0; start < len(positions); start += positionUpsertBatchSize {
+		end := min(start+positionUpsertBatchSize, len(positions))
+		batch := positions[start:end]
+
+		placeholders := make([]string, len(batch))
+		args := make([]any, 0, len(batch)*18)
+		for i, pos := range batch {
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, " + sqlNow + ")"
+			args = append(args,
+				pos.UserID, pos.Address, pos.ConditionID, pos.Asset, pos.MarketTitle,
+				marketDisplayTitle(pos.MarketTitle, pos.MarketDisplayTitle), pos.MarketSlug,
+				pos.Outcome, pos.Size, pos.AvgPrice, pos.CurrentPrice, pos.InitialValue, pos.CurrentValue,
+				pos.UnrealizedPnl, pos.UnrealizedPnlPercent, pos.RealizedPnl, formatNullTimestamp(pos.EndDate),
+				positionHash(pos),
+			)
+		}
 
-	if filters.Username != nil {
-		whereConditions = append(whereConditions, "u.username = ?")
-		args = append(args, *filters.Username)
+		query := fmt.Sprintf(`
+			INSERT INTO positions (
+				user_id, address, condition_id, asset, market_title, market_display_title, market_slug,
+				outcome, size, avg_price, current_price, initial_value, current_value,
+				unrealized_pnl, unrealized_pnl_percent, realized_pnl, end_date, payload_hash, updated_at
+			) VALUES %s
+			ON CONFLICT(user_id, address, condition_id, asset) DO UPDATE SET
+				market_title = excluded.market_title,
+				market_display_title = excluded.market_display_title,
+				market_slug = excluded.market_slug,
+				outcome = excluded.outcome,
+				size = excluded.size,
+				avg_price = excluded.avg_price,
+				current_price = excluded.current_price,
+				initial_value = excluded.initial_value,
+				current_value = excluded.current_value,
+				unrealized_pnl = excluded.unrealized_pnl,
+				unrealized_pnl_percent = excluded.unrealized_pnl_percent,
+				realized_pnl = excluded.realized_pnl,
+				end_date = excluded.end_date,
+				payload_hash = excluded.payload_hash,
+				updated_at = excluded.updated_at
+			WHERE positions.payload_hash IS NOT excluded.payload_hash
+		`, strings.Join(placeholders, ", "))
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert positions: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get upserted positions: %w", err)
+		}
+		written += int(affected)
 	}
 
-	if filters.Side != nil {
-		whereConditions = append(whereConditions, "t.side = ?")
-		args = append(args, *filters.Side)
-	}
+	return written, nil
+}
 
-	if filters.MinValue != nil {
-		whereConditions = append(whereConditions, "t.value >= ?")
-		args = append(args, *filters.MinValue)
+// positionHash fingerprints the synced fields of a position
+func positionHash(pos *Position) string {
+	h := sha256.New()
+	for _, field := range []*string{pos.MarketTitle, pos.MarketSlug, pos.Outcome} {
+		if field != nil {
+			fmt.Fprintf(h, "%q|", *field)
+		} else {
+			h.Write([]byte("-|"))
+		}
 	}
-
-	whereClause := ""
-	if len(whereConditions) > 0 {
-		whereClause = "WHERE " + fmt.Sprintf("%s", whereConditions[0])
-		for i := 1; i < len(whereConditions); i++ {
-			whereClause += " AND " + whereConditions[i]
+	for _, field := range []*float64{
+		pos.Size, pos.AvgPrice, pos.CurrentPrice, pos.InitialValue, pos.CurrentValue,
+		pos.UnrealizedPnl, pos.UnrealizedPnlPercent, pos.RealizedPnl,
+	} {
+		if field != nil {
+			h.Write([]byte(strconv.FormatFloat(*field, 'g', -1, 64) + "|"))
+		} else {
+			h.Write([]byte("-|"))
 		}
 	}
+	if pos.EndDate != nil {
+		h.Write([]byte(formatTimestamp(*pos.EndDate)))
+	}
 
-	// Get total count
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*)
-		FROM trades t
-		JOIN users u ON t.user_id = u.id
-		%s
-	`, whereClause)
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	var total int
-	err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+// GetUserPositions retrieves all positions for a user, including dust
+func (s *storage) GetUserPositions(ctx context.Context, userID int64) ([]*Position, error) {
+	return s.queryUserPositions(ctx, userID, "")
+}
+
+// GetUserOpenPositions retrieves a user's positions for display, leaving out dust unless
+// includeDust is set
+func (s *storage) GetUserOpenPositions(ctx context.Context, userID int64, includeDust bool) ([]*Position, error) {
+	filter := ""
+	if !includeDust {
+		filter = "AND " + s.notDust("p.")
+	}
+
+	positions, err := s.queryUserPositions(ctx, userID, filter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
+		return nil, err
+	}
+	if err := s.attachPositionEntries(ctx, positions); err != nil {
+		return nil, err
 	}
 
-	// Build ORDER BY clause
-	sortColumn := "t.timestamp"
-	switch filters.SortBy {
-	case "value":
-		sortColumn = "t.value"
-	case "size":
-		sortColumn = "t.size"
-	case "timestamp":
-		sortColumn = "t.timestamp"
-	default:
-		sortColumn = "t.timestamp"
-	}
+	return positions, nil
+}
 
-	sortOrder := "DESC"
-	if filters.SortDirection == "asc" {
-		sortOrder = "ASC"
+// attachPositionEntries sets when each position's holding was opened and the buy that opened
+// it, from the latest trade of its user and outcome classified PositionChangeOpen
+func (s *storage) attachPositionEntries(ctx context.Context, positions []*Position) error {
+	if len(positions) == 0 {
+		return nil
 	}
 
-	orderByClause := fmt.Sprintf("ORDER BY %s %s", sortColumn, sortOrder)
-
-	// Build full query
-	query := fmt.Sprintf(`
-		SELECT
-			t.id, t.user_id, t.address, t.trade_id, t.condition_id, t.market_title,
-			t.market_slug, t.outcome, t.side, t.price, t.size, t.value,
-			t.timestamp, t.created_at, u.username
-		FROM trades t
-		JOIN users u ON t.user_id = u.id
-		%s
-		%s
-		LIMIT ? OFFSET ?
-	`, whereClause, orderByClause)
+	type entryKey struct {
+		userID      int64
+		conditionID string
+		outcome     string
+	}
+	type entry struct {
+		timestamp *time.Time
+		tradeID   *string
+	}
 
-	// Append limit and offset to args
-	queryArgs := append(args, filters.Limit, filters.Offset)
+	userIDs := make([]any, 0)
+	seen := make(map[int64]bool)
+	for _, pos := range positions {
+		if !seen[pos.UserID] {
+			seen[pos.UserID] = true
+			userIDs = append(userIDs, pos.UserID)
+		}
+	}
 
-	rows, err := s.db.QueryContext(ctx, query, queryArgs...)
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT user_id, condition_id, outcome, trade_id, timestamp
+		FROM trades
+		WHERE user_id IN (`+placeholders(len(userIDs))+`)
+		AND position_change = ?
+		AND removed_at IS NULL
+		ORDER BY timestamp, id
+	`, append(userIDs, PositionChangeOpen)...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query trades: %w", err)
+		return fmt.Errorf("failed to query position entries: %w", err)
 	}
 	defer rows.Close()
 
-	trades := make([]*TradeWithUsername, 0, filters.Limit)
+	// Ordered oldest first, so each outcome ends up with the buy that last opened it
+	entries := make(map[entryKey]entry)
 	for rows.Next() {
-		var trade TradeWithUsername
-		if err := rows.Scan(
-			&trade.ID, &trade.UserID, &trade.Address, &trade.TradeID, &trade.ConditionID,
-			&trade.MarketTitle, &trade.MarketSlug, &trade.Outcome, &trade.Side, &trade.Price,
-			&trade.Size, &trade.Value, &trade.Timestamp, &trade.CreatedAt, &trade.Username,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
+		var (
+			key     entryKey
+			e       entry
+			outcome *string
+		)
+		if err := rows.Scan(&key.userID, &key.conditionID, &outcome, &e.tradeID, &e.timestamp); err != nil {
+			return fmt.Errorf("failed to scan position entry: %w", err)
 		}
-		trades = append(trades, &trade)
+		if outcome != nil {
+			key.outcome = *outcome
+		}
+		entries[key] = e
 	}
-
 	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
+		return fmt.Errorf("error iterating position entries: %w", err)
 	}
 
-	return trades, total, nil
-}
-
-// InsertPnlSnapshot inserts a PNL snapshot
-func (s *storage) InsertPnlSnapshot(ctx context.Context, snapshot *PnlSnapshot) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO pnl_snapshots (user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl)
-		VALUES (?, ?, ?, ?, ?)
-	`,
-		snapshot.UserID, snapshot.Timestamp, snapshot.TotalPnl, snapshot.RealizedPnl, snapshot.UnrealizedPnl,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to insert pnl snapshot: %w", err)
+	for _, pos := range positions {
+		if pos.Outcome == nil {
+			continue
+		}
+		if e, ok := entries[entryKey{userID: pos.UserID, conditionID: pos.ConditionID, outcome: *pos.Outcome}]; ok {
+			pos.FirstEnteredAt = e.timestamp
+			pos.EntryTradeID = e.tradeID
+		}
 	}
+
 	return nil
 }
 
-// GetUserPnlHistory retrieves PNL history for a user
-func (s *storage) GetUserPnlHistory(ctx context.Context, userID int64, start, end *time.Time) ([]*PnlSnapshot, error) {
-	query := `
-		SELECT id, user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl
-		FROM pnl_snapshots
-		WHERE user_id = ?
-	`
-	args := []any{userID}
-
-	if start != nil {
-		query += " AND timestamp >= ?"
-		args = append(args, start)
-	}
-	if end != nil {
-		query += " AND timestamp <= ?"
-		args = append(args, end)
-	}
-
-	query += " ORDER BY timestamp ASC"
-
-	rows, err := s.db.QueryContext(ctx, query, args...)
+// queryUserPositions retrieves a user's positions matching an extra filter, newest first
+func (s *storage) queryUserPositions(ctx context.Context, userID int64, filter string) ([]*Position, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT `+positionColumns+`
+		FROM positions p
+		WHERE p.user_id = ?
+		`+filter+`
+		ORDER BY p.updated_at DESC
+	`, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query pnl history: %w", err)
+		return nil, fmt.Errorf("failed to query positions: %w", err)
 	}
 	defer rows.Close()
 
-	snapshots := make([]*PnlSnapshot, 0)
+	positions := make([]*Position, 0)
 	for rows.Next() {
-		var snapshot PnlSnapshot
-		if err := rows.Scan(
-			&snapshot.ID, &snapshot.UserID, &snapshot.Timestamp,
-			&snapshot.TotalPnl, &snapshot.RealizedPnl, &snapshot.UnrealizedPnl,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan pnl snapshot: %w", err)
+		var pos Position
+		var endDate sql.NullString
+		if err := rows.Scan(positionScanDest(&pos, &endDate)...); err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
 		}
-		snapshots = append(snapshots, &snapshot)
+		pos.EndDate = parseNullTimestamp(endDate)
+		positions = append(positions, &pos)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating pnl snapshots: %w", err)
+		return nil, fmt.Errorf("error iterating positions: %w", err)
 	}
 
-	return snapshots, nil
+	return positions, nil
 }
 
-// GetUserStats retrieves aggregated statistics for a user
-func (s *storage) GetUserStats(ctx context.Context, username string) (*UserStats, error) {
-	user, err := s.GetUser(ctx, username)
-	if err != nil {
-		return nil, err
+// PruneUserPositions deletes a user's positions held at addresses other than the given ones,
+// returning the number deleted
+func (s *storage) PruneUserPositions(ctx context.Context, userID int64, addresses []string) (int, error) {
+	args := []any{userID}
+	filter := ""
+	if len(addresses) > 0 {
+		for _, address := range addresses {
+			args = append(args, address)
+		}
+		filter = " AND address NOT IN (" + placeholders(len(addresses)) + ")"
 	}
 
-	addresses, err := s.GetUserAddresses(ctx, user.ID)
+	result, err := s.db.ExecContext(ctx, "DELETE FROM positions WHERE user_id = ?"+filter, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user addresses: %w", err)
-	}
-
-	addressList := make([]string, len(addresses))
-	for i, addr := range addresses {
-		addressList[i] = addr.Address
+		return 0, fmt.Errorf("failed to delete positions: %w", err)
 	}
 
-	stats := &UserStats{
-		Username:     username,
-		Addresses:    addressList,
-		ProfileImage: user.ProfileImage,
-		LastSynced:   user.LastSynced,
-	}
-
-	// Get position stats (only unrealized PnL from current open positions)
-	var openPositions int
-	var unrealizedPnl sql.NullFloat64
-	err = s.db.QueryRowContext(ctx, `
-		SELECT
-			COUNT(*) as open_positions,
-			COALESCE(SUM(unrealized_pnl), 0) as unrealized_pnl
-		FROM positions
-		WHERE user_id = ?
-	`, user.ID).Scan(&openPositions, &unrealizedPnl)
+	deleted, err := result.RowsAffected()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get position stats: %w", err)
+		return 0, fmt.Errorf("failed to get deleted positions: %w", err)
 	}
 
-	stats.OpenPositions = openPositions
-	if unrealizedPnl.Valid {
-		stats.UnrealizedPnl = unrealizedPnl.Float64
-	}
+	return int(deleted), nil
+}
 
-	// Use official PnL from Polymarket if available (all-time accurate data)
-	// Otherwise fall back to FIFO calculation from available trade history
-	if user.OfficialPnl != nil {
-		// Official PnL is the total (realized + unrealized)
-		stats.TotalPnl = *user.OfficialPnl
-		// Calculate realized as: total - current unrealized
-		stats.RealizedPnl = stats.TotalPnl - stats.UnrealizedPnl
-	} else {
-		// Fall back to FIFO calculation from trade history
-		realizedPnl, _, _, err := s.CalculateRealizedPnlFromTrades(ctx, user.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to calculate realized pnl: %w", err)
+// InsertTrade inserts a new trade, reporting whether it was not already stored. A new trade
+// gets its ID and position change set.
+func (s *storage) InsertTrade(ctx context.Context, trade *Trade) (bool, error) {
+	if trade.EventSlug != nil {
+		if _, err := s.db.ExecContext(ctx,
+			"INSERT INTO events (slug, created_at) VALUES (?, "+sqlNow+") ON CONFLICT(slug) DO NOTHING",
+			*trade.EventSlug,
+		); err != nil {
+			return false, fmt.Errorf("failed to upsert event: %w", err)
 		}
-		stats.RealizedPnl = realizedPnl
-		stats.TotalPnl = stats.RealizedPnl + stats.UnrealizedPnl
 	}
 
-	// Get trade stats
-	var totalTrades int
-	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM trades WHERE user_id = ?", user.ID).Scan(&totalTrades)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count trades: %w", err)
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM trades
+			WHERE user_id = ? AND condition_id IS ? AND timestamp IS ? AND side IS ? AND size IS ? AND price IS ?
+		)
+	`, trade.UserID, trade.ConditionID, formatNullTimestamp(trade.Timestamp), trade.Side, trade.Size, trade.Price).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check for existing trade: %w", err)
 	}
-	stats.TotalTrades = totalTrades
 
-	// Calculate win rate from FIFO (we still need this for win/loss tracking)
-	_, wins, totalClosed, err := s.CalculateRealizedPnlFromTrades(ctx, user.ID)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO trades (
+			user_id, address, trade_id, condition_id, market_title, market_display_title, market_slug,
+			event_slug, outcome, side, price, size, value, timestamp, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, `+sqlNow+`)
+		ON CONFLICT(user_id, condition_id, timestamp, side, size, price) DO UPDATE SET
+			event_slug = COALESCE(trades.event_slug, excluded.event_slug)
+	`,
+		trade.UserID, trade.Address, trade.TradeID, trade.ConditionID, trade.MarketTitle,
+		marketDisplayTitle(trade.MarketTitle, trade.MarketDisplayTitle), trade.MarketSlug, trade.EventSlug,
+		trade.Outcome, trade.Side, trade.Price, trade.Size, tradeValue(trade), formatNullTimestamp(trade.Timestamp),
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate win rate: %w", err)
-	}
-	if totalClosed > 0 {
-		stats.WinRate = float64(wins) / float64(totalClosed)
+		return false, fmt.Errorf("failed to insert trade: %w", err)
 	}
-
-	return stats, nil
-}
-
-// GetLeaderboard retrieves leaderboard of all users
-func (s *storage) GetLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*UserStats, error) {
-	users, err := s.GetUsers(ctx)
-	if err != nil {
-		return nil, err
+	if exists {
+		return false, nil
 	}
+	s.fifo.invalidate(trade.UserID)
 
-	leaderboard := make([]*UserStats, 0, len(users))
-	for _, user := range users {
-		stats, err := s.GetUserStats(ctx, user.Username)
-		if err != nil {
-			s.log.WithError(err).WithField("username", user.Username).Error("failed to get user stats")
-			continue
+	// Classify the new trade, along with later trades of the market it may have come before
+	if trade.ConditionID != nil {
+		if _, err := classifyTrades(ctx, s.db, trade.UserID, trade.ConditionID); err != nil {
+			return false, err
 		}
-		leaderboard = append(leaderboard, stats)
 	}
-
-	// Sort leaderboard
-	// Note: In a production system, this should be done in SQL for better performance
-	// For simplicity, we're doing it in Go here
-
-	return leaderboard, nil
-}
-
-// GetUserTradesChronological retrieves all trades for a user sorted by timestamp ASC
-func (s *storage) GetUserTradesChronological(ctx context.Context, userID int64) ([]*Trade, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, user_id, address, trade_id, condition_id, market_title, market_slug,
-			outcome, side, price, size, value, timestamp, created_at
-		FROM trades
-		WHERE user_id = ?
-		ORDER BY timestamp ASC
-	`, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query trades: %w", err)
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT id, position_change FROM trades
+		WHERE user_id = ? AND condition_id IS ? AND timestamp IS ? AND side IS ? AND size IS ? AND price IS ?
+	`, trade.UserID, trade.ConditionID, formatNullTimestamp(trade.Timestamp), trade.Side, trade.Size, trade.Price).Scan(&trade.ID, &trade.PositionChange); err != nil {
+		return false, fmt.Errorf("failed to get inserted trade: %w", err)
 	}
-	defer rows.Close()
 
-	trades := make([]*Trade, 0)
-	for rows.Next() {
-		var trade Trade
-		if err := rows.Scan(
-			&trade.ID, &trade.UserID, &trade.Address, &trade.TradeID, &trade.ConditionID,
-			&trade.MarketTitle, &trade.MarketSlug, &trade.Outcome, &trade.Side, &trade.Price,
-			&trade.Size, &trade.Value, &trade.Timestamp, &trade.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan trade: %w", err)
-		}
-		trades = append(trades, &trade)
-	}
+	return true, nil
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating trades: %w", err)
+// tradeValue returns the notional of a trade, its value or else price times size, so trades
+// are always stored with one when they can be
+func tradeValue(trade *Trade) *float64 {
+	if trade.Value == nil && trade.Price != nil && trade.Size != nil {
+		value := *trade.Price * *trade.Size
+		trade.Value = &value
 	}
-
-	return trades, nil
+	return trade.Value
 }
 
-// DeleteUserPnlSnapshots deletes all PNL snapshots for a user
-func (s *storage) DeleteUserPnlSnapshots(ctx context.Context, userID int64) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM pnl_snapshots WHERE user_id = ?", userID)
+// UpdateTradeBook stores the order book snapshot of a stored trade
+func (s *storage) UpdateTradeBook(ctx context.Context, trade *Trade) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE trades
+		SET book_midpoint = ?, book_best_bid = ?, book_best_ask = ?, book_captured_at = ?
+		WHERE id = ?
+	`, trade.BookMidpoint, trade.BookBestBid, trade.BookBestAsk, formatNullTimestamp(trade.BookCapturedAt), trade.ID)
 	if err != nil {
-		return fmt.Errorf("failed to delete pnl snapshots: %w", err)
+		return fmt.Errorf("failed to update trade book: %w", err)
 	}
 	return nil
 }
 
-// BulkInsertPnlSnapshots inserts multiple PNL snapshots in a single transaction
-func (s *storage) BulkInsertPnlSnapshots(ctx context.Context, snapshots []*PnlSnapshot) error {
-	if len(snapshots) == 0 {
-		return nil
-	}
+// importSizeTolerance is how far in shares an imported trade's size may be from a stored trade
+// with the same ID for them to be the same trade, exports rounding sizes
+const importSizeTolerance = 0.01
 
+// ImportTrades inserts trades imported from a file in one transaction, skipping any already
+// stored, and returns how many were new. A dry run counts them without keeping them. Imported
+// trades are marked so reconciliation doesn't tombstone them for being missing upstream.
+// Trades with an ID are also already stored when a trade of the user with the same ID, side
+// and size is, as prices recomputed from exported amounts rarely match synced ones exactly.
+func (s *storage) ImportTrades(ctx context.Context, trades []*Trade, dryRun bool) (int, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	existing, err := tx.PrepareContext(ctx, `
+		SELECT 1 FROM trades
+		WHERE user_id = ? AND trade_id = ? AND side = ? AND ABS(size - ?) < ?
+		LIMIT 1
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare trade lookup: %w", err)
+	}
+	defer existing.Close()
+
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO pnl_snapshots (user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO trades (
+			user_id, address, trade_id, condition_id, market_title, market_display_title, market_slug,
+			outcome, side, price, size, value, timestamp, created_at, imported_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, `+sqlNow+`, `+sqlNow+`)
+		ON CONFLICT(user_id, condition_id, timestamp, side, size, price) DO NOTHING
 	`)
 	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
+		return 0, fmt.Errorf("failed to prepare trade import: %w", err)
 	}
 	defer stmt.Close()
 
-	for _, snapshot := range snapshots {
-		_, err := stmt.ExecContext(ctx,
-			snapshot.UserID, snapshot.Timestamp, snapshot.TotalPnl, snapshot.RealizedPnl, snapshot.UnrealizedPnl,
+	imported := 0
+	users := make(map[int64]bool)
+	for _, trade := range trades {
+		users[trade.UserID] = true
+
+		if trade.TradeID != nil && trade.Side != nil && trade.Size != nil {
+			var found int
+			err := existing.QueryRowContext(ctx, trade.UserID, *trade.TradeID, *trade.Side, *trade.Size, importSizeTolerance).Scan(&found)
+			if err == nil {
+				continue
+			}
+			if err != sql.ErrNoRows {
+				return 0, fmt.Errorf("failed to look up imported trade: %w", err)
+			}
+		}
+
+		result, err := stmt.ExecContext(ctx,
+			trade.UserID, trade.Address, trade.TradeID, trade.ConditionID, trade.MarketTitle,
+			marketDisplayTitle(trade.MarketTitle, trade.MarketDisplayTitle), trade.MarketSlug, trade.Outcome,
+			trade.Side, trade.Price, trade.Size, tradeValue(trade), formatNullTimestamp(trade.Timestamp),
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert pnl snapshot: %w", err)
+			return 0, fmt.Errorf("failed to import trade: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		imported += int(affected)
+	}
+
+	// Imported trades usually predate the synced ones, shifting their classification
+	if imported > 0 && !dryRun {
+		for userID := range users {
+			if _, err := classifyTrades(ctx, tx, userID, nil); err != nil {
+				return 0, err
+			}
 		}
 	}
 
+	if dryRun {
+		return imported, nil
+	}
+
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit trade import: %w", err)
+	}
+	for userID := range users {
+		s.fifo.invalidate(userID)
 	}
 
-	return nil
+	return imported, nil
 }
 
-// CreateUserWithPersona creates a new user with addresses and associates with a persona
-func (s *storage) CreateUserWithPersona(ctx context.Context, username string, addresses []string, personaID int64) (*User, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+// GetUserTrades retrieves trades for a user with pagination, only those worth at least
+// minValue when set
+func (s *storage) GetUserTrades(ctx context.Context, userID int64, limit, offset int, minValue *float64) ([]*Trade, int, error) {
+	filter, args := "", []any{userID}
+	if minValue != nil {
+		filter, args = "AND t.value >= ?", append(args, *minValue)
 	}
-	defer tx.Rollback()
 
-	// Insert user with persona_id
-	result, err := tx.ExecContext(ctx,
-		"INSERT INTO users (username, created_at, persona_id) VALUES (?, CURRENT_TIMESTAMP, ?)",
-		username, personaID,
-	)
+	// Get total count
+	var total int
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM trades t WHERE t.user_id = ? AND t.removed_at IS NULL "+filter,
+		args...,
+	).Scan(&total)
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert user: %w", err)
+		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
 	}
 
-	userID, err := result.LastInsertId()
+	// Get trades with pagination
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT `+tradeColumns+`
+		FROM trades t
+		WHERE t.user_id = ?
+		AND t.removed_at IS NULL
+		`+filter+`
+		ORDER BY t.timestamp DESC
+		LIMIT ? OFFSET ?
+	`, append(args, limit, offset)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user id: %w", err)
+		return nil, 0, fmt.Errorf("failed to query trades: %w", err)
 	}
+	defer rows.Close()
 
-	// Insert addresses
-	for _, addr := range addresses {
-		_, err := tx.ExecContext(ctx,
-			"INSERT INTO addresses (user_id, address) VALUES (?, ?)",
-			userID, addr,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert address: %w", err)
+	trades := make([]*Trade, 0, limit)
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(tradeScanDest(&trade)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
 		}
+		trades = append(trades, &trade)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
 	}
 
-	return s.GetUser(ctx, username)
+	return trades, total, nil
 }
 
-// UpdateUserPersona updates a user's persona association
-func (s *storage) UpdateUserPersona(ctx context.Context, userID int64, personaID int64) error {
-	_, err := s.db.ExecContext(ctx,
-		"UPDATE users SET persona_id = ? WHERE id = ?",
-		personaID, userID,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to update user persona: %w", err)
-	}
-	return nil
-}
+// GetAllTrades retrieves all trades across all users with filtering and pagination
+func (s *storage) GetAllTrades(ctx context.Context, filters TradeFilters) ([]*TradeWithUsername, int, error) {
+	whereClause, args := tradeFilterClause(filters)
 
-// CreatePersona creates a new persona
-func (s *storage) CreatePersona(ctx context.Context, slug, displayName string) (*Persona, error) {
-	result, err := s.db.ExecContext(ctx,
-		"INSERT INTO personas (slug, display_name, created_at) VALUES (?, ?, CURRENT_TIMESTAMP)",
-		slug, displayName,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert persona: %w", err)
-	}
+	// Get total count
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		%s
+	`, whereClause)
 
-	id, err := result.LastInsertId()
+	var total int
+	err := s.reader.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get persona id: %w", err)
+		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
 	}
 
-	return &Persona{
-		ID:          id,
-		Slug:        slug,
-		DisplayName: displayName,
-	}, nil
-}
-
-// GetPersona retrieves a persona by slug
-func (s *storage) GetPersona(ctx context.Context, slug string) (*Persona, error) {
-	var persona Persona
-	err := s.db.QueryRowContext(ctx,
-		"SELECT id, slug, display_name, image, created_at FROM personas WHERE slug = ?",
-		slug,
-	).Scan(&persona.ID, &persona.Slug, &persona.DisplayName, &persona.Image, &persona.CreatedAt)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("persona not found: %s", slug)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to query persona: %w", err)
-	}
+	// Build full query
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		%s
+		%s
+		LIMIT ? OFFSET ?
+	`, tradeWithUsernameColumns, whereClause, tradeOrderClause(filters))
 
-	return &persona, nil
-}
+	// Append limit and offset to args
+	queryArgs := append(args, filters.Limit, filters.Offset)
 
-// GetPersonas retrieves all personas
-func (s *storage) GetPersonas(ctx context.Context) ([]*Persona, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, slug, display_name, image, created_at FROM personas ORDER BY display_name",
-	)
+	rows, err := s.reader.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query personas: %w", err)
+		return nil, 0, fmt.Errorf("failed to query trades: %w", err)
 	}
 	defer rows.Close()
 
-	personas := make([]*Persona, 0)
+	trades := make([]*TradeWithUsername, 0, filters.Limit)
 	for rows.Next() {
-		var persona Persona
-		if err := rows.Scan(&persona.ID, &persona.Slug, &persona.DisplayName, &persona.Image, &persona.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan persona: %w", err)
+		var trade TradeWithUsername
+		if err := rows.Scan(tradeWithUsernameScanDest(&trade)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
 		}
-		personas = append(personas, &persona)
+		trades = append(trades, &trade)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating personas: %w", err)
+		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
 	}
 
-	return personas, nil
+	return trades, total, nil
 }
 
-// GetPersonaUsers retrieves all users belonging to a persona
-func (s *storage) GetPersonaUsers(ctx context.Context, personaID int64) ([]*User, error) {
-	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, username, created_at, last_synced, profile_image, official_pnl, official_volume FROM users WHERE persona_id = ? ORDER BY username",
-		personaID,
-	)
+// IterateTrades streams trades matching filters to fn one row at a time without buffering the result set.
+// Limit and Offset are ignored. The connection is held for the duration of the iteration, so fn must not
+// call back into storage. Returning an error from fn stops the iteration and is returned as-is.
+func (s *storage) IterateTrades(ctx context.Context, filters TradeFilters, fn func(*TradeWithUsername) error) error {
+	whereClause, args := tradeFilterClause(filters)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		%s
+		%s
+	`, tradeWithUsernameColumns, whereClause, tradeOrderClause(filters))
+
+	rows, err := s.reader.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query users: %w", err)
+		return fmt.Errorf("failed to query trades: %w", err)
 	}
 	defer rows.Close()
 
-	users := make([]*User, 0)
 	for rows.Next() {
-		var user User
-		if err := rows.Scan(&user.ID, &user.Username, &user.CreatedAt, &user.LastSynced, &user.ProfileImage, &user.OfficialPnl, &user.OfficialVolume); err != nil {
-			return nil, fmt.Errorf("failed to scan user: %w", err)
+		var trade TradeWithUsername
+		if err := rows.Scan(tradeWithUsernameScanDest(&trade)...); err != nil {
+			return fmt.Errorf("failed to scan trade: %w", err)
+		}
+		if err := fn(&trade); err != nil {
+			return err
 		}
-		users = append(users, &user)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating users: %w", err)
+		return fmt.Errorf("error iterating trades: %w", err)
 	}
 
-	return users, nil
+	return nil
 }
 
-// GetPersonaStats retrieves aggregated statistics for a persona across all their users
-func (s *storage) GetPersonaStats(ctx context.Context, slug string) (*PersonaStats, error) {
-	persona, err := s.GetPersona(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
+// tradeGroupSortKeys maps trade sort keys to how market groups sort on them
+var tradeGroupSortKeys = map[string]func(*TradeMarketGroup) float64{
+	"timestamp": func(g *TradeMarketGroup) float64 {
+		if g.LastTradeAt == nil {
+			return 0
+		}
+		return float64(g.LastTradeAt.Unix())
+	},
+	"value": (*TradeMarketGroup).Volume,
+	"size":  func(g *TradeMarketGroup) float64 { return g.Size },
+}
 
-	users, err := s.GetPersonaUsers(ctx, persona.ID)
+// GetTradeMarketGroups groups the trades matching filters by market, for a feed of one entry
+// per market. Groups sort by filters.SortBy, volume when empty, and Limit and Offset page the
+// groups. Returns the page and the total number of groups.
+func (s *storage) GetTradeMarketGroups(ctx context.Context, filters TradeFilters) ([]*TradeMarketGroup, int, error) {
+	whereClause, args := tradeFilterClause(filters)
+
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT t.condition_id, MAX(%s), MAX(%s), t.user_id, u.username,
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN t.side = 'BUY' THEN t.value END), 0),
+			COALESCE(SUM(CASE WHEN t.side = 'SELL' THEN t.value END), 0),
+			COALESCE(SUM(t.size), 0),
+			MIN(t.timestamp), MAX(t.timestamp)
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		%s
+		AND t.condition_id IS NOT NULL
+		GROUP BY t.condition_id, t.user_id
+	`, marketColumnSQL("t", "title", "market_title"), marketColumnSQL("t", "slug", "market_slug"), whereClause), args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get persona users: %w", err)
-	}
-
-	stats := &PersonaStats{
-		Slug:        persona.Slug,
-		DisplayName: persona.DisplayName,
-		Image:       persona.Image,
-		Usernames:   make([]string, 0, len(users)),
+		return nil, 0, fmt.Errorf("failed to query trade groups: %w", err)
 	}
+	defer rows.Close()
 
-	var totalWins, totalClosed int
-	var hasOfficialPnl bool
-	var totalOfficialPnl float64
-
-	for _, user := range users {
-		stats.Usernames = append(stats.Usernames, user.Username)
-
-		// Get position stats for this user (only unrealized PnL)
-		var openPositions int
-		var unrealizedPnl sql.NullFloat64
-		err = s.db.QueryRowContext(ctx, `
-			SELECT
-				COUNT(*) as open_positions,
-				COALESCE(SUM(unrealized_pnl), 0) as unrealized_pnl
-			FROM positions
-			WHERE user_id = ?
-		`, user.ID).Scan(&openPositions, &unrealizedPnl)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get position stats for user %s: %w", user.Username, err)
+	groups := make([]*TradeMarketGroup, 0)
+	byMarket := make(map[string]*TradeMarketGroup)
+	for rows.Next() {
+		var conditionID string
+		var marketTitle, marketSlug *string
+		var size float64
+		var first, last sql.NullString
+		participant := &TradeMarketParticipant{}
+		if err := rows.Scan(
+			&conditionID, &marketTitle, &marketSlug, &participant.UserID, &participant.Username,
+			&participant.Trades, &participant.BuyValue, &participant.SellValue, &size, &first, &last,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan trade group: %w", err)
 		}
 
-		stats.OpenPositions += openPositions
-		if unrealizedPnl.Valid {
-			stats.UnrealizedPnl += unrealizedPnl.Float64
+		group, ok := byMarket[conditionID]
+		if !ok {
+			group = &TradeMarketGroup{ConditionID: conditionID}
+			byMarket[conditionID] = group
+			groups = append(groups, group)
 		}
-
-		// Calculate win rate data from FIFO for this user
-		_, wins, closed, err := s.CalculateRealizedPnlFromTrades(ctx, user.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to calculate win rate for user %s: %w", user.Username, err)
+		if group.MarketTitle == nil {
+			group.MarketTitle = marketTitle
 		}
-		totalWins += wins
-		totalClosed += closed
-
-		// Use official PnL if available, otherwise fall back to FIFO calculation
-		if user.OfficialPnl != nil {
-			hasOfficialPnl = true
-			totalOfficialPnl += *user.OfficialPnl
+		if group.MarketSlug == nil {
+			group.MarketSlug = marketSlug
 		}
-
-		// Get trade count for this user
-		var tradeCount int
-		err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM trades WHERE user_id = ?", user.ID).Scan(&tradeCount)
-		if err != nil {
-			return nil, fmt.Errorf("failed to count trades for user %s: %w", user.Username, err)
+		group.Trades += participant.Trades
+		group.BuyValue += participant.BuyValue
+		group.SellValue += participant.SellValue
+		group.Size += size
+		if t := parseNullTimestamp(first); t != nil && (group.FirstTradeAt == nil || t.Before(*group.FirstTradeAt)) {
+			group.FirstTradeAt = t
+		}
+		if t := parseNullTimestamp(last); t != nil && (group.LastTradeAt == nil || t.After(*group.LastTradeAt)) {
+			group.LastTradeAt = t
 		}
-		stats.TotalTrades += tradeCount
+		group.Participants = append(group.Participants, participant)
 	}
 
-	// Use official PnL if any user has it
-	if hasOfficialPnl {
-		stats.TotalPnl = totalOfficialPnl
-		stats.RealizedPnl = stats.TotalPnl - stats.UnrealizedPnl
-	} else {
-		stats.TotalPnl = stats.RealizedPnl + stats.UnrealizedPnl
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating trade groups: %w", err)
 	}
 
-	if totalClosed > 0 {
-		stats.WinRate = float64(totalWins) / float64(totalClosed)
+	key, ok := tradeGroupSortKeys[filters.SortBy]
+	if !ok {
+		key = (*TradeMarketGroup).Volume
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if filters.SortDirection == "asc" {
+			return key(groups[i]) < key(groups[j])
+		}
+		return key(groups[i]) > key(groups[j])
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Participants, func(i, j int) bool {
+			a, b := group.Participants[i], group.Participants[j]
+			return a.BuyValue+a.SellValue > b.BuyValue+b.SellValue
+		})
 	}
 
-	return stats, nil
+	total := len(groups)
+	start := min(filters.Offset, total)
+	end := total
+	if filters.Limit > 0 {
+		end = min(start+filters.Limit, total)
+	}
+
+	return groups[start:end], total, nil
 }
 
-// GetPersonaLeaderboard retrieves leaderboard of all personas
-func (s *storage) GetPersonaLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*PersonaStats, error) {
-	personas, err := s.GetPersonas(ctx)
-	if err != nil {
-		return nil, err
+// marketColumnSQL returns an SQL expression for a column of the market a trade or position
+// references, qualified by alias (e.g. "t"): the canonical one cached in markets, or the row's
+// own copy in own until the market is cached or when Gamma doesn't know it
+func marketColumnSQL(alias, column, own string) string {
+	return fmt.Sprintf("COALESCE((SELECT %[2]s FROM markets WHERE condition_id = %[1]s.condition_id AND found = 1), %[1]s.%[3]s)",
+		alias, column, own)
+}
+
+// marketTitleColumnsSQL returns the title, display title and slug of the market a trade or
+// position aliased alias references, in that order
+func marketTitleColumnsSQL(alias string) string {
+	return marketColumnSQL(alias, "title", "market_title") + ", " +
+		marketColumnSQL(alias, "display_title(title)", "market_display_title") + ", " +
+		marketColumnSQL(alias, "slug", "market_slug")
+}
+
+// tradeColumns is the column list selected for Trade rows from trades aliased t, in scan order
+var tradeColumns = `
+			t.id, t.user_id, t.address, t.trade_id, t.condition_id, ` + marketTitleColumnsSQL("t") + `,
+			t.outcome, t.side, t.price, t.size, t.value,
+			t.timestamp, t.created_at, t.position_change,
+			t.book_midpoint, t.book_best_bid, t.book_best_ask, t.book_captured_at`
+
+// tradeWithUsernameColumns is the column list selected for TradeWithUsername rows, in scan order
+var tradeWithUsernameColumns = tradeColumns + `, u.username`
+
+// positionColumns is the column list selected for Position rows from positions aliased p, in
+// scan order. The end date is a string, see positionScanDest.
+var positionColumns = `
+			p.id, p.user_id, p.address, p.condition_id, p.asset, ` + marketTitleColumnsSQL("p") + `,
+			p.outcome, p.size, p.avg_price, p.current_price, p.initial_value, p.current_value,
+			p.unrealized_pnl, p.unrealized_pnl_percent, p.realized_pnl,
+			` + marketColumnSQL("p", "end_date", "end_date") + `, p.updated_at`
+
+// positionScanDest returns the scan destinations for a row selected with positionColumns. The
+// end date is scanned into endDate, for parseNullTimestamp: coalesced from markets, SQLite
+// returns it as a string.
+func positionScanDest(pos *Position, endDate *sql.NullString) []any {
+	return []any{
+		&pos.ID, &pos.UserID, &pos.Address, &pos.ConditionID, &pos.Asset,
+		&pos.MarketTitle, &pos.MarketDisplayTitle, &pos.MarketSlug, &pos.Outcome, &pos.Size, &pos.AvgPrice,
+		&pos.CurrentPrice, &pos.InitialValue, &pos.CurrentValue, &pos.UnrealizedPnl,
+		&pos.UnrealizedPnlPercent, &pos.RealizedPnl, endDate, &pos.UpdatedAt,
 	}
+}
 
-	leaderboard := make([]*PersonaStats, 0, len(personas))
-	for _, persona := range personas {
-		stats, err := s.GetPersonaStats(ctx, persona.Slug)
-		if err != nil {
-			s.log.WithError(err).WithField("slug", persona.Slug).Error("failed to get persona stats")
-			continue
-		}
-		leaderboard = append(leaderboard, stats)
+// tradeScanDest returns the scan destinations for a row selected with tradeColumns
+func tradeScanDest(trade *Trade) []any {
+	return []any{
+		&trade.ID, &trade.UserID, &trade.Address, &trade.TradeID, &trade.ConditionID,
+		&trade.MarketTitle, &trade.MarketDisplayTitle, &trade.MarketSlug, &trade.Outcome, &trade.Side, &trade.Price,
+		&trade.Size, &trade.Value, &trade.Timestamp, &trade.CreatedAt, &trade.PositionChange,
+		&trade.BookMidpoint, &trade.BookBestBid, &trade.BookBestAsk, &trade.BookCapturedAt,
 	}
+}
 
-	return leaderboard, nil
+// tradeWithUsernameScanDest returns the scan destinations for a row selected with tradeWithUsernameColumns
+func tradeWithUsernameScanDest(trade *TradeWithUsername) []any {
+	return append(tradeScanDest(&trade.Trade), &trade.Username)
 }
 
-// GetPersonaPositions retrieves combined positions across all accounts for a persona
-func (s *storage) GetPersonaPositions(ctx context.Context, slug string) ([]*PositionWithUsername, error) {
-	persona, err := s.GetPersona(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
+// tradeFilterClause builds the WHERE clause and args for trade filters
+func tradeFilterClause(filters TradeFilters) (string, []any) {
+	// Trades removed upstream are tombstoned rather than deleted
+	whereConditions := []string{"t.removed_at IS NULL"}
+	args := make([]any, 0)
 
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT
-			p.id, p.user_id, p.address, p.condition_id, p.asset,
-			p.market_title, p.market_slug, p.outcome,
-			p.size, p.avg_price, p.current_price,
-			p.initial_value, p.current_value,
-			p.unrealized_pnl, p.unrealized_pnl_percent, p.realized_pnl,
-			p.end_date, p.updated_at,
-			u.username
-		FROM positions p
-		JOIN users u ON p.user_id = u.id
-		WHERE u.persona_id = ?
-		ORDER BY p.unrealized_pnl DESC
-	`, persona.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query persona positions: %w", err)
+	if filters.Username != nil {
+		whereConditions = append(whereConditions, "u.username = ?")
+		args = append(args, *filters.Username)
 	}
-	defer rows.Close()
 
-	positions := make([]*PositionWithUsername, 0)
-	for rows.Next() {
-		var pos PositionWithUsername
-		err := rows.Scan(
-			&pos.ID, &pos.UserID, &pos.Address, &pos.ConditionID, &pos.Asset,
-			&pos.MarketTitle, &pos.MarketSlug, &pos.Outcome,
-			&pos.Size, &pos.AvgPrice, &pos.CurrentPrice,
-			&pos.InitialValue, &pos.CurrentValue,
-			&pos.UnrealizedPnl, &pos.UnrealizedPnlPercent, &pos.RealizedPnl,
-			&pos.EndDate, &pos.UpdatedAt,
-			&pos.Username,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan position: %w", err)
-		}
-		positions = append(positions, &pos)
+	if filters.Side != nil {
+		whereConditions = append(whereConditions, "t.side = ?")
+		args = append(args, *filters.Side)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating positions: %w", err)
+	if filters.MinValue != nil {
+		whereConditions = append(whereConditions, "t.value >= ?")
+		args = append(args, *filters.MinValue)
 	}
 
-	return positions, nil
-}
+	if filters.Start != nil {
+		whereConditions = append(whereConditions, "t.timestamp >= ?")
+		args = append(args, formatTimestamp(*filters.Start))
+	}
 
-// GetPersonaTrades retrieves combined trades across all accounts for a persona
-func (s *storage) GetPersonaTrades(ctx context.Context, slug string, limit, offset int) ([]*TradeWithUsername, int, error) {
-	persona, err := s.GetPersona(ctx, slug)
-	if err != nil {
-		return nil, 0, err
+	if filters.End != nil {
+		whereConditions = append(whereConditions, "t.timestamp < ?")
+		args = append(args, formatTimestamp(*filters.End))
 	}
 
-	// Get total count
-	var total int
-	err = s.db.QueryRowContext(ctx, `
-		SELECT COUNT(*)
-		FROM trades t
-		JOIN users u ON t.user_id = u.id
-		WHERE u.persona_id = ?
-	`, persona.ID).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
+	if !filters.IncludeGhosts {
+		whereConditions = append(whereConditions, "u.ghost = 0")
 	}
 
-	// Get trades
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT
-			t.id, t.user_id, t.address, t.trade_id, t.condition_id,
-			t.market_title, t.market_slug, t.outcome, t.side,
-			t.price, t.size, t.value, t.timestamp, t.created_at,
-			u.username
-		FROM trades t
-		JOIN users u ON t.user_id = u.id
-		WHERE u.persona_id = ?
-		ORDER BY t.timestamp DESC
-		LIMIT ? OFFSET ?
-	`, persona.ID, limit, offset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query persona trades: %w", err)
+	if len(filters.MutedUsers) > 0 {
+		whereConditions = append(whereConditions, "u.username NOT IN ("+placeholders(len(filters.MutedUsers))+")")
+		for _, username := range filters.MutedUsers {
+			args = append(args, username)
+		}
 	}
-	defer rows.Close()
 
-	trades := make([]*TradeWithUsername, 0)
-	for rows.Next() {
-		var t TradeWithUsername
-		err := rows.Scan(
-			&t.ID, &t.UserID, &t.Address, &t.TradeID, &t.ConditionID,
-			&t.MarketTitle, &t.MarketSlug, &t.Outcome, &t.Side,
-			&t.Price, &t.Size, &t.Value, &t.Timestamp, &t.CreatedAt,
-			&t.Username,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
+	if len(filters.MutedCategories) > 0 {
+		whereConditions = append(whereConditions,
+			marketCategorySQL("t")+" NOT IN ("+placeholders(len(filters.MutedCategories))+")")
+		for _, category := range filters.MutedCategories {
+			args = append(args, category)
 		}
-		trades = append(trades, &t)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + fmt.Sprintf("%s", whereConditions[0])
+		for i := 1; i < len(whereConditions); i++ {
+			whereClause += " AND " + whereConditions[i]
+		}
 	}
 
-	return trades, total, nil
+	return whereClause, args
 }
 
-// GetUserPersonaInfo retrieves persona info for a user
-func (s *storage) GetUserPersonaInfo(ctx context.Context, userID int64) (*PersonaInfo, error) {
-	var info PersonaInfo
-	err := s.db.QueryRowContext(ctx, `
-		SELECT p.slug, p.display_name
-		FROM personas p
-		JOIN users u ON u.persona_id = p.id
-		WHERE u.id = ?
-	`, userID).Scan(&info.Slug, &info.DisplayName)
+// notDust returns a condition matching positions worth at least the dust threshold. prefix
+// qualifies the positions columns, e.g. "p.".
+func (s *storage) notDust(prefix string) string {
+	if s.dustValue <= 0 {
+		return "1 = 1"
+	}
+	// The threshold comes from config, so it is safe to inline
+	return fmt.Sprintf("COALESCE(%[1]scurrent_value, %[1]ssize * %[1]scurrent_price, 0) >= %[2]s",
+		prefix, strconv.FormatFloat(s.dustValue, 'g', -1, 64))
+}
 
-	if err == sql.ErrNoRows {
-		return nil, nil // User has no persona
+// isDust reports whether a position is worth less than the dust threshold
+func (s *storage) isDust(pos *Position) bool {
+	if s.dustValue <= 0 {
+		return false
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user persona info: %w", err)
+
+	var value float64
+	switch {
+	case pos.CurrentValue != nil:
+		value = *pos.CurrentValue
+	case pos.Size != nil && pos.CurrentPrice != nil:
+		value = *pos.Size * *pos.CurrentPrice
 	}
+	return value < s.dustValue
+}
 
-	return &info, nil
+// placeholders returns a comma-separated list of n query placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
 }
 
-// UpdateUserProfileImage updates a user's profile image
-func (s *storage) UpdateUserProfileImage(ctx context.Context, userID int64, profileImage string) error {
-	_, err := s.db.ExecContext(ctx,
-		"UPDATE users SET profile_image = ? WHERE id = ?",
-		profileImage, userID,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to update user profile image: %w", err)
-	}
-	return nil
+// personaPositionSortColumns maps persona position sort keys to columns
+var personaPositionSortColumns = map[string]string{
+	"unrealizedPnl": "p.unrealized_pnl",
+	"currentValue":  "p.current_value",
+	"initialValue":  "p.initial_value",
+	"size":          "p.size",
+	"endDate":       marketColumnSQL("p", "end_date", "end_date"),
+	"marketTitle":   marketColumnSQL("p", "title", "market_title"),
 }
 
-// UpdateUserOfficialPnl updates a user's official PnL and volume from Polymarket
-func (s *storage) UpdateUserOfficialPnl(ctx context.Context, userID int64, pnl, volume float64) error {
-	_, err := s.db.ExecContext(ctx,
+// personaTradeSortColumns maps persona trade sort keys to columns
+var personaTradeSortColumns = map[string]string{
+	"timestamp": "t.timestamp",
+	"value":     "t.value",
+	"size":      "t.size",
+}
+
+// personaResultSortColumns maps persona result sort keys to columns of the grouped results query
+var personaResultSortColumns = map[string]string{
+	"resolutionDate": "resolution_date",
+	"realizedPnl":    "realized_pnl",
+	"initialValue":   "initial_value",
+	"endDate":        "end_date",
+}
+
+// sortOrderClause builds an ORDER BY clause from a whitelist of sortable columns.
+// Unknown keys fall back to defaultKey, direction defaults to descending, and
+// tiebreaker keeps paging stable between equal values.
+func sortOrderClause(columns map[string]string, defaultKey, sortBy, sortDirection, tiebreaker string) string {
+	column, ok := columns[sortBy]
+	if !ok {
+		column = columns[defaultKey]
+	}
+
+	sortOrder := "DESC"
+	if sortDirection == "asc" {
+		sortOrder = "ASC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, %s %s", column, sortOrder, tiebreaker, sortOrder)
+}
+
+// tradeOrderClause builds the ORDER BY clause for trade filters
+func tradeOrderClause(filters TradeFilters) string {
+	sortColumn := "t.timestamp"
+	switch filters.SortBy {
+	case "value":
+		sortColumn = "t.value"
+	case "size":
+		sortColumn = "t.size"
+	case "timestamp":
+		sortColumn = "t.timestamp"
+	default:
+		sortColumn = "t.timestamp"
+	}
+
+	sortOrder := "DESC"
+	if filters.SortDirection == "asc" {
+		sortOrder = "ASC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", sortColumn, sortOrder)
+}
+
+// InsertPnlSnapshot inserts a PNL snapshot
+func (s *storage) InsertPnlSnapshot(ctx context.Context, snapshot *PnlSnapshot) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO pnl_snapshots (user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl, source)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		snapshot.UserID, formatTimestamp(snapshot.Timestamp), snapshot.TotalPnl, snapshot.RealizedPnl, snapshot.UnrealizedPnl,
+		snapshotSource(snapshot),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert pnl snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetUserPnlHistory retrieves PNL history for a user
+func (s *storage) GetUserPnlHistory(ctx context.Context, userID int64, start, end *time.Time) ([]*PnlSnapshot, error) {
+	query := `
+		SELECT id, user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl, source
+		FROM pnl_snapshots
+		WHERE user_id = ?
+	`
+	args := []any{userID}
+
+	if start != nil {
+		query += " AND timestamp >= ?"
+		args = append(args, formatTimestamp(*start))
+	}
+	if end != nil {
+		query += " AND timestamp <= ?"
+		args = append(args, formatTimestamp(*end))
+	}
+
+	query += " ORDER BY timestamp ASC"
+
+	rows, err := s.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pnl history: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := make([]*PnlSnapshot, 0)
+	for rows.Next() {
+		var snapshot PnlSnapshot
+		if err := rows.Scan(
+			&snapshot.ID, &snapshot.UserID, &snapshot.Timestamp,
+			&snapshot.TotalPnl, &snapshot.RealizedPnl, &snapshot.UnrealizedPnl, &snapshot.Source,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pnl snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pnl snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetUserStats retrieves aggregated statistics for a user
+func (s *storage) GetUserStats(ctx context.Context, username string) (*UserStats, error) {
+	stats, err := s.userStats(ctx, "WHERE username = ?", username)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("user %w: %s", ErrNotFound, username)
+	}
+
+	return stats[0], nil
+}
+
+// GetLeaderboard retrieves leaderboard of all users, ordered by username. Ghost users are
+// tracked but never ranked publicly.
+func (s *storage) GetLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*UserStats, error) {
+	return s.userStats(ctx, "WHERE ghost = 0")
+}
+
+// GetUserStatsPage retrieves a sorted page of users' stats along with the total count of users
+// it pages through, from the aggregation behind the leaderboard. It sorts by the keys of
+// UserSortColumns and UserStatSorts. Ghost users are left out unless filters.IncludeGhosts is
+// set, and a Limit of 0 returns every user.
+func (s *storage) GetUserStatsPage(ctx context.Context, filters UserFilters) ([]*UserStats, int, error) {
+	filter := ""
+	if !filters.IncludeGhosts {
+		filter = "WHERE ghost = 0"
+	}
+	stats, err := s.userStats(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Sorted here since realized PnL and win rates come from matching trades, not SQL
+	key := func(stats *UserStats) float64 {
+		switch filters.SortBy {
+		case "totalPnl":
+			return stats.TotalPnl
+		case "realizedPnl":
+			return stats.RealizedPnl
+		case "unrealizedPnl":
+			return stats.UnrealizedPnl
+		case "volume":
+			return stats.Volume
+		case "totalTrades":
+			return float64(stats.TotalTrades)
+		case "openPositions":
+			return float64(stats.OpenPositions)
+		case "winRate":
+			return stats.WinRate
+		case "createdAt":
+			return float64(stats.CreatedAt.UnixNano())
+		case "lastSynced":
+			if stats.LastSynced == nil {
+				return math.Inf(-1)
+			}
+			return float64(stats.LastSynced.UnixNano())
+		}
+		return 0
+	}
+	// Stable over the username order userStats returns, which breaks ties
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if filters.SortDirection == "desc" {
+			a, b = b, a
+		}
+		if filters.SortBy == "username" || filters.SortBy == "" {
+			return a.Username < b.Username
+		}
+		return key(a) < key(b)
+	})
+
+	total := len(stats)
+	start := min(filters.Offset, total)
+	end := total
+	if filters.Limit > 0 {
+		end = min(start+filters.Limit, total)
+	}
+
+	return stats[start:end], total, nil
+}
+
+// userStats aggregates statistics for the users matching filter, ordered by username. Address,
+// position and trade totals come from a single aggregation across the users; realized PnL and
+// win rates come from one chronological pass over their trades.
+func (s *storage) userStats(ctx context.Context, filter string, args ...any) ([]*UserStats, error) {
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			%s,
+			COALESCE(a.addresses, '') as addresses,
+			COALESCE(p.open_positions, 0) as open_positions,
+			COALESCE(p.unrealized_pnl, 0) as unrealized_pnl,
+			COALESCE(t.trades, 0) as trades,
+			COALESCE(t.volume, 0) as volume,
+			t.last_trade_at
+		FROM users
+		LEFT JOIN (
+			SELECT user_id, GROUP_CONCAT(address, char(31) ORDER BY id) as addresses
+			FROM addresses
+			GROUP BY user_id
+		) a ON a.user_id = users.id
+		LEFT JOIN (
+			SELECT user_id, COUNT(*) FILTER (WHERE %s) as open_positions, SUM(unrealized_pnl) as unrealized_pnl
+			FROM positions
+			GROUP BY user_id
+		) p ON p.user_id = users.id
+		LEFT JOIN (
+			SELECT user_id, COUNT(*) as trades, SUM(value) as volume, MAX(timestamp) as last_trade_at
+			FROM trades
+			WHERE removed_at IS NULL
+			GROUP BY user_id
+		) t ON t.user_id = users.id
+		%s
+		ORDER BY username
+	`, userColumns, s.notDust(""), filter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user stats: %w", err)
+	}
+	defer rows.Close()
+
+	type userTotals struct {
+		user    User
+		stats   *UserStats
+		volume  float64
+		version uint64 // of the user's cached FIFO totals
+	}
+	totals := make([]*userTotals, 0)
+	for rows.Next() {
+		t := &userTotals{stats: &UserStats{}}
+		var addresses string
+		var lastTradeAt sql.NullString
+		dest := append(userScanDest(&t.user),
+			&addresses, &t.stats.OpenPositions, &t.stats.UnrealizedPnl,
+			&t.stats.TotalTrades, &t.volume, &lastTradeAt,
+		)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan user stats: %w", err)
+		}
+
+		t.stats.Username = t.user.Username
+		t.stats.ProfileImage = t.user.ProfileImage
+		t.stats.LastSynced = t.user.LastSynced
+		t.stats.LastTradeAt = parseNullTimestamp(lastTradeAt)
+		t.stats.CreatedAt = t.user.CreatedAt
+		t.stats.Ghost = t.user.Ghost
+		t.stats.Addresses = make([]string, 0)
+		if addresses != "" {
+			t.stats.Addresses = strings.Split(addresses, "\x1f")
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user stats: %w", err)
+	}
+	rows.Close()
+
+	// FIFO totals only change with the trades, so they're recomputed after a sync
+	fifo := make(map[int64]fifoTotals, len(totals))
+	var stale []*userTotals
+	for _, t := range totals {
+		cached, version, ok := s.fifo.get(t.user.ID, t.user.LastSynced)
+		if ok {
+			fifo[t.user.ID] = cached
+		} else {
+			t.version = version
+			stale = append(stale, t)
+		}
+	}
+	if len(stale) > 0 {
+		ids := make([]int64, len(stale))
+		for i, t := range stale {
+			ids[i] = t.user.ID
+		}
+		computed, err := s.userFIFOTotals(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range stale {
+			fifo[t.user.ID] = computed[t.user.ID]
+			s.fifo.put(t.user.ID, t.version, t.user.LastSynced, computed[t.user.ID])
+		}
+	}
+
+	leaderboard := make([]*UserStats, len(totals))
+	for i, t := range totals {
+		stats, user, matched := t.stats, t.user, fifo[t.user.ID]
+
+		// Use official PnL from Polymarket if available (all-time accurate data)
+		// Otherwise fall back to FIFO calculation from available trade history
+		if user.OfficialPnl != nil {
+			// Official PnL is the total (realized + unrealized)
+			stats.TotalPnl = *user.OfficialPnl
+			stats.RealizedPnl = stats.TotalPnl - stats.UnrealizedPnl
+		} else {
+			stats.RealizedPnl = matched.realizedPnl
+			stats.TotalPnl = stats.RealizedPnl + stats.UnrealizedPnl
+		}
+
+		// Use official volume from Polymarket if available, otherwise sum of tracked trade value
+		if user.OfficialVolume != nil {
+			stats.Volume = *user.OfficialVolume
+		} else {
+			stats.Volume = t.volume
+		}
+
+		if matched.closed > 0 {
+			stats.WinRate = float64(matched.wins) / float64(matched.closed)
+		}
+
+		leaderboard[i] = stats
+	}
+
+	return leaderboard, nil
+}
+
+// UserStatsCacheStats reports the hits and misses of the cache of users' FIFO totals
+func (s *storage) UserStatsCacheStats() CacheStats {
+	return s.fifo.stats()
+}
+
+// fifoTotals is what matching a user's trades with FIFO cost basis realized
+type fifoTotals struct {
+	realizedPnl float64
+	wins        int
+	closed      int
+}
+
+// userFIFOTotals matches the trades of each of the given users with FIFO cost basis
+func (s *storage) userFIFOTotals(ctx context.Context, userIDs []int64) (map[int64]fifoTotals, error) {
+	totals := make(map[int64]fifoTotals, len(userIDs))
+	if len(userIDs) == 0 {
+		return totals, nil
+	}
+
+	args := make([]any, len(userIDs))
+	for i, id := range userIDs {
+		args[i] = id
+	}
+
+	// Ordered by user so each user's trades can be matched as soon as the next user starts
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT user_id, condition_id, outcome, side, price, size, timestamp, trade_id
+		FROM trades
+		WHERE user_id IN (`+placeholders(len(userIDs))+`)
+		AND removed_at IS NULL
+		ORDER BY user_id, timestamp ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user trades: %w", err)
+	}
+	defer rows.Close()
+
+	var currentUser int64
+	trades := make([]*Trade, 0)
+	flush := func() {
+		realizedPnl, wins, closed := realizedPnlFIFO(trades)
+		totals[currentUser] = fifoTotals{realizedPnl: realizedPnl, wins: wins, closed: closed}
+		trades = trades[:0]
+	}
+
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(
+			&trade.UserID, &trade.ConditionID, &trade.Outcome, &trade.Side,
+			&trade.Price, &trade.Size, &trade.Timestamp, &trade.TradeID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user trade: %w", err)
+		}
+		if len(trades) > 0 && trade.UserID != currentUser {
+			flush()
+		}
+		currentUser = trade.UserID
+		trades = append(trades, &trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user trades: %w", err)
+	}
+	if len(trades) > 0 {
+		flush()
+	}
+
+	return totals, nil
+}
+
+// GetUserLastTradeBefore retrieves the time of a user's most recent trade made before the given
+// time, nil when there is none
+func (s *storage) GetUserLastTradeBefore(ctx context.Context, userID int64, before time.Time) (*time.Time, error) {
+	var last sql.NullString
+	if err := s.reader.QueryRowContext(ctx,
+		"SELECT MAX(timestamp) FROM trades WHERE user_id = ? AND timestamp < ? AND removed_at IS NULL",
+		userID, formatTimestamp(before),
+	).Scan(&last); err != nil {
+		return nil, fmt.Errorf("failed to get last trade: %w", err)
+	}
+
+	return parseNullTimestamp(last), nil
+}
+
+// GetAddressLastTrades retrieves the time of the most recent trade of each address. Addresses
+// without trades are left out.
+func (s *storage) GetAddressLastTrades(ctx context.Context, addresses []string) (map[string]time.Time, error) {
+	last := make(map[string]time.Time, len(addresses))
+	if len(addresses) == 0 {
+		return last, nil
+	}
+
+	args := make([]any, len(addresses))
+	for i, address := range addresses {
+		args[i] = address
+	}
+
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT address, MAX(timestamp) FROM trades WHERE address IN ("+placeholders(len(addresses))+") AND removed_at IS NULL GROUP BY address",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query last trades: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address string
+		var timestamp sql.NullString
+		if err := rows.Scan(&address, &timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan last trade: %w", err)
+		}
+		if t := parseNullTimestamp(timestamp); t != nil {
+			last[address] = *t
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating last trades: %w", err)
+	}
+
+	return last, nil
+}
+
+// GetUserTradesChronological retrieves all trades for a user sorted by timestamp ASC
+func (s *storage) GetUserTradesChronological(ctx context.Context, userID int64) ([]*Trade, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT `+tradeColumns+`
+		FROM trades t
+		WHERE t.user_id = ?
+		AND t.removed_at IS NULL
+		ORDER BY t.timestamp ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := make([]*Trade, 0)
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(tradeScanDest(&trade)...); err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, &trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trades: %w", err)
+	}
+
+	return trades, nil
+}
+
+// DeleteUserPnlSnapshots deletes all PNL snapshots for a user
+func (s *storage) DeleteUserPnlSnapshots(ctx context.Context, userID int64) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM pnl_snapshots WHERE user_id = ?", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pnl snapshots: %w", err)
+	}
+	return nil
+}
+
+// DownsamplePnlSnapshots applies retention tiers to PnL snapshots, deleting all but the latest
+// snapshot per user and source in each of a tier's buckets once they're older than its age.
+// Tiers are applied in order, so each should be older and coarser than the one before.
+// Returns the number of snapshots deleted.
+func (s *storage) DownsamplePnlSnapshots(ctx context.Context, tiers []SnapshotTier, now time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted := 0
+	for _, tier := range tiers {
+		bucket := int64(tier.Every / time.Second)
+		if bucket <= 0 {
+			continue
+		}
+		cutoff := formatTimestamp(now.Add(-tier.After))
+
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM pnl_snapshots
+			WHERE timestamp < ?
+			AND id NOT IN (
+				SELECT id FROM (
+					SELECT id, ROW_NUMBER() OVER (
+						PARTITION BY user_id, source, CAST(strftime('%s', timestamp) AS INTEGER) / ?
+						ORDER BY timestamp DESC, id DESC
+					) AS rn
+					FROM pnl_snapshots
+					WHERE timestamp < ?
+				)
+				WHERE rn = 1
+			)
+		`, cutoff, bucket, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to downsample pnl snapshots: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to count downsampled pnl snapshots: %w", err)
+		}
+		deleted += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// BulkInsertPnlSnapshots inserts multiple PNL snapshots in a single transaction
+func (s *storage) BulkInsertPnlSnapshots(ctx context.Context, snapshots []*PnlSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertPnlSnapshots(ctx, tx, snapshots); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// MergeBackfilledPnlSnapshots replaces a user's backfilled snapshots with new ones, keeping
+// snapshots recorded by syncs. Only backfilled snapshots from before the earliest synced one are
+// inserted, so reconstructed history fills the gap without overlapping live data. Returns the
+// number of snapshots inserted.
+func (s *storage) MergeBackfilledPnlSnapshots(ctx context.Context, userID int64, snapshots []*PnlSnapshot) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM pnl_snapshots WHERE user_id = ? AND source = ?",
+		userID, SnapshotSourceBackfill,
+	); err != nil {
+		return 0, fmt.Errorf("failed to delete backfilled pnl snapshots: %w", err)
+	}
+
+	var earliestLive *time.Time
+	var earliest time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT timestamp FROM pnl_snapshots
+		WHERE user_id = ? AND source != ?
+		ORDER BY timestamp ASC
+		LIMIT 1
+	`, userID, SnapshotSourceBackfill).Scan(&earliest)
+	switch {
+	case err == nil:
+		earliestLive = &earliest
+	case err != sql.ErrNoRows:
+		return 0, fmt.Errorf("failed to get earliest synced pnl snapshot: %w", err)
+	}
+
+	gap := make([]*PnlSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if earliestLive == nil || snapshot.Timestamp.Before(*earliestLive) {
+			gap = append(gap, snapshot)
+		}
+	}
+
+	if err := insertPnlSnapshots(ctx, tx, gap); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(gap), nil
+}
+
+// insertPnlSnapshots inserts snapshots within a transaction
+func insertPnlSnapshots(ctx context.Context, tx *sql.Tx, snapshots []*PnlSnapshot) error {
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO pnl_snapshots (user_id, timestamp, total_pnl, realized_pnl, unrealized_pnl, source)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, snapshot := range snapshots {
+		_, err := stmt.ExecContext(ctx,
+			snapshot.UserID, formatTimestamp(snapshot.Timestamp), snapshot.TotalPnl, snapshot.RealizedPnl, snapshot.UnrealizedPnl,
+			snapshotSource(snapshot),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert pnl snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// snapshotSource is where a snapshot came from, defaulting to a sync
+func snapshotSource(snapshot *PnlSnapshot) string {
+	if snapshot.Source == "" {
+		return SnapshotSourceSync
+	}
+	return snapshot.Source
+}
+
+// CreateUserWithPersona creates a new user with addresses and associates with a persona
+func (s *storage) CreateUserWithPersona(ctx context.Context, username string, addresses []string, personaID int64) (*User, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Insert user with persona_id
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO users (username, created_at, persona_id) VALUES (?, "+sqlNow+", ?)",
+		username, personaID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert user: %w", conflictError(err))
+	}
+
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user id: %w", err)
+	}
+
+	// Insert addresses
+	for _, addr := range addresses {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO addresses (user_id, address) VALUES (?, ?)",
+			userID, addr,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert address: %w", conflictError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetUser(ctx, username)
+}
+
+// UpdateUserPersona updates a user's persona association
+func (s *storage) UpdateUserPersona(ctx context.Context, userID int64, personaID int64) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE users SET persona_id = ? WHERE id = ?",
+		personaID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user persona: %w", err)
+	}
+	return nil
+}
+
+// ClearUserPersona detaches a user from its persona
+func (s *storage) ClearUserPersona(ctx context.Context, userID int64) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE users SET persona_id = NULL WHERE id = ?",
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear user persona: %w", err)
+	}
+	return nil
+}
+
+// AddUserAddress adds a wallet address to a user
+func (s *storage) AddUserAddress(ctx context.Context, userID int64, address string) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO addresses (user_id, address) VALUES (?, ?) ON CONFLICT(user_id, address) DO NOTHING",
+		userID, address,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert address: %w", conflictError(err))
+	}
+	return nil
+}
+
+// RemoveUserAddress removes a wallet address from a user along with its positions.
+// Trades of the address are kept so realized PnL history stays intact.
+func (s *storage) RemoveUserAddress(ctx context.Context, userID int64, address string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM positions WHERE user_id = ? AND address = ?", userID, address); err != nil {
+		return fmt.Errorf("failed to delete address positions: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM addresses WHERE user_id = ? AND address = ?", userID, address); err != nil {
+		return fmt.Errorf("failed to delete address: %w", err)
+	}
+
+	// The user's official numbers are the sum over their addresses, which no longer include it
+	pnl, volume, known, err := sumAddressOfficialPnl(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	if known {
+		if err := setUserOfficialPnl(ctx, tx, userID, pnl, volume); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetAddressGroup moves one of a user's addresses into a named group, or out of any group when group is nil
+func (s *storage) SetAddressGroup(ctx context.Context, userID int64, address string, group *string) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE addresses SET group_name = ? WHERE user_id = ? AND address = ?",
+		group, userID, address,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update address group: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("address %w: %s", ErrNotFound, address)
+	}
+
+	return nil
+}
+
+// GetUserAddressGroupStats computes stats for each address group of a user. Addresses
+// without a group are reported together under UngroupedAddressGroup.
+func (s *storage) GetUserAddressGroupStats(ctx context.Context, userID int64) ([]*AddressGroupStats, error) {
+	addresses, err := s.GetUserAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*AddressGroupStats)
+	groupOf := make(map[string]string, len(addresses))
+	for _, addr := range addresses {
+		name := UngroupedAddressGroup
+		if addr.Group != nil {
+			name = *addr.Group
+		}
+
+		group, ok := groups[name]
+		if !ok {
+			group = &AddressGroupStats{Name: name, Addresses: make([]string, 0, 1)}
+			groups[name] = group
+		}
+		group.Addresses = append(group.Addresses, addr.Address)
+		groupOf[addr.Address] = name
+	}
+
+	positions, err := s.GetUserPositions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pos := range positions {
+		group, ok := groups[groupOf[pos.Address]]
+		if !ok {
+			continue
+		}
+		if pos.UnrealizedPnl != nil {
+			group.UnrealizedPnl += *pos.UnrealizedPnl
+		}
+		if s.isDust(pos) {
+			continue
+		}
+		group.OpenPositions++
+		if pos.CurrentValue != nil {
+			group.CurrentValue += *pos.CurrentValue
+		}
+	}
+
+	trades, err := s.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tradesByGroup := make(map[string][]*Trade, len(groups))
+	for _, trade := range trades {
+		name, ok := groupOf[trade.Address]
+		if !ok {
+			continue
+		}
+		tradesByGroup[name] = append(tradesByGroup[name], trade)
+		groups[name].TotalTrades++
+		if trade.Value != nil {
+			groups[name].Volume += *trade.Value
+		}
+	}
+
+	result := make([]*AddressGroupStats, 0, len(groups))
+	for name, group := range groups {
+		realizedPnl, wins, totalClosed := realizedPnlFIFO(tradesByGroup[name])
+		group.RealizedPnl = realizedPnl
+		group.TotalPnl = group.RealizedPnl + group.UnrealizedPnl
+		if totalClosed > 0 {
+			group.WinRate = float64(wins) / float64(totalClosed)
+		}
+		result = append(result, group)
+	}
+
+	// Named groups alphabetically, with the ungrouped addresses last
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Name == UngroupedAddressGroup) != (result[j].Name == UngroupedAddressGroup) {
+			return result[j].Name == UngroupedAddressGroup
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// DeleteUser deletes a user and all data tracked for them
+func (s *storage) DeleteUser(ctx context.Context, userID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{
+		"positions", "trades", "pnl_snapshots", "sync_errors", "official_pnl_history", "position_settlements",
+		"user_identities", "user_badges", "reactions", "user_claims", "addresses",
+	} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE user_id = ?", userID); err != nil {
+			return fmt.Errorf("failed to delete user %s: %w", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = ?", userID); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.fifo.invalidate(userID)
+	return nil
+}
+
+// CreatePersona creates a new persona
+func (s *storage) CreatePersona(ctx context.Context, slug, displayName string) (*Persona, error) {
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO personas (slug, display_name, created_at) VALUES (?, ?, "+sqlNow+")",
+		slug, displayName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert persona: %w", conflictError(err))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persona id: %w", err)
+	}
+
+	return &Persona{
+		ID:          id,
+		Slug:        slug,
+		DisplayName: displayName,
+	}, nil
+}
+
+// GetPersona retrieves a persona by slug
+func (s *storage) GetPersona(ctx context.Context, slug string) (*Persona, error) {
+	var persona Persona
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT id, slug, display_name, image, created_at FROM personas WHERE slug = ?",
+		slug,
+	).Scan(&persona.ID, &persona.Slug, &persona.DisplayName, &persona.Image, &persona.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("persona %w: %s", ErrNotFound, slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persona: %w", err)
+	}
+
+	return &persona, nil
+}
+
+// GetPersonas retrieves all personas
+func (s *storage) GetPersonas(ctx context.Context) ([]*Persona, error) {
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT id, slug, display_name, image, created_at FROM personas ORDER BY display_name",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query personas: %w", err)
+	}
+	defer rows.Close()
+
+	personas := make([]*Persona, 0)
+	for rows.Next() {
+		var persona Persona
+		if err := rows.Scan(&persona.ID, &persona.Slug, &persona.DisplayName, &persona.Image, &persona.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan persona: %w", err)
+		}
+		personas = append(personas, &persona)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating personas: %w", err)
+	}
+
+	return personas, nil
+}
+
+// GetPersonaUsers retrieves all users belonging to a persona
+func (s *storage) GetPersonaUsers(ctx context.Context, personaID int64) ([]*User, error) {
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT "+userColumns+" FROM users WHERE persona_id = ? ORDER BY username",
+		personaID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*User, 0)
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(userScanDest(&user)...); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetPersonaStats retrieves aggregated statistics for a persona across all their users
+func (s *storage) GetPersonaStats(ctx context.Context, slug string) (*PersonaStats, error) {
+	persona, err := s.GetPersona(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.personaStats(ctx, &persona.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("persona %w: %s", ErrNotFound, slug)
+	}
+
+	return stats[0], nil
+}
+
+// GetPersonaLeaderboard retrieves leaderboard of all personas
+func (s *storage) GetPersonaLeaderboard(ctx context.Context, sortBy, sortDirection string) ([]*PersonaStats, error) {
+	return s.personaStats(ctx, nil)
+}
+
+// personaStats aggregates statistics for every persona, or only the given one, ordered by display
+// name. Position and trade totals come from a single aggregation across all personas; win rates
+// come from one chronological pass over their users' trades. Ghost users are left out, the
+// stats are public.
+func (s *storage) personaStats(ctx context.Context, personaID *int64) ([]*PersonaStats, error) {
+	filter, args := "", []any{}
+	if personaID != nil {
+		filter, args = "WHERE pe.id = ?", []any{*personaID}
+	}
+
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			pe.id, pe.slug, pe.display_name, pe.image,
+			COALESCE(GROUP_CONCAT(u.username, char(31) ORDER BY u.username), '') as usernames,
+			COALESCE(SUM(p.open_positions), 0) as open_positions,
+			COALESCE(SUM(p.unrealized_pnl), 0) as unrealized_pnl,
+			COALESCE(SUM(t.trades), 0) as trades,
+			COUNT(u.official_pnl) as official_pnl_users,
+			COALESCE(SUM(u.official_pnl), 0) as official_pnl,
+			COALESCE(SUM(COALESCE(u.official_volume, t.volume)), 0) as volume
+		FROM personas pe
+		LEFT JOIN users u ON u.persona_id = pe.id AND u.ghost = 0
+		LEFT JOIN (
+			SELECT user_id, COUNT(*) FILTER (WHERE %s) as open_positions, SUM(unrealized_pnl) as unrealized_pnl
+			FROM positions
+			GROUP BY user_id
+		) p ON p.user_id = u.id
+		LEFT JOIN (
+			SELECT user_id, COUNT(*) as trades, SUM(value) as volume
+			FROM trades
+			WHERE removed_at IS NULL
+			GROUP BY user_id
+		) t ON t.user_id = u.id
+		%s
+		GROUP BY pe.id
+		ORDER BY pe.display_name
+	`, s.notDust(""), filter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persona stats: %w", err)
+	}
+	defer rows.Close()
+
+	leaderboard := make([]*PersonaStats, 0)
+	byID := make(map[int64]*PersonaStats)
+	officialPnl := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		var usernames string
+		var officialPnlUsers int
+		var totalOfficialPnl float64
+		stats := &PersonaStats{}
+		if err := rows.Scan(
+			&id, &stats.Slug, &stats.DisplayName, &stats.Image, &usernames,
+			&stats.OpenPositions, &stats.UnrealizedPnl, &stats.TotalTrades,
+			&officialPnlUsers, &totalOfficialPnl, &stats.Volume,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan persona stats: %w", err)
+		}
+
+		stats.Usernames = make([]string, 0)
+		if usernames != "" {
+			stats.Usernames = strings.Split(usernames, "\x1f")
+		}
+
+		// Use official PnL if any user has it
+		if officialPnlUsers > 0 {
+			officialPnl[id] = true
+			stats.TotalPnl = totalOfficialPnl
+			stats.RealizedPnl = stats.TotalPnl - stats.UnrealizedPnl
+		} else {
+			stats.TotalPnl = stats.RealizedPnl + stats.UnrealizedPnl
+		}
+
+		leaderboard = append(leaderboard, stats)
+		byID[id] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating persona stats: %w", err)
+	}
+	rows.Close()
+
+	wins, closed, err := s.personaWinCounts(ctx, personaID)
+	if err != nil {
+		return nil, err
+	}
+	for id, stats := range byID {
+		if closed[id] > 0 {
+			stats.WinRate = float64(wins[id]) / float64(closed[id])
+		}
+	}
+
+	return leaderboard, nil
+}
+
+// personaWinCounts matches the trades of every persona's users with FIFO cost basis, or only
+// the given persona's, returning winning and total closed disposals per persona. Ghost users
+// are left out.
+func (s *storage) personaWinCounts(ctx context.Context, personaID *int64) (map[int64]int, map[int64]int, error) {
+	filter, args := "u.persona_id IS NOT NULL", []any{}
+	if personaID != nil {
+		filter, args = "u.persona_id = ?", []any{*personaID}
+	}
+
+	// Ordered by user so each user's trades can be matched as soon as the next user starts
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT u.persona_id, t.user_id, t.condition_id, t.outcome, t.side, t.price, t.size, t.timestamp, t.trade_id
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		WHERE %s
+		AND u.ghost = 0
+		AND t.removed_at IS NULL
+		ORDER BY t.user_id, t.timestamp ASC
+	`, filter), args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query persona trades: %w", err)
+	}
+	defer rows.Close()
+
+	wins := make(map[int64]int)
+	closed := make(map[int64]int)
+	var currentPersona, currentUser int64
+	trades := make([]*Trade, 0)
+	flush := func() {
+		_, w, c := realizedPnlFIFO(trades)
+		wins[currentPersona] += w
+		closed[currentPersona] += c
+		trades = trades[:0]
+	}
+
+	for rows.Next() {
+		var pid int64
+		var trade Trade
+		if err := rows.Scan(
+			&pid, &trade.UserID, &trade.ConditionID, &trade.Outcome, &trade.Side,
+			&trade.Price, &trade.Size, &trade.Timestamp, &trade.TradeID,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan persona trade: %w", err)
+		}
+		if len(trades) > 0 && trade.UserID != currentUser {
+			flush()
+		}
+		currentPersona, currentUser = pid, trade.UserID
+		trades = append(trades, &trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating persona trades: %w", err)
+	}
+	if len(trades) > 0 {
+		flush()
+	}
+
+	return wins, closed, nil
+}
+
+// GetPersonaPositions retrieves combined positions across all accounts for a persona, ghost
+// users left out
+func (s *storage) GetPersonaPositions(ctx context.Context, slug, sortBy, sortDirection string, includeDust bool) ([]*PositionWithUsername, error) {
+	persona, err := s.GetPersona(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy := sortOrderClause(personaPositionSortColumns, "unrealizedPnl", sortBy, sortDirection, "p.id")
+
+	filter := ""
+	if !includeDust {
+		filter = "AND " + s.notDust("p.")
+	}
+
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s, u.username
+		FROM positions p
+		JOIN users u ON p.user_id = u.id
+		WHERE u.persona_id = ?
+		AND u.ghost = 0
+		%s
+		%s
+	`, positionColumns, filter, orderBy), persona.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persona positions: %w", err)
+	}
+	defer rows.Close()
+
+	positions := make([]*PositionWithUsername, 0)
+	for rows.Next() {
+		var pos PositionWithUsername
+		var endDate sql.NullString
+		if err := rows.Scan(append(positionScanDest(&pos.Position, &endDate), &pos.Username)...); err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		pos.EndDate = parseNullTimestamp(endDate)
+		positions = append(positions, &pos)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating positions: %w", err)
+	}
+
+	held := make([]*Position, len(positions))
+	for i, pos := range positions {
+		held[i] = &pos.Position
+	}
+	if err := s.attachPositionEntries(ctx, held); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// GetPersonaTrades retrieves combined trades across all accounts for a persona, ghost users
+// left out
+func (s *storage) GetPersonaTrades(ctx context.Context, slug string, limit, offset int, sortBy, sortDirection string, minValue *float64) ([]*TradeWithUsername, int, error) {
+	persona, err := s.GetPersona(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter, args := "", []any{persona.ID}
+	if minValue != nil {
+		filter, args = "AND t.value >= ?", append(args, *minValue)
+	}
+
+	// Get total count
+	var total int
+	err = s.reader.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		WHERE u.persona_id = ?
+		AND u.ghost = 0
+		AND t.removed_at IS NULL
+		`+filter, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count trades: %w", err)
+	}
+
+	// Get trades
+	orderBy := sortOrderClause(personaTradeSortColumns, "timestamp", sortBy, sortDirection, "t.id")
+
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		WHERE u.persona_id = ?
+		AND u.ghost = 0
+		AND t.removed_at IS NULL
+		%s
+		%s
+		LIMIT ? OFFSET ?
+	`, tradeWithUsernameColumns, filter, orderBy), append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query persona trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := make([]*TradeWithUsername, 0)
+	for rows.Next() {
+		var t TradeWithUsername
+		err := rows.Scan(tradeWithUsernameScanDest(&t)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating trades: %w", err)
+	}
+
+	return trades, total, nil
+}
+
+// UpdateUserIdentity stores the identity from a user's Polymarket profile
+func (s *storage) UpdateUserIdentity(ctx context.Context, identity *UserIdentity) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO user_identities (user_id, name, pseudonym, bio, x_username, updated_at)
+		VALUES (?, ?, ?, ?, ?, `+sqlNow+`)
+		ON CONFLICT(user_id) DO UPDATE SET
+			name = excluded.name,
+			pseudonym = excluded.pseudonym,
+			bio = excluded.bio,
+			x_username = excluded.x_username,
+			updated_at = excluded.updated_at
+	`, identity.UserID, identity.Name, identity.Pseudonym, identity.Bio, identity.XUsername)
+	if err != nil {
+		return fmt.Errorf("failed to update user identity: %w", err)
+	}
+	return nil
+}
+
+// GetPersonaIdentities retrieves the stored profile identity of each account in a persona
+// other than ghost users
+func (s *storage) GetPersonaIdentities(ctx context.Context, slug string) ([]*UserIdentity, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT u.id, u.username, i.name, i.pseudonym, i.bio, i.x_username, i.updated_at
+		FROM users u
+		JOIN personas p ON u.persona_id = p.id
+		JOIN user_identities i ON i.user_id = u.id
+		WHERE p.slug = ?
+		AND u.ghost = 0
+		ORDER BY u.username
+	`, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persona identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := make([]*UserIdentity, 0)
+	for rows.Next() {
+		var identity UserIdentity
+		if err := rows.Scan(
+			&identity.UserID, &identity.Username, &identity.Name, &identity.Pseudonym,
+			&identity.Bio, &identity.XUsername, &identity.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan persona identity: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating persona identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// GetUserPersonaInfo retrieves persona info for a user
+func (s *storage) GetUserPersonaInfo(ctx context.Context, userID int64) (*PersonaInfo, error) {
+	var info PersonaInfo
+	err := s.reader.QueryRowContext(ctx, `
+		SELECT p.slug, p.display_name
+		FROM personas p
+		JOIN users u ON u.persona_id = p.id
+		WHERE u.id = ?
+	`, userID).Scan(&info.Slug, &info.DisplayName)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // User has no persona
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user persona info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// UpdateUserProfileImage updates a user's profile image
+func (s *storage) UpdateUserProfileImage(ctx context.Context, userID int64, profileImage string) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE users SET profile_image = ? WHERE id = ?",
+		profileImage, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user profile image: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserOfficialPnl updates a user's official PnL and volume from Polymarket.
+// The change is also appended to official_pnl_history when it differs from the last recorded value.
+func (s *storage) UpdateUserOfficialPnl(ctx context.Context, userID int64, pnl, volume float64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setUserOfficialPnl(ctx, tx, userID, pnl, volume); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAddressOfficialPnl records the official PnL and volume of one of a user's addresses,
+// and sets the user's official numbers to the sum over their addresses. Addresses whose
+// official PnL was never fetched are left out of the sum. Returns the user's new totals.
+func (s *storage) UpdateAddressOfficialPnl(ctx context.Context, userID int64, address string, pnl, volume float64) (float64, float64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE addresses SET official_pnl = ?, official_volume = ?, official_updated_at = `+sqlNow+`
+		WHERE user_id = ? AND address = ?
+	`, pnl, volume, userID, address)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to update address official pnl: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return 0, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	} else if n == 0 {
+		return 0, 0, fmt.Errorf("address %w: %s", ErrNotFound, address)
+	}
+
+	totalPnl, totalVolume, _, err := sumAddressOfficialPnl(ctx, tx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := setUserOfficialPnl(ctx, tx, userID, totalPnl, totalVolume); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return totalPnl, totalVolume, nil
+}
+
+// sumAddressOfficialPnl sums the official PnL and volume of a user's addresses within a
+// transaction, reporting whether any address has them
+func sumAddressOfficialPnl(ctx context.Context, tx *sql.Tx, userID int64) (float64, float64, bool, error) {
+	var (
+		known       int
+		pnl, volume float64
+	)
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(official_pnl), 0), COALESCE(SUM(official_volume), 0)
+		FROM addresses
+		WHERE user_id = ? AND official_pnl IS NOT NULL
+	`, userID).Scan(&known, &pnl, &volume); err != nil {
+		return 0, 0, false, fmt.Errorf("failed to sum address official pnl: %w", err)
+	}
+	return pnl, volume, known > 0, nil
+}
+
+// setUserOfficialPnl sets a user's official PnL and volume within a transaction, appending to
+// official_pnl_history when they differ from the last recorded values
+func setUserOfficialPnl(ctx context.Context, tx *sql.Tx, userID int64, pnl, volume float64) error {
+	_, err := tx.ExecContext(ctx,
 		"UPDATE users SET official_pnl = ?, official_volume = ? WHERE id = ?",
 		pnl, volume, userID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update user official pnl: %w", err)
+		return fmt.Errorf("failed to update user official pnl: %w", err)
+	}
+
+	// Only record a history row when the value actually moved
+	var lastPnl, lastVolume sql.NullFloat64
+	err = tx.QueryRowContext(ctx, `
+		SELECT pnl, volume
+		FROM official_pnl_history
+		WHERE user_id = ?
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1
+	`, userID).Scan(&lastPnl, &lastVolume)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get last official pnl: %w", err)
+	}
+
+	if err == sql.ErrNoRows || lastPnl.Float64 != pnl || lastVolume.Float64 != volume {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO official_pnl_history (user_id, timestamp, pnl, volume)
+			VALUES (?, ?, ?, ?)
+		`, userID, formatTimestamp(time.Now()), pnl, volume)
+		if err != nil {
+			return fmt.Errorf("failed to insert official pnl history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetUserOfficialPnlHistory retrieves the official PnL change history for a user
+func (s *storage) GetUserOfficialPnlHistory(ctx context.Context, userID int64, start, end *time.Time) ([]*OfficialPnlSnapshot, error) {
+	query := `
+		SELECT id, user_id, timestamp, pnl, volume
+		FROM official_pnl_history
+		WHERE user_id = ?
+	`
+	args := []any{userID}
+
+	if start != nil {
+		query += " AND timestamp >= ?"
+		args = append(args, formatTimestamp(*start))
+	}
+	if end != nil {
+		query += " AND timestamp <= ?"
+		args = append(args, formatTimestamp(*end))
+	}
+
+	query += " ORDER BY timestamp ASC"
+
+	rows, err := s.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query official pnl history: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := make([]*OfficialPnlSnapshot, 0)
+	for rows.Next() {
+		var snapshot OfficialPnlSnapshot
+		if err := rows.Scan(&snapshot.ID, &snapshot.UserID, &snapshot.Timestamp, &snapshot.Pnl, &snapshot.Volume); err != nil {
+			return nil, fmt.Errorf("failed to scan official pnl snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating official pnl history: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// CreatePersonaWithImage creates a new persona with an image
+func (s *storage) CreatePersonaWithImage(ctx context.Context, slug, displayName, image string) (*Persona, error) {
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO personas (slug, display_name, image, created_at) VALUES (?, ?, ?, "+sqlNow+")",
+		slug, displayName, image,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert persona: %w", conflictError(err))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persona id: %w", err)
+	}
+
+	return &Persona{
+		ID:          id,
+		Slug:        slug,
+		DisplayName: displayName,
+		Image:       &image,
+	}, nil
+}
+
+// UpdatePersonaImage updates a persona's image
+func (s *storage) UpdatePersonaImage(ctx context.Context, personaID int64, image string) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE personas SET image = ? WHERE id = ?",
+		image, personaID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update persona image: %w", err)
+	}
+	return nil
+}
+
+// UpdatePersonaDisplayName updates the display name of a persona
+func (s *storage) UpdatePersonaDisplayName(ctx context.Context, personaID int64, displayName string) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE personas SET display_name = ? WHERE id = ?",
+		displayName, personaID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update persona display name: %w", err)
+	}
+	return nil
+}
+
+// DeletePersona deletes a persona, leaving its users tracked without a persona
+func (s *storage) DeletePersona(ctx context.Context, personaID int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET persona_id = NULL WHERE persona_id = ?", personaID); err != nil {
+		return fmt.Errorf("failed to detach persona users: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM persona_tokens WHERE persona_id = ?", personaID); err != nil {
+		return fmt.Errorf("failed to delete persona tokens: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM personas WHERE id = ?", personaID); err != nil {
+		return fmt.Errorf("failed to delete persona: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetUserResults retrieves resolved positions (results) for a user
+// A position is considered "resolved" if:
+// 1. The position has realized PnL (position was closed/exited)
+// 2. The market has ended (end_date has passed)
+func (s *storage) GetUserResults(ctx context.Context, userID int64, limit, offset int) ([]*Result, int, error) {
+	// Get total count of resolved positions
+	var total int
+	err := s.reader.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT condition_id)
+		FROM positions
+		WHERE user_id = ?
+		AND realized_pnl IS NOT NULL
+	`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count results: %w", err)
+	}
+
+	// Get results with pagination
+	// We group by condition_id to avoid duplicates and sum realized_pnl across all positions for that market
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT
+			MIN(p.id) as id,
+			p.user_id,
+			p.condition_id,
+			`+marketTitleColumnsSQL("p")+`,
+			p.outcome,
+			COALESCE(SUM(p.realized_pnl), 0) as realized_pnl,
+			SUM(p.initial_value) as initial_value,
+			`+marketColumnSQL("p", "end_date", "end_date")+` as end_date,
+			MAX(p.updated_at) as resolution_date
+		FROM positions p
+		WHERE p.user_id = ?
+		AND p.realized_pnl IS NOT NULL
+		GROUP BY p.condition_id, p.user_id
+		ORDER BY p.updated_at DESC
+		LIMIT ? OFFSET ?
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*Result, 0, limit)
+	for rows.Next() {
+		var result Result
+		var endDateStr, resolutionDateStr sql.NullString
+		if err := rows.Scan(
+			&result.ID,
+			&result.UserID,
+			&result.ConditionID,
+			&result.MarketTitle,
+			&result.MarketDisplayTitle,
+			&result.MarketSlug,
+			&result.Outcome,
+			&result.RealizedPnl,
+			&result.InitialValue,
+			&endDateStr,
+			&resolutionDateStr,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan result: %w", err)
+		}
+		result.EndDate = parseNullTimestamp(endDateStr)
+		result.ResolutionDate = parseNullTimestamp(resolutionDateStr)
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating results: %w", err)
+	}
+	rows.Close()
+
+	for _, r := range results {
+		if err := s.attachSettlement(ctx, r); err != nil {
+			return nil, 0, err
+		}
+		if err := s.attachPriceSeries(ctx, r); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return results, total, nil
+}
+
+// GetPersonaResults retrieves resolved positions (results) across all accounts for a persona.
+// Ghost users' results are left out unless includeGhosts is set.
+func (s *storage) GetPersonaResults(ctx context.Context, slug string, limit, offset int, sortBy, sortDirection string, includeGhosts bool) ([]*ResultWithUsername, int, error) {
+	persona, err := s.GetPersona(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ghosts := "AND u.ghost = 0"
+	if includeGhosts {
+		ghosts = ""
+	}
+
+	// Get total count
+	var total int
+	err = s.reader.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT p.condition_id)
+		FROM positions p
+		JOIN users u ON p.user_id = u.id
+		WHERE u.persona_id = ?
+		AND p.realized_pnl IS NOT NULL
+		`+ghosts, persona.ID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count persona results: %w", err)
+	}
+
+	// Get results
+	orderBy := sortOrderClause(personaResultSortColumns, "resolutionDate", sortBy, sortDirection, "id")
+
+	rows, err := s.reader.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			MIN(p.id) as id,
+			p.user_id,
+			p.condition_id,
+			%s,
+			p.outcome,
+			COALESCE(SUM(p.realized_pnl), 0) as realized_pnl,
+			SUM(p.initial_value) as initial_value,
+			%s as end_date,
+			MAX(p.updated_at) as resolution_date,
+			u.username
+		FROM positions p
+		JOIN users u ON p.user_id = u.id
+		WHERE u.persona_id = ?
+		AND p.realized_pnl IS NOT NULL
+		%s
+		GROUP BY p.condition_id, u.username
+		%s
+		LIMIT ? OFFSET ?
+	`, marketTitleColumnsSQL("p"), marketColumnSQL("p", "end_date", "end_date"), ghosts, orderBy), persona.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query persona results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*ResultWithUsername, 0)
+	for rows.Next() {
+		var result ResultWithUsername
+		var endDateStr, resolutionDateStr sql.NullString
+		if err := rows.Scan(
+			&result.ID,
+			&result.UserID,
+			&result.ConditionID,
+			&result.MarketTitle,
+			&result.MarketDisplayTitle,
+			&result.MarketSlug,
+			&result.Outcome,
+			&result.RealizedPnl,
+			&result.InitialValue,
+			&endDateStr,
+			&resolutionDateStr,
+			&result.Username,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan persona result: %w", err)
+		}
+		result.EndDate = parseNullTimestamp(endDateStr)
+		result.ResolutionDate = parseNullTimestamp(resolutionDateStr)
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating persona results: %w", err)
+	}
+	rows.Close()
+
+	for _, r := range results {
+		if err := s.attachSettlement(ctx, &r.Result); err != nil {
+			return nil, 0, err
+		}
+		if err := s.attachPriceSeries(ctx, &r.Result); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return results, total, nil
+}
+
+// CalculateRealizedPnlFromTrades calculates realized PnL using FIFO cost basis from trade history.
+// This is the source of truth for realized PnL since closed positions are deleted during sync.
+// Returns: realizedPnl, wins, totalClosed, error
+func (s *storage) CalculateRealizedPnlFromTrades(ctx context.Context, userID int64) (float64, int, int, error) {
+	trades, err := s.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	realizedPnl, wins, totalClosed := realizedPnlFIFO(trades)
+
+	return realizedPnl, wins, totalClosed, nil
+}
+
+// realizedPnlFIFO matches chronologically ordered trades with FIFO cost basis.
+// Returns: realizedPnl, wins, totalClosed
+func realizedPnlFIFO(trades []*Trade) (float64, int, int) {
+	disposals, _ := matchFIFO(trades)
+
+	realizedPnl := float64(0)
+	wins := 0
+	losses := 0
+	for _, disposal := range disposals {
+		realizedPnl += disposal.Pnl
+		if disposal.Pnl > 0 {
+			wins++
+		} else if disposal.Pnl < 0 {
+			losses++
+		}
+	}
+
+	return realizedPnl, wins, wins + losses
+}
+
+// matchFIFO matches sells in chronologically ordered trades against earlier buys of the same
+// position, first in first out. Each lot (or part of a lot) consumed by a sell is a disposal.
+// Returns the disposals and the lots still open per position.
+func matchFIFO(trades []*Trade) ([]fifoDisposal, map[fifoKey][]fifoLot) {
+	// FIFO lots per position
+	inventory := make(map[fifoKey][]fifoLot)
+	disposals := make([]fifoDisposal, 0)
+
+	for _, trade := range trades {
+		if trade.ConditionID == nil || trade.Outcome == nil || trade.Side == nil {
+			continue
+		}
+		if trade.Price == nil || trade.Size == nil {
+			continue
+		}
+
+		key := fifoKey{
+			conditionID: *trade.ConditionID,
+			outcome:     *trade.Outcome,
+		}
+
+		price := *trade.Price
+		size := *trade.Size
+
+		if *trade.Side == "BUY" {
+			// Add to inventory
+			inventory[key] = append(inventory[key], fifoLot{
+				Shares:   size,
+				Price:    price,
+				OpenedAt: trade.Timestamp,
+				TradeID:  trade.TradeID,
+			})
+		} else if *trade.Side == "SELL" {
+			// Match against FIFO lots and realize PnL
+			lots := inventory[key]
+			remainingToSell := size
+
+			for remainingToSell > 0 && len(lots) > 0 {
+				lot := &lots[0]
+				shares := min(lot.Shares, remainingToSell)
+
+				disposals = append(disposals, fifoDisposal{
+					Shares:       shares,
+					Pnl:          shares*price - shares*lot.Price,
+					Hold:         holdDuration(lot.OpenedAt, trade.Timestamp),
+					ConditionID:  key.conditionID,
+					Outcome:      key.outcome,
+					OpenPrice:    lot.Price,
+					ClosePrice:   price,
+					OpenedAt:     lot.OpenedAt,
+					ClosedAt:     trade.Timestamp,
+					OpenTradeID:  lot.TradeID,
+					CloseTradeID: trade.TradeID,
+				})
+
+				if lot.Shares <= remainingToSell {
+					// Consume entire lot
+					remainingToSell -= lot.Shares
+					lots = lots[1:] // Remove consumed lot
+				} else {
+					// Partial lot consumption
+					lot.Shares -= remainingToSell
+					remainingToSell = 0
+				}
+			}
+			inventory[key] = lots
+		}
+	}
+
+	return disposals, inventory
+}
+
+// holdDuration returns how long a lot was held, or nil if either time is unknown
+func holdDuration(openedAt, closedAt *time.Time) *time.Duration {
+	if openedAt == nil || closedAt == nil || closedAt.Before(*openedAt) {
+		return nil
+	}
+	hold := closedAt.Sub(*openedAt)
+	return &hold
+}
+
+// GetClosedHoldings replays a user's trades to find which of tradeIDs closed a position, with
+// the FIFO PnL realized over the holding each one closed
+func (s *storage) GetClosedHoldings(ctx context.Context, userID int64, tradeIDs []int64) ([]*ClosedHolding, error) {
+	if len(tradeIDs) == 0 {
+		return nil, nil
+	}
+
+	trades, err := s.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int64]bool, len(tradeIDs))
+	for _, id := range tradeIDs {
+		wanted[id] = true
+	}
+
+	// Trades of each position since it was last opened, so a holding's PnL leaves out earlier ones
+	changes := positionChanges(trades)
+	holdings := make(map[fifoKey][]*Trade)
+	var closed []*ClosedHolding
+	for _, trade := range trades {
+		change, ok := changes[trade.ID]
+		if !ok {
+			continue
+		}
+
+		key := fifoKey{conditionID: *trade.ConditionID, outcome: *trade.Outcome}
+		if change == PositionChangeOpen {
+			holdings[key] = nil
+		}
+		holdings[key] = append(holdings[key], trade)
+
+		if change != PositionChangeClose {
+			continue
+		}
+		if wanted[trade.ID] {
+			realizedPnl, _, _ := realizedPnlFIFO(holdings[key])
+			closed = append(closed, &ClosedHolding{Trade: trade, RealizedPnl: realizedPnl})
+		}
+		delete(holdings, key)
+	}
+
+	return closed, nil
+}
+
+// RebuildUserPositions replaces a user's positions with ones reconstructed from their trades,
+// for when only the trades survive. Each outcome traded at an address is replayed FIFO, with
+// lots held to a captured settlement closed at it. Trades don't record the outcome's token, so
+// rebuilt positions are keyed by outcome until the next sync replaces them with Polymarket's.
+// Prices are those of the last trade. Returns the number of positions stored.
+func (s *storage) RebuildUserPositions(ctx context.Context, userID int64) (int, error) {
+	trades, err := s.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	byAddress := make(map[string][]*Trade)
+	var addresses []string
+	for _, trade := range trades {
+		if _, ok := byAddress[trade.Address]; !ok {
+			addresses = append(addresses, trade.Address)
+		}
+		byAddress[trade.Address] = append(byAddress[trade.Address], trade)
+	}
+
+	var positions []*Position
+	for _, address := range addresses {
+		rebuilt, err := s.rebuildAddressPositions(ctx, userID, address, byAddress[address])
+		if err != nil {
+			return 0, err
+		}
+		positions = append(positions, rebuilt...)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM positions WHERE user_id = ?", userID); err != nil {
+		return 0, fmt.Errorf("failed to delete positions: %w", err)
+	}
+	if _, err := upsertPositions(ctx, tx, positions); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit rebuilt positions: %w", err)
+	}
+
+	return len(positions), nil
+}
+
+// rebuildAddressPositions reconstructs the positions of one address from its chronologically
+// ordered trades
+func (s *storage) rebuildAddressPositions(ctx context.Context, userID int64, address string, trades []*Trade) ([]*Position, error) {
+	disposals, open := matchFIFO(trades)
+
+	// Lots held to resolution are closed at the settlement when one was captured, leaving open
+	// only the lots still held
+	settled, err := s.settlementDisposals(ctx, userID, open)
+	if err != nil {
+		return nil, err
+	}
+
+	realized := make(map[fifoKey]float64)
+	for _, disposal := range append(disposals, settled...) {
+		realized[fifoKey{conditionID: disposal.ConditionID, outcome: disposal.Outcome}] += disposal.Pnl
+	}
+
+	// The latest trade of each outcome gives its market details and last price
+	latest := make(map[fifoKey]*Trade)
+	var keys []fifoKey
+	for _, trade := range trades {
+		if trade.ConditionID == nil || trade.Outcome == nil || trade.Price == nil {
+			continue
+		}
+		key := fifoKey{conditionID: *trade.ConditionID, outcome: *trade.Outcome}
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		latest[key] = trade
+	}
+
+	positions := make([]*Position, 0, len(keys))
+	for _, key := range keys {
+		trade := latest[key]
+		outcome := key.outcome
+		pos := &Position{
+			UserID:             userID,
+			Address:            address,
+			ConditionID:        key.conditionID,
+			Asset:              outcome,
+			MarketTitle:        trade.MarketTitle,
+			MarketDisplayTitle: trade.MarketDisplayTitle,
+			MarketSlug:         trade.MarketSlug,
+			Outcome:            &outcome,
+		}
+
+		var size, cost float64
+		for _, lot := range open[key] {
+			size += lot.Shares
+			cost += lot.Shares * lot.Price
+		}
+		pos.Size = &size
+		pos.InitialValue = &cost
+		if size > positionEpsilon {
+			avgPrice := cost / size
+			currentValue := size * *trade.Price
+			unrealized := currentValue - cost
+			pos.AvgPrice = &avgPrice
+			pos.CurrentPrice = trade.Price
+			pos.CurrentValue = &currentValue
+			pos.UnrealizedPnl = &unrealized
+			if cost > 0 {
+				percent := unrealized / cost * 100
+				pos.UnrealizedPnlPercent = &percent
+			}
+		}
+		if pnl, ok := realized[key]; ok {
+			pos.RealizedPnl = &pnl
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions, nil
+}
+
+// positionEpsilon is the share count at or below which a position counts as closed, absorbing
+// rounding left over by partial sells
+const positionEpsilon = 1e-6
+
+// positionChanges classifies chronologically ordered trades against the running position in
+// their market outcome, by trade ID. A sell of at least what is held closes the position, and
+// a sell of a position never bought isn't classified since its buys predate the history.
+func positionChanges(trades []*Trade) map[int64]string {
+	held := make(map[fifoKey]float64)
+	changes := make(map[int64]string, len(trades))
+
+	for _, trade := range trades {
+		if trade.ConditionID == nil || trade.Outcome == nil || trade.Side == nil || trade.Size == nil {
+			continue
+		}
+
+		key := fifoKey{
+			conditionID: *trade.ConditionID,
+			outcome:     *trade.Outcome,
+		}
+		size := *trade.Size
+
+		switch *trade.Side {
+		case "BUY":
+			if held[key] > positionEpsilon {
+				changes[trade.ID] = PositionChangeAdd
+			} else {
+				changes[trade.ID] = PositionChangeOpen
+			}
+			held[key] += size
+		case "SELL":
+			if held[key] <= positionEpsilon {
+				continue
+			}
+			if size >= held[key]-positionEpsilon {
+				changes[trade.ID] = PositionChangeClose
+				held[key] = 0
+			} else {
+				changes[trade.ID] = PositionChangeTrim
+				held[key] -= size
+			}
+		}
+	}
+
+	return changes
+}
+
+// queryExecer is satisfied by both the database and a transaction
+type queryExecer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// classifyTrades stores the position change of each of a user's trades, only those in one
+// market when conditionID is given, and returns how many changed. A trade stored out of order
+// or removed upstream can change the classification of every later trade in its market.
+func classifyTrades(ctx context.Context, db queryExecer, userID int64, conditionID *string) (int, error) {
+	query := `
+		SELECT id, condition_id, outcome, side, size, position_change
+		FROM trades
+		WHERE user_id = ?
+		AND removed_at IS NULL`
+	args := []any{userID}
+	if conditionID != nil {
+		query += " AND condition_id = ?"
+		args = append(args, *conditionID)
+	}
+	query += " ORDER BY timestamp ASC, id ASC"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query trades to classify: %w", err)
+	}
+
+	trades := make([]*Trade, 0)
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(
+			&trade.ID, &trade.ConditionID, &trade.Outcome, &trade.Side, &trade.Size, &trade.PositionChange,
+		); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan trade to classify: %w", err)
+		}
+		trades = append(trades, &trade)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("error iterating trades to classify: %w", err)
+	}
+	// Writes share the single connection, so the rows must be closed first
+	rows.Close()
+
+	changes := positionChanges(trades)
+
+	updated := 0
+	for _, trade := range trades {
+		var change *string
+		if c, ok := changes[trade.ID]; ok {
+			change = &c
+		}
+		if (change == nil && trade.PositionChange == nil) ||
+			(change != nil && trade.PositionChange != nil && *change == *trade.PositionChange) {
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx,
+			"UPDATE trades SET position_change = ? WHERE id = ?",
+			change, trade.ID,
+		); err != nil {
+			return 0, fmt.Errorf("failed to update trade position change: %w", err)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// ClassifyTrades stores how each of a user's trades moved its position, returning how many
+// classifications changed
+func (s *storage) ClassifyTrades(ctx context.Context, userID int64) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updated, err := classifyTrades(ctx, tx, userID, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit trade classification: %w", err)
+	}
+
+	return updated, nil
+}
+
+// settlementDisposals disposes of lots that were never sold at their market's captured settlement
+func (s *storage) settlementDisposals(ctx context.Context, userID int64, open map[fifoKey][]fifoLot) ([]fifoDisposal, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT condition_id, outcome, settlement_price, resolved_at
+		FROM position_settlements
+		WHERE user_id = ?
+		AND outcome IS NOT NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query position settlements: %w", err)
+	}
+	defer rows.Close()
+
+	disposals := make([]fifoDisposal, 0)
+	for rows.Next() {
+		var key fifoKey
+		var settlementPrice float64
+		var resolvedAt time.Time
+		if err := rows.Scan(&key.conditionID, &key.outcome, &settlementPrice, &resolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan position settlement: %w", err)
+		}
+
+		for _, lot := range open[key] {
+			disposals = append(disposals, fifoDisposal{
+				Shares:      lot.Shares,
+				Pnl:         (settlementPrice - lot.Price) * lot.Shares,
+				Hold:        holdDuration(lot.OpenedAt, &resolvedAt),
+				ConditionID: key.conditionID,
+				Outcome:     key.outcome,
+				OpenPrice:   lot.Price,
+				ClosePrice:  settlementPrice,
+				OpenedAt:    lot.OpenedAt,
+				ClosedAt:    &resolvedAt,
+				OpenTradeID: lot.TradeID,
+			})
+		}
+		// Addresses of the same user can settle the same position; only dispose of the lots once
+		delete(open, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating position settlements: %w", err)
+	}
+
+	return disposals, nil
+}
+
+// holdDisposals returns every disposal in a user's trade history, including lots held to resolution
+func (s *storage) holdDisposals(ctx context.Context, userID int64, trades []*Trade) ([]fifoDisposal, error) {
+	disposals, open := matchFIFO(trades)
+
+	settled, err := s.settlementDisposals(ctx, userID, open)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(disposals, settled...), nil
+}
+
+// GetUserHoldTimeStats summarizes how long a user holds positions before selling or resolution
+func (s *storage) GetUserHoldTimeStats(ctx context.Context, userID int64) (*HoldTimeStats, error) {
+	trades, err := s.GetUserTradesChronological(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	disposals, err := s.holdDisposals(ctx, userID, trades)
+	if err != nil {
+		return nil, err
+	}
+
+	return holdTimeStats(disposals), nil
+}
+
+// GetUserEntryTiming summarizes how close to market end dates a user enters positions
+func (s *storage) GetUserEntryTiming(ctx context.Context, userID int64) (*EntryTimingStats, error) {
+	return s.entryTiming(ctx, []int64{userID})
+}
+
+// entryTiming summarizes how long before the end date of their markets users' buys were
+// entered. A market's end date is taken from any stored position in it.
+func (s *storage) entryTiming(ctx context.Context, userIDs []int64) (*EntryTimingStats, error) {
+	stats := &EntryTimingStats{}
+	if len(userIDs) == 0 {
+		return stats, nil
+	}
+
+	args := make([]any, len(userIDs))
+	for i, id := range userIDs {
+		args[i] = id
+	}
+
+	var avgDays sql.NullFloat64
+	if err := s.reader.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+			SUM(t.price * t.size * MAX(julianday(m.end_date) - julianday(t.timestamp), 0)) / SUM(t.price * t.size)
+		FROM trades t
+		JOIN (
+			SELECT condition_id, MAX(end_date) AS end_date FROM positions
+			WHERE end_date IS NOT NULL
+			GROUP BY condition_id
+		) m ON m.condition_id = t.condition_id
+		WHERE t.user_id IN (`+placeholders(len(userIDs))+`)
+		AND t.side = 'BUY' AND t.removed_at IS NULL
+		AND t.price IS NOT NULL AND t.size IS NOT NULL
+	`, args...).Scan(&stats.Entries, &avgDays); err != nil {
+		return nil, fmt.Errorf("failed to get entry timing: %w", err)
+	}
+	if avgDays.Valid {
+		stats.AvgDaysBeforeEnd = &avgDays.Float64
+	}
+
+	return stats, nil
+}
+
+// GetUserResultDetail expands a user's result in one market into its trades, FIFO-matched
+// lots and resolution. Lots still held when the market resolved are closed at the captured
+// settlement price.
+func (s *storage) GetUserResultDetail(ctx context.Context, userID int64, conditionID string) (*ResultDetail, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT `+tradeColumns+`
+		FROM trades t
+		WHERE t.user_id = ? AND t.condition_id = ?
+		AND t.removed_at IS NULL
+		ORDER BY t.timestamp ASC, t.id ASC
+	`, userID, conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+
+	trades := make([]*Trade, 0)
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(tradeScanDest(&trade)...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, &trade)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating trades: %w", err)
+	}
+	rows.Close()
+
+	settlements, err := s.getMarketSettlements(ctx, userID, conditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(trades) == 0 && len(settlements) == 0 {
+		return nil, fmt.Errorf("result %w: %s", ErrNotFound, conditionID)
+	}
+
+	detail := &ResultDetail{
+		ConditionID: conditionID,
+		Trades:      trades,
+		Lots:        make([]*ResultLot, 0),
+		Settlements: settlements,
+	}
+	for _, trade := range trades {
+		if detail.MarketTitle == nil {
+			detail.MarketTitle = trade.MarketTitle
+		}
+		if detail.MarketSlug == nil {
+			detail.MarketSlug = trade.MarketSlug
+		}
+	}
+	for _, settlement := range settlements {
+		if detail.MarketTitle == nil {
+			detail.MarketTitle = settlement.MarketTitle
+		}
+		if detail.MarketSlug == nil {
+			detail.MarketSlug = settlement.MarketSlug
+		}
+	}
+
+	disposals, open := matchFIFO(trades)
+	settled, err := s.settlementDisposals(ctx, userID, open)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, disposal := range append(disposals, settled...) {
+		detail.Lots = append(detail.Lots, &ResultLot{
+			Outcome:      disposal.Outcome,
+			Shares:       disposal.Shares,
+			OpenPrice:    disposal.OpenPrice,
+			ClosePrice:   disposal.ClosePrice,
+			Pnl:          disposal.Pnl,
+			OpenedAt:     disposal.OpenedAt,
+			ClosedAt:     disposal.ClosedAt,
+			Hold:         disposal.Hold,
+			OpenTradeID:  disposal.OpenTradeID,
+			CloseTradeID: disposal.CloseTradeID,
+			Settled:      i >= len(disposals),
+		})
+		detail.RealizedPnl += disposal.Pnl
+	}
+
+	// settlementDisposals removes the settled positions, leaving lots that are still held
+	for _, lots := range open {
+		for _, lot := range lots {
+			detail.OpenShares += lot.Shares
+		}
+	}
+
+	return detail, nil
+}
+
+// getMarketSettlements returns the captured settlements of a user's positions in one market
+func (s *storage) getMarketSettlements(ctx context.Context, userID int64, conditionID string) ([]*PositionSettlement, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT user_id, address, condition_id, asset, outcome, market_title, market_slug,
+			size, avg_price, settlement_price, pnl, resolved_at
+		FROM position_settlements
+		WHERE user_id = ? AND condition_id = ?
+		ORDER BY address, asset
+	`, userID, conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query position settlements: %w", err)
+	}
+	defer rows.Close()
+
+	settlements := make([]*PositionSettlement, 0)
+	for rows.Next() {
+		var st PositionSettlement
+		if err := rows.Scan(
+			&st.UserID, &st.Address, &st.ConditionID, &st.Asset, &st.Outcome, &st.MarketTitle, &st.MarketSlug,
+			&st.Size, &st.AvgPrice, &st.SettlementPrice, &st.Pnl, &st.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan position settlement: %w", err)
+		}
+		settlements = append(settlements, &st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating position settlements: %w", err)
+	}
+
+	return settlements, nil
+}
+
+// holdTimeBuckets are the upper bounds of the hold time distribution buckets
+var holdTimeBuckets = []struct {
+	label string
+	max   time.Duration
+}{
+	{"<1h", time.Hour},
+	{"1h-1d", 24 * time.Hour},
+	{"1d-1w", 7 * 24 * time.Hour},
+	{"1w-1m", 30 * 24 * time.Hour},
+	{">1m", 0},
+}
+
+// holdTimeStats aggregates disposals with a known hold time
+func holdTimeStats(disposals []fifoDisposal) *HoldTimeStats {
+	stats := &HoldTimeStats{
+		Buckets: make([]*HoldTimeBucket, len(holdTimeBuckets)),
+	}
+	for i, bucket := range holdTimeBuckets {
+		stats.Buckets[i] = &HoldTimeBucket{Label: bucket.label}
+	}
+
+	holds := make([]float64, 0, len(disposals))
+	var weightedHours, shares float64
+	for _, disposal := range disposals {
+		if disposal.Hold == nil {
+			continue
+		}
+
+		hours := disposal.Hold.Hours()
+		holds = append(holds, hours)
+		weightedHours += hours * disposal.Shares
+		shares += disposal.Shares
+
+		for i, bucket := range holdTimeBuckets {
+			if bucket.max == 0 || *disposal.Hold < bucket.max {
+				stats.Buckets[i].Disposals++
+				stats.Buckets[i].Shares += disposal.Shares
+				break
+			}
+		}
+	}
+
+	stats.Disposals = len(holds)
+	if len(holds) == 0 {
+		return stats
+	}
+
+	sort.Float64s(holds)
+	median := holds[len(holds)/2]
+	if len(holds)%2 == 0 {
+		median = (holds[len(holds)/2-1] + holds[len(holds)/2]) / 2
+	}
+	stats.MedianHours = &median
+
+	if shares > 0 {
+		mean := weightedHours / shares
+		stats.MeanHours = &mean
+	}
+
+	return stats
+}
+
+// RecordSyncError inserts a sync error and prunes the user's history down to the most recent keep entries
+func (s *storage) RecordSyncError(ctx context.Context, syncErr *SyncError, keep int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sync_errors (user_id, address, phase, message, timestamp)
+		VALUES (?, ?, ?, ?, ?)
+	`, syncErr.UserID, syncErr.Address, syncErr.Phase, syncErr.Message, formatTimestamp(syncErr.Timestamp))
+	if err != nil {
+		return fmt.Errorf("failed to insert sync error: %w", err)
+	}
+
+	// Only retain the most recent errors per user
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM sync_errors
+		WHERE user_id = ?
+		AND id NOT IN (
+			SELECT id FROM sync_errors
+			WHERE user_id = ?
+			ORDER BY timestamp DESC, id DESC
+			LIMIT ?
+		)
+	`, syncErr.UserID, syncErr.UserID, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune sync errors: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSyncErrors retrieves the most recent sync errors for a user, newest first
+func (s *storage) GetUserSyncErrors(ctx context.Context, userID int64, limit int) ([]*SyncError, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT id, user_id, address, phase, message, timestamp
+		FROM sync_errors
+		WHERE user_id = ?
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ?
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync errors: %w", err)
+	}
+	defer rows.Close()
+
+	syncErrors := make([]*SyncError, 0)
+	for rows.Next() {
+		var syncErr SyncError
+		if err := rows.Scan(
+			&syncErr.ID, &syncErr.UserID, &syncErr.Address,
+			&syncErr.Phase, &syncErr.Message, &syncErr.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sync error: %w", err)
+		}
+		syncErrors = append(syncErrors, &syncErr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync errors: %w", err)
+	}
+
+	return syncErrors, nil
+}
+
+// RecordSyncRun inserts a sync run with its per-user breakdown and prunes the history down to
+// the most recent keep runs
+func (s *storage) RecordSyncRun(ctx context.Context, run *SyncRun, keep int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO sync_runs (trigger, started_at, finished_at, duration_ms, users, deferred_users, api_calls, rows_written, errors)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, run.Trigger, formatTimestamp(run.StartedAt), formatTimestamp(run.FinishedAt), run.Duration.Milliseconds(),
+		run.Users, run.DeferredUsers, run.APICalls, run.RowsWritten, run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to insert sync run: %w", err)
+	}
+
+	run.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get sync run id: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO sync_run_users (run_id, username, started_at, duration_ms, api_calls, rows_written, errors, failure)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare sync run user insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, userRun := range run.UserRuns {
+		userRun.RunID = run.ID
+		if _, err := stmt.ExecContext(ctx,
+			userRun.RunID, userRun.Username, formatTimestamp(userRun.StartedAt), userRun.Duration.Milliseconds(),
+			userRun.APICalls, userRun.RowsWritten, userRun.Errors, userRun.Failure,
+		); err != nil {
+			return fmt.Errorf("failed to insert sync run user: %w", err)
+		}
+	}
+
+	// Only retain the most recent runs
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM sync_run_users
+		WHERE run_id NOT IN (SELECT id FROM sync_runs ORDER BY id DESC LIMIT ?)
+	`, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune sync run users: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM sync_runs
+		WHERE id NOT IN (SELECT id FROM sync_runs ORDER BY id DESC LIMIT ?)
+	`, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune sync runs: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetSyncRuns retrieves the most recent sync runs with their per-user breakdown, newest first
+func (s *storage) GetSyncRuns(ctx context.Context, limit int) ([]*SyncRun, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT id, trigger, started_at, finished_at, duration_ms, users, deferred_users, api_calls, rows_written, errors
+		FROM sync_runs
+		ORDER BY id DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]*SyncRun, 0)
+	byID := make(map[int64]*SyncRun)
+	for rows.Next() {
+		run := SyncRun{UserRuns: make([]*SyncRunUser, 0)}
+		var durationMs int64
+		if err := rows.Scan(
+			&run.ID, &run.Trigger, &run.StartedAt, &run.FinishedAt, &durationMs,
+			&run.Users, &run.DeferredUsers, &run.APICalls, &run.RowsWritten, &run.Errors,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sync run: %w", err)
+		}
+		run.Duration = time.Duration(durationMs) * time.Millisecond
+		runs = append(runs, &run)
+		byID[run.ID] = &run
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync runs: %w", err)
+	}
+
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	ids := make([]any, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, run.ID)
+	}
+
+	userRows, err := s.reader.QueryContext(ctx, `
+		SELECT id, run_id, username, started_at, duration_ms, api_calls, rows_written, errors, failure
+		FROM sync_run_users
+		WHERE run_id IN (`+placeholders(len(ids))+`)
+		ORDER BY run_id DESC, id ASC
+	`, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync run users: %w", err)
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var userRun SyncRunUser
+		var durationMs int64
+		if err := userRows.Scan(
+			&userRun.ID, &userRun.RunID, &userRun.Username, &userRun.StartedAt, &durationMs,
+			&userRun.APICalls, &userRun.RowsWritten, &userRun.Errors, &userRun.Failure,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sync run user: %w", err)
+		}
+		userRun.Duration = time.Duration(durationMs) * time.Millisecond
+
+		if run, ok := byID[userRun.RunID]; ok {
+			run.UserRuns = append(run.UserRuns, &userRun)
+		}
+	}
+
+	if err := userRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync run users: %w", err)
+	}
+
+	return runs, nil
+}
+
+// AcquireLease takes or renews the named lease for holder until ttl from now. It succeeds
+// when the lease is free, expired or already held by holder.
+func (s *storage) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			holder = excluded.holder,
+			expires_at = excluded.expires_at
+		WHERE leases.holder = excluded.holder OR leases.expires_at <= ?
+	`, name, holder, now.Add(ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ReleaseLease gives up the named lease if holder still holds it
+func (s *storage) ReleaseLease(ctx context.Context, name, holder string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM leases WHERE name = ? AND holder = ?", name, holder); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
+
+// AwardBadge records a badge for a user, reporting whether it is new. A badge the user
+// already holds is left unchanged.
+func (s *storage) AwardBadge(ctx context.Context, badge *UserBadge) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO user_badges (user_id, badge, awarded_at, detail, created_at)
+		VALUES (?, ?, ?, ?, `+sqlNow+`)
+		ON CONFLICT(user_id, badge) DO NOTHING
+	`, badge.UserID, badge.Badge, formatTimestamp(badge.AwardedAt), badge.Detail)
+	if err != nil {
+		return false, fmt.Errorf("failed to award badge: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("failed to get badge id: %w", err)
+	}
+	badge.ID = id
+
+	return true, nil
+}
+
+// GetUserBadges retrieves the badges a user has been awarded, oldest first
+func (s *storage) GetUserBadges(ctx context.Context, userID int64) ([]*UserBadge, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT id, user_id, badge, awarded_at, detail, created_at
+		FROM user_badges
+		WHERE user_id = ?
+		ORDER BY awarded_at ASC, id ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query badges: %w", err)
+	}
+	defer rows.Close()
+
+	badges := make([]*UserBadge, 0)
+	for rows.Next() {
+		var badge UserBadge
+		if err := rows.Scan(&badge.ID, &badge.UserID, &badge.Badge, &badge.AwardedAt, &badge.Detail, &badge.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan badge: %w", err)
+		}
+		badges = append(badges, &badge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating badges: %w", err)
+	}
+
+	return badges, nil
+}
+
+// SaveUserMilestones records a user's milestones, returning those not recorded before. A
+// milestone already recorded under its kind and key is updated in place, such as an all-time
+// high rising again later the same day.
+func (s *storage) SaveUserMilestones(ctx context.Context, userID int64, milestones []*UserMilestone) ([]*UserMilestone, error) {
+	if len(milestones) == 0 {
+		return nil, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT kind, key FROM user_milestones WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query milestones: %w", err)
+	}
+	recorded := make(map[[2]string]bool)
+	for rows.Next() {
+		var kind, key string
+		if err := rows.Scan(&kind, &key); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+		recorded[[2]string{kind, key}] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating milestones: %w", err)
+	}
+
+	added := make([]*UserMilestone, 0)
+	for _, m := range milestones {
+		if recorded[[2]string{m.Kind, m.Key}] {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE user_milestones SET occurred_at = ?, value = ?, detail = ?
+				WHERE user_id = ? AND kind = ? AND key = ?
+			`, formatTimestamp(m.OccurredAt), m.Value, m.Detail, userID, m.Kind, m.Key); err != nil {
+				return nil, fmt.Errorf("failed to update milestone: %w", err)
+			}
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO user_milestones (user_id, kind, key, occurred_at, value, detail, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, `+sqlNow+`)
+		`, userID, m.Kind, m.Key, formatTimestamp(m.OccurredAt), m.Value, m.Detail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert milestone: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get milestone id: %w", err)
+		}
+		m.ID = id
+		m.UserID = userID
+		recorded[[2]string{m.Kind, m.Key}] = true
+		added = append(added, m)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return added, nil
+}
+
+// GetUserMilestones retrieves a user's most recent milestones, newest first. A limit of 0
+// returns all of them.
+func (s *storage) GetUserMilestones(ctx context.Context, userID int64, limit int) ([]*UserMilestone, error) {
+	query := `
+		SELECT id, user_id, kind, key, occurred_at, value, detail, created_at
+		FROM user_milestones
+		WHERE user_id = ?
+		ORDER BY occurred_at DESC, id DESC
+	`
+	args := []any{userID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query milestones: %w", err)
+	}
+	defer rows.Close()
+
+	milestones := make([]*UserMilestone, 0)
+	for rows.Next() {
+		var m UserMilestone
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Kind, &m.Key, &m.OccurredAt, &m.Value, &m.Detail, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+		milestones = append(milestones, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+// RecordRosterChanges appends changes to the roster change log
+func (s *storage) RecordRosterChanges(ctx context.Context, changes []*RosterChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, change := range changes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO roster_changes (action, target, detail, source, created_at)
+			VALUES (?, ?, ?, ?, `+sqlNow+`)
+		`, change.Action, change.Target, change.Detail, change.Source); err != nil {
+			return fmt.Errorf("failed to insert roster change: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit roster changes: %w", err)
+	}
+
+	return nil
+}
+
+// GetRosterChanges returns the roster change log matching filters, newest first, and its total
+// length
+func (s *storage) GetRosterChanges(ctx context.Context, filters RosterChangeFilters) ([]*RosterChange, int, error) {
+	where, args := "WHERE 1 = 1", []any{}
+	if len(filters.ExcludeActions) > 0 {
+		where += " AND action NOT IN (" + placeholders(len(filters.ExcludeActions)) + ")"
+		for _, action := range filters.ExcludeActions {
+			args = append(args, action)
+		}
+	}
+	if filters.ExcludeGhosts {
+		where += " AND target NOT IN (SELECT username FROM users WHERE ghost = 1)"
+	}
+
+	var total int
+	if err := s.reader.QueryRowContext(ctx, "SELECT COUNT(*) FROM roster_changes "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count roster changes: %w", err)
+	}
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT id, action, target, detail, source, created_at
+		FROM roster_changes
+		`+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, append(args, filters.Limit, filters.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query roster changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]*RosterChange, 0, filters.Limit)
+	for rows.Next() {
+		var c RosterChange
+		if err := rows.Scan(&c.ID, &c.Action, &c.Target, &c.Detail, &c.Source, &c.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan roster change: %w", err)
+		}
+		changes = append(changes, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating roster changes: %w", err)
+	}
+
+	return changes, total, nil
+}
+
+// personaTokenColumns are the persona_tokens columns scanned by scanPersonaToken
+const personaTokenColumns = "id, persona_id, name, created_at, last_used_at, revoked_at"
+
+// scanPersonaToken scans a row of personaTokenColumns
+func scanPersonaToken(row interface{ Scan(...any) error }) (*PersonaToken, error) {
+	var token PersonaToken
+	var lastUsedAt, revokedAt sql.NullString
+	if err := row.Scan(&token.ID, &token.PersonaID, &token.Name, &token.CreatedAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	token.LastUsedAt = parseNullTimestamp(lastUsedAt)
+	token.RevokedAt = parseNullTimestamp(revokedAt)
+	return &token, nil
+}
+
+// CreatePersonaToken stores the hash of a new token for a persona
+func (s *storage) CreatePersonaToken(ctx context.Context, personaID int64, name, tokenHash string) (*PersonaToken, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO persona_tokens (persona_id, name, token_hash, created_at) VALUES (?, ?, ?, ?)",
+		personaID, name, tokenHash, formatTimestamp(now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create persona token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persona token id: %w", err)
+	}
+
+	return &PersonaToken{ID: id, PersonaID: personaID, Name: name, CreatedAt: now}, nil
+}
+
+// GetPersonaTokens retrieves a persona's tokens, revoked ones included, oldest first
+func (s *storage) GetPersonaTokens(ctx context.Context, personaID int64) ([]*PersonaToken, error) {
+	rows, err := s.reader.QueryContext(ctx,
+		"SELECT "+personaTokenColumns+" FROM persona_tokens WHERE persona_id = ? ORDER BY id",
+		personaID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persona tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*PersonaToken
+	for rows.Next() {
+		token, err := scanPersonaToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan persona token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating persona tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokePersonaToken revokes one of a persona's tokens. Revoking a revoked token is a no-op.
+func (s *storage) RevokePersonaToken(ctx context.Context, personaID, tokenID int64) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE persona_tokens SET revoked_at = COALESCE(revoked_at, "+sqlNow+") WHERE id = ? AND persona_id = ?",
+		tokenID, personaID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke persona token: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get revoked persona tokens: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("persona token %w: %d", ErrNotFound, tokenID)
+	}
+	return nil
+}
+
+// UsePersonaToken looks up an unrevoked token by its hash and records that it was used
+func (s *storage) UsePersonaToken(ctx context.Context, tokenHash string) (*PersonaToken, error) {
+	token, err := scanPersonaToken(s.db.QueryRowContext(ctx, `
+		UPDATE persona_tokens SET last_used_at = `+sqlNow+`
+		WHERE token_hash = ? AND revoked_at IS NULL
+		RETURNING `+personaTokenColumns,
+		tokenHash,
+	))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("persona token %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to use persona token: %w", err)
+	}
+	return token, nil
+}
+
+// apiKeyColumns are the api_keys columns scanned by scanAPIKey
+const apiKeyColumns = "id, name, scope, created_at, last_used_at, revoked_at"
+
+// scanAPIKey scans a row of apiKeyColumns
+func scanAPIKey(row interface{ Scan(...any) error }) (*APIKey, error) {
+	var key APIKey
+	var lastUsedAt, revokedAt sql.NullString
+	if err := row.Scan(&key.ID, &key.Name, &key.Scope, &key.CreatedAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	key.LastUsedAt = parseNullTimestamp(lastUsedAt)
+	key.RevokedAt = parseNullTimestamp(revokedAt)
+	return &key, nil
+}
+
+// CreateAPIKey stores the hash of a new API key
+func (s *storage) CreateAPIKey(ctx context.Context, name, scope, keyHash string) (*APIKey, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO api_keys (name, scope, key_hash, created_at) VALUES (?, ?, ?, ?)",
+		name, scope, keyHash, formatTimestamp(now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key id: %w", err)
+	}
+
+	return &APIKey{ID: id, Name: name, Scope: scope, CreatedAt: now}, nil
+}
+
+// GetAPIKeys retrieves the stored API keys, revoked ones included, oldest first
+func (s *storage) GetAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	rows, err := s.reader.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key. Revoking a revoked key is a no-op.
+func (s *storage) RevokeAPIKey(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE api_keys SET revoked_at = COALESCE(revoked_at, "+sqlNow+") WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get revoked api keys: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("api key %w: %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// UseAPIKey looks up an unrevoked API key by its hash and records that it was used
+func (s *storage) UseAPIKey(ctx context.Context, keyHash string) (*APIKey, error) {
+	key, err := scanAPIKey(s.db.QueryRowContext(ctx, `
+		UPDATE api_keys SET last_used_at = `+sqlNow+`
+		WHERE key_hash = ? AND revoked_at IS NULL
+		RETURNING `+apiKeyColumns,
+		keyHash,
+	))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("api key %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to use api key: %w", err)
+	}
+	return key, nil
+}
+
+// HasAPIKeys reports whether any unrevoked API key has the scope
+func (s *storage) HasAPIKeys(ctx context.Context, scope string) (bool, error) {
+	var exists bool
+	if err := s.reader.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM api_keys WHERE scope = ? AND revoked_at IS NULL)",
+		scope,
+	).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check api keys: %w", err)
+	}
+	return exists, nil
+}
+
+// ReplaceGlobalLeaderboard replaces the stored global leaderboard with entries
+func (s *storage) ReplaceGlobalLeaderboard(ctx context.Context, entries []*GlobalLeaderboardEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM global_leaderboard"); err != nil {
+		return fmt.Errorf("failed to clear global leaderboard: %w", err)
+	}
+
+	for _, entry := range entries {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO global_leaderboard (rank, address, name, pnl, volume, profile_image, fetched_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, entry.Rank, strings.ToLower(entry.Address), entry.Name, entry.Pnl, entry.Volume, entry.ProfileImage,
+			formatTimestamp(entry.FetchedAt)); err != nil {
+			return fmt.Errorf("failed to insert global leaderboard entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetGlobalLeaderboard retrieves a page of the stored global leaderboard by rank, and the
+// number of entries stored
+func (s *storage) GetGlobalLeaderboard(ctx context.Context, limit, offset int) ([]*GlobalLeaderboardEntry, int, error) {
+	var total int
+	if err := s.reader.QueryRowContext(ctx, "SELECT COUNT(*) FROM global_leaderboard").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count global leaderboard: %w", err)
+	}
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT rank, address, name, pnl, volume, profile_image, fetched_at
+		FROM global_leaderboard
+		ORDER BY rank
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query global leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*GlobalLeaderboardEntry, 0)
+	for rows.Next() {
+		var entry GlobalLeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.Address, &entry.Name, &entry.Pnl, &entry.Volume,
+			&entry.ProfileImage, &entry.FetchedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan global leaderboard entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating global leaderboard: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// GetGlobalRank finds the best rank on the stored global leaderboard held by one of
+// addresses. When none of them is on it, the rank pnl would take among the stored entries is
+// estimated instead. It returns nil when pnl falls below the last stored entry.
+func (s *storage) GetGlobalRank(ctx context.Context, addresses []string, pnl float64) (*GlobalRank, error) {
+	if len(addresses) > 0 {
+		args := make([]any, len(addresses))
+		for i, address := range addresses {
+			args[i] = strings.ToLower(address)
+		}
+
+		var rank sql.NullInt64
+		if err := s.reader.QueryRowContext(ctx,
+			"SELECT MIN(rank) FROM global_leaderboard WHERE address IN ("+placeholders(len(args))+")", args...,
+		).Scan(&rank); err != nil {
+			return nil, fmt.Errorf("failed to query global rank: %w", err)
+		}
+		if rank.Valid {
+			return &GlobalRank{Rank: int(rank.Int64)}, nil
+		}
+	}
+
+	var (
+		above int
+		floor sql.NullFloat64
+	)
+	if err := s.reader.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(CASE WHEN pnl > ? THEN 1 ELSE 0 END), 0), MIN(pnl)
+		FROM global_leaderboard
+	`, pnl).Scan(&above, &floor); err != nil {
+		return nil, fmt.Errorf("failed to estimate global rank: %w", err)
+	}
+	if !floor.Valid || pnl < floor.Float64 {
+		return nil, nil
+	}
+
+	return &GlobalRank{Rank: above + 1, Estimated: true}, nil
+}
+
+// AddReaction posts a reaction on the trade with TradeID, or when TradeID is nil on the result
+// of UserID in ConditionID. It reports false when the trade or result doesn't exist.
+func (s *storage) AddReaction(ctx context.Context, reaction *Reaction) (bool, error) {
+	// The target's user and market are copied from one of its trades, so the insert only
+	// happens when there is one
+	target := "WHERE user_id = ? AND condition_id = ?"
+	args := []any{reaction.UserID, reaction.ConditionID}
+	if reaction.TradeID != nil {
+		target = "WHERE trade_id = ?"
+		args = []any{*reaction.TradeID}
+	}
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO reactions (user_id, trade_id, condition_id, author, emoji, comment, created_at)
+		SELECT user_id, ?, condition_id, ?, ?, ?, ?
+		FROM trades
+		`+target+`
+		AND removed_at IS NULL
+		LIMIT 1
+	`, append([]any{reaction.TradeID, reaction.Author, reaction.Emoji, reaction.Comment, formatTimestamp(createdAt)}, args...)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert reaction: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("failed to get reaction id: %w", err)
+	}
+
+	// Read back the target's user and market
+	err = s.db.QueryRowContext(ctx, `
+		SELECT r.user_id, r.condition_id, u.username
+		FROM reactions r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.id = ?
+	`, id).Scan(&reaction.UserID, &reaction.ConditionID, &reaction.Username)
+	if err != nil {
+		return false, fmt.Errorf("failed to get reaction target: %w", err)
+	}
+	reaction.ID = id
+	reaction.CreatedAt = createdAt
+
+	return true, nil
+}
+
+// GetTradeReactions retrieves the reactions on trades, oldest first, keyed by trade ID
+func (s *storage) GetTradeReactions(ctx context.Context, tradeIDs []string) (map[string][]*Reaction, error) {
+	reactions := make(map[string][]*Reaction)
+	if len(tradeIDs) == 0 {
+		return reactions, nil
+	}
+
+	args := make([]any, 0, len(tradeIDs))
+	for _, id := range tradeIDs {
+		args = append(args, id)
+	}
+
+	found, err := s.queryReactions(ctx, "r.trade_id IN ("+placeholders(len(tradeIDs))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, reaction := range found {
+		reactions[*reaction.TradeID] = append(reactions[*reaction.TradeID], reaction)
+	}
+
+	return reactions, nil
+}
+
+// GetResultReactions retrieves the reactions on a user's result in a market, oldest first
+func (s *storage) GetResultReactions(ctx context.Context, userID int64, conditionID string) ([]*Reaction, error) {
+	return s.queryReactions(ctx, "r.user_id = ? AND r.condition_id = ? AND r.trade_id IS NULL", userID, conditionID)
+}
+
+// queryReactions retrieves the reactions matching a condition, oldest first
+func (s *storage) queryReactions(ctx context.Context, where string, args ...any) ([]*Reaction, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT r.id, r.user_id, r.trade_id, r.condition_id, r.author, r.emoji, r.comment, r.created_at, u.username
+		FROM reactions r
+		JOIN users u ON u.id = r.user_id
+		WHERE `+where+`
+		ORDER BY r.created_at ASC, r.id ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reactions: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := make([]*Reaction, 0)
+	for rows.Next() {
+		var reaction Reaction
+		if err := rows.Scan(&reaction.ID, &reaction.UserID, &reaction.TradeID, &reaction.ConditionID, &reaction.Author,
+			&reaction.Emoji, &reaction.Comment, &reaction.CreatedAt, &reaction.Username); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		reactions = append(reactions, &reaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reactions: %w", err)
+	}
+
+	return reactions, nil
+}
+
+// DeleteReaction removes a reaction posted by author, reporting whether there was one
+func (s *storage) DeleteReaction(ctx context.Context, id int64, author string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM reactions WHERE id = ? AND author = ?", id, author)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// GetMarketsByTitle returns the markets stored trades and positions have with exactly the
+// given titles, keyed by title. A title shared by several markets resolves to the one with the
+// most stored rows. Only ConditionID, MarketTitle and MarketSlug are set.
+func (s *storage) GetMarketsByTitle(ctx context.Context, titles []string) (map[string]*MarketSearchResult, error) {
+	markets := make(map[string]*MarketSearchResult, len(titles))
+	if len(titles) == 0 {
+		return markets, nil
+	}
+
+	// Both halves of the union bind the titles
+	args := make([]any, 0, 2*len(titles))
+	for range 2 {
+		for _, title := range titles {
+			args = append(args, title)
+		}
+	}
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT market_title, condition_id, MAX(market_slug), COUNT(*) AS n
+		FROM (
+			SELECT condition_id, market_title, market_slug FROM positions WHERE market_title IN (`+placeholders(len(titles))+`)
+			UNION ALL
+			SELECT condition_id, market_title, market_slug FROM trades WHERE market_title IN (`+placeholders(len(titles))+`) AND removed_at IS NULL
+		)
+		GROUP BY market_title, condition_id
+		ORDER BY n ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets by title: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			market MarketSearchResult
+			title  string
+			n      int
+		)
+		if err := rows.Scan(&title, &market.ConditionID, &market.MarketSlug, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan market: %w", err)
+		}
+		market.MarketTitle = &title
+		// Ordered by count, so the most stored market of a title is seen last
+		markets[title] = &market
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating markets: %w", err)
+	}
+
+	return markets, nil
+}
+
+// GetUncategorizedMarkets returns up to limit markets of stored trades and positions that have
+// no stored category yet, and aren't cached from Gamma in markets (whose category is its
+// answer). Only ConditionID, MarketTitle and MarketSlug are set.
+func (s *storage) GetUncategorizedMarkets(ctx context.Context, limit int) ([]*MarketSearchResult, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT condition_id, MAX(market_title), MAX(market_slug)
+		FROM (
+			SELECT condition_id, market_title, market_slug FROM positions
+			UNION ALL
+			SELECT condition_id, market_title, market_slug FROM trades WHERE removed_at IS NULL
+		)
+		WHERE condition_id IS NOT NULL AND condition_id != ''
+		AND condition_id NOT IN (SELECT condition_id FROM market_categories)
+		AND condition_id NOT IN (SELECT condition_id FROM markets WHERE found = 1)
+		GROUP BY condition_id
+		ORDER BY condition_id
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query uncategorized markets: %w", err)
+	}
+	defer rows.Close()
+
+	markets := make([]*MarketSearchResult, 0)
+	for rows.Next() {
+		var market MarketSearchResult
+		if err := rows.Scan(&market.ConditionID, &market.MarketTitle, &market.MarketSlug); err != nil {
+			return nil, fmt.Errorf("failed to scan uncategorized market: %w", err)
+		}
+		markets = append(markets, &market)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating uncategorized markets: %w", err)
+	}
+
+	return markets, nil
+}
+
+// SetMarketCategories stores the categories of markets. An inferred category never replaces
+// one from the Gamma API.
+func (s *storage) SetMarketCategories(ctx context.Context, markets []*CategorizedMarket) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, market := range markets {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO market_categories (condition_id, category, source, updated_at)
+			VALUES (?, ?, ?, `+sqlNow+`)
+			ON CONFLICT(condition_id) DO UPDATE SET
+				category = excluded.category,
+				source = excluded.source,
+				updated_at = excluded.updated_at
+			WHERE excluded.source = ? OR market_categories.source = ?
+		`, market.ConditionID, market.Category, market.Source, CategorySourceGamma, CategorySourceInferred); err != nil {
+			return fmt.Errorf("failed to store market category: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetStaleMarkets returns up to limit markets of stored trades and positions that aren't cached
+// yet, then cached ones still trading that were fetched before fetchedBefore, oldest first. Only
+// ConditionID, Title, Slug and EventSlug are set, from the trades and positions.
+func (s *storage) GetStaleMarkets(ctx context.Context, fetchedBefore time.Time, limit int) ([]*Market, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT traded.condition_id, COALESCE(MAX(traded.market_title), ''), MAX(traded.market_slug), MAX(traded.event_slug)
+		FROM (
+			SELECT condition_id, market_title, market_slug, NULL AS event_slug FROM positions
+			UNION ALL
+			SELECT condition_id, market_title, market_slug, event_slug FROM trades WHERE removed_at IS NULL
+		) traded
+		LEFT JOIN markets m ON m.condition_id = traded.condition_id
+		WHERE traded.condition_id IS NOT NULL AND traded.condition_id != ''
+		AND (m.condition_id IS NULL OR (m.closed = 0 AND m.fetched_at < ?))
+		GROUP BY traded.condition_id
+		ORDER BY MAX(m.fetched_at) IS NOT NULL, MAX(m.fetched_at), traded.condition_id
+		LIMIT ?
+	`, formatTimestamp(fetchedBefore), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale markets: %w", err)
+	}
+	defer rows.Close()
+
+	markets := make([]*Market, 0)
+	for rows.Next() {
+		var market Market
+		if err := rows.Scan(&market.ConditionID, &market.Title, &market.Slug, &market.EventSlug); err != nil {
+			return nil, fmt.Errorf("failed to scan stale market: %w", err)
+		}
+		markets = append(markets, &market)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stale markets: %w", err)
+	}
+
+	return markets, nil
+}
+
+// UpsertMarkets caches the metadata of markets, replacing what was cached before
+func (s *storage) UpsertMarkets(ctx context.Context, markets []*Market) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, market := range markets {
+		outcomes, err := json.Marshal(market.Outcomes)
+		if err != nil {
+			return fmt.Errorf("failed to encode market outcomes: %w", err)
+		}
+		if market.Outcomes == nil {
+			outcomes = []byte("[]")
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO markets (
+				condition_id, title, slug, event_slug, event_title, category, end_date,
+				closed, resolved, winning_outcome, outcomes, found, fetched_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(condition_id) DO UPDATE SET
+				title = excluded.title,
+				slug = excluded.slug,
+				event_slug = excluded.event_slug,
+				event_title = excluded.event_title,
+				category = excluded.category,
+				end_date = excluded.end_date,
+				closed = excluded.closed,
+				resolved = excluded.resolved,
+				winning_outcome = excluded.winning_outcome,
+				outcomes = excluded.outcomes,
+				found = excluded.found,
+				fetched_at = excluded.fetched_at
+		`, market.ConditionID, market.Title, market.Slug, market.EventSlug, market.EventTitle,
+			market.Category, formatNullTimestamp(market.EndDate), market.Closed, market.Resolved,
+			market.WinningOutcome, string(outcomes), market.Found, formatTimestamp(market.FetchedAt)); err != nil {
+			return fmt.Errorf("failed to store market: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetMarket returns a cached market
+func (s *storage) GetMarket(ctx context.Context, conditionID string) (*Market, error) {
+	var market Market
+	var endDate sql.NullString
+	var outcomes string
+	err := s.reader.QueryRowContext(ctx, `
+		SELECT condition_id, title, slug, event_slug, event_title, category, end_date,
+			closed, resolved, winning_outcome, outcomes, found, fetched_at
+		FROM markets
+		WHERE condition_id = ?
+	`, conditionID).Scan(
+		&market.ConditionID, &market.Title, &market.Slug, &market.EventSlug, &market.EventTitle,
+		&market.Category, &endDate, &market.Closed, &market.Resolved, &market.WinningOutcome,
+		&outcomes, &market.Found, &market.FetchedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market: %w", err)
+	}
+
+	market.EndDate = parseNullTimestamp(endDate)
+	if err := json.Unmarshal([]byte(outcomes), &market.Outcomes); err != nil {
+		return nil, fmt.Errorf("failed to decode market outcomes: %w", err)
+	}
+
+	return &market, nil
+}
+
+// storedMarketCategories returns the stored category of every categorized market, keyed by
+// condition ID. The Gamma category of a cached market wins over the one categorization stored.
+func (s *storage) storedMarketCategories(ctx context.Context) (map[string]string, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT condition_id, category FROM markets WHERE category IS NOT NULL
+		UNION ALL
+		SELECT condition_id, category FROM market_categories
+		WHERE condition_id NOT IN (SELECT condition_id FROM markets WHERE category IS NOT NULL)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make(map[string]string)
+	for rows.Next() {
+		var conditionID, category string
+		if err := rows.Scan(&conditionID, &category); err != nil {
+			return nil, fmt.Errorf("failed to scan market category: %w", err)
+		}
+		categories[conditionID] = category
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// SearchMarkets finds markets whose title matches the query and attaches tracked holder counts,
+// open position size and per-outcome breakdowns
+func (s *storage) SearchMarkets(ctx context.Context, query string, limit int) ([]*MarketSearchResult, error) {
+	pattern := "%" + query + "%"
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT condition_id, MAX(market_title), MAX(market_slug)
+		FROM (
+			SELECT condition_id, market_title, market_slug FROM positions WHERE market_title LIKE ?
+			UNION ALL
+			SELECT condition_id, market_title, market_slug FROM trades WHERE market_title LIKE ? AND removed_at IS NULL
+		)
+		GROUP BY condition_id
+		ORDER BY COUNT(*) DESC
+		LIMIT ?
+	`, pattern, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search markets: %w", err)
+	}
+
+	results := make([]*MarketSearchResult, 0)
+	for rows.Next() {
+		var result MarketSearchResult
+		if err := rows.Scan(&result.ConditionID, &result.MarketTitle, &result.MarketSlug); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan market: %w", err)
+		}
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating markets: %w", err)
+	}
+	rows.Close()
+
+	// Attach counts per market (rows must be closed first as SQLite uses a single connection)
+	for _, result := range results {
+		err := s.reader.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM trades WHERE condition_id = ? AND removed_at IS NULL",
+			result.ConditionID,
+		).Scan(&result.TradeCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count market trades: %w", err)
+		}
+
+		err = s.reader.QueryRowContext(ctx, `
+			SELECT COUNT(DISTINCT user_id), COALESCE(SUM(size), 0)
+			FROM positions
+			WHERE condition_id = ?
+			AND `+s.notDust("")+`
+		`, result.ConditionID).Scan(&result.Holders, &result.TotalSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get market holders: %w", err)
+		}
+
+		outcomes, err := s.getMarketOutcomeStats(ctx, result.ConditionID)
+		if err != nil {
+			return nil, err
+		}
+		result.Outcomes = outcomes
+	}
+
+	return results, nil
+}
+
+// getMarketOutcomeStats aggregates tracked open positions in a market by outcome
+func (s *storage) getMarketOutcomeStats(ctx context.Context, conditionID string) ([]*MarketOutcomeStats, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT COALESCE(outcome, ''), COUNT(DISTINCT user_id), COALESCE(SUM(size), 0)
+		FROM positions
+		WHERE condition_id = ?
+		AND `+s.notDust("")+`
+		GROUP BY outcome
+		ORDER BY SUM(size) DESC
+	`, conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	outcomes := make([]*MarketOutcomeStats, 0)
+	for rows.Next() {
+		var outcome MarketOutcomeStats
+		if err := rows.Scan(&outcome.Outcome, &outcome.Holders, &outcome.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan market outcome: %w", err)
+		}
+		outcomes = append(outcomes, &outcome)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market outcomes: %w", err)
+	}
+
+	return outcomes, nil
+}
+
+// GetMarketSentiment aggregates open positions across all tracked users per market and outcome
+func (s *storage) GetMarketSentiment(ctx context.Context) ([]*MarketSentiment, error) {
+	markets := make(map[string]*MarketSentiment, 64)
+	order := make([]string, 0, 64)
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT
+			condition_id,
+			MAX(market_title),
+			MAX(market_slug),
+			COUNT(DISTINCT user_id),
+			COALESCE(SUM(size), 0),
+			COALESCE(SUM(current_value), 0)
+		FROM positions
+		WHERE `+s.notDust("")+`
+		GROUP BY condition_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market sentiment: %w", err)
+	}
+
+	for rows.Next() {
+		var market MarketSentiment
+		if err := rows.Scan(
+			&market.ConditionID, &market.MarketTitle, &market.MarketSlug,
+			&market.Holders, &market.TotalSize, &market.TotalValue,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan market sentiment: %w", err)
+		}
+		market.Outcomes = make([]*MarketOutcomeStats, 0, 2)
+		markets[market.ConditionID] = &market
+		order = append(order, market.ConditionID)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating market sentiment: %w", err)
+	}
+	rows.Close()
+
+	// Attach per-outcome breakdowns in a single pass
+	rows, err = s.reader.QueryContext(ctx, `
+		SELECT condition_id, COALESCE(outcome, ''), COUNT(DISTINCT user_id), COALESCE(SUM(size), 0)
+		FROM positions
+		WHERE `+s.notDust("")+`
+		GROUP BY condition_id, outcome
+		ORDER BY condition_id, SUM(size) DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var conditionID string
+		var outcome MarketOutcomeStats
+		if err := rows.Scan(&conditionID, &outcome.Outcome, &outcome.Holders, &outcome.Size); err != nil {
+			return nil, fmt.Errorf("failed to scan market outcome: %w", err)
+		}
+		if market, ok := markets[conditionID]; ok {
+			market.Outcomes = append(market.Outcomes, &outcome)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market outcomes: %w", err)
+	}
+
+	sentiment := make([]*MarketSentiment, 0, len(order))
+	for _, conditionID := range order {
+		sentiment = append(sentiment, markets[conditionID])
+	}
+
+	return sentiment, nil
+}
+
+// edgeAccumulator collects entry prices for a single EdgeStats bucket
+type edgeAccumulator struct {
+	winEntries  []float64
+	lossEntries []float64
+}
+
+// stats converts the accumulated entries into EdgeStats
+func (a *edgeAccumulator) stats(category string) *EdgeStats {
+	mean := func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+
+	stats := &EdgeStats{
+		Category: category,
+		Wins:     len(a.winEntries),
+		Losses:   len(a.lossEntries),
+		Resolved: len(a.winEntries) + len(a.lossEntries),
+	}
+	if stats.Resolved == 0 {
+		return stats
+	}
+
+	stats.WinRate = float64(stats.Wins) / float64(stats.Resolved)
+	stats.AvgEntryPrice = mean(append(append([]float64{}, a.winEntries...), a.lossEntries...))
+
+	if stats.Wins > 0 {
+		avg := mean(a.winEntries)
+		stats.AvgWinEntryPrice = &avg
+	}
+	if stats.Losses > 0 {
+		avg := mean(a.lossEntries)
+		stats.AvgLossEntryPrice = &avg
+	}
+
+	stats.Edge = stats.WinRate - stats.AvgEntryPrice
+	if stats.AvgEntryPrice < 1 {
+		stats.KellyFraction = stats.Edge / (1 - stats.AvgEntryPrice)
+	}
+
+	return stats
+}
+
+// GetUserEdgeStats computes entry price and edge statistics over a user's resolved positions
+func (s *storage) GetUserEdgeStats(ctx context.Context, userID int64) (*UserEdgeStats, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT `+marketCategorySQL("p")+`, p.avg_price, p.realized_pnl
+		FROM positions p
+		WHERE p.user_id = ?
+		AND p.realized_pnl IS NOT NULL
+		AND p.avg_price IS NOT NULL
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resolved positions: %w", err)
+	}
+	defer rows.Close()
+
+	overall := &edgeAccumulator{}
+	byCategory := make(map[string]*edgeAccumulator, 8)
+
+	for rows.Next() {
+		var category string
+		var avgPrice, realizedPnl float64
+		if err := rows.Scan(&category, &avgPrice, &realizedPnl); err != nil {
+			return nil, fmt.Errorf("failed to scan resolved position: %w", err)
+		}
+
+		acc, ok := byCategory[category]
+		if !ok {
+			acc = &edgeAccumulator{}
+			byCategory[category] = acc
+		}
+
+		if realizedPnl > 0 {
+			overall.winEntries = append(overall.winEntries, avgPrice)
+			acc.winEntries = append(acc.winEntries, avgPrice)
+		} else {
+			overall.lossEntries = append(overall.lossEntries, avgPrice)
+			acc.lossEntries = append(acc.lossEntries, avgPrice)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resolved positions: %w", err)
+	}
+
+	result := &UserEdgeStats{
+		Overall:    overall.stats("all"),
+		Categories: make([]*EdgeStats, 0, len(byCategory)),
+	}
+	for category, acc := range byCategory {
+		result.Categories = append(result.Categories, acc.stats(category))
+	}
+
+	sort.Slice(result.Categories, func(i, j int) bool {
+		if result.Categories[i].Resolved != result.Categories[j].Resolved {
+			return result.Categories[i].Resolved > result.Categories[j].Resolved
+		}
+		return result.Categories[i].Category < result.Categories[j].Category
+	})
+
+	return result, nil
+}
+
+// GetEvent retrieves an event by slug
+func (s *storage) GetEvent(ctx context.Context, slug string) (*Event, error) {
+	var event Event
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT slug, created_at FROM events WHERE slug = ?",
+		slug,
+	).Scan(&event.Slug, &event.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("event %w: %s", ErrNotFound, slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return &event, nil
+}
+
+// GetEventLeaderboard computes per-user PnL within a single event.
+// Realized PnL uses FIFO over the user's trades in the event, and unrealized PnL
+// comes from current positions in markets that belong to the event.
+func (s *storage) GetEventLeaderboard(ctx context.Context, slug string) ([]*EventUserStats, error) {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT t.id, t.user_id, t.address, t.trade_id, t.condition_id,
+			`+marketColumnSQL("t", "title", "market_title")+`, `+marketColumnSQL("t", "slug", "market_slug")+`,
+			t.outcome, t.side, t.price, t.size, t.value, t.timestamp, t.created_at,
+			u.username, u.profile_image
+		FROM trades t
+		JOIN users u ON t.user_id = u.id
+		WHERE t.event_slug = ?
+		AND t.removed_at IS NULL
+		AND u.ghost = 0
+		ORDER BY t.user_id, t.timestamp ASC
+	`, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event trades: %w", err)
+	}
+
+	statsByUser := make(map[int64]*EventUserStats, 16)
+	tradesByUser := make(map[int64][]*Trade, 16)
+	order := make([]int64, 0, 16)
+
+	for rows.Next() {
+		var trade Trade
+		var username string
+		var profileImage *string
+		if err := rows.Scan(
+			&trade.ID, &trade.UserID, &trade.Address, &trade.TradeID, &trade.ConditionID,
+			&trade.MarketTitle, &trade.MarketSlug, &trade.Outcome, &trade.Side, &trade.Price,
+			&trade.Size, &trade.Value, &trade.Timestamp, &trade.CreatedAt,
+			&username, &profileImage,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan event trade: %w", err)
+		}
+
+		stats, ok := statsByUser[trade.UserID]
+		if !ok {
+			stats = &EventUserStats{
+				UserID:       trade.UserID,
+				Username:     username,
+				ProfileImage: profileImage,
+			}
+			statsByUser[trade.UserID] = stats
+			order = append(order, trade.UserID)
+		}
+
+		stats.TotalTrades++
+		if trade.Value != nil {
+			stats.Volume += *trade.Value
+		}
+		tradesByUser[trade.UserID] = append(tradesByUser[trade.UserID], &trade)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating event trades: %w", err)
+	}
+	rows.Close()
+
+	// Mark open positions in the event's markets
+	rows, err = s.reader.QueryContext(ctx, `
+		SELECT user_id, COUNT(*) FILTER (WHERE `+s.notDust("")+`), COALESCE(SUM(unrealized_pnl), 0)
+		FROM positions
+		WHERE condition_id IN (SELECT DISTINCT condition_id FROM trades WHERE event_slug = ?)
+		GROUP BY user_id
+	`, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event positions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		var openPositions int
+		var unrealizedPnl float64
+		if err := rows.Scan(&userID, &openPositions, &unrealizedPnl); err != nil {
+			return nil, fmt.Errorf("failed to scan event positions: %w", err)
+		}
+		if stats, ok := statsByUser[userID]; ok {
+			stats.OpenPositions = openPositions
+			stats.UnrealizedPnl = unrealizedPnl
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event positions: %w", err)
+	}
+
+	leaderboard := make([]*EventUserStats, 0, len(order))
+	for _, userID := range order {
+		stats := statsByUser[userID]
+
+		realizedPnl, wins, totalClosed := realizedPnlFIFO(tradesByUser[userID])
+		stats.RealizedPnl = realizedPnl
+		stats.TotalPnl = stats.RealizedPnl + stats.UnrealizedPnl
+		if totalClosed > 0 {
+			stats.WinRate = float64(wins) / float64(totalClosed)
+		}
+
+		leaderboard = append(leaderboard, stats)
+	}
+
+	sort.Slice(leaderboard, func(i, j int) bool {
+		return leaderboard[i].TotalPnl > leaderboard[j].TotalPnl
+	})
+
+	return leaderboard, nil
+}
+
+// ReconcileTrades compares stored trades for an address at or after since against the set of
+// trades currently returned upstream. Stored trades missing upstream are tombstoned, and
+// tombstoned trades that reappear are restored. Imported trades are left alone.
+func (s *storage) ReconcileTrades(ctx context.Context, userID int64, address string, since time.Time, upstream map[TradeKey]struct{}) (*ReconcileResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, condition_id, timestamp, side, size, price, removed_at IS NOT NULL
+		FROM trades
+		WHERE user_id = ?
+		AND address = ?
+		AND timestamp >= ?
+		AND imported_at IS NULL
+	`, userID, address, formatTimestamp(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades for reconciliation: %w", err)
+	}
+
+	result := &ReconcileResult{}
+	toRemove := make([]int64, 0)
+	toRestore := make([]int64, 0)
+
+	for rows.Next() {
+		var id int64
+		var conditionID, side *string
+		var timestamp *time.Time
+		var size, price *float64
+		var removed bool
+		if err := rows.Scan(&id, &conditionID, &timestamp, &side, &size, &price, &removed); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan trade for reconciliation: %w", err)
+		}
+
+		// Trades missing identifying fields can't be matched, leave them alone
+		if conditionID == nil || timestamp == nil || side == nil || size == nil || price == nil {
+			continue
+		}
+
+		result.Checked++
+
+		key := TradeKey{
+			ConditionID: *conditionID,
+			Timestamp:   timestamp.Unix(),
+			Side:        *side,
+			Size:        *size,
+			Price:       *price,
+		}
+
+		_, present := upstream[key]
+		switch {
+		case !present && !removed:
+			toRemove = append(toRemove, id)
+		case present && removed:
+			toRestore = append(toRestore, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating trades for reconciliation: %w", err)
+	}
+	rows.Close()
+
+	if len(toRemove) == 0 && len(toRestore) == 0 {
+		return result, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range toRemove {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE trades SET removed_at = "+sqlNow+" WHERE id = ?", id,
+		); err != nil {
+			return nil, fmt.Errorf("failed to tombstone trade: %w", err)
+		}
+	}
+
+	for _, id := range toRestore {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE trades SET removed_at = NULL WHERE id = ?", id,
+		); err != nil {
+			return nil, fmt.Errorf("failed to restore trade: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reconciliation: %w", err)
+	}
+	s.fifo.invalidate(userID)
+
+	result.Removed = len(toRemove)
+	result.Restored = len(toRestore)
+
+	return result, nil
+}
+
+// CountRemovedTrades returns the number of tombstoned trades for a user
+func (s *storage) CountRemovedTrades(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := s.reader.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM trades WHERE user_id = ? AND removed_at IS NOT NULL",
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count removed trades: %w", err)
+	}
+	return count, nil
+}
+
+// RecordPositionSettlements archives positions of resolved markets. The first capture of a
+// position wins, so later syncs after partial redemption don't overwrite the settled size.
+// Returns the settlements that were newly recorded.
+func (s *storage) RecordPositionSettlements(ctx context.Context, settlements []*PositionSettlement) ([]*PositionSettlement, error) {
+	if len(settlements) == 0 {
+		return nil, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	recorded := make([]*PositionSettlement, 0)
+	for _, st := range settlements {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO position_settlements (
+				user_id, address, condition_id, asset, outcome, market_title, market_slug,
+				size, avg_price, settlement_price, pnl, resolved_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(user_id, address, condition_id, asset) DO NOTHING
+		`,
+			st.UserID, st.Address, st.ConditionID, st.Asset, st.Outcome, st.MarketTitle, st.MarketSlug,
+			st.Size, st.AvgPrice, st.SettlementPrice, st.Pnl, formatTimestamp(st.ResolvedAt),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record position settlement: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			recorded = append(recorded, st)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit position settlements: %w", err)
+	}
+
+	return recorded, nil
+}
+
+// attachSettlement sets the captured settlement price and exact PnL on a result, if any
+func (s *storage) attachSettlement(ctx context.Context, result *Result) error {
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT outcome, settlement_price, pnl
+		FROM position_settlements
+		WHERE user_id = ? AND condition_id = ?
+	`, result.UserID, result.ConditionID)
+	if err != nil {
+		return fmt.Errorf("failed to query position settlements: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var outcome *string
+		var price, pnl float64
+		if err := rows.Scan(&outcome, &price, &pnl); err != nil {
+			return fmt.Errorf("failed to scan position settlement: %w", err)
+		}
+
+		settled := pnl
+		if result.SettledPnl != nil {
+			settled += *result.SettledPnl
+		}
+		result.SettledPnl = &settled
+
+		if result.SettlementPrice == nil || (outcome != nil && result.Outcome != nil && *outcome == *result.Outcome) {
+			settlementPrice := price
+			result.SettlementPrice = &settlementPrice
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating position settlements: %w", err)
+	}
+
+	return nil
+}
+
+// resultSeriesPoints is the most points in a result's price series
+const resultSeriesPoints = 50
+
+// attachPriceSeries adds the price of a result's outcome from the user's first buy to its
+// resolution, from the hourly prices seen in synced positions and the prices of tracked trades
+// in the market, downsampled to resultSeriesPoints and ending at the settlement price when known
+func (s *storage) attachPriceSeries(ctx context.Context, result *Result) error {
+	if result.Outcome == nil || result.ResolutionDate == nil {
+		return nil
+	}
+
+	var entry sql.NullString
+	if err := s.reader.QueryRowContext(ctx, `
+		SELECT MIN(timestamp) FROM trades
+		WHERE user_id = ? AND condition_id = ? AND outcome = ? AND side = 'BUY' AND removed_at IS NULL
+	`, result.UserID, result.ConditionID, *result.Outcome).Scan(&entry); err != nil {
+		return fmt.Errorf("failed to get result entry: %w", err)
+	}
+	start := parseNullTimestamp(entry)
+	if start == nil {
+		return nil
+	}
+	end := *result.ResolutionDate
+
+	rows, err := s.reader.QueryContext(ctx, `
+		SELECT hour, price FROM price_history
+		WHERE condition_id = ? AND outcome = ? AND hour >= ? AND hour <= ?
+		UNION ALL
+		SELECT timestamp, price FROM trades
+		WHERE condition_id = ? AND outcome = ? AND timestamp >= ? AND timestamp <= ? AND removed_at IS NULL
+		ORDER BY 1
+	`,
+		result.ConditionID, *result.Outcome, formatTimestamp(start.Truncate(time.Hour)), formatTimestamp(end),
+		result.ConditionID, *result.Outcome, formatTimestamp(*start), formatTimestamp(end),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query price series: %w", err)
+	}
+	defer rows.Close()
+
+	var points []*PricePoint
+	for rows.Next() {
+		var timestamp sql.NullString
+		var price float64
+		if err := rows.Scan(&timestamp, &price); err != nil {
+			return fmt.Errorf("failed to scan price point: %w", err)
+		}
+		if t := parseNullTimestamp(timestamp); t != nil {
+			points = append(points, &PricePoint{ConditionID: result.ConditionID, Outcome: *result.Outcome, Timestamp: *t, Price: price})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating price series: %w", err)
+	}
+
+	points = downsamplePrices(points, *start, end, resultSeriesPoints-1)
+	if result.SettlementPrice != nil {
+		points = append(points, &PricePoint{ConditionID: result.ConditionID, Outcome: *result.Outcome, Timestamp: end, Price: *result.SettlementPrice})
+	}
+	if len(points) > 0 {
+		result.PriceSeries = points
 	}
+
 	return nil
 }
 
-// CreatePersonaWithImage creates a new persona with an image
-func (s *storage) CreatePersonaWithImage(ctx context.Context, slug, displayName, image string) (*Persona, error) {
-	result, err := s.db.ExecContext(ctx,
-		"INSERT INTO personas (slug, display_name, image, created_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)",
-		slug, displayName, image,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert persona: %w", err)
+// downsamplePrices keeps the last point of each of n equal spans between start and end.
+// points must be ordered oldest first.
+func downsamplePrices(points []*PricePoint, start, end time.Time, n int) []*PricePoint {
+	if len(points) <= n || !end.After(start) {
+		return points
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get persona id: %w", err)
+	span := end.Sub(start) / time.Duration(n)
+	sampled := make([]*PricePoint, 0, n)
+	bucket := -1
+	for _, point := range points {
+		b := min(int(point.Timestamp.Sub(start)/span), n-1)
+		if b == bucket {
+			sampled[len(sampled)-1] = point
+			continue
+		}
+		bucket = b
+		sampled = append(sampled, point)
 	}
-
-	return &Persona{
-		ID:          id,
-		Slug:        slug,
-		DisplayName: displayName,
-		Image:       &image,
-	}, nil
+	return sampled
 }
 
-// UpdatePersonaImage updates a persona's image
-func (s *storage) UpdatePersonaImage(ctx context.Context, personaID int64, image string) error {
-	_, err := s.db.ExecContext(ctx,
-		"UPDATE personas SET image = ? WHERE id = ?",
-		image, personaID,
-	)
+// RecordPrices stores the current price of each open position as its outcome's price for the
+// hour, returning the number of hourly prices added or changed
+func (s *storage) RecordPrices(ctx context.Context, positions []*Position) (int, error) {
+	hour := formatTimestamp(time.Now().Truncate(time.Hour))
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to update persona image: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return nil
+	defer tx.Rollback()
+
+	written := 0
+	for _, pos := range positions {
+		if pos.Outcome == nil || pos.CurrentPrice == nil || pos.Size == nil || *pos.Size <= 0 {
+			continue
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO price_history (condition_id, outcome, hour, price) VALUES (?, ?, ?, ?)
+			ON CONFLICT(condition_id, outcome, hour) DO UPDATE SET price = excluded.price
+			WHERE price_history.price != excluded.price
+		`, pos.ConditionID, *pos.Outcome, hour, *pos.CurrentPrice)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record price: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			written++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit prices: %w", err)
+	}
+
+	return written, nil
 }
 
-// GetUserResults retrieves resolved positions (results) for a user
-// A position is considered "resolved" if:
-// 1. The position has realized PnL (position was closed/exited)
-// 2. The market has ended (end_date has passed)
-func (s *storage) GetUserResults(ctx context.Context, userID int64, limit, offset int) ([]*Result, int, error) {
-	// Get total count of resolved positions
-	var total int
-	err := s.db.QueryRowContext(ctx, `
-		SELECT COUNT(DISTINCT condition_id)
-		FROM positions
-		WHERE user_id = ?
-		AND realized_pnl IS NOT NULL
-	`, userID).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count results: %w", err)
+// GetGroupEquity combines the open exposure, PnL and PnL history of a set of users.
+// Snapshots are taken per user at slightly different times, so the history is bucketed
+// hourly and each user's last known snapshot is carried forward into later buckets.
+func (s *storage) GetGroupEquity(ctx context.Context, users []*User, start, end *time.Time) (*GroupEquity, error) {
+	equity := &GroupEquity{
+		Users:      len(users),
+		DataPoints: make([]*GroupEquityPoint, 0),
 	}
 
-	// Get results with pagination
-	// We group by condition_id to avoid duplicates and sum realized_pnl across all positions for that market
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT
-			MIN(id) as id,
-			user_id,
-			condition_id,
-			market_title,
-			market_slug,
-			outcome,
-			COALESCE(SUM(realized_pnl), 0) as realized_pnl,
-			SUM(initial_value) as initial_value,
-			end_date,
-			MAX(updated_at) as resolution_date
-		FROM positions
-		WHERE user_id = ?
-		AND realized_pnl IS NOT NULL
-		GROUP BY condition_id, user_id
-		ORDER BY updated_at DESC
-		LIMIT ? OFFSET ?
-	`, userID, limit, offset)
+	for _, user := range users {
+		stats, err := s.GetUserStats(ctx, user.Username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user stats: %w", err)
+		}
+
+		equity.OpenPositions += stats.OpenPositions
+		equity.TotalPnl += stats.TotalPnl
+		equity.RealizedPnl += stats.RealizedPnl
+		equity.UnrealizedPnl += stats.UnrealizedPnl
+
+		var openValue float64
+		if err := s.reader.QueryRowContext(ctx,
+			"SELECT COALESCE(SUM(current_value), 0) FROM positions WHERE user_id = ? AND "+s.notDust(""),
+			user.ID,
+		).Scan(&openValue); err != nil {
+			return nil, fmt.Errorf("failed to get open position value: %w", err)
+		}
+		equity.OpenPositionValue += openValue
+	}
+
+	// Last snapshot per user within each hourly bucket
+	buckets := make(map[time.Time]map[int64]*PnlSnapshot, 64)
+	for _, user := range users {
+		snapshots, err := s.GetUserPnlHistory(ctx, user.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snapshot := range snapshots {
+			bucket := snapshot.Timestamp.UTC().Truncate(time.Hour)
+			if _, exists := buckets[bucket]; !exists {
+				buckets[bucket] = make(map[int64]*PnlSnapshot, len(users))
+			}
+			buckets[bucket][user.ID] = snapshot
+		}
+	}
+
+	timestamps := make([]time.Time, 0, len(buckets))
+	for bucket := range buckets {
+		timestamps = append(timestamps, bucket)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+
+	latest := make(map[int64]*PnlSnapshot, len(users))
+	for _, bucket := range timestamps {
+		for userID, snapshot := range buckets[bucket] {
+			latest[userID] = snapshot
+		}
+
+		point := &GroupEquityPoint{Timestamp: bucket}
+		for _, snapshot := range latest {
+			if snapshot.TotalPnl != nil {
+				point.TotalPnl += *snapshot.TotalPnl
+			}
+			if snapshot.RealizedPnl != nil {
+				point.RealizedPnl += *snapshot.RealizedPnl
+			}
+			if snapshot.UnrealizedPnl != nil {
+				point.UnrealizedPnl += *snapshot.UnrealizedPnl
+			}
+		}
+		equity.DataPoints = append(equity.DataPoints, point)
+	}
+
+	return equity, nil
+}
+
+// GetMuteRules retrieves the trade feed mute rules defined through the API
+func (s *storage) GetMuteRules(ctx context.Context) (*MuteRules, error) {
+	rows, err := s.reader.QueryContext(ctx, "SELECT kind, value FROM mute_rules ORDER BY kind, value")
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query results: %w", err)
+		return nil, fmt.Errorf("failed to query mute rules: %w", err)
 	}
 	defer rows.Close()
 
-	results := make([]*Result, 0, limit)
+	rules := &MuteRules{
+		Users:      make([]string, 0),
+		Categories: make([]string, 0),
+	}
 	for rows.Next() {
-		var result Result
-		var endDateStr, resolutionDateStr sql.NullString
-		if err := rows.Scan(
-			&result.ID,
-			&result.UserID,
-			&result.ConditionID,
-			&result.MarketTitle,
-			&result.MarketSlug,
-			&result.Outcome,
-			&result.RealizedPnl,
-			&result.InitialValue,
-			&endDateStr,
-			&resolutionDateStr,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan result: %w", err)
-		}
-		// Parse date strings manually since SQLite returns strings
-		if endDateStr.Valid {
-			if t, err := time.Parse("2006-01-02 15:04:05", endDateStr.String); err == nil {
-				result.EndDate = &t
-			} else if t, err := time.Parse("2006-01-02 15:04:05 +0000 UTC", endDateStr.String); err == nil {
-				result.EndDate = &t
-			}
+		var kind, value string
+		if err := rows.Scan(&kind, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan mute rule: %w", err)
 		}
-		if resolutionDateStr.Valid {
-			if t, err := time.Parse("2006-01-02 15:04:05", resolutionDateStr.String); err == nil {
-				result.ResolutionDate = &t
-			} else if t, err := time.Parse("2006-01-02 15:04:05 +0000 UTC", resolutionDateStr.String); err == nil {
-				result.ResolutionDate = &t
+
+		switch kind {
+		case "minValue":
+			minValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mute min value %q: %w", value, err)
 			}
+			rules.MinValue = &minValue
+		case "user":
+			rules.Users = append(rules.Users, value)
+		case "category":
+			rules.Categories = append(rules.Categories, value)
 		}
-		results = append(results, &result)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating results: %w", err)
+		return nil, fmt.Errorf("error iterating mute rules: %w", err)
 	}
 
-	return results, total, nil
+	return rules, nil
 }
 
-// GetPersonaResults retrieves resolved positions (results) across all accounts for a persona
-func (s *storage) GetPersonaResults(ctx context.Context, slug string, limit, offset int) ([]*ResultWithUsername, int, error) {
-	persona, err := s.GetPersona(ctx, slug)
+// SetMuteRules replaces the trade feed mute rules defined through the API
+func (s *storage) SetMuteRules(ctx context.Context, rules *MuteRules) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, 0, err
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	// Get total count
-	var total int
-	err = s.db.QueryRowContext(ctx, `
-		SELECT COUNT(DISTINCT p.condition_id)
-		FROM positions p
-		JOIN users u ON p.user_id = u.id
-		WHERE u.persona_id = ?
-		AND p.realized_pnl IS NOT NULL
-	`, persona.ID).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count persona results: %w", err)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM mute_rules"); err != nil {
+		return fmt.Errorf("failed to clear mute rules: %w", err)
 	}
 
-	// Get results
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT
-			MIN(p.id) as id,
-			p.user_id,
-			p.condition_id,
-			p.market_title,
-			p.market_slug,
-			p.outcome,
-			COALESCE(SUM(p.realized_pnl), 0) as realized_pnl,
-			SUM(p.initial_value) as initial_value,
-			p.end_date,
-			MAX(p.updated_at) as resolution_date,
-			u.username
-		FROM positions p
-		JOIN users u ON p.user_id = u.id
-		WHERE u.persona_id = ?
-		AND p.realized_pnl IS NOT NULL
-		GROUP BY p.condition_id, u.username
-		ORDER BY p.updated_at DESC
-		LIMIT ? OFFSET ?
-	`, persona.ID, limit, offset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query persona results: %w", err)
+	insert := func(kind, value string) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO mute_rules (kind, value, created_at) VALUES (?, ?, "+sqlNow+") ON CONFLICT(kind, value) DO NOTHING",
+			kind, value,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert mute rule: %w", err)
+		}
+		return nil
 	}
-	defer rows.Close()
 
-	results := make([]*ResultWithUsername, 0)
-	for rows.Next() {
-		var result ResultWithUsername
-		var endDateStr, resolutionDateStr sql.NullString
-		if err := rows.Scan(
-			&result.ID,
-			&result.UserID,
-			&result.ConditionID,
-			&result.MarketTitle,
-			&result.MarketSlug,
-			&result.Outcome,
-			&result.RealizedPnl,
-			&result.InitialValue,
-			&endDateStr,
-			&resolutionDateStr,
-			&result.Username,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan persona result: %w", err)
+	if rules.MinValue != nil {
+		if err := insert("minValue", strconv.FormatFloat(*rules.MinValue, 'f', -1, 64)); err != nil {
+			return err
 		}
-		// Parse date strings manually since SQLite returns strings
-		if endDateStr.Valid {
-			if t, err := time.Parse("2006-01-02 15:04:05", endDateStr.String); err == nil {
-				result.EndDate = &t
-			} else if t, err := time.Parse("2006-01-02 15:04:05 +0000 UTC", endDateStr.String); err == nil {
-				result.EndDate = &t
-			}
+	}
+	for _, username := range rules.Users {
+		if err := insert("user", username); err != nil {
+			return err
 		}
-		if resolutionDateStr.Valid {
-			if t, err := time.Parse("2006-01-02 15:04:05", resolutionDateStr.String); err == nil {
-				result.ResolutionDate = &t
-			} else if t, err := time.Parse("2006-01-02 15:04:05 +0000 UTC", resolutionDateStr.String); err == nil {
-				result.ResolutionDate = &t
-			}
+	}
+	for _, category := range rules.Categories {
+		if err := insert("category", category); err != nil {
+			return err
 		}
-		results = append(results, &result)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating persona results: %w", err)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit mute rules: %w", err)
 	}
 
-	return results, total, nil
+	return nil
 }
 
-// CalculateRealizedPnlFromTrades calculates realized PnL using FIFO cost basis from trade history.
-// This is the source of truth for realized PnL since closed positions are deleted during sync.
-// Returns: realizedPnl, wins, totalClosed, error
-func (s *storage) CalculateRealizedPnlFromTrades(ctx context.Context, userID int64) (float64, int, int, error) {
-	trades, err := s.GetUserTradesChronological(ctx, userID)
+// GetPersonaStyle derives trading style descriptors from the trade history of a persona's
+// accounts, ghost users left out
+func (s *storage) GetPersonaStyle(ctx context.Context, slug string) (*PersonaStyle, error) {
+	persona, err := s.GetPersona(ctx, slug)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to get trades: %w", err)
+		return nil, err
+	}
+
+	users, err := s.GetPersonaUsers(ctx, persona.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persona users: %w", err)
+	}
+	categories, err := s.storedMarketCategories(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Group trades by condition_id + outcome (each represents a unique position)
 	type positionKey struct {
+		userID      int64
 		conditionID string
 		outcome     string
 	}
 
-	// FIFO lots per position
-	inventory := make(map[positionKey][]fifoLot)
-	realizedPnl := float64(0)
-	wins := 0
-	losses := 0
+	positionCost := make(map[positionKey]float64, 64)
+	categoryVolume := make(map[string]float64, 8)
+	disposals := make([]fifoDisposal, 0)
+	var buys, longShots int
 
-	for _, trade := range trades {
-		if trade.ConditionID == nil || trade.Outcome == nil || trade.Side == nil {
+	for _, user := range users {
+		if user.Ghost {
 			continue
 		}
-		if trade.Price == nil || trade.Size == nil {
-			continue
+		trades, err := s.GetUserTradesChronological(ctx, user.ID)
+		if err != nil {
+			return nil, err
 		}
 
-		key := positionKey{
-			conditionID: *trade.ConditionID,
-			outcome:     *trade.Outcome,
-		}
+		for _, trade := range trades {
+			if trade.ConditionID == nil || trade.Outcome == nil || trade.Side == nil ||
+				trade.Price == nil || trade.Size == nil || *trade.Side != "BUY" {
+				continue
+			}
 
-		price := *trade.Price
-		size := *trade.Size
+			key := positionKey{userID: user.ID, conditionID: *trade.ConditionID, outcome: *trade.Outcome}
+			cost := *trade.Price * *trade.Size
 
-		if *trade.Side == "BUY" {
-			// Add to inventory
-			inventory[key] = append(inventory[key], fifoLot{
-				Shares: size,
-				Price:  price,
-			})
-		} else if *trade.Side == "SELL" {
-			// Match against FIFO lots and realize PnL
-			lots := inventory[key]
-			remainingToSell := size
+			buys++
+			if *trade.Price <= LongShotPrice {
+				longShots++
+			}
+			positionCost[key] += cost
+			category, ok := categories[*trade.ConditionID]
+			if !ok {
+				category = MarketCategory(trade.MarketTitle, trade.MarketSlug)
+			}
+			categoryVolume[category] += cost
+		}
 
-			for remainingToSell > 0 && len(lots) > 0 {
-				lot := &lots[0]
+		userDisposals, err := s.holdDisposals(ctx, user.ID, trades)
+		if err != nil {
+			return nil, err
+		}
+		disposals = append(disposals, userDisposals...)
+	}
 
-				if lot.Shares <= remainingToSell {
-					// Consume entire lot
-					costBasis := lot.Shares * lot.Price
-					proceeds := lot.Shares * price
-					pnl := proceeds - costBasis
-					realizedPnl += pnl
+	userIDs := make([]int64, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	entryTiming, err := s.entryTiming(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
 
-					if pnl > 0 {
-						wins++
-					} else if pnl < 0 {
-						losses++
-					}
+	style := &PersonaStyle{
+		Positions:   len(positionCost),
+		HoldTime:    holdTimeStats(disposals),
+		EntryTiming: entryTiming,
+	}
+	style.AvgHoldHours = style.HoldTime.MeanHours
 
-					remainingToSell -= lot.Shares
-					lots = lots[1:] // Remove consumed lot
-				} else {
-					// Partial lot consumption
-					costBasis := remainingToSell * lot.Price
-					proceeds := remainingToSell * price
-					pnl := proceeds - costBasis
-					realizedPnl += pnl
+	if len(positionCost) > 0 {
+		var totalCost float64
+		for _, cost := range positionCost {
+			totalCost += cost
+		}
+		style.AvgPositionSize = totalCost / float64(len(positionCost))
+	}
 
-					if pnl > 0 {
-						wins++
-					} else if pnl < 0 {
-						losses++
-					}
+	if buys > 0 {
+		style.LongShotRatio = float64(longShots) / float64(buys)
+	}
 
-					lot.Shares -= remainingToSell
-					remainingToSell = 0
-				}
-			}
-			inventory[key] = lots
+	var favouriteVolume float64
+	for category, volume := range categoryVolume {
+		if volume > favouriteVolume || (volume == favouriteVolume && style.FavouriteCategory != nil && category < *style.FavouriteCategory) {
+			favourite := category
+			style.FavouriteCategory = &favourite
+			favouriteVolume = volume
 		}
 	}
 
-	return realizedPnl, wins, wins + losses, nil
+	return style, nil
 }
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheStats counts lookups in a cache since startup
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// fifoCache keeps each user's FIFO totals for as long as their last sync time is unchanged.
+// Sync updates that time when it finishes with a user, so other instances reading the same
+// database miss too. Trades written outside a sync, such as imports, invalidate the user here.
+type fifoCache struct {
+	mu      sync.Mutex
+	entries map[int64]fifoCacheEntry
+	// invalidations counts each user's invalidations, so totals computed from trades read
+	// before one aren't cached after it
+	invalidations map[int64]uint64
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// fifoCacheEntry is a user's FIFO totals as of a last sync time
+type fifoCacheEntry struct {
+	lastSynced time.Time
+	totals     fifoTotals
+}
+
+func newFIFOCache() *fifoCache {
+	return &fifoCache{entries: make(map[int64]fifoCacheEntry), invalidations: make(map[int64]uint64)}
+}
+
+// get returns a user's cached totals when they were cached as of lastSynced. On a miss it
+// returns the version to put the recomputed totals with.
+func (c *fifoCache) get(userID int64, lastSynced *time.Time) (fifoTotals, uint64, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	version := c.invalidations[userID]
+	c.mu.Unlock()
+
+	if !ok || !entry.lastSynced.Equal(syncedAt(lastSynced)) {
+		c.misses.Add(1)
+		return fifoTotals{}, version, false
+	}
+	c.hits.Add(1)
+	return entry.totals, version, true
+}
+
+// put caches a user's totals as of lastSynced, unless the user was invalidated since get
+// returned version
+func (c *fifoCache) put(userID int64, version uint64, lastSynced *time.Time, totals fifoTotals) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.invalidations[userID] != version {
+		return
+	}
+	c.entries[userID] = fifoCacheEntry{lastSynced: syncedAt(lastSynced), totals: totals}
+}
+
+// invalidate drops the given users' totals
+func (c *fifoCache) invalidate(userIDs ...int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range userIDs {
+		delete(c.entries, id)
+		c.invalidations[id]++
+	}
+}
+
+// stats reports the cache's hits and misses
+func (c *fifoCache) stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// syncedAt is the cache key of a last sync time, the zero time for users never synced
+func syncedAt(lastSynced *time.Time) time.Time {
+	if lastSynced == nil {
+		return time.Time{}
+	}
+	return *lastSynced
+}
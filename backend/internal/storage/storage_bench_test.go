@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The generated database the benchmarks share: one user with a long trading history, the
+// others with 2,000 trades each, read over as many connections as a default server uses
+const (
+	benchWhaleTrades     = 100000
+	benchUsers           = 20
+	benchReadConnections = 4
+	benchImportBatchSize = 5000
+)
+
+// bench is the generated database, created by the first benchmark that needs it
+var bench struct {
+	once  sync.Once
+	dir   string
+	store Storage
+	whale *User
+	err   error
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if bench.store != nil {
+		bench.store.Stop()
+	}
+	if bench.dir != "" {
+		os.RemoveAll(bench.dir)
+	}
+
+	os.Exit(code)
+}
+
+// benchStore returns the generated database and the user with the longest history, generating
+// them on first use
+func benchStore(b *testing.B) (Storage, *User) {
+	b.Helper()
+
+	bench.once.Do(func() {
+		bench.dir, bench.err = os.MkdirTemp("", "pyre-bench-")
+		if bench.err != nil {
+			return
+		}
+
+		log := logrus.New()
+		log.SetLevel(logrus.WarnLevel)
+
+		ctx := context.Background()
+		store := NewStorage(filepath.Join(bench.dir, "bench.db"), benchReadConnections, 0.1, log)
+		if bench.err = store.Start(ctx); bench.err != nil {
+			return
+		}
+		bench.store = store
+		bench.whale, bench.err = generateBenchTrades(ctx, store, benchUsers, benchWhaleTrades)
+	})
+	if bench.err != nil {
+		b.Fatalf("failed to generate the benchmark database: %v", bench.err)
+	}
+
+	b.ResetTimer()
+	return bench.store, bench.whale
+}
+
+func BenchmarkGetAllTradesFiltered(b *testing.B) {
+	store, _ := benchStore(b)
+
+	side := "BUY"
+	minValue := 100.0
+	filters := TradeFilters{
+		Limit:         50,
+		Side:          &side,
+		MinValue:      &minValue,
+		SortBy:        "value",
+		SortDirection: "desc",
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, err := store.GetAllTrades(context.Background(), filters); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetAllTradesUserWindow(b *testing.B) {
+	store, whale := benchStore(b)
+
+	start := time.Now().AddDate(0, 0, -30)
+	filters := TradeFilters{
+		Limit:         100,
+		Username:      &whale.Username,
+		Start:         &start,
+		SortBy:        "timestamp",
+		SortDirection: "desc",
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, err := store.GetAllTrades(context.Background(), filters); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetLeaderboard(b *testing.B) {
+	store, _ := benchStore(b)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := store.GetLeaderboard(context.Background(), "totalPnl", "desc"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkFIFOHoldTimes(b *testing.B) {
+	store, whale := benchStore(b)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := store.GetUserHoldTimeStats(context.Background(), whale.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// generateBenchTrades creates users with random trading histories over the last year, buying
+// into and selling out of a shared set of markets, and returns the user with the longest history
+func generateBenchTrades(ctx context.Context, store Storage, users, whaleTrades int) (*User, error) {
+	rng := rand.New(rand.NewSource(1))
+	now := time.Now().UTC().Truncate(time.Second)
+	start := now.AddDate(-1, 0, 0)
+
+	conditions := make([]string, 500)
+	for i := range conditions {
+		conditions[i] = fmt.Sprintf("0x%064x", i+1)
+	}
+	outcomes := []string{"Yes", "No"}
+
+	var whale *User
+	for i := range users {
+		address := fmt.Sprintf("0x%040x", i+1)
+		user, err := store.CreateUser(ctx, fmt.Sprintf("bench%02d", i), []string{address})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+
+		count := 2000
+		if i == 0 {
+			whale, count = user, whaleTrades
+		}
+
+		batch := make([]*Trade, 0, benchImportBatchSize)
+		held := make(map[[2]string]float64)
+		step := now.Sub(start) / time.Duration(count+1)
+		for n := range count {
+			conditionID := conditions[rng.Intn(len(conditions))]
+			outcome := outcomes[rng.Intn(len(outcomes))]
+			key := [2]string{conditionID, outcome}
+
+			side, size := "BUY", float64(10+rng.Intn(500))
+			if held[key] > 0 && rng.Intn(3) == 0 {
+				side, size = "SELL", min(size, held[key])
+				held[key] -= size
+			} else {
+				held[key] += size
+			}
+
+			price := 0.05 + rng.Float64()*0.9
+			value := price * size
+			ts := start.Add(step * time.Duration(n+1))
+			batch = append(batch, &Trade{
+				UserID:      user.ID,
+				Address:     address,
+				ConditionID: &conditionID,
+				Outcome:     &outcome,
+				Side:        &side,
+				Price:       &price,
+				Size:        &size,
+				Value:       &value,
+				Timestamp:   &ts,
+			})
+
+			if len(batch) == benchImportBatchSize || n == count-1 {
+				if _, err := store.ImportTrades(ctx, batch, false); err != nil {
+					return nil, fmt.Errorf("failed to import trades: %w", err)
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+
+	return whale, nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"database/sql/driver"
+
+	"github.com/samcm/pyre/internal/titles"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// Expose normalization to SQL so migrations can fill in display titles of stored rows
+	sqlite.MustRegisterDeterministicScalarFunction("display_title", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			title, ok := args[0].(string)
+			if !ok {
+				return nil, nil
+			}
+			return titles.Display(title), nil
+		},
+	)
+}
+
+// marketDisplayTitle returns the display title stored next to a market's raw title: display
+// when the caller already has it, otherwise made from raw
+func marketDisplayTitle(raw, display *string) *string {
+	if display != nil || raw == nil {
+		return display
+	}
+	title := titles.Display(*raw)
+	return &title
+}
+
+// displayTitle prefers a display title, falling back to the raw title for rows stored without
+// one
+func displayTitle(raw, display *string) string {
+	switch {
+	case display != nil:
+		return *display
+	case raw != nil:
+		return titles.Display(*raw)
+	}
+	return ""
+}
+
+// DisplayTitle is the title of the trade's market to show in notifications and exports
+func (t *Trade) DisplayTitle() string {
+	return displayTitle(t.MarketTitle, t.MarketDisplayTitle)
+}
+
+// DisplayTitle is the title of the position's market to show in notifications and exports
+func (p *Position) DisplayTitle() string {
+	return displayTitle(p.MarketTitle, p.MarketDisplayTitle)
+}
+
+// DisplayTitle is the title of the result's market to show in notifications and exports
+func (r *Result) DisplayTitle() string {
+	return displayTitle(r.MarketTitle, r.MarketDisplayTitle)
+}
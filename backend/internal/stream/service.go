@@ -0,0 +1,373 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samcm/pyre/internal/events"
+	"github.com/samcm/pyre/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// directoryTTL is how long the user and persona directory is used before it is reloaded
+const directoryTTL = time.Minute
+
+// Message is an update delivered to a subscription
+type Message struct {
+	Topics      []string      // the subscription's topics the message matched
+	Time        time.Time     // when it happened
+	Username    string        // user the event is about, empty for leaderboard changes
+	Event       *events.Event // set for user, persona and whale trade messages
+	Leaderboard []*RankChange // set for leaderboard changes
+}
+
+// RankChange is a user whose rank on the total PnL leaderboard moved
+type RankChange struct {
+	Username     string
+	Rank         int
+	PreviousRank int // 0 when the user wasn't ranked before
+	TotalPnl     float64
+}
+
+// Options configures the stream service
+type Options struct {
+	WhaleThreshold      float64       // trades worth at least this, in USDC, are published on trades:whale
+	LeaderboardInterval time.Duration // how often the leaderboard is checked for changes after users sync
+	BufferSize          int           // messages a subscription can fall behind by before new ones are dropped
+}
+
+// Service fans events from the bus out to subscriptions by topic
+type Service interface {
+	// Start subscribes to the event bus and begins watching the leaderboard
+	Start(ctx context.Context) error
+	// Stop unsubscribes from the bus and closes every subscription
+	Stop() error
+	// Subscribe creates a subscription with no topics
+	Subscribe() *Subscription
+	// ValidateTopic checks a topic is well formed and names a tracked user or persona
+	ValidateTopic(ctx context.Context, topic string) error
+}
+
+// member is a user as seen by topic routing
+type member struct {
+	username string
+	persona  string // slug, empty when the user has no persona
+	ghost    bool
+}
+
+// directory maps users to the topics their events belong to
+type directory struct {
+	members  map[int64]*member
+	users    map[string]bool // non-ghost usernames
+	personas map[string]bool // persona slugs
+	loadedAt time.Time
+}
+
+// service implements the stream Service
+type service struct {
+	storage storage.Storage
+	bus     events.Bus
+	opts    Options
+	log     logrus.FieldLogger
+
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+
+	dirMu sync.Mutex
+	dir   *directory
+
+	leaderboardDirty atomic.Bool
+	ranks            map[string]int // last published ranking, nil until the first check
+
+	unsubscribe func()
+	done        chan struct{}
+	wg          sync.WaitGroup
+}
+
+var _ Service = (*service)(nil)
+
+// NewService creates a new stream service
+func NewService(storage storage.Storage, bus events.Bus, opts Options, log logrus.FieldLogger) Service {
+	return &service{
+		storage: storage,
+		bus:     bus,
+		opts:    opts,
+		log:     log.WithField("package", "stream"),
+		subs:    make(map[*Subscription]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start subscribes to the event bus and begins watching the leaderboard
+func (s *service) Start(ctx context.Context) error {
+	s.unsubscribe = s.bus.Subscribe("stream", s.handle)
+
+	s.wg.Add(1)
+	go s.watchLeaderboard(ctx)
+
+	s.log.WithFields(logrus.Fields{
+		"whale_threshold":      s.opts.WhaleThreshold,
+		"leaderboard_interval": s.opts.LeaderboardInterval.String(),
+	}).Info("stream service started")
+	return nil
+}
+
+// Stop unsubscribes from the bus and closes every subscription
+func (s *service) Stop() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	close(s.done)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		delete(s.subs, sub)
+		close(sub.queue)
+	}
+
+	return nil
+}
+
+// Subscribe creates a subscription with no topics
+func (s *service) Subscribe() *Subscription {
+	sub := &Subscription{
+		service: s,
+		queue:   make(chan *Message, s.opts.BufferSize),
+		topics:  make(map[string]bool),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// ValidateTopic checks a topic is well formed and names a tracked user or persona
+func (s *service) ValidateTopic(ctx context.Context, topic string) error {
+	prefix, name, err := parseTopic(topic)
+	if err != nil || prefix == "" {
+		return err
+	}
+
+	known := func(dir *directory) bool {
+		if prefix == TopicUserPrefix {
+			return dir.users[name]
+		}
+		return dir.personas[name]
+	}
+
+	dir, err := s.directory(ctx, false)
+	if err != nil {
+		return err
+	}
+	if !known(dir) {
+		// Users and personas added since the directory was loaded are picked up on a reload
+		if dir, err = s.directory(ctx, true); err != nil {
+			return err
+		}
+		if !known(dir) {
+			return fmt.Errorf("topic %q names an unknown %s", topic, prefix[:len(prefix)-1])
+		}
+	}
+
+	return nil
+}
+
+// handle routes an event from the bus to the subscriptions of its topics
+func (s *service) handle(ctx context.Context, event events.Event) {
+	if event.Type == events.UserSynced || event.Type == events.MarketResolved {
+		s.leaderboardDirty.Store(true)
+	}
+
+	m, err := s.member(ctx, event.UserID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", event.UserID).Error("failed to resolve event topics")
+		return
+	}
+	// Ghost users are hidden from the feed
+	if m == nil || m.ghost {
+		return
+	}
+
+	topics := []string{UserTopic(m.username), TopicAllUsers}
+	if m.persona != "" {
+		topics = append(topics, PersonaTopic(m.persona))
+	}
+	if event.Type == events.TradeIngested && event.Trade != nil && event.Trade.Value != nil &&
+		*event.Trade.Value >= s.opts.WhaleThreshold {
+		topics = append(topics, TopicWhaleTrades)
+	}
+
+	s.publish(&Message{Time: event.Time, Username: m.username, Event: &event}, topics)
+}
+
+// publish delivers a message to every subscription holding one of its topics. It never blocks
+// on slow subscriptions.
+func (s *service) publish(msg *Message, topics []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subs {
+		matched := sub.matching(topics)
+		if len(matched) == 0 {
+			continue
+		}
+
+		delivery := *msg
+		delivery.Topics = matched
+		select {
+		case sub.queue <- &delivery:
+		default:
+			s.log.WithField("topics", matched).Warn("subscription queue full, dropping message")
+		}
+	}
+}
+
+// member returns the user an event belongs to, or nil if the user is no longer tracked
+func (s *service) member(ctx context.Context, userID int64) (*member, error) {
+	dir, err := s.directory(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := dir.members[userID]; ok {
+		return m, nil
+	}
+
+	// Users added since the directory was loaded are picked up on a reload
+	if dir, err = s.directory(ctx, true); err != nil {
+		return nil, err
+	}
+	return dir.members[userID], nil
+}
+
+// directory returns the user and persona directory, loading it when it is stale or reload is set
+func (s *service) directory(ctx context.Context, reload bool) (*directory, error) {
+	s.dirMu.Lock()
+	defer s.dirMu.Unlock()
+
+	if s.dir != nil && !reload && time.Since(s.dir.loadedAt) < directoryTTL {
+		return s.dir, nil
+	}
+
+	users, err := s.storage.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	personas, err := s.storage.GetPersonas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := &directory{
+		members:  make(map[int64]*member, len(users)),
+		users:    make(map[string]bool, len(users)),
+		personas: make(map[string]bool, len(personas)),
+		loadedAt: time.Now(),
+	}
+	for _, user := range users {
+		dir.members[user.ID] = &member{username: user.Username, ghost: user.Ghost}
+		if !user.Ghost {
+			dir.users[user.Username] = true
+		}
+	}
+	for _, persona := range personas {
+		dir.personas[persona.Slug] = true
+
+		members, err := s.storage.GetPersonaUsers(ctx, persona.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range members {
+			if m, ok := dir.members[user.ID]; ok {
+				m.persona = persona.Slug
+			}
+		}
+	}
+
+	s.dir = dir
+	return dir, nil
+}
+
+// watchLeaderboard periodically publishes rank changes once users have synced
+func (s *service) watchLeaderboard(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.LeaderboardInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.leaderboardDirty.Swap(false) {
+				continue
+			}
+			if err := s.checkLeaderboard(ctx); err != nil {
+				s.log.WithError(err).Error("failed to check leaderboard for changes")
+			}
+		}
+	}
+}
+
+// checkLeaderboard ranks users by total PnL and publishes those whose rank moved since the last
+// check. Without subscribers the ranking is forgotten, so the next subscriber starts from a
+// fresh one rather than a backlog of changes.
+func (s *service) checkLeaderboard(ctx context.Context) error {
+	if !s.hasSubscribers(TopicLeaderboard) {
+		s.ranks = nil
+		return nil
+	}
+
+	stats, err := s.storage.GetLeaderboard(ctx, "totalPnl", "desc")
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].TotalPnl > stats[j].TotalPnl })
+
+	ranks := make(map[string]int, len(stats))
+	changes := make([]*RankChange, 0)
+	for i, st := range stats {
+		rank := i + 1
+		ranks[st.Username] = rank
+		if s.ranks != nil && s.ranks[st.Username] != rank {
+			changes = append(changes, &RankChange{
+				Username:     st.Username,
+				Rank:         rank,
+				PreviousRank: s.ranks[st.Username],
+				TotalPnl:     st.TotalPnl,
+			})
+		}
+	}
+
+	first := s.ranks == nil
+	s.ranks = ranks
+	if first || len(changes) == 0 {
+		return nil
+	}
+
+	s.publish(&Message{Time: time.Now().UTC(), Leaderboard: changes}, []string{TopicLeaderboard})
+	return nil
+}
+
+// hasSubscribers reports whether any subscription holds topic
+func (s *service) hasSubscribers(topic string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subs {
+		if len(sub.matching([]string{topic})) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Subscription receives the messages of the topics it holds. Topics can be added and removed
+// at any time.
+type Subscription struct {
+	service *service
+	queue   chan *Message
+
+	mu     sync.Mutex
+	topics map[string]bool
+}
+
+// Messages delivers the subscription's messages. It is closed when the subscription or the
+// service is closed.
+func (s *Subscription) Messages() <-chan *Message {
+	return s.queue
+}
+
+// Add subscribes to topics. Topics should be checked with the service's ValidateTopic first.
+func (s *Subscription) Add(topics ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, topic := range topics {
+		if !s.topics[topic] {
+			added++
+		}
+	}
+	if len(s.topics)+added > MaxTopics {
+		return fmt.Errorf("a subscription can hold at most %d topics", MaxTopics)
+	}
+
+	for _, topic := range topics {
+		s.topics[topic] = true
+	}
+	return nil
+}
+
+// Remove unsubscribes from topics
+func (s *Subscription) Remove(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+}
+
+// Topics returns the subscribed topics, sorted
+func (s *Subscription) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Close stops delivery and closes the messages channel
+func (s *Subscription) Close() {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+
+	if _, ok := s.service.subs[s]; ok {
+		delete(s.service.subs, s)
+		close(s.queue)
+	}
+}
+
+// matching returns the given topics the subscription holds
+func (s *Subscription) matching(topics []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []string
+	for _, topic := range topics {
+		if s.topics[topic] {
+			matched = append(matched, topic)
+		}
+	}
+	return matched
+}
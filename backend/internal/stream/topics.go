@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topics clients can subscribe to. User and persona topics name a user or persona after the
+// prefix, e.g. user:alice or persona:bob. users:all carries the events of every user.
+const (
+	TopicUserPrefix    = "user:"
+	TopicPersonaPrefix = "persona:"
+	TopicAllUsers      = "users:all"
+	TopicWhaleTrades   = "trades:whale"
+	TopicLeaderboard   = "leaderboard:changes"
+)
+
+// MaxTopics is the most topics a single subscription can hold
+const MaxTopics = 50
+
+// UserTopic is the topic of a user's events
+func UserTopic(username string) string {
+	return TopicUserPrefix + username
+}
+
+// PersonaTopic is the topic of the events of a persona's users
+func PersonaTopic(slug string) string {
+	return TopicPersonaPrefix + slug
+}
+
+// parseTopic checks a topic is well formed, returning the prefix and name of user and persona
+// topics
+func parseTopic(topic string) (prefix, name string, err error) {
+	switch topic {
+	case TopicAllUsers, TopicWhaleTrades, TopicLeaderboard:
+		return "", "", nil
+	}
+
+	for _, prefix := range []string{TopicUserPrefix, TopicPersonaPrefix} {
+		if name, ok := strings.CutPrefix(topic, prefix); ok {
+			if name == "" {
+				return "", "", fmt.Errorf("topic %q is missing a name", topic)
+			}
+			return prefix, name, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unknown topic %q (expected user:<username>, persona:<slug>, %s, %s or %s)",
+		topic, TopicAllUsers, TopicWhaleTrades, TopicLeaderboard)
+}
@@ -0,0 +1,50 @@
+package telegram
+
+import (
+	"errors"
+	"html"
+	"strings"
+
+	"github.com/samcm/pyre/internal/chatbot"
+)
+
+// render formats a reply as an HTML message
+func render(reply *chatbot.Reply) string {
+	var sb strings.Builder
+	sb.WriteString("<b>" + escape(reply.Title) + "</b>")
+	if reply.Summary != "" {
+		sb.WriteString("\n" + escape(reply.Summary))
+	}
+
+	if len(reply.Fields) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, field := range reply.Fields {
+		if field.Inline {
+			sb.WriteString("\n" + escape(field.Name) + ": <b>" + escape(field.Value) + "</b>")
+		} else {
+			sb.WriteString("\n<b>" + escape(field.Name) + "</b>\n" + escape(field.Value))
+		}
+	}
+
+	if reply.Footer != "" {
+		sb.WriteString("\n\n<i>" + escape(reply.Footer) + "</i>")
+	}
+	return sb.String()
+}
+
+// errorText is the reply to a failed command. Only user errors are shown as is.
+func (b *bot) errorText(command string, err error) string {
+	var userErr chatbot.UserError
+	if errors.As(err, &userErr) {
+		return escape(userErr.Error())
+	}
+
+	b.log.WithError(err).WithField("command", command).Warn("command failed")
+	return "Something went wrong, try again later."
+}
+
+// escape escapes text for HTML messages
+func escape(s string) string {
+	return html.EscapeString(s)
+}
@@ -0,0 +1,338 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcm/pyre/internal/chatbot"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// telegramAPI is the base URL of the Telegram Bot API
+	telegramAPI = "https://api.telegram.org"
+	// pollTimeout is how long a getUpdates call waits for updates before returning empty
+	pollTimeout = 30 * time.Second
+	// retryDelay is the wait after a failed getUpdates call
+	retryDelay = 5 * time.Second
+	// commandTimeout limits answering a single command
+	commandTimeout = 10 * time.Second
+	// inlineCacheTime is how long Telegram may serve an inline query answer without asking again
+	inlineCacheTime = 30
+)
+
+// Options configures the Telegram bot
+type Options struct {
+	BotToken string
+}
+
+// Bot answers chat commands sent to a Telegram bot, and inline queries such as
+// "@yourbot pnl alice" typed in any chat. Updates are fetched by long polling, so the bot
+// needs no public URL.
+type Bot interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
+
+// bot implements Bot on the shared chat commands
+type bot struct {
+	opts     Options
+	commands chatbot.Service
+	http     *http.Client
+	log      logrus.FieldLogger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ Bot = (*bot)(nil)
+
+// NewBot creates a new Telegram bot
+func NewBot(opts Options, commands chatbot.Service, log logrus.FieldLogger) Bot {
+	return &bot{
+		opts:     opts,
+		commands: commands,
+		http:     &http.Client{Timeout: pollTimeout + 10*time.Second},
+		log:      log.WithField("package", "telegram"),
+	}
+}
+
+// Start registers the command list shown by Telegram clients and starts polling for updates
+func (b *bot) Start(ctx context.Context) error {
+	b.log.Info("starting telegram bot")
+
+	botCommands := make([]botCommand, 0, len(b.commands.Commands()))
+	for _, cmd := range b.commands.Commands() {
+		botCommands = append(botCommands, botCommand{Command: cmd.Name, Description: cmd.Description})
+	}
+	if err := b.call(ctx, "setMyCommands", map[string]any{"commands": botCommands}, nil); err != nil {
+		b.log.WithError(err).Warn("failed to register bot commands")
+	}
+
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.wg.Add(1)
+	go b.poll(ctx)
+
+	b.log.Info("telegram bot started")
+	return nil
+}
+
+// Stop stops polling for updates
+func (b *bot) Stop() error {
+	b.log.Info("stopping telegram bot")
+
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+
+	b.log.Info("telegram bot stopped")
+	return nil
+}
+
+// Telegram Bot API types, limited to the fields the bot uses
+type (
+	update struct {
+		UpdateID    int64        `json:"update_id"`
+		Message     *message     `json:"message"`
+		InlineQuery *inlineQuery `json:"inline_query"`
+	}
+
+	message struct {
+		MessageID int64  `json:"message_id"`
+		Text      string `json:"text"`
+		Chat      struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	}
+
+	inlineQuery struct {
+		ID    string `json:"id"`
+		Query string `json:"query"`
+	}
+
+	botCommand struct {
+		Command     string `json:"command"`
+		Description string `json:"description"`
+	}
+
+	inlineQueryResultArticle struct {
+		Type                string              `json:"type"`
+		ID                  string              `json:"id"`
+		Title               string              `json:"title"`
+		Description         string              `json:"description,omitempty"`
+		InputMessageContent inputMessageContent `json:"input_message_content"`
+	}
+
+	inputMessageContent struct {
+		MessageText string `json:"message_text"`
+		ParseMode   string `json:"parse_mode"`
+	}
+
+	apiResponse struct {
+		OK          bool            `json:"ok"`
+		Result      json.RawMessage `json:"result"`
+		Description string          `json:"description"`
+	}
+)
+
+// poll fetches updates until the context is cancelled. Each update is handled before the
+// next batch is fetched, which acknowledges it.
+func (b *bot) poll(ctx context.Context) {
+	defer b.wg.Done()
+
+	var offset int64
+	for ctx.Err() == nil {
+		var updates []update
+		err := b.call(ctx, "getUpdates", map[string]any{
+			"offset":          offset,
+			"timeout":         int(pollTimeout.Seconds()),
+			"allowed_updates": []string{"message", "inline_query"},
+		}, &updates)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.log.WithError(err).Warn("failed to get updates")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			b.handle(ctx, &u)
+		}
+	}
+}
+
+// handle answers a command message or an inline query
+func (b *bot) handle(ctx context.Context, u *update) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	switch {
+	case u.Message != nil && strings.HasPrefix(u.Message.Text, "/"):
+		b.handleMessage(ctx, u.Message)
+	case u.InlineQuery != nil:
+		b.handleInlineQuery(ctx, u.InlineQuery)
+	}
+}
+
+// handleMessage replies to a command such as "/pnl alice" in the chat it was sent to
+func (b *bot) handleMessage(ctx context.Context, msg *message) {
+	name, values := parseCommand(msg.Text)
+
+	var text string
+	if name == "start" || name == "help" {
+		text = b.help()
+	} else {
+		reply, err := b.run(ctx, name, values)
+		if err != nil {
+			text = b.errorText(name, err)
+		} else {
+			text = render(reply)
+		}
+	}
+
+	if err := b.call(ctx, "sendMessage", map[string]any{
+		"chat_id":    msg.Chat.ID,
+		"text":       text,
+		"parse_mode": "HTML",
+		"reply_parameters": map[string]any{
+			"message_id":                  msg.MessageID,
+			"allow_sending_without_reply": true,
+		},
+		"link_preview_options": map[string]any{"is_disabled": true},
+	}, nil); err != nil {
+		b.log.WithError(err).WithField("command", name).Warn("failed to send reply")
+	}
+}
+
+// handleInlineQuery answers an inline query such as "pnl alice" with a single result, the
+// command's reply. An empty query answers /whoiswinning.
+func (b *bot) handleInlineQuery(ctx context.Context, query *inlineQuery) {
+	text := strings.TrimSpace(query.Query)
+	if text == "" {
+		text = "whoiswinning"
+	}
+	name, values := parseCommand("/" + strings.TrimPrefix(text, "/"))
+
+	results := make([]inlineQueryResultArticle, 0, 1)
+	reply, err := b.run(ctx, name, values)
+	var userErr chatbot.UserError
+	switch {
+	case err == nil:
+		results = append(results, inlineQueryResultArticle{
+			Type:                "article",
+			ID:                  name,
+			Title:               reply.Title,
+			Description:         reply.Summary,
+			InputMessageContent: inputMessageContent{MessageText: render(reply), ParseMode: "HTML"},
+		})
+	case !errors.As(err, &userErr):
+		b.log.WithError(err).WithField("command", name).Warn("inline query failed")
+	}
+
+	if err := b.call(ctx, "answerInlineQuery", map[string]any{
+		"inline_query_id": query.ID,
+		"results":         results,
+		"cache_time":      inlineCacheTime,
+	}, nil); err != nil {
+		b.log.WithError(err).WithField("command", name).Warn("failed to answer inline query")
+	}
+}
+
+// run answers a command given its positional arguments
+func (b *bot) run(ctx context.Context, name string, values []string) (*chatbot.Reply, error) {
+	for _, cmd := range b.commands.Commands() {
+		if cmd.Name == name {
+			return b.commands.Run(ctx, name, chatbot.PositionalArgs(cmd, values))
+		}
+	}
+	return nil, chatbot.UserError(fmt.Sprintf("Unknown command: /%s. Send /help for the commands.", name))
+}
+
+// help lists the commands
+func (b *bot) help() string {
+	var sb strings.Builder
+	sb.WriteString("<b>Commands</b>\n")
+	for _, cmd := range b.commands.Commands() {
+		fmt.Fprintf(&sb, "%s · %s\n", escape(chatbot.Usage(cmd)), escape(cmd.Description))
+	}
+	sb.WriteString("\nIn any chat, type the bot's username and a command without the slash, e.g. pnl alice.")
+	return sb.String()
+}
+
+// call invokes a Bot API method, decoding its result into out when given
+func (b *bot) call(ctx context.Context, method string, params any, out any) error {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/%s", telegramAPI, b.opts.BotToken, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		// The URL carries the bot token, so it is left out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("%s failed: %s", method, result.Description)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(result.Result, out); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// parseCommand splits a command message such as "/pnl@yourbot alice" into the command name
+// and its arguments
+func parseCommand(text string) (string, []string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	name := strings.TrimPrefix(fields[0], "/")
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ToLower(name), fields[1:]
+}
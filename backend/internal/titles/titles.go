@@ -0,0 +1,110 @@
+// Package titles makes market titles safe to display where Polymarket's raw ones cause
+// trouble: notification embeds, chat messages and CSV exports.
+package titles
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxDisplayLength is the most runes a display title has, ellipsis included
+const MaxDisplayLength = 200
+
+// ellipsis ends titles cut to MaxDisplayLength
+const ellipsis = "…"
+
+// Zero width joiners shape letters in scripts such as Persian and Devanagari, so unlike other
+// formatting characters they're kept, except between the parts of an emoji
+const (
+	zeroWidthNonJoiner = 0x200C
+	zeroWidthJoiner    = 0x200D
+)
+
+// Display returns the display-safe form of a market title: composed to NFC, without control,
+// invisible formatting or emoji characters, whitespace collapsed to single spaces and cut to
+// MaxDisplayLength runes. Letters of every script are kept. Titles that are nothing but emoji
+// keep them rather than coming out empty.
+func Display(raw string) string {
+	clean := collapse(norm.NFC.String(raw), func(rune) bool { return false })
+
+	var prev rune
+	title := collapse(clean, func(r rune) bool {
+		drop := isEmoji(r) || (r == zeroWidthJoiner && isEmoji(prev))
+		prev = r
+		return drop
+	})
+	if title == "" {
+		title = clean
+	}
+
+	return truncate(title, MaxDisplayLength)
+}
+
+// collapse drops control and formatting characters, and those matching drop, joining what's
+// left with single spaces
+func collapse(s string, drop func(rune) bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	space := false
+	for _, r := range s {
+		switch {
+		case r == utf8.RuneError:
+			continue
+		case drop(r):
+			continue
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		case unicode.IsControl(r):
+			continue
+		case unicode.Is(unicode.Cf, r) && r != zeroWidthNonJoiner && r != zeroWidthJoiner:
+			continue
+		}
+
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// isEmoji reports whether a rune is an emoji or a part of an emoji sequence: pictographs,
+// flags, skin tones, variation selectors and keycaps
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, flags and skin tones
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF, r >= 0x2B00 && r <= 0x2BFF: // watches, stars and arrows
+		return unicode.Is(unicode.So, r)
+	case r >= 0xFE00 && r <= 0xFE0F: // variation selectors
+		return true
+	case r == 0x20E3: // combining keycap
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tags of subdivision flags
+		return true
+	}
+	return false
+}
+
+// truncate cuts a title to at most limit runes, at a space when one is near the end
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	runes := []rune(s)[:limit-utf8.RuneCountInString(ellipsis)]
+	cut := string(runes)
+	if i := strings.LastIndexByte(cut, ' '); i > len(cut)*3/4 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.:;-") + ellipsis
+}
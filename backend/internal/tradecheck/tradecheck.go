@@ -0,0 +1,129 @@
+package tradecheck
+
+import (
+	"math"
+
+	"github.com/samcm/pyre/internal/polymarket"
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// Discrepancy kinds
+const (
+	KindMissingValue = "missing_value" // no value stored although price and size are
+	KindInvalidValue = "invalid_value" // negative, NaN or infinite value
+	KindExceedsSize  = "exceeds_size"  // value above the size, which would mean a price above $1
+	KindRecomputed   = "recomputed"    // value differs from price times size
+	KindUpstream     = "upstream"      // value differs from the USDC amount Polymarket reports
+)
+
+// Discrepancy is a stored trade whose value fails a check
+type Discrepancy struct {
+	Trade      *storage.Trade
+	Kind       string
+	Recomputed *float64 // price times size, when both are stored
+	Upstream   *float64 // USDC amount reported by Polymarket, when the trade was found upstream
+}
+
+// Report is the result of checking a set of trades
+type Report struct {
+	Checked       int // trades checked
+	Compared      int // trades found upstream and compared against it
+	Discrepancies []Discrepancy
+}
+
+// Upstream maps trades to the USDC amount Polymarket reports for them
+type Upstream map[storage.TradeKey]float64
+
+// UpstreamValues indexes the trade activity of an address by the fields trades are matched on.
+// Activity without a USDC amount is left out.
+func UpstreamValues(activities polymarket.ActivitiesResponse) Upstream {
+	upstream := make(Upstream, len(activities))
+	for _, activity := range activities {
+		if activity.Type != "TRADE" || activity.UsdcSize == nil || activity.Price == nil || activity.Size == nil {
+			continue
+		}
+
+		upstream[storage.TradeKey{
+			ConditionID: activity.ConditionID,
+			Timestamp:   activity.Timestamp,
+			Side:        activity.Side,
+			Size:        *activity.Size,
+			Price:       *activity.Price,
+		}] = *activity.UsdcSize
+	}
+
+	return upstream
+}
+
+// Verify checks the stored value of each trade against price times size and, for trades
+// found in upstream, against the amount Polymarket reports. Values within tolerance dollars
+// of each other agree. Fees and rounding mean small differences are expected upstream.
+func Verify(trades []*storage.Trade, upstream Upstream, tolerance float64) *Report {
+	report := &Report{Discrepancies: make([]Discrepancy, 0)}
+
+	for _, trade := range trades {
+		report.Checked++
+
+		var recomputed *float64
+		if trade.Price != nil && trade.Size != nil {
+			v := *trade.Price * *trade.Size
+			recomputed = &v
+		}
+
+		var upstreamValue *float64
+		if key, ok := tradeKey(trade); ok {
+			if v, found := upstream[key]; found {
+				upstreamValue = &v
+				report.Compared++
+			}
+		}
+
+		flag := func(kind string) {
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Trade:      trade,
+				Kind:       kind,
+				Recomputed: recomputed,
+				Upstream:   upstreamValue,
+			})
+		}
+
+		if trade.Value == nil {
+			if recomputed != nil {
+				flag(KindMissingValue)
+			}
+			continue
+		}
+
+		value := *trade.Value
+		if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			flag(KindInvalidValue)
+			continue
+		}
+		if trade.Size != nil && value > *trade.Size+tolerance {
+			flag(KindExceedsSize)
+		}
+		if recomputed != nil && math.Abs(value-*recomputed) > tolerance {
+			flag(KindRecomputed)
+		}
+		if upstreamValue != nil && math.Abs(value-*upstreamValue) > tolerance {
+			flag(KindUpstream)
+		}
+	}
+
+	return report
+}
+
+// tradeKey returns the fields a stored trade is matched to upstream activity on
+func tradeKey(trade *storage.Trade) (storage.TradeKey, bool) {
+	if trade.ConditionID == nil || trade.Timestamp == nil || trade.Side == nil || trade.Size == nil || trade.Price == nil {
+		return storage.TradeKey{}, false
+	}
+
+	return storage.TradeKey{
+		ConditionID: *trade.ConditionID,
+		Timestamp:   trade.Timestamp.Unix(),
+		Side:        *trade.Side,
+		Size:        *trade.Size,
+		Price:       *trade.Price,
+	}, true
+}
@@ -0,0 +1,189 @@
+package tradeimport
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+// Headers of Polymarket's account history export, lower case
+const (
+	exportMarket    = "marketname"
+	exportAction    = "action"
+	exportUSDC      = "usdcamount"  // USDC paid for a buy or received for a sell
+	exportTokens    = "tokenamount" // shares traded
+	exportOutcome   = "tokenname"
+	exportTimestamp = "timestamp" // unix seconds
+	exportHash      = "hash"      // transaction hash, stored as the trade ID like synced trades
+)
+
+// exportFeeColumns are the headers an optional fee column is recognised by
+var exportFeeColumns = []string{"fee", "fees", "feeamount", "feeusdc"}
+
+// MarketLookup returns the stored markets with the given titles, keyed by title
+type MarketLookup func(titles []string) (map[string]*storage.MarketSearchResult, error)
+
+// exportRow is a trade read from an export, waiting for its market to be resolved
+type exportRow struct {
+	line  int
+	title string
+	trade *storage.Trade
+}
+
+// ParseExport reads trades for a user from Polymarket's account history export. The export
+// covers one account, so the address it belongs to must be given when the user has more than
+// one. Only buys and sells are trades, other rows such as deposits and redemptions are
+// skipped. The export names markets by title only, so each trade's market is looked up among
+// the stored ones; rows of markets pyre has never seen are reported as invalid.
+func ParseExport(r io.Reader, user *storage.User, addresses []*storage.Address, address string, markets MarketLookup, maxRows int) (*Result, error) {
+	reader := newReader(r)
+
+	index, err := readHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{exportMarket, exportAction, exportUSDC, exportTokens, exportTimestamp} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q, expected a Polymarket history export", name)
+		}
+	}
+	fee := -1
+	for _, name := range exportFeeColumns {
+		if i, ok := index[name]; ok {
+			fee = i
+			break
+		}
+	}
+
+	if address == "" && len(addresses) != 1 {
+		return nil, fmt.Errorf("the user has %d addresses, the address the export belongs to is required", len(addresses))
+	}
+	owned, err := rowAddress(address, ownedAddresses(addresses))
+	if err != nil {
+		return nil, err
+	}
+
+	result := newResult()
+	rows := make([]exportRow, 0)
+	err = readRows(reader, maxRows, result, func(record []string, line int) {
+		get := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		side := strings.ToUpper(get(exportAction))
+		if side != "BUY" && side != "SELL" {
+			result.Skipped++
+			return
+		}
+
+		trade, err := parseExportRow(get, fee, record, side)
+		if err != nil {
+			result.Errors = append(result.Errors, RowError{Row: line, Message: err.Error()})
+			return
+		}
+		trade.UserID = user.ID
+		trade.Address = owned
+		rows = append(rows, exportRow{line: line, title: get(exportMarket), trade: trade})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if !seen[row.title] {
+			seen[row.title] = true
+			titles = append(titles, row.title)
+		}
+	}
+	known, err := markets(titles)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMarketLookup, err)
+	}
+
+	for _, row := range rows {
+		market, ok := known[row.title]
+		if !ok {
+			result.Errors = append(result.Errors, RowError{Row: row.line, Message: fmt.Sprintf("unknown market %q", row.title)})
+			continue
+		}
+		row.trade.ConditionID = &market.ConditionID
+		row.trade.MarketTitle = market.MarketTitle
+		row.trade.MarketSlug = market.MarketSlug
+		result.Trades = append(result.Trades, row.trade)
+	}
+	slices.SortStableFunc(result.Errors, func(a, b RowError) int { return a.Row - b.Row })
+
+	return result, nil
+}
+
+// parseExportRow validates a buy or sell of an export. The price is what was paid per share
+// before fees, and the value the USDC that changed hands.
+func parseExportRow(get func(string) string, fee int, record []string, side string) (*storage.Trade, error) {
+	if get(exportMarket) == "" {
+		return nil, fmt.Errorf("market name is empty")
+	}
+
+	timestamp, err := parseTimestamp(get(exportTimestamp))
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.After(time.Now()) {
+		return nil, fmt.Errorf("timestamp %s is in the future", timestamp.Format(time.RFC3339))
+	}
+
+	usdc, err := strconv.ParseFloat(get(exportUSDC), 64)
+	if err != nil || usdc <= 0 {
+		return nil, fmt.Errorf("invalid USDC amount %q, expected a positive number", get(exportUSDC))
+	}
+
+	size, err := strconv.ParseFloat(get(exportTokens), 64)
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("invalid token amount %q, expected a positive number", get(exportTokens))
+	}
+
+	var fees float64
+	if fee >= 0 && fee < len(record) {
+		if raw := strings.TrimSpace(record[fee]); raw != "" {
+			fees, err = strconv.ParseFloat(raw, 64)
+			if err != nil || fees < 0 {
+				return nil, fmt.Errorf("invalid fee %q, expected a non-negative number", raw)
+			}
+		}
+	}
+
+	// Buys pay the fee on top of the shares, sells have it taken from the proceeds
+	cost := usdc - fees
+	if side == "SELL" {
+		cost = usdc + fees
+	}
+	price := cost / size
+	if price <= 0 || price > 1 {
+		return nil, fmt.Errorf("price %.4f from USDC amount %s and token amount %s is not between 0 and 1", price, get(exportUSDC), get(exportTokens))
+	}
+
+	trade := &storage.Trade{
+		Side:      &side,
+		Price:     &price,
+		Size:      &size,
+		Value:     &usdc,
+		Timestamp: &timestamp,
+	}
+	if outcome := get(exportOutcome); outcome != "" {
+		trade.Outcome = &outcome
+	}
+	if hash := get(exportHash); hash != "" {
+		trade.TradeID = &hash
+	}
+	return trade, nil
+}
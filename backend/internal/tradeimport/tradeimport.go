@@ -0,0 +1,324 @@
+package tradeimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samcm/pyre/internal/storage"
+)
+
+var (
+	// ErrTooManyRows is returned when a file has more rows than an import accepts
+	ErrTooManyRows = errors.New("too many rows")
+	// ErrMarketLookup is returned when the markets of an export couldn't be looked up
+	ErrMarketLookup = errors.New("failed to look up markets")
+)
+
+// timestampLayouts are the timestamp formats accepted besides unix seconds
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+}
+
+// Columns maps each trade field to the CSV header of the column holding it. Optional fields
+// may be left empty, and columns not mapped to a field are ignored.
+type Columns struct {
+	ID          string
+	Timestamp   string // required
+	Address     string // required when the user has more than one address
+	ConditionID string // required
+	MarketTitle string
+	MarketSlug  string
+	Outcome     string
+	Side        string // required, BUY or SELL
+	Price       string // required, between 0 and 1
+	Size        string // required, positive
+	Value       string // defaults to price times size
+}
+
+// RowError is a row that couldn't be imported
+type RowError struct {
+	Row     int // line of the file, the header being line 1
+	Message string
+}
+
+// Result is a parsed file
+type Result struct {
+	Rows    int              // data rows read
+	Trades  []*storage.Trade // valid rows
+	Errors  []RowError       // invalid rows
+	Skipped int              // rows that aren't trades, e.g. deposits in an account export
+}
+
+// Parse reads trades for a user from CSV with a header row. Rows that fail validation are
+// reported rather than failing the whole file; an error is returned only when the file itself
+// can't be read, is missing a required column, or has more than maxRows rows.
+func Parse(r io.Reader, columns Columns, user *storage.User, addresses []*storage.Address, maxRows int) (*Result, error) {
+	reader := newReader(r)
+
+	index, err := readHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := columnIndexes(columns, index)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := ownedAddresses(addresses)
+
+	result := newResult()
+	err = readRows(reader, maxRows, result, func(record []string, line int) {
+		trade, err := parseRow(record, fields, user, owned)
+		if err != nil {
+			result.Errors = append(result.Errors, RowError{Row: line, Message: err.Error()})
+			return
+		}
+		result.Trades = append(result.Trades, trade)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// newReader creates a CSV reader tolerating rows of different lengths
+func newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// newResult creates an empty result
+func newResult() *Result {
+	return &Result{
+		Trades: make([]*storage.Trade, 0),
+		Errors: make([]RowError, 0),
+	}
+}
+
+// readHeader reads the header row, returning the index of each column by lower case name
+func readHeader(reader *csv.Reader) (map[string]int, error) {
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a byte order mark
+		name = strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")
+		index[strings.ToLower(name)] = i
+	}
+	return index, nil
+}
+
+// readRows calls fn with each data row and the line it starts on, counting rows and recording
+// those the CSV reader can't parse as errors. Fails when there are more than maxRows rows.
+func readRows(reader *csv.Reader, maxRows int, result *Result, fn func(record []string, line int)) error {
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		result.Rows++
+		if maxRows > 0 && result.Rows > maxRows {
+			return fmt.Errorf("%w: the limit is %d", ErrTooManyRows, maxRows)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Errors = append(result.Errors, RowError{Row: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read row %d: %w", result.Rows, err)
+		}
+
+		// Quoted fields can span lines, so the reader knows where a row starts
+		line, _ := reader.FieldPos(0)
+		fn(record, line)
+	}
+}
+
+// ownedAddresses maps a user's addresses by lower case address
+func ownedAddresses(addresses []*storage.Address) map[string]string {
+	owned := make(map[string]string, len(addresses))
+	for _, addr := range addresses {
+		owned[strings.ToLower(addr.Address)] = addr.Address
+	}
+	return owned
+}
+
+// fieldIndexes holds the column index of each trade field, -1 when it isn't in the file
+type fieldIndexes struct {
+	id, timestamp, address, conditionID, marketTitle, marketSlug, outcome, side, price, size, value int
+}
+
+// columnIndexes finds the columns of each trade field in a header, failing when a required
+// one is missing
+func columnIndexes(columns Columns, index map[string]int) (*fieldIndexes, error) {
+	lookup := func(name string, required bool) (int, error) {
+		if name == "" && !required {
+			return -1, nil
+		}
+		i, ok := index[strings.ToLower(name)]
+		if !ok {
+			if required {
+				return 0, fmt.Errorf("missing required column %q", name)
+			}
+			return -1, nil
+		}
+		return i, nil
+	}
+
+	var fields fieldIndexes
+	var err error
+	for _, f := range []struct {
+		dest     *int
+		name     string
+		required bool
+	}{
+		{&fields.id, columns.ID, false},
+		{&fields.timestamp, columns.Timestamp, true},
+		{&fields.address, columns.Address, false},
+		{&fields.conditionID, columns.ConditionID, true},
+		{&fields.marketTitle, columns.MarketTitle, false},
+		{&fields.marketSlug, columns.MarketSlug, false},
+		{&fields.outcome, columns.Outcome, false},
+		{&fields.side, columns.Side, true},
+		{&fields.price, columns.Price, true},
+		{&fields.size, columns.Size, true},
+		{&fields.value, columns.Value, false},
+	} {
+		if *f.dest, err = lookup(f.name, f.required); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fields, nil
+}
+
+// parseRow validates a CSV record and converts it to a trade
+func parseRow(record []string, fields *fieldIndexes, user *storage.User, owned map[string]string) (*storage.Trade, error) {
+	get := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	optional := func(i int) *string {
+		if v := get(i); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	timestamp, err := parseTimestamp(get(fields.timestamp))
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.After(time.Now()) {
+		return nil, fmt.Errorf("timestamp %s is in the future", timestamp.Format(time.RFC3339))
+	}
+
+	conditionID := get(fields.conditionID)
+	if conditionID == "" {
+		return nil, fmt.Errorf("condition ID is empty")
+	}
+
+	side := strings.ToUpper(get(fields.side))
+	if side != "BUY" && side != "SELL" {
+		return nil, fmt.Errorf("invalid side %q, expected BUY or SELL", get(fields.side))
+	}
+
+	price, err := strconv.ParseFloat(get(fields.price), 64)
+	if err != nil || price <= 0 || price > 1 {
+		return nil, fmt.Errorf("invalid price %q, expected a number between 0 and 1", get(fields.price))
+	}
+
+	size, err := strconv.ParseFloat(get(fields.size), 64)
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("invalid size %q, expected a positive number", get(fields.size))
+	}
+
+	value := price * size
+	if raw := get(fields.value); raw != "" {
+		value, err = strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid value %q, expected a non-negative number", raw)
+		}
+	}
+
+	address, err := rowAddress(get(fields.address), owned)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.Trade{
+		UserID:      user.ID,
+		Address:     address,
+		TradeID:     optional(fields.id),
+		ConditionID: &conditionID,
+		MarketTitle: optional(fields.marketTitle),
+		MarketSlug:  optional(fields.marketSlug),
+		Outcome:     optional(fields.outcome),
+		Side:        &side,
+		Price:       &price,
+		Size:        &size,
+		Value:       &value,
+		Timestamp:   &timestamp,
+	}, nil
+}
+
+// rowAddress resolves the address a row was traded from, which must be one of the user's.
+// Rows without one are attributed to the user's only address.
+func rowAddress(raw string, owned map[string]string) (string, error) {
+	if raw == "" {
+		if len(owned) != 1 {
+			return "", fmt.Errorf("address is empty and the user has %d addresses", len(owned))
+		}
+		for _, address := range owned {
+			return address, nil
+		}
+	}
+
+	address, ok := owned[strings.ToLower(raw)]
+	if !ok {
+		return "", fmt.Errorf("address %s is not one of the user's", raw)
+	}
+	return address, nil
+}
+
+// parseTimestamp parses an RFC 3339 or similar timestamp, or unix seconds, as UTC
+func parseTimestamp(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("timestamp is empty")
+	}
+
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", raw)
+}
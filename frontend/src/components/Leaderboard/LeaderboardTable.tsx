@@ -7,6 +7,7 @@ import { Card } from '@/components/Common/Card';
 import { LoadingOverlay } from '@/components/Common/Loading';
 import { ErrorState } from '@/components/Common/ErrorState';
 import { formatCurrency, formatPercent, pnlColor, pnlSign } from '@/utils/formatters';
+import { personaAvatarUrl, userAvatarUrl } from '@/utils/api-config';
 
 const POLYMARKET_PROFILE_URL = 'https://polymarket.com/profile/@';
 
@@ -239,7 +240,7 @@ export function LeaderboardTable() {
                       <div className="flex items-center gap-3">
                         {entry.profileImage ? (
                           <img
-                            src={entry.profileImage}
+                            src={userAvatarUrl(entry.username, 64)}
                             alt={entry.username}
                             className="size-8 rounded-full object-cover ring-2 ring-bg-deep"
                           />
@@ -305,7 +306,7 @@ export function LeaderboardTable() {
                       <div className="flex items-center gap-3">
                         {entry.image ? (
                           <img
-                            src={entry.image}
+                            src={personaAvatarUrl(entry.slug, 64)}
                             alt={entry.displayName}
                             className="size-8 rounded-full object-cover ring-2 ring-bg-deep"
                           />
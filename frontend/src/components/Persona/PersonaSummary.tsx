@@ -4,6 +4,7 @@ import { LoadingOverlay } from '@/components/Common/Loading';
 import { ErrorState } from '@/components/Common/ErrorState';
 import { formatCurrency, formatPercent, pnlColor, pnlSign } from '@/utils/formatters';
 import { UsersIcon } from '@heroicons/react/24/solid';
+import { personaAvatarUrl } from '@/utils/api-config';
 
 interface PersonaSummaryProps {
   slug: string;
@@ -89,7 +90,7 @@ export function PersonaSummary({ slug }: PersonaSummaryProps) {
           {/* Avatar - persona image or fallback */}
           {persona.image ? (
             <img
-              src={persona.image}
+              src={personaAvatarUrl(slug, 128)}
               alt={persona.displayName}
               className="size-16 rounded-xl object-cover ring-4 ring-bg-deep shadow-lg"
             />
@@ -1,7 +1,7 @@
 import { type Position } from '@/hooks/usePositions';
 import { Card } from '@/components/Common/Card';
 import { Badge } from '@/components/Common/Badge';
-import { formatCurrency, formatNumber, pnlColor, pnlSign } from '@/utils/formatters';
+import { formatCurrency, formatDate, formatNumber, pnlColor, pnlSign } from '@/utils/formatters';
 import { ArrowTopRightOnSquareIcon } from '@heroicons/react/24/solid';
 
 interface PositionCardProps {
@@ -31,6 +31,10 @@ export function PositionCard({ position }: PositionCardProps) {
           <Badge variant={position.side === 'YES' ? 'success' : 'error'}>{position.side}</Badge>
         </div>
 
+        {position.firstEnteredAt && (
+          <p className="text-text-muted -mt-2 text-xs">Held since {formatDate(position.firstEnteredAt)}</p>
+        )}
+
         <div className="grid grid-cols-2 gap-4">
           <div>
             <span className="text-text-muted text-xs font-medium tracking-wider uppercase">Outcome</span>
@@ -12,6 +12,8 @@ export interface Position {
   unrealizedPnl: number;
   unrealizedPnlPercent: number;
   value: number;
+  firstEnteredAt?: string;
+  entryTradeId?: string;
 }
 
 export function usePositions(username: string) {
@@ -5,6 +5,7 @@ import { CombinedPnlChart } from '@/components/Dashboard/CombinedPnlChart';
 import { RecentTradesTable } from '@/components/Dashboard/RecentTradesTable';
 import { usePersonas, type PersonaSummary } from '@/hooks/usePersonas';
 import { FireIcon } from '@heroicons/react/24/solid';
+import { personaAvatarUrl } from '@/utils/api-config';
 
 export const Route = createFileRoute('/')({
   component: HomePage,
@@ -182,7 +183,7 @@ function PersonaAvatar({
       } ${isAnimating ? 'scale-90' : 'scale-100'}`}
     >
       {persona.image ? (
-        <img src={persona.image} alt={persona.displayName} className="size-full object-cover" />
+        <img src={personaAvatarUrl(persona.slug, 256)} alt={persona.displayName} className="size-full object-cover" />
       ) : (
         <div className="from-ember-500 to-ember-700 flex size-full items-center justify-center bg-linear-to-br">
           <span className="font-display text-2xl font-bold text-white lg:text-3xl">
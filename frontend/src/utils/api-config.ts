@@ -8,3 +8,10 @@ export const API_REFETCH_INTERVAL = 60000; // 1 minute
 // client.setConfig({
 //   baseUrl: API_BASE_URL,
 // });
+
+// Avatars are served through the backend's caching image proxy rather than hotlinked
+export const userAvatarUrl = (username: string, size: number): string =>
+  `${API_BASE_URL}/users/${encodeURIComponent(username)}/avatar?size=${size}`;
+
+export const personaAvatarUrl = (slug: string, size: number): string =>
+  `${API_BASE_URL}/personas/${encodeURIComponent(slug)}/avatar?size=${size}`;